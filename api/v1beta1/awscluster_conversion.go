@@ -60,15 +60,22 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Status.Bastion.PrivateDNSName = restored.Status.Bastion.PrivateDNSName
 		dst.Status.Bastion.PublicIPOnLaunch = restored.Status.Bastion.PublicIPOnLaunch
 		dst.Status.Bastion.NetworkInterfaceType = restored.Status.Bastion.NetworkInterfaceType
+		dst.Status.Bastion.SecondaryNetworkInterfaces = restored.Status.Bastion.SecondaryNetworkInterfaces
 		dst.Status.Bastion.CapacityReservationID = restored.Status.Bastion.CapacityReservationID
 		dst.Status.Bastion.MarketType = restored.Status.Bastion.MarketType
+		dst.Status.Bastion.VPCID = restored.Status.Bastion.VPCID
 	}
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.ControlPlaneAZSpread = restored.Spec.ControlPlaneAZSpread
+	dst.Spec.APIServerPrivateDNSRecord = restored.Spec.APIServerPrivateDNSRecord
+	dst.Spec.ControlPlaneEndpointDNS = restored.Spec.ControlPlaneEndpointDNS
 
 	for role, sg := range restored.Status.Network.SecurityGroups {
 		dst.Status.Network.SecurityGroups[role] = sg
 	}
 	dst.Status.Network.NatGatewaysIPs = restored.Status.Network.NatGatewaysIPs
+	dst.Status.Network.ReplacedAPIServerELB = restored.Status.Network.ReplacedAPIServerELB
+	dst.Status.Network.ControlPlaneEndpointDNSChangeID = restored.Status.Network.ControlPlaneEndpointDNSChangeID
 
 	if restored.Spec.NetworkSpec.VPC.IPAMPool != nil {
 		if dst.Spec.NetworkSpec.VPC.IPAMPool == nil {
@@ -88,6 +95,17 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.NetworkSpec.AdditionalControlPlaneIngressRules = restored.Spec.NetworkSpec.AdditionalControlPlaneIngressRules
 	dst.Spec.NetworkSpec.NodePortIngressRuleCidrBlocks = restored.Spec.NetworkSpec.NodePortIngressRuleCidrBlocks
+	dst.Spec.NetworkSpec.AllowNodeEFATraffic = restored.Spec.NetworkSpec.AllowNodeEFATraffic
+	dst.Spec.NetworkSpec.SkipSecurityGroupCreation = restored.Spec.NetworkSpec.SkipSecurityGroupCreation
+	dst.Spec.NetworkSpec.VPCEndpoints = restored.Spec.NetworkSpec.VPCEndpoints
+	dst.Spec.NetworkSpec.TransitGatewayAttachment = restored.Spec.NetworkSpec.TransitGatewayAttachment
+	dst.Spec.NetworkSpec.VPCPeeringConnections = restored.Spec.NetworkSpec.VPCPeeringConnections
+	dst.Spec.NetworkSpec.NetworkACLs = restored.Spec.NetworkSpec.NetworkACLs
+	dst.Spec.NetworkSpec.Mode = restored.Spec.NetworkSpec.Mode
+
+	if restored.Spec.NetworkSpec.CNI != nil {
+		dst.Spec.NetworkSpec.CNI = restored.Spec.NetworkSpec.CNI
+	}
 
 	if restored.Spec.NetworkSpec.VPC.IPAMPool != nil {
 		if dst.Spec.NetworkSpec.VPC.IPAMPool == nil {
@@ -109,6 +127,12 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch = restored.Spec.NetworkSpec.VPC.PrivateDNSHostnameTypeOnLaunch
 	dst.Spec.NetworkSpec.VPC.CarrierGatewayID = restored.Spec.NetworkSpec.VPC.CarrierGatewayID
 	dst.Spec.NetworkSpec.VPC.SubnetSchema = restored.Spec.NetworkSpec.VPC.SubnetSchema
+	dst.Spec.NetworkSpec.VPC.NatGatewayMode = restored.Spec.NetworkSpec.VPC.NatGatewayMode
+	dst.Spec.NetworkSpec.VPC.NatGatewayElasticIPAllocationIDs = restored.Spec.NetworkSpec.VPC.NatGatewayElasticIPAllocationIDs
+	dst.Spec.NetworkSpec.VPC.NatInstance = restored.Spec.NetworkSpec.VPC.NatInstance
+	dst.Spec.NetworkSpec.VPC.FlowLogs = restored.Spec.NetworkSpec.VPC.FlowLogs
+	dst.Spec.NetworkSpec.VPC.DHCPOptions = restored.Spec.NetworkSpec.VPC.DHCPOptions
+	dst.Spec.NetworkSpec.VPC.AvailabilityZones = restored.Spec.NetworkSpec.VPC.AvailabilityZones
 	dst.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = restored.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
 
 	if restored.Spec.NetworkSpec.VPC.ElasticIPPool != nil {
@@ -123,19 +147,36 @@ func (src *AWSCluster) ConvertTo(dstRaw conversion.Hub) error {
 		}
 	}
 
-	// Restore SubnetSpec.ResourceID, SubnetSpec.ParentZoneName, and SubnetSpec.ZoneType fields, if any.
+	// Restore SubnetSpec.ResourceID, SubnetSpec.AvailabilityZoneID, SubnetSpec.ParentZoneName, SubnetSpec.ZoneType,
+	// SubnetSpec.ExistingRouteTableID, SubnetSpec.NatInstanceID, SubnetSpec.LoadBalancerRole and SubnetSpec.OutpostARN
+	// fields, if any.
 	for _, subnet := range restored.Spec.NetworkSpec.Subnets {
 		for i, dstSubnet := range dst.Spec.NetworkSpec.Subnets {
 			if dstSubnet.ID == subnet.ID {
 				if len(subnet.ResourceID) > 0 {
 					dstSubnet.ResourceID = subnet.ResourceID
 				}
+				if len(subnet.AvailabilityZoneID) > 0 {
+					dstSubnet.AvailabilityZoneID = subnet.AvailabilityZoneID
+				}
 				if subnet.ParentZoneName != nil {
 					dstSubnet.ParentZoneName = subnet.ParentZoneName
 				}
 				if subnet.ZoneType != nil {
 					dstSubnet.ZoneType = subnet.ZoneType
 				}
+				if len(subnet.ExistingRouteTableID) > 0 {
+					dstSubnet.ExistingRouteTableID = subnet.ExistingRouteTableID
+				}
+				if subnet.NatInstanceID != nil {
+					dstSubnet.NatInstanceID = subnet.NatInstanceID
+				}
+				if subnet.LoadBalancerRole != nil {
+					dstSubnet.LoadBalancerRole = subnet.LoadBalancerRole
+				}
+				if subnet.OutpostARN != nil {
+					dstSubnet.OutpostARN = subnet.OutpostARN
+				}
 				dstSubnet.DeepCopyInto(&dst.Spec.NetworkSpec.Subnets[i])
 			}
 		}
@@ -161,6 +202,7 @@ func restoreControlPlaneLoadBalancerStatus(restored, dst *infrav2.LoadBalancer)
 	dst.Tags = restored.Tags
 	dst.ClassicELBListeners = restored.ClassicELBListeners
 	dst.AvailabilityZones = restored.AvailabilityZones
+	dst.CanonicalHostedZoneID = restored.CanonicalHostedZoneID
 }
 
 // restoreIPAMPool manually restores the ipam pool data.
@@ -175,6 +217,7 @@ func restoreIPAMPool(restored, dst *infrav2.IPAMPool) {
 // Assumes restored and dst are non-nil.
 func restoreControlPlaneLoadBalancer(restored, dst *infrav2.AWSLoadBalancerSpec) {
 	dst.Name = restored.Name
+	dst.ARN = restored.ARN
 	dst.HealthCheckProtocol = restored.HealthCheckProtocol
 	dst.HealthCheck = restored.HealthCheck
 	dst.LoadBalancerType = restored.LoadBalancerType
@@ -186,6 +229,10 @@ func restoreControlPlaneLoadBalancer(restored, dst *infrav2.AWSLoadBalancerSpec)
 	dst.Scheme = restored.Scheme
 	dst.CrossZoneLoadBalancing = restored.CrossZoneLoadBalancing
 	dst.Subnets = restored.Subnets
+	dst.SubnetFilters = restored.SubnetFilters
+	dst.IdleTimeout = restored.IdleTimeout
+	dst.AccessLogs = restored.AccessLogs
+	dst.AdditionalTags = restored.AdditionalTags
 }
 
 // ConvertFrom converts the v1beta1 AWSCluster receiver to a v1beta1 AWSCluster.
@@ -51,13 +51,20 @@ func Convert_v1beta2_Instance_To_v1beta1_Instance(in *v1beta2.Instance, out *Ins
 	return autoConvert_v1beta2_Instance_To_v1beta1_Instance(in, out, s)
 }
 
+func Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in *v1beta2.ClassicELBAttributes, out *ClassicELBAttributes, s conversion.Scope) error {
+	return autoConvert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in, out, s)
+}
+
 func Convert_v1beta1_ClassicELB_To_v1beta2_LoadBalancer(in *ClassicELB, out *v1beta2.LoadBalancer, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DNSName = in.DNSName
 	out.Scheme = v1beta2.ELBScheme(in.Scheme)
 	out.HealthCheck = (*v1beta2.ClassicELBHealthCheck)(in.HealthCheck)
 	out.AvailabilityZones = in.AvailabilityZones
-	out.ClassicElbAttributes = (v1beta2.ClassicELBAttributes)(in.Attributes)
+	out.ClassicElbAttributes = v1beta2.ClassicELBAttributes{
+		IdleTimeout:            in.Attributes.IdleTimeout,
+		CrossZoneLoadBalancing: in.Attributes.CrossZoneLoadBalancing,
+	}
 	out.ClassicELBListeners = *(*[]v1beta2.ClassicELBListener)(unsafe.Pointer(&in.Listeners))
 	out.SecurityGroupIDs = in.SecurityGroupIDs
 	out.Tags = in.Tags
@@ -71,7 +78,10 @@ func Convert_v1beta2_LoadBalancer_To_v1beta1_ClassicELB(in *v1beta2.LoadBalancer
 	out.Scheme = ClassicELBScheme(in.Scheme)
 	out.HealthCheck = (*ClassicELBHealthCheck)(in.HealthCheck)
 	out.AvailabilityZones = in.AvailabilityZones
-	out.Attributes = (ClassicELBAttributes)(in.ClassicElbAttributes)
+	out.Attributes = ClassicELBAttributes{
+		IdleTimeout:            in.ClassicElbAttributes.IdleTimeout,
+		CrossZoneLoadBalancing: in.ClassicElbAttributes.CrossZoneLoadBalancing,
+	}
 	out.Listeners = *(*[]ClassicELBListener)(unsafe.Pointer(&in.ClassicELBListeners))
 	out.SecurityGroupIDs = in.SecurityGroupIDs
 	out.Tags = in.Tags
@@ -83,6 +93,10 @@ func Convert_v1beta2_IngressRule_To_v1beta1_IngressRule(in *v1beta2.IngressRule,
 	return autoConvert_v1beta2_IngressRule_To_v1beta1_IngressRule(in, out, s)
 }
 
+func Convert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(in *v1beta2.CNIIngressRule, out *CNIIngressRule, s conversion.Scope) error {
+	return autoConvert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(in, out, s)
+}
+
 func Convert_v1beta2_VPCSpec_To_v1beta1_VPCSpec(in *v1beta2.VPCSpec, out *VPCSpec, s conversion.Scope) error {
 	return autoConvert_v1beta2_VPCSpec_To_v1beta1_VPCSpec(in, out, s)
 }
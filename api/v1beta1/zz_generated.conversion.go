@@ -369,11 +369,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.CNIIngressRule)(nil), (*CNIIngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(a.(*v1beta2.CNIIngressRule), b.(*CNIIngressRule), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*CNISpec)(nil), (*v1beta2.CNISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_CNISpec_To_v1beta2_CNISpec(a.(*CNISpec), b.(*v1beta2.CNISpec), scope)
 	}); err != nil {
@@ -389,11 +384,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.ClassicELBAttributes)(nil), (*ClassicELBAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(a.(*v1beta2.ClassicELBAttributes), b.(*ClassicELBAttributes), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ClassicELBHealthCheck)(nil), (*v1beta2.ClassicELBHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ClassicELBHealthCheck_To_v1beta2_ClassicELBHealthCheck(a.(*ClassicELBHealthCheck), b.(*v1beta2.ClassicELBHealthCheck), scope)
 	}); err != nil {
@@ -549,6 +539,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.CNIIngressRule)(nil), (*CNIIngressRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(a.(*v1beta2.CNIIngressRule), b.(*CNIIngressRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta2.ClassicELBAttributes)(nil), (*ClassicELBAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(a.(*v1beta2.ClassicELBAttributes), b.(*ClassicELBAttributes), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.IPv6)(nil), (*IPv6)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_IPv6_To_v1beta1_IPv6(a.(*v1beta2.IPv6), b.(*IPv6), scope)
 	}); err != nil {
@@ -932,6 +932,9 @@ func autoConvert_v1beta2_AWSClusterSpec_To_v1beta1_AWSClusterSpec(in *v1beta2.AW
 		out.ControlPlaneLoadBalancer = nil
 	}
 	// WARNING: in.SecondaryControlPlaneLoadBalancer requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIServerPrivateDNSRecord requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEndpointDNS requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneAZSpread requires manual conversion: does not exist in peer-type
 	out.ImageLookupFormat = in.ImageLookupFormat
 	out.ImageLookupOrg = in.ImageLookupOrg
 	out.ImageLookupBaseOS = in.ImageLookupBaseOS
@@ -1230,9 +1233,12 @@ func Convert_v1beta1_AWSLoadBalancerSpec_To_v1beta2_AWSLoadBalancerSpec(in *AWSL
 
 func autoConvert_v1beta2_AWSLoadBalancerSpec_To_v1beta1_AWSLoadBalancerSpec(in *v1beta2.AWSLoadBalancerSpec, out *AWSLoadBalancerSpec, s conversion.Scope) error {
 	out.Name = (*string)(unsafe.Pointer(in.Name))
+	// WARNING: in.ARN requires manual conversion: does not exist in peer-type
 	out.Scheme = (*ClassicELBScheme)(unsafe.Pointer(in.Scheme))
 	out.CrossZoneLoadBalancing = in.CrossZoneLoadBalancing
+	// WARNING: in.IdleTimeout requires manual conversion: does not exist in peer-type
 	out.Subnets = *(*[]string)(unsafe.Pointer(&in.Subnets))
+	// WARNING: in.SubnetFilters requires manual conversion: does not exist in peer-type
 	out.HealthCheckProtocol = (*ClassicELBProtocol)(unsafe.Pointer(in.HealthCheckProtocol))
 	// WARNING: in.HealthCheck requires manual conversion: does not exist in peer-type
 	out.AdditionalSecurityGroups = *(*[]string)(unsafe.Pointer(&in.AdditionalSecurityGroups))
@@ -1241,6 +1247,8 @@ func autoConvert_v1beta2_AWSLoadBalancerSpec_To_v1beta1_AWSLoadBalancerSpec(in *
 	// WARNING: in.LoadBalancerType requires manual conversion: does not exist in peer-type
 	// WARNING: in.DisableHostsRewrite requires manual conversion: does not exist in peer-type
 	// WARNING: in.PreserveClientIP requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessLogs requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdditionalTags requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1415,6 +1423,7 @@ func autoConvert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in *v1beta2.AW
 	out.NonRootVolumes = *(*[]Volume)(unsafe.Pointer(&in.NonRootVolumes))
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
 	// WARNING: in.NetworkInterfaceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecondaryNetworkInterfaces requires manual conversion: does not exist in peer-type
 	out.UncompressedUserData = (*bool)(unsafe.Pointer(in.UncompressedUserData))
 	if err := Convert_v1beta2_CloudInit_To_v1beta1_CloudInit(&in.CloudInit, &out.CloudInit, s); err != nil {
 		return err
@@ -1433,6 +1442,7 @@ func autoConvert_v1beta2_AWSMachineSpec_To_v1beta1_AWSMachineSpec(in *v1beta2.AW
 	// WARNING: in.PlacementGroupPartition requires manual conversion: does not exist in peer-type
 	out.Tenancy = in.Tenancy
 	// WARNING: in.PrivateDNSName requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostnameTemplate requires manual conversion: does not exist in peer-type
 	// WARNING: in.CapacityReservationID requires manual conversion: does not exist in peer-type
 	// WARNING: in.MarketType requires manual conversion: does not exist in peer-type
 	return nil
@@ -1758,16 +1768,22 @@ func autoConvert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(in *v1beta2.CN
 	out.Protocol = SecurityGroupProtocol(in.Protocol)
 	out.FromPort = in.FromPort
 	out.ToPort = in.ToPort
+	// WARNING: in.PrefixListIDs requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule is an autogenerated conversion function.
-func Convert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(in *v1beta2.CNIIngressRule, out *CNIIngressRule, s conversion.Scope) error {
-	return autoConvert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(in, out, s)
-}
-
 func autoConvert_v1beta1_CNISpec_To_v1beta2_CNISpec(in *CNISpec, out *v1beta2.CNISpec, s conversion.Scope) error {
-	out.CNIIngressRules = *(*v1beta2.CNIIngressRules)(unsafe.Pointer(&in.CNIIngressRules))
+	if in.CNIIngressRules != nil {
+		in, out := &in.CNIIngressRules, &out.CNIIngressRules
+		*out = make(v1beta2.CNIIngressRules, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_CNIIngressRule_To_v1beta2_CNIIngressRule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.CNIIngressRules = nil
+	}
 	return nil
 }
 
@@ -1777,7 +1793,17 @@ func Convert_v1beta1_CNISpec_To_v1beta2_CNISpec(in *CNISpec, out *v1beta2.CNISpe
 }
 
 func autoConvert_v1beta2_CNISpec_To_v1beta1_CNISpec(in *v1beta2.CNISpec, out *CNISpec, s conversion.Scope) error {
-	out.CNIIngressRules = *(*CNIIngressRules)(unsafe.Pointer(&in.CNIIngressRules))
+	if in.CNIIngressRules != nil {
+		in, out := &in.CNIIngressRules, &out.CNIIngressRules
+		*out = make(CNIIngressRules, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_CNIIngressRule_To_v1beta1_CNIIngressRule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.CNIIngressRules = nil
+	}
 	return nil
 }
 
@@ -1800,14 +1826,10 @@ func Convert_v1beta1_ClassicELBAttributes_To_v1beta2_ClassicELBAttributes(in *Cl
 func autoConvert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in *v1beta2.ClassicELBAttributes, out *ClassicELBAttributes, s conversion.Scope) error {
 	out.IdleTimeout = time.Duration(in.IdleTimeout)
 	out.CrossZoneLoadBalancing = in.CrossZoneLoadBalancing
+	// WARNING: in.AccessLogs requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes is an autogenerated conversion function.
-func Convert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in *v1beta2.ClassicELBAttributes, out *ClassicELBAttributes, s conversion.Scope) error {
-	return autoConvert_v1beta2_ClassicELBAttributes_To_v1beta1_ClassicELBAttributes(in, out, s)
-}
-
 func autoConvert_v1beta1_ClassicELBHealthCheck_To_v1beta2_ClassicELBHealthCheck(in *ClassicELBHealthCheck, out *v1beta2.ClassicELBHealthCheck, s conversion.Scope) error {
 	out.Target = in.Target
 	out.Interval = time.Duration(in.Interval)
@@ -1971,6 +1993,7 @@ func autoConvert_v1beta2_IngressRule_To_v1beta1_IngressRule(in *v1beta2.IngressR
 	out.ToPort = in.ToPort
 	out.CidrBlocks = *(*[]string)(unsafe.Pointer(&in.CidrBlocks))
 	out.IPv6CidrBlocks = *(*[]string)(unsafe.Pointer(&in.IPv6CidrBlocks))
+	// WARNING: in.PrefixListIDs requires manual conversion: does not exist in peer-type
 	out.SourceSecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SourceSecurityGroupIDs))
 	// WARNING: in.SourceSecurityGroupRoles requires manual conversion: does not exist in peer-type
 	// WARNING: in.NatGatewaysIPsSource requires manual conversion: does not exist in peer-type
@@ -2013,6 +2036,7 @@ func autoConvert_v1beta2_Instance_To_v1beta1_Instance(in *v1beta2.Instance, out
 	out.State = InstanceState(in.State)
 	out.Type = in.Type
 	out.SubnetID = in.SubnetID
+	// WARNING: in.VPCID requires manual conversion: does not exist in peer-type
 	out.ImageID = in.ImageID
 	out.SSHKeyName = (*string)(unsafe.Pointer(in.SSHKeyName))
 	out.SecurityGroupIDs = *(*[]string)(unsafe.Pointer(&in.SecurityGroupIDs))
@@ -2027,6 +2051,7 @@ func autoConvert_v1beta2_Instance_To_v1beta1_Instance(in *v1beta2.Instance, out
 	out.NonRootVolumes = *(*[]Volume)(unsafe.Pointer(&in.NonRootVolumes))
 	out.NetworkInterfaces = *(*[]string)(unsafe.Pointer(&in.NetworkInterfaces))
 	// WARNING: in.NetworkInterfaceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.SecondaryNetworkInterfaces requires manual conversion: does not exist in peer-type
 	out.Tags = *(*map[string]string)(unsafe.Pointer(&in.Tags))
 	out.AvailabilityZone = in.AvailabilityZone
 	out.SpotMarketOptions = (*SpotMarketOptions)(unsafe.Pointer(in.SpotMarketOptions))
@@ -2057,7 +2082,15 @@ func autoConvert_v1beta1_NetworkSpec_To_v1beta2_NetworkSpec(in *NetworkSpec, out
 	} else {
 		out.Subnets = nil
 	}
-	out.CNI = (*v1beta2.CNISpec)(unsafe.Pointer(in.CNI))
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(v1beta2.CNISpec)
+		if err := Convert_v1beta1_CNISpec_To_v1beta2_CNISpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CNI = nil
+	}
 	out.SecurityGroupOverrides = *(*map[v1beta2.SecurityGroupRole]string)(unsafe.Pointer(&in.SecurityGroupOverrides))
 	return nil
 }
@@ -2068,6 +2101,7 @@ func Convert_v1beta1_NetworkSpec_To_v1beta2_NetworkSpec(in *NetworkSpec, out *v1
 }
 
 func autoConvert_v1beta2_NetworkSpec_To_v1beta1_NetworkSpec(in *v1beta2.NetworkSpec, out *NetworkSpec, s conversion.Scope) error {
+	// WARNING: in.Mode requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta2_VPCSpec_To_v1beta1_VPCSpec(&in.VPC, &out.VPC, s); err != nil {
 		return err
 	}
@@ -2082,10 +2116,24 @@ func autoConvert_v1beta2_NetworkSpec_To_v1beta1_NetworkSpec(in *v1beta2.NetworkS
 	} else {
 		out.Subnets = nil
 	}
-	out.CNI = (*CNISpec)(unsafe.Pointer(in.CNI))
+	if in.CNI != nil {
+		in, out := &in.CNI, &out.CNI
+		*out = new(CNISpec)
+		if err := Convert_v1beta2_CNISpec_To_v1beta1_CNISpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CNI = nil
+	}
 	out.SecurityGroupOverrides = *(*map[SecurityGroupRole]string)(unsafe.Pointer(&in.SecurityGroupOverrides))
+	// WARNING: in.SkipSecurityGroupCreation requires manual conversion: does not exist in peer-type
 	// WARNING: in.AdditionalControlPlaneIngressRules requires manual conversion: does not exist in peer-type
 	// WARNING: in.NodePortIngressRuleCidrBlocks requires manual conversion: does not exist in peer-type
+	// WARNING: in.AllowNodeEFATraffic requires manual conversion: does not exist in peer-type
+	// WARNING: in.VPCEndpoints requires manual conversion: does not exist in peer-type
+	// WARNING: in.TransitGatewayAttachment requires manual conversion: does not exist in peer-type
+	// WARNING: in.VPCPeeringConnections requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkACLs requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2133,6 +2181,8 @@ func autoConvert_v1beta2_NetworkStatus_To_v1beta1_NetworkStatus(in *v1beta2.Netw
 	}
 	// WARNING: in.SecondaryAPIServerELB requires manual conversion: does not exist in peer-type
 	// WARNING: in.NatGatewaysIPs requires manual conversion: does not exist in peer-type
+	// WARNING: in.ReplacedAPIServerELB requires manual conversion: does not exist in peer-type
+	// WARNING: in.ControlPlaneEndpointDNSChangeID requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2267,13 +2317,18 @@ func autoConvert_v1beta2_SubnetSpec_To_v1beta1_SubnetSpec(in *v1beta2.SubnetSpec
 	out.CidrBlock = in.CidrBlock
 	out.IPv6CidrBlock = in.IPv6CidrBlock
 	out.AvailabilityZone = in.AvailabilityZone
+	// WARNING: in.AvailabilityZoneID requires manual conversion: does not exist in peer-type
 	out.IsPublic = in.IsPublic
 	out.IsIPv6 = in.IsIPv6
 	out.RouteTableID = (*string)(unsafe.Pointer(in.RouteTableID))
+	// WARNING: in.ExistingRouteTableID requires manual conversion: does not exist in peer-type
 	out.NatGatewayID = (*string)(unsafe.Pointer(in.NatGatewayID))
+	// WARNING: in.NatInstanceID requires manual conversion: does not exist in peer-type
 	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
+	// WARNING: in.LoadBalancerRole requires manual conversion: does not exist in peer-type
 	// WARNING: in.ZoneType requires manual conversion: does not exist in peer-type
 	// WARNING: in.ParentZoneName requires manual conversion: does not exist in peer-type
+	// WARNING: in.OutpostARN requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2320,10 +2375,16 @@ func autoConvert_v1beta2_VPCSpec_To_v1beta1_VPCSpec(in *v1beta2.VPCSpec, out *VP
 	out.Tags = *(*Tags)(unsafe.Pointer(&in.Tags))
 	out.AvailabilityZoneUsageLimit = (*int)(unsafe.Pointer(in.AvailabilityZoneUsageLimit))
 	out.AvailabilityZoneSelection = (*AZSelectionScheme)(unsafe.Pointer(in.AvailabilityZoneSelection))
+	// WARNING: in.AvailabilityZones requires manual conversion: does not exist in peer-type
 	// WARNING: in.EmptyRoutesDefaultVPCSecurityGroup requires manual conversion: does not exist in peer-type
 	// WARNING: in.PrivateDNSHostnameTypeOnLaunch requires manual conversion: does not exist in peer-type
 	// WARNING: in.ElasticIPPool requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayElasticIPAllocationIDs requires manual conversion: does not exist in peer-type
 	// WARNING: in.SubnetSchema requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatGatewayMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.NatInstance requires manual conversion: does not exist in peer-type
+	// WARNING: in.FlowLogs requires manual conversion: does not exist in peer-type
+	// WARNING: in.DHCPOptions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
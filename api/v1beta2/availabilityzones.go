@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateAvailabilityZones validates the availability zones to use for the subnets of the VPC.
+func (n *NetworkSpec) ValidateAvailabilityZones() []*field.Error {
+	var errs field.ErrorList
+
+	if len(n.VPC.AvailabilityZones) == 0 {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network", "vpc", "availabilityZones")
+	if n.VPC.ID != "" {
+		return append(errs, field.Forbidden(fldPath, "can only be set for a managed VPC"))
+	}
+
+	zones := make(map[string]bool, len(n.VPC.AvailabilityZones))
+	for i, zone := range n.VPC.AvailabilityZones {
+		if zone == "" {
+			errs = append(errs, field.Required(fldPath.Index(i), "must not be empty"))
+			continue
+		}
+		if zones[zone] {
+			errs = append(errs, field.Duplicate(fldPath.Index(i), zone))
+		}
+		zones[zone] = true
+	}
+
+	return errs
+}
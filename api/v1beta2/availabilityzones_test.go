@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateAvailabilityZones(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no availability zones",
+		},
+		{
+			name:    "availability zones of a managed vpc",
+			network: NetworkSpec{VPC: VPCSpec{AvailabilityZones: []string{"us-east-1a", "us-east-1c"}}},
+		},
+		{
+			name:           "availability zones of an unmanaged vpc",
+			network:        NetworkSpec{VPC: VPCSpec{ID: "vpc-exists", AvailabilityZones: []string{"us-east-1a"}}},
+			expectedFields: []string{"spec.network.vpc.availabilityZones"},
+		},
+		{
+			name:           "empty and duplicate availability zones",
+			network:        NetworkSpec{VPC: VPCSpec{AvailabilityZones: []string{"us-east-1a", "", "us-east-1a"}}},
+			expectedFields: []string{"spec.network.vpc.availabilityZones[1]", "spec.network.vpc.availabilityZones[2]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateAvailabilityZones() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
@@ -68,6 +68,30 @@ type AWSClusterSpec struct {
 	// +optional
 	SecondaryControlPlaneLoadBalancer *AWSLoadBalancerSpec `json:"secondaryControlPlaneLoadBalancer,omitempty"`
 
+	// APIServerPrivateDNSRecord is an optional record in a private Route53 hosted zone that is created
+	// for the control plane load balancer, so that workloads in the VPC can reach the API server
+	// through a stable internal name.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="apiServerPrivateDnsRecord is immutable"
+	// +optional
+	APIServerPrivateDNSRecord *PrivateDNSRecord `json:"apiServerPrivateDnsRecord,omitempty"`
+
+	// ControlPlaneEndpointDNS is an optional custom DNS name for the control plane endpoint.
+	// When set, an alias record for the control plane load balancer is created in the given Route53
+	// hosted zone, and the control plane endpoint is set to the custom name once the record has
+	// propagated, so that kubeconfigs use it instead of the load balancer DNS name.
+	// The API server certificate has to include the custom name in its subject alternative names.
+	// +optional
+	ControlPlaneEndpointDNS *ControlPlaneEndpointDNS `json:"controlPlaneEndpointDNS,omitempty"`
+
+	// ControlPlaneAZSpread controls how control plane instances are spread across availability zones.
+	// With Strict, a control plane instance is never launched into an availability zone which already
+	// hosts another control plane instance of the cluster. If the machine isn't bound to an availability
+	// zone, another zone is picked, otherwise the instance isn't launched until the zone is free.
+	// Defaults to None, which leaves the spread to Cluster API.
+	// +kubebuilder:validation:Enum=None;Strict
+	// +optional
+	ControlPlaneAZSpread ControlPlaneAZSpreadPolicy `json:"controlPlaneAZSpread,omitempty"`
+
 	// ImageLookupFormat is the AMI naming format to look up machine images when
 	// a machine does not specify an AMI. When set, this will be used for all
 	// cluster machines unless a machine specifies a different ImageLookupOrg.
@@ -113,6 +137,17 @@ type AWSClusterSpec struct {
 	S3Bucket *S3Bucket `json:"s3Bucket,omitempty"`
 }
 
+// ControlPlaneAZSpreadPolicy defines how control plane instances are spread across availability zones.
+type ControlPlaneAZSpreadPolicy string
+
+const (
+	// ControlPlaneAZSpreadPolicyNone leaves the spread of control plane instances to Cluster API.
+	ControlPlaneAZSpreadPolicyNone = ControlPlaneAZSpreadPolicy("None")
+
+	// ControlPlaneAZSpreadPolicyStrict prevents two control plane instances from running in the same availability zone.
+	ControlPlaneAZSpreadPolicyStrict = ControlPlaneAZSpreadPolicy("Strict")
+)
+
 // AWSIdentityKind defines allowed AWS identity types.
 type AWSIdentityKind string
 
@@ -193,27 +228,52 @@ type AWSLoadBalancerSpec struct {
 	// +optional
 	Name *string `json:"name,omitempty"`
 
+	// ARN references an existing classic, network or application load balancer to use for the control plane,
+	// instead of one created by CAPA. CAPA registers the control plane instances with the load balancer and
+	// sets the control plane endpoint to its DNS name, but never creates, modifies or deletes the load balancer
+	// itself. The type of the load balancer must match LoadBalancerType. Mutually exclusive with Name, and
+	// only supported for the primary control plane load balancer. Once set, the value cannot be changed.
+	// +kubebuilder:validation:Pattern=`^arn:[a-z0-9-]+:elasticloadbalancing:[a-z0-9-]+:[0-9]{12}:loadbalancer/.+$`
+	// +optional
+	ARN *string `json:"arn,omitempty"`
+
 	// Scheme sets the scheme of the load balancer (defaults to internet-facing)
 	// +kubebuilder:default=internet-facing
 	// +kubebuilder:validation:Enum=internet-facing;internal
 	// +optional
 	Scheme *ELBScheme `json:"scheme,omitempty"`
 
-	// CrossZoneLoadBalancing enables the classic ELB cross availability zone balancing.
+	// CrossZoneLoadBalancing enables the cross availability zone balancing of classic and network load balancers.
 	//
-	// With cross-zone load balancing, each load balancer node for your Classic Load Balancer
-	// distributes requests evenly across the registered instances in all enabled Availability Zones.
+	// With cross-zone load balancing, each load balancer node distributes requests evenly across
+	// the registered instances in all enabled Availability Zones.
 	// If cross-zone load balancing is disabled, each load balancer node distributes requests evenly across
 	// the registered instances in its Availability Zone only.
+	// Application load balancers always balance across availability zones and ignore this setting.
 	//
-	// Defaults to false.
+	// Changes are applied to existing load balancers. Defaults to false.
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing"`
 
+	// IdleTimeout sets the time, in seconds, that a connection to the load balancer is allowed to be idle
+	// (no data has been sent over the connection) before it is closed by the load balancer.
+	// Only applicable to classic and application load balancers. Defaults to 600 seconds for classic
+	// load balancers and 60 seconds for application load balancers.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4000
+	// +optional
+	IdleTimeout *int64 `json:"idleTimeout,omitempty"`
+
 	// Subnets sets the subnets that should be applied to the control plane load balancer (defaults to discovered subnets for managed VPCs or an empty set for unmanaged VPCs)
 	// +optional
 	Subnets []string `json:"subnets,omitempty"`
 
+	// SubnetFilters selects the subnets that should be applied to the control plane load balancer using
+	// EC2 subnet filters, for example by tag. Matching subnets must belong to the cluster VPC, and only
+	// one subnet may match per availability zone. Mutually exclusive with Subnets.
+	// +optional
+	SubnetFilters []Filter `json:"subnetFilters,omitempty"`
+
 	// HealthCheckProtocol sets the protocol type for ELB health check target
 	// default value is ELBProtocolSSL
 	// +kubebuilder:validation:Enum=TCP;SSL;HTTP;HTTPS;TLS;UDP
@@ -252,6 +312,39 @@ type AWSLoadBalancerSpec struct {
 	// PreserveClientIP lets the user control if preservation of client ips must be retained or not.
 	// If this is enabled 6443 will be opened to 0.0.0.0/0.
 	PreserveClientIP bool `json:"preserveClientIP,omitempty"`
+
+	// AccessLogs enables the access logs of the load balancer and stores them in an S3 bucket,
+	// so that the traffic to the API server can be audited. Access logs are disabled when unset.
+	// +optional
+	AccessLogs *LoadBalancerAccessLogs `json:"accessLogs,omitempty"`
+
+	// AdditionalTags is an optional set of tags to add to the load balancer, for example for cost allocation.
+	// They are added to the tags of spec.additionalTags and take precedence over them, and can override the
+	// Name tag. The tags of the load balancer are updated when they change, while the target groups and
+	// listeners of a network or application load balancer only get them when they are created.
+	// +optional
+	AdditionalTags Tags `json:"additionalTags,omitempty"`
+}
+
+// LoadBalancerAccessLogs defines where a load balancer stores its access logs.
+type LoadBalancerAccessLogs struct {
+	// Bucket is the name of the S3 bucket where the access logs are stored. The bucket must exist
+	// in the same region as the load balancer and its policy must allow the load balancer to write to it.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+
+	// Prefix is the prefix of the S3 object keys under which the access logs are stored.
+	// Access logs are stored at the root of the bucket when unset.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// EmitIntervalMinutes is the interval, in minutes, at which the access logs are published.
+	// Only applicable to classic load balancers, as network and application load balancers
+	// publish their access logs every 5 minutes. Defaults to 60 minutes.
+	// +kubebuilder:validation:Enum=5;60
+	// +optional
+	EmitIntervalMinutes *int64 `json:"emitIntervalMinutes,omitempty"`
 }
 
 // AdditionalListenerSpec defines the desired state of an
@@ -268,11 +361,26 @@ type AdditionalListenerSpec struct {
 	// +kubebuilder:default=TCP
 	Protocol ELBProtocol `json:"protocol,omitempty"`
 
+	// TargetPort sets the port on the control plane instances the additional listener forwards traffic to.
+	// Defaults to the listener port. The field is immutable.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	TargetPort *int64 `json:"targetPort,omitempty"`
+
 	// HealthCheck sets the optional custom health check configuration to the API target group.
 	// +optional
 	HealthCheck *TargetGroupHealthCheckAdditionalSpec `json:"healthCheck,omitempty"`
 }
 
+// GetTargetPort returns the port on the control plane instances the additional listener forwards traffic to.
+func (l *AdditionalListenerSpec) GetTargetPort() int64 {
+	if l.TargetPort != nil {
+		return *l.TargetPort
+	}
+	return l.Port
+}
+
 // AWSClusterStatus defines the observed state of AWSCluster.
 type AWSClusterStatus struct {
 	// +kubebuilder:default=false
@@ -112,10 +112,29 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		}
 
 		allErrs = append(allErrs, r.validateControlPlaneLoadBalancerUpdate(oldLB, newLB)...)
+		if newLB != nil {
+			allErrs = append(allErrs, newLB.AdditionalTags.Validate()...)
+		}
 	}
 
+	// Changed ingress rules of the control plane load balancers must not allow overlapping CIDR blocks.
+	for _, lb := range []struct {
+		path         *field.Path
+		oldLB, newLB *AWSLoadBalancerSpec
+	}{
+		{field.NewPath("spec", "controlPlaneLoadBalancer", "ingressRules"), oldC.Spec.ControlPlaneLoadBalancer, r.Spec.ControlPlaneLoadBalancer},
+		{field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "ingressRules"), oldC.Spec.SecondaryControlPlaneLoadBalancer, r.Spec.SecondaryControlPlaneLoadBalancer},
+	} {
+		if lb.newLB == nil || (lb.oldLB != nil && cmp.Equal(lb.oldLB.IngressRules, lb.newLB.IngressRules)) {
+			continue
+		}
+		allErrs = append(allErrs, validateIngressRulesCidrBlocksOverlap(lb.path, lb.newLB.IngressRules)...)
+	}
+
+	// The control plane endpoint changes when the control plane load balancer is replaced after a scheme change.
 	if !cmp.Equal(oldC.Spec.ControlPlaneEndpoint, clusterv1.APIEndpoint{}) &&
-		!cmp.Equal(r.Spec.ControlPlaneEndpoint, oldC.Spec.ControlPlaneEndpoint) {
+		!cmp.Equal(r.Spec.ControlPlaneEndpoint, oldC.Spec.ControlPlaneEndpoint) &&
+		!r.allowsControlPlaneLoadBalancerSchemeChange() {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "controlPlaneEndpoint"), r.Spec.ControlPlaneEndpoint, "field is immutable"),
 		)
@@ -134,6 +153,20 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 		}
 	}
 
+	if oldC.Spec.NetworkSpec.VPC.IsIPv6Enabled() != r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "network", "vpc", "ipv6"),
+				r.Spec.NetworkSpec.VPC.IPv6, "changing IP family is not allowed after it has been set"))
+	}
+
+	// The control plane endpoint can't be changed once set, neither can the custom name it is set to.
+	if !cmp.Equal(oldC.Spec.ControlPlaneEndpointDNS, r.Spec.ControlPlaneEndpointDNS) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneEndpointDNS"),
+				r.Spec.ControlPlaneEndpointDNS, "field is immutable"),
+		)
+	}
+
 	// If a identityRef is already set, do not allow removal of it.
 	if oldC.Spec.IdentityRef != nil && r.Spec.IdentityRef == nil {
 		allErrs = append(allErrs,
@@ -152,6 +185,12 @@ func (r *AWSCluster) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	allErrs = append(allErrs, r.Spec.Bastion.Validate()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.Spec.S3Bucket.Validate()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateTransitGatewayAttachmentUpdate(&oldC.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateVPCPeeringConnectionsUpdate(&oldC.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatGatewayModeUpdate(&oldC.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatInstanceUpdate(&oldC.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkACLsUpdate(&oldC.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkModeUpdate(&oldC.Spec.NetworkSpec)...)
 
 	if r.Spec.ControlPlaneLoadBalancer != nil {
 		if r.Spec.ControlPlaneLoadBalancer.LoadBalancerType == LoadBalancerTypeClassic {
@@ -194,23 +233,49 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 					newlb.Scheme, "field is immutable when created of disabled type"),
 			)
 		}
-		// If old scheme was not nil, the new scheme should be the same.
-		if !cmp.Equal(oldlb.Scheme, newlb.Scheme) {
+		// If old scheme was not nil, the new scheme should be the same, unless the user explicitly
+		// allowed replacing the primary load balancer with one of the new scheme.
+		schemeChange := !cmp.Equal(oldlb.Scheme, newlb.Scheme)
+		if schemeChange && (newlb != r.Spec.ControlPlaneLoadBalancer || !r.allowsControlPlaneLoadBalancerSchemeChange()) {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"),
-					newlb.Scheme, "field is immutable"),
+					newlb.Scheme, fmt.Sprintf("field is immutable unless the %s annotation is set", AllowControlPlaneLoadBalancerSchemeChangeAnnotation)),
 			)
+			schemeChange = false
+		}
+		if schemeChange {
+			// The replacement load balancer is created next to the old one, so it needs a different name.
+			if newlb.Name == nil || cmp.Equal(oldlb.Name, newlb.Name) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "name"),
+						newlb.Name, "a new name must be set when changing the scheme"),
+				)
+			}
+			if oldlb.LoadBalancerType == LoadBalancerTypeClassic || newlb.LoadBalancerType != oldlb.LoadBalancerType {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "scheme"),
+						newlb.Scheme, "the scheme can only be changed for network and application load balancers, together with the type"),
+				)
+			}
 		}
 		// The name must be defined when the AWSCluster is created. If it is not defined,
 		// then the controller generates a default name at runtime, but does not store it,
-		// so the name remains nil. In either case, the name cannot be changed.
-		if !cmp.Equal(oldlb.Name, newlb.Name) {
+		// so the name remains nil. In either case, the name cannot be changed, unless
+		// the load balancer is replaced after a scheme change.
+		if !cmp.Equal(oldlb.Name, newlb.Name) && !schemeChange {
 			allErrs = append(allErrs,
 				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "name"),
 					newlb.Name, "field is immutable"),
 			)
 		}
 
+		if !cmp.Equal(oldlb.ARN, newlb.ARN) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "arn"),
+					newlb.ARN, "field is immutable"),
+			)
+		}
+
 		// Block the update for Protocol :
 		// - if it was not set in old spec but added in new spec
 		// - if it was set in old spec but changed in new spec
@@ -221,12 +286,43 @@ func (r *AWSCluster) validateControlPlaneLoadBalancerUpdate(oldlb, newlb *AWSLoa
 						newlb.HealthCheckProtocol, "field is immutable once set"),
 				)
 			}
+			if !cmp.Equal(healthCheckPath(newlb), healthCheckPath(oldlb)) {
+				allErrs = append(allErrs,
+					field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "healthCheck", "path"),
+						healthCheckPath(newlb), "field is immutable once set"),
+				)
+			}
+		}
+
+		// The target group of an additional listener can't be changed without recreating the listener.
+		for i, ln := range newlb.AdditionalListeners {
+			for _, oldLn := range oldlb.AdditionalListeners {
+				if ln.Port == oldLn.Port && ln.GetTargetPort() != oldLn.GetTargetPort() {
+					allErrs = append(allErrs,
+						field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalListeners").Index(i).Child("targetPort"),
+							ln.GetTargetPort(), "field is immutable"),
+					)
+				}
+			}
 		}
 	}
 
 	return allErrs
 }
 
+// allowsControlPlaneLoadBalancerSchemeChange returns true if the user allowed replacing the primary
+// control plane load balancer to change its scheme.
+func (r *AWSCluster) allowsControlPlaneLoadBalancerSchemeChange() bool {
+	return r.GetAnnotations()[AllowControlPlaneLoadBalancerSchemeChangeAnnotation] == "true"
+}
+
+func healthCheckPath(lb *AWSLoadBalancerSpec) *string {
+	if lb.HealthCheck == nil {
+		return nil
+	}
+	return lb.HealthCheck.Path
+}
+
 // Default satisfies the defaulting webhook interface.
 func (r *AWSCluster) Default() {
 	SetObjectDefaults_AWSCluster(r)
@@ -274,13 +370,24 @@ func (r *AWSCluster) validateSSHKeyName() field.ErrorList {
 
 func (r *AWSCluster) validateNetwork() field.ErrorList {
 	var allErrs field.ErrorList
-	if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
-		allErrs = append(allErrs, field.Invalid(field.NewPath("ipv6"), r.Spec.NetworkSpec.VPC.IPv6, "IPv6 cannot be used with unmanaged clusters at this time."))
-	}
+	allErrs = append(allErrs, r.validateIPv6()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateVPCEndpoints()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateTransitGatewayAttachment()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateVPCPeeringConnections()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateExistingRouteTables()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatGatewayMode()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatGatewayElasticIPAllocationIDs()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatInstance()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateFlowLogs()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateDHCPOptions()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkACLs()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateAvailabilityZones()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateOutposts()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrefixLists()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkMode(r.securityGroupRoles()...)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSecurityGroupOverrides(r.securityGroupRoles()...)...)
+
 	for _, subnet := range r.Spec.NetworkSpec.Subnets {
-		if subnet.IsIPv6 || subnet.IPv6CidrBlock != "" {
-			allErrs = append(allErrs, field.Invalid(field.NewPath("subnets"), r.Spec.NetworkSpec.Subnets, "IPv6 cannot be used with unmanaged clusters at this time."))
-		}
 		if subnet.ZoneType != nil && subnet.IsEdge() {
 			if subnet.ParentZoneName == nil {
 				allErrs = append(allErrs, field.Invalid(field.NewPath("subnets"), r.Spec.NetworkSpec.Subnets, "ParentZoneName must be set when ZoneType is 'local-zone'."))
@@ -324,10 +431,58 @@ func (r *AWSCluster) validateNetwork() field.ErrorList {
 
 	secondaryCidrBlocks := r.Spec.NetworkSpec.VPC.SecondaryCidrBlocks
 	secondaryCidrBlocksField := field.NewPath("spec", "network", "vpc", "secondaryCidrBlocks")
-	for _, cidrBlock := range secondaryCidrBlocks {
+	seenCidrBlocks := map[string]bool{}
+	for i, cidrBlock := range secondaryCidrBlocks {
 		if r.Spec.NetworkSpec.VPC.CidrBlock != "" && r.Spec.NetworkSpec.VPC.CidrBlock == cidrBlock.IPv4CidrBlock {
 			allErrs = append(allErrs, field.Invalid(secondaryCidrBlocksField, secondaryCidrBlocks, fmt.Sprintf("AWSCluster.spec.network.vpc.secondaryCidrBlocks must not contain the primary AWSCluster.spec.network.vpc.cidrBlock %v", r.Spec.NetworkSpec.VPC.CidrBlock)))
 		}
+		if _, _, err := net.ParseCIDR(cidrBlock.IPv4CidrBlock); err != nil {
+			allErrs = append(allErrs, field.Invalid(secondaryCidrBlocksField.Index(i).Child("ipv4CidrBlock"), cidrBlock.IPv4CidrBlock, "CIDR block is invalid"))
+		}
+		if seenCidrBlocks[cidrBlock.IPv4CidrBlock] {
+			allErrs = append(allErrs, field.Duplicate(secondaryCidrBlocksField.Index(i).Child("ipv4CidrBlock"), cidrBlock.IPv4CidrBlock))
+		}
+		seenCidrBlocks[cidrBlock.IPv4CidrBlock] = true
+	}
+
+	return allErrs
+}
+
+// validateIPv6 validates the IPv6 configuration of a dual-stack VPC.
+// securityGroupRoles returns the roles of the security groups the cluster needs.
+func (r *AWSCluster) securityGroupRoles() []SecurityGroupRole {
+	roles := []SecurityGroupRole{SecurityGroupAPIServerLB, SecurityGroupLB, SecurityGroupControlPlane, SecurityGroupNode}
+	if r.Spec.Bastion.Enabled {
+		roles = append(roles, SecurityGroupBastion)
+	}
+	return roles
+}
+
+func (r *AWSCluster) validateIPv6() field.ErrorList {
+	var allErrs field.ErrorList
+
+	vpc := r.Spec.NetworkSpec.VPC
+	if !vpc.IsIPv6Enabled() {
+		return allErrs
+	}
+
+	ipv6Field := field.NewPath("spec", "network", "vpc", "ipv6")
+	if vpc.IPv6.CidrBlock != "" && vpc.IPv6.PoolID == "" {
+		allErrs = append(allErrs, field.Invalid(ipv6Field.Child("poolId"), vpc.IPv6.PoolID, "poolId cannot be empty if cidrBlock is set"))
+	}
+	if vpc.IPv6.PoolID != "" && vpc.IPv6.IPAMPool != nil {
+		allErrs = append(allErrs, field.Invalid(ipv6Field.Child("poolId"), vpc.IPv6.PoolID, "poolId and ipamPool cannot be used together"))
+	}
+	if vpc.IPv6.CidrBlock != "" && vpc.IPv6.IPAMPool != nil {
+		allErrs = append(allErrs, field.Invalid(ipv6Field.Child("cidrBlock"), vpc.IPv6.CidrBlock, "cidrBlock and ipamPool cannot be used together"))
+	}
+	if vpc.IPv6.IPAMPool != nil && vpc.IPv6.IPAMPool.ID == "" && vpc.IPv6.IPAMPool.Name == "" {
+		allErrs = append(allErrs, field.Invalid(ipv6Field.Child("ipamPool"), vpc.IPv6.IPAMPool, "ipamPool must have either id or name"))
+	}
+
+	// Classic load balancers can't be reached over IPv6 in a VPC.
+	if r.Spec.ControlPlaneLoadBalancer != nil && r.Spec.ControlPlaneLoadBalancer.LoadBalancerType == LoadBalancerTypeClassic {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "loadBalancerType"), r.Spec.ControlPlaneLoadBalancer.LoadBalancerType, "classic load balancers do not support IPv6, use a network or application load balancer"))
 	}
 
 	return allErrs
@@ -349,9 +504,15 @@ func (r *AWSCluster) validateControlPlaneLBs() (admission.Warnings, field.ErrorL
 		}
 	}
 
+	allErrs = append(allErrs, r.Spec.ControlPlaneLoadBalancer.ValidateARN(field.NewPath("spec", "controlPlaneLoadBalancer"))...)
+
 	// If the secondary is defined, check that the name is not empty and different from the primary.
 	// Also, ensure that the secondary load balancer is an NLB
 	if r.Spec.SecondaryControlPlaneLoadBalancer != nil {
+		if r.Spec.SecondaryControlPlaneLoadBalancer.ARN != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "arn"), *r.Spec.SecondaryControlPlaneLoadBalancer.ARN, "existing load balancers are only supported for the primary control plane load balancer"))
+		}
+
 		if r.Spec.SecondaryControlPlaneLoadBalancer.Name == nil || *r.Spec.SecondaryControlPlaneLoadBalancer.Name == "" {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secondaryControlPlaneLoadBalancer", "name"), r.Spec.SecondaryControlPlaneLoadBalancer.Name, "secondary controlPlaneLoadBalancer.name cannot be empty"))
 		}
@@ -378,13 +539,42 @@ func (r *AWSCluster) validateControlPlaneLBs() (admission.Warnings, field.ErrorL
 		r.Spec.ControlPlaneLoadBalancer,
 		r.Spec.SecondaryControlPlaneLoadBalancer,
 	}
-	for _, cp := range loadBalancers {
+	loadBalancerPaths := []*field.Path{
+		field.NewPath("spec", "controlPlaneLoadBalancer"),
+		field.NewPath("spec", "secondaryControlPlaneLoadBalancer"),
+	}
+	for i, cp := range loadBalancers {
 		if cp == nil {
 			continue
 		}
 
-		for _, rule := range cp.IngressRules {
+		for j, rule := range cp.IngressRules {
 			allErrs = append(allErrs, r.validateIngressRule(rule)...)
+			allErrs = append(allErrs, validateIngressRulePrefixLists(loadBalancerPaths[i].Child("ingressRules").Index(j), rule)...)
+		}
+		allErrs = append(allErrs, validateIngressRulesCidrBlocksOverlap(loadBalancerPaths[i].Child("ingressRules"), cp.IngressRules)...)
+		allErrs = append(allErrs, cp.AdditionalTags.Validate()...)
+
+		if cp.HealthCheck != nil && cp.HealthCheck.Path != nil &&
+			(cp.HealthCheckProtocol == nil || (*cp.HealthCheckProtocol != ELBProtocolHTTP && *cp.HealthCheckProtocol != ELBProtocolHTTPS)) {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPaths[i].Child("healthCheck", "path"), cp.HealthCheck.Path, "health check path can only be set when healthCheckProtocol is HTTP or HTTPS"))
+		}
+
+		if cp.HealthCheck != nil && cp.HealthCheck.TimeoutSeconds != nil && cp.HealthCheck.IntervalSeconds != nil &&
+			*cp.HealthCheck.TimeoutSeconds >= *cp.HealthCheck.IntervalSeconds {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPaths[i].Child("healthCheck", "timeoutSeconds"), *cp.HealthCheck.TimeoutSeconds, "health check timeout must be smaller than the health check interval"))
+		}
+
+		if len(cp.Subnets) > 0 && len(cp.SubnetFilters) > 0 {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPaths[i].Child("subnetFilters"), cp.SubnetFilters, "subnetFilters cannot be set together with subnets"))
+		}
+
+		if cp.IdleTimeout != nil && (cp.LoadBalancerType == LoadBalancerTypeNLB || cp.LoadBalancerType == LoadBalancerTypeDisabled) {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPaths[i].Child("idleTimeout"), *cp.IdleTimeout, "idle timeout can only be set for classic and application load balancers"))
+		}
+
+		if cp.AccessLogs != nil && cp.AccessLogs.EmitIntervalMinutes != nil && cp.LoadBalancerType != LoadBalancerTypeClassic {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPaths[i].Child("accessLogs", "emitIntervalMinutes"), *cp.AccessLogs.EmitIntervalMinutes, "access logs emit interval can only be set for classic load balancers"))
 		}
 	}
 
@@ -401,6 +591,10 @@ func (r *AWSCluster) validateControlPlaneLBs() (admission.Warnings, field.ErrorL
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "subnets"), r.Spec.ControlPlaneLoadBalancer.Subnets, "subnets cannot be set if the LoadBalancer reconciliation is disabled"))
 		}
 
+		if len(r.Spec.ControlPlaneLoadBalancer.SubnetFilters) > 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "subnetFilters"), r.Spec.ControlPlaneLoadBalancer.SubnetFilters, "subnet filters cannot be set if the LoadBalancer reconciliation is disabled"))
+		}
+
 		if r.Spec.ControlPlaneLoadBalancer.HealthCheckProtocol != nil {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "healthCheckProtocol"), r.Spec.ControlPlaneLoadBalancer.HealthCheckProtocol, "healthcheck protocol cannot be set if the LoadBalancer reconciliation is disabled"))
 		}
@@ -413,6 +607,14 @@ func (r *AWSCluster) validateControlPlaneLBs() (admission.Warnings, field.ErrorL
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalListeners"), r.Spec.ControlPlaneLoadBalancer.AdditionalListeners, "cannot set additional listeners if the LoadBalancer reconciliation is disabled"))
 		}
 
+		if r.Spec.ControlPlaneLoadBalancer.AccessLogs != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "accessLogs"), r.Spec.ControlPlaneLoadBalancer.AccessLogs, "access logs cannot be set if the LoadBalancer reconciliation is disabled"))
+		}
+
+		if len(r.Spec.ControlPlaneLoadBalancer.AdditionalTags) > 0 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "additionalTags"), r.Spec.ControlPlaneLoadBalancer.AdditionalTags, "additional tags cannot be set if the LoadBalancer reconciliation is disabled"))
+		}
+
 		if len(r.Spec.ControlPlaneLoadBalancer.IngressRules) > 0 {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "ingressRules"), r.Spec.ControlPlaneLoadBalancer.IngressRules, "ingress rules cannot be set if the LoadBalancer reconciliation is disabled"))
 		}
@@ -424,11 +626,56 @@ func (r *AWSCluster) validateControlPlaneLBs() (admission.Warnings, field.ErrorL
 		if r.Spec.ControlPlaneLoadBalancer.DisableHostsRewrite {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneLoadBalancer", "disableHostsRewrite"), r.Spec.ControlPlaneLoadBalancer.DisableHostsRewrite, "cannot disable hosts rewrite if the LoadBalancer reconciliation is disabled"))
 		}
+
+		if r.Spec.ControlPlaneEndpointDNS != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "controlPlaneEndpointDNS"), r.Spec.ControlPlaneEndpointDNS, "cannot set a custom control plane endpoint DNS name if the LoadBalancer reconciliation is disabled"))
+		}
 	}
 
 	return allWarnings, allErrs
 }
 
+// validateIngressRulesCidrBlocksOverlap rejects the CIDR blocks overlapping another CIDR block allowed to
+// the same ports, which would lead to redundant or duplicate security group rules.
+func validateIngressRulesCidrBlocksOverlap(fldPath *field.Path, rules []IngressRule) field.ErrorList {
+	var allErrs field.ErrorList
+
+	type allowedCidrBlock struct {
+		rule  IngressRule
+		cidr  string
+		ipNet *net.IPNet
+	}
+	var allowed []allowedCidrBlock
+
+	check := func(path *field.Path, rule IngressRule, cidr string) {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return
+		}
+		for _, a := range allowed {
+			if a.rule.Protocol != rule.Protocol || a.rule.FromPort != rule.FromPort || a.rule.ToPort != rule.ToPort {
+				continue
+			}
+			if a.ipNet.Contains(ipNet.IP) || ipNet.Contains(a.ipNet.IP) {
+				allErrs = append(allErrs, field.Invalid(path, cidr, fmt.Sprintf("overlaps with CIDR block %s allowed to the same ports", a.cidr)))
+				return
+			}
+		}
+		allowed = append(allowed, allowedCidrBlock{rule: rule, cidr: cidr, ipNet: ipNet})
+	}
+
+	for i, rule := range rules {
+		for j, cidr := range rule.CidrBlocks {
+			check(fldPath.Index(i).Child("cidrBlocks").Index(j), rule, cidr)
+		}
+		for j, cidr := range rule.IPv6CidrBlocks {
+			check(fldPath.Index(i).Child("ipv6CidrBlocks").Index(j), rule, cidr)
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSCluster) validateIngressRule(rule IngressRule) field.ErrorList {
 	var allErrs field.ErrorList
 	if rule.NatGatewaysIPsSource {
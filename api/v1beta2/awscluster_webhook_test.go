@@ -88,6 +88,172 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "No options are allowed when LoadBalancer is disabled (subnetFilters)",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						SubnetFilters:    []Filter{{Name: "tag:lb", Values: []string{"true"}}},
+						LoadBalancerType: LoadBalancerTypeDisabled,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check path requires an HTTP or HTTPS health check protocol",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:    LoadBalancerTypeNLB,
+						HealthCheckProtocol: &ELBProtocolTCP,
+						HealthCheck:         &TargetGroupHealthCheckAPISpec{Path: ptr.To("/healthz")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check path is allowed with an HTTPS health check protocol",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType:    LoadBalancerTypeNLB,
+						HealthCheckProtocol: &ELBProtocolHTTPS,
+						HealthCheck:         &TargetGroupHealthCheckAPISpec{Path: ptr.To("/healthz")},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "health check timeout must be smaller than the interval",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						HealthCheck: &TargetGroupHealthCheckAPISpec{
+							IntervalSeconds: ptr.To[int64](5),
+							TimeoutSeconds:  ptr.To[int64](5),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "health check port, interval, timeout and thresholds are allowed",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						HealthCheck: &TargetGroupHealthCheckAPISpec{
+							Port:                    ptr.To[int64](8443),
+							IntervalSeconds:         ptr.To[int64](5),
+							TimeoutSeconds:          ptr.To[int64](3),
+							ThresholdCount:          ptr.To[int64](2),
+							UnhealthyThresholdCount: ptr.To[int64](2),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "access logs are allowed for network load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Bucket: "access-logs",
+							Prefix: "apiserver",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "access logs emit interval is allowed for classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Bucket:              "access-logs",
+							EmitIntervalMinutes: ptr.To[int64](5),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "access logs emit interval is rejected for network load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Bucket:              "access-logs",
+							EmitIntervalMinutes: ptr.To[int64](5),
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "access logs are rejected when the load balancer reconciliation is disabled",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeDisabled,
+						AccessLogs: &LoadBalancerAccessLogs{
+							Bucket: "access-logs",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "idle timeout is allowed for classic load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+						IdleTimeout:      ptr.To[int64](3600),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "idle timeout is rejected for network load balancers",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IdleTimeout:      ptr.To[int64](3600),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnets and subnetFilters are mutually exclusive",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Subnets:       []string{"foo"},
+						SubnetFilters: []Filter{{Name: "tag:lb", Values: []string{"true"}}},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "No options are allowed when LoadBalancer is disabled (healthCheckProtocol)",
 			cluster: &AWSCluster{
@@ -160,6 +326,21 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "No options are allowed when LoadBalancer is disabled (controlPlaneEndpointDNS)",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeDisabled,
+					},
+					ControlPlaneEndpointDNS: &ControlPlaneEndpointDNS{
+						HostedZoneID: "Z0123456789ABCDEFGHIJ",
+						Name:         "api.example.com",
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "No options are allowed when LoadBalancer is disabled (preserveClientIP)",
 			cluster: &AWSCluster{
@@ -323,33 +504,51 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "rejects ipv6",
+			name: "accepts ipv6 with an amazon provided cidr block",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							IPv6: &IPv6{},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "accepts ipv6 with a byoip cidr block",
 			cluster: &AWSCluster{
 				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
 					NetworkSpec: NetworkSpec{
 						VPC: VPCSpec{
 							IPv6: &IPv6{
-								CidrBlock: "2001:2345:5678::/64",
+								CidrBlock: "2001:2345:5678::/56",
 								PoolID:    "pool-id",
 							},
 						},
 					},
 				},
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "rejects ipv6 enabled subnet",
+			name: "rejects ipv6 cidr block without pool id",
 			cluster: &AWSCluster{
 				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
 					NetworkSpec: NetworkSpec{
-						Subnets: []SubnetSpec{
-							{
-								ID:     "sub-1",
-								IsIPv6: true,
-							},
-							{
-								ID: "sub-2",
+						VPC: VPCSpec{
+							IPv6: &IPv6{
+								CidrBlock: "2001:2345:5678::/56",
 							},
 						},
 					},
@@ -358,14 +557,17 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "rejects ipv6 cidr block for subnets",
+			name: "rejects ipv6 pool id together with an ipam pool",
 			cluster: &AWSCluster{
 				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
 					NetworkSpec: NetworkSpec{
-						Subnets: []SubnetSpec{
-							{
-								ID:            "sub-1",
-								IPv6CidrBlock: "2022:1234:5678:9101::/64",
+						VPC: VPCSpec{
+							IPv6: &IPv6{
+								PoolID:   "pool-id",
+								IPAMPool: &IPAMPool{ID: "ipam-pool-id"},
 							},
 						},
 					},
@@ -373,6 +575,22 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "rejects ipv6 with a classic load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeClassic,
+					},
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							IPv6: &IPv6{},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "rejects ingress rules with cidr block and source security group id",
 			cluster: &AWSCluster{
@@ -477,6 +695,54 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "rejects ingress rules with overlapping cidr blocks for the same ports",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						IngressRules: []IngressRule{
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.0.0.0/16"},
+							},
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.0.1.0/24"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts ingress rules with overlapping cidr blocks for different ports",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						IngressRules: []IngressRule{
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.0.0.0/16", "192.168.0.0/16"},
+							},
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   22623,
+								ToPort:     22623,
+								CidrBlocks: []string{"10.0.1.0/24"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "accepts ingress rules with nat gateway IPs source",
 			cluster: &AWSCluster{
@@ -660,44 +926,166 @@ func TestAWSClusterValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.BootstrapFormatIgnition, true)
-
-			cluster := tt.cluster.DeepCopy()
-			cluster.ObjectMeta = metav1.ObjectMeta{
-				GenerateName: "cluster-",
-				Namespace:    "default",
-			}
-			ctx := context.TODO()
-			if err := testEnv.Create(ctx, cluster); (err != nil) != tt.wantErr {
-				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
-			}
-
-			if tt.wantErr {
-				return
-			}
-
-			c := &AWSCluster{}
-			key := client.ObjectKey{
-				Name:      cluster.Name,
-				Namespace: "default",
-			}
-
-			g := NewWithT(t)
-			g.Eventually(func() bool {
-				err := testEnv.Get(ctx, key, c)
-				return err == nil
-			}, 10*time.Second).Should(BeTrue(), fmt.Sprintf("Eventually failed getting the newly created cluster %q", cluster.Name))
-
-			if tt.expect != nil {
-				tt.expect(g, c.Spec.ControlPlaneLoadBalancer)
-			}
-		})
-	}
-}
-
+		{
+			name: "accepts secondary CIDR blocks",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							CidrBlock:           "10.0.0.0/16",
+							SecondaryCidrBlocks: []VpcCidrBlock{{IPv4CidrBlock: "100.64.0.0/16"}, {IPv4CidrBlock: "10.1.0.0/16"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rejects an invalid secondary CIDR block",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							SecondaryCidrBlocks: []VpcCidrBlock{{IPv4CidrBlock: "100.64.0.0"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "No options are allowed when LoadBalancer is disabled (additionalTags)",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeDisabled,
+						AdditionalTags:   Tags{"cost-center": "1234"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts control plane load balancer additional tags",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:           ptr.To("platform-prod-apiserver"),
+						AdditionalTags: Tags{"cost-center": "1234"},
+					},
+				},
+			},
+			expect: func(g *WithT, res *AWSLoadBalancerSpec) {
+				g.Expect(res.Name).To(HaveValue(Equal("platform-prod-apiserver")))
+				g.Expect(res.AdditionalTags).To(Equal(Tags{"cost-center": "1234"}))
+			},
+		},
+		{
+			name: "rejects invalid control plane load balancer additional tags",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						AdditionalTags: Tags{"aws:cost-center": "1234"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts an existing control plane load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/my-nlb/0123456789abcdef"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			expect: func(g *WithT, res *AWSLoadBalancerSpec) {
+				g.Expect(res.ARN).To(HaveValue(Equal("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/my-nlb/0123456789abcdef")))
+				g.Expect(res.Name).To(BeNil())
+			},
+		},
+		{
+			name: "rejects an existing load balancer of a different type",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/my-nlb/0123456789abcdef"),
+						LoadBalancerType: LoadBalancerTypeClassic,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects an existing secondary control plane load balancer",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					SecondaryControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             ptr.To("test-scheme-internal"),
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/test-scheme-internal/0123456789abcdef"),
+						Scheme:           &ELBSchemeInternal,
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects duplicate secondary CIDR blocks",
+			cluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							SecondaryCidrBlocks: []VpcCidrBlock{{IPv4CidrBlock: "100.64.0.0/16"}, {IPv4CidrBlock: "100.64.0.0/16"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.BootstrapFormatIgnition, true)
+
+			cluster := tt.cluster.DeepCopy()
+			cluster.ObjectMeta = metav1.ObjectMeta{
+				GenerateName: "cluster-",
+				Namespace:    "default",
+			}
+			ctx := context.TODO()
+			if err := testEnv.Create(ctx, cluster); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			c := &AWSCluster{}
+			key := client.ObjectKey{
+				Name:      cluster.Name,
+				Namespace: "default",
+			}
+
+			g := NewWithT(t)
+			g.Eventually(func() bool {
+				err := testEnv.Get(ctx, key, c)
+				return err == nil
+			}, 10*time.Second).Should(BeTrue(), fmt.Sprintf("Eventually failed getting the newly created cluster %q", cluster.Name))
+
+			if tt.expect != nil {
+				tt.expect(g, c.Spec.ControlPlaneLoadBalancer)
+			}
+		})
+	}
+}
+
 func TestAWSClusterValidateUpdate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -705,6 +1093,109 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 		newCluster *AWSCluster
 		wantErr    bool
 	}{
+		{
+			name: "Control plane load balancer additional tags can be changed",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						AdditionalTags: Tags{"cost-center": "1234"},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						AdditionalTags: Tags{"cost-center": "5678", "team": "platform"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Control plane load balancer additional tags are validated",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						AdditionalTags: Tags{"aws:cost-center": "1234"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Existing control plane load balancer is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/my-nlb/0123456789abcdef"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						ARN:              ptr.To("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/other-nlb/0123456789abcdef"),
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Additional listener target port is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8132, Protocol: ELBProtocolTCP},
+						},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8132, Protocol: ELBProtocolTCP, TargetPort: ptr.To[int64](8133)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Additional listener target port can be set to the listener port",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8132, Protocol: ELBProtocolTCP},
+						},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						AdditionalListeners: []AdditionalListenerSpec{
+							{Port: 8132, Protocol: ELBProtocolTCP, TargetPort: ptr.To[int64](8132)},
+							{Port: 8443, Protocol: ELBProtocolTCP, TargetPort: ptr.To[int64](9443)},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Control Plane LB type is immutable when switching from disabled to any",
 			oldCluster: &AWSCluster{
@@ -809,6 +1300,81 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "controlPlaneLoadBalancer scheme can be changed with a new name if the annotation is set",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("example-apiserver"),
+						Scheme:           &ELBSchemeInternal,
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AllowControlPlaneLoadBalancerSchemeChangeAnnotation: "true"},
+				},
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("example-apiserver-public"),
+						Scheme:           &ELBSchemeInternetFacing,
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "controlPlaneLoadBalancer scheme cannot be changed without a new name",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("example-apiserver"),
+						Scheme:           &ELBSchemeInternal,
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AllowControlPlaneLoadBalancerSchemeChangeAnnotation: "true"},
+				},
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("example-apiserver"),
+						Scheme:           &ELBSchemeInternetFacing,
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneLoadBalancer scheme of a classic load balancer cannot be changed",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("example-apiserver"),
+						Scheme:           &ELBSchemeInternal,
+						LoadBalancerType: LoadBalancerTypeClassic,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AllowControlPlaneLoadBalancerSchemeChangeAnnotation: "true"},
+				},
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						Name:             aws.String("example-apiserver-public"),
+						Scheme:           &ELBSchemeInternetFacing,
+						LoadBalancerType: LoadBalancerTypeClassic,
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "controlPlaneLoadBalancer scheme is immutable when left empty",
 			oldCluster: &AWSCluster{
@@ -875,6 +1441,29 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "controlPlaneEndpoint can be updated if the load balancer scheme change annotation is set",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{
+						Host: "example.com",
+						Port: int32(8000),
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{AllowControlPlaneLoadBalancerSchemeChangeAnnotation: "true"},
+				},
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{
+						Host: "foo.example.com",
+						Port: int32(8000),
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "controlPlaneEndpoint can be updated if it is empty",
 			oldCluster: &AWSCluster{
@@ -892,6 +1481,41 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "controlPlaneEndpointDNS is immutable",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpointDNS: &ControlPlaneEndpointDNS{
+						HostedZoneID: "Z0123456789ABCDEFGHIJ",
+						Name:         "api.example.com",
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpointDNS: &ControlPlaneEndpointDNS{
+						HostedZoneID: "Z0123456789ABCDEFGHIJ",
+						Name:         "api2.example.com",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "controlPlaneEndpointDNS can't be added after creation",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneEndpointDNS: &ControlPlaneEndpointDNS{
+						HostedZoneID: "Z0123456789ABCDEFGHIJ",
+						Name:         "api.example.com",
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "removal of externally managed annotation is not allowed",
 			oldCluster: &AWSCluster{
@@ -1118,6 +1742,97 @@ func TestAWSClusterValidateUpdate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Control Plane LB ingress rules can be changed",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IngressRules: []IngressRule{
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.0.0.0/16"},
+							},
+						},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IngressRules: []IngressRule{
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.1.0.0/16", "192.168.0.0/24"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Control Plane LB ingress rules cannot be changed to overlapping cidr blocks",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IngressRules: []IngressRule{
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.0.0.0/16"},
+							},
+						},
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+						IngressRules: []IngressRule{
+							{
+								Protocol:   SecurityGroupProtocolTCP,
+								FromPort:   6443,
+								ToPort:     6443,
+								CidrBlocks: []string{"10.0.0.0/16", "10.0.1.0/24"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "IPv6 cannot be enabled on an existing cluster",
+			oldCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+				},
+			},
+			newCluster: &AWSCluster{
+				Spec: AWSClusterSpec{
+					ControlPlaneLoadBalancer: &AWSLoadBalancerSpec{
+						LoadBalancerType: LoadBalancerTypeNLB,
+					},
+					NetworkSpec: NetworkSpec{
+						VPC: VPCSpec{
+							IPv6: &IPv6{},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
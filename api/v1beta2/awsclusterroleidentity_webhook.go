@@ -18,7 +18,9 @@ package v1beta2
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -60,6 +62,10 @@ func (r *AWSClusterRoleIdentity) ValidateCreate() (admission.Warnings, error) {
 		}
 	}
 
+	if errs := r.validatePolicyARNs(); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("AWSClusterRoleIdentity").GroupKind(), r.Name, errs)
+	}
+
 	return nil, nil
 }
 
@@ -89,9 +95,32 @@ func (r *AWSClusterRoleIdentity) ValidateUpdate(old runtime.Object) (admission.W
 		}
 	}
 
+	if errs := r.validatePolicyARNs(); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("AWSClusterRoleIdentity").GroupKind(), r.Name, errs)
+	}
+
 	return nil, nil
 }
 
+// validatePolicyARNs checks that the session policy ARNs refer to IAM managed policies.
+func (r *AWSClusterRoleIdentity) validatePolicyARNs() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, policyARN := range r.Spec.PolicyARNs {
+		path := field.NewPath("spec", "policyARNs").Index(i)
+		parsedARN, err := arn.Parse(policyARN)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, policyARN, "must be a valid ARN"))
+			continue
+		}
+		if parsedARN.Service != "iam" || !strings.HasPrefix(parsedARN.Resource, "policy/") {
+			allErrs = append(allErrs, field.Invalid(path, policyARN, "must be the ARN of an IAM managed policy"))
+		}
+	}
+
+	return allErrs
+}
+
 // Default will set default values for the AWSClusterRoleIdentity.
 func (r *AWSClusterRoleIdentity) Default() {
 	SetDefaults_Labels(&r.ObjectMeta)
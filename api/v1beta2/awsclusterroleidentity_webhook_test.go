@@ -56,6 +56,42 @@ func TestAWSClusterRoleValidateCreate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "successfully create AWSClusterRoleIdentity with session policy ARNs",
+			identity: &AWSClusterRoleIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "role",
+				},
+				Spec: AWSClusterRoleIdentitySpec{
+					AWSRoleSpec: AWSRoleSpec{
+						PolicyARNs: []string{"arn:aws:iam::123456789012:policy/eks-cluster-creation"},
+					},
+					SourceIdentityRef: &AWSIdentityReference{
+						Name: "another-role",
+						Kind: ClusterRoleIdentityKind,
+					},
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "do not allow session policy ARNs that are not IAM policies",
+			identity: &AWSClusterRoleIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "role",
+				},
+				Spec: AWSClusterRoleIdentitySpec{
+					AWSRoleSpec: AWSRoleSpec{
+						PolicyARNs: []string{"arn:aws:iam::123456789012:role/eks-cluster-creation", "not-an-arn"},
+					},
+					SourceIdentityRef: &AWSIdentityReference{
+						Name: "another-role",
+						Kind: ClusterRoleIdentityKind,
+					},
+				},
+			},
+			wantError: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,6 +63,29 @@ const (
 	NetworkInterfaceTypeEFAWithENAInterface NetworkInterfaceType = NetworkInterfaceType("efa")
 )
 
+// SecondaryNetworkInterface defines an ENI that is created and attached to an instance besides its
+// primary network interface.
+type SecondaryNetworkInterface struct {
+	// DeviceIndex is the index of the device of the network interface on the instance. The primary
+	// network interface and the network interfaces in networkInterfaces use the first indexes.
+	// +kubebuilder:validation:Minimum=1
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// SubnetID is the ID of the subnet of the network interface, which must be in the availability
+	// zone of the instance. Defaults to the subnet of the instance.
+	// +optional
+	SubnetID string `json:"subnetID,omitempty"`
+
+	// SecurityGroupIDs are the IDs of the security groups of the network interface, which are not
+	// changed afterwards. Defaults to the security groups of the instance when it is launched.
+	// +optional
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+
+	// Description is the description of the network interface.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
 // AWSMachineSpec defines the desired state of an Amazon EC2 instance.
 type AWSMachineSpec struct {
 	// ProviderID is the unique identifier as specified by the cloud provider.
@@ -100,9 +123,10 @@ type AWSMachineSpec struct {
 	ImageLookupBaseOS string `json:"imageLookupBaseOS,omitempty"`
 
 	// InstanceType is the type of instance to create. Example: m4.xlarge
-	// +kubebuilder:validation:Required
+	// Required unless the controller is configured with a default instance type.
 	// +kubebuilder:validation:MinLength:=2
-	InstanceType string `json:"instanceType"`
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
 
 	// AdditionalTags is an optional set of tags to add to an instance, in addition to the ones added by default by the
 	// AWS provider. If both the AWSCluster and the AWSMachine specify the same tag name with different values, the
@@ -168,6 +192,14 @@ type AWSMachineSpec struct {
 	// +optional
 	NetworkInterfaceType NetworkInterfaceType `json:"networkInterfaceType,omitempty"`
 
+	// SecondaryNetworkInterfaces is a list of additional ENIs that are created and attached to the
+	// instance when it is launched, and deleted when it is terminated, for instance for multi-homed nodes.
+	// A public IP cannot be assigned to an instance with secondary network interfaces.
+	// +optional
+	// +listType=map
+	// +listMapKey=deviceIndex
+	SecondaryNetworkInterfaces []SecondaryNetworkInterface `json:"secondaryNetworkInterfaces,omitempty"`
+
 	// UncompressedUserData specify whether the user data is gzip-compressed before it is sent to ec2 instance.
 	// cloud-init has built-in support for gzip-compressed user data
 	// user data stored in aws secret manager is always gzip-compressed.
@@ -209,6 +241,17 @@ type AWSMachineSpec struct {
 	// +optional
 	PrivateDNSName *PrivateDNSName `json:"privateDnsName,omitempty"`
 
+	// HostnameTemplate is a Go template used to render the hostname of the instance, which
+	// is also used as the node name when the bootstrap provider does not pin it explicitly.
+	// The template may reference {{ .MachineName }} and {{ .ClusterName }}, e.g. "{{ .MachineName }}-aws",
+	// and must render to a valid RFC 1123 DNS label. Rendered hostnames longer than 63 characters are
+	// truncated and suffixed with a hash of the full hostname.
+	// It is only supported for cloud-init user data stored in AWS Secrets Manager or SSM Parameter Store.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +optional
+	HostnameTemplate *string `json:"hostnameTemplate,omitempty"`
+
 	// CapacityReservationID specifies the target Capacity Reservation into which the instance should be launched.
 	// +optional
 	CapacityReservationID *string `json:"capacityReservationId,omitempty"`
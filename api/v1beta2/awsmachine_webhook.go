@@ -17,11 +17,15 @@ limitations under the License.
 package v1beta2
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net"
 	"net/url"
+	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -40,24 +44,80 @@ import (
 // log is for logging in this package.
 var log = ctrl.Log.WithName("awsmachine-resource")
 
-func (r *AWSMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (r *AWSMachineWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
-		For(r).
+		For(&AWSMachine{}).
+		WithDefaulter(r).
 		Complete()
 }
 
+// AWSMachineWebhook implements a custom defaulting webhook for AWSMachine, which applies the
+// defaults the controller is configured with.
+// +kubebuilder:object:generate=false
+type AWSMachineWebhook struct {
+	// MachineDefaults are the defaults applied to new AWSMachines.
+	MachineDefaults MachineDefaults
+}
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachine,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,versions=v1beta2,name=validation.awsmachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachine,mutating=true,failurePolicy=fail,groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,versions=v1beta2,name=mawsmachine.kb.io,name=mutation.awsmachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 var (
-	_ webhook.Validator = &AWSMachine{}
-	_ webhook.Defaulter = &AWSMachine{}
+	_ webhook.Validator       = &AWSMachine{}
+	_ webhook.Defaulter       = &AWSMachine{}
+	_ webhook.CustomDefaulter = &AWSMachineWebhook{}
 )
 
+// minRootVolumeSize is the minimum size (in Gi) of a root volume, see Volume.Size.
+const minRootVolumeSize = 8
+
+// instanceTypeRegex matches EC2 instance type names, e.g. m5.large or u-6tb1.metal.
+var instanceTypeRegex = regexp.MustCompile(`^[a-z0-9-]+\.[a-z0-9-]+$`)
+
+// MachineDefaults holds the defaults the AWSMachine defaulting webhook applies to
+// new AWSMachines which leave the corresponding fields unset.
+// +kubebuilder:object:generate=false
+type MachineDefaults struct {
+	// InstanceType is the instance type of AWSMachines without one.
+	InstanceType string
+	// RootVolumeSize is the size (in Gi) of the root volume of AWSMachines without one.
+	RootVolumeSize int64
+}
+
+// Validate validates the defaults applied to new AWSMachines.
+func (d MachineDefaults) Validate() error {
+	if d.InstanceType != "" && !instanceTypeRegex.MatchString(d.InstanceType) {
+		return errors.Errorf("invalid default instance type %q", d.InstanceType)
+	}
+	if d.RootVolumeSize != 0 && d.RootVolumeSize < minRootVolumeSize {
+		return errors.Errorf("invalid default root volume size %d: must be at least %d", d.RootVolumeSize, minRootVolumeSize)
+	}
+	return nil
+}
+
+func (d MachineDefaults) apply(spec *AWSMachineSpec) {
+	if spec.InstanceType == "" {
+		spec.InstanceType = d.InstanceType
+	}
+
+	if d.RootVolumeSize == 0 {
+		return
+	}
+	if spec.RootVolume == nil {
+		spec.RootVolume = &Volume{}
+	}
+	if spec.RootVolume.Size == 0 {
+		spec.RootVolume.Size = d.RootVolumeSize
+	}
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *AWSMachine) ValidateCreate() (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
+	if r.Spec.InstanceType == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "instanceType"), "must be set as no default instance type is configured"))
+	}
 	allErrs = append(allErrs, r.validateCloudInitSecret()...)
 	allErrs = append(allErrs, r.validateIgnitionAndCloudInit()...)
 	allErrs = append(allErrs, r.validateRootVolume()...)
@@ -67,6 +127,8 @@ func (r *AWSMachine) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetworkElasticIPPool()...)
 	allErrs = append(allErrs, r.validateInstanceMarketType()...)
+	allErrs = append(allErrs, validateSecondaryNetworkInterfaces(&r.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateHostnameTemplate(&r.Spec, field.NewPath("spec"))...)
 
 	return nil, aggregateObjErrors(r.GroupVersionKind().GroupKind(), r.Name, allErrs)
 }
@@ -310,6 +372,66 @@ func (r *AWSMachine) validateIgnitionTLS() field.ErrorList {
 	return allErrs
 }
 
+// validateHostnameTemplate checks that the hostname template is well formed and is only used where
+// the hostname can be injected into the cloud-init user data. The template is rendered with placeholder
+// names as the name of the owning Machine is not known at admission time, hostnames exceeding the length
+// of a DNS label due to long machine names are truncated when the instance is created.
+func validateHostnameTemplate(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.HostnameTemplate == nil {
+		return allErrs
+	}
+
+	templatePath := fldPath.Child("hostnameTemplate")
+	if spec.Ignition != nil {
+		allErrs = append(allErrs, field.Forbidden(templatePath, fmt.Sprintf("cannot be set if %s is set", fldPath.Child("ignition"))))
+	}
+	if spec.CloudInit.InsecureSkipSecretsManager {
+		allErrs = append(allErrs, field.Forbidden(templatePath, fmt.Sprintf("cannot be set if %s is true", fldPath.Child("cloudInit", "insecureSkipSecretsManager"))))
+	}
+
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(*spec.HostnameTemplate)
+	if err != nil {
+		return append(allErrs, field.Invalid(templatePath, *spec.HostnameTemplate, fmt.Sprintf("failed to parse hostname template: %v", err)))
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{"MachineName": "machine", "ClusterName": "cluster"}); err != nil {
+		return append(allErrs, field.Invalid(templatePath, *spec.HostnameTemplate, fmt.Sprintf("failed to render hostname template: %v", err)))
+	}
+	if errs := validation.IsDNS1123Label(buf.String()); len(errs) > 0 {
+		allErrs = append(allErrs, field.Invalid(templatePath, *spec.HostnameTemplate, fmt.Sprintf("rendered hostname %q is not a valid node name: %s", buf.String(), strings.Join(errs, ", "))))
+	}
+
+	return allErrs
+}
+
+// validateSecondaryNetworkInterfaces checks that the device indexes of the secondary network interfaces
+// don't overlap with the ones of the other network interfaces of the instance.
+func validateSecondaryNetworkInterfaces(spec *AWSMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(spec.SecondaryNetworkInterfaces) == 0 {
+		return allErrs
+	}
+
+	interfacesPath := fldPath.Child("secondaryNetworkInterfaces")
+	if ptr.Deref(spec.PublicIP, false) {
+		allErrs = append(allErrs, field.Forbidden(interfacesPath, fmt.Sprintf("cannot be set if %s is true", fldPath.Child("publicIP"))))
+	}
+
+	// The primary network interface, or the network interfaces given by ID, use the first device indexes.
+	firstDeviceIndex := int64(max(1, len(spec.NetworkInterfaces)))
+	for i, eni := range spec.SecondaryNetworkInterfaces {
+		if eni.DeviceIndex < firstDeviceIndex {
+			allErrs = append(allErrs, field.Invalid(interfacesPath.Index(i).Child("deviceIndex"), eni.DeviceIndex,
+				fmt.Sprintf("must be at least %d, the device indexes before are used by the other network interfaces", firstDeviceIndex)))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSMachine) validateRootVolume() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -406,6 +528,23 @@ func (r *AWSMachine) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// Default implements webhook.CustomDefaulter and applies the configured defaults to new AWSMachines,
+// in addition to the defaults of AWSMachine.Default.
+func (r *AWSMachineWebhook) Default(_ context.Context, obj runtime.Object) error {
+	machine, ok := obj.(*AWSMachine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an AWSMachine but got a %T", obj))
+	}
+
+	// The spec of existing machines is immutable, so defaults configured
+	// after their creation must not be applied to them.
+	if machine.CreationTimestamp.IsZero() {
+		r.MachineDefaults.apply(&machine.Spec)
+	}
+	machine.Default()
+	return nil
+}
+
 // Default implements webhook.Defaulter such that an empty CloudInit will be defined with a default
 // SecureSecretsBackend as SecretBackendSecretsManager iff InsecureSkipSecretsManager is unset.
 func (r *AWSMachine) Default() {
@@ -32,13 +32,105 @@ import (
 )
 
 func TestMachineDefault(t *testing.T) {
-	machine := &AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+	machine := &AWSMachine{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}, Spec: AWSMachineSpec{InstanceType: "test"}}
 	t.Run("for AWSMachine", utildefaulting.DefaultValidateTest(machine))
 	machine.Default()
 	g := NewWithT(t)
 	g.Expect(machine.Spec.CloudInit.SecureSecretsBackend).To(Equal(SecretBackendSecretsManager))
 }
 
+func TestMachineDefaultWithMachineDefaults(t *testing.T) {
+	webhook := &AWSMachineWebhook{MachineDefaults: MachineDefaults{InstanceType: "m5.large", RootVolumeSize: 50}}
+
+	tests := []struct {
+		name         string
+		machine      *AWSMachine
+		instanceType string
+		rootVolume   *Volume
+	}{
+		{
+			name:         "unset fields are defaulted",
+			machine:      &AWSMachine{},
+			instanceType: "m5.large",
+			rootVolume:   &Volume{Size: 50},
+		},
+		{
+			name: "set fields are kept",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "t3.small",
+					RootVolume:   &Volume{Size: 20, Type: VolumeTypeGP3},
+				},
+			},
+			instanceType: "t3.small",
+			rootVolume:   &Volume{Size: 20, Type: VolumeTypeGP3},
+		},
+		{
+			name: "root volume size is defaulted if the root volume is set without size",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					RootVolume: &Volume{Type: VolumeTypeGP3},
+				},
+			},
+			instanceType: "m5.large",
+			rootVolume:   &Volume{Size: 50, Type: VolumeTypeGP3},
+		},
+		{
+			name: "existing machines are not defaulted",
+			machine: &AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+				Spec:       AWSMachineSpec{InstanceType: "t3.small"},
+			},
+			instanceType: "t3.small",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(webhook.Default(context.Background(), tt.machine)).To(Succeed())
+			g.Expect(tt.machine.Spec.InstanceType).To(Equal(tt.instanceType))
+			g.Expect(tt.machine.Spec.RootVolume).To(Equal(tt.rootVolume))
+		})
+	}
+}
+
+func TestMachineDefaultsValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults MachineDefaults
+		wantErr  bool
+	}{
+		{
+			name: "empty defaults are valid",
+		},
+		{
+			name:     "valid defaults",
+			defaults: MachineDefaults{InstanceType: "u-6tb1.metal", RootVolumeSize: 8},
+		},
+		{
+			name:     "invalid instance type",
+			defaults: MachineDefaults{InstanceType: "large"},
+			wantErr:  true,
+		},
+		{
+			name:     "root volume size below the minimum",
+			defaults: MachineDefaults{RootVolumeSize: 4},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.defaults.Validate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func TestAWSMachineCreate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -479,6 +571,101 @@ func TestAWSMachineCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "hostname template rendering a valid node name is accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "type",
+					HostnameTemplate: aws.String("{{ .MachineName }}-aws"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "hostname template rendering an invalid node name is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "type",
+					HostnameTemplate: aws.String("{{ .MachineName }}_aws"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hostname template referencing an unknown field is rejected",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "type",
+					HostnameTemplate: aws.String("{{ .Namespace }}"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hostname template cannot be used with insecureSkipSecretsManager",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "type",
+					HostnameTemplate: aws.String("{{ .MachineName }}"),
+					CloudInit: CloudInit{
+						InsecureSkipSecretsManager: true,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "hostname template cannot be used with ignition",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:     "type",
+					HostnameTemplate: aws.String("{{ .MachineName }}"),
+					Ignition: &Ignition{
+						Version: "3.0",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secondary network interfaces are accepted",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "type",
+					SecondaryNetworkInterfaces: []SecondaryNetworkInterface{
+						{DeviceIndex: 1, SubnetID: "subnet-1"},
+						{DeviceIndex: 2},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "secondary network interfaces cannot overlap the network interfaces",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType:      "type",
+					NetworkInterfaces: []string{"eni-1", "eni-2"},
+					SecondaryNetworkInterfaces: []SecondaryNetworkInterface{
+						{DeviceIndex: 1},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secondary network interfaces cannot be used with a public IP",
+			machine: &AWSMachine{
+				Spec: AWSMachineSpec{
+					InstanceType: "type",
+					PublicIP:     aws.Bool(true),
+					SecondaryNetworkInterfaces: []SecondaryNetworkInterface{
+						{DeviceIndex: 1},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -42,7 +42,10 @@ func (r *AWSMachineTemplateWebhook) SetupWebhookWithManager(mgr ctrl.Manager) er
 // AWSMachineTemplateWebhook implements a custom validation webhook for AWSMachineTemplate.
 // Note: we use a custom validator to access the request context for SSA of AWSMachineTemplate.
 // +kubebuilder:object:generate=false
-type AWSMachineTemplateWebhook struct{}
+type AWSMachineTemplateWebhook struct {
+	// MachineDefaults are the defaults applied to the AWSMachines created from templates.
+	MachineDefaults MachineDefaults
+}
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-awsmachinetemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=awsmachinetemplates,versions=v1beta2,name=validation.awsmachinetemplate.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
@@ -197,6 +200,10 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "providerID"), "cannot be set in templates"))
 	}
 
+	if spec.InstanceType == "" && r.MachineDefaults.InstanceType == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "template", "spec", "instanceType"), "must be set as no default instance type is configured"))
+	}
+
 	allErrs = append(allErrs, obj.validateCloudInitSecret()...)
 	allErrs = append(allErrs, obj.validateIgnitionAndCloudInit()...)
 	allErrs = append(allErrs, obj.validateRootVolume()...)
@@ -204,6 +211,8 @@ func (r *AWSMachineTemplateWebhook) ValidateCreate(_ context.Context, raw runtim
 	allErrs = append(allErrs, obj.validateSSHKeyName()...)
 	allErrs = append(allErrs, obj.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, obj.Spec.Template.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, validateHostnameTemplate(&spec, field.NewPath("spec", "template", "spec"))...)
+	allErrs = append(allErrs, validateSecondaryNetworkInterfaces(&spec, field.NewPath("spec", "template", "spec"))...)
 
 	return nil, aggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, allErrs)
 }
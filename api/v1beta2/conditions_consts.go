@@ -51,6 +51,9 @@ const (
 	SubnetsReadyCondition clusterv1.ConditionType = "SubnetsReady"
 	// SubnetsReconciliationFailedReason used to report failures while reconciling subnets.
 	SubnetsReconciliationFailedReason = "SubnetsReconciliationFailed"
+	// ExternallyManagedNetworkInvalidReason used when the VPC or subnets of an externally managed network don't
+	// satisfy the requirements of the cluster.
+	ExternallyManagedNetworkInvalidReason = "ExternallyManagedNetworkInvalid"
 )
 
 const (
@@ -87,6 +90,38 @@ const (
 	NatGatewaysReconciliationFailedReason = "NatGatewaysReconciliationFailed"
 )
 
+const (
+	// NatInstancesReadyCondition reports successful reconciliation of the NAT instances used instead of NAT gateways.
+	// Only applicable to managed clusters.
+	NatInstancesReadyCondition clusterv1.ConditionType = "NatInstancesReady"
+	// NatInstancesReconciliationFailedReason used when any errors occur during reconciliation of NAT instances.
+	NatInstancesReconciliationFailedReason = "NatInstancesReconciliationFailed"
+)
+
+const (
+	// VpcFlowLogsReadyCondition reports successful reconciliation of the flow log of the VPC.
+	// Only applicable to managed clusters.
+	VpcFlowLogsReadyCondition clusterv1.ConditionType = "VpcFlowLogsReady"
+	// VpcFlowLogsReconciliationFailedReason used when any errors occur during reconciliation of the flow log of the VPC.
+	VpcFlowLogsReconciliationFailedReason = "VpcFlowLogsReconciliationFailed"
+)
+
+const (
+	// DhcpOptionsReadyCondition reports successful reconciliation of the DHCP options set of the VPC.
+	// Only applicable to managed clusters.
+	DhcpOptionsReadyCondition clusterv1.ConditionType = "DhcpOptionsReady"
+	// DhcpOptionsReconciliationFailedReason used when any errors occur during reconciliation of the DHCP options set of the VPC.
+	DhcpOptionsReconciliationFailedReason = "DhcpOptionsReconciliationFailed"
+)
+
+const (
+	// NetworkACLsReadyCondition reports successful reconciliation of the network ACLs of the subnets.
+	// Only applicable to managed clusters.
+	NetworkACLsReadyCondition clusterv1.ConditionType = "NetworkACLsReady"
+	// NetworkACLsReconciliationFailedReason used when any errors occur during reconciliation of the network ACLs.
+	NetworkACLsReconciliationFailedReason = "NetworkACLsReconciliationFailed"
+)
+
 const (
 	// RouteTablesReadyCondition reports successful reconciliation of route tables.
 	// Only applicable to managed clusters.
@@ -103,6 +138,30 @@ const (
 	VpcEndpointsReconciliationFailedReason = "VpcEndpointsReconciliationFailed"
 )
 
+const (
+	// TransitGatewayAttachmentReadyCondition reports successful reconciliation of the transit gateway attachment.
+	// Only applicable to managed clusters.
+	TransitGatewayAttachmentReadyCondition clusterv1.ConditionType = "TransitGatewayAttachmentReady"
+	// TransitGatewayAttachmentReconciliationFailedReason used when any errors occur during reconciliation of the
+	// transit gateway attachment.
+	TransitGatewayAttachmentReconciliationFailedReason = "TransitGatewayAttachmentReconciliationFailed"
+	// TransitGatewayAttachmentNotAvailableReason used when the transit gateway attachment is not available yet,
+	// for example while it waits to be accepted by the owner of the transit gateway.
+	TransitGatewayAttachmentNotAvailableReason = "TransitGatewayAttachmentNotAvailable"
+)
+
+const (
+	// VpcPeeringConnectionsReadyCondition reports successful reconciliation of the VPC peering connections.
+	// Only applicable to managed clusters.
+	VpcPeeringConnectionsReadyCondition clusterv1.ConditionType = "VpcPeeringConnectionsReady"
+	// VpcPeeringConnectionsReconciliationFailedReason used when any errors occur during reconciliation of the
+	// VPC peering connections.
+	VpcPeeringConnectionsReconciliationFailedReason = "VpcPeeringConnectionsReconciliationFailed"
+	// VpcPeeringConnectionsNotActiveReason used when a VPC peering connection is not active yet, for example
+	// while it waits to be accepted by the owner of the peer VPC.
+	VpcPeeringConnectionsNotActiveReason = "VpcPeeringConnectionsNotActive"
+)
+
 const (
 	// SecondaryCidrsReadyCondition reports successful reconciliation of secondary CIDR blocks.
 	// Only applicable to managed clusters.
@@ -142,6 +201,26 @@ const (
 	LoadBalancerFailedReason = "LoadBalancerFailed"
 )
 
+const (
+	// APIServerPrivateDNSRecordReadyCondition reports on whether the record of the API server in a private
+	// hosted zone was successfully reconciled.
+	APIServerPrivateDNSRecordReadyCondition clusterv1.ConditionType = "APIServerPrivateDNSRecordReady"
+	// APIServerPrivateDNSRecordFailedReason used when an error occurs during the reconciliation of the record.
+	APIServerPrivateDNSRecordFailedReason = "APIServerPrivateDNSRecordFailed"
+)
+
+const (
+	// ControlPlaneEndpointDNSReadyCondition reports on whether the alias record of the custom control plane
+	// endpoint DNS name was successfully reconciled and has propagated.
+	ControlPlaneEndpointDNSReadyCondition clusterv1.ConditionType = "ControlPlaneEndpointDNSReady"
+	// ControlPlaneEndpointDNSFailedReason used when an error occurs during the reconciliation of the alias record.
+	ControlPlaneEndpointDNSFailedReason = "ControlPlaneEndpointDNSFailed"
+	// WaitForCanonicalHostedZoneIDReason used while waiting for the hosted zone ID of the control plane load balancer.
+	WaitForCanonicalHostedZoneIDReason = "WaitForCanonicalHostedZoneID"
+	// WaitForControlPlaneEndpointDNSPropagationReason used while waiting for a change of the alias record to propagate.
+	WaitForControlPlaneEndpointDNSPropagationReason = "WaitForControlPlaneEndpointDNSPropagation"
+)
+
 const (
 	// InstanceReadyCondition reports on current status of the EC2 instance. Ready indicates the instance is in a Running state.
 	InstanceReadyCondition clusterv1.ConditionType = "InstanceReady"
@@ -158,12 +237,27 @@ const (
 	InstanceProvisionStartedReason = "InstanceProvisionStarted"
 	// InstanceProvisionFailedReason used for failures during instance provisioning.
 	InstanceProvisionFailedReason = "InstanceProvisionFailed"
+	// WaitingForIAMInstanceProfileReason used when the instance cannot be launched yet because the IAM instance profile
+	// has not propagated.
+	WaitingForIAMInstanceProfileReason = "WaitingForIAMInstanceProfile"
+	// InstanceAdoptionFailedReason used when a pre-existing instance referenced by the AWSMachine cannot be adopted.
+	InstanceAdoptionFailedReason = "InstanceAdoptionFailed"
 	// WaitingForClusterInfrastructureReason used when machine is waiting for cluster infrastructure to be ready before proceeding.
 	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
 	// WaitingForBootstrapDataReason used when machine is waiting for bootstrap data to be ready before proceeding.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
 )
 
+const (
+	// ControlPlaneAZSpreadCondition reports whether a control plane instance could be placed in an availability zone
+	// without another control plane instance of the cluster. Only set if the AWSCluster enforces a strict spread.
+	ControlPlaneAZSpreadCondition clusterv1.ConditionType = "ControlPlaneAZSpread"
+
+	// AvailabilityZoneOccupiedReason used when the availability zone of a control plane machine already hosts another
+	// control plane instance and no other zone can be used.
+	AvailabilityZoneOccupiedReason = "AvailabilityZoneOccupied"
+)
+
 const (
 	// SecurityGroupsReadyCondition indicates the security groups are up to date on the AWSMachine.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
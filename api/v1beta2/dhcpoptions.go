@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// AmazonProvidedDNS is the domain name server value to resolve names through the Route 53 Resolver of the VPC.
+const AmazonProvidedDNS = "AmazonProvidedDNS"
+
+// ValidateDHCPOptions validates the DHCP options set of the VPC.
+func (n *NetworkSpec) ValidateDHCPOptions() []*field.Error {
+	var errs field.ErrorList
+
+	dhcpOptions := n.VPC.DHCPOptions
+	if dhcpOptions == nil {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network", "vpc", "dhcpOptions")
+	if n.VPC.ID != "" {
+		return append(errs, field.Forbidden(fldPath, "can only be set for a managed VPC"))
+	}
+
+	if dhcpOptions.DomainName == "" && len(dhcpOptions.DomainNameServers) == 0 && len(dhcpOptions.NTPServers) == 0 {
+		errs = append(errs, field.Required(fldPath, "at least one of domainName, domainNameServers or ntpServers must be set"))
+	}
+
+	for i, server := range dhcpOptions.DomainNameServers {
+		if server != AmazonProvidedDNS && net.ParseIP(server) == nil {
+			errs = append(errs, field.Invalid(fldPath.Child("domainNameServers").Index(i), server, "must be an IP address or "+AmazonProvidedDNS))
+		}
+	}
+
+	for i, server := range dhcpOptions.NTPServers {
+		if net.ParseIP(server) == nil {
+			errs = append(errs, field.Invalid(fldPath.Child("ntpServers").Index(i), server, "must be an IP address"))
+		}
+	}
+
+	return errs
+}
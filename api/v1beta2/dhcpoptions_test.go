@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateDHCPOptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no dhcp options",
+		},
+		{
+			name: "domain name and servers",
+			network: NetworkSpec{VPC: VPCSpec{DHCPOptions: &DHCPOptionsSpec{
+				DomainName:        "corp.example.com",
+				DomainNameServers: []string{"10.0.0.2", AmazonProvidedDNS},
+				NTPServers:        []string{"169.254.169.123"},
+			}}},
+		},
+		{
+			name: "dhcp options of an unmanaged vpc",
+			network: NetworkSpec{VPC: VPCSpec{ID: "vpc-exists", DHCPOptions: &DHCPOptionsSpec{
+				DomainName: "corp.example.com",
+			}}},
+			expectedFields: []string{"spec.network.vpc.dhcpOptions"},
+		},
+		{
+			name:           "empty dhcp options",
+			network:        NetworkSpec{VPC: VPCSpec{DHCPOptions: &DHCPOptionsSpec{}}},
+			expectedFields: []string{"spec.network.vpc.dhcpOptions"},
+		},
+		{
+			name: "servers which are not ip addresses",
+			network: NetworkSpec{VPC: VPCSpec{DHCPOptions: &DHCPOptionsSpec{
+				DomainNameServers: []string{"10.0.0.2", "dns.example.com"},
+				NTPServers:        []string{AmazonProvidedDNS},
+			}}},
+			expectedFields: []string{"spec.network.vpc.dhcpOptions.domainNameServers[1]", "spec.network.vpc.dhcpOptions.ntpServers[0]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateDHCPOptions() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateFlowLogs validates the flow log of the VPC.
+func (n *NetworkSpec) ValidateFlowLogs() []*field.Error {
+	var errs field.ErrorList
+
+	flowLogs := n.VPC.FlowLogs
+	if flowLogs == nil {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network", "vpc", "flowLogs")
+	if n.VPC.ID != "" {
+		return append(errs, field.Forbidden(fldPath, "can only be set for a managed VPC"))
+	}
+
+	switch flowLogs.DestinationType {
+	case FlowLogsDestinationTypeS3:
+		if !strings.HasPrefix(flowLogs.Destination, "arn:") || !strings.Contains(flowLogs.Destination, ":s3:::") {
+			errs = append(errs, field.Invalid(fldPath.Child("destination"), flowLogs.Destination, "must be the ARN of an S3 bucket"))
+		}
+		if flowLogs.DeliverLogsPermissionARN != "" {
+			errs = append(errs, field.Forbidden(fldPath.Child("deliverLogsPermissionArn"), "is not supported for the s3 destination type"))
+		}
+	default:
+		if !strings.HasPrefix(flowLogs.Destination, "arn:") || !strings.Contains(flowLogs.Destination, ":logs:") {
+			errs = append(errs, field.Invalid(fldPath.Child("destination"), flowLogs.Destination, "must be the ARN of a CloudWatch Logs log group"))
+		}
+		if flowLogs.DeliverLogsPermissionARN == "" {
+			errs = append(errs, field.Required(fldPath.Child("deliverLogsPermissionArn"), "is required for the cloud-watch-logs destination type"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateFlowLogs(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no flow logs",
+		},
+		{
+			name: "cloudwatch logs destination",
+			network: NetworkSpec{VPC: VPCSpec{FlowLogs: &VPCFlowLogsSpec{
+				DestinationType:          FlowLogsDestinationTypeCloudWatchLogs,
+				Destination:              "arn:aws:logs:us-east-1:123456789012:log-group:flow-logs",
+				DeliverLogsPermissionARN: "arn:aws:iam::123456789012:role/flow-logs",
+			}}},
+		},
+		{
+			name: "s3 destination",
+			network: NetworkSpec{VPC: VPCSpec{FlowLogs: &VPCFlowLogsSpec{
+				DestinationType: FlowLogsDestinationTypeS3,
+				Destination:     "arn:aws:s3:::flow-logs/cluster/",
+			}}},
+		},
+		{
+			name: "flow logs of an unmanaged vpc",
+			network: NetworkSpec{VPC: VPCSpec{ID: "vpc-exists", FlowLogs: &VPCFlowLogsSpec{
+				DestinationType: FlowLogsDestinationTypeS3,
+				Destination:     "arn:aws:s3:::flow-logs",
+			}}},
+			expectedFields: []string{"spec.network.vpc.flowLogs"},
+		},
+		{
+			name: "cloudwatch logs destination without role",
+			network: NetworkSpec{VPC: VPCSpec{FlowLogs: &VPCFlowLogsSpec{
+				Destination: "flow-logs",
+			}}},
+			expectedFields: []string{"spec.network.vpc.flowLogs.destination", "spec.network.vpc.flowLogs.deliverLogsPermissionArn"},
+		},
+		{
+			name: "s3 destination with role",
+			network: NetworkSpec{VPC: VPCSpec{FlowLogs: &VPCFlowLogsSpec{
+				DestinationType:          FlowLogsDestinationTypeS3,
+				Destination:              "arn:aws:logs:us-east-1:123456789012:log-group:flow-logs",
+				DeliverLogsPermissionARN: "arn:aws:iam::123456789012:role/flow-logs",
+			}}},
+			expectedFields: []string{"spec.network.vpc.flowLogs.destination", "spec.network.vpc.flowLogs.deliverLogsPermissionArn"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateFlowLogs() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
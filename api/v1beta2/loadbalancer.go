@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// IsExisting returns true if the load balancer is an existing load balancer referenced by its ARN,
+// whose lifecycle is not managed by CAPA.
+func (l *AWSLoadBalancerSpec) IsExisting() bool {
+	return l != nil && l.ARN != nil
+}
+
+// ParseLoadBalancerARN returns the name and the type of the load balancer identified by the given ARN.
+// Classic load balancer ARNs have the resource loadbalancer/<name>, while network and application load
+// balancer ARNs have the resource loadbalancer/net/<name>/<id> and loadbalancer/app/<name>/<id>.
+func ParseLoadBalancerARN(lbARN string) (string, LoadBalancerType, error) {
+	parsed, err := arn.Parse(lbARN)
+	if err != nil {
+		return "", "", err
+	}
+	if parsed.Service != "elasticloadbalancing" || !strings.HasPrefix(parsed.Resource, "loadbalancer/") {
+		return "", "", fmt.Errorf("%q is not the ARN of a load balancer", lbARN)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(parsed.Resource, "loadbalancer/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] != "":
+		return parts[0], LoadBalancerTypeClassic, nil
+	case len(parts) == 3 && parts[0] == "net" && parts[1] != "":
+		return parts[1], LoadBalancerTypeNLB, nil
+	case len(parts) == 3 && parts[0] == "app" && parts[1] != "":
+		return parts[1], LoadBalancerTypeALB, nil
+	default:
+		return "", "", fmt.Errorf("%q is not the ARN of a classic, network or application load balancer", lbARN)
+	}
+}
+
+// ValidateARN validates the reference to an existing load balancer.
+func (l *AWSLoadBalancerSpec) ValidateARN(fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if !l.IsExisting() {
+		return errs
+	}
+
+	_, lbType, err := ParseLoadBalancerARN(*l.ARN)
+	if err != nil {
+		return append(errs, field.Invalid(fldPath.Child("arn"), *l.ARN, "must be the ARN of a classic, network or application load balancer"))
+	}
+	if l.LoadBalancerType != lbType {
+		errs = append(errs, field.Invalid(fldPath.Child("loadBalancerType"), l.LoadBalancerType, fmt.Sprintf("must be %s to match the type of the existing load balancer", lbType)))
+	}
+	if l.Name != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("name"), *l.Name, "cannot be set together with arn"))
+	}
+	// The listeners and attributes of an existing load balancer are left to the user.
+	if len(l.AdditionalListeners) > 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("additionalListeners"), l.AdditionalListeners, "cannot be set for an existing load balancer"))
+	}
+	if l.AccessLogs != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("accessLogs"), l.AccessLogs, "cannot be set for an existing load balancer"))
+	}
+	if len(l.AdditionalTags) > 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("additionalTags"), l.AdditionalTags, "cannot be set for an existing load balancer"))
+	}
+
+	return errs
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseLoadBalancerARN(t *testing.T) {
+	tests := []struct {
+		name         string
+		arn          string
+		expectedName string
+		expectedType LoadBalancerType
+		expectErr    bool
+	}{
+		{
+			name:         "classic load balancer",
+			arn:          "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/my-elb",
+			expectedName: "my-elb",
+			expectedType: LoadBalancerTypeClassic,
+		},
+		{
+			name:         "network load balancer",
+			arn:          "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/0123456789abcdef",
+			expectedName: "my-nlb",
+			expectedType: LoadBalancerTypeNLB,
+		},
+		{
+			name:         "application load balancer",
+			arn:          "arn:aws-us-gov:elasticloadbalancing:us-gov-west-1:123456789012:loadbalancer/app/my-alb/0123456789abcdef",
+			expectedName: "my-alb",
+			expectedType: LoadBalancerTypeALB,
+		},
+		{
+			name:      "gateway load balancer",
+			arn:       "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/gwy/my-gwlb/0123456789abcdef",
+			expectErr: true,
+		},
+		{
+			name:      "target group",
+			arn:       "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/0123456789abcdef",
+			expectErr: true,
+		},
+		{
+			name:      "not an arn",
+			arn:       "my-elb",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			name, lbType, err := ParseLoadBalancerARN(tc.arn)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(name).To(Equal(tc.expectedName))
+			g.Expect(lbType).To(Equal(tc.expectedType))
+		})
+	}
+}
+
+func TestAWSLoadBalancerSpecValidateARN(t *testing.T) {
+	nlbARN := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/0123456789abcdef"
+
+	tests := []struct {
+		name           string
+		lb             *AWSLoadBalancerSpec
+		expectedFields []string
+	}{
+		{
+			name: "no load balancer",
+		},
+		{
+			name: "load balancer created by capa",
+			lb:   &AWSLoadBalancerSpec{Name: ptr.To("my-nlb"), LoadBalancerType: LoadBalancerTypeNLB},
+		},
+		{
+			name: "existing load balancer",
+			lb:   &AWSLoadBalancerSpec{ARN: ptr.To(nlbARN), LoadBalancerType: LoadBalancerTypeNLB},
+		},
+		{
+			name:           "invalid arn",
+			lb:             &AWSLoadBalancerSpec{ARN: ptr.To("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-tg/0123"), LoadBalancerType: LoadBalancerTypeNLB},
+			expectedFields: []string{"spec.controlPlaneLoadBalancer.arn"},
+		},
+		{
+			name:           "type does not match the existing load balancer",
+			lb:             &AWSLoadBalancerSpec{ARN: ptr.To(nlbARN), LoadBalancerType: LoadBalancerTypeClassic},
+			expectedFields: []string{"spec.controlPlaneLoadBalancer.loadBalancerType"},
+		},
+		{
+			name: "configuration applied to the load balancer",
+			lb: &AWSLoadBalancerSpec{
+				ARN:                 ptr.To(nlbARN),
+				Name:                ptr.To("my-nlb"),
+				LoadBalancerType:    LoadBalancerTypeNLB,
+				AdditionalListeners: []AdditionalListenerSpec{{Port: 8132, Protocol: ELBProtocolTCP}},
+				AccessLogs:          &LoadBalancerAccessLogs{Bucket: "my-bucket"},
+				AdditionalTags:      Tags{"cost-center": "1234"},
+			},
+			expectedFields: []string{
+				"spec.controlPlaneLoadBalancer.name",
+				"spec.controlPlaneLoadBalancer.additionalListeners",
+				"spec.controlPlaneLoadBalancer.accessLogs",
+				"spec.controlPlaneLoadBalancer.additionalTags",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := tc.lb.ValidateARN(field.NewPath("spec", "controlPlaneLoadBalancer"))
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tc.expectedFields))
+		})
+	}
+}
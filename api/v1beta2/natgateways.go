@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var elasticIPAllocationIDPattern = regexp.MustCompile(`^eipalloc-[0-9a-f]+$`)
+
+// ValidateNatGatewayMode validates the NAT gateway mode of the VPC.
+func (n *NetworkSpec) ValidateNatGatewayMode() []*field.Error {
+	var errs field.ErrorList
+
+	if n.VPC.ID != "" && n.VPC.NatGatewayMode != nil {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "network", "vpc", "natGatewayMode"),
+			"can only be set for a managed VPC, the NAT gateways of an unmanaged VPC are never modified"))
+	}
+
+	return errs
+}
+
+// ValidateNatGatewayElasticIPAllocationIDs validates the pre-allocated Elastic IPs of the NAT gateways.
+func (n *NetworkSpec) ValidateNatGatewayElasticIPAllocationIDs() []*field.Error {
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec", "network", "vpc", "natGatewayElasticIpAllocationIds")
+	if n.VPC.ID != "" && len(n.VPC.NatGatewayElasticIPAllocationIDs) > 0 {
+		return append(errs, field.Forbidden(fldPath,
+			"can only be set for a managed VPC, the NAT gateways of an unmanaged VPC are never modified"))
+	}
+
+	seen := make(map[string]bool)
+	for i, id := range n.VPC.NatGatewayElasticIPAllocationIDs {
+		if !elasticIPAllocationIDPattern.MatchString(id) {
+			errs = append(errs, field.Invalid(fldPath.Index(i), id, "must be an Elastic IP allocation ID starting with 'eipalloc-'"))
+			continue
+		}
+		if seen[id] {
+			errs = append(errs, field.Duplicate(fldPath.Index(i), id))
+		}
+		seen[id] = true
+	}
+
+	return errs
+}
+
+// ValidateNatInstance validates the NAT instances of the VPC.
+func (n *NetworkSpec) ValidateNatInstance() []*field.Error {
+	var errs field.ErrorList
+
+	if !n.VPC.IsNatInstanceEnabled() {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network", "vpc", "natInstance")
+	if n.VPC.ID != "" {
+		errs = append(errs, field.Forbidden(fldPath,
+			"can only be set for a managed VPC, the NAT gateways of an unmanaged VPC are never modified"))
+	}
+	if len(n.VPC.NatGatewayElasticIPAllocationIDs) > 0 {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "network", "vpc", "natGatewayElasticIpAllocationIds"),
+			"cannot be set along with natInstance, NAT instances use the public IP assigned at launch"))
+	}
+
+	return errs
+}
+
+// ValidateNatInstanceUpdate validates the changes to the NAT instances of the VPC.
+func (n *NetworkSpec) ValidateNatInstanceUpdate(old *NetworkSpec) []*field.Error {
+	var errs field.ErrorList
+
+	if n.VPC.IsNatInstanceEnabled() != old.VPC.IsNatInstanceEnabled() {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "network", "vpc", "natInstance"),
+			"cannot be added or removed after creation"))
+	}
+
+	return errs
+}
+
+// ValidateNatGatewayModeUpdate validates the changes to the NAT gateway mode of the VPC.
+func (n *NetworkSpec) ValidateNatGatewayModeUpdate(old *NetworkSpec) []*field.Error {
+	var errs field.ErrorList
+
+	if n.VPC.IsSingleNatGateway() != old.VPC.IsSingleNatGateway() {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "network", "vpc", "natGatewayMode"),
+			n.VPC.NatGatewayMode, "field is immutable"))
+	}
+
+	return errs
+}
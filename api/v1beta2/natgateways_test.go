@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateNatGatewayMode(t *testing.T) {
+	single := NatGatewayModeSingle
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "single nat gateway in a managed vpc",
+			network: NetworkSpec{VPC: VPCSpec{NatGatewayMode: &single}},
+		},
+		{
+			name:    "unmanaged vpc without nat gateway mode",
+			network: NetworkSpec{VPC: VPCSpec{ID: "vpc-exists"}},
+		},
+		{
+			name:           "single nat gateway in an unmanaged vpc",
+			network:        NetworkSpec{VPC: VPCSpec{ID: "vpc-exists", NatGatewayMode: &single}},
+			expectedFields: []string{"spec.network.vpc.natGatewayMode"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateNatGatewayMode() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateNatGatewayElasticIPAllocationIDs(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "elastic ips in a managed vpc",
+			network: NetworkSpec{VPC: VPCSpec{NatGatewayElasticIPAllocationIDs: []string{"eipalloc-0a1", "eipalloc-0b2"}}},
+		},
+		{
+			name:           "elastic ips in an unmanaged vpc",
+			network:        NetworkSpec{VPC: VPCSpec{ID: "vpc-exists", NatGatewayElasticIPAllocationIDs: []string{"eipalloc-0a1"}}},
+			expectedFields: []string{"spec.network.vpc.natGatewayElasticIpAllocationIds"},
+		},
+		{
+			name:    "invalid and duplicate allocation ids",
+			network: NetworkSpec{VPC: VPCSpec{NatGatewayElasticIPAllocationIDs: []string{"eipalloc-0a1", "203.0.113.10", "eipalloc-0a1"}}},
+			expectedFields: []string{
+				"spec.network.vpc.natGatewayElasticIpAllocationIds[1]",
+				"spec.network.vpc.natGatewayElasticIpAllocationIds[2]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateNatGatewayElasticIPAllocationIDs() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateNatInstance(t *testing.T) {
+	natInstance := &NatInstanceSpec{AMI: "ami-0a1", InstanceType: "t3.micro"}
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "nat instances in a managed vpc",
+			network: NetworkSpec{VPC: VPCSpec{NatInstance: natInstance}},
+		},
+		{
+			name:           "nat instances in an unmanaged vpc",
+			network:        NetworkSpec{VPC: VPCSpec{ID: "vpc-exists", NatInstance: natInstance}},
+			expectedFields: []string{"spec.network.vpc.natInstance"},
+		},
+		{
+			name:           "nat instances with pre-allocated elastic ips",
+			network:        NetworkSpec{VPC: VPCSpec{NatInstance: natInstance, NatGatewayElasticIPAllocationIDs: []string{"eipalloc-0a1"}}},
+			expectedFields: []string{"spec.network.vpc.natGatewayElasticIpAllocationIds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateNatInstance() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateNatInstanceUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		old         NetworkSpec
+		network     NetworkSpec
+		expectError bool
+	}{
+		{
+			name:    "nat instance ami updated",
+			old:     NetworkSpec{VPC: VPCSpec{NatInstance: &NatInstanceSpec{AMI: "ami-0a1"}}},
+			network: NetworkSpec{VPC: VPCSpec{NatInstance: &NatInstanceSpec{AMI: "ami-0b2"}}},
+		},
+		{
+			name:        "nat instances added",
+			old:         NetworkSpec{},
+			network:     NetworkSpec{VPC: VPCSpec{NatInstance: &NatInstanceSpec{AMI: "ami-0a1"}}},
+			expectError: true,
+		},
+		{
+			name:        "nat instances removed",
+			old:         NetworkSpec{VPC: VPCSpec{NatInstance: &NatInstanceSpec{AMI: "ami-0a1"}}},
+			network:     NetworkSpec{},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := tt.network.ValidateNatInstanceUpdate(&tt.old)
+			if tt.expectError {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestNetworkSpecValidateNatGatewayModeUpdate(t *testing.T) {
+	single := NatGatewayModeSingle
+	perAZ := NatGatewayModePerAvailabilityZone
+	tests := []struct {
+		name        string
+		old         NetworkSpec
+		network     NetworkSpec
+		expectError bool
+	}{
+		{
+			name:    "unchanged single nat gateway",
+			old:     NetworkSpec{VPC: VPCSpec{NatGatewayMode: &single}},
+			network: NetworkSpec{VPC: VPCSpec{NatGatewayMode: &single}},
+		},
+		{
+			name:    "defaulted mode set explicitly",
+			old:     NetworkSpec{},
+			network: NetworkSpec{VPC: VPCSpec{NatGatewayMode: &perAZ}},
+		},
+		{
+			name:        "switch to single nat gateway",
+			old:         NetworkSpec{},
+			network:     NetworkSpec{VPC: VPCSpec{NatGatewayMode: &single}},
+			expectError: true,
+		},
+		{
+			name:        "switch to one nat gateway per availability zone",
+			old:         NetworkSpec{VPC: VPCSpec{NatGatewayMode: &single}},
+			network:     NetworkSpec{VPC: VPCSpec{NatGatewayMode: &perAZ}},
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := tt.network.ValidateNatGatewayModeUpdate(&tt.old)
+			if tt.expectError {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -63,6 +63,17 @@ type NetworkStatus struct {
 
 	// NatGatewaysIPs contains the public IPs of the NAT Gateways
 	NatGatewaysIPs []string `json:"natGatewaysIPs,omitempty"`
+
+	// ReplacedAPIServerELB is the api server load balancer that was replaced after a change of the scheme
+	// of the control plane load balancer. It's deleted once the
+	// aws.cluster.x-k8s.io/allow-control-plane-load-balancer-scheme-change annotation is removed.
+	// +optional
+	ReplacedAPIServerELB *LoadBalancer `json:"replacedApiServerElb,omitempty"`
+
+	// ControlPlaneEndpointDNSChangeID is the ID of the Route53 change of the alias record of the custom
+	// control plane endpoint DNS name that hasn't propagated yet.
+	// +optional
+	ControlPlaneEndpointDNSChangeID string `json:"controlPlaneEndpointDNSChangeID,omitempty"`
 }
 
 // ELBScheme defines the scheme of a load balancer.
@@ -153,6 +164,20 @@ type TargetGroupHealthCheckAPISpec struct {
 	// +kubebuilder:validation:Maximum=10
 	// +optional
 	UnhealthyThresholdCount *int64 `json:"unhealthyThresholdCount,omitempty"`
+
+	// Path is the destination for health checks of the API server targets, for example /readyz or /healthz.
+	// It can only be set when the load balancer HealthCheckProtocol is HTTP or HTTPS. Defaults to /readyz.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Port is the port the load balancer uses when performing health checks on the API server targets.
+	// Defaults to the API server port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int64 `json:"port,omitempty"`
 }
 
 // TargetGroupHealthCheckAdditionalSpec defines the optional health check settings for the additional target groups.
@@ -219,6 +244,12 @@ var (
 	LoadBalancerAttributeIdleTimeTimeoutSeconds = "idle_timeout.timeout_seconds"
 	// LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds defines the default idle timeout in seconds.
 	LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds = "60"
+	// LoadBalancerAttributeAccessLogsS3Enabled defines the attribute key for enabling the access logs.
+	LoadBalancerAttributeAccessLogsS3Enabled = "access_logs.s3.enabled"
+	// LoadBalancerAttributeAccessLogsS3Bucket defines the attribute key for the access logs S3 bucket.
+	LoadBalancerAttributeAccessLogsS3Bucket = "access_logs.s3.bucket"
+	// LoadBalancerAttributeAccessLogsS3Prefix defines the attribute key for the access logs S3 prefix.
+	LoadBalancerAttributeAccessLogsS3Prefix = "access_logs.s3.prefix"
 )
 
 // TargetGroupSpec specifies target group settings for a given listener.
@@ -289,6 +320,11 @@ type LoadBalancer struct {
 	// LoadBalancerType sets the type for a load balancer. The default type is classic.
 	// +kubebuilder:validation:Enum:=classic;elb;alb;nlb
 	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// CanonicalHostedZoneID is the ID of the Route53 hosted zone of the load balancer, used as the target
+	// of alias records pointing at it.
+	// +optional
+	CanonicalHostedZoneID string `json:"canonicalHostedZoneId,omitempty"`
 }
 
 // IsUnmanaged returns true if the Classic ELB is unmanaged.
@@ -310,6 +346,11 @@ type ClassicELBAttributes struct {
 	// CrossZoneLoadBalancing enables the classic load balancer load balancing.
 	// +optional
 	CrossZoneLoadBalancing bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	// AccessLogs is the access logs configuration of the classic load balancer.
+	// It is nil when the access logs are disabled.
+	// +optional
+	AccessLogs *LoadBalancerAccessLogs `json:"accessLogs,omitempty"`
 }
 
 // ClassicELBListener defines an AWS classic load balancer listener.
@@ -331,6 +372,19 @@ type ClassicELBHealthCheck struct {
 
 // NetworkSpec encapsulates all things related to AWS network.
 type NetworkSpec struct {
+	// Mode specifies whether the network of the cluster is managed by CAPA.
+	// Managed - CAPA creates the VPC and its resources, or adopts an existing VPC and creates the security
+	// groups and tags of the cluster in it.
+	// ExternallyManaged - the network is owned by another party and CAPA doesn't create, tag or modify any
+	// network resource: no security groups, routes, NAT or internet gateways. CAPA only validates that the
+	// referenced VPC and subnets satisfy the requirements of the cluster, and populates the status and
+	// failure domains of the cluster from them. The VPC and subnets have to be referenced by ID, and
+	// the security groups of the cluster have to be provided through SecurityGroupOverrides.
+	// Defaults to Managed. Cannot be changed after creation.
+	// +optional
+	// +kubebuilder:validation:Enum=Managed;ExternallyManaged
+	Mode *NetworkMode `json:"mode,omitempty"`
+
 	// VPC configuration.
 	// +optional
 	VPC VPCSpec `json:"vpc,omitempty"`
@@ -348,6 +402,13 @@ type NetworkSpec struct {
 	// +optional
 	SecurityGroupOverrides map[SecurityGroupRole]string `json:"securityGroupOverrides,omitempty"`
 
+	// SkipSecurityGroupCreation prevents CAPA from creating any security group for the cluster, for
+	// environments where the security groups are created by a central process. The security groups of
+	// all the roles used by the cluster must then be provided through SecurityGroupOverrides, in an
+	// existing VPC referenced by ID.
+	// +optional
+	SkipSecurityGroupCreation bool `json:"skipSecurityGroupCreation,omitempty"`
+
 	// AdditionalControlPlaneIngressRules is an optional set of ingress rules to add to the control plane
 	// +optional
 	AdditionalControlPlaneIngressRules []IngressRule `json:"additionalControlPlaneIngressRules,omitempty"`
@@ -356,6 +417,219 @@ type NetworkSpec struct {
 	// If none are specified here, all IPs are allowed to connect.
 	// +optional
 	NodePortIngressRuleCidrBlocks []string `json:"nodePortIngressRuleCidrBlocks,omitempty"`
+
+	// AllowNodeEFATraffic adds a rule to the security group of the nodes allowing all the traffic from the
+	// security group itself, which the Elastic Fabric Adapter (EFA) network interfaces of the machines require
+	// for MPI and NCCL workloads. The outbound traffic is already allowed by the default egress rule.
+	// +optional
+	AllowNodeEFATraffic bool `json:"allowNodeEFATraffic,omitempty"`
+
+	// VPCEndpoints are the VPC endpoints of AWS services to create in a managed VPC, so that the instances
+	// can reach the services without going through a NAT gateway. Endpoints removed from the list are deleted.
+	// +optional
+	VPCEndpoints VPCEndpoints `json:"vpcEndpoints,omitempty"`
+
+	// TransitGatewayAttachment attaches a managed VPC to a transit gateway, for clusters in a hub-and-spoke
+	// network design.
+	// +optional
+	TransitGatewayAttachment *TransitGatewayAttachmentSpec `json:"transitGatewayAttachment,omitempty"`
+
+	// VPCPeeringConnections are the peering connections of a managed VPC to other VPCs.
+	// +optional
+	VPCPeeringConnections VPCPeeringConnections `json:"vpcPeeringConnections,omitempty"`
+
+	// NetworkACLs are the custom network ACLs of the subnets of a managed VPC, which enforce stateless allow
+	// and deny rules at the subnet level on top of the security groups. The subnets keep the default network
+	// ACL of the VPC, which allows all traffic, when omitted.
+	// +optional
+	NetworkACLs *NetworkACLsSpec `json:"networkAcls,omitempty"`
+}
+
+// IsExternallyManaged returns true if the network is owned by another party and only validated by CAPA.
+func (n *NetworkSpec) IsExternallyManaged() bool {
+	return n.Mode != nil && *n.Mode == NetworkModeExternallyManaged
+}
+
+// TransitGatewayAttachmentSpec defines the attachment of the VPC to a transit gateway.
+type TransitGatewayAttachmentSpec struct {
+	// TransitGatewayID is the ID of the transit gateway to attach the VPC to.
+	// +kubebuilder:validation:MinLength=1
+	TransitGatewayID string `json:"transitGatewayId"`
+
+	// SubnetIDs are the IDs of the subnets the transit gateway places a network interface in, at most
+	// one per availability zone. Defaults to a private subnet of each availability zone.
+	// +optional
+	SubnetIDs []string `json:"subnetIds,omitempty"`
+
+	// RouteCidrBlocks are the CIDR blocks routed through the transit gateway from the route tables of
+	// the private subnets. The routes are added once the attachment is available.
+	// +optional
+	RouteCidrBlocks []string `json:"routeCidrBlocks,omitempty"`
+}
+
+// VPCEndpointType is the type of a VPC endpoint.
+type VPCEndpointType string
+
+var (
+	// VPCEndpointTypeGateway is a gateway endpoint, added as a route to the route tables of the subnets.
+	// Only S3 and DynamoDB support gateway endpoints.
+	VPCEndpointTypeGateway = VPCEndpointType("Gateway")
+
+	// VPCEndpointTypeInterface is an interface endpoint, a network interface in the private subnets.
+	VPCEndpointTypeInterface = VPCEndpointType("Interface")
+)
+
+// VPCEndpointSpec defines a VPC endpoint of an AWS service.
+type VPCEndpointSpec struct {
+	// Service is the name of the AWS service, for example s3, ssm or ecr.api. It is prefixed with
+	// com.amazonaws.<region> to get the name of the endpoint service.
+	// +kubebuilder:validation:MinLength=1
+	Service string `json:"service"`
+
+	// Type is the type of the VPC endpoint. Defaults to Interface.
+	// +kubebuilder:validation:Enum=Gateway;Interface
+	// +kubebuilder:default=Interface
+	// +optional
+	Type VPCEndpointType `json:"type,omitempty"`
+
+	// PrivateDNSEnabled associates a private hosted zone with the VPC, so that the default DNS name
+	// of the service resolves to the endpoint. Only applies to interface endpoints. Defaults to true.
+	// +optional
+	PrivateDNSEnabled *bool `json:"privateDnsEnabled,omitempty"`
+
+	// AdditionalSecurityGroups are the IDs of security groups attached to an interface endpoint next to
+	// the security group the provider creates for the interface endpoints.
+	// +optional
+	AdditionalSecurityGroups []string `json:"additionalSecurityGroups,omitempty"`
+}
+
+// IsGateway returns whether the endpoint is a gateway endpoint.
+func (e *VPCEndpointSpec) IsGateway() bool {
+	return e.Type == VPCEndpointTypeGateway
+}
+
+// VPCEndpoints is a slice of VPCEndpointSpec.
+// +listType=map
+// +listMapKey=service
+type VPCEndpoints []VPCEndpointSpec
+
+// FilterInterface returns the interface endpoints.
+func (e VPCEndpoints) FilterInterface() (res VPCEndpoints) {
+	for _, endpoint := range e {
+		if !endpoint.IsGateway() {
+			res = append(res, endpoint)
+		}
+	}
+	return
+}
+
+// FilterGateway returns the gateway endpoints.
+func (e VPCEndpoints) FilterGateway() (res VPCEndpoints) {
+	for _, endpoint := range e {
+		if endpoint.IsGateway() {
+			res = append(res, endpoint)
+		}
+	}
+	return
+}
+
+// VPCPeeringConnectionSpec defines a peering connection of the VPC to another VPC.
+type VPCPeeringConnectionSpec struct {
+	// PeerVPCID is the ID of the VPC to peer with.
+	// +kubebuilder:validation:MinLength=1
+	PeerVPCID string `json:"peerVpcId"`
+
+	// PeerOwnerID is the ID of the AWS account owning the peer VPC. Defaults to the account of the cluster.
+	// A peering connection to a VPC of another account has to be accepted by the owner of the peer VPC.
+	// +optional
+	PeerOwnerID string `json:"peerOwnerId,omitempty"`
+
+	// PeerRegion is the region of the peer VPC. Defaults to the region of the cluster.
+	// A peering connection to a VPC of another region has to be accepted in the region of the peer VPC.
+	// +optional
+	PeerRegion string `json:"peerRegion,omitempty"`
+
+	// RouteCidrBlocks are the CIDR blocks of the peer VPC routed through the peering connection from the
+	// route tables of the subnets. The routes are added once the peering connection is active.
+	// +optional
+	RouteCidrBlocks []string `json:"routeCidrBlocks,omitempty"`
+}
+
+// VPCPeeringConnections is a slice of VPCPeeringConnectionSpec.
+// +listType=map
+// +listMapKey=peerVpcId
+type VPCPeeringConnections []VPCPeeringConnectionSpec
+
+// NetworkACLsSpec defines the custom network ACLs of the subnets of a managed VPC.
+type NetworkACLsSpec struct {
+	// Public is the network ACL of the public subnets. Cannot be removed after creation.
+	// +optional
+	Public *NetworkACLSpec `json:"public,omitempty"`
+
+	// Private is the network ACL of the private subnets. Cannot be removed after creation.
+	// +optional
+	Private *NetworkACLSpec `json:"private,omitempty"`
+}
+
+// NetworkACLSpec defines the rules of a network ACL. The traffic matching none of the rules is denied, so
+// the rules must allow the return traffic of the connections as well, network ACLs being stateless.
+type NetworkACLSpec struct {
+	// Ingress are the rules evaluated for the traffic entering the subnets.
+	// +optional
+	Ingress []NetworkACLRule `json:"ingress,omitempty"`
+
+	// Egress are the rules evaluated for the traffic leaving the subnets.
+	// +optional
+	Egress []NetworkACLRule `json:"egress,omitempty"`
+}
+
+// NetworkACLRuleAction is the action of a network ACL rule.
+type NetworkACLRuleAction string
+
+var (
+	// NetworkACLRuleActionAllow allows the traffic matching the rule.
+	NetworkACLRuleActionAllow = NetworkACLRuleAction("allow")
+
+	// NetworkACLRuleActionDeny denies the traffic matching the rule.
+	NetworkACLRuleActionDeny = NetworkACLRuleAction("deny")
+)
+
+// NetworkACLRule defines a rule of a network ACL.
+type NetworkACLRule struct {
+	// RuleNumber is the number of the rule. The rules are evaluated in increasing order of their numbers,
+	// and the first rule matching the traffic applies. Must be unique within the ingress or egress rules.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32766
+	RuleNumber int64 `json:"ruleNumber"`
+
+	// Action is whether the rule allows or denies the traffic.
+	// +kubebuilder:validation:Enum=allow;deny
+	Action NetworkACLRuleAction `json:"action"`
+
+	// Protocol is the protocol of the traffic. Accepted values are "-1" (all), "4" (IP in IP), "tcp", "udp",
+	// "icmp", "58" (ICMPv6) and "50" (ESP).
+	// +kubebuilder:validation:Enum="-1";"4";tcp;udp;icmp;"58";"50"
+	Protocol SecurityGroupProtocol `json:"protocol"`
+
+	// FromPort is the start of the port range. Required for the tcp and udp protocols only.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	FromPort int64 `json:"fromPort,omitempty"`
+
+	// ToPort is the end of the port range. Required for the tcp and udp protocols only.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ToPort int64 `json:"toPort,omitempty"`
+
+	// CidrBlock is the IPv4 CIDR block the rule applies to. Cannot be specified with IPv6CidrBlock.
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+
+	// IPv6CidrBlock is the IPv6 CIDR block the rule applies to. Cannot be specified with CidrBlock.
+	// +optional
+	IPv6CidrBlock string `json:"ipv6CidrBlock,omitempty"`
 }
 
 // IPv6 contains ipv6 specific settings for the network.
@@ -412,7 +686,8 @@ type VPCSpec struct {
 
 	// SecondaryCidrBlocks are additional CIDR blocks to be associated when the provider creates a managed VPC.
 	// Defaults to none. Mutually exclusive with IPAMPool. This makes sense to use if, for example, you want to use
-	// a separate IP range for pods (e.g. Cilium ENI mode).
+	// a separate IP range for pods (e.g. Cilium ENI mode). Subnets may be carved out of the secondary CIDR blocks,
+	// and blocks removed from the list are disassociated from the managed VPC.
 	// +optional
 	SecondaryCidrBlocks []VpcCidrBlock `json:"secondaryCidrBlocks,omitempty"`
 
@@ -455,6 +730,15 @@ type VPCSpec struct {
 	// +kubebuilder:validation:Enum=Ordered;Random
 	AvailabilityZoneSelection *AZSelectionScheme `json:"availabilityZoneSelection,omitempty"`
 
+	// AvailabilityZones restricts the availability zones (AZ) used when automatically creating subnets
+	// to the given list, e.g. `["us-east-1a", "us-east-1c"]`. AvailabilityZoneUsageLimit and
+	// AvailabilityZoneSelection are then applied to the zones of this list instead of all the zones
+	// of the region. Each zone must be available in the region of the cluster.
+	// This field can only be set for a managed VPC.
+	// +optional
+	// +listType=set
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
 	// EmptyRoutesDefaultVPCSecurityGroup specifies whether the default VPC security group ingress
 	// and egress rules should be removed.
 	//
@@ -480,6 +764,14 @@ type VPCSpec struct {
 	// +optional
 	ElasticIPPool *ElasticIPPool `json:"elasticIpPool,omitempty"`
 
+	// NatGatewayElasticIPAllocationIDs is a list of allocation IDs of pre-allocated Elastic IPs to use for the
+	// NAT gateways of a managed VPC, instead of allocating new ones. The egress IPs of the cluster then remain
+	// stable across cluster rebuilds and can be allowed in advance by external parties. The Elastic IPs are
+	// not released when the cluster is deleted. The list must hold at least one unassociated Elastic IP per
+	// NAT gateway to create, that is one per availability zone or a single one when NatGatewayMode is Single.
+	// +optional
+	NatGatewayElasticIPAllocationIDs []string `json:"natGatewayElasticIpAllocationIds,omitempty"`
+
 	// SubnetSchema specifies how CidrBlock should be divided on subnets in the VPC depending on the number of AZs.
 	// PreferPrivate - one private subnet for each AZ plus one other subnet that will be further sub-divided for the public subnets.
 	// PreferPublic - have the reverse logic of PreferPrivate, one public subnet for each AZ plus one other subnet
@@ -489,6 +781,37 @@ type VPCSpec struct {
 	// +kubebuilder:default=PreferPrivate
 	// +kubebuilder:validation:Enum=PreferPrivate;PreferPublic
 	SubnetSchema *SubnetSchemaType `json:"subnetSchema,omitempty"`
+
+	// NatGatewayMode specifies how NAT gateways are provisioned for the private subnets of a managed VPC.
+	// PerAvailabilityZone - one NAT gateway in a public subnet of each AZ, private subnets route through the
+	// NAT gateway of their own AZ.
+	// Single - one NAT gateway shared by the private subnets of all AZs. This reduces the cost of the NAT
+	// gateways and of the Elastic IPs they use, at the expense of availability: if the AZ hosting the NAT
+	// gateway becomes unavailable, the private subnets of every AZ lose outbound internet access, and
+	// traffic from the other AZs incurs cross-AZ data transfer charges.
+	// Defaults to PerAvailabilityZone. Cannot be changed after creation.
+	// The mode applies to the NAT instances as well when NatInstance is set.
+	// +optional
+	// +kubebuilder:validation:Enum=PerAvailabilityZone;Single
+	NatGatewayMode *NatGatewayMode `json:"natGatewayMode,omitempty"`
+
+	// NatInstance configures self-managed NAT instances to provision instead of NAT gateways for the private
+	// subnets of a managed VPC. NAT instances cost less than NAT gateways, which makes them a fit for
+	// development clusters, but their bandwidth is bound to the instance type and they are neither highly
+	// available nor patched by AWS. Cannot be added or removed after creation.
+	// +optional
+	NatInstance *NatInstanceSpec `json:"natInstance,omitempty"`
+
+	// FlowLogs configures the flow log of a managed VPC, which captures the IP traffic of its network
+	// interfaces. The flow log is created along with the VPC and deleted with it, or once removed.
+	// +optional
+	FlowLogs *VPCFlowLogsSpec `json:"flowLogs,omitempty"`
+
+	// DHCPOptions configures a custom DHCP options set to create and associate with a managed VPC, e.g.
+	// to resolve names through internal DNS servers. Changing it replaces the DHCP options set, removing it
+	// associates the VPC with a DHCP options set using the Amazon provided DNS again.
+	// +optional
+	DHCPOptions *DHCPOptionsSpec `json:"dhcpOptions,omitempty"`
 }
 
 // String returns a string representation of the VPC.
@@ -506,6 +829,16 @@ func (v *VPCSpec) IsManaged(clusterName string) bool {
 	return !v.IsUnmanaged(clusterName)
 }
 
+// IsNatInstanceEnabled returns true if NAT instances are provisioned instead of NAT gateways.
+func (v *VPCSpec) IsNatInstanceEnabled() bool {
+	return v.NatInstance != nil
+}
+
+// IsSingleNatGateway returns true if a single NAT gateway is shared by all the private subnets of the VPC.
+func (v *VPCSpec) IsSingleNatGateway() bool {
+	return v.NatGatewayMode != nil && *v.NatGatewayMode == NatGatewayModeSingle
+}
+
 // IsIPv6Enabled returns true if the IPv6 block is defined on the network spec.
 func (v *VPCSpec) IsIPv6Enabled() bool {
 	return v.IPv6 != nil
@@ -556,6 +889,13 @@ type SubnetSpec struct {
 	// AvailabilityZone defines the availability zone to use for this subnet in the cluster's region.
 	AvailabilityZone string `json:"availabilityZone,omitempty"`
 
+	// AvailabilityZoneID defines the ID of the availability zone to use for this subnet, e.g. `use1-az1`.
+	// Unlike availability zone names, which are mapped to physical zones independently for each AWS account,
+	// availability zone IDs identify the same physical zone across accounts.
+	// If set, the availability zone name is resolved from the ID in the account of the cluster.
+	// +optional
+	AvailabilityZoneID string `json:"availabilityZoneID,omitempty"`
+
 	// IsPublic defines the subnet as a public subnet. A subnet is public when it is associated with a route table that has a route to an internet gateway.
 	// +optional
 	IsPublic bool `json:"isPublic"`
@@ -569,14 +909,36 @@ type SubnetSpec struct {
 	// +optional
 	RouteTableID *string `json:"routeTableId,omitempty"`
 
+	// ExistingRouteTableID is the ID of an existing route table, not managed by the provider, to associate a subnet
+	// of a managed VPC with. The provider doesn't create a route table for the subnet and doesn't modify the routes
+	// of the existing route table, which are up to its owner.
+	// +kubebuilder:validation:Pattern=`^rtb-[0-9a-f]+$`
+	// +optional
+	ExistingRouteTableID string `json:"existingRouteTableId,omitempty"`
+
 	// NatGatewayID is the NAT gateway id associated with the subnet.
 	// Ignored unless the subnet is managed by the provider, in which case this is set on the public subnet where the NAT gateway resides. It is then used to determine routes for private subnets in the same AZ as the public subnet.
 	// +optional
 	NatGatewayID *string `json:"natGatewayId,omitempty"`
 
+	// NatInstanceID is the NAT instance id associated with the subnet, when NAT instances are used instead of NAT gateways.
+	// Ignored unless the subnet is managed by the provider, in which case this is set on the public subnet where the NAT instance resides. It is then used to determine routes for private subnets in the same AZ as the public subnet.
+	// +optional
+	NatInstanceID *string `json:"natInstanceId,omitempty"`
+
 	// Tags is a collection of tags describing the resource.
 	Tags Tags `json:"tags,omitempty"`
 
+	// LoadBalancerRole is the role of the subnet for the load balancers of the Kubernetes services of type
+	// LoadBalancer, which the cloud provider discovers through the kubernetes.io/role/elb (external) and
+	// kubernetes.io/role/internal-elb (internal) subnet tags. Defaults to external for the public subnets and
+	// internal for the private subnets, except in edge zones where the subnets get neither.
+	// When set, the tag of the other role is removed from the subnet, and the subnets of an unmanaged VPC are
+	// tagged even when the tagging of unmanaged network resources is disabled.
+	// +kubebuilder:validation:Enum=external;internal;none
+	// +optional
+	LoadBalancerRole *SubnetLoadBalancerRole `json:"loadBalancerRole,omitempty"`
+
 	// ZoneType defines the type of the zone where the subnet is created.
 	//
 	// The valid values are availability-zone, local-zone, and wavelength-zone.
@@ -612,6 +974,18 @@ type SubnetSpec struct {
 	//
 	// +optional
 	ParentZoneName *string `json:"parentZoneName,omitempty"`
+
+	// OutpostARN is the Amazon Resource Name (ARN) of the Outpost the subnet lives on, e.g.
+	// `arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0`.
+	//
+	// The subnet is then created on the Outpost, which must be anchored to the availability zone
+	// of the subnet. For an existing subnet, the field is discovered from AWS.
+	//
+	// Subnets on an Outpost are not eligible to automatically create regular cluster resources,
+	// they only host the machines and machine pools which reference them explicitly.
+	//
+	// +optional
+	OutpostARN *string `json:"outpostArn,omitempty"`
 }
 
 // GetResourceID returns the identifier for this subnet,
@@ -643,6 +1017,11 @@ func (s *SubnetSpec) IsEdge() bool {
 	return false
 }
 
+// IsOutpost returns true when the subnet lives on an AWS Outpost.
+func (s *SubnetSpec) IsOutpost() bool {
+	return s.OutpostARN != nil && *s.OutpostARN != ""
+}
+
 // IsEdgeWavelength returns true only when the subnet is created in Wavelength Zone.
 func (s *SubnetSpec) IsEdgeWavelength() bool {
 	if s.ZoneType == nil {
@@ -754,8 +1133,8 @@ func (s Subnets) FindEqual(spec *SubnetSpec) *SubnetSpec {
 // FilterPrivate returns a slice containing all subnets marked as private.
 func (s Subnets) FilterPrivate() (res Subnets) {
 	for _, x := range s {
-		// Subnets in AWS Local Zones or Wavelength should not be used by core infrastructure.
-		if x.IsEdge() {
+		// Subnets in AWS Local Zones, Wavelength or on Outposts should not be used by core infrastructure.
+		if x.IsEdge() || x.IsOutpost() {
 			continue
 		}
 		if !x.IsPublic {
@@ -779,8 +1158,8 @@ func (s Subnets) FilterNonCni() (res Subnets) {
 // FilterPublic returns a slice containing all subnets marked as public.
 func (s Subnets) FilterPublic() (res Subnets) {
 	for _, x := range s {
-		// Subnets in AWS Local Zones or Wavelength should not be used by core infrastructure.
-		if x.IsEdge() {
+		// Subnets in AWS Local Zones, Wavelength or on Outposts should not be used by core infrastructure.
+		if x.IsEdge() || x.IsOutpost() {
 			continue
 		}
 		if x.IsPublic {
@@ -790,6 +1169,16 @@ func (s Subnets) FilterPublic() (res Subnets) {
 	return
 }
 
+// FilterEdge returns a slice containing all subnets in AWS Local Zones or Wavelength Zones.
+func (s Subnets) FilterEdge() (res Subnets) {
+	for _, x := range s {
+		if x.IsEdge() {
+			res = append(res, x)
+		}
+	}
+	return
+}
+
 // FilterByZone returns a slice containing all subnets that live in the availability zone specified.
 func (s Subnets) FilterByZone(zone string) (res Subnets) {
 	for _, x := range s {
@@ -852,6 +1241,35 @@ type CNIIngressRule struct {
 	Protocol    SecurityGroupProtocol `json:"protocol"`
 	FromPort    int64                 `json:"fromPort"`
 	ToPort      int64                 `json:"toPort"`
+
+	// PrefixListIDs is a list of managed prefix list IDs to allow access from, in addition to
+	// the control plane and worker security groups.
+	// +optional
+	PrefixListIDs []string `json:"prefixListIds,omitempty"`
+}
+
+// PrivateDNSRecord defines a CNAME record in a private Route53 hosted zone.
+type PrivateDNSRecord struct {
+	// HostedZoneID is the ID of the private hosted zone to create the record in, e.g. "Z0123456789ABCDEFGHIJ".
+	// The hosted zone has to be associated with the VPC of the cluster for the record to resolve in it.
+	// +kubebuilder:validation:MinLength=1
+	HostedZoneID string `json:"hostedZoneId"`
+
+	// Name is the fully qualified name of the record, e.g. "api.my-cluster.internal".
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// ControlPlaneEndpointDNS defines a custom DNS name for the control plane endpoint.
+type ControlPlaneEndpointDNS struct {
+	// HostedZoneID is the ID of the Route53 hosted zone to create the alias record in, e.g. "Z0123456789ABCDEFGHIJ".
+	// +kubebuilder:validation:MinLength=1
+	HostedZoneID string `json:"hostedZoneId"`
+
+	// Name is the fully qualified domain name of the control plane endpoint, e.g. "api.my-cluster.example.com".
+	// The name has to be part of the domain of the hosted zone.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // RouteTable defines an AWS routing table.
@@ -881,6 +1299,9 @@ var (
 
 	// SecurityGroupLB defines a container for the cloud provider to inject its load balancer ingress rules.
 	SecurityGroupLB = SecurityGroupRole("lb")
+
+	// SecurityGroupVPCEndpoint defines a security group for the interface VPC endpoints.
+	SecurityGroupVPCEndpoint = SecurityGroupRole("vpc-endpoint")
 )
 
 // SecurityGroup defines an AWS security group.
@@ -950,6 +1371,11 @@ type IngressRule struct {
 	// +optional
 	IPv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
 
+	// List of managed prefix list IDs to allow access from, e.g. "pl-0123456789abcdef0".
+	// Cannot be specified with SourceSecurityGroupID.
+	// +optional
+	PrefixListIDs []string `json:"prefixListIds,omitempty"`
+
 	// The security group id to allow access from. Cannot be specified with CidrBlocks.
 	// +optional
 	SourceSecurityGroupIDs []string `json:"sourceSecurityGroupIds,omitempty"`
@@ -1022,6 +1448,20 @@ func (i *IngressRule) Equals(o *IngressRule) bool {
 		}
 	}
 
+	// prefix lists
+	if len(i.PrefixListIDs) != len(o.PrefixListIDs) {
+		return false
+	}
+
+	sort.Strings(i.PrefixListIDs)
+	sort.Strings(o.PrefixListIDs)
+
+	for i, v := range i.PrefixListIDs {
+		if v != o.PrefixListIDs[i] {
+			return false
+		}
+	}
+
 	if len(i.SourceSecurityGroupIDs) != len(o.SourceSecurityGroupIDs) {
 		return false
 	}
@@ -1068,6 +1508,97 @@ func (z ZoneType) Equal(other ZoneType) bool {
 	return z == other
 }
 
+// NatInstanceSpec defines the self-managed NAT instances of a managed VPC.
+type NatInstanceSpec struct {
+	// AMI is the ID of the AMI to run the NAT instances from. The AMI must be configured to forward and
+	// masquerade the IPv4 traffic of the VPC, for example an fck-nat AMI.
+	// +kubebuilder:validation:Pattern=`^ami-[0-9a-f]+$`
+	AMI string `json:"ami"`
+
+	// InstanceType is the type of the NAT instances. Defaults to t3.micro.
+	// +kubebuilder:default=t3.micro
+	// +kubebuilder:validation:MinLength:=2
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+}
+
+// FlowLogsDestinationType is the type of destination the flow log records are published to.
+type FlowLogsDestinationType string
+
+var (
+	// FlowLogsDestinationTypeCloudWatchLogs publishes the flow log records to a CloudWatch Logs log group.
+	FlowLogsDestinationTypeCloudWatchLogs = FlowLogsDestinationType("cloud-watch-logs")
+
+	// FlowLogsDestinationTypeS3 publishes the flow log records to an S3 bucket.
+	FlowLogsDestinationTypeS3 = FlowLogsDestinationType("s3")
+)
+
+// FlowLogsTrafficType is the type of traffic captured by a flow log.
+type FlowLogsTrafficType string
+
+var (
+	// FlowLogsTrafficTypeAccept captures the accepted traffic only.
+	FlowLogsTrafficTypeAccept = FlowLogsTrafficType("ACCEPT")
+
+	// FlowLogsTrafficTypeReject captures the rejected traffic only.
+	FlowLogsTrafficTypeReject = FlowLogsTrafficType("REJECT")
+
+	// FlowLogsTrafficTypeAll captures both the accepted and rejected traffic.
+	FlowLogsTrafficTypeAll = FlowLogsTrafficType("ALL")
+)
+
+// VPCFlowLogsSpec defines the flow log of a managed VPC.
+type VPCFlowLogsSpec struct {
+	// DestinationType is the type of destination the flow log records are published to.
+	// Defaults to cloud-watch-logs.
+	// +kubebuilder:default=cloud-watch-logs
+	// +kubebuilder:validation:Enum=cloud-watch-logs;s3
+	// +optional
+	DestinationType FlowLogsDestinationType `json:"destinationType,omitempty"`
+
+	// Destination is the ARN of the CloudWatch Logs log group, or of the S3 bucket, optionally followed by a
+	// folder, e.g. arn:aws:s3:::my-bucket/flow-logs/. The log group or bucket must already exist.
+	// +kubebuilder:validation:MinLength=1
+	Destination string `json:"destination"`
+
+	// DeliverLogsPermissionARN is the ARN of the IAM role that allows the flow log to publish to the
+	// CloudWatch Logs log group. Required when DestinationType is cloud-watch-logs, not supported otherwise.
+	// +optional
+	DeliverLogsPermissionARN string `json:"deliverLogsPermissionArn,omitempty"`
+
+	// TrafficType is the type of traffic to capture. Defaults to ALL.
+	// +kubebuilder:default=ALL
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT;ALL
+	// +optional
+	TrafficType FlowLogsTrafficType `json:"trafficType,omitempty"`
+
+	// MaxAggregationInterval is the maximum interval of time, in seconds, during which a flow of packets is
+	// captured and aggregated into a flow log record. Defaults to 600.
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Enum=60;600
+	// +optional
+	MaxAggregationInterval int64 `json:"maxAggregationInterval,omitempty"`
+}
+
+// DHCPOptionsSpec defines the DHCP options set of a managed VPC.
+type DHCPOptionsSpec struct {
+	// DomainName is the domain name the instances use to complete unqualified DNS hostnames.
+	// When omitted, the instances use the default domain name of the region.
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+
+	// DomainNameServers are the IP addresses of up to four DNS servers, or AmazonProvidedDNS.
+	// When omitted, the instances use AmazonProvidedDNS.
+	// +kubebuilder:validation:MaxItems=4
+	// +optional
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+
+	// NTPServers are the IP addresses of up to four NTP servers.
+	// +kubebuilder:validation:MaxItems=4
+	// +optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+}
+
 // ElasticIPPool allows configuring a Elastic IP pool for resources allocating
 // public IPv4 addresses on public subnets.
 type ElasticIPPool struct {
@@ -1096,7 +1627,7 @@ type ElasticIPPool struct {
 	// 'static'/preallocated amazon-provided IPsstrucute currently holds only 'BYO Public IP from Public IPv4 Pool' (user brought to AWS),
 	// although a dedicated structure would help to hold 'BYO Elastic IP' variants like:
 	// - AllocationIdPoolApiLoadBalancer: an user-defined (static) IP address to the Public API Load Balancer.
-	// - AllocationIdPoolNatGateways: an user-defined (static) IP address to allocate to NAT Gateways (egress traffic).
+	// The NAT Gateways variant is supported by VPCSpec.NatGatewayElasticIPAllocationIDs.
 }
 
 // PublicIpv4PoolFallbackOrder defines the list of available fallback action when the PublicIpv4Pool is exhausted.
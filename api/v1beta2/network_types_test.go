@@ -768,6 +768,71 @@ func TestSubnets_FilterPublic(t *testing.T) {
 	}
 }
 
+func TestSubnets_FilterEdge(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnets Subnets
+		want    Subnets
+	}{
+		{
+			name:    "no edge subnets",
+			subnets: nil,
+			want:    nil,
+		},
+		{
+			name: "no edge subnets",
+			subnets: Subnets{
+				{
+					ResourceID: "subnet-az-1a",
+				},
+				{
+					ResourceID: "subnet-az-3b",
+					ZoneType:   ptr.To(ZoneTypeAvailabilityZone),
+					IsPublic:   true,
+				},
+			},
+			want: nil,
+		},
+		{
+			name:    "edge subnets",
+			subnets: subnetsAllZones,
+			want: Subnets{
+				{
+					ResourceID:       "subnet-lz-1a",
+					ZoneType:         ptr.To(ZoneTypeLocalZone),
+					IsPublic:         false,
+					AvailabilityZone: "us-east-1-nyc-1a",
+				},
+				{
+					ResourceID:       "subnet-lz-2b",
+					ZoneType:         ptr.To(ZoneTypeLocalZone),
+					IsPublic:         true,
+					AvailabilityZone: "us-east-1-nyc-1a",
+				},
+				{
+					ResourceID:       "subnet-wl-1a",
+					ZoneType:         ptr.To(ZoneTypeWavelengthZone),
+					IsPublic:         false,
+					AvailabilityZone: "us-east-1-wl1-nyc-wlz-1",
+				},
+				{
+					ResourceID:       "subnet-wl-1b",
+					ZoneType:         ptr.To(ZoneTypeWavelengthZone),
+					IsPublic:         true,
+					AvailabilityZone: "us-east-1-wl1-nyc-wlz-1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subnets.FilterEdge(); !cmp.Equal(got, tt.want) {
+				t.Errorf("Subnets.FilterEdge() got unwanted value:\n %v", cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
 func TestSubnets_GetUniqueZones(t *testing.T) {
 	tests := []struct {
 		name    string
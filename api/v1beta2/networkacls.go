@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateNetworkACLs validates the network ACLs of the subnets.
+func (n *NetworkSpec) ValidateNetworkACLs() []*field.Error {
+	var errs field.ErrorList
+
+	if n.NetworkACLs == nil {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network", "networkAcls")
+	if n.VPC.ID != "" {
+		return append(errs, field.Forbidden(fldPath, "can only be set for a managed VPC"))
+	}
+
+	if n.NetworkACLs.Public != nil {
+		errs = append(errs, n.NetworkACLs.Public.validate(fldPath.Child("public"))...)
+	}
+	if n.NetworkACLs.Private != nil {
+		errs = append(errs, n.NetworkACLs.Private.validate(fldPath.Child("private"))...)
+	}
+
+	return errs
+}
+
+// ValidateNetworkACLsUpdate validates the changes to the network ACLs of the subnets. The rules can be
+// changed, but the network ACLs can't be removed.
+func (n *NetworkSpec) ValidateNetworkACLsUpdate(old *NetworkSpec) []*field.Error {
+	var errs field.ErrorList
+
+	if old.NetworkACLs == nil {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network", "networkAcls")
+	newACLs := n.NetworkACLs
+	if newACLs == nil {
+		newACLs = &NetworkACLsSpec{}
+	}
+	if old.NetworkACLs.Public != nil && newACLs.Public == nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("public"), "cannot be removed"))
+	}
+	if old.NetworkACLs.Private != nil && newACLs.Private == nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("private"), "cannot be removed"))
+	}
+
+	return errs
+}
+
+func (a *NetworkACLSpec) validate(fldPath *field.Path) []*field.Error {
+	var errs field.ErrorList
+
+	errs = append(errs, validateNetworkACLRules(fldPath.Child("ingress"), a.Ingress)...)
+	errs = append(errs, validateNetworkACLRules(fldPath.Child("egress"), a.Egress)...)
+
+	return errs
+}
+
+func validateNetworkACLRules(fldPath *field.Path, rules []NetworkACLRule) []*field.Error {
+	var errs field.ErrorList
+
+	ruleNumbers := sets.New[int64]()
+	for i, rule := range rules {
+		rulePath := fldPath.Index(i)
+
+		if ruleNumbers.Has(rule.RuleNumber) {
+			errs = append(errs, field.Duplicate(rulePath.Child("ruleNumber"), rule.RuleNumber))
+		}
+		ruleNumbers.Insert(rule.RuleNumber)
+
+		switch {
+		case rule.CidrBlock == "" && rule.IPv6CidrBlock == "":
+			errs = append(errs, field.Required(rulePath.Child("cidrBlock"), "one of cidrBlock or ipv6CidrBlock must be set"))
+		case rule.CidrBlock != "" && rule.IPv6CidrBlock != "":
+			errs = append(errs, field.Forbidden(rulePath.Child("ipv6CidrBlock"), "cannot be set along with cidrBlock"))
+		case rule.CidrBlock != "":
+			if ip, _, err := net.ParseCIDR(rule.CidrBlock); err != nil || ip.To4() == nil {
+				errs = append(errs, field.Invalid(rulePath.Child("cidrBlock"), rule.CidrBlock, "must be an IPv4 CIDR block"))
+			}
+		default:
+			if ip, _, err := net.ParseCIDR(rule.IPv6CidrBlock); err != nil || ip.To4() != nil {
+				errs = append(errs, field.Invalid(rulePath.Child("ipv6CidrBlock"), rule.IPv6CidrBlock, "must be an IPv6 CIDR block"))
+			}
+		}
+
+		switch rule.Protocol {
+		case SecurityGroupProtocolTCP, SecurityGroupProtocolUDP:
+			if rule.ToPort == 0 {
+				errs = append(errs, field.Required(rulePath.Child("toPort"), "is required for the tcp and udp protocols"))
+			} else if rule.ToPort < rule.FromPort {
+				errs = append(errs, field.Invalid(rulePath.Child("toPort"), rule.ToPort, "must be greater than or equal to fromPort"))
+			}
+		default:
+			if rule.FromPort != 0 || rule.ToPort != 0 {
+				errs = append(errs, field.Forbidden(rulePath.Child("fromPort"), "ports are only supported for the tcp and udp protocols"))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateNetworkACLs(t *testing.T) {
+	allowHTTPS := NetworkACLRule{
+		RuleNumber: 100,
+		Action:     NetworkACLRuleActionAllow,
+		Protocol:   SecurityGroupProtocolTCP,
+		FromPort:   443,
+		ToPort:     443,
+		CidrBlock:  "0.0.0.0/0",
+	}
+
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no network acls",
+		},
+		{
+			name: "public and private network acls",
+			network: NetworkSpec{NetworkACLs: &NetworkACLsSpec{
+				Public: &NetworkACLSpec{
+					Ingress: []NetworkACLRule{allowHTTPS},
+					Egress: []NetworkACLRule{
+						{RuleNumber: 100, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolAll, CidrBlock: "0.0.0.0/0"},
+						{RuleNumber: 101, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolAll, IPv6CidrBlock: "::/0"},
+					},
+				},
+				Private: &NetworkACLSpec{
+					Ingress: []NetworkACLRule{
+						{RuleNumber: 100, Action: NetworkACLRuleActionDeny, Protocol: SecurityGroupProtocolICMP, CidrBlock: "10.20.0.0/16"},
+					},
+				},
+			}},
+		},
+		{
+			name: "network acls of an unmanaged vpc",
+			network: NetworkSpec{VPC: VPCSpec{ID: "vpc-exists"}, NetworkACLs: &NetworkACLsSpec{
+				Public: &NetworkACLSpec{Ingress: []NetworkACLRule{allowHTTPS}},
+			}},
+			expectedFields: []string{"spec.network.networkAcls"},
+		},
+		{
+			name: "duplicate rule numbers",
+			network: NetworkSpec{NetworkACLs: &NetworkACLsSpec{
+				Private: &NetworkACLSpec{Ingress: []NetworkACLRule{allowHTTPS, allowHTTPS}, Egress: []NetworkACLRule{allowHTTPS}},
+			}},
+			expectedFields: []string{"spec.network.networkAcls.private.ingress[1].ruleNumber"},
+		},
+		{
+			name: "invalid cidr blocks",
+			network: NetworkSpec{NetworkACLs: &NetworkACLsSpec{
+				Public: &NetworkACLSpec{Ingress: []NetworkACLRule{
+					{RuleNumber: 100, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolAll},
+					{RuleNumber: 101, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolAll, CidrBlock: "0.0.0.0/0", IPv6CidrBlock: "::/0"},
+					{RuleNumber: 102, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolAll, CidrBlock: "::/0"},
+					{RuleNumber: 103, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolAll, IPv6CidrBlock: "10.0.0.0/8"},
+				}},
+			}},
+			expectedFields: []string{
+				"spec.network.networkAcls.public.ingress[0].cidrBlock",
+				"spec.network.networkAcls.public.ingress[1].ipv6CidrBlock",
+				"spec.network.networkAcls.public.ingress[2].cidrBlock",
+				"spec.network.networkAcls.public.ingress[3].ipv6CidrBlock",
+			},
+		},
+		{
+			name: "invalid ports",
+			network: NetworkSpec{NetworkACLs: &NetworkACLsSpec{
+				Public: &NetworkACLSpec{Egress: []NetworkACLRule{
+					{RuleNumber: 100, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolTCP, CidrBlock: "0.0.0.0/0"},
+					{RuleNumber: 101, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolUDP, FromPort: 1024, ToPort: 53, CidrBlock: "0.0.0.0/0"},
+					{RuleNumber: 102, Action: NetworkACLRuleActionAllow, Protocol: SecurityGroupProtocolICMP, FromPort: 8, CidrBlock: "0.0.0.0/0"},
+				}},
+			}},
+			expectedFields: []string{
+				"spec.network.networkAcls.public.egress[0].toPort",
+				"spec.network.networkAcls.public.egress[1].toPort",
+				"spec.network.networkAcls.public.egress[2].fromPort",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateNetworkACLs() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateNetworkACLsUpdate(t *testing.T) {
+	networkACLs := &NetworkACLsSpec{Public: &NetworkACLSpec{}, Private: &NetworkACLSpec{}}
+	g := NewWithT(t)
+
+	g.Expect((&NetworkSpec{NetworkACLs: networkACLs}).ValidateNetworkACLsUpdate(&NetworkSpec{})).To(BeEmpty())
+	g.Expect((&NetworkSpec{NetworkACLs: networkACLs}).ValidateNetworkACLsUpdate(&NetworkSpec{NetworkACLs: networkACLs})).To(BeEmpty())
+	g.Expect((&NetworkSpec{NetworkACLs: &NetworkACLsSpec{Public: &NetworkACLSpec{}}}).ValidateNetworkACLsUpdate(&NetworkSpec{NetworkACLs: networkACLs})).To(HaveLen(1))
+	g.Expect((&NetworkSpec{}).ValidateNetworkACLsUpdate(&NetworkSpec{NetworkACLs: networkACLs})).To(HaveLen(2))
+}
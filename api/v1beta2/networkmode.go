@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateNetworkMode validates the network of an externally managed network, for a cluster that needs
+// security groups with the given roles.
+func (n *NetworkSpec) ValidateNetworkMode(securityGroupRoles ...SecurityGroupRole) []*field.Error {
+	var errs field.ErrorList
+
+	if !n.IsExternallyManaged() {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network")
+	if n.VPC.ID == "" {
+		errs = append(errs, field.Required(fldPath.Child("vpc", "id"), "the VPC of an externally managed network must be referenced by ID"))
+	}
+	if n.VPC.EmptyRoutesDefaultVPCSecurityGroup {
+		errs = append(errs, field.Forbidden(fldPath.Child("vpc", "emptyRoutesDefaultVPCSecurityGroup"),
+			"cannot be set for an externally managed network, its default security group is never modified"))
+	}
+	if len(n.VPC.SecondaryCidrBlocks) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("vpc", "secondaryCidrBlocks"),
+			"cannot be set for an externally managed network, its CIDR blocks are never modified"))
+	}
+
+	if len(n.Subnets) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("subnets"), "the subnets of an externally managed network must be referenced"))
+	}
+	for i, subnet := range n.Subnets {
+		if subnet.ID == "" && subnet.ResourceID == "" {
+			errs = append(errs, field.Required(fldPath.Child("subnets").Index(i).Child("id"),
+				"the subnets of an externally managed network must be referenced by ID"))
+		}
+		if subnet.LoadBalancerRole != nil {
+			errs = append(errs, field.Forbidden(fldPath.Child("subnets").Index(i).Child("loadBalancerRole"),
+				"cannot be set for an externally managed network, its subnets are never tagged"))
+		}
+	}
+
+	for _, role := range securityGroupRoles {
+		if n.SecurityGroupOverrides[role] == "" {
+			errs = append(errs, field.Required(fldPath.Child("securityGroupOverrides").Key(string(role)),
+				"the security groups of an externally managed network must be provided, none is created"))
+		}
+	}
+
+	// The options below configure resources CAPA would create or modify in the network.
+	if n.CNI != nil && len(n.CNI.CNIIngressRules) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("cni", "cniIngressRules"),
+			"cannot be set for an externally managed network, its security groups are never modified"))
+	}
+	if len(n.AdditionalControlPlaneIngressRules) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("additionalControlPlaneIngressRules"),
+			"cannot be set for an externally managed network, its security groups are never modified"))
+	}
+	if len(n.NodePortIngressRuleCidrBlocks) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("nodePortIngressRuleCidrBlocks"),
+			"cannot be set for an externally managed network, its security groups are never modified"))
+	}
+	if n.AllowNodeEFATraffic {
+		errs = append(errs, field.Forbidden(fldPath.Child("allowNodeEFATraffic"),
+			"cannot be set for an externally managed network, its security groups are never modified"))
+	}
+	if len(n.VPCEndpoints) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("vpcEndpoints"),
+			"cannot be set for an externally managed network, no VPC endpoint is created"))
+	}
+	if n.TransitGatewayAttachment != nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("transitGatewayAttachment"),
+			"cannot be set for an externally managed network, no transit gateway attachment is created"))
+	}
+	if len(n.VPCPeeringConnections) > 0 {
+		errs = append(errs, field.Forbidden(fldPath.Child("vpcPeeringConnections"),
+			"cannot be set for an externally managed network, no peering connection is created"))
+	}
+	if n.NetworkACLs != nil {
+		errs = append(errs, field.Forbidden(fldPath.Child("networkAcls"),
+			"cannot be set for an externally managed network, no network ACL is created"))
+	}
+
+	return errs
+}
+
+// ValidateNetworkModeUpdate validates the changes to the mode of the network.
+func (n *NetworkSpec) ValidateNetworkModeUpdate(old *NetworkSpec) []*field.Error {
+	var errs field.ErrorList
+
+	if n.IsExternallyManaged() != old.IsExternallyManaged() {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "network", "mode"), n.Mode, "field is immutable"))
+	}
+
+	return errs
+}
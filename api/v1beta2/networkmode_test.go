@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkSpecValidateNetworkMode(t *testing.T) {
+	managed := NetworkModeManaged
+	external := NetworkModeExternallyManaged
+	overrides := map[SecurityGroupRole]string{
+		SecurityGroupControlPlane: "sg-controlplane",
+		SecurityGroupNode:         "sg-node",
+	}
+	roles := []SecurityGroupRole{SecurityGroupControlPlane, SecurityGroupNode}
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "managed network",
+			network: NetworkSpec{Mode: &managed, AdditionalControlPlaneIngressRules: []IngressRule{{Description: "test"}}},
+		},
+		{
+			name: "externally managed network",
+			network: NetworkSpec{
+				Mode:                   &external,
+				VPC:                    VPCSpec{ID: "vpc-exists"},
+				Subnets:                Subnets{{ID: "subnet-1"}, {ResourceID: "subnet-2"}},
+				SecurityGroupOverrides: overrides,
+			},
+		},
+		{
+			name:    "externally managed network without vpc, subnets and security groups",
+			network: NetworkSpec{Mode: &external},
+			expectedFields: []string{
+				"spec.network.vpc.id",
+				"spec.network.subnets",
+				"spec.network.securityGroupOverrides[controlplane]",
+				"spec.network.securityGroupOverrides[node]",
+			},
+		},
+		{
+			name: "externally managed network with subnets not referenced by id",
+			network: NetworkSpec{
+				Mode:                   &external,
+				VPC:                    VPCSpec{ID: "vpc-exists"},
+				Subnets:                Subnets{{ID: "subnet-1"}, {CidrBlock: "10.0.1.0/24"}},
+				SecurityGroupOverrides: overrides,
+			},
+			expectedFields: []string{"spec.network.subnets[1].id"},
+		},
+		{
+			name: "externally managed network with options modifying the network",
+			network: NetworkSpec{
+				Mode: &external,
+				VPC: VPCSpec{
+					ID:                                 "vpc-exists",
+					EmptyRoutesDefaultVPCSecurityGroup: true,
+				},
+				Subnets:                            Subnets{{ID: "subnet-1", LoadBalancerRole: ptr.To(SubnetLoadBalancerRoleInternal)}},
+				SecurityGroupOverrides:             overrides,
+				AdditionalControlPlaneIngressRules: []IngressRule{{Description: "test"}},
+				AllowNodeEFATraffic:                true,
+				VPCEndpoints:                       VPCEndpoints{{Service: "s3"}},
+				NetworkACLs:                        &NetworkACLsSpec{},
+			},
+			expectedFields: []string{
+				"spec.network.vpc.emptyRoutesDefaultVPCSecurityGroup",
+				"spec.network.subnets[0].loadBalancerRole",
+				"spec.network.additionalControlPlaneIngressRules",
+				"spec.network.allowNodeEFATraffic",
+				"spec.network.vpcEndpoints",
+				"spec.network.networkAcls",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateNetworkMode(roles...) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateNetworkModeUpdate(t *testing.T) {
+	managed := NetworkModeManaged
+	external := NetworkModeExternallyManaged
+	tests := []struct {
+		name           string
+		old            NetworkSpec
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "defaulted managed mode set explicitly",
+			old:     NetworkSpec{},
+			network: NetworkSpec{Mode: &managed},
+		},
+		{
+			name:    "externally managed mode unchanged",
+			old:     NetworkSpec{Mode: &external},
+			network: NetworkSpec{Mode: &external},
+		},
+		{
+			name:           "switching to externally managed mode",
+			old:            NetworkSpec{},
+			network:        NetworkSpec{Mode: &external},
+			expectedFields: []string{"spec.network.mode"},
+		},
+		{
+			name:           "switching to managed mode",
+			old:            NetworkSpec{Mode: &external},
+			network:        NetworkSpec{Mode: &managed},
+			expectedFields: []string{"spec.network.mode"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateNetworkModeUpdate(&tt.old) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateOutposts validates the Outposts of the subnets.
+func (n *NetworkSpec) ValidateOutposts() []*field.Error {
+	var errs field.ErrorList
+
+	fldPath := field.NewPath("spec", "network", "subnets")
+	for i, subnet := range n.Subnets {
+		if subnet.OutpostARN == nil {
+			continue
+		}
+
+		outpostPath := fldPath.Index(i).Child("outpostArn")
+		parsed, err := arn.Parse(*subnet.OutpostARN)
+		if err != nil || parsed.Service != "outposts" || !strings.HasPrefix(parsed.Resource, "outpost/") {
+			errs = append(errs, field.Invalid(outpostPath, *subnet.OutpostARN, "must be the ARN of an Outpost"))
+			continue
+		}
+		if subnet.IsEdge() {
+			errs = append(errs, field.Forbidden(outpostPath, "cannot be set for a subnet in a Local Zone or Wavelength Zone"))
+		}
+		if n.VPC.ID == "" && subnet.AvailabilityZone == "" && subnet.AvailabilityZoneID == "" {
+			errs = append(errs, field.Required(fldPath.Index(i).Child("availabilityZone"), "must be set to the availability zone of the Outpost"))
+		}
+	}
+
+	return errs
+}
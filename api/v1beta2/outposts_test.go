@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkSpecValidateOutposts(t *testing.T) {
+	outpostARN := "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"
+
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "no outposts",
+			network: NetworkSpec{Subnets: Subnets{{ID: "subnet-1", AvailabilityZone: "us-west-2a"}}},
+		},
+		{
+			name:    "subnet on an outpost",
+			network: NetworkSpec{Subnets: Subnets{{ID: "subnet-1", AvailabilityZone: "us-west-2a", OutpostARN: ptr.To(outpostARN)}}},
+		},
+		{
+			name: "existing subnet on an outpost of an unmanaged vpc",
+			network: NetworkSpec{
+				VPC:     VPCSpec{ID: "vpc-exists"},
+				Subnets: Subnets{{ID: "subnet-1", OutpostARN: ptr.To(outpostARN)}},
+			},
+		},
+		{
+			name: "invalid outpost arns",
+			network: NetworkSpec{Subnets: Subnets{
+				{ID: "subnet-1", AvailabilityZone: "us-west-2a", OutpostARN: ptr.To("op-0123456789abcdef0")},
+				{ID: "subnet-2", AvailabilityZone: "us-west-2a", OutpostARN: ptr.To("arn:aws:ec2:us-west-2:123456789012:subnet/subnet-1")},
+			}},
+			expectedFields: []string{"spec.network.subnets[0].outpostArn", "spec.network.subnets[1].outpostArn"},
+		},
+		{
+			name:           "subnet on an outpost in a local zone",
+			network:        NetworkSpec{Subnets: Subnets{{ID: "subnet-1", AvailabilityZone: "us-west-2-lax-1a", ZoneType: ptr.To(ZoneTypeLocalZone), OutpostARN: ptr.To(outpostARN)}}},
+			expectedFields: []string{"spec.network.subnets[0].outpostArn"},
+		},
+		{
+			name:           "managed subnet on an outpost without availability zone",
+			network:        NetworkSpec{Subnets: Subnets{{ID: "subnet-1", OutpostARN: ptr.To(outpostARN)}}},
+			expectedFields: []string{"spec.network.subnets[0].availabilityZone"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateOutposts() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
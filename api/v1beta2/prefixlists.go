@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidatePrefixLists validates the managed prefix lists referenced by the CNI ingress rules and the
+// additional control plane ingress rules.
+func (n *NetworkSpec) ValidatePrefixLists() []*field.Error {
+	var errs field.ErrorList
+
+	if n.CNI != nil {
+		fldPath := field.NewPath("spec", "network", "cni", "cniIngressRules")
+		for i, rule := range n.CNI.CNIIngressRules {
+			errs = append(errs, validatePrefixListIDs(fldPath.Index(i).Child("prefixListIds"), rule.PrefixListIDs)...)
+		}
+	}
+
+	fldPath := field.NewPath("spec", "network", "additionalControlPlaneIngressRules")
+	for i, rule := range n.AdditionalControlPlaneIngressRules {
+		errs = append(errs, validateIngressRulePrefixLists(fldPath.Index(i), rule)...)
+	}
+
+	return errs
+}
+
+// validateIngressRulePrefixLists validates the managed prefix lists of an ingress rule. Prefix lists
+// are a source on their own and cannot be combined with source security groups or NAT gateway IPs.
+func validateIngressRulePrefixLists(fldPath *field.Path, rule IngressRule) field.ErrorList {
+	if len(rule.PrefixListIDs) == 0 {
+		return nil
+	}
+
+	errs := validatePrefixListIDs(fldPath.Child("prefixListIds"), rule.PrefixListIDs)
+	if len(rule.SourceSecurityGroupIDs) > 0 || len(rule.SourceSecurityGroupRoles) > 0 || rule.NatGatewaysIPsSource {
+		errs = append(errs, field.Forbidden(fldPath.Child("prefixListIds"), "cannot be used together with security group IDs, security group roles or NAT gateway IPs"))
+	}
+
+	return errs
+}
+
+func validatePrefixListIDs(fldPath *field.Path, ids []string) field.ErrorList {
+	var errs field.ErrorList
+
+	seen := sets.New[string]()
+	for i, id := range ids {
+		if !strings.HasPrefix(id, "pl-") {
+			errs = append(errs, field.Invalid(fldPath.Index(i), id, "must be a managed prefix list ID starting with \"pl-\""))
+			continue
+		}
+		if seen.Has(id) {
+			errs = append(errs, field.Duplicate(fldPath.Index(i), id))
+		}
+		seen.Insert(id)
+	}
+
+	return errs
+}
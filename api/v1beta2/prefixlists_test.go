@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidatePrefixLists(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "no prefix lists",
+			network: NetworkSpec{AdditionalControlPlaneIngressRules: []IngressRule{{Protocol: SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, CidrBlocks: []string{"10.0.0.0/8"}}}},
+		},
+		{
+			name: "valid prefix lists",
+			network: NetworkSpec{
+				CNI:                                &CNISpec{CNIIngressRules: CNIIngressRules{{Protocol: SecurityGroupProtocolTCP, FromPort: 179, ToPort: 179, PrefixListIDs: []string{"pl-1"}}}},
+				AdditionalControlPlaneIngressRules: []IngressRule{{Protocol: SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, CidrBlocks: []string{"10.0.0.0/8"}, PrefixListIDs: []string{"pl-1", "pl-2"}}},
+			},
+		},
+		{
+			name: "invalid and duplicate prefix list ids",
+			network: NetworkSpec{
+				CNI:                                &CNISpec{CNIIngressRules: CNIIngressRules{{Protocol: SecurityGroupProtocolTCP, FromPort: 179, ToPort: 179, PrefixListIDs: []string{"sg-1"}}}},
+				AdditionalControlPlaneIngressRules: []IngressRule{{Protocol: SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, PrefixListIDs: []string{"pl-1", "pl-1"}}},
+			},
+			expectedFields: []string{"spec.network.cni.cniIngressRules[0].prefixListIds[0]", "spec.network.additionalControlPlaneIngressRules[0].prefixListIds[1]"},
+		},
+		{
+			name: "prefix lists with source security groups",
+			network: NetworkSpec{AdditionalControlPlaneIngressRules: []IngressRule{
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, PrefixListIDs: []string{"pl-1"}, SourceSecurityGroupIDs: []string{"sg-1"}},
+				{Protocol: SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, PrefixListIDs: []string{"pl-1"}, SourceSecurityGroupRoles: []SecurityGroupRole{SecurityGroupNode}},
+			}},
+			expectedFields: []string{"spec.network.additionalControlPlaneIngressRules[0].prefixListIds", "spec.network.additionalControlPlaneIngressRules[1].prefixListIds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidatePrefixLists() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateExistingRouteTables validates the existing route tables of the subnets.
+func (n *NetworkSpec) ValidateExistingRouteTables() []*field.Error {
+	var errs field.ErrorList
+
+	if n.VPC.ID == "" {
+		return errs
+	}
+
+	for i, subnet := range n.Subnets {
+		if subnet.ExistingRouteTableID != "" {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "network", "subnets").Index(i).Child("existingRouteTableId"),
+				"can only be set for the subnets of a managed VPC, the route tables of an unmanaged VPC are never modified"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateExistingRouteTables(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "existing route table in a managed vpc",
+			network: NetworkSpec{
+				Subnets: Subnets{{ID: "private-1a", ExistingRouteTableID: "rtb-1"}, {ID: "public-1a", IsPublic: true}},
+			},
+		},
+		{
+			name: "subnets of an unmanaged vpc",
+			network: NetworkSpec{
+				VPC:     VPCSpec{ID: "vpc-exists"},
+				Subnets: Subnets{{ID: "subnet-1"}, {ID: "subnet-2", ExistingRouteTableID: "rtb-1"}},
+			},
+			expectedFields: []string{"spec.network.subnets[1].existingRouteTableId"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateExistingRouteTables() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
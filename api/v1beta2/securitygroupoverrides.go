@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateSecurityGroupOverrides validates that the security groups of all the given roles are provided when
+// the creation of the security groups is skipped.
+func (n *NetworkSpec) ValidateSecurityGroupOverrides(securityGroupRoles ...SecurityGroupRole) []*field.Error {
+	var errs field.ErrorList
+
+	// The overrides of an externally managed network are validated with its mode.
+	if !n.SkipSecurityGroupCreation || n.IsExternallyManaged() {
+		return errs
+	}
+
+	fldPath := field.NewPath("spec", "network")
+	if n.VPC.ID == "" {
+		errs = append(errs, field.Required(fldPath.Child("vpc", "id"),
+			"security group overrides can only be used in an existing VPC referenced by ID"))
+	}
+
+	for _, role := range securityGroupRoles {
+		if n.SecurityGroupOverrides[role] == "" {
+			errs = append(errs, field.Required(fldPath.Child("securityGroupOverrides").Key(string(role)),
+				"the security groups must be provided when their creation is skipped"))
+		}
+	}
+
+	return errs
+}
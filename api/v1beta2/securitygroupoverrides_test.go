@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateSecurityGroupOverrides(t *testing.T) {
+	roles := []SecurityGroupRole{SecurityGroupAPIServerLB, SecurityGroupLB, SecurityGroupControlPlane, SecurityGroupNode}
+	overrides := map[SecurityGroupRole]string{
+		SecurityGroupAPIServerLB:  "sg-1",
+		SecurityGroupLB:           "sg-2",
+		SecurityGroupControlPlane: "sg-3",
+		SecurityGroupNode:         "sg-4",
+	}
+	external := NetworkModeExternallyManaged
+
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "security groups created",
+			network: NetworkSpec{},
+		},
+		{
+			name: "security group creation skipped with all the overrides",
+			network: NetworkSpec{
+				VPC:                       VPCSpec{ID: "vpc-exists"},
+				SecurityGroupOverrides:    overrides,
+				SkipSecurityGroupCreation: true,
+			},
+		},
+		{
+			name: "security group creation skipped with missing overrides in a managed vpc",
+			network: NetworkSpec{
+				SecurityGroupOverrides:    map[SecurityGroupRole]string{SecurityGroupNode: "sg-4"},
+				SkipSecurityGroupCreation: true,
+			},
+			expectedFields: []string{
+				"spec.network.vpc.id",
+				"spec.network.securityGroupOverrides[apiserver-lb]",
+				"spec.network.securityGroupOverrides[lb]",
+				"spec.network.securityGroupOverrides[controlplane]",
+			},
+		},
+		{
+			name: "externally managed network",
+			network: NetworkSpec{
+				Mode:                      &external,
+				SkipSecurityGroupCreation: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateSecurityGroupOverrides(roles...) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
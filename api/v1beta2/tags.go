@@ -176,6 +176,12 @@ const (
 	// APIServerRoleTagValue describes the value for the apiserver role.
 	APIServerRoleTagValue = "apiserver"
 
+	// ControlPlaneRoleTagValue describes the value for the control plane role.
+	ControlPlaneRoleTagValue = "control-plane"
+
+	// NodeRoleTagValue describes the value for the node role.
+	NodeRoleTagValue = "node"
+
 	// BastionRoleTagValue describes the value for the bastion role.
 	BastionRoleTagValue = "bastion"
 
@@ -188,6 +194,9 @@ const (
 	// PrivateRoleTagValue describes the value for the private role.
 	PrivateRoleTagValue = "private"
 
+	// NatInstanceRoleTagValue describes the value for the NAT instance role.
+	NatInstanceRoleTagValue = "nat-instance"
+
 	// MachineNameTagKey is the key for machine name.
 	MachineNameTagKey = "MachineName"
 
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateTransitGatewayAttachment validates the transit gateway attachment of the network.
+func (n *NetworkSpec) ValidateTransitGatewayAttachment() []*field.Error {
+	var errs field.ErrorList
+
+	if n.TransitGatewayAttachment == nil {
+		return errs
+	}
+
+	attachmentPath := field.NewPath("spec", "network", "transitGatewayAttachment")
+	if n.VPC.ID != "" {
+		errs = append(errs, field.Forbidden(attachmentPath, "a transit gateway attachment can only be created for a managed VPC"))
+	}
+
+	subnetIDs := sets.New[string]()
+	for i, id := range n.TransitGatewayAttachment.SubnetIDs {
+		if subnetIDs.Has(id) {
+			errs = append(errs, field.Duplicate(attachmentPath.Child("subnetIds").Index(i), id))
+		}
+		subnetIDs.Insert(id)
+	}
+
+	cidrBlocks := sets.New[string]()
+	for i, cidrBlock := range n.TransitGatewayAttachment.RouteCidrBlocks {
+		cidrPath := attachmentPath.Child("routeCidrBlocks").Index(i)
+		if err := validateRouteCidrBlock(cidrPath, cidrBlock); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if cidrBlocks.Has(cidrBlock) {
+			errs = append(errs, field.Duplicate(cidrPath, cidrBlock))
+		}
+		cidrBlocks.Insert(cidrBlock)
+	}
+
+	return errs
+}
+
+// validateRouteCidrBlock validates the destination of a route added to the route tables of the subnets.
+func validateRouteCidrBlock(path *field.Path, cidrBlock string) *field.Error {
+	_, ipNet, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return field.Invalid(path, cidrBlock, "must be a valid CIDR block")
+	}
+	if ones, _ := ipNet.Mask.Size(); ones == 0 {
+		return field.Invalid(path, cidrBlock, "must not overlap with the default route of the subnets")
+	}
+	return nil
+}
+
+// ValidateTransitGatewayAttachmentUpdate validates the update of the transit gateway attachment of the network.
+// The attachment can be added to an existing cluster, but neither removed nor moved to another transit gateway.
+func (n *NetworkSpec) ValidateTransitGatewayAttachmentUpdate(old *NetworkSpec) []*field.Error {
+	errs := n.ValidateTransitGatewayAttachment()
+
+	if old.TransitGatewayAttachment == nil {
+		return errs
+	}
+
+	attachmentPath := field.NewPath("spec", "network", "transitGatewayAttachment")
+	if n.TransitGatewayAttachment == nil {
+		return append(errs, field.Forbidden(attachmentPath, "the transit gateway attachment can't be removed"))
+	}
+	if n.TransitGatewayAttachment.TransitGatewayID != old.TransitGatewayAttachment.TransitGatewayID {
+		errs = append(errs, field.Invalid(attachmentPath.Child("transitGatewayId"), n.TransitGatewayAttachment.TransitGatewayID, "field is immutable"))
+	}
+
+	return errs
+}
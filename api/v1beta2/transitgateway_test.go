@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateTransitGatewayAttachment(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no attachment",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-exists"},
+			},
+		},
+		{
+			name: "attachment with subnets and routes",
+			network: NetworkSpec{
+				TransitGatewayAttachment: &TransitGatewayAttachmentSpec{
+					TransitGatewayID: "tgw-1",
+					SubnetIDs:        []string{"subnet-1", "subnet-2"},
+					RouteCidrBlocks:  []string{"10.0.0.0/8", "192.168.0.0/16"},
+				},
+			},
+		},
+		{
+			name: "attachment of an unmanaged vpc",
+			network: NetworkSpec{
+				VPC:                      VPCSpec{ID: "vpc-exists"},
+				TransitGatewayAttachment: &TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1"},
+			},
+			expectedFields: []string{"spec.network.transitGatewayAttachment"},
+		},
+		{
+			name: "duplicate subnets and invalid routes",
+			network: NetworkSpec{
+				TransitGatewayAttachment: &TransitGatewayAttachmentSpec{
+					TransitGatewayID: "tgw-1",
+					SubnetIDs:        []string{"subnet-1", "subnet-1"},
+					RouteCidrBlocks:  []string{"10.0.0.0/8", "10.0.0.0", "0.0.0.0/0", "10.0.0.0/8"},
+				},
+			},
+			expectedFields: []string{
+				"spec.network.transitGatewayAttachment.subnetIds[1]",
+				"spec.network.transitGatewayAttachment.routeCidrBlocks[1]",
+				"spec.network.transitGatewayAttachment.routeCidrBlocks[2]",
+				"spec.network.transitGatewayAttachment.routeCidrBlocks[3]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateTransitGatewayAttachment() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateTransitGatewayAttachmentUpdate(t *testing.T) {
+	attachment := &TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1"}
+
+	tests := []struct {
+		name           string
+		oldNetwork     NetworkSpec
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "adding an attachment",
+			network: NetworkSpec{TransitGatewayAttachment: attachment},
+		},
+		{
+			name:       "changing the routes of an attachment",
+			oldNetwork: NetworkSpec{TransitGatewayAttachment: attachment},
+			network: NetworkSpec{
+				TransitGatewayAttachment: &TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1", RouteCidrBlocks: []string{"10.0.0.0/8"}},
+			},
+		},
+		{
+			name:           "removing an attachment",
+			oldNetwork:     NetworkSpec{TransitGatewayAttachment: attachment},
+			expectedFields: []string{"spec.network.transitGatewayAttachment"},
+		},
+		{
+			name:           "changing the transit gateway",
+			oldNetwork:     NetworkSpec{TransitGatewayAttachment: attachment},
+			network:        NetworkSpec{TransitGatewayAttachment: &TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-2"}},
+			expectedFields: []string{"spec.network.transitGatewayAttachment.transitGatewayId"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateTransitGatewayAttachmentUpdate(&tt.oldNetwork) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
@@ -86,6 +86,20 @@ const (
 	// ExternalResourceGCTasksAnnotation is the name of an annotation that indicates what
 	// external resources tasks should be executed by garbage collector for the cluster.
 	ExternalResourceGCTasksAnnotation = "aws.cluster.x-k8s.io/external-resource-tasks-gc"
+
+	// AWSSDKDebugLogAnnotation is the name of an annotation that enables AWS SDK request
+	// and response logging, including HTTP bodies, for the AWS clients of the cluster.
+	AWSSDKDebugLogAnnotation = "aws.cluster.x-k8s.io/aws-sdk-debug-logging"
+
+	// AllowControlPlaneLoadBalancerSchemeChangeAnnotation is the name of an annotation that allows changing the
+	// scheme of the control plane load balancer. The load balancer is replaced by a new one, which changes the
+	// control plane endpoint of the cluster.
+	AllowControlPlaneLoadBalancerSchemeChangeAnnotation = "aws.cluster.x-k8s.io/allow-control-plane-load-balancer-scheme-change"
+
+	// SecondaryControlPlaneEndpointAnnotation is the name of an annotation set by the controller on the AWSCluster,
+	// holding the host:port endpoint of the secondary control plane load balancer once it is available. It can be
+	// used to add the endpoint to the API server certificate SANs or to generate an additional kubeconfig.
+	SecondaryControlPlaneEndpointAnnotation = "aws.cluster.x-k8s.io/secondary-control-plane-endpoint"
 )
 
 // GCTask defines a task to be executed by the garbage collector.
@@ -175,6 +189,10 @@ type Instance struct {
 	// The ID of the subnet of the instance.
 	SubnetID string `json:"subnetId,omitempty"`
 
+	// The ID of the VPC of the instance.
+	// +optional
+	VPCID string `json:"vpcId,omitempty"`
+
 	// The ID of the AMI used to launch the instance.
 	ImageID string `json:"imageId,omitempty"`
 
@@ -220,6 +238,9 @@ type Instance struct {
 	// NetworkInterfaceType is the interface type of the primary network Interface.
 	NetworkInterfaceType NetworkInterfaceType `json:"networkInterfaceType,omitempty"`
 
+	// SecondaryNetworkInterfaces are the ENIs created and attached to the instance besides the primary network interface.
+	SecondaryNetworkInterfaces []SecondaryNetworkInterface `json:"secondaryNetworkInterfaces,omitempty"`
+
 	// The tags associated with the instance.
 	Tags map[string]string `json:"tags,omitempty"`
 
@@ -494,3 +515,36 @@ var (
 	// SubnetSchemaPreferPublic allocates more subnets in the VPC to public subnets.
 	SubnetSchemaPreferPublic = SubnetSchemaType("PreferPublic")
 )
+
+// NatGatewayMode specifies how NAT gateways are provisioned for the private subnets of a managed VPC.
+type NatGatewayMode string
+
+var (
+	// NatGatewayModePerAvailabilityZone provisions one NAT gateway in each availability zone.
+	NatGatewayModePerAvailabilityZone = NatGatewayMode("PerAvailabilityZone")
+	// NatGatewayModeSingle provisions one NAT gateway shared by the private subnets of all availability zones.
+	NatGatewayModeSingle = NatGatewayMode("Single")
+)
+
+// NetworkMode specifies whether the network of the cluster is managed by CAPA.
+type NetworkMode string
+
+var (
+	// NetworkModeManaged lets CAPA create or adopt the network resources of the cluster.
+	NetworkModeManaged = NetworkMode("Managed")
+	// NetworkModeExternallyManaged makes CAPA only validate the network resources of the cluster, which are owned
+	// by another party, without creating or modifying any of them.
+	NetworkModeExternallyManaged = NetworkMode("ExternallyManaged")
+)
+
+// SubnetLoadBalancerRole is the role of a subnet for the load balancers of the Kubernetes services.
+type SubnetLoadBalancerRole string
+
+var (
+	// SubnetLoadBalancerRoleExternal selects the subnet for the internet-facing load balancers.
+	SubnetLoadBalancerRoleExternal = SubnetLoadBalancerRole("external")
+	// SubnetLoadBalancerRoleInternal selects the subnet for the internal load balancers.
+	SubnetLoadBalancerRoleInternal = SubnetLoadBalancerRole("internal")
+	// SubnetLoadBalancerRoleNone selects the subnet for no load balancer.
+	SubnetLoadBalancerRoleNone = SubnetLoadBalancerRole("none")
+)
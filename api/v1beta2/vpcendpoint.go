@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// gatewayVPCEndpointServices are the services supporting gateway VPC endpoints.
+var gatewayVPCEndpointServices = sets.New("s3", "dynamodb")
+
+// ValidateVPCEndpoints validates the VPC endpoints of the network.
+func (n *NetworkSpec) ValidateVPCEndpoints() []*field.Error {
+	var errs field.ErrorList
+
+	if len(n.VPCEndpoints) == 0 {
+		return errs
+	}
+
+	endpointsPath := field.NewPath("spec", "network", "vpcEndpoints")
+	if n.VPC.ID != "" {
+		errs = append(errs, field.Forbidden(endpointsPath, "VPC endpoints can only be created in a managed VPC"))
+	}
+
+	for i, endpoint := range n.VPCEndpoints {
+		endpointPath := endpointsPath.Index(i)
+		if endpoint.Service == "" {
+			errs = append(errs, field.Required(endpointPath.Child("service"), "can't be empty"))
+		}
+		if !endpoint.IsGateway() {
+			continue
+		}
+		if !gatewayVPCEndpointServices.Has(endpoint.Service) {
+			errs = append(errs, field.NotSupported(endpointPath.Child("service"), endpoint.Service, sets.List(gatewayVPCEndpointServices)))
+		}
+		if endpoint.PrivateDNSEnabled != nil {
+			errs = append(errs, field.Forbidden(endpointPath.Child("privateDnsEnabled"), "can only be set for interface endpoints"))
+		}
+		if len(endpoint.AdditionalSecurityGroups) > 0 {
+			errs = append(errs, field.Forbidden(endpointPath.Child("additionalSecurityGroups"), "can only be set for interface endpoints"))
+		}
+	}
+
+	return errs
+}
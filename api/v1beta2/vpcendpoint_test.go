@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkSpecValidateVPCEndpoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no endpoints",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-exists"},
+			},
+		},
+		{
+			name: "gateway and interface endpoints",
+			network: NetworkSpec{
+				VPCEndpoints: VPCEndpoints{
+					{Service: "s3", Type: VPCEndpointTypeGateway},
+					{Service: "ssm", PrivateDNSEnabled: ptr.To(false), AdditionalSecurityGroups: []string{"sg-1"}},
+				},
+			},
+		},
+		{
+			name: "endpoints in an unmanaged vpc",
+			network: NetworkSpec{
+				VPC:          VPCSpec{ID: "vpc-exists"},
+				VPCEndpoints: VPCEndpoints{{Service: "ssm"}},
+			},
+			expectedFields: []string{"spec.network.vpcEndpoints"},
+		},
+		{
+			name: "gateway endpoint of a service without gateway endpoints",
+			network: NetworkSpec{
+				VPCEndpoints: VPCEndpoints{{Service: "ssm", Type: VPCEndpointTypeGateway}},
+			},
+			expectedFields: []string{"spec.network.vpcEndpoints[0].service"},
+		},
+		{
+			name: "interface settings on a gateway endpoint",
+			network: NetworkSpec{
+				VPCEndpoints: VPCEndpoints{
+					{Service: "ssm"},
+					{Service: "s3", Type: VPCEndpointTypeGateway, PrivateDNSEnabled: ptr.To(true), AdditionalSecurityGroups: []string{"sg-1"}},
+				},
+			},
+			expectedFields: []string{"spec.network.vpcEndpoints[1].privateDnsEnabled", "spec.network.vpcEndpoints[1].additionalSecurityGroups"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateVPCEndpoints() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateVPCPeeringConnections validates the VPC peering connections of the network.
+func (n *NetworkSpec) ValidateVPCPeeringConnections() []*field.Error {
+	var errs field.ErrorList
+
+	if len(n.VPCPeeringConnections) == 0 {
+		return errs
+	}
+
+	connectionsPath := field.NewPath("spec", "network", "vpcPeeringConnections")
+	if n.VPC.ID != "" {
+		errs = append(errs, field.Forbidden(connectionsPath, "VPC peering connections can only be created for a managed VPC"))
+	}
+
+	// A destination can only be routed through a single peering connection or the transit gateway.
+	cidrBlocks := sets.New[string]()
+	if n.TransitGatewayAttachment != nil {
+		cidrBlocks.Insert(n.TransitGatewayAttachment.RouteCidrBlocks...)
+	}
+
+	for i, connection := range n.VPCPeeringConnections {
+		for j, cidrBlock := range connection.RouteCidrBlocks {
+			cidrPath := connectionsPath.Index(i).Child("routeCidrBlocks").Index(j)
+			if err := validateRouteCidrBlock(cidrPath, cidrBlock); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if cidrBlocks.Has(cidrBlock) {
+				errs = append(errs, field.Duplicate(cidrPath, cidrBlock))
+			}
+			cidrBlocks.Insert(cidrBlock)
+		}
+	}
+
+	return errs
+}
+
+// ValidateVPCPeeringConnectionsUpdate validates the update of the VPC peering connections of the network.
+// Peering connections can be added to and removed from an existing cluster, but not moved to another account
+// or region.
+func (n *NetworkSpec) ValidateVPCPeeringConnectionsUpdate(old *NetworkSpec) []*field.Error {
+	errs := n.ValidateVPCPeeringConnections()
+
+	connectionsPath := field.NewPath("spec", "network", "vpcPeeringConnections")
+	for _, oldConnection := range old.VPCPeeringConnections {
+		i := n.VPCPeeringConnections.index(oldConnection.PeerVPCID)
+		if i < 0 {
+			continue
+		}
+		connection := n.VPCPeeringConnections[i]
+		if connection.PeerOwnerID != oldConnection.PeerOwnerID {
+			errs = append(errs, field.Invalid(connectionsPath.Index(i).Child("peerOwnerId"), connection.PeerOwnerID, "field is immutable"))
+		}
+		if connection.PeerRegion != oldConnection.PeerRegion {
+			errs = append(errs, field.Invalid(connectionsPath.Index(i).Child("peerRegion"), connection.PeerRegion, "field is immutable"))
+		}
+	}
+
+	return errs
+}
+
+// index returns the index of the peering connection to the VPC, or -1.
+func (c VPCPeeringConnections) index(peerVPCID string) int {
+	for i := range c {
+		if c[i].PeerVPCID == peerVPCID {
+			return i
+		}
+	}
+	return -1
+}
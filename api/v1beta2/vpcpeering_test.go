@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworkSpecValidateVPCPeeringConnections(t *testing.T) {
+	tests := []struct {
+		name           string
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name: "no peering connections",
+			network: NetworkSpec{
+				VPC: VPCSpec{ID: "vpc-exists"},
+			},
+		},
+		{
+			name: "peering connections with routes",
+			network: NetworkSpec{
+				TransitGatewayAttachment: &TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1", RouteCidrBlocks: []string{"10.0.0.0/8"}},
+				VPCPeeringConnections: VPCPeeringConnections{
+					{PeerVPCID: "vpc-1", RouteCidrBlocks: []string{"172.16.0.0/16"}},
+					{PeerVPCID: "vpc-2", PeerOwnerID: "123456789012", PeerRegion: "eu-west-1", RouteCidrBlocks: []string{"172.17.0.0/16"}},
+				},
+			},
+		},
+		{
+			name: "peering connections of an unmanaged vpc",
+			network: NetworkSpec{
+				VPC:                   VPCSpec{ID: "vpc-exists"},
+				VPCPeeringConnections: VPCPeeringConnections{{PeerVPCID: "vpc-1"}},
+			},
+			expectedFields: []string{"spec.network.vpcPeeringConnections"},
+		},
+		{
+			name: "invalid and duplicate routes",
+			network: NetworkSpec{
+				TransitGatewayAttachment: &TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1", RouteCidrBlocks: []string{"10.0.0.0/8"}},
+				VPCPeeringConnections: VPCPeeringConnections{
+					{PeerVPCID: "vpc-1", RouteCidrBlocks: []string{"10.0.0.0/8", "172.16.0.0"}},
+					{PeerVPCID: "vpc-2", RouteCidrBlocks: []string{"0.0.0.0/0", "172.16.0.0/16", "172.16.0.0/16"}},
+				},
+			},
+			expectedFields: []string{
+				"spec.network.vpcPeeringConnections[0].routeCidrBlocks[0]",
+				"spec.network.vpcPeeringConnections[0].routeCidrBlocks[1]",
+				"spec.network.vpcPeeringConnections[1].routeCidrBlocks[0]",
+				"spec.network.vpcPeeringConnections[1].routeCidrBlocks[2]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateVPCPeeringConnections() {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
+
+func TestNetworkSpecValidateVPCPeeringConnectionsUpdate(t *testing.T) {
+	connections := VPCPeeringConnections{{PeerVPCID: "vpc-1"}, {PeerVPCID: "vpc-2", PeerOwnerID: "123456789012"}}
+
+	tests := []struct {
+		name           string
+		oldNetwork     NetworkSpec
+		network        NetworkSpec
+		expectedFields []string
+	}{
+		{
+			name:    "adding peering connections",
+			network: NetworkSpec{VPCPeeringConnections: connections},
+		},
+		{
+			name:       "changing the routes of a peering connection",
+			oldNetwork: NetworkSpec{VPCPeeringConnections: connections},
+			network: NetworkSpec{
+				VPCPeeringConnections: VPCPeeringConnections{
+					{PeerVPCID: "vpc-2", PeerOwnerID: "123456789012", RouteCidrBlocks: []string{"172.16.0.0/16"}},
+					{PeerVPCID: "vpc-1"},
+				},
+			},
+		},
+		{
+			name:       "removing a peering connection",
+			oldNetwork: NetworkSpec{VPCPeeringConnections: connections},
+			network:    NetworkSpec{VPCPeeringConnections: connections[:1]},
+		},
+		{
+			name:       "changing the account and region of a peering connection",
+			oldNetwork: NetworkSpec{VPCPeeringConnections: connections},
+			network: NetworkSpec{
+				VPCPeeringConnections: VPCPeeringConnections{
+					{PeerVPCID: "vpc-1", PeerRegion: "eu-west-1"},
+					{PeerVPCID: "vpc-2"},
+				},
+			},
+			expectedFields: []string{"spec.network.vpcPeeringConnections[0].peerRegion", "spec.network.vpcPeeringConnections[1].peerOwnerId"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			var fields []string
+			for _, err := range tt.network.ValidateVPCPeeringConnectionsUpdate(&tt.oldNetwork) {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(Equal(tt.expectedFields))
+		})
+	}
+}
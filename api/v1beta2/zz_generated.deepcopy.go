@@ -312,6 +312,16 @@ func (in *AWSClusterSpec) DeepCopyInto(out *AWSClusterSpec) {
 		*out = new(AWSLoadBalancerSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.APIServerPrivateDNSRecord != nil {
+		in, out := &in.APIServerPrivateDNSRecord, &out.APIServerPrivateDNSRecord
+		*out = new(PrivateDNSRecord)
+		**out = **in
+	}
+	if in.ControlPlaneEndpointDNS != nil {
+		in, out := &in.ControlPlaneEndpointDNS, &out.ControlPlaneEndpointDNS
+		*out = new(ControlPlaneEndpointDNS)
+		**out = **in
+	}
 	in.Bastion.DeepCopyInto(&out.Bastion)
 	if in.IdentityRef != nil {
 		in, out := &in.IdentityRef, &out.IdentityRef
@@ -558,16 +568,33 @@ func (in *AWSLoadBalancerSpec) DeepCopyInto(out *AWSLoadBalancerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ARN != nil {
+		in, out := &in.ARN, &out.ARN
+		*out = new(string)
+		**out = **in
+	}
 	if in.Scheme != nil {
 		in, out := &in.Scheme, &out.Scheme
 		*out = new(ELBScheme)
 		**out = **in
 	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(int64)
+		**out = **in
+	}
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SubnetFilters != nil {
+		in, out := &in.SubnetFilters, &out.SubnetFilters
+		*out = make([]Filter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.HealthCheckProtocol != nil {
 		in, out := &in.HealthCheckProtocol, &out.HealthCheckProtocol
 		*out = new(ELBProtocol)
@@ -597,6 +624,18 @@ func (in *AWSLoadBalancerSpec) DeepCopyInto(out *AWSLoadBalancerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(LoadBalancerAccessLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make(Tags, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSLoadBalancerSpec.
@@ -745,6 +784,13 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecondaryNetworkInterfaces != nil {
+		in, out := &in.SecondaryNetworkInterfaces, &out.SecondaryNetworkInterfaces
+		*out = make([]SecondaryNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.UncompressedUserData != nil {
 		in, out := &in.UncompressedUserData, &out.UncompressedUserData
 		*out = new(bool)
@@ -766,6 +812,11 @@ func (in *AWSMachineSpec) DeepCopyInto(out *AWSMachineSpec) {
 		*out = new(PrivateDNSName)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostnameTemplate != nil {
+		in, out := &in.HostnameTemplate, &out.HostnameTemplate
+		*out = new(string)
+		**out = **in
+	}
 	if in.CapacityReservationID != nil {
 		in, out := &in.CapacityReservationID, &out.CapacityReservationID
 		*out = new(string)
@@ -1093,6 +1144,11 @@ func (in *AWSRoleSpec) DeepCopy() *AWSRoleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalListenerSpec) DeepCopyInto(out *AdditionalListenerSpec) {
 	*out = *in
+	if in.TargetPort != nil {
+		in, out := &in.TargetPort, &out.TargetPort
+		*out = new(int64)
+		**out = **in
+	}
 	if in.HealthCheck != nil {
 		in, out := &in.HealthCheck, &out.HealthCheck
 		*out = new(TargetGroupHealthCheckAdditionalSpec)
@@ -1186,6 +1242,11 @@ func (in *BuildParams) DeepCopy() *BuildParams {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CNIIngressRule) DeepCopyInto(out *CNIIngressRule) {
 	*out = *in
+	if in.PrefixListIDs != nil {
+		in, out := &in.PrefixListIDs, &out.PrefixListIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNIIngressRule.
@@ -1203,7 +1264,9 @@ func (in CNIIngressRules) DeepCopyInto(out *CNIIngressRules) {
 	{
 		in := &in
 		*out = make(CNIIngressRules, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1223,7 +1286,9 @@ func (in *CNISpec) DeepCopyInto(out *CNISpec) {
 	if in.CNIIngressRules != nil {
 		in, out := &in.CNIIngressRules, &out.CNIIngressRules
 		*out = make(CNIIngressRules, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1240,6 +1305,11 @@ func (in *CNISpec) DeepCopy() *CNISpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassicELBAttributes) DeepCopyInto(out *ClassicELBAttributes) {
 	*out = *in
+	if in.AccessLogs != nil {
+		in, out := &in.AccessLogs, &out.AccessLogs
+		*out = new(LoadBalancerAccessLogs)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClassicELBAttributes.
@@ -1297,6 +1367,46 @@ func (in *CloudInit) DeepCopy() *CloudInit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneEndpointDNS) DeepCopyInto(out *ControlPlaneEndpointDNS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneEndpointDNS.
+func (in *ControlPlaneEndpointDNS) DeepCopy() *ControlPlaneEndpointDNS {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneEndpointDNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsSpec) DeepCopyInto(out *DHCPOptionsSpec) {
+	*out = *in
+	if in.DomainNameServers != nil {
+		in, out := &in.DomainNameServers, &out.DomainNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsSpec.
+func (in *DHCPOptionsSpec) DeepCopy() *DHCPOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticIPPool) DeepCopyInto(out *ElasticIPPool) {
 	*out = *in
@@ -1470,6 +1580,11 @@ func (in *IngressRule) DeepCopyInto(out *IngressRule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrefixListIDs != nil {
+		in, out := &in.PrefixListIDs, &out.PrefixListIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SourceSecurityGroupIDs != nil {
 		in, out := &in.SourceSecurityGroupIDs, &out.SourceSecurityGroupIDs
 		*out = make([]string, len(*in))
@@ -1573,6 +1688,13 @@ func (in *Instance) DeepCopyInto(out *Instance) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecondaryNetworkInterfaces != nil {
+		in, out := &in.SecondaryNetworkInterfaces, &out.SecondaryNetworkInterfaces
+		*out = make([]SecondaryNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -1681,7 +1803,7 @@ func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 		*out = new(ClassicELBHealthCheck)
 		**out = **in
 	}
-	out.ClassicElbAttributes = in.ClassicElbAttributes
+	in.ClassicElbAttributes.DeepCopyInto(&out.ClassicElbAttributes)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -1724,9 +1846,114 @@ func (in *LoadBalancer) DeepCopy() *LoadBalancer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerAccessLogs) DeepCopyInto(out *LoadBalancerAccessLogs) {
+	*out = *in
+	if in.EmitIntervalMinutes != nil {
+		in, out := &in.EmitIntervalMinutes, &out.EmitIntervalMinutes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerAccessLogs.
+func (in *LoadBalancerAccessLogs) DeepCopy() *LoadBalancerAccessLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerAccessLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatInstanceSpec) DeepCopyInto(out *NatInstanceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatInstanceSpec.
+func (in *NatInstanceSpec) DeepCopy() *NatInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NatInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkACLRule) DeepCopyInto(out *NetworkACLRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkACLRule.
+func (in *NetworkACLRule) DeepCopy() *NetworkACLRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkACLRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkACLSpec) DeepCopyInto(out *NetworkACLSpec) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]NetworkACLRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]NetworkACLRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkACLSpec.
+func (in *NetworkACLSpec) DeepCopy() *NetworkACLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkACLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkACLsSpec) DeepCopyInto(out *NetworkACLsSpec) {
+	*out = *in
+	if in.Public != nil {
+		in, out := &in.Public, &out.Public
+		*out = new(NetworkACLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Private != nil {
+		in, out := &in.Private, &out.Private
+		*out = new(NetworkACLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkACLsSpec.
+func (in *NetworkACLsSpec) DeepCopy() *NetworkACLsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkACLsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(NetworkMode)
+		**out = **in
+	}
 	in.VPC.DeepCopyInto(&out.VPC)
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -1759,6 +1986,30 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.VPCEndpoints
+		*out = make(VPCEndpoints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TransitGatewayAttachment != nil {
+		in, out := &in.TransitGatewayAttachment, &out.TransitGatewayAttachment
+		*out = new(TransitGatewayAttachmentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPCPeeringConnections != nil {
+		in, out := &in.VPCPeeringConnections, &out.VPCPeeringConnections
+		*out = make(VPCPeeringConnections, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkACLs != nil {
+		in, out := &in.NetworkACLs, &out.NetworkACLs
+		*out = new(NetworkACLsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -1788,6 +2039,11 @@ func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReplacedAPIServerELB != nil {
+		in, out := &in.ReplacedAPIServerELB, &out.ReplacedAPIServerELB
+		*out = new(LoadBalancer)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
@@ -1830,6 +2086,21 @@ func (in *PrivateDNSName) DeepCopy() *PrivateDNSName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSRecord) DeepCopyInto(out *PrivateDNSRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSRecord.
+func (in *PrivateDNSRecord) DeepCopy() *PrivateDNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteTable) DeepCopyInto(out *RouteTable) {
 	*out = *in
@@ -1875,6 +2146,26 @@ func (in *S3Bucket) DeepCopy() *S3Bucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryNetworkInterface) DeepCopyInto(out *SecondaryNetworkInterface) {
+	*out = *in
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryNetworkInterface.
+func (in *SecondaryNetworkInterface) DeepCopy() *SecondaryNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
@@ -1937,6 +2228,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.NatInstanceID != nil {
+		in, out := &in.NatInstanceID, &out.NatInstanceID
+		*out = new(string)
+		**out = **in
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(Tags, len(*in))
@@ -1944,6 +2240,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.LoadBalancerRole != nil {
+		in, out := &in.LoadBalancerRole, &out.LoadBalancerRole
+		*out = new(SubnetLoadBalancerRole)
+		**out = **in
+	}
 	if in.ZoneType != nil {
 		in, out := &in.ZoneType, &out.ZoneType
 		*out = new(ZoneType)
@@ -1954,6 +2255,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OutpostARN != nil {
+		in, out := &in.OutpostARN, &out.OutpostARN
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -2081,6 +2387,16 @@ func (in *TargetGroupHealthCheckAPISpec) DeepCopyInto(out *TargetGroupHealthChec
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetGroupHealthCheckAPISpec.
@@ -2163,6 +2479,133 @@ func (in *TargetGroupSpec) DeepCopy() *TargetGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewayAttachmentSpec) DeepCopyInto(out *TransitGatewayAttachmentSpec) {
+	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteCidrBlocks != nil {
+		in, out := &in.RouteCidrBlocks, &out.RouteCidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewayAttachmentSpec.
+func (in *TransitGatewayAttachmentSpec) DeepCopy() *TransitGatewayAttachmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewayAttachmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCEndpointSpec) DeepCopyInto(out *VPCEndpointSpec) {
+	*out = *in
+	if in.PrivateDNSEnabled != nil {
+		in, out := &in.PrivateDNSEnabled, &out.PrivateDNSEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalSecurityGroups != nil {
+		in, out := &in.AdditionalSecurityGroups, &out.AdditionalSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCEndpointSpec.
+func (in *VPCEndpointSpec) DeepCopy() *VPCEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in VPCEndpoints) DeepCopyInto(out *VPCEndpoints) {
+	{
+		in := &in
+		*out = make(VPCEndpoints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCEndpoints.
+func (in VPCEndpoints) DeepCopy() VPCEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCEndpoints)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCFlowLogsSpec) DeepCopyInto(out *VPCFlowLogsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCFlowLogsSpec.
+func (in *VPCFlowLogsSpec) DeepCopy() *VPCFlowLogsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCFlowLogsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCPeeringConnectionSpec) DeepCopyInto(out *VPCPeeringConnectionSpec) {
+	*out = *in
+	if in.RouteCidrBlocks != nil {
+		in, out := &in.RouteCidrBlocks, &out.RouteCidrBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnectionSpec.
+func (in *VPCPeeringConnectionSpec) DeepCopy() *VPCPeeringConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in VPCPeeringConnections) DeepCopyInto(out *VPCPeeringConnections) {
+	{
+		in := &in
+		*out = make(VPCPeeringConnections, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCPeeringConnections.
+func (in VPCPeeringConnections) DeepCopy() VPCPeeringConnections {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCPeeringConnections)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 	*out = *in
@@ -2208,6 +2651,11 @@ func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 		*out = new(AZSelectionScheme)
 		**out = **in
 	}
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.PrivateDNSHostnameTypeOnLaunch != nil {
 		in, out := &in.PrivateDNSHostnameTypeOnLaunch, &out.PrivateDNSHostnameTypeOnLaunch
 		*out = new(string)
@@ -2218,11 +2666,36 @@ func (in *VPCSpec) DeepCopyInto(out *VPCSpec) {
 		*out = new(ElasticIPPool)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NatGatewayElasticIPAllocationIDs != nil {
+		in, out := &in.NatGatewayElasticIPAllocationIDs, &out.NatGatewayElasticIPAllocationIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SubnetSchema != nil {
 		in, out := &in.SubnetSchema, &out.SubnetSchema
 		*out = new(SubnetSchemaType)
 		**out = **in
 	}
+	if in.NatGatewayMode != nil {
+		in, out := &in.NatGatewayMode, &out.NatGatewayMode
+		*out = new(NatGatewayMode)
+		**out = **in
+	}
+	if in.NatInstance != nil {
+		in, out := &in.NatInstance, &out.NatInstance
+		*out = new(NatInstanceSpec)
+		**out = **in
+	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(VPCFlowLogsSpec)
+		**out = **in
+	}
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = new(DHCPOptionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCSpec.
@@ -61,6 +61,7 @@ func (r *EKSConfig) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.NTP != nil {
 		dst.Spec.NTP = restored.Spec.NTP
 	}
+	dst.Spec.OperatingSystem = restored.Spec.OperatingSystem
 
 	return nil
 }
@@ -128,6 +129,7 @@ func (r *EKSConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Template.Spec.NTP != nil {
 		dst.Spec.Template.Spec.NTP = restored.Spec.Template.Spec.NTP
 	}
+	dst.Spec.Template.Spec.OperatingSystem = restored.Spec.Template.Spec.OperatingSystem
 
 	return nil
 }
@@ -238,6 +238,7 @@ func autoConvert_v1beta2_EKSConfigSpec_To_v1beta1_EKSConfigSpec(in *v1beta2.EKSC
 	// WARNING: in.Mounts requires manual conversion: does not exist in peer-type
 	// WARNING: in.Users requires manual conversion: does not exist in peer-type
 	// WARNING: in.NTP requires manual conversion: does not exist in peer-type
+	// WARNING: in.OperatingSystem requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -74,8 +74,24 @@ type EKSConfigSpec struct {
 	// NTP specifies NTP configuration
 	// +optional
 	NTP *NTP `json:"ntp,omitempty"`
+	// OperatingSystem is the operating system of the nodes, which determines the format of the bootstrap data.
+	// linux generates cloud-init bootstrap data, windows generates a PowerShell script running the
+	// bootstrap script of the EKS optimized Windows AMIs. Defaults to linux.
+	// +kubebuilder:validation:Enum=linux;windows
+	// +optional
+	OperatingSystem OperatingSystem `json:"operatingSystem,omitempty"`
 }
 
+// OperatingSystem is the operating system of the nodes bootstrapped with an EKSConfig.
+type OperatingSystem string
+
+const (
+	// OperatingSystemLinux is the operating system of nodes using the EKS optimized Linux AMIs.
+	OperatingSystemLinux = OperatingSystem("linux")
+	// OperatingSystemWindows is the operating system of nodes using the EKS optimized Windows AMIs.
+	OperatingSystemWindows = OperatingSystem("windows")
+)
+
 // PauseContainer contains details of pause container.
 type PauseContainer struct {
 	//  AccountNumber is the AWS account number to pull the pause container from.
@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta2
 
 import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -38,12 +40,53 @@ var _ webhook.Validator = &EKSConfig{}
 
 // ValidateCreate will do any extra validation when creating a EKSConfig.
 func (r *EKSConfig) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
 }
 
 // ValidateUpdate will do any extra validation when updating a EKSConfig.
 func (r *EKSConfig) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+	return nil, r.validate()
+}
+
+func (r *EKSConfig) validate() error {
+	allErrs := r.Spec.validateOperatingSystem(field.NewPath("spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("EKSConfig").GroupKind(), r.Name, allErrs)
+}
+
+// validateOperatingSystem rejects the fields which are only supported by the bootstrap data of Linux
+// nodes when the bootstrap data of Windows nodes is generated.
+func (s *EKSConfigSpec) validateOperatingSystem(fldPath *field.Path) field.ErrorList {
+	if s.OperatingSystem != OperatingSystemWindows {
+		return nil
+	}
+
+	linuxOnlyFields := []struct {
+		name string
+		set  bool
+	}{
+		{"dockerConfigJson", s.DockerConfigJSON != nil},
+		{"apiRetryAttempts", s.APIRetryAttempts != nil},
+		{"pauseContainer", s.PauseContainer != nil},
+		{"useMaxPods", s.UseMaxPods != nil},
+		{"serviceIPV6Cidr", s.ServiceIPV6Cidr != nil},
+		{"files", len(s.Files) > 0},
+		{"diskSetup", s.DiskSetup != nil},
+		{"mounts", len(s.Mounts) > 0},
+		{"users", len(s.Users) > 0},
+		{"ntp", s.NTP != nil},
+	}
+
+	var allErrs field.ErrorList
+	for _, f := range linuxOnlyFields {
+		if f.set {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(f.name), "not supported for the windows operating system"))
+		}
+	}
+	return allErrs
 }
 
 // ValidateDelete allows you to add any extra validation when deleting.
@@ -20,6 +20,8 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -271,8 +273,22 @@ func (r *EKSConfigReconciler) joinWorker(ctx context.Context, cluster *clusterv1
 		nodeInput.IPFamily = ptr.To[string]("ipv6")
 	}
 
+	// Self-managed nodes (AWSMachines) get the cluster connection details from the
+	// control plane so the bootstrap script doesn't need to describe the EKS cluster.
+	if configOwner.GetKind() == "Machine" {
+		if err := setSelfManagedNodeInput(nodeInput, controlPlane); err != nil {
+			log.Error(err, "Failed to resolve cluster details from the control plane")
+			conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+			return err
+		}
+	}
+
 	// generate userdata
-	userDataScript, err := userdata.NewNode(nodeInput)
+	newNode := userdata.NewNode
+	if config.Spec.OperatingSystem == eksbootstrapv1.OperatingSystemWindows {
+		newNode = userdata.NewWindowsNode
+	}
+	userDataScript, err := newNode(nodeInput)
 	if err != nil {
 		log.Error(err, "Failed to create a worker join configuration")
 		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
@@ -289,6 +305,53 @@ func (r *EKSConfigReconciler) joinWorker(ctx context.Context, cluster *clusterv1
 	return nil
 }
 
+// setSelfManagedNodeInput populates the API server endpoint, cluster CA and DNS cluster IP
+// of the node input from the AWSManagedControlPlane, leaving user provided values untouched.
+func setSelfManagedNodeInput(nodeInput *userdata.NodeInput, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) error {
+	if controlPlane.Status.CertificateAuthorityData == nil || !controlPlane.Spec.ControlPlaneEndpoint.IsValid() {
+		return nil
+	}
+
+	endpoint := controlPlane.Spec.ControlPlaneEndpoint.Host
+	if !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+	nodeInput.APIServerEndpoint = endpoint
+	nodeInput.B64ClusterCA = *controlPlane.Status.CertificateAuthorityData
+
+	// IPv6 clusters derive the DNS cluster IP from the service IPv6 CIDR instead.
+	if nodeInput.DNSClusterIP != nil || nodeInput.IPFamily != nil || controlPlane.Status.ServiceCIDR == nil {
+		return nil
+	}
+
+	dnsClusterIP, err := dnsClusterIPFromServiceCIDR(*controlPlane.Status.ServiceCIDR)
+	if err != nil {
+		return err
+	}
+	nodeInput.DNSClusterIP = &dnsClusterIP
+
+	return nil
+}
+
+// dnsClusterIPFromServiceCIDR returns the IP address EKS assigns to the cluster DNS service,
+// which is the tenth address of the service CIDR.
+func dnsClusterIPFromServiceCIDR(serviceCIDR string) (string, error) {
+	prefix, err := netip.ParsePrefix(serviceCIDR)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse service CIDR %q", serviceCIDR)
+	}
+
+	addr := prefix.Masked().Addr()
+	for range 10 {
+		addr = addr.Next()
+	}
+	if !prefix.Contains(addr) {
+		return "", errors.Errorf("service CIDR %q is too small to contain the DNS cluster IP", serviceCIDR)
+	}
+
+	return addr.String(), nil
+}
+
 func (r *EKSConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, option controller.Options) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&eksbootstrapv1.EKSConfig{}).
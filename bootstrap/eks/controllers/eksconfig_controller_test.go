@@ -22,7 +22,10 @@ import (
 
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 
+	"sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/internal/userdata"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
 )
@@ -69,6 +72,86 @@ func TestEKSConfigReconcilerReturnEarlyIfClusterControlPlaneNotInitialized(t *te
 	}).Should(Succeed())
 }
 
+func TestSetSelfManagedNodeInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *userdata.NodeInput
+		status    ekscontrolplanev1.AWSManagedControlPlaneStatus
+		endpoint  clusterv1.APIEndpoint
+		expected  *userdata.NodeInput
+		expectErr bool
+	}{
+		{
+			name:     "control plane without certificate authority data",
+			input:    &userdata.NodeInput{ClusterName: "test-cluster"},
+			endpoint: clusterv1.APIEndpoint{Host: "https://abc.eks.amazonaws.com", Port: 443},
+			expected: &userdata.NodeInput{ClusterName: "test-cluster"},
+		},
+		{
+			name:  "endpoint, cluster ca and dns cluster ip from control plane",
+			input: &userdata.NodeInput{ClusterName: "test-cluster"},
+			status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+				CertificateAuthorityData: ptr.To[string]("Y2EtZGF0YQ=="),
+				ServiceCIDR:              ptr.To[string]("172.20.0.0/16"),
+			},
+			endpoint: clusterv1.APIEndpoint{Host: "https://abc.eks.amazonaws.com", Port: 443},
+			expected: &userdata.NodeInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://abc.eks.amazonaws.com",
+				B64ClusterCA:      "Y2EtZGF0YQ==",
+				DNSClusterIP:      ptr.To[string]("172.20.0.10"),
+			},
+		},
+		{
+			name: "user provided dns cluster ip is kept",
+			input: &userdata.NodeInput{
+				ClusterName:  "test-cluster",
+				DNSClusterIP: ptr.To[string]("10.100.0.10"),
+			},
+			status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+				CertificateAuthorityData: ptr.To[string]("Y2EtZGF0YQ=="),
+				ServiceCIDR:              ptr.To[string]("172.20.0.0/16"),
+			},
+			endpoint: clusterv1.APIEndpoint{Host: "abc.eks.amazonaws.com", Port: 443},
+			expected: &userdata.NodeInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://abc.eks.amazonaws.com",
+				B64ClusterCA:      "Y2EtZGF0YQ==",
+				DNSClusterIP:      ptr.To[string]("10.100.0.10"),
+			},
+		},
+		{
+			name:  "invalid service cidr",
+			input: &userdata.NodeInput{ClusterName: "test-cluster"},
+			status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+				CertificateAuthorityData: ptr.To[string]("Y2EtZGF0YQ=="),
+				ServiceCIDR:              ptr.To[string]("not-a-cidr"),
+			},
+			endpoint:  clusterv1.APIEndpoint{Host: "https://abc.eks.amazonaws.com", Port: 443},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				Spec:   ekscontrolplanev1.AWSManagedControlPlaneSpec{ControlPlaneEndpoint: tc.endpoint},
+				Status: tc.status,
+			}
+
+			err := setSelfManagedNodeInput(tc.input, controlPlane)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(tc.input).To(Equal(tc.expected))
+		})
+	}
+}
+
 func configOwner(kind string) *bsutil.ConfigOwner {
 	unstructuredOwner := unstructured.Unstructured{
 		Object: map[string]interface{}{"kind": kind},
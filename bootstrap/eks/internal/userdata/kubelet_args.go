@@ -17,6 +17,8 @@ limitations under the License.
 package userdata
 
 const argsTemplate = `{{- define "args" -}}
+{{- if .APIServerEndpoint }} --apiserver-endpoint {{.APIServerEndpoint}}{{- end -}}
+{{- if .B64ClusterCA }} --b64-cluster-ca {{.B64ClusterCA}}{{- end -}}
 {{- if .KubeletExtraArgs }} --kubelet-extra-args '{{ template "kubeletArgsTemplate" .KubeletExtraArgs }}'
 {{- end -}}
 {{- if .ContainerRuntime }} --container-runtime {{.ContainerRuntime}}{{- end -}}
@@ -46,6 +46,8 @@ runcmd:
 // NodeInput defines the context to generate a node user data.
 type NodeInput struct {
 	ClusterName           string
+	APIServerEndpoint     string
+	B64ClusterCA          string
 	KubeletExtraArgs      map[string]string
 	ContainerRuntime      *string
 	DNSClusterIP          *string
@@ -174,6 +174,21 @@ runcmd:
 write_files:
 runcmd:
   - /etc/eks/bootstrap.sh test-cluster --dns-cluster-ip 192.168.0.1
+`),
+		},
+		{
+			name: "with api server endpoint and cluster ca",
+			args: args{
+				input: &NodeInput{
+					ClusterName:       "test-cluster",
+					APIServerEndpoint: "https://ABCDEF.gr7.eu-west-1.eks.amazonaws.com",
+					B64ClusterCA:      "Y2EtZGF0YQ==",
+				},
+			},
+			expectedBytes: []byte(`#cloud-config
+write_files:
+runcmd:
+  - /etc/eks/bootstrap.sh test-cluster --apiserver-endpoint https://ABCDEF.gr7.eu-west-1.eks.amazonaws.com --b64-cluster-ca Y2EtZGF0YQ==
 `),
 		},
 		{
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+const (
+	defaultWindowsBootstrapCommand = `$env:ProgramFiles\Amazon\EKS\Start-EKSBootstrap.ps1`
+
+	windowsArgsTemplate = `{{- define "windowsArgs" -}}
+{{- if .APIServerEndpoint }} -APIServerEndpoint '{{.APIServerEndpoint}}'{{- end -}}
+{{- if .B64ClusterCA }} -Base64ClusterCA '{{.B64ClusterCA}}'{{- end -}}
+{{- if .DNSClusterIP }} -DNSClusterIP '{{.DNSClusterIP}}'{{- end -}}
+{{- if .ContainerRuntime }} -ContainerRuntime '{{.ContainerRuntime}}'{{- end -}}
+{{- if .KubeletExtraArgs }} -KubeletExtraArgs '{{ template "kubeletArgsTemplate" .KubeletExtraArgs }}'{{- end -}}
+{{- end -}}`
+
+	windowsNodeUserData = `<powershell>
+{{- range .PreBootstrapCommands }}
+{{ . }}
+{{- end }}
+[string]$EKSBootstrapScriptFile = "{{ .WindowsBootstrapCommand }}"
+& $EKSBootstrapScriptFile -EKSClusterName '{{.ClusterName}}' {{- template "windowsArgs" . }} 3>&1 4>&1
+{{- range .PostBootstrapCommands }}
+{{ . }}
+{{- end }}
+</powershell>
+`
+)
+
+// WindowsBootstrapCommand returns the bootstrap script to be run on a Windows node instance.
+func (ni *NodeInput) WindowsBootstrapCommand() string {
+	if ni.BootstrapCommandOverride != nil && *ni.BootstrapCommandOverride != "" {
+		return *ni.BootstrapCommandOverride
+	}
+
+	return defaultWindowsBootstrapCommand
+}
+
+// NewWindowsNode returns the user data to be used on a Windows node instance. It is a PowerShell
+// script running the bootstrap script of the EKS optimized Windows AMIs, the pre and post bootstrap
+// commands are run as PowerShell commands.
+func NewWindowsNode(input *NodeInput) ([]byte, error) {
+	tm := template.New("WindowsNode")
+
+	if _, err := tm.Parse(windowsArgsTemplate); err != nil {
+		return nil, fmt.Errorf("failed to parse windows args template: %w", err)
+	}
+
+	if _, err := tm.Parse(kubeletArgsTemplate); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeletExtraArgs template: %w", err)
+	}
+
+	t, err := tm.Parse(windowsNodeUserData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse WindowsNode template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, input); err != nil {
+		return nil, fmt.Errorf("failed to generate WindowsNode template: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewWindowsNode(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *NodeInput
+		expectedBytes []byte
+	}{
+		{
+			name: "only cluster name",
+			input: &NodeInput{
+				ClusterName: "test-cluster",
+			},
+			expectedBytes: []byte(`<powershell>
+[string]$EKSBootstrapScriptFile = "$env:ProgramFiles\Amazon\EKS\Start-EKSBootstrap.ps1"
+& $EKSBootstrapScriptFile -EKSClusterName 'test-cluster' 3>&1 4>&1
+</powershell>
+`),
+		},
+		{
+			name: "with cluster details and kubelet extra args",
+			input: &NodeInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				B64ClusterCA:      "Y2E=",
+				DNSClusterIP:      ptr.To[string]("10.100.0.10"),
+				ContainerRuntime:  ptr.To[string]("containerd"),
+				KubeletExtraArgs: map[string]string{
+					"node-labels":          "os=windows",
+					"register-with-taints": "os=windows:NoSchedule",
+				},
+			},
+			expectedBytes: []byte(`<powershell>
+[string]$EKSBootstrapScriptFile = "$env:ProgramFiles\Amazon\EKS\Start-EKSBootstrap.ps1"
+& $EKSBootstrapScriptFile -EKSClusterName 'test-cluster' -APIServerEndpoint 'https://example.com' -Base64ClusterCA 'Y2E=' -DNSClusterIP '10.100.0.10' -ContainerRuntime 'containerd' -KubeletExtraArgs '--node-labels=os=windows --register-with-taints=os=windows:NoSchedule' 3>&1 4>&1
+</powershell>
+`),
+		},
+		{
+			name: "with pre and post bootstrap commands and a bootstrap command override",
+			input: &NodeInput{
+				ClusterName:              "test-cluster",
+				PreBootstrapCommands:     []string{"Write-Output 'pre'"},
+				PostBootstrapCommands:    []string{"Write-Output 'post'"},
+				BootstrapCommandOverride: ptr.To[string](`C:\bootstrap.ps1`),
+			},
+			expectedBytes: []byte(`<powershell>
+Write-Output 'pre'
+[string]$EKSBootstrapScriptFile = "C:\bootstrap.ps1"
+& $EKSBootstrapScriptFile -EKSClusterName 'test-cluster' 3>&1 4>&1
+Write-Output 'post'
+</powershell>
+`),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			bytes, err := NewWindowsNode(tc.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(bytes)).To(Equal(string(tc.expectedBytes)))
+		})
+	}
+}
@@ -90,6 +90,10 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:AssignPrivateIpAddresses",
 				"ec2:UnassignPrivateIpAddresses",
 				"ec2:AssociateRouteTable",
+				"ec2:AssociateDhcpOptions",
+				"ec2:ReplaceRouteTableAssociation",
+				"ec2:ReplaceNetworkAclAssociation",
+				"ec2:ReplaceNetworkAclEntry",
 				"ec2:AssociateVpcCidrBlock",
 				"ec2:AttachInternetGateway",
 				"ec2:AuthorizeSecurityGroupIngress",
@@ -97,6 +101,10 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateInternetGateway",
 				"ec2:CreateEgressOnlyInternetGateway",
 				"ec2:CreateNatGateway",
+				"ec2:CreateFlowLogs",
+				"ec2:CreateDhcpOptions",
+				"ec2:CreateNetworkAcl",
+				"ec2:CreateNetworkAclEntry",
 				"ec2:CreateNetworkInterface",
 				"ec2:CreateRoute",
 				"ec2:CreateRouteTable",
@@ -105,45 +113,64 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:CreateTags",
 				"ec2:CreateVpc",
 				"ec2:CreateVpcEndpoint",
+				"ec2:CreateTransitGatewayVpcAttachment",
+				"ec2:CreateVpcPeeringConnection",
+				"ec2:AcceptVpcPeeringConnection",
 				"ec2:DisassociateVpcCidrBlock",
 				"ec2:ModifyVpcAttribute",
 				"ec2:ModifyVpcEndpoint",
+				"ec2:ModifyTransitGatewayVpcAttachment",
 				"ec2:DeleteCarrierGateway",
 				"ec2:DeleteInternetGateway",
 				"ec2:DeleteEgressOnlyInternetGateway",
 				"ec2:DeleteNatGateway",
+				"ec2:DeleteFlowLogs",
+				"ec2:DeleteDhcpOptions",
+				"ec2:DeleteNetworkAcl",
+				"ec2:DeleteNetworkAclEntry",
 				"ec2:DeleteRouteTable",
+				"ec2:DeleteRoute",
 				"ec2:ReplaceRoute",
 				"ec2:DeleteSecurityGroup",
 				"ec2:DeleteSubnet",
 				"ec2:DeleteTags",
 				"ec2:DeleteVpc",
 				"ec2:DeleteVpcEndpoints",
+				"ec2:DeleteTransitGatewayVpcAttachment",
+				"ec2:DeleteVpcPeeringConnection",
 				"ec2:DescribeAccountAttributes",
 				"ec2:DescribeAddresses",
 				"ec2:DescribeAvailabilityZones",
 				"ec2:DescribeCarrierGateways",
 				"ec2:DescribeInstances",
 				"ec2:DescribeInstanceTypes",
+				"ec2:DescribeInstanceTypeOfferings",
 				"ec2:DescribeInternetGateways",
 				"ec2:DescribeEgressOnlyInternetGateways",
 				"ec2:DescribeInstanceTypes",
 				"ec2:DescribeImages",
+				"ec2:DescribeManagedPrefixLists",
 				"ec2:DescribeNatGateways",
+				"ec2:DescribeFlowLogs",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DescribeNetworkInterfaceAttribute",
 				"ec2:DescribeRouteTables",
 				"ec2:DescribeSecurityGroups",
+				"ec2:DescribeSecurityGroupRules",
 				"ec2:DescribeSubnets",
 				"ec2:DescribeVpcs",
 				"ec2:DescribeDhcpOptions",
+				"ec2:DescribeNetworkAcls",
 				"ec2:DescribeVpcAttribute",
 				"ec2:DescribeVpcEndpoints",
+				"ec2:DescribeTransitGatewayVpcAttachments",
+				"ec2:DescribeVpcPeeringConnections",
 				"ec2:DescribeVolumes",
 				"ec2:DescribeTags",
 				"ec2:DetachInternetGateway",
 				"ec2:DisassociateRouteTable",
 				"ec2:DisassociateAddress",
+				"ec2:GetEbsEncryptionByDefault",
 				"ec2:ModifyInstanceAttribute",
 				"ec2:ModifyNetworkInterfaceAttribute",
 				"ec2:ModifySubnetAttribute",
@@ -151,6 +178,8 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"ec2:RevokeSecurityGroupIngress",
 				"ec2:RunInstances",
 				"ec2:TerminateInstances",
+				"logs:CreateLogDelivery",
+				"logs:DeleteLogDelivery",
 				"tag:GetResources",
 				"elasticloadbalancing:AddTags",
 				"elasticloadbalancing:CreateLoadBalancer",
@@ -173,6 +202,8 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"elasticloadbalancing:DescribeListeners",
 				"elasticloadbalancing:CreateListener",
 				"elasticloadbalancing:DescribeTargetHealth",
+				"elasticloadbalancing:DescribeInstanceHealth",
+				"elasticloadbalancing:DeregisterTargets",
 				"elasticloadbalancing:RegisterTargets",
 				"elasticloadbalancing:DeleteListener",
 				"autoscaling:DescribeAutoScalingGroups",
@@ -201,6 +232,26 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"autoscaling:DeleteTags",
 			},
 		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:route53:::hostedzone/*",
+			},
+			Action: iamv1.Actions{
+				"route53:GetHostedZone",
+				"route53:ListResourceRecordSets",
+				"route53:ChangeResourceRecordSets",
+			},
+		},
+		{
+			Effect: iamv1.EffectAllow,
+			Resource: iamv1.Resources{
+				"arn:*:route53:::change/*",
+			},
+			Action: iamv1.Actions{
+				"route53:GetChange",
+			},
+		},
 		{
 			Effect: iamv1.EffectAllow,
 			Resource: iamv1.Resources{
@@ -244,6 +295,16 @@ func (t Template) ControllersPolicy() *iamv1.PolicyDocument {
 				"iam:PassRole",
 			},
 		},
+		{
+			Effect:   iamv1.EffectAllow,
+			Resource: iamv1.Resources{iamv1.Any},
+			Action: iamv1.Actions{
+				"iam:PassRole",
+			},
+			Condition: iamv1.Conditions{
+				iamv1.StringEquals: map[string]string{"iam:PassedToService": "vpc-flow-logs.amazonaws.com"},
+			},
+		},
 	}
 	for _, secureSecretBackend := range t.Spec.SecureSecretsBackends {
 		switch secureSecretBackend {
@@ -390,6 +451,10 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 			"iam:CreateRole",
 			"iam:TagRole",
 			"iam:AttachRolePolicy",
+			"iam:GetRolePolicy",
+			"iam:PutRolePolicy",
+			"iam:ListRolePolicies",
+			"iam:DeleteRolePolicy",
 		}...)
 
 		statements = append(statements, iamv1.StatementEntry{
@@ -467,6 +532,20 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 				"eks:DescribeFargateProfile",
 				"eks:CreateFargateProfile",
 				"eks:DeleteFargateProfile",
+				"eks:ListAccessEntries",
+				"eks:CreateAccessEntry",
+				"eks:DescribeAccessEntry",
+				"eks:UpdateAccessEntry",
+				"eks:DeleteAccessEntry",
+				"eks:ListAssociatedAccessPolicies",
+				"eks:AssociateAccessPolicy",
+				"eks:DisassociateAccessPolicy",
+				"eks:ListPodIdentityAssociations",
+				"eks:CreatePodIdentityAssociation",
+				"eks:DescribePodIdentityAssociation",
+				"eks:UpdatePodIdentityAssociation",
+				"eks:DeletePodIdentityAssociation",
+				"servicequotas:GetServiceQuota",
 			},
 			Resource: iamv1.Resources{
 				"*",
@@ -487,6 +566,20 @@ func (t Template) ControllersPolicyEKS() *iamv1.PolicyDocument {
 			},
 			Effect: iamv1.EffectAllow,
 		},
+		{
+			Action: iamv1.Actions{
+				"iam:PassRole",
+			},
+			Resource: iamv1.Resources{
+				"*",
+			},
+			Condition: iamv1.Conditions{
+				"StringEquals": map[string]string{
+					"iam:PassedToService": "pods.eks.amazonaws.com",
+				},
+			},
+			Effect: iamv1.EffectAllow,
+		},
 		{
 			Action: iamv1.Actions{
 				"kms:CreateGrant",
@@ -19,6 +19,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -37,6 +39,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -44,6 +47,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/gc"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/s3"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -115,6 +119,9 @@ func securityGroupRolesForCluster(scope scope.ClusterScope) []infrav1.SecurityGr
 	if scope.Bastion().Enabled {
 		roles = append(roles, infrav1.SecurityGroupBastion)
 	}
+	if len(scope.VPCEndpoints().FilterInterface()) > 0 {
+		roles = append(roles, infrav1.SecurityGroupVPCEndpoint)
+	}
 	return roles
 }
 
@@ -129,8 +136,10 @@ func (r *AWSClusterReconciler) getSecurityGroupService(scope scope.ClusterScope)
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusterroleidentities;awsclusterstaticidentities,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclustercontrolleridentities,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
 
 func (r *AWSClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := logger.FromContext(ctx)
@@ -225,9 +234,14 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 	networkSvc := r.getNetworkService(*clusterScope)
 	sgService := r.getSecurityGroupService(*clusterScope)
 	s3Service := s3.NewService(clusterScope)
+	route53Service := route53.NewService(clusterScope)
 
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		instancestateSvc := instancestate.NewService(clusterScope)
+		// The spot interruption rule is deleted even with the feature gate disabled, it may have been enabled before.
+		if err := instancestateSvc.DeleteSpotInterruptionEvents(); err != nil {
+			clusterScope.Error(err, "non-fatal: failed to delete EventBridge spot interruption notifications")
+		}
 		if err := instancestateSvc.DeleteEC2Events(); err != nil {
 			// Not deleting the events isn't critical to cluster deletion
 			clusterScope.Error(err, "non-fatal: failed to delete EventBridge notifications")
@@ -247,6 +261,17 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting S3 Bucket"))
 	}
 
+	// The record points at the control plane load balancer, it has to be deleted while the load balancer is known.
+	if err := route53Service.DeleteAPIServerPrivateDNSRecord(); err != nil {
+		allErrs = append(allErrs, errors.Wrapf(err, "error deleting API server private DNS record"))
+	}
+
+	if endpointDNS := clusterScope.AWSCluster.Spec.ControlPlaneEndpointDNS; endpointDNS != nil {
+		if err := route53Service.DeleteControlPlaneEndpointDNSRecord(endpointDNS, &clusterScope.AWSCluster.Status.Network.APIServerELB); err != nil {
+			allErrs = append(allErrs, errors.Wrapf(err, "error deleting control plane endpoint DNS record"))
+		}
+	}
+
 	if err := elbsvc.DeleteLoadbalancers(); err != nil {
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting load balancers"))
 	}
@@ -255,6 +280,20 @@ func (r *AWSClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 		allErrs = append(allErrs, errors.Wrapf(err, "error deleting bastion"))
 	}
 
+	// The interface VPC endpoints use one of the security groups, they have to be deleted first.
+	if len(clusterScope.VPCEndpoints().FilterInterface()) > 0 {
+		if err := networkSvc.DeleteVPCEndpoints(); err != nil {
+			allErrs = append(allErrs, errors.Wrap(err, "error deleting vpc endpoints"))
+		}
+	}
+
+	// The NAT instances use one of the security groups, they have to be terminated first.
+	if clusterScope.VPC().IsNatInstanceEnabled() {
+		if err := networkSvc.DeleteNatInstances(); err != nil {
+			allErrs = append(allErrs, errors.Wrap(err, "error deleting nat instances"))
+		}
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		allErrs = append(allErrs, errors.Wrap(err, "error deleting security groups"))
 	}
@@ -303,14 +342,62 @@ func (r *AWSClusterReconciler) reconcileLoadBalancer(clusterScope *scope.Cluster
 
 	conditions.MarkTrue(awsCluster, infrav1.LoadBalancerReadyCondition)
 
+	host := awsCluster.Status.Network.APIServerELB.DNSName
+	if endpointDNS := awsCluster.Spec.ControlPlaneEndpointDNS; endpointDNS != nil {
+		// The hosted zone of a classic load balancer is only known once it has been described after its creation.
+		if awsCluster.Status.Network.APIServerELB.CanonicalHostedZoneID == "" {
+			conditions.MarkFalse(awsCluster, infrav1.ControlPlaneEndpointDNSReadyCondition, infrav1.WaitForCanonicalHostedZoneIDReason, clusterv1.ConditionSeverityInfo, "")
+			clusterScope.Info("Waiting on API server ELB hosted zone ID")
+			return &retryAfterDuration, nil
+		}
+
+		requeue, err := route53.NewService(clusterScope).ReconcileControlPlaneEndpointDNSRecord(endpointDNS, &awsCluster.Status.Network.APIServerELB, &awsCluster.Status.Network.ControlPlaneEndpointDNSChangeID)
+		if err != nil {
+			conditions.MarkFalse(awsCluster, infrav1.ControlPlaneEndpointDNSReadyCondition, infrav1.ControlPlaneEndpointDNSFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			return nil, errors.Wrapf(err, "failed to reconcile control plane endpoint DNS record for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+		}
+		if requeue {
+			conditions.MarkFalse(awsCluster, infrav1.ControlPlaneEndpointDNSReadyCondition, infrav1.WaitForControlPlaneEndpointDNSPropagationReason, clusterv1.ConditionSeverityInfo, "")
+			clusterScope.Info("Waiting on the control plane endpoint alias record to propagate")
+			return &retryAfterDuration, nil
+		}
+		conditions.MarkTrue(awsCluster, infrav1.ControlPlaneEndpointDNSReadyCondition)
+		host = endpointDNS.Name
+	}
+
 	awsCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
-		Host: awsCluster.Status.Network.APIServerELB.DNSName,
+		Host: host,
 		Port: clusterScope.APIServerPort(),
 	}
 
+	reconcileSecondaryControlPlaneEndpointAnnotation(awsCluster, clusterScope.APIServerPort())
+
+	// Cluster API copies the endpoint from the AWSCluster only once, so it has to be updated after the
+	// load balancer was replaced following a scheme change.
+	if cluster := clusterScope.Cluster; cluster.Spec.ControlPlaneEndpoint.IsValid() && cluster.Spec.ControlPlaneEndpoint != awsCluster.Spec.ControlPlaneEndpoint &&
+		awsCluster.Annotations[infrav1.AllowControlPlaneLoadBalancerSchemeChangeAnnotation] == "true" {
+		clusterPatch := client.MergeFrom(cluster.DeepCopy())
+		cluster.Spec.ControlPlaneEndpoint = awsCluster.Spec.ControlPlaneEndpoint
+		if err := r.Client.Patch(context.TODO(), cluster, clusterPatch); err != nil {
+			return nil, errors.Wrapf(err, "failed to update the control plane endpoint of Cluster %s", cluster.Name)
+		}
+		clusterScope.Info("Updated the control plane endpoint of the Cluster", "endpoint", cluster.Spec.ControlPlaneEndpoint.String())
+	}
+
 	return nil, nil
 }
 
+// reconcileSecondaryControlPlaneEndpointAnnotation publishes the endpoint of the secondary control plane load balancer
+// in an annotation of the AWSCluster, and removes the annotation when there is no secondary load balancer.
+func reconcileSecondaryControlPlaneEndpointAnnotation(awsCluster *infrav1.AWSCluster, port int32) {
+	host := awsCluster.Status.Network.SecondaryAPIServerELB.DNSName
+	if awsCluster.Spec.SecondaryControlPlaneLoadBalancer == nil || host == "" {
+		annotations.Delete(awsCluster, infrav1.SecondaryControlPlaneEndpointAnnotation)
+		return
+	}
+	annotations.Set(awsCluster, infrav1.SecondaryControlPlaneEndpointAnnotation, net.JoinHostPort(host, strconv.Itoa(int(port))))
+}
+
 func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling AWSCluster")
 
@@ -340,6 +427,11 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{}, err
 	}
 
+	if err := networkSvc.ReconcileVPCEndpoints(); err != nil {
+		clusterScope.Error(err, "failed to reconcile vpc endpoints")
+		return reconcile.Result{}, err
+	}
+
 	if err := ec2Service.ReconcileBastion(); err != nil {
 		conditions.MarkFalse(awsCluster, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, infrautilconditions.ErrorConditionAfterInit(clusterScope.ClusterObj()), "%s", err.Error())
 		clusterScope.Error(err, "failed to reconcile bastion host")
@@ -351,6 +443,13 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		if err := instancestateSvc.ReconcileEC2Events(); err != nil {
 			// non fatal error, so we continue
 			clusterScope.Error(err, "non-fatal: failed to set up EventBridge")
+		} else if feature.Gates.Enabled(feature.SpotInterruptionHandling) {
+			if err := instancestateSvc.ReconcileSpotInterruptionEvents(); err != nil {
+				clusterScope.Error(err, "non-fatal: failed to set up EventBridge spot interruption notifications")
+			}
+		} else if err := instancestateSvc.DeleteSpotInterruptionEvents(); err != nil {
+			// The rule of a previously enabled feature gate is removed.
+			clusterScope.Error(err, "non-fatal: failed to delete EventBridge spot interruption notifications")
 		}
 	}
 
@@ -360,6 +459,14 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		return reconcile.Result{RequeueAfter: *requeueAfter}, err
 	}
 
+	if clusterScope.APIServerPrivateDNSRecord() != nil {
+		if err := route53.NewService(clusterScope).ReconcileAPIServerPrivateDNSRecord(); err != nil {
+			conditions.MarkFalse(awsCluster, infrav1.APIServerPrivateDNSRecordReadyCondition, infrav1.APIServerPrivateDNSRecordFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile API server private DNS record for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
+		}
+		conditions.MarkTrue(awsCluster, infrav1.APIServerPrivateDNSRecordReadyCondition)
+	}
+
 	if err := s3Service.ReconcileBucket(); err != nil {
 		conditions.MarkFalse(awsCluster, infrav1.S3BucketReadyCondition, infrav1.S3BucketFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile S3 Bucket for AWSCluster %s/%s", awsCluster.Namespace, awsCluster.Name)
@@ -380,6 +487,21 @@ func (r *AWSClusterReconciler) reconcileNormal(clusterScope *scope.ClusterScope)
 		})
 	}
 
+	// Subnets in AWS Local Zones or Wavelength Zones are never used for the control plane, and only host the
+	// machines whose failure domain is explicitly set to their zone.
+	for _, subnet := range clusterScope.Subnets().FilterEdge() {
+		clusterScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
+			ControlPlane: false,
+		})
+	}
+
+	if feature.Gates.Enabled(feature.ClusterResourceInventory) {
+		if err := r.reconcileInventory(context.TODO(), clusterScope); err != nil {
+			// non fatal error, so we continue
+			clusterScope.Error(err, "non-fatal: failed to reconcile resource inventory")
+		}
+	}
+
 	awsCluster.Status.Ready = true
 	return reconcile.Result{}, nil
 }
@@ -992,6 +992,23 @@ func mockedCallsForMissingEverything(m *mocks.MockEC2APIMockRecorder, e *mocks.M
 		EnableDnsSupport: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
 	}, nil)
 
+	m.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String("vpc-new")},
+	})).Return(&ec2.DescribeVpcsOutput{
+		Vpcs: []*ec2.Vpc{
+			{
+				VpcId:     aws.String("vpc-new"),
+				CidrBlock: aws.String("10.0.0.0/8"),
+				CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+					{
+						CidrBlock:      aws.String("10.0.0.0/8"),
+						CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)},
+					},
+				},
+			},
+		},
+	}, nil)
+
 	m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
 			{
@@ -199,6 +199,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
+					networkSvc.EXPECT().ReconcileVPCEndpoints().Return(nil)
 				}
 
 				awsCluster := getAWSCluster("test", "test")
@@ -255,6 +256,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
+					networkSvc.EXPECT().ReconcileVPCEndpoints().Return(nil)
 				}
 
 				awsCluster := getAWSCluster("test", "test")
@@ -328,6 +330,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				runningCluster := func() {
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
+					networkSvc.EXPECT().ReconcileVPCEndpoints().Return(nil)
 					ec2Svc.EXPECT().ReconcileBastion().Return(expectedErr)
 				}
 				csClient := setup(t, &awsCluster)
@@ -351,6 +354,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				runningCluster := func() {
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
+					networkSvc.EXPECT().ReconcileVPCEndpoints().Return(nil)
 					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(expectedErr)
 				}
@@ -375,6 +379,7 @@ func TestAWSClusterReconcileOperations(t *testing.T) {
 				runningCluster := func() {
 					networkSvc.EXPECT().ReconcileNetwork().Return(nil)
 					sgSvc.EXPECT().ReconcileSecurityGroups().Return(nil)
+					networkSvc.EXPECT().ReconcileVPCEndpoints().Return(nil)
 					ec2Svc.EXPECT().ReconcileBastion().Return(nil)
 					elbSvc.EXPECT().ReconcileLoadbalancers().Return(nil)
 				}
@@ -558,6 +563,7 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 	tests := []struct {
 		name           string
 		bastionEnabled bool
+		vpcEndpoints   infrav1.VPCEndpoints
 		want           []infrav1.SecurityGroupRole
 	}{
 		{
@@ -570,6 +576,16 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 			bastionEnabled: false,
 			want:           defaultAWSSecurityGroupRoles,
 		},
+		{
+			name:         "Should use vpc endpoint security group when there are interface endpoints",
+			vpcEndpoints: infrav1.VPCEndpoints{{Service: "ssm", Type: infrav1.VPCEndpointTypeInterface}},
+			want:         append(defaultAWSSecurityGroupRoles, infrav1.SecurityGroupVPCEndpoint),
+		},
+		{
+			name:         "Should not use vpc endpoint security group when there are only gateway endpoints",
+			vpcEndpoints: infrav1.VPCEndpoints{{Service: "s3", Type: infrav1.VPCEndpointTypeGateway}},
+			want:         defaultAWSSecurityGroupRoles,
+		},
 	}
 
 	for _, tt := range tests {
@@ -578,6 +594,7 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 
 			c := getAWSCluster("test", "test")
 			c.Spec.Bastion.Enabled = tt.bastionEnabled
+			c.Spec.NetworkSpec.VPCEndpoints = tt.vpcEndpoints
 			s, err := getClusterScope(c)
 			g.Expect(err).To(BeNil(), "failed to create cluster scope for test")
 
@@ -586,3 +603,60 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileSecondaryControlPlaneEndpointAnnotation(t *testing.T) {
+	tests := []struct {
+		name             string
+		secondaryLB      *infrav1.AWSLoadBalancerSpec
+		secondaryDNSName string
+		annotations      map[string]string
+		want             map[string]string
+	}{
+		{
+			name: "Should not set the annotation without a secondary load balancer",
+			want: nil,
+		},
+		{
+			name:        "Should not set the annotation before the secondary load balancer has a DNS name",
+			secondaryLB: &infrav1.AWSLoadBalancerSpec{Name: aws.String("internal-apiserver")},
+			want:        nil,
+		},
+		{
+			name:             "Should set the annotation once the secondary load balancer has a DNS name",
+			secondaryLB:      &infrav1.AWSLoadBalancerSpec{Name: aws.String("internal-apiserver")},
+			secondaryDNSName: "internal-apiserver.elb.us-east-1.amazonaws.com",
+			want: map[string]string{
+				infrav1.SecondaryControlPlaneEndpointAnnotation: "internal-apiserver.elb.us-east-1.amazonaws.com:6443",
+			},
+		},
+		{
+			name:             "Should remove the annotation when the secondary load balancer is removed",
+			secondaryDNSName: "internal-apiserver.elb.us-east-1.amazonaws.com",
+			annotations: map[string]string{
+				infrav1.SecondaryControlPlaneEndpointAnnotation: "internal-apiserver.elb.us-east-1.amazonaws.com:6443",
+				"foo": "bar",
+			},
+			want: map[string]string{
+				"foo": "bar",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := getAWSCluster("test", "test")
+			c.Annotations = tt.annotations
+			c.Spec.SecondaryControlPlaneLoadBalancer = tt.secondaryLB
+			c.Status.Network.SecondaryAPIServerELB.DNSName = tt.secondaryDNSName
+
+			reconcileSecondaryControlPlaneEndpointAnnotation(&c, 6443)
+			if tt.want == nil {
+				g.Expect(c.Annotations).To(BeEmpty())
+				return
+			}
+			g.Expect(c.Annotations).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// inventoryConfigMapSuffix is appended to the AWSCluster name to build the name of the inventory ConfigMap.
+	inventoryConfigMapSuffix = "-aws-inventory"
+
+	// inventoryTruncatedKey is set in the inventory ConfigMap if any of the lists were truncated.
+	inventoryTruncatedKey = "truncated"
+
+	// maxInventoryEntries bounds the number of IDs listed per resource kind, which keeps the
+	// inventory ConfigMap far below the ConfigMap size limit for large clusters.
+	maxInventoryEntries = 500
+)
+
+// inventoryConfigMapName returns the name of the ConfigMap listing the AWS resources of the cluster.
+func inventoryConfigMapName(awsCluster *infrav1.AWSCluster) string {
+	return awsCluster.Name + inventoryConfigMapSuffix
+}
+
+// clusterInventory returns the IDs of the AWS resources managed for the cluster, keyed by resource kind.
+// Network resources are only listed if CAPA manages the VPC, security groups only if they aren't overridden.
+func clusterInventory(clusterScope *scope.ClusterScope) map[string][]string {
+	inventory := map[string][]string{}
+	add := func(kind string, ids ...string) {
+		for _, id := range ids {
+			if id != "" {
+				inventory[kind] = append(inventory[kind], id)
+			}
+		}
+	}
+
+	if vpc := clusterScope.VPC(); vpc.ID != "" && !vpc.IsUnmanaged(clusterScope.Name()) {
+		add("vpc", vpc.ID)
+		add("internetGateways", aws.StringValue(vpc.InternetGatewayID), aws.StringValue(vpc.CarrierGatewayID))
+		if vpc.IPv6 != nil {
+			add("internetGateways", aws.StringValue(vpc.IPv6.EgressOnlyInternetGatewayID))
+		}
+		for _, subnet := range clusterScope.Subnets() {
+			add("subnets", subnet.GetResourceID())
+			add("routeTables", aws.StringValue(subnet.RouteTableID))
+			add("natGateways", aws.StringValue(subnet.NatGatewayID))
+		}
+	}
+
+	overrides := clusterScope.SecurityGroupOverrides()
+	for role, sg := range clusterScope.SecurityGroups() {
+		if _, ok := overrides[role]; !ok {
+			add("securityGroups", sg.ID)
+		}
+	}
+
+	lbs := []infrav1.LoadBalancer{clusterScope.Network().APIServerELB, clusterScope.Network().SecondaryAPIServerELB}
+	if replaced := clusterScope.Network().ReplacedAPIServerELB; replaced != nil {
+		lbs = append(lbs, *replaced)
+	}
+	for _, lb := range lbs {
+		if lb.ARN != "" {
+			add("loadBalancers", lb.ARN)
+		} else {
+			add("loadBalancers", lb.Name)
+		}
+	}
+
+	if bastion := clusterScope.AWSCluster.Status.Bastion; bastion != nil {
+		add("instances", bastion.ID)
+		add("iamInstanceProfiles", bastion.IAMProfile)
+	}
+
+	if bucket := clusterScope.Bucket(); bucket != nil {
+		add("s3Buckets", bucket.Name)
+		add("iamInstanceProfiles", bucket.ControlPlaneIAMInstanceProfile)
+		add("iamInstanceProfiles", bucket.NodesIAMInstanceProfiles...)
+	}
+
+	return inventory
+}
+
+// inventoryData renders the inventory as ConfigMap data, with one sorted, de-duplicated,
+// newline separated list of IDs per resource kind.
+func inventoryData(inventory map[string][]string) map[string]string {
+	data := map[string]string{}
+	truncated := false
+	for kind, ids := range inventory {
+		sort.Strings(ids)
+		unique := ids[:0]
+		for i, id := range ids {
+			if i == 0 || id != ids[i-1] {
+				unique = append(unique, id)
+			}
+		}
+		if len(unique) > maxInventoryEntries {
+			unique = unique[:maxInventoryEntries]
+			truncated = true
+		}
+		data[kind] = strings.Join(unique, "\n")
+	}
+	if truncated {
+		data[inventoryTruncatedKey] = "true"
+	}
+	return data
+}
+
+// reconcileInventory writes the inventory of the AWS resources of the cluster to a ConfigMap
+// in the namespace of the AWSCluster, so that tooling without AWS access can detect drift.
+func (r *AWSClusterReconciler) reconcileInventory(ctx context.Context, clusterScope *scope.ClusterScope) error {
+	awsCluster := clusterScope.AWSCluster
+	data := inventoryData(clusterInventory(clusterScope))
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: awsCluster.Namespace, Name: inventoryConfigMapName(awsCluster)}
+	if err := r.Get(ctx, key, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get inventory ConfigMap %s", key)
+		}
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterScope.Name()},
+			},
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(awsCluster, cm, r.Scheme()); err != nil {
+			return errors.Wrap(err, "failed to set owner of inventory ConfigMap")
+		}
+		if err := r.Create(ctx, cm); err != nil {
+			return errors.Wrapf(err, "failed to create inventory ConfigMap %s", key)
+		}
+		clusterScope.Debug("Created inventory ConfigMap", "configmap", key)
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(cm.Data, data) {
+		return nil
+	}
+	cm.Data = data
+	if err := r.Update(ctx, cm); err != nil {
+		return errors.Wrapf(err, "failed to update inventory ConfigMap %s", key)
+	}
+	clusterScope.Debug("Updated inventory ConfigMap", "configmap", key)
+	return nil
+}
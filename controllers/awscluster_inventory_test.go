@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestClusterInventory(t *testing.T) {
+	managedTags := infrav1.Tags{infrav1.ClusterTagKey("test-cluster"): string(infrav1.ResourceLifecycleOwned)}
+
+	testCases := []struct {
+		name     string
+		spec     infrav1.AWSClusterSpec
+		status   infrav1.AWSClusterStatus
+		expected map[string][]string
+	}{
+		{
+			name: "lists managed network resources, security groups and load balancers",
+			spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: "vpc-1", InternetGatewayID: aws.String("igw-1"), Tags: managedTags},
+					Subnets: infrav1.Subnets{
+						{ID: "subnet-private", ResourceID: "subnet-1", RouteTableID: aws.String("rtb-1")},
+						{ID: "subnet-public", ResourceID: "subnet-2", RouteTableID: aws.String("rtb-2"), NatGatewayID: aws.String("nat-1"), IsPublic: true},
+					},
+				},
+			},
+			status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupNode:         {ID: "sg-node"},
+						infrav1.SecurityGroupControlPlane: {ID: "sg-cp"},
+					},
+					APIServerELB:         infrav1.LoadBalancer{Name: "test-apiserver"},
+					ReplacedAPIServerELB: &infrav1.LoadBalancer{Name: "test-apiserver-internal"},
+				},
+				Bastion: &infrav1.Instance{ID: "i-bastion"},
+			},
+			expected: map[string][]string{
+				"vpc":              {"vpc-1"},
+				"internetGateways": {"igw-1"},
+				"subnets":          {"subnet-1", "subnet-2"},
+				"routeTables":      {"rtb-1", "rtb-2"},
+				"natGateways":      {"nat-1"},
+				"securityGroups":   {"sg-cp", "sg-node"},
+				"loadBalancers":    {"test-apiserver", "test-apiserver-internal"},
+				"instances":        {"i-bastion"},
+			},
+		},
+		{
+			name: "skips unmanaged network resources and overridden security groups",
+			spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC:                    infrav1.VPCSpec{ID: "vpc-byo"},
+					Subnets:                infrav1.Subnets{{ID: "subnet-byo"}},
+					SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{infrav1.SecurityGroupNode: "sg-byo"},
+				},
+				S3Bucket: &infrav1.S3Bucket{Name: "test-bucket", ControlPlaneIAMInstanceProfile: "control-plane", NodesIAMInstanceProfiles: []string{"nodes"}},
+			},
+			status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+						infrav1.SecurityGroupNode:        {ID: "sg-byo"},
+						infrav1.SecurityGroupAPIServerLB: {ID: "sg-lb"},
+					},
+					APIServerELB: infrav1.LoadBalancer{Name: "test-apiserver", ARN: "arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/net/test-apiserver/1"},
+				},
+			},
+			expected: map[string][]string{
+				"securityGroups":      {"sg-lb"},
+				"loadBalancers":       {"arn:aws:elasticloadbalancing:eu-west-1:123456789012:loadbalancer/net/test-apiserver/1"},
+				"s3Buckets":           {"test-bucket"},
+				"iamInstanceProfiles": {"control-plane", "nodes"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterScope := &scope.ClusterScope{
+				Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}},
+				AWSCluster: &infrav1.AWSCluster{Spec: tc.spec, Status: tc.status},
+			}
+
+			inventory := clusterInventory(clusterScope)
+			g.Expect(inventory).To(HaveLen(len(tc.expected)))
+			for kind, ids := range tc.expected {
+				g.Expect(inventory).To(HaveKeyWithValue(kind, ConsistOf(ids)))
+			}
+		})
+	}
+}
+
+func TestInventoryData(t *testing.T) {
+	g := NewWithT(t)
+
+	data := inventoryData(map[string][]string{"securityGroups": {"sg-2", "sg-1", "sg-2"}})
+	g.Expect(data).To(Equal(map[string]string{"securityGroups": "sg-1\nsg-2"}))
+
+	subnets := make([]string, 0, maxInventoryEntries+1)
+	for i := 0; i <= maxInventoryEntries; i++ {
+		subnets = append(subnets, fmt.Sprintf("subnet-%04d", i))
+	}
+	data = inventoryData(map[string][]string{"subnets": subnets})
+	g.Expect(data).To(HaveKeyWithValue(inventoryTruncatedKey, "true"))
+	g.Expect(strings.Split(data["subnets"], "\n")).To(HaveLen(maxInventoryEntries))
+}
+
+func TestAWSClusterReconcilerReconcileInventory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "1"},
+		Status: infrav1.AWSClusterStatus{
+			Network: infrav1.NetworkStatus{
+				SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+					infrav1.SecurityGroupNode: {ID: "sg-node"},
+				},
+			},
+		},
+	}
+	clusterScope := &scope.ClusterScope{
+		Logger:     *logger.NewLogger(logr.Discard()),
+		Cluster:    &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+		AWSCluster: awsCluster,
+	}
+	reconciler := &AWSClusterReconciler{Client: fake.NewClientBuilder().Build()}
+
+	g.Expect(reconciler.reconcileInventory(ctx, clusterScope)).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: "default", Name: "test-aws-inventory"}
+	g.Expect(reconciler.Get(ctx, key, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"securityGroups": "sg-node"}))
+	g.Expect(cm.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "test-cluster"))
+	g.Expect(cm.OwnerReferences).To(HaveLen(1))
+	g.Expect(cm.OwnerReferences[0].Name).To(Equal("test"))
+
+	awsCluster.Status.Network.SecurityGroups[infrav1.SecurityGroupControlPlane] = infrav1.SecurityGroup{ID: "sg-cp"}
+	g.Expect(reconciler.reconcileInventory(ctx, clusterScope)).To(Succeed())
+	g.Expect(reconciler.Get(ctx, key, cm)).To(Succeed())
+	g.Expect(cm.Data).To(Equal(map[string]string{"securityGroups": "sg-cp\nsg-node"}))
+}
@@ -49,6 +49,7 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -458,6 +459,23 @@ func (r *AWSMachineReconciler) findInstance(machineScope *scope.MachineScope, ec
 	return instance, nil
 }
 
+// adoptInstance takes ownership of the pre-existing instance referenced by Spec.InstanceID and
+// sets the providerID, so that the instance is managed like the ones created by CAPA.
+func (r *AWSMachineReconciler) adoptInstance(machineScope *scope.MachineScope, ec2svc services.EC2Interface) error {
+	instance, err := ec2svc.InstanceIfExists(machineScope.AWSMachine.Spec.InstanceID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find instance %q to adopt", *machineScope.AWSMachine.Spec.InstanceID)
+	}
+
+	if err := ec2svc.AdoptInstance(machineScope, instance); err != nil {
+		return err
+	}
+
+	machineScope.Info("Adopted existing instance", "instance-id", instance.ID)
+	machineScope.SetProviderID(instance.ID, instance.AvailabilityZone)
+	return nil
+}
+
 //nolint:gocyclo
 func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *scope.MachineScope, clusterScope cloud.ClusterScoper, ec2Scope scope.EC2Scope, elbScope scope.ELBScope, objectStoreScope scope.S3Scope) (ctrl.Result, error) {
 	machineScope.Trace("Reconciling AWSMachine")
@@ -490,6 +508,16 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 
 	ec2svc := r.getEC2Service(ec2Scope)
 
+	// Adopt a pre-existing instance referenced by ID before looking up the instance by providerID.
+	if feature.Gates.Enabled(feature.InstanceAdoption) && !machineScope.IsMachinePoolMachine() &&
+		machineScope.GetProviderID() == "" && machineScope.AWSMachine.Spec.InstanceID != nil {
+		if err := r.adoptInstance(machineScope, ec2svc); err != nil {
+			machineScope.Error(err, "unable to adopt instance")
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceAdoptionFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Find existing instance
 	instance, err := r.findInstance(machineScope, ec2svc)
 	if err != nil {
@@ -531,11 +559,24 @@ func (r *AWSMachineReconciler) reconcileNormal(_ context.Context, machineScope *
 		}
 
 		instance, err = r.createInstance(ec2svc, machineScope, clusterScope, objectStoreSvc)
+		if err != nil && awserrors.IsInvalidIAMInstanceProfile(errors.Cause(err)) {
+			machineScope.Info("IAM instance profile is not usable yet, waiting for IAM propagation", "instance-profile", machineScope.AWSMachine.Spec.IAMInstanceProfile)
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.WaitingForIAMInstanceProfileReason, clusterv1.ConditionSeverityInfo, "%s", err.Error())
+			return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
+		}
+		if err != nil && errors.Is(err, ec2.ErrAvailabilityZoneOccupied) {
+			machineScope.Info("Waiting for a free availability zone to launch the control plane instance", "reason", err.Error())
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.ControlPlaneAZSpreadCondition, infrav1.AvailabilityZoneOccupiedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return ctrl.Result{RequeueAfter: DefaultReconcilerRequeue}, nil
+		}
 		if err != nil {
 			machineScope.Error(err, "unable to create instance")
 			conditions.MarkFalse(machineScope.AWSMachine, infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
 			return ctrl.Result{}, err
 		}
+		if machineScope.IsControlPlane() && machineScope.InfraCluster.ControlPlaneAZSpread() == infrav1.ControlPlaneAZSpreadPolicyStrict {
+			conditions.MarkTrue(machineScope.AWSMachine, infrav1.ControlPlaneAZSpreadCondition)
+		}
 	}
 
 	// BYO Public IPv4 Pool feature: allocates and associates an EIP to machine when PublicIP and
@@ -807,6 +848,18 @@ func (r *AWSMachineReconciler) cloudInitUserData(machineScope *scope.MachineScop
 		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedGenerateAWSSecretsCloudInit", err.Error())
 		return nil, err
 	}
+
+	hostname, err := ec2.RenderHostname(machineScope)
+	if err != nil {
+		r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "FailedRenderHostname", err.Error())
+		return nil, err
+	}
+	if hostname != "" {
+		encryptedCloudInit, err = userdata.WithHostname(encryptedCloudInit, hostname)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return encryptedCloudInit, nil
 }
 
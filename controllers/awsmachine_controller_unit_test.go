@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -35,6 +36,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,7 +45,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	ec2Service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
@@ -320,6 +324,32 @@ func TestAWSMachineReconciler(t *testing.T) {
 				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
 				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
 			})
+
+			t.Run("should set the hostname rendered from the hostname template in the user data", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				awsMachine.Spec.HostnameTemplate = aws.String("{{ .MachineName }}-aws")
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				ms.Machine.Name = "machine-0"
+
+				providerID(t, g)
+				expectedErr := errors.New("Invalid instance")
+				encryptedCloudInit := "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"b\"\n\n--b\nContent-Type: text/x-include-url\n\nfile:///etc/secret-userdata.txt\n--b--\n"
+				var userData []byte
+				ec2Svc.EXPECT().InstanceIfExists(gomock.Any()).Return(nil, nil)
+				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(encryptedCloudInit), nil).Times(1)
+				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ *scope.MachineScope, data []byte, _ string) (*infrav1.Instance, error) {
+					userData = data
+					return nil, expectedErr
+				})
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
+				g.Expect(string(userData)).To(ContainSubstring("hostname: machine-0-aws\n"))
+				g.Expect(string(userData)).To(ContainSubstring("file:///etc/secret-userdata.txt"))
+			})
 		})
 
 		t.Run("should fail to find instance if no provider ID provided", func(t *testing.T) {
@@ -336,6 +366,64 @@ func TestAWSMachineReconciler(t *testing.T) {
 			g.Expect(err.Error()).To(ContainSubstring(expectedErr))
 		})
 
+		t.Run("when adopting an existing instance", func(t *testing.T) {
+			existing := &infrav1.Instance{
+				ID:               "i-existing",
+				State:            infrav1.InstanceStateRunning,
+				AvailabilityZone: "us-east-1a",
+			}
+
+			t.Run("should adopt the instance referenced by instanceID and set the provider ID", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.InstanceAdoption, true)
+
+				ms.AWSMachine.Spec.InstanceID = ptr.To[string](existing.ID)
+				expectedErr := errors.New("no connection available ")
+				ec2Svc.EXPECT().InstanceIfExists(PointsTo(existing.ID)).Return(existing, nil)
+				ec2Svc.EXPECT().AdoptInstance(gomock.Any(), existing).Return(nil)
+				ec2Svc.EXPECT().InstanceIfExists(PointsTo(existing.ID)).Return(nil, expectedErr)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
+				g.Expect(ms.AWSMachine.Spec.ProviderID).To(PointTo(Equal("aws:///us-east-1a/i-existing")))
+			})
+
+			t.Run("should not adopt an instance that fails validation", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.InstanceAdoption, true)
+
+				ms.AWSMachine.Spec.InstanceID = ptr.To[string](existing.ID)
+				ec2Svc.EXPECT().InstanceIfExists(PointsTo(existing.ID)).Return(existing, nil)
+				ec2Svc.EXPECT().AdoptInstance(gomock.Any(), existing).Return(errors.New("instance type mismatch"))
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(ms.AWSMachine.Spec.ProviderID).To(BeNil())
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.InstanceAdoptionFailedReason}})
+			})
+
+			t.Run("should ignore instanceID when the feature gate is disabled", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+
+				ms.AWSMachine.Spec.InstanceID = ptr.To[string](existing.ID)
+				expectedErr := errors.New("no connection available ")
+				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, expectedErr)
+
+				_, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(errors.Cause(err)).To(MatchError(expectedErr))
+				g.Expect(ms.AWSMachine.Spec.ProviderID).To(BeNil())
+			})
+		})
+
 		t.Run("when instance creation succeeds", func(t *testing.T) {
 			var instance *infrav1.Instance
 
@@ -772,6 +860,26 @@ func TestAWSMachineReconciler(t *testing.T) {
 				g.Expect(ms.AWSMachine.Finalizers).To(ContainElement(infrav1.MachineFinalizer))
 				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityError, infrav1.InstanceProvisionFailedReason}})
 			})
+			t.Run("should requeue while waiting for IAM instance profile propagation", func(t *testing.T) {
+				g := NewWithT(t)
+				awsMachine := getAWSMachine()
+				setup(t, g, awsMachine)
+				defer teardown(t, g)
+				instanceCreate(t, g)
+
+				ms.AWSMachine.Spec.IAMInstanceProfile = "new-profile"
+				iamErr := awserr.New(awserrors.InvalidParameterValue, "Value (new-profile) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name", nil)
+
+				ec2Svc.EXPECT().GetRunningInstanceByTags(gomock.Any()).Return(nil, nil)
+				ec2Svc.EXPECT().CreateInstance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(iamErr, "failed to run instance"))
+				secretSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Return("test", int32(1), nil).Times(1)
+				secretSvc.EXPECT().UserData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+				res, err := reconciler.reconcileNormal(context.Background(), ms, cs, cs, cs, cs)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res.RequeueAfter).To(Equal(DefaultReconcilerRequeue))
+				expectConditions(g, ms.AWSMachine, []conditionAssertion{{infrav1.InstanceReadyCondition, corev1.ConditionFalse, clusterv1.ConditionSeverityInfo, infrav1.WaitingForIAMInstanceProfileReason}})
+			})
 			t.Run("should fail to determine the registration status of control plane ELB", func(t *testing.T) {
 				g := NewWithT(t)
 				awsMachine := getAWSMachine()
@@ -206,6 +206,7 @@ func mockedCreateLBCalls(t *testing.T, m *mocks.MockELBAPIMockRecorder, withHeal
 		}, nil)
 	m.ModifyLoadBalancerAttributes(gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
 		LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+			AccessLog:              &elb.AccessLog{Enabled: aws.Bool(false)},
 			ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(600)},
 			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(false)},
 		},
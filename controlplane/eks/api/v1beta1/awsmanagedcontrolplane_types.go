@@ -158,6 +158,7 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// Addons defines the EKS addons to enable with the EKS cluster.
 	// +optional
+	// +k8s:conversion-gen=false
 	Addons *[]Addon `json:"addons,omitempty"`
 
 	// IdentityProviderconfig is used to specify the oidc provider config
@@ -40,10 +40,30 @@ func (r *AWSManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Spec.VpcCni.Disable = r.Spec.DisableVPCCNI
+	dst.Spec.VpcCni.EnableWindowsIPAM = restored.Spec.VpcCni.EnableWindowsIPAM
+	dst.Spec.VpcCni.PodSubnetIDs = restored.Spec.VpcCni.PodSubnetIDs
 	dst.Spec.Partition = restored.Spec.Partition
 	dst.Spec.RestrictPrivateSubnets = restored.Spec.RestrictPrivateSubnets
 	dst.Status.Version = restored.Status.Version
+	dst.Status.CertificateAuthorityData = restored.Status.CertificateAuthorityData
+	dst.Status.ServiceCIDR = restored.Status.ServiceCIDR
+	dst.Status.AuthenticationMode = restored.Status.AuthenticationMode
+	dst.Status.PlatformVersion = restored.Status.PlatformVersion
+	dst.Status.VersionUpdate = restored.Status.VersionUpdate
+	dst.Status.AccessEntries = restored.Status.AccessEntries
+	dst.Status.PodIdentityAssociations = restored.Status.PodIdentityAssociations
 	dst.Spec.BootstrapSelfManagedAddons = restored.Spec.BootstrapSelfManagedAddons
+	dst.Spec.NodegroupUpgrade = restored.Spec.NodegroupUpgrade
+	dst.Spec.AccessConfig = restored.Spec.AccessConfig
+	dst.Spec.AccessEntries = restored.Spec.AccessEntries
+	dst.Spec.PodIdentityAssociations = restored.Spec.PodIdentityAssociations
+	dst.Spec.ClusterSecurityGroupIngressRules = restored.Spec.ClusterSecurityGroupIngressRules
+	dst.Spec.APIServerPrivateDNSRecord = restored.Spec.APIServerPrivateDNSRecord
+	dst.Spec.KubeconfigRefreshInterval = restored.Spec.KubeconfigRefreshInterval
+	dst.Spec.OutpostConfig = restored.Spec.OutpostConfig
+	dst.Spec.UpgradePolicy = restored.Spec.UpgradePolicy
+	dst.Spec.EndpointAccess.CreateVPCEndpoints = restored.Spec.EndpointAccess.CreateVPCEndpoints
+	restoreAddonServiceAccountRoles(restored.Spec.Addons, dst.Spec.Addons)
 	return nil
 }
 
@@ -108,19 +128,73 @@ func Convert_v1beta2_Bastion_To_v1beta1_Bastion(in *infrav1beta2.Bastion, out *i
 }
 
 func Convert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in *AWSManagedControlPlaneSpec, out *ekscontrolplanev1.AWSManagedControlPlaneSpec, s apiconversion.Scope) error {
-	return autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in, out, s)
+	if err := autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControlPlaneSpec(in, out, s); err != nil {
+		return err
+	}
+
+	// The addons are a pointer to a slice, which conversion-gen can't convert element by element.
+	out.Addons = nil
+	if in.Addons != nil {
+		addons := make([]ekscontrolplanev1.Addon, len(*in.Addons))
+		for i := range *in.Addons {
+			if err := Convert_v1beta1_Addon_To_v1beta2_Addon(&(*in.Addons)[i], &addons[i], s); err != nil {
+				return err
+			}
+		}
+		out.Addons = &addons
+	}
+	return nil
 }
 
 func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out *VpcCni, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in, out, s)
 }
 
+// Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess is an autogenerated conversion function.
+func Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(in *ekscontrolplanev1.EndpointAccess, out *EndpointAccess, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(in, out, s)
+}
+
 // Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec is a generated conversion function
 func Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in *ekscontrolplanev1.AWSManagedControlPlaneSpec, out *AWSManagedControlPlaneSpec, scope apiconversion.Scope) error {
-	return autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, scope)
+	if err := autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, scope); err != nil {
+		return err
+	}
+
+	out.Addons = nil
+	if in.Addons != nil {
+		addons := make([]Addon, len(*in.Addons))
+		for i := range *in.Addons {
+			if err := Convert_v1beta2_Addon_To_v1beta1_Addon(&(*in.Addons)[i], &addons[i], scope); err != nil {
+				return err
+			}
+		}
+		out.Addons = &addons
+	}
+	return nil
 }
 
 // Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus is an autogenerated conversion function.
 func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *v1beta2.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, s)
 }
+
+// Convert_v1beta2_Addon_To_v1beta1_Addon is an autogenerated conversion function.
+func Convert_v1beta2_Addon_To_v1beta1_Addon(in *ekscontrolplanev1.Addon, out *Addon, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_Addon_To_v1beta1_Addon(in, out, s)
+}
+
+// restoreAddonServiceAccountRoles restores the service account roles of the addons, which don't exist in v1beta1.
+func restoreAddonServiceAccountRoles(restored, dst *[]ekscontrolplanev1.Addon) {
+	if restored == nil || dst == nil {
+		return
+	}
+	for i := range *dst {
+		for _, addon := range *restored {
+			if addon.Name == (*dst)[i].Name {
+				(*dst)[i].ServiceAccountRole = addon.ServiceAccountRole
+				break
+			}
+		}
+	}
+}
@@ -70,11 +70,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.Addon)(nil), (*Addon)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_Addon_To_v1beta1_Addon(a.(*v1beta2.Addon), b.(*Addon), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*AddonIssue)(nil), (*v1beta2.AddonIssue)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AddonIssue_To_v1beta2_AddonIssue(a.(*AddonIssue), b.(*v1beta2.AddonIssue), scope)
 	}); err != nil {
@@ -120,11 +115,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.EndpointAccess)(nil), (*EndpointAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(a.(*v1beta2.EndpointAccess), b.(*EndpointAccess), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*IAMAuthenticatorConfig)(nil), (*v1beta2.IAMAuthenticatorConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_IAMAuthenticatorConfig_To_v1beta2_IAMAuthenticatorConfig(a.(*IAMAuthenticatorConfig), b.(*v1beta2.IAMAuthenticatorConfig), scope)
 	}); err != nil {
@@ -240,11 +230,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.Addon)(nil), (*Addon)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_Addon_To_v1beta1_Addon(a.(*v1beta2.Addon), b.(*Addon), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*apiv1beta2.Bastion)(nil), (*apiv1beta1.Bastion)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_Bastion_To_v1beta1_Bastion(a.(*apiv1beta2.Bastion), b.(*apiv1beta1.Bastion), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.EndpointAccess)(nil), (*EndpointAccess)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(a.(*v1beta2.EndpointAccess), b.(*EndpointAccess), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*apiv1beta2.NetworkSpec)(nil), (*apiv1beta1.NetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_NetworkSpec_To_v1beta1_NetworkSpec(a.(*apiv1beta2.NetworkSpec), b.(*apiv1beta1.NetworkSpec), scope)
 	}); err != nil {
@@ -361,7 +361,7 @@ func autoConvert_v1beta1_AWSManagedControlPlaneSpec_To_v1beta2_AWSManagedControl
 	out.Bastion = in.Bastion
 	out.TokenMethod = (*v1beta2.EKSTokenMethod)(unsafe.Pointer(in.TokenMethod))
 	out.AssociateOIDCProvider = in.AssociateOIDCProvider
-	out.Addons = (*[]v1beta2.Addon)(unsafe.Pointer(in.Addons))
+	// INFO: in.Addons opted out of conversion generation
 	out.OIDCIdentityProviderConfig = (*v1beta2.OIDCIdentityProviderConfig)(unsafe.Pointer(in.OIDCIdentityProviderConfig))
 	// WARNING: in.DisableVPCCNI requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta1_VpcCni_To_v1beta2_VpcCni(&in.VpcCni, &out.VpcCni, s); err != nil {
@@ -397,8 +397,11 @@ func autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControl
 	out.ImageLookupBaseOS = in.ImageLookupBaseOS
 	out.Bastion = in.Bastion
 	out.TokenMethod = (*EKSTokenMethod)(unsafe.Pointer(in.TokenMethod))
+	// WARNING: in.OutpostConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.KubeconfigRefreshInterval requires manual conversion: does not exist in peer-type
+	// WARNING: in.UpgradePolicy requires manual conversion: does not exist in peer-type
 	out.AssociateOIDCProvider = in.AssociateOIDCProvider
-	out.Addons = (*[]Addon)(unsafe.Pointer(in.Addons))
+	// INFO: in.Addons opted out of conversion generation
 	out.OIDCIdentityProviderConfig = (*OIDCIdentityProviderConfig)(unsafe.Pointer(in.OIDCIdentityProviderConfig))
 	if err := Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(&in.VpcCni, &out.VpcCni, s); err != nil {
 		return err
@@ -408,6 +411,12 @@ func autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControl
 	if err := Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(&in.KubeProxy, &out.KubeProxy, s); err != nil {
 		return err
 	}
+	// WARNING: in.NodegroupUpgrade requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessConfig requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessEntries requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodIdentityAssociations requires manual conversion: does not exist in peer-type
+	// WARNING: in.ClusterSecurityGroupIngressRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIServerPrivateDNSRecord requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -452,6 +461,13 @@ func autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedContr
 		return err
 	}
 	// WARNING: in.Version requires manual conversion: does not exist in peer-type
+	// WARNING: in.CertificateAuthorityData requires manual conversion: does not exist in peer-type
+	// WARNING: in.ServiceCIDR requires manual conversion: does not exist in peer-type
+	// WARNING: in.AuthenticationMode requires manual conversion: does not exist in peer-type
+	// WARNING: in.PlatformVersion requires manual conversion: does not exist in peer-type
+	// WARNING: in.VersionUpdate requires manual conversion: does not exist in peer-type
+	// WARNING: in.AccessEntries requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodIdentityAssociations requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -475,14 +491,10 @@ func autoConvert_v1beta2_Addon_To_v1beta1_Addon(in *v1beta2.Addon, out *Addon, s
 	out.Configuration = in.Configuration
 	out.ConflictResolution = (*AddonResolution)(unsafe.Pointer(in.ConflictResolution))
 	out.ServiceAccountRoleArn = (*string)(unsafe.Pointer(in.ServiceAccountRoleArn))
+	// WARNING: in.ServiceAccountRole requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_Addon_To_v1beta1_Addon is an autogenerated conversion function.
-func Convert_v1beta2_Addon_To_v1beta1_Addon(in *v1beta2.Addon, out *Addon, s conversion.Scope) error {
-	return autoConvert_v1beta2_Addon_To_v1beta1_Addon(in, out, s)
-}
-
 func autoConvert_v1beta1_AddonIssue_To_v1beta2_AddonIssue(in *AddonIssue, out *v1beta2.AddonIssue, s conversion.Scope) error {
 	out.Code = (*string)(unsafe.Pointer(in.Code))
 	out.Message = (*string)(unsafe.Pointer(in.Message))
@@ -607,14 +619,10 @@ func autoConvert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(in *v1beta2.En
 	out.Public = (*bool)(unsafe.Pointer(in.Public))
 	out.PublicCIDRs = *(*[]*string)(unsafe.Pointer(&in.PublicCIDRs))
 	out.Private = (*bool)(unsafe.Pointer(in.Private))
+	// WARNING: in.CreateVPCEndpoints requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess is an autogenerated conversion function.
-func Convert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(in *v1beta2.EndpointAccess, out *EndpointAccess, s conversion.Scope) error {
-	return autoConvert_v1beta2_EndpointAccess_To_v1beta1_EndpointAccess(in, out, s)
-}
-
 func autoConvert_v1beta1_IAMAuthenticatorConfig_To_v1beta2_IAMAuthenticatorConfig(in *IAMAuthenticatorConfig, out *v1beta2.IAMAuthenticatorConfig, s conversion.Scope) error {
 	out.RoleMappings = *(*[]v1beta2.RoleMapping)(unsafe.Pointer(&in.RoleMappings))
 	out.UserMappings = *(*[]v1beta2.UserMapping)(unsafe.Pointer(&in.UserMappings))
@@ -824,5 +832,7 @@ func Convert_v1beta1_VpcCni_To_v1beta2_VpcCni(in *VpcCni, out *v1beta2.VpcCni, s
 func autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *v1beta2.VpcCni, out *VpcCni, s conversion.Scope) error {
 	// WARNING: in.Disable requires manual conversion: does not exist in peer-type
 	out.Env = *(*[]v1.EnvVar)(unsafe.Pointer(&in.Env))
+	// WARNING: in.EnableWindowsIPAM requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSubnetIDs requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -150,11 +150,30 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// TokenMethod is used to specify the method for obtaining a client token for communicating with EKS
 	// iam-authenticator - obtains a client token using iam-authentictor
 	// aws-cli - obtains a client token using the AWS CLI
+	// embedded - embeds a token generated by the controller, which is refreshed periodically
 	// Defaults to iam-authenticator
 	// +kubebuilder:default=iam-authenticator
-	// +kubebuilder:validation:Enum=iam-authenticator;aws-cli
+	// +kubebuilder:validation:Enum=iam-authenticator;aws-cli;embedded
 	TokenMethod *EKSTokenMethod `json:"tokenMethod,omitempty"`
 
+	// OutpostConfig specifies the Outpost to create a local EKS cluster on. The cluster requires an existing
+	// VPC with subnets on the Outpost and only supports private endpoint access. It can't be changed after
+	// creation.
+	// +optional
+	OutpostConfig *OutpostConfig `json:"outpostConfig,omitempty"`
+
+	// KubeconfigRefreshInterval is the minimum interval at which the tokens embedded in the kubeconfig
+	// secrets are regenerated. It must be at least a minute and less than the token lifetime of 15 minutes.
+	// Defaults to regenerating the tokens on every reconciliation of the control plane.
+	// +optional
+	KubeconfigRefreshInterval *metav1.Duration `json:"kubeconfigRefreshInterval,omitempty"`
+
+	// UpgradePolicy specifies the support policy of the cluster. Clusters with the extended support type enter
+	// extended support at the end of the standard support of their Kubernetes version, clusters with the
+	// standard support type are upgraded automatically instead. Defaults to the support type of EKS if not set.
+	// +optional
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
+
 	// AssociateOIDCProvider can be enabled to automatically create an identity
 	// provider for the controller for use with IAM roles for service accounts
 	// +kubebuilder:default=false
@@ -162,6 +181,7 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// Addons defines the EKS addons to enable with the EKS cluster.
 	// +optional
+	// +k8s:conversion-gen=false
 	Addons *[]Addon `json:"addons,omitempty"`
 
 	// IdentityProviderconfig is used to specify the oidc provider config
@@ -185,6 +205,53 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 
 	// KubeProxy defines managed attributes of the kube-proxy daemonset
 	KubeProxy KubeProxy `json:"kubeProxy,omitempty"`
+
+	// NodegroupUpgrade orchestrates the upgrades of the AWSManagedMachinePools of the cluster
+	// following a control plane version upgrade. If not set, each machine pool is upgraded
+	// independently to the version of its MachinePool.
+	// +optional
+	NodegroupUpgrade *NodegroupUpgrade `json:"nodegroupUpgrade,omitempty"`
+
+	// AccessConfig specifies the authentication mode of the cluster, i.e. whether access to it
+	// is granted through EKS access entries, the aws-auth ConfigMap or both.
+	// +optional
+	AccessConfig *AccessConfig `json:"accessConfig,omitempty"`
+
+	// AccessEntries specifies the EKS access entries of IAM principals and the access policies
+	// associated with them. Requires an authentication mode of API or API_AND_CONFIG_MAP.
+	// +optional
+	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
+
+	// PodIdentityAssociations specifies the EKS Pod Identity associations of the cluster, which grant the
+	// pods using a service account the permissions of an IAM role. Requires the eks-pod-identity-agent addon.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
+
+	// ClusterSecurityGroupIngressRules is an optional set of ingress rules to add to the cluster
+	// security group created by EKS, e.g. to allow access to the API server from a management VPC.
+	// Only rules added by this field are managed, rules added by EKS or other tools are left untouched.
+	// +optional
+	ClusterSecurityGroupIngressRules []infrav1.IngressRule `json:"clusterSecurityGroupIngressRules,omitempty"`
+
+	// APIServerPrivateDNSRecord is an optional record in a private Route53 hosted zone that is created
+	// for the EKS API server endpoint, so that workloads in the VPC can reach the API server
+	// through a stable internal name.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="apiServerPrivateDnsRecord is immutable"
+	// +optional
+	APIServerPrivateDNSRecord *infrav1.PrivateDNSRecord `json:"apiServerPrivateDnsRecord,omitempty"`
+}
+
+// NodegroupUpgrade specifies how the EKS managed node groups of the cluster are upgraded after
+// the control plane has been upgraded. AWSManagedMachinePools without an explicit version follow
+// the control plane version and are upgraded to the latest AMI release of it, one after another
+// in the given order.
+type NodegroupUpgrade struct {
+	// Order lists the names of AWSManagedMachinePools in the order they are upgraded in. A machine
+	// pool is only upgraded once the machine pools listed before it have been upgraded. Machine pools
+	// not listed are upgraded in parallel after all listed machine pools. The number of nodes of a
+	// machine pool unavailable during its upgrade is controlled by its updateConfig.
+	// +optional
+	Order []string `json:"order,omitempty"`
 }
 
 // KubeProxy specifies how the kube-proxy daemonset is managed.
@@ -210,6 +277,19 @@ type VpcCni struct {
 	// Env defines a list of environment variables to apply to the `aws-node` DaemonSet
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+	// EnableWindowsIPAM enables the IP address management of the Amazon VPC CNI for Windows nodes,
+	// which is required to run Windows nodes in the cluster. The policy of the VPC resource
+	// controller is attached to the control plane role as well if CAPA manages it.
+	// +optional
+	EnableWindowsIPAM bool `json:"enableWindowsIPAM,omitempty"`
+	// PodSubnetIDs are the IDs of existing subnets of the VPC dedicated to the pods, at most one per
+	// availability zone, to set up the custom networking of the Amazon VPC CNI. The subnets are tagged
+	// as secondary subnets, which keeps the nodes out of them, an ENIConfig is generated for the
+	// availability zone of each subnet, and the custom networking is enabled on the `aws-node` DaemonSet.
+	// Cannot be used together with the secondary CIDR block, whose pod subnets are created by CAPA.
+	// +listType=set
+	// +optional
+	PodSubnetIDs []string `json:"podSubnetIDs,omitempty"`
 }
 
 // EndpointAccess specifies how control plane endpoints are accessible.
@@ -223,6 +303,13 @@ type EndpointAccess struct {
 	// Private points VPC-internal control plane access to the private endpoint
 	// +optional
 	Private *bool `json:"private,omitempty"`
+	// CreateVPCEndpoints creates the VPC endpoints nodes in private subnets need to join
+	// a cluster whose control plane is only privately accessible, so that no NAT gateway
+	// is required. These are interface endpoints for ECR, STS, EC2 and Elastic Load Balancing,
+	// and a gateway endpoint for S3. It requires private only endpoint access and a VPC
+	// managed by the provider.
+	// +optional
+	CreateVPCEndpoints bool `json:"createVPCEndpoints,omitempty"`
 }
 
 // EncryptionConfig specifies the encryption configuration for the EKS clsuter.
@@ -293,6 +380,54 @@ type AWSManagedControlPlaneStatus struct {
 	// in the cluster.
 	// +optional
 	Version *string `json:"version,omitempty"`
+	// CertificateAuthorityData is the base64 encoded certificate authority data
+	// for the EKS cluster. It is used when bootstrapping self-managed nodes.
+	// +optional
+	CertificateAuthorityData *string `json:"certificateAuthorityData,omitempty"`
+	// ServiceCIDR is the CIDR block the EKS cluster assigns Kubernetes service
+	// IP addresses from.
+	// +optional
+	ServiceCIDR *string `json:"serviceCIDR,omitempty"`
+	// AuthenticationMode is the authentication mode the EKS cluster currently uses.
+	// It lags behind spec.accessConfig.authenticationMode while the cluster is migrated
+	// to a different mode.
+	// +optional
+	AuthenticationMode EKSAuthenticationMode `json:"authenticationMode,omitempty"`
+	// PlatformVersion is the EKS platform version of the cluster. It changes when
+	// EKS rolls out a new platform version or the Kubernetes version is updated.
+	// +optional
+	PlatformVersion string `json:"platformVersion,omitempty"`
+	// VersionUpdate holds the progress of the last update of the Kubernetes version
+	// of the control plane initiated by the controller.
+	// +optional
+	VersionUpdate *VersionUpdateStatus `json:"versionUpdate,omitempty"`
+	// AccessEntries are the access entries of the spec as last applied to the EKS cluster. They are
+	// used to only update the access entries that changed, and to delete the ones removed from the spec.
+	// +optional
+	AccessEntries []AccessEntry `json:"accessEntries,omitempty"`
+	// PodIdentityAssociations are the Pod Identity associations of the spec as last applied to the
+	// EKS cluster. They are used to only update the associations that changed, and to delete the ones
+	// removed from the spec.
+	// +optional
+	PodIdentityAssociations []PodIdentityAssociation `json:"podIdentityAssociations,omitempty"`
+}
+
+// VersionUpdateStatus represents the progress of an update of the Kubernetes version of the EKS cluster.
+type VersionUpdateStatus struct {
+	// ID is the identifier of the EKS update.
+	ID string `json:"id"`
+	// Version is the Kubernetes version the control plane is updated to.
+	Version string `json:"version"`
+	// Phase is the status of the EKS update as reported by EKS, i.e. InProgress,
+	// Successful, Failed or Cancelled.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// StartedAt is the time the update was initiated.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// Errors holds the errors reported by EKS for a failed update.
+	// +optional
+	Errors []string `json:"errors,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -18,15 +18,21 @@ package v1beta2
 
 import (
 	"fmt"
+	"math/bits"
 	"net"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -51,6 +57,8 @@ const (
 	cidrSizeMin    = 16
 	vpcCniAddon    = "vpc-cni"
 	kubeProxyAddon = "kube-proxy"
+
+	defaultAddonServiceAccountNamespace = "kube-system"
 )
 
 // SetupWebhookWithManager will setup the webhooks for the AWSManagedControlPlane.
@@ -90,9 +98,20 @@ func (r *AWSManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateAddonServiceAccountRoles()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
+	allErrs = append(allErrs, r.validatePodSubnetIDs()...)
 	allErrs = append(allErrs, r.validateRestrictPrivateSubnets()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validateNodegroupUpgrade()...)
+	allErrs = append(allErrs, r.validateAccessConfig(nil)...)
+	allErrs = append(allErrs, r.validateAccessEntries()...)
+	allErrs = append(allErrs, r.validatePodIdentityAssociations()...)
+	allErrs = append(allErrs, r.validateClusterSecurityGroupIngressRules()...)
+	allErrs = append(allErrs, r.validateKubeconfigRefreshInterval()...)
+	allErrs = append(allErrs, r.validateOutpostConfig()...)
+	allErrs = append(allErrs, r.validateCreateVPCEndpoints()...)
+	allErrs = append(allErrs, r.validateSecondaryCidrBlockNetworkMode()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
@@ -126,10 +145,27 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateAddonServiceAccountRoles()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
+	allErrs = append(allErrs, r.validatePodSubnetIDs()...)
 	allErrs = append(allErrs, r.validateRestrictPrivateSubnets()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validateNodegroupUpgrade()...)
+	allErrs = append(allErrs, r.validateAccessConfig(oldAWSManagedControlplane)...)
+	allErrs = append(allErrs, r.validateAccessEntries()...)
+	allErrs = append(allErrs, r.validatePodIdentityAssociations()...)
+	allErrs = append(allErrs, r.validateClusterSecurityGroupIngressRules()...)
+	allErrs = append(allErrs, r.validateKubeconfigRefreshInterval()...)
+	allErrs = append(allErrs, r.validateOutpostConfig()...)
+	allErrs = append(allErrs, r.validateCreateVPCEndpoints()...)
+	allErrs = append(allErrs, r.validateSecondaryCidrBlockNetworkMode()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateTransitGatewayAttachmentUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateVPCPeeringConnectionsUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatGatewayModeUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatInstanceUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkACLsUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkModeUpdate(&oldAWSManagedControlplane.Spec.NetworkSpec)...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
 
 	if r.Spec.Region != oldAWSManagedControlplane.Spec.Region {
@@ -156,6 +192,12 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 		)
 	}
 
+	if !reflect.DeepEqual(oldAWSManagedControlplane.Spec.OutpostConfig, r.Spec.OutpostConfig) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "outpostConfig"), r.Spec.OutpostConfig, "field is immutable"),
+		)
+	}
+
 	// If a identityRef is already set, do not allow removal of it.
 	if oldAWSManagedControlplane.Spec.IdentityRef != nil && r.Spec.IdentityRef == nil {
 		allErrs = append(allErrs,
@@ -289,6 +331,36 @@ func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateAddonServiceAccountRoles() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.Addons == nil {
+		return allErrs
+	}
+
+	for i, addon := range *r.Spec.Addons {
+		if addon.ServiceAccountRole == nil {
+			continue
+		}
+		rolePath := field.NewPath("spec", "addons").Index(i).Child("serviceAccountRole")
+
+		if addon.ServiceAccountRoleArn != nil {
+			allErrs = append(allErrs, field.Forbidden(rolePath, "serviceAccountRole can't be used together with serviceAccountRoleARN"))
+		}
+		if !r.Spec.AssociateOIDCProvider {
+			allErrs = append(allErrs, field.Forbidden(rolePath, "serviceAccountRole requires associateOIDCProvider to be enabled"))
+		}
+		if addon.ServiceAccountRole.ServiceAccountName == "" {
+			allErrs = append(allErrs, field.Required(rolePath.Child("serviceAccountName"), fmt.Sprintf("serviceAccountName is required for addon %s", addon.Name)))
+		}
+		if _, _, ok := KnownAddonServiceAccountRole(addon.Name); !ok && len(addon.ServiceAccountRole.PolicyARNs) == 0 {
+			allErrs = append(allErrs, field.Required(rolePath.Child("policyARNs"), fmt.Sprintf("policyARNs are required for addon %s", addon.Name)))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -342,6 +414,17 @@ func (r *AWSManagedControlPlane) validateSecondaryCIDR() field.ErrorList {
 		if (!validRange1.Contains(start) || !validRange1.Contains(end)) && (!validRange2.Contains(start) || !validRange2.Contains(end)) {
 			allErrs = append(allErrs, field.Invalid(cidrField, *r.Spec.SecondaryCidrBlock, "must be within the 100.64.0.0/10 or 198.19.0.0/16 range"))
 		}
+
+		// In a managed VPC, the block is split into one pod subnet per availability zone, and a subnet
+		// can't be smaller than a /28 netmask.
+		if azLimit := r.Spec.NetworkSpec.VPC.AvailabilityZoneUsageLimit; r.Spec.NetworkSpec.VPC.ID == "" && azLimit != nil && *azLimit > 1 {
+			prefixLen, _ := ipv4Net.Mask.Size()
+			subnetBits := bits.Len(uint(*azLimit - 1))
+			if prefixLen+subnetBits > 28 {
+				allErrs = append(allErrs, field.Invalid(cidrField, *r.Spec.SecondaryCidrBlock,
+					fmt.Sprintf("must be at least a /%d netmask to be split into a /28 subnet for each of the %d availability zones", 28-subnetBits, *azLimit)))
+			}
+		}
 	}
 
 	if len(allErrs) == 0 {
@@ -372,6 +455,243 @@ func (r *AWSManagedControlPlane) validateKubeProxy() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateNodegroupUpgrade() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.NodegroupUpgrade != nil {
+		orderField := field.NewPath("spec", "nodegroupUpgrade", "order")
+		seen := map[string]bool{}
+		for i, name := range r.Spec.NodegroupUpgrade.Order {
+			if seen[name] {
+				allErrs = append(allErrs, field.Duplicate(orderField.Index(i), name))
+			}
+			seen[name] = true
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// authenticationModeOrder lists the authentication modes in the order EKS allows them to be changed in.
+var authenticationModeOrder = []EKSAuthenticationMode{
+	EKSAuthenticationModeConfigMap,
+	EKSAuthenticationModeAPIAndConfigMap,
+	EKSAuthenticationModeAPI,
+}
+
+func (r *AWSManagedControlPlane) validateAccessConfig(old *AWSManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if old == nil {
+		return nil
+	}
+
+	// Without a previous access config, the authentication mode of the cluster is the one reported in the status.
+	oldMode := old.Status.AuthenticationMode
+	if old.Spec.AccessConfig != nil {
+		oldMode = old.Spec.AccessConfig.AuthenticationMode
+	} else if r.Spec.AccessConfig == nil {
+		return nil
+	}
+
+	modeField := field.NewPath("spec", "accessConfig", "authenticationMode")
+	if r.Spec.AccessConfig == nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "accessConfig"), r.Spec.AccessConfig, "field cannot be removed once set"))
+	} else if oldMode != "" && oldMode != r.Spec.AccessConfig.AuthenticationMode {
+		// EKS only allows moving to the next authentication mode, so CONFIG_MAP must be changed to API_AND_CONFIG_MAP
+		// before it can be changed to API.
+		oldIndex := slices.Index(authenticationModeOrder, oldMode)
+		if slices.Index(authenticationModeOrder, r.Spec.AccessConfig.AuthenticationMode) != oldIndex+1 {
+			allErrs = append(allErrs, field.Invalid(modeField, r.Spec.AccessConfig.AuthenticationMode,
+				fmt.Sprintf("authentication mode cannot be changed from %s to %s", oldMode, r.Spec.AccessConfig.AuthenticationMode)))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validateAccessEntries() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.AccessEntries) == 0 {
+		return nil
+	}
+
+	entriesField := field.NewPath("spec", "accessEntries")
+	if r.Spec.AccessConfig == nil || r.Spec.AccessConfig.AuthenticationMode == EKSAuthenticationModeConfigMap {
+		allErrs = append(allErrs, field.Invalid(entriesField, len(r.Spec.AccessEntries),
+			fmt.Sprintf("access entries require the authentication mode %s or %s", EKSAuthenticationModeAPI, EKSAuthenticationModeAPIAndConfigMap)))
+	}
+
+	seen := map[string]bool{}
+	for i, entry := range r.Spec.AccessEntries {
+		entryField := entriesField.Index(i)
+		if seen[entry.PrincipalARN] {
+			allErrs = append(allErrs, field.Duplicate(entryField.Child("principalARN"), entry.PrincipalARN))
+		}
+		seen[entry.PrincipalARN] = true
+
+		if len(entry.AccessPolicies) > 0 && entry.Type != "" && entry.Type != AccessEntryTypeStandard {
+			allErrs = append(allErrs, field.Invalid(entryField.Child("accessPolicies"), len(entry.AccessPolicies),
+				fmt.Sprintf("access policies can only be associated with %s access entries", AccessEntryTypeStandard)))
+		}
+
+		policies := map[string]bool{}
+		for j, policy := range entry.AccessPolicies {
+			policyField := entryField.Child("accessPolicies").Index(j)
+			if policies[policy.PolicyARN] {
+				allErrs = append(allErrs, field.Duplicate(policyField.Child("policyARN"), policy.PolicyARN))
+			}
+			policies[policy.PolicyARN] = true
+
+			namespacesField := policyField.Child("accessScope", "namespaces")
+			switch {
+			case policy.AccessScope.Type == AccessScopeTypeNamespace && len(policy.AccessScope.Namespaces) == 0:
+				allErrs = append(allErrs, field.Required(namespacesField, "namespaces are required for a namespace access scope"))
+			case policy.AccessScope.Type != AccessScopeTypeNamespace && len(policy.AccessScope.Namespaces) > 0:
+				allErrs = append(allErrs, field.Invalid(namespacesField, policy.AccessScope.Namespaces, "namespaces can only be set for a namespace access scope"))
+			}
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validatePodIdentityAssociations() field.ErrorList {
+	var allErrs field.ErrorList
+	associationsField := field.NewPath("spec", "podIdentityAssociations")
+
+	seen := map[string]bool{}
+	for i, association := range r.Spec.PodIdentityAssociations {
+		serviceAccount := fmt.Sprintf("%s/%s", association.ServiceAccountNamespace, association.ServiceAccountName)
+		if seen[serviceAccount] {
+			allErrs = append(allErrs, field.Duplicate(associationsField.Index(i).Child("serviceAccountName"), serviceAccount))
+		}
+		seen[serviceAccount] = true
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validateClusterSecurityGroupIngressRules() field.ErrorList {
+	var allErrs field.ErrorList
+	rulesPath := field.NewPath("spec", "clusterSecurityGroupIngressRules")
+
+	for i, rule := range r.Spec.ClusterSecurityGroupIngressRules {
+		rulePath := rulesPath.Index(i)
+		if len(rule.SourceSecurityGroupRoles) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("sourceSecurityGroupRoles"), "sourceSecurityGroupRoles are not supported for the cluster security group"))
+		}
+		if rule.NatGatewaysIPsSource {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("natGatewaysIPsSource"), "natGatewaysIPsSource is not supported for the cluster security group"))
+		}
+		if len(rule.PrefixListIDs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("prefixListIds"), "prefixListIds are not supported for the cluster security group"))
+		}
+		if len(rule.CidrBlocks) == 0 && len(rule.IPv6CidrBlocks) == 0 && len(rule.SourceSecurityGroupIDs) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath, "one of cidrBlocks, ipv6CidrBlocks or sourceSecurityGroupIDs must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedControlPlane) validateKubeconfigRefreshInterval() field.ErrorList {
+	if r.Spec.KubeconfigRefreshInterval == nil {
+		return nil
+	}
+
+	// The tokens embedded in the kubeconfigs are valid for 15 minutes.
+	if interval := r.Spec.KubeconfigRefreshInterval.Duration; interval < time.Minute || interval >= 15*time.Minute {
+		return field.ErrorList{
+			field.Invalid(field.NewPath("spec", "kubeconfigRefreshInterval"), r.Spec.KubeconfigRefreshInterval.Duration.String(), "must be at least 1m and less than 15m"),
+		}
+	}
+
+	return nil
+}
+
+// validateOutpostConfig checks the requirements of local clusters on Outposts.
+func (r *AWSManagedControlPlane) validateOutpostConfig() field.ErrorList {
+	if r.Spec.OutpostConfig == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	outpostPath := field.NewPath("spec", "outpostConfig")
+	for i, outpostARN := range r.Spec.OutpostConfig.OutpostARNs {
+		if parsed, err := arn.Parse(outpostARN); err != nil || parsed.Service != "outposts" {
+			allErrs = append(allErrs, field.Invalid(outpostPath.Child("outpostARNs").Index(i), outpostARN, "must be the ARN of an Outpost"))
+		}
+	}
+	if r.Spec.NetworkSpec.VPC.ID == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "network", "vpc", "id"),
+			"local clusters on Outposts require an existing VPC with subnets on the Outpost"))
+	}
+	if ptr.Deref(r.Spec.EndpointAccess.Public, false) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "endpointAccess", "public"), true,
+			"local clusters on Outposts only support private endpoint access"))
+	}
+	if !ptr.Deref(r.Spec.EndpointAccess.Private, true) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "endpointAccess", "private"), false,
+			"local clusters on Outposts only support private endpoint access"))
+	}
+	if r.Spec.AssociateOIDCProvider {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "associateOIDCProvider"), true,
+			"local clusters on Outposts don't support IAM roles for service accounts"))
+	}
+
+	return allErrs
+}
+
+// validateCreateVPCEndpoints checks that the VPC endpoints are only created for private clusters in a managed VPC.
+func (r *AWSManagedControlPlane) validateCreateVPCEndpoints() field.ErrorList {
+	if !r.Spec.EndpointAccess.CreateVPCEndpoints {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	createPath := field.NewPath("spec", "endpointAccess", "createVPCEndpoints")
+	if ptr.Deref(r.Spec.EndpointAccess.Public, true) || !ptr.Deref(r.Spec.EndpointAccess.Private, false) {
+		allErrs = append(allErrs, field.Invalid(createPath, true,
+			"VPC endpoints can only be created when the public endpoint access is disabled and the private endpoint access is enabled"))
+	}
+	if r.Spec.NetworkSpec.VPC.ID != "" {
+		allErrs = append(allErrs, field.Invalid(createPath, true, "VPC endpoints can only be created in a VPC managed by the provider"))
+	}
+
+	return allErrs
+}
+
+// securityGroupRoles returns the roles of the security groups the control plane needs.
+func (r *AWSManagedControlPlane) securityGroupRoles() []infrav1.SecurityGroupRole {
+	roles := []infrav1.SecurityGroupRole{infrav1.SecurityGroupEKSNodeAdditional}
+	if r.Spec.Bastion.Enabled {
+		roles = append(roles, infrav1.SecurityGroupBastion)
+	}
+	return roles
+}
+
+func (r *AWSManagedControlPlane) validateSecondaryCidrBlockNetworkMode() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.NetworkSpec.IsExternallyManaged() && r.Spec.SecondaryCidrBlock != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "secondaryCidrBlock"),
+			"cannot be set for an externally managed network, its CIDR blocks are never modified"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -394,6 +714,27 @@ func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validatePodSubnetIDs() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(r.Spec.VpcCni.PodSubnetIDs) == 0 {
+		return nil
+	}
+
+	podSubnetIDsField := field.NewPath("spec", "vpcCni", "podSubnetIDs")
+	if r.Spec.VpcCni.Disable {
+		allErrs = append(allErrs, field.Forbidden(podSubnetIDsField, "cannot be set when the vpc cni is disabled"))
+	}
+	if r.Spec.SecondaryCidrBlock != nil {
+		allErrs = append(allErrs, field.Forbidden(podSubnetIDsField, "cannot be set together with spec.secondaryCidrBlock, which creates the pod subnets"))
+	}
+	if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+		allErrs = append(allErrs, field.Forbidden(podSubnetIDsField, "custom networking of the vpc cni is not supported for IPv6 clusters"))
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateRestrictPrivateSubnets() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -474,6 +815,22 @@ func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(ipamPoolField, r.Spec.NetworkSpec.VPC.IPv6.IPAMPool, "ipamPool must have either id or name"))
 	}
 
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateVPCEndpoints()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateTransitGatewayAttachment()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateVPCPeeringConnections()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateExistingRouteTables()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatGatewayMode()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatGatewayElasticIPAllocationIDs()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNatInstance()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateFlowLogs()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateDHCPOptions()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkACLs()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateAvailabilityZones()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateOutposts()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidatePrefixLists()...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateNetworkMode(r.securityGroupRoles()...)...)
+	allErrs = append(allErrs, r.Spec.NetworkSpec.ValidateSecurityGroupOverrides(r.securityGroupRoles()...)...)
+
 	return allErrs
 }
 
@@ -503,6 +860,36 @@ func (r *AWSManagedControlPlane) Default() {
 	infrav1.SetDefaults_Bastion(&r.Spec.Bastion)
 	infrav1.SetDefaults_NetworkSpec(&r.Spec.NetworkSpec)
 
+	// Local clusters on Outposts only support private endpoint access.
+	if r.Spec.OutpostConfig != nil {
+		if r.Spec.EndpointAccess.Public == nil {
+			r.Spec.EndpointAccess.Public = ptr.To(false)
+		}
+		if r.Spec.EndpointAccess.Private == nil {
+			r.Spec.EndpointAccess.Private = ptr.To(true)
+		}
+	}
+
+	if r.Spec.Addons != nil {
+		for i := range *r.Spec.Addons {
+			setDefaultsAddonServiceAccountRole(&(*r.Spec.Addons)[i])
+		}
+	}
+
 	// Set default value for BootstrapSelfManagedAddons
 	r.Spec.BootstrapSelfManagedAddons = true
 }
+
+// setDefaultsAddonServiceAccountRole defaults the service account of the service account role of an addon
+// to the one of the addon if CAPA knows it.
+func setDefaultsAddonServiceAccountRole(addon *Addon) {
+	if addon.ServiceAccountRole == nil {
+		return
+	}
+	if addon.ServiceAccountRole.ServiceAccountNamespace == "" {
+		addon.ServiceAccountRole.ServiceAccountNamespace = defaultAddonServiceAccountNamespace
+	}
+	if addon.ServiceAccountRole.ServiceAccountName == "" {
+		addon.ServiceAccountRole.ServiceAccountName, _, _ = KnownAddonServiceAccountRole(addon.Name)
+	}
+}
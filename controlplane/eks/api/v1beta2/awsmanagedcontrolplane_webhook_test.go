@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	. "github.com/onsi/gomega"
@@ -178,6 +179,7 @@ func TestWebhookCreate(t *testing.T) {
 		additionalTags       infrav1.Tags
 		secondaryCidr        *string
 		secondaryCidrBlocks  []infrav1.VpcCidrBlock
+		azUsageLimit         *int
 		kubeProxy            KubeProxy
 	}{
 		{
@@ -247,6 +249,25 @@ func TestWebhookCreate(t *testing.T) {
 			vpcCNI:         VpcCni{Disable: true},
 			secondaryCidr:  aws.String("100.64.0.0/16"),
 		},
+		{
+			name:                 "secondary CIDR block too small for a subnet per availability zone",
+			eksClusterName:       "default_cluster1",
+			eksVersion:           "v1.19",
+			expectError:          true,
+			expectErrorToContain: "must be at least a /26 netmask",
+			vpcCNI:               VpcCni{Disable: false},
+			secondaryCidr:        aws.String("100.64.0.0/27"),
+			azUsageLimit:         aws.Int(3),
+		},
+		{
+			name:           "secondary CIDR block large enough for a subnet per availability zone",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.19",
+			expectError:    false,
+			vpcCNI:         VpcCni{Disable: false},
+			secondaryCidr:  aws.String("100.64.0.0/26"),
+			azUsageLimit:   aws.Int(3),
+		},
 		{
 			name:           "disable vpc cni allowed with invalid secondary",
 			eksClusterName: "default_cluster1",
@@ -341,7 +362,8 @@ func TestWebhookCreate(t *testing.T) {
 					VpcCni:         tc.vpcCNI,
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
-							SecondaryCidrBlocks: tc.secondaryCidrBlocks,
+							SecondaryCidrBlocks:        tc.secondaryCidrBlocks,
+							AvailabilityZoneUsageLimit: tc.azUsageLimit,
 						},
 					},
 				},
@@ -726,6 +748,87 @@ func TestWebhookUpdate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "changing authentication mode from CONFIG_MAP to API_AND_CONFIG_MAP is allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeConfigMap},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeAPIAndConfigMap},
+				AccessEntries: []AccessEntry{
+					{
+						PrincipalARN: "arn:aws:iam::123456789012:role/admin",
+						AccessPolicies: []AccessPolicyReference{
+							{
+								PolicyARN:   "arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy",
+								AccessScope: AccessScope{Type: AccessScopeTypeCluster},
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "changing authentication mode from API to CONFIG_MAP is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeAPI},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeConfigMap},
+			},
+			expectError: true,
+		},
+		{
+			name: "changing authentication mode from CONFIG_MAP to API is not allowed",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeConfigMap},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeAPI},
+			},
+			expectError: true,
+		},
+		{
+			name: "access entries require an authentication mode allowing them",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessEntries:  []AccessEntry{{PrincipalARN: "arn:aws:iam::123456789012:role/admin"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "namespace access scope requires namespaces",
+			oldClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeAPI},
+			},
+			newClusterSpec: AWSManagedControlPlaneSpec{
+				EKSClusterName: "default_cluster1",
+				AccessConfig:   &AccessConfig{AuthenticationMode: EKSAuthenticationModeAPI},
+				AccessEntries: []AccessEntry{
+					{
+						PrincipalARN: "arn:aws:iam::123456789012:role/dev",
+						AccessPolicies: []AccessPolicyReference{
+							{
+								PolicyARN:   "arn:aws:eks::aws:cluster-access-policy/AmazonEKSEditPolicy",
+								AccessScope: AccessScope{Type: AccessScopeTypeNamespace},
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -892,3 +995,453 @@ func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingWebhookCreateClusterSecurityGroupIngressRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        infrav1.IngressRule
+		expectError bool
+	}{
+		{
+			name: "cidr block source",
+			rule: infrav1.IngressRule{
+				Protocol:   infrav1.SecurityGroupProtocolTCP,
+				FromPort:   443,
+				ToPort:     443,
+				CidrBlocks: []string{"10.200.0.0/16"},
+			},
+			expectError: false,
+		},
+		{
+			name: "no source",
+			rule: infrav1.IngressRule{
+				Protocol: infrav1.SecurityGroupProtocolTCP,
+				FromPort: 443,
+				ToPort:   443,
+			},
+			expectError: true,
+		},
+		{
+			name: "security group role source",
+			rule: infrav1.IngressRule{
+				Protocol:                 infrav1.SecurityGroupProtocolTCP,
+				FromPort:                 443,
+				ToPort:                   443,
+				SourceSecurityGroupRoles: []infrav1.SecurityGroupRole{infrav1.SecurityGroupBastion},
+			},
+			expectError: true,
+		},
+		{
+			name: "nat gateway IPs source",
+			rule: infrav1.IngressRule{
+				Protocol:             infrav1.SecurityGroupProtocolTCP,
+				FromPort:             443,
+				ToPort:               443,
+				NatGatewaysIPsSource: true,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:                   "default_cluster1",
+					ClusterSecurityGroupIngressRules: []infrav1.IngressRule{tc.rule},
+				},
+			}
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreateKubeconfigRefreshInterval(t *testing.T) {
+	tests := []struct {
+		name        string
+		interval    time.Duration
+		expectError bool
+	}{
+		{
+			name:        "within the token lifetime",
+			interval:    10 * time.Minute,
+			expectError: false,
+		},
+		{
+			name:        "too short",
+			interval:    30 * time.Second,
+			expectError: true,
+		},
+		{
+			name:        "token lifetime",
+			interval:    15 * time.Minute,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:            "default_cluster1",
+					KubeconfigRefreshInterval: &metav1.Duration{Duration: tc.interval},
+				},
+			}
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreateOutpostConfig(t *testing.T) {
+	outpostConfig := &OutpostConfig{
+		OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"},
+		ControlPlaneInstanceType: "m5d.large",
+	}
+	tests := []struct {
+		name                  string
+		outpostConfig         *OutpostConfig
+		vpcID                 string
+		endpointAccess        EndpointAccess
+		associateOIDCProvider bool
+		expectError           bool
+	}{
+		{
+			name:          "existing VPC with private endpoint access",
+			outpostConfig: outpostConfig,
+			vpcID:         "vpc-123",
+			endpointAccess: EndpointAccess{
+				Public:  aws.Bool(false),
+				Private: aws.Bool(true),
+			},
+			expectError: false,
+		},
+		{
+			name:          "managed VPC",
+			outpostConfig: outpostConfig,
+			expectError:   true,
+		},
+		{
+			name:           "public endpoint access",
+			outpostConfig:  outpostConfig,
+			vpcID:          "vpc-123",
+			endpointAccess: EndpointAccess{Public: aws.Bool(true)},
+			expectError:    true,
+		},
+		{
+			name:                  "IAM roles for service accounts",
+			outpostConfig:         outpostConfig,
+			vpcID:                 "vpc-123",
+			associateOIDCProvider: true,
+			expectError:           true,
+		},
+		{
+			name: "invalid Outpost ARN",
+			outpostConfig: &OutpostConfig{
+				OutpostARNs:              []string{"op-0123456789abcdef0"},
+				ControlPlaneInstanceType: "m5d.large",
+			},
+			vpcID:       "vpc-123",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:        "default_cluster1",
+					OutpostConfig:         tc.outpostConfig,
+					EndpointAccess:        tc.endpointAccess,
+					AssociateOIDCProvider: tc.associateOIDCProvider,
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{ID: tc.vpcID},
+					},
+				},
+			}
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreateVPCEndpoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		vpcID          string
+		endpointAccess EndpointAccess
+		expectError    bool
+	}{
+		{
+			name: "private endpoint access in a managed VPC",
+			endpointAccess: EndpointAccess{
+				Public:             aws.Bool(false),
+				Private:            aws.Bool(true),
+				CreateVPCEndpoints: true,
+			},
+			expectError: false,
+		},
+		{
+			name: "default endpoint access",
+			endpointAccess: EndpointAccess{
+				CreateVPCEndpoints: true,
+			},
+			expectError: true,
+		},
+		{
+			name: "public and private endpoint access",
+			endpointAccess: EndpointAccess{
+				Public:             aws.Bool(true),
+				Private:            aws.Bool(true),
+				CreateVPCEndpoints: true,
+			},
+			expectError: true,
+		},
+		{
+			name:  "existing VPC",
+			vpcID: "vpc-123",
+			endpointAccess: EndpointAccess{
+				Public:             aws.Bool(false),
+				Private:            aws.Bool(true),
+				CreateVPCEndpoints: true,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName: "default_cluster1",
+					EndpointAccess: tc.endpointAccess,
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{ID: tc.vpcID},
+					},
+				},
+			}
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreatePodSubnetIDs(t *testing.T) {
+	tests := []struct {
+		name               string
+		vpcCni             VpcCni
+		secondaryCidrBlock *string
+		expectError        bool
+	}{
+		{
+			name: "pod subnets",
+			vpcCni: VpcCni{
+				PodSubnetIDs: []string{"subnet-pods-1", "subnet-pods-2"},
+			},
+			expectError: false,
+		},
+		{
+			name: "pod subnets with the vpc cni disabled",
+			vpcCni: VpcCni{
+				Disable:      true,
+				PodSubnetIDs: []string{"subnet-pods-1"},
+			},
+			expectError: true,
+		},
+		{
+			name: "pod subnets with a secondary CIDR block",
+			vpcCni: VpcCni{
+				PodSubnetIDs: []string{"subnet-pods-1"},
+			},
+			secondaryCidrBlock: aws.String("100.64.0.0/16"),
+			expectError:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:     "default_cluster1",
+					VpcCni:             tc.vpcCni,
+					SecondaryCidrBlock: tc.secondaryCidrBlock,
+				},
+			}
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookUpdateOutpostConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	oldMCP := &AWSManagedControlPlane{
+		Spec: AWSManagedControlPlaneSpec{
+			EKSClusterName: "default_cluster1",
+			OutpostConfig: &OutpostConfig{
+				OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"},
+				ControlPlaneInstanceType: "m5d.large",
+			},
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{ID: "vpc-123"},
+			},
+		},
+	}
+	newMCP := oldMCP.DeepCopy()
+	newMCP.Spec.OutpostConfig.ControlPlaneInstanceType = "m5d.xlarge"
+
+	_, err := newMCP.ValidateUpdate(oldMCP)
+	g.Expect(err).ToNot(BeNil())
+}
+
+func TestValidatingWebhookCreateAddonServiceAccountRoles(t *testing.T) {
+	tests := []struct {
+		name                  string
+		addon                 Addon
+		associateOIDCProvider bool
+		expectError           bool
+	}{
+		{
+			name:                  "known addon with defaults",
+			addon:                 Addon{Name: "aws-ebs-csi-driver", Version: "v1.30.0", ServiceAccountRole: &AddonServiceAccountRole{}},
+			associateOIDCProvider: true,
+			expectError:           false,
+		},
+		{
+			name: "other addon with service account and policies",
+			addon: Addon{Name: "adot", Version: "v0.94.1", ServiceAccountRole: &AddonServiceAccountRole{
+				ServiceAccountName: "adot-collector",
+				PolicyARNs:         []string{"arn:aws:iam::aws:policy/AWSXrayWriteOnlyAccess"},
+			}},
+			associateOIDCProvider: true,
+			expectError:           false,
+		},
+		{
+			name:                  "other addon without service account and policies",
+			addon:                 Addon{Name: "adot", Version: "v0.94.1", ServiceAccountRole: &AddonServiceAccountRole{}},
+			associateOIDCProvider: true,
+			expectError:           true,
+		},
+		{
+			name:        "without OIDC provider",
+			addon:       Addon{Name: "vpc-cni", Version: "v1.18.0", ServiceAccountRole: &AddonServiceAccountRole{}},
+			expectError: true,
+		},
+		{
+			name: "together with a service account role ARN",
+			addon: Addon{
+				Name:                  "vpc-cni",
+				Version:               "v1.18.0",
+				ServiceAccountRoleArn: aws.String("arn:aws:iam::123456789012:role/vpc-cni"),
+				ServiceAccountRole:    &AddonServiceAccountRole{},
+			},
+			associateOIDCProvider: true,
+			expectError:           true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:        "default_cluster1",
+					AssociateOIDCProvider: tc.associateOIDCProvider,
+					Addons:                &[]Addon{tc.addon},
+				},
+			}
+			mcp.Default()
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestValidatingWebhookCreatePodIdentityAssociations(t *testing.T) {
+	tests := []struct {
+		name         string
+		associations []PodIdentityAssociation
+		expectError  bool
+	}{
+		{
+			name: "associations of different service accounts",
+			associations: []PodIdentityAssociation{
+				{ServiceAccountNamespace: "apps", ServiceAccountName: "app", RoleARN: "arn:aws:iam::123456789012:role/app"},
+				{ServiceAccountNamespace: "jobs", ServiceAccountName: "app", RoleARN: "arn:aws:iam::123456789012:role/jobs"},
+			},
+			expectError: false,
+		},
+		{
+			name: "duplicate associations of a service account",
+			associations: []PodIdentityAssociation{
+				{ServiceAccountNamespace: "apps", ServiceAccountName: "app", RoleARN: "arn:aws:iam::123456789012:role/app"},
+				{ServiceAccountNamespace: "apps", ServiceAccountName: "app", RoleARN: "arn:aws:iam::123456789012:role/other"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{
+				Spec: AWSManagedControlPlaneSpec{
+					EKSClusterName:          "default_cluster1",
+					PodIdentityAssociations: tc.associations,
+				},
+			}
+			mcp.Default()
+			_, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
@@ -29,6 +29,36 @@ const (
 	EKSControlPlaneUpdatingCondition clusterv1.ConditionType = "EKSControlPlaneUpdating"
 	// EKSControlPlaneReconciliationFailedReason used to report failures while reconciling EKS control plane.
 	EKSControlPlaneReconciliationFailedReason = "EKSControlPlaneReconciliationFailed"
+	// EKSEncryptionKeyAccessDeniedReason used to report that EKS can't use the KMS key of the encryption config.
+	EKSEncryptionKeyAccessDeniedReason = "EKSEncryptionKeyAccessDenied"
+)
+
+const (
+	// EKSVersionUpdateInProgressCondition condition reports on whether an update of the Kubernetes version of
+	// the EKS control plane is in progress. The update is tracked with the EKS DescribeUpdate API.
+	EKSVersionUpdateInProgressCondition clusterv1.ConditionType = "EKSVersionUpdateInProgress"
+	// EKSVersionUpdateSucceededReason used when the last version update of the EKS control plane succeeded.
+	EKSVersionUpdateSucceededReason = "EKSVersionUpdateSucceeded"
+	// EKSVersionUpdateFailedReason used when the last version update of the EKS control plane failed or was cancelled.
+	EKSVersionUpdateFailedReason = "EKSVersionUpdateFailed"
+)
+
+const (
+	// EKSAddonUpdatePendingCondition condition reports on whether EKS addons still have to be updated to the
+	// version of the spec, e.g. while they wait for a version update of the control plane to complete.
+	EKSAddonUpdatePendingCondition clusterv1.ConditionType = "EKSAddonUpdatePending"
+	// EKSAddonsUpToDateReason used when all the EKS addons of the spec are installed with their desired version.
+	EKSAddonsUpToDateReason = "EKSAddonsUpToDate"
+)
+
+const (
+	// EKSEncryptionConfigAssociatedCondition condition reports on whether the encryption config of the spec is
+	// associated with the EKS cluster. Associating an encryption config with an existing cluster can take a while.
+	EKSEncryptionConfigAssociatedCondition clusterv1.ConditionType = "EKSEncryptionConfigAssociated"
+	// EKSEncryptionConfigAssociatingReason used to report that the encryption config is being associated with the EKS cluster.
+	EKSEncryptionConfigAssociatingReason = "EKSEncryptionConfigAssociating"
+	// EKSEncryptionConfigAssociationFailedReason used to report failures while associating the encryption config.
+	EKSEncryptionConfigAssociationFailedReason = "EKSEncryptionConfigAssociationFailed"
 )
 
 const (
@@ -58,3 +88,27 @@ const (
 	// EKSIdentityProviderConfiguredFailedReason used to report failures while reconciling the identity provider config association.
 	EKSIdentityProviderConfiguredFailedReason = "EKSIdentityProviderConfiguredFailed"
 )
+
+const (
+	// EKSNodegroupsUpgradedCondition condition reports on the progress of the orchestrated upgrade of the
+	// AWSManagedMachinePools following a control plane version upgrade.
+	EKSNodegroupsUpgradedCondition clusterv1.ConditionType = "EKSNodegroupsUpgraded"
+	// EKSNodegroupsUpgradingReason used when machine pools are still to be upgraded to the control plane version.
+	EKSNodegroupsUpgradingReason = "EKSNodegroupsUpgrading"
+)
+
+const (
+	// EKSAccessEntriesConfiguredCondition condition reports on the successful reconciliation of the EKS access entries.
+	EKSAccessEntriesConfiguredCondition clusterv1.ConditionType = "EKSAccessEntriesConfigured"
+	// EKSAccessEntriesConfiguredFailedReason used to report failures while reconciling the EKS access entries.
+	EKSAccessEntriesConfiguredFailedReason = "EKSAccessEntriesConfiguredFailed"
+	// WaitingForAuthenticationModeReason used when the authentication mode of the cluster doesn't allow access entries yet.
+	WaitingForAuthenticationModeReason = "WaitingForAuthenticationMode"
+)
+
+const (
+	// EKSPodIdentityAssociationsConfiguredCondition condition reports on the successful reconciliation of the EKS Pod Identity associations.
+	EKSPodIdentityAssociationsConfiguredCondition clusterv1.ConditionType = "EKSPodIdentityAssociationsConfigured"
+	// EKSPodIdentityAssociationsConfiguredFailedReason used to report failures while reconciling the EKS Pod Identity associations.
+	EKSPodIdentityAssociationsConfiguredFailedReason = "EKSPodIdentityAssociationsConfiguredFailed"
+)
@@ -77,6 +77,10 @@ var (
 	// EKSTokenMethodAWSCli indicates that the AWS CLI will be used to get a token
 	// Version 1.16.156 or greater is required of the AWS CLI.
 	EKSTokenMethodAWSCli = EKSTokenMethod("aws-cli")
+
+	// EKSTokenMethodEmbedded indicates that a token generated by the controller will be embedded
+	// in the kubeconfig. The token is refreshed along with the token of the CAPI kubeconfig.
+	EKSTokenMethodEmbedded = EKSTokenMethod("embedded")
 )
 
 var (
@@ -141,6 +145,47 @@ type Addon struct {
 	// ServiceAccountRoleArn is the ARN of an IAM role to bind to the addons service account
 	// +optional
 	ServiceAccountRoleArn *string `json:"serviceAccountRoleARN,omitempty"`
+	// ServiceAccountRole configures an IAM role that is created for the service account of the addon
+	// and bound to it. It requires the EKSEnableIAM feature flag and an associated OIDC provider and
+	// can't be used together with ServiceAccountRoleArn.
+	// +optional
+	ServiceAccountRole *AddonServiceAccountRole `json:"serviceAccountRole,omitempty"`
+}
+
+// AddonServiceAccountRole configures the IAM role created for the service account of an addon.
+type AddonServiceAccountRole struct {
+	// ServiceAccountNamespace is the namespace of the service account of the addon.
+	// Defaults to kube-system.
+	// +optional
+	ServiceAccountNamespace string `json:"serviceAccountNamespace,omitempty"`
+	// ServiceAccountName is the name of the service account of the addon. It defaults to
+	// the service account of the vpc-cni, aws-ebs-csi-driver and aws-efs-csi-driver addons
+	// and must be set for other addons.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// PolicyARNs are the ARNs of the IAM policies attached to the role. They default to the
+	// AWS managed policies required by the vpc-cni, aws-ebs-csi-driver and aws-efs-csi-driver
+	// addons and must be set for other addons.
+	// +optional
+	PolicyARNs []string `json:"policyARNs,omitempty"`
+}
+
+// knownAddonServiceAccountRoles are the service accounts and the AWS managed policies required by the
+// addons for which CAPA can default the service account role.
+var knownAddonServiceAccountRoles = map[string]struct {
+	serviceAccountName string
+	policyNames        []string
+}{
+	vpcCniAddon:          {serviceAccountName: "aws-node", policyNames: []string{"AmazonEKS_CNI_Policy"}},
+	"aws-ebs-csi-driver": {serviceAccountName: "ebs-csi-controller-sa", policyNames: []string{"service-role/AmazonEBSCSIDriverPolicy"}},
+	"aws-efs-csi-driver": {serviceAccountName: "efs-csi-controller-sa", policyNames: []string{"service-role/AmazonEFSCSIDriverPolicy"}},
+}
+
+// KnownAddonServiceAccountRole returns the service account of an addon and the names of the AWS managed
+// policies its service account role requires. ok is false if CAPA doesn't know the addon.
+func KnownAddonServiceAccountRole(addonName string) (serviceAccountName string, policyNames []string, ok bool) {
+	known, ok := knownAddonServiceAccountRoles[addonName]
+	return known.serviceAccountName, known.policyNames, ok
 }
 
 // AddonResolution defines the method for resolving parameter conflicts.
@@ -218,6 +263,32 @@ const (
 	SecurityGroupCluster = infrav1.SecurityGroupRole("cluster")
 )
 
+// OutpostConfig specifies the configuration of a local EKS cluster on AWS Outposts, whose
+// Kubernetes control plane runs on the Outpost instead of in the AWS region.
+type OutpostConfig struct {
+	// OutpostARNs are the ARNs of the Outposts to host the Kubernetes control plane on.
+	// Only a single Outpost is supported.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1
+	OutpostARNs []string `json:"outpostARNs"`
+
+	// ControlPlaneInstanceType is the EC2 instance type of the control plane instances,
+	// which must be available on the Outpost, e.g. m5d.large.
+	// +kubebuilder:validation:MinLength=2
+	ControlPlaneInstanceType string `json:"controlPlaneInstanceType"`
+
+	// ControlPlanePlacement specifies the placement of the control plane instances on the Outpost.
+	// +optional
+	ControlPlanePlacement *ControlPlanePlacement `json:"controlPlanePlacement,omitempty"`
+}
+
+// ControlPlanePlacement specifies the placement of the control plane instances of a local EKS cluster.
+type ControlPlanePlacement struct {
+	// GroupName is the name of an existing placement group for the control plane instances.
+	// +kubebuilder:validation:MinLength=1
+	GroupName string `json:"groupName"`
+}
+
 // OIDCIdentityProviderConfig represents the configuration for an OIDC identity provider.
 type OIDCIdentityProviderConfig struct {
 	// This is also known as audience. The ID for the client application that makes
@@ -279,3 +350,145 @@ type OIDCIdentityProviderConfig struct {
 	// +optional
 	Tags infrav1.Tags `json:"tags,omitempty"`
 }
+
+// EKSAuthenticationMode defines how IAM principals are authenticated against the EKS cluster.
+type EKSAuthenticationMode string
+
+var (
+	// EKSAuthenticationModeConfigMap indicates that only the aws-auth ConfigMap is used.
+	EKSAuthenticationModeConfigMap = EKSAuthenticationMode("CONFIG_MAP")
+
+	// EKSAuthenticationModeAPI indicates that only EKS access entries are used.
+	EKSAuthenticationModeAPI = EKSAuthenticationMode("API")
+
+	// EKSAuthenticationModeAPIAndConfigMap indicates that both EKS access entries and the
+	// aws-auth ConfigMap are used.
+	EKSAuthenticationModeAPIAndConfigMap = EKSAuthenticationMode("API_AND_CONFIG_MAP")
+)
+
+// AccessConfig represents the access configuration of the EKS cluster.
+type AccessConfig struct {
+	// AuthenticationMode specifies the source of the authenticated IAM principals. The mode can
+	// only be changed from CONFIG_MAP to API_AND_CONFIG_MAP and from API_AND_CONFIG_MAP to API.
+	// +kubebuilder:default=CONFIG_MAP
+	// +kubebuilder:validation:Enum=CONFIG_MAP;API;API_AND_CONFIG_MAP
+	AuthenticationMode EKSAuthenticationMode `json:"authenticationMode,omitempty"`
+}
+
+// UpgradeSupportType defines the support type of an EKS cluster.
+type UpgradeSupportType string
+
+var (
+	// UpgradeSupportTypeStandard indicates that the cluster is upgraded automatically at the end of
+	// the standard support of its Kubernetes version.
+	UpgradeSupportTypeStandard = UpgradeSupportType("STANDARD")
+
+	// UpgradeSupportTypeExtended indicates that the cluster enters extended support at the end of
+	// the standard support of its Kubernetes version.
+	UpgradeSupportTypeExtended = UpgradeSupportType("EXTENDED")
+)
+
+// UpgradePolicy represents the support policy of the EKS cluster.
+type UpgradePolicy struct {
+	// SupportType specifies whether the cluster enters extended support at the end of the standard
+	// support of its Kubernetes version.
+	// +kubebuilder:validation:Enum=STANDARD;EXTENDED
+	SupportType UpgradeSupportType `json:"supportType"`
+}
+
+// AccessEntryType defines the type of an access entry.
+type AccessEntryType string
+
+var (
+	// AccessEntryTypeStandard is the type of access entries of users and roles accessing the cluster.
+	AccessEntryTypeStandard = AccessEntryType("STANDARD")
+
+	// AccessEntryTypeEC2Linux is the type of access entries of roles used by self-managed Linux nodes.
+	AccessEntryTypeEC2Linux = AccessEntryType("EC2_LINUX")
+
+	// AccessEntryTypeEC2Windows is the type of access entries of roles used by self-managed Windows nodes.
+	AccessEntryTypeEC2Windows = AccessEntryType("EC2_WINDOWS")
+
+	// AccessEntryTypeFargateLinux is the type of access entries of roles used by Fargate pods.
+	AccessEntryTypeFargateLinux = AccessEntryType("FARGATE_LINUX")
+)
+
+// AccessEntry represents an EKS access entry of an IAM principal.
+type AccessEntry struct {
+	// PrincipalARN is the ARN of the IAM user or role granted access to the cluster.
+	// +kubebuilder:validation:Required
+	PrincipalARN string `json:"principalARN"`
+
+	// Type is the type of the access entry. Access policies can only be associated with
+	// STANDARD access entries.
+	// +kubebuilder:default=STANDARD
+	// +kubebuilder:validation:Enum=STANDARD;EC2_LINUX;EC2_WINDOWS;FARGATE_LINUX
+	// +optional
+	Type AccessEntryType `json:"type,omitempty"`
+
+	// KubernetesGroups are the Kubernetes groups the principal is mapped to, which can be
+	// referenced by RBAC role bindings.
+	// +optional
+	KubernetesGroups []string `json:"kubernetesGroups,omitempty"`
+
+	// Username is the Kubernetes username of the principal. Defaults to the ARN of the
+	// principal if not set.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// AccessPolicies are the EKS access policies associated with the access entry.
+	// +optional
+	AccessPolicies []AccessPolicyReference `json:"accessPolicies,omitempty"`
+}
+
+// PodIdentityAssociation represents an EKS Pod Identity association between a service account
+// and an IAM role.
+type PodIdentityAssociation struct {
+	// ServiceAccountNamespace is the namespace of the service account.
+	// +kubebuilder:validation:MinLength=1
+	ServiceAccountNamespace string `json:"serviceAccountNamespace"`
+
+	// ServiceAccountName is the name of the service account.
+	// +kubebuilder:validation:MinLength=1
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// RoleARN is the ARN of the IAM role whose credentials are provided to the pods using the
+	// service account. Its trust policy must allow pods.eks.amazonaws.com to assume it.
+	// +kubebuilder:validation:MinLength=1
+	RoleARN string `json:"roleARN"`
+}
+
+// AccessScopeType defines the scope of an access policy.
+type AccessScopeType string
+
+var (
+	// AccessScopeTypeCluster grants the permissions of the access policy in the whole cluster.
+	AccessScopeTypeCluster = AccessScopeType("cluster")
+
+	// AccessScopeTypeNamespace grants the permissions of the access policy in the listed namespaces.
+	AccessScopeTypeNamespace = AccessScopeType("namespace")
+)
+
+// AccessPolicyReference represents an EKS access policy associated with an access entry.
+type AccessPolicyReference struct {
+	// PolicyARN is the ARN of the access policy, e.g.
+	// arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy.
+	// +kubebuilder:validation:Required
+	PolicyARN string `json:"policyARN"`
+
+	// AccessScope is the scope in which the access policy applies.
+	// +kubebuilder:validation:Required
+	AccessScope AccessScope `json:"accessScope"`
+}
+
+// AccessScope represents the scope of an access policy.
+type AccessScope struct {
+	// Type is the type of the scope.
+	// +kubebuilder:default=cluster
+	// +kubebuilder:validation:Enum=cluster;namespace
+	Type AccessScopeType `json:"type"`
+
+	// Namespaces are the namespaces the access policy applies to if the type is namespace.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
@@ -31,7 +31,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -49,6 +51,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/kubeproxy"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/securitygroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/paused"
@@ -63,6 +66,14 @@ const (
 	// has dependencies during deletion.
 	deleteRequeueAfter = 20 * time.Second
 
+	// nodegroupUpgradeRequeueAfter is how long to wait before checking again on the progress of an
+	// orchestrated nodegroup upgrade.
+	nodegroupUpgradeRequeueAfter = time.Minute
+
+	// controlPlaneUpdateRequeueAfter is how long to wait before checking again on the progress of an update
+	// of the EKS control plane.
+	controlPlaneUpdateRequeueAfter = 30 * time.Second
+
 	awsManagedControlPlaneKind = "AWSManagedControlPlane"
 )
 
@@ -79,6 +90,9 @@ func securityGroupRolesForControlPlane(scope *scope.ManagedControlPlaneScope) []
 	if scope.Bastion().Enabled {
 		roles = append(roles, infrav1.SecurityGroupBastion)
 	}
+	if scope.CreateVPCEndpoints() || len(scope.VPCEndpoints().FilterInterface()) > 0 {
+		roles = append(roles, infrav1.SecurityGroupVPCEndpoint)
+	}
 	return roles
 }
 
@@ -190,6 +204,18 @@ func (r *AWSManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 		return fmt.Errorf("failed adding a watch for AWSManagedCluster")
 	}
 
+	// The progress of an orchestrated nodegroup upgrade is reported by this controller only, as the machine pools
+	// of a cluster are reconciled concurrently. The machine pools trigger its reconciliation once upgraded.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		if err = c.Watch(
+			source.Kind[client.Object](mgr.GetCache(), &expinfrav1.AWSManagedMachinePool{},
+				handler.EnqueueRequestsFromMapFunc(r.managedMachinePoolToManagedControlPlane(ctx, log)),
+				nodegroupUpgradedChanged()),
+		); err != nil {
+			return fmt.Errorf("failed adding a watch for AWSManagedMachinePool: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -270,10 +296,23 @@ func (r *AWSManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		if managedScope.VPC().IsManaged(managedScope.Name()) {
 			applicableConditions = append(applicableConditions,
 				infrav1.InternetGatewayReadyCondition,
-				infrav1.NatGatewaysReadyCondition,
 				infrav1.RouteTablesReadyCondition,
 				infrav1.VpcEndpointsReadyCondition,
 			)
+			if managedScope.VPC().IsNatInstanceEnabled() {
+				applicableConditions = append(applicableConditions, infrav1.NatInstancesReadyCondition)
+			} else {
+				applicableConditions = append(applicableConditions, infrav1.NatGatewaysReadyCondition)
+			}
+			if managedScope.VPC().FlowLogs != nil {
+				applicableConditions = append(applicableConditions, infrav1.VpcFlowLogsReadyCondition)
+			}
+			if managedScope.VPC().DHCPOptions != nil {
+				applicableConditions = append(applicableConditions, infrav1.DhcpOptionsReadyCondition)
+			}
+			if managedScope.NetworkACLs() != nil {
+				applicableConditions = append(applicableConditions, infrav1.NetworkACLsReadyCondition)
+			}
 			if managedScope.Bastion().Enabled {
 				applicableConditions = append(applicableConditions, infrav1.BastionHostReadyCondition)
 			}
@@ -342,6 +381,10 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile general security groups for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
 	}
 
+	if err := networkSvc.ReconcileVPCEndpoints(); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to reconcile VPC endpoints for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
+	}
+
 	if err := ec2Service.ReconcileBastion(); err != nil {
 		conditions.MarkFalse(awsManagedControlPlane, infrav1.BastionHostReadyCondition, infrav1.BastionHostFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile bastion host for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
@@ -351,6 +394,15 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
 	}
 
+	// The endpoint is only known once the EKS cluster is active.
+	if managedScope.APIServerPrivateDNSRecord() != nil && managedScope.APIServerDNSName() != "" {
+		if err := route53.NewService(managedScope).ReconcileAPIServerPrivateDNSRecord(); err != nil {
+			conditions.MarkFalse(awsManagedControlPlane, infrav1.APIServerPrivateDNSRecordReadyCondition, infrav1.APIServerPrivateDNSRecordFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile API server private DNS record for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+		}
+		conditions.MarkTrue(awsManagedControlPlane, infrav1.APIServerPrivateDNSRecordReadyCondition)
+	}
+
 	if err := awsnodeService.ReconcileCNI(ctx); err != nil {
 		conditions.MarkFalse(managedScope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition, infrav1.SecondaryCidrReconciliationFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
 		return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane for AWSManagedControlPlane %s/%s: %w", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name, err)
@@ -367,11 +419,14 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 			managedScope.Error(err, "non-fatal: failed to set up EventBridge")
 		}
 	}
-	if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
-		conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
-		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile aws-iam-authenticator config for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+	// The aws-auth ConfigMap is ignored by EKS if only access entries are used for authentication.
+	if accessConfig := awsManagedControlPlane.Spec.AccessConfig; accessConfig == nil || accessConfig.AuthenticationMode != ekscontrolplanev1.EKSAuthenticationModeAPI {
+		if err := authService.ReconcileIAMAuthenticator(ctx); err != nil {
+			conditions.MarkFalse(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition, ekscontrolplanev1.IAMAuthenticatorConfigurationFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile aws-iam-authenticator config for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+		}
+		conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 	}
-	conditions.MarkTrue(awsManagedControlPlane, ekscontrolplanev1.IAMAuthenticatorConfiguredCondition)
 
 	for _, subnet := range managedScope.Subnets().FilterPrivate() {
 		managedScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
@@ -379,9 +434,66 @@ func (r *AWSManagedControlPlaneReconciler) reconcileNormal(ctx context.Context,
 		})
 	}
 
+	// Edge zones can only host the nodes of machine deployments which opt in by setting their failure domain.
+	for _, subnet := range managedScope.Subnets().FilterEdge() {
+		managedScope.SetFailureDomain(subnet.AvailabilityZone, clusterv1.FailureDomainSpec{
+			ControlPlane: false,
+		})
+	}
+
+	upgrading, err := r.reconcileNodegroupUpgrade(ctx, managedScope)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile nodegroup upgrade for AWSManagedControlPlane %s/%s", awsManagedControlPlane.Namespace, awsManagedControlPlane.Name)
+	}
+	if upgrading {
+		return reconcile.Result{RequeueAfter: nodegroupUpgradeRequeueAfter}, nil
+	}
+
+	// Updates of the EKS control plane aren't waited for, so check on them again until they have completed.
+	if conditions.IsTrue(awsManagedControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition) {
+		return reconcile.Result{RequeueAfter: controlPlaneUpdateRequeueAfter}, nil
+	}
+
+	// The kubeconfig tokens are only regenerated during reconciliation, so requeue to regenerate them
+	// once the refresh interval has passed.
+	if interval := managedScope.KubeconfigRefreshInterval(); interval > 0 {
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// reconcileNodegroupUpgrade reports the progress of an orchestrated upgrade of the AWSManagedMachinePools
+// of the cluster, based on the upgrade conditions reported by the machine pools. It returns whether machine
+// pools are still to be upgraded.
+func (r *AWSManagedControlPlaneReconciler) reconcileNodegroupUpgrade(ctx context.Context, managedScope *scope.ManagedControlPlaneScope) (bool, error) {
+	controlPlane := managedScope.ControlPlane
+	if controlPlane.Spec.NodegroupUpgrade == nil || !feature.Gates.Enabled(feature.MachinePool) {
+		conditions.Delete(controlPlane, ekscontrolplanev1.EKSNodegroupsUpgradedCondition)
+		return false, nil
+	}
+
+	managedMachinePools := &expinfrav1.AWSManagedMachinePoolList{}
+	if err := r.Client.List(ctx, managedMachinePools, client.InNamespace(managedScope.Namespace()), client.MatchingLabels{clusterv1.ClusterNameLabel: managedScope.Name()}); err != nil {
+		return false, fmt.Errorf("failed to list managed machine pools: %w", err)
+	}
+
+	var pending []string
+	for i := range managedMachinePools.Items {
+		pool := &managedMachinePools.Items[i]
+		if !conditions.IsTrue(pool, expinfrav1.EKSNodegroupUpgradedCondition) {
+			pending = append(pending, pool.Name)
+		}
+	}
+	if len(pending) > 0 {
+		conditions.MarkFalse(controlPlane, ekscontrolplanev1.EKSNodegroupsUpgradedCondition, ekscontrolplanev1.EKSNodegroupsUpgradingReason, clusterv1.ConditionSeverityInfo,
+			"%d of %d machine pools upgraded, waiting for %s", len(managedMachinePools.Items)-len(pending), len(managedMachinePools.Items), strings.Join(pending, ", "))
+		return true, nil
+	}
+	conditions.MarkTrue(controlPlane, ekscontrolplanev1.EKSNodegroupsUpgradedCondition)
+	return false, nil
+}
+
 func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context, managedScope *scope.ManagedControlPlaneScope) (_ ctrl.Result, reterr error) {
 	log := logger.FromContext(ctx)
 
@@ -405,6 +517,12 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 	networkSvc := network.NewService(managedScope)
 	sgService := securitygroup.NewService(managedScope, securityGroupRolesForControlPlane(managedScope))
 
+	// The record points at the EKS endpoint, it has to be deleted while the endpoint is known.
+	if err := route53.NewService(managedScope).DeleteAPIServerPrivateDNSRecord(); err != nil {
+		log.Error(err, "error deleting API server private DNS record for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+
 	if err := ekssvc.DeleteControlPlane(); err != nil {
 		log.Error(err, "error deleting EKS cluster for EKS control plane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
@@ -415,6 +533,18 @@ func (r *AWSManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		return reconcile.Result{}, err
 	}
 
+	// The interface VPC endpoints use a security group of the cluster.
+	if err := networkSvc.DeleteVPCEndpoints(); err != nil {
+		log.Error(err, "error deleting VPC endpoints for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+
+	// The NAT instances use a security group of the cluster.
+	if err := networkSvc.DeleteNatInstances(); err != nil {
+		log.Error(err, "error deleting NAT instances for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
+		return reconcile.Result{}, err
+	}
+
 	if err := sgService.DeleteSecurityGroups(); err != nil {
 		log.Error(err, "error deleting general security groups for AWSManagedControlPlane", "namespace", controlPlane.Namespace, "name", controlPlane.Name)
 		return reconcile.Result{}, err
@@ -536,3 +666,58 @@ func (r *AWSManagedControlPlaneReconciler) managedClusterToManagedControlPlane(_
 		}
 	}
 }
+
+func (r *AWSManagedControlPlaneReconciler) managedMachinePoolToManagedControlPlane(_ context.Context, log *logger.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []ctrl.Request {
+		awsManagedMachinePool, ok := o.(*expinfrav1.AWSManagedMachinePool)
+		if !ok {
+			log.Error(fmt.Errorf("expected a AWSManagedMachinePool but got a %T", o), "Expected AWSManagedMachinePool")
+			return nil
+		}
+
+		cluster, err := util.GetClusterFromMetadata(ctx, r.Client, awsManagedMachinePool.ObjectMeta)
+		if err != nil {
+			log.Debug("Failed to get cluster of AWSManagedMachinePool, skipping mapping", "error", err)
+			return nil
+		}
+
+		controlPlaneRef := cluster.Spec.ControlPlaneRef
+		if controlPlaneRef == nil || controlPlaneRef.Kind != awsManagedControlPlaneKind {
+			log.Debug("ControlPlaneRef is nil or not AWSManagedControlPlane, skipping mapping")
+			return nil
+		}
+
+		return []ctrl.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      controlPlaneRef.Name,
+					Namespace: controlPlaneRef.Namespace,
+				},
+			},
+		}
+	}
+}
+
+// nodegroupUpgradedChanged filters the events of AWSManagedMachinePools to those changing the progress of an
+// orchestrated nodegroup upgrade, i.e. the upgrade of a machine pool completing or a machine pool being deleted.
+func nodegroupUpgradedChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPool, ok := e.ObjectOld.(*expinfrav1.AWSManagedMachinePool)
+			if !ok {
+				return false
+			}
+			newPool, ok := e.ObjectNew.(*expinfrav1.AWSManagedMachinePool)
+			if !ok {
+				return false
+			}
+			return conditions.IsTrue(oldPool, expinfrav1.EKSNodegroupUpgradedCondition) != conditions.IsTrue(newPool, expinfrav1.EKSNodegroupUpgradedCondition)
+		},
+		GenericFunc: func(event.GenericEvent) bool {
+			return false
+		},
+	}
+}
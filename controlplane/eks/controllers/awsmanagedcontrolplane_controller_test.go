@@ -310,6 +310,23 @@ func mockedCallsForMissingEverything(ec2Rec *mocks.MockEC2APIMockRecorder, subne
 		EnableDnsSupport: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
 	}, nil)
 
+	ec2Rec.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{aws.String("vpc-new")},
+	})).Return(&ec2.DescribeVpcsOutput{
+		Vpcs: []*ec2.Vpc{
+			{
+				VpcId:     aws.String("vpc-new"),
+				CidrBlock: aws.String("10.0.0.0/8"),
+				CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+					{
+						CidrBlock:      aws.String("10.0.0.0/8"),
+						CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)},
+					},
+				},
+			},
+		},
+	}, nil)
+
 	ec2Rec.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
 		Filters: []*ec2.Filter{
 			{
@@ -919,6 +936,7 @@ func mockedEKSCluster(g *WithT, eksRec *mock_eksiface.MockEKSAPIMockRecorder, ia
 		GroupIds: aws.StringSlice([]string{"eks-cluster-sg-test-cluster-44556677"}),
 	})).Return(
 		clusterSgDesc, nil)
+	ec2Rec.DescribeSecurityGroupRulesPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
 
 	req, err := http.NewRequest(http.MethodGet, "foobar", http.NoBody)
 	g.Expect(err).To(BeNil())
@@ -17,11 +17,24 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestSecurityGroupRolesForCluster(t *testing.T) {
@@ -61,3 +74,135 @@ func TestSecurityGroupRolesForCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityGroupRolesForVPCEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	_, _, awsManagedControlPlane := getManagedClusterObjects("test", "test")
+	s, err := getManagedControlPlaneScope(awsManagedControlPlane)
+	g.Expect(err).To(BeNil(), "failed to create cluster scope for test")
+	g.Expect(securityGroupRolesForControlPlane(s)).ToNot(ContainElement(infrav1.SecurityGroupVPCEndpoint))
+
+	awsManagedControlPlane.Spec.NetworkSpec.VPCEndpoints = infrav1.VPCEndpoints{{Service: "ssm", Type: infrav1.VPCEndpointTypeInterface}}
+	s, err = getManagedControlPlaneScope(awsManagedControlPlane)
+	g.Expect(err).To(BeNil(), "failed to create cluster scope for test")
+	g.Expect(securityGroupRolesForControlPlane(s)).To(ContainElement(infrav1.SecurityGroupVPCEndpoint))
+
+	awsManagedControlPlane.Spec.NetworkSpec.VPCEndpoints = nil
+	awsManagedControlPlane.Spec.EndpointAccess.CreateVPCEndpoints = true
+	s, err = getManagedControlPlaneScope(awsManagedControlPlane)
+	g.Expect(err).To(BeNil(), "failed to create cluster scope for test")
+	g.Expect(securityGroupRolesForControlPlane(s)).To(ContainElement(infrav1.SecurityGroupVPCEndpoint))
+}
+
+func newManagedMachinePool(name string, upgraded bool) *expinfrav1.AWSManagedMachinePool {
+	pool := &expinfrav1.AWSManagedMachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+		},
+	}
+	if upgraded {
+		conditions.MarkTrue(pool, expinfrav1.EKSNodegroupUpgradedCondition)
+	} else {
+		conditions.MarkFalse(pool, expinfrav1.EKSNodegroupUpgradedCondition, expinfrav1.WaitingForNodegroupUpgradeReason, clusterv1.ConditionSeverityInfo, "")
+	}
+	return pool
+}
+
+func TestReconcileNodegroupUpgrade(t *testing.T) {
+	tests := []struct {
+		name              string
+		pools             []client.Object
+		expectUpgrading   bool
+		expectedCondition corev1.ConditionStatus
+	}{
+		{
+			name:              "reports the machine pools still to be upgraded",
+			pools:             []client.Object{newManagedMachinePool("pool-a", false), newManagedMachinePool("pool-b", true)},
+			expectUpgrading:   true,
+			expectedCondition: corev1.ConditionFalse,
+		},
+		{
+			name:              "reports the upgrade of all machine pools",
+			pools:             []client.Object{newManagedMachinePool("pool-a", true), newManagedMachinePool("pool-b", true)},
+			expectedCondition: corev1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			_ = expinfrav1.AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.pools...).Build()
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-control-plane", Namespace: "default"},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					NodegroupUpgrade: &ekscontrolplanev1.NodegroupUpgrade{},
+				},
+			}
+			managedScope := &scope.ManagedControlPlaneScope{
+				Cluster:      &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}},
+				ControlPlane: controlPlane,
+			}
+
+			r := &AWSManagedControlPlaneReconciler{Client: c}
+			upgrading, err := r.reconcileNodegroupUpgrade(context.TODO(), managedScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(upgrading).To(Equal(tc.expectUpgrading))
+
+			condition := conditions.Get(controlPlane, ekscontrolplanev1.EKSNodegroupsUpgradedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedCondition))
+		})
+	}
+}
+
+func TestManagedMachinePoolToManagedControlPlane(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	scheme := runtime.NewScheme()
+	_ = expinfrav1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				Kind:      awsManagedControlPlaneKind,
+				Name:      "test-control-plane",
+				Namespace: "default",
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster).Build()
+
+	r := &AWSManagedControlPlaneReconciler{Client: c}
+	mapFunc := r.managedMachinePoolToManagedControlPlane(ctx, logger.FromContext(ctx))
+
+	requests := mapFunc(ctx, newManagedMachinePool("pool-a", true))
+	g.Expect(requests).To(HaveLen(1))
+	g.Expect(requests[0].NamespacedName).To(Equal(client.ObjectKey{Namespace: "default", Name: "test-control-plane"}))
+
+	orphan := newManagedMachinePool("pool-b", true)
+	orphan.Labels = nil
+	g.Expect(mapFunc(ctx, orphan)).To(BeEmpty())
+}
+
+func TestNodegroupUpgradedChanged(t *testing.T) {
+	g := NewWithT(t)
+	p := nodegroupUpgradedChanged()
+
+	g.Expect(p.Create(event.CreateEvent{Object: newManagedMachinePool("pool-a", false)})).To(BeFalse())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: newManagedMachinePool("pool-a", false), ObjectNew: newManagedMachinePool("pool-a", true)})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: newManagedMachinePool("pool-a", true), ObjectNew: newManagedMachinePool("pool-a", false)})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: newManagedMachinePool("pool-a", false), ObjectNew: newManagedMachinePool("pool-a", false)})).To(BeFalse())
+	g.Expect(p.Delete(event.DeleteEvent{Object: newManagedMachinePool("pool-a", false)})).To(BeTrue())
+}
@@ -61,8 +61,27 @@ type FargateProfileSpec struct {
 
 	// Selectors specify fargate pod selectors.
 	Selectors []FargateSelector `json:"selectors,omitempty"`
+
+	// UpdateStrategy specifies how changes of the selectors or subnets are applied. As EKS fargate
+	// profiles can't be updated, the profile is replaced: CreateBeforeDelete creates a new profile
+	// and deletes the old one once the new one is active, DeleteBeforeCreate deletes the profile
+	// and creates it again. If not set, the selectors and subnets are immutable.
+	// +kubebuilder:validation:Enum=CreateBeforeDelete;DeleteBeforeCreate
+	// +optional
+	UpdateStrategy FargateProfileUpdateStrategy `json:"updateStrategy,omitempty"`
 }
 
+// FargateProfileUpdateStrategy specifies how a fargate profile is replaced when its selectors or subnets change.
+type FargateProfileUpdateStrategy string
+
+const (
+	// FargateProfileUpdateStrategyCreateBeforeDelete creates the new profile before deleting the old one.
+	FargateProfileUpdateStrategyCreateBeforeDelete FargateProfileUpdateStrategy = "CreateBeforeDelete"
+
+	// FargateProfileUpdateStrategyDeleteBeforeCreate deletes the old profile before creating the new one.
+	FargateProfileUpdateStrategyDeleteBeforeCreate FargateProfileUpdateStrategy = "DeleteBeforeCreate"
+)
+
 // FargateSelector specifies a selector for pods that should run on this fargate pool.
 type FargateSelector struct {
 	// Labels specifies which pod labels this selector should match.
@@ -116,6 +135,16 @@ type FargateProfileStatus struct {
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
+	// ProfileName is the name of the EKS fargate profile in use. It differs from
+	// spec.profileName once the profile has been replaced by a new one.
+	// +optional
+	ProfileName string `json:"profileName,omitempty"`
+
+	// ReplacedProfileName is the name of the EKS fargate profile that is deleted
+	// once the profile replacing it is active.
+	// +optional
+	ReplacedProfileName string `json:"replacedProfileName,omitempty"`
+
 	// Conditions defines current state of the Fargate profile.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
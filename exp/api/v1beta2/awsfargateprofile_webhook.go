@@ -114,6 +114,13 @@ func (r *AWSFargateProfile) ValidateUpdate(oldObj runtime.Object) (admission.War
 	old.Spec.AdditionalTags = nil
 	r.Spec.AdditionalTags = nil
 
+	// selectors and subnets are mutable if the profile is replaced on changes
+	old.Spec.UpdateStrategy = r.Spec.UpdateStrategy
+	if r.Spec.UpdateStrategy != "" {
+		old.Spec.Selectors = r.Spec.Selectors
+		old.Spec.SubnetIDs = r.Spec.SubnetIDs
+	}
+
 	if !cmp.Equal(old.Spec, r.Spec) {
 		allErrs = append(
 			allErrs,
@@ -130,6 +130,57 @@ func TestAWSFargateProfileValidateRoleNameUpdate(t *testing.T) {
 	}
 }
 
+func TestAWSFargateProfileValidateSelectorsUpdate(t *testing.T) {
+	before := &AWSFargateProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "default",
+		},
+		Spec: FargateProfileSpec{
+			ClusterName: "clustername",
+			ProfileName: "profilename",
+			SubnetIDs:   []string{"subnet-1"},
+			Selectors:   []FargateSelector{{Namespace: "default"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		expectErr      bool
+		before         *AWSFargateProfile
+		updateStrategy FargateProfileUpdateStrategy
+	}{
+		{
+			name:      "update selectors and subnets should fail without update strategy",
+			expectErr: true,
+			before:    before,
+		},
+		{
+			name:           "update selectors and subnets should succeed with update strategy",
+			before:         before,
+			updateStrategy: FargateProfileUpdateStrategyCreateBeforeDelete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fargateProfile := tt.before.DeepCopy()
+			fargateProfile.Spec.UpdateStrategy = tt.updateStrategy
+			fargateProfile.Spec.SubnetIDs = []string{"subnet-2"}
+			fargateProfile.Spec.Selectors = []FargateSelector{{Namespace: "apps", Labels: map[string]string{"app": "web"}}}
+
+			_, err := fargateProfile.ValidateUpdate(tt.before.DeepCopy())
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func TestAWSFargateProfileValidateCreate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -40,6 +40,20 @@ const (
 	Al2023x86_64 ManagedMachineAMIType = "AL2023_x86_64_STANDARD"
 	// Al2023Arm64 is the AL2023 Arm AMI type.
 	Al2023Arm64 ManagedMachineAMIType = "AL2023_ARM_64_STANDARD"
+	// Bottlerocketx86_64 is the Bottlerocket x86-64 AMI type.
+	Bottlerocketx86_64 ManagedMachineAMIType = "BOTTLEROCKET_x86_64"
+	// BottlerocketArm64 is the Bottlerocket Arm AMI type.
+	BottlerocketArm64 ManagedMachineAMIType = "BOTTLEROCKET_ARM_64"
+	// WindowsCore2019x86_64 is the Windows Server 2019 Core AMI type.
+	WindowsCore2019x86_64 ManagedMachineAMIType = "WINDOWS_CORE_2019_x86_64"
+	// WindowsFull2019x86_64 is the Windows Server 2019 Full AMI type.
+	WindowsFull2019x86_64 ManagedMachineAMIType = "WINDOWS_FULL_2019_x86_64"
+	// WindowsCore2022x86_64 is the Windows Server 2022 Core AMI type.
+	WindowsCore2022x86_64 ManagedMachineAMIType = "WINDOWS_CORE_2022_x86_64"
+	// WindowsFull2022x86_64 is the Windows Server 2022 Full AMI type.
+	WindowsFull2022x86_64 ManagedMachineAMIType = "WINDOWS_FULL_2022_x86_64"
+	// Custom is the AMI type of node groups using a custom AMI specified in their launch template.
+	Custom ManagedMachineAMIType = "CUSTOM"
 )
 
 // ManagedMachinePoolCapacityType specifies the capacity type to be used for the managed MachinePool.
@@ -52,6 +66,14 @@ const (
 	ManagedMachinePoolCapacityTypeSpot ManagedMachinePoolCapacityType = "spot"
 )
 
+const (
+	// ExternalScalingAnnotation marks an AWSManagedMachinePool whose nodegroup desired size is managed
+	// by an external autoscaler, e.g. cluster-autoscaler. The desired size of the nodegroup is then
+	// no longer reconciled to the MachinePool replicas, but copied into them instead.
+	// It only takes effect when set to "true".
+	ExternalScalingAnnotation = "aws.cluster.x-k8s.io/external-scaling"
+)
+
 var (
 	// DefaultEKSNodegroupRole is the name of the default IAM role to use for EKS nodegroups
 	// if no other role is supplied in the spec and if iam role creation is not enabled. The default
@@ -99,6 +121,15 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	RoleName string `json:"roleName,omitempty"`
 
+	// Version defines the desired Kubernetes version of the node group. This allows
+	// the node group to be upgraded independently of the control plane. If no version
+	// is supplied then the MachinePool version is used, falling back to the control
+	// plane version. The version must be within one minor version of the control plane.
+	// +kubebuilder:validation:MinLength:=2
+	// +kubebuilder:validation:Pattern:=^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.?(\.0|[1-9][0-9]*)?$
+	// +optional
+	Version *string `json:"version,omitempty"`
+
 	// AMIVersion defines the desired AMI release version. If no version number
 	// is supplied then the latest version for the Kubernetes version
 	// will be used
@@ -107,7 +138,7 @@ type AWSManagedMachinePoolSpec struct {
 	AMIVersion *string `json:"amiVersion,omitempty"`
 
 	// AMIType defines the AMI type
-	// +kubebuilder:validation:Enum:=AL2_x86_64;AL2_x86_64_GPU;AL2_ARM_64;AL2023_x86_64_STANDARD;AL2023_ARM_64_STANDARD;CUSTOM
+	// +kubebuilder:validation:Enum:=AL2_x86_64;AL2_x86_64_GPU;AL2_ARM_64;AL2023_x86_64_STANDARD;AL2023_ARM_64_STANDARD;BOTTLEROCKET_x86_64;BOTTLEROCKET_ARM_64;WINDOWS_CORE_2019_x86_64;WINDOWS_FULL_2019_x86_64;WINDOWS_CORE_2022_x86_64;WINDOWS_FULL_2022_x86_64;CUSTOM
 	// +kubebuilder:default:=AL2_x86_64
 	// +optional
 	AMIType *ManagedMachineAMIType `json:"amiType,omitempty"`
@@ -128,6 +159,11 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	InstanceType *string `json:"instanceType,omitempty"`
 
+	// InstanceTypes specifies multiple AWS instance types, e.g. to diversify the Spot capacity
+	// of the pool. It can't be combined with InstanceType.
+	// +optional
+	InstanceTypes []string `json:"instanceTypes,omitempty"`
+
 	// Scaling specifies scaling for the ASG behind this pool
 	// +optional
 	Scaling *ManagedMachinePoolScaling `json:"scaling,omitempty"`
@@ -153,11 +189,46 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	UpdateConfig *UpdateConfig `json:"updateConfig,omitempty"`
 
+	// ForceUpdate forces the version and AMI updates of the nodegroup, including launch template
+	// version changes, to complete even if pods can't be drained from the nodes due to pod disruption
+	// budgets. Nodes are terminated regardless of their pod disruption budgets in that case.
+	// +optional
+	ForceUpdate bool `json:"forceUpdate,omitempty"`
+
 	// AWSLaunchTemplate specifies the launch template to use to create the managed node group.
 	// If AWSLaunchTemplate is specified, certain node group configuraions outside of launch template
 	// are prohibited (https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html).
 	// +optional
 	AWSLaunchTemplate *AWSLaunchTemplate `json:"awsLaunchTemplate,omitempty"`
+
+	// LaunchTemplate references an existing launch template to use to create the managed node group,
+	// as an alternative to AWSLaunchTemplate. The launch template is neither modified nor deleted.
+	// Changing its version updates the node group to the new version.
+	// +optional
+	LaunchTemplate *LaunchTemplateReference `json:"launchTemplate,omitempty"`
+
+	// AdditionalIngressRules is an optional set of ingress rules to add to the security group
+	// of the nodegroup reported in status.securityGroupID, e.g. to allow scraping node metrics
+	// from a monitoring VPC. Only rules added by this field are managed, rules added by EKS or
+	// other tools are left untouched.
+	// +optional
+	AdditionalIngressRules []infrav1.IngressRule `json:"additionalIngressRules,omitempty"`
+}
+
+// LaunchTemplateReference references an existing EC2 launch template by ID or name.
+type LaunchTemplateReference struct {
+	// ID is the ID of the launch template. Exactly one of ID and Name must be set.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// Name is the name of the launch template. Exactly one of ID and Name must be set.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Version is the version of the launch template. Defaults to the default version of the
+	// launch template at the time the node group is created.
+	// +optional
+	Version *string `json:"version,omitempty"`
 }
 
 // ManagedMachinePoolScaling specifies scaling options.
@@ -198,6 +269,12 @@ type AWSManagedMachinePoolStatus struct {
 	// +optional
 	LaunchTemplateVersion *string `json:"launchTemplateVersion,omitempty"`
 
+	// SecurityGroupID is the ID of the security group created by EKS for the nodegroup
+	// that additional ingress rules are applied to. This is the remote access security
+	// group if remote access is enabled, otherwise the EKS cluster security group.
+	// +optional
+	SecurityGroupID *string `json:"securityGroupID,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the MachinePool and will contain a succinct value suitable
 	// for machine interpretation.
@@ -19,6 +19,8 @@ package v1beta2
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -38,6 +40,25 @@ const (
 	maxNodegroupNameLength = 64
 )
 
+// gravitonInstanceFamily matches the instance families with AWS Graviton processors, which have a "g"
+// following the generation, e.g. m6g, c7gn and t4g, and the first generation a1.
+var gravitonInstanceFamily = regexp.MustCompile(`^(a1|[a-z]+\d+g[a-z]*)$`)
+
+// arm64AMITypes are the AMI types for arm64 instances, all other AMI types except CUSTOM are for x86-64 instances.
+var arm64AMITypes = map[ManagedMachineAMIType]bool{
+	Al2Arm64:          true,
+	Al2023Arm64:       true,
+	BottlerocketArm64: true,
+}
+
+// windowsAMITypes are the AMI types for Windows nodes.
+var windowsAMITypes = map[ManagedMachineAMIType]bool{
+	WindowsCore2019x86_64: true,
+	WindowsFull2019x86_64: true,
+	WindowsCore2022x86_64: true,
+	WindowsFull2022x86_64: true,
+}
+
 // log is for logging in this package.
 var mmpLog = ctrl.Log.WithName("awsmanagedmachinepool-resource")
 
@@ -100,6 +121,73 @@ func (r *AWSManagedMachinePool) validateNodegroupUpdateConfig() field.ErrorList
 	return allErrs
 }
 
+func (r *AWSManagedMachinePool) validateInstanceTypes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	instanceTypesPath := field.NewPath("spec", "instanceTypes")
+	if r.Spec.InstanceType != nil && len(r.Spec.InstanceTypes) > 0 {
+		allErrs = append(allErrs, field.Forbidden(instanceTypesPath, "instanceTypes cannot be specified when instanceType is specified"))
+	}
+	seen := map[string]bool{}
+	for i, instanceType := range r.Spec.InstanceTypes {
+		if seen[instanceType] {
+			allErrs = append(allErrs, field.Duplicate(instanceTypesPath.Index(i), instanceType))
+		}
+		seen[instanceType] = true
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedMachinePool) validateInstanceTypeArchitecture() field.ErrorList {
+	var allErrs field.ErrorList
+
+	amiType := r.Spec.AMIType
+	if amiType == nil || *amiType == Custom {
+		return allErrs
+	}
+	arm64 := arm64AMITypes[*amiType]
+
+	type instanceTypeField struct {
+		path         *field.Path
+		instanceType string
+	}
+	var instanceTypes []instanceTypeField
+	if r.Spec.InstanceType != nil {
+		instanceTypes = append(instanceTypes, instanceTypeField{field.NewPath("spec", "instanceType"), *r.Spec.InstanceType})
+	}
+	for i, instanceType := range r.Spec.InstanceTypes {
+		instanceTypes = append(instanceTypes, instanceTypeField{field.NewPath("spec", "instanceTypes").Index(i), instanceType})
+	}
+	if r.Spec.AWSLaunchTemplate != nil && r.Spec.AWSLaunchTemplate.InstanceType != "" {
+		instanceTypes = append(instanceTypes, instanceTypeField{field.NewPath("spec", "awsLaunchTemplate", "instanceType"), r.Spec.AWSLaunchTemplate.InstanceType})
+	}
+	for _, f := range instanceTypes {
+		family, _, _ := strings.Cut(f.instanceType, ".")
+		if graviton := gravitonInstanceFamily.MatchString(family); graviton != arm64 {
+			allErrs = append(allErrs, field.Invalid(f.path, f.instanceType, fmt.Sprintf("instance type doesn't match the architecture of amiType %s", *amiType)))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedMachinePool) validateTaints() field.ErrorList {
+	var allErrs field.ErrorList
+
+	// EKS identifies the taints of a nodegroup by key and effect.
+	seen := map[string]bool{}
+	for i, taint := range r.Spec.Taints {
+		id := fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+		if seen[id] {
+			allErrs = append(allErrs, field.Duplicate(field.NewPath("spec", "taints").Index(i), taint))
+		}
+		seen[id] = true
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedMachinePool) validateRemoteAccess() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.RemoteAccess == nil {
@@ -120,6 +208,9 @@ func (r *AWSManagedMachinePool) validateRemoteAccess() field.ErrorList {
 
 func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 	var allErrs field.ErrorList
+	if r.Spec.LaunchTemplate != nil {
+		allErrs = append(allErrs, r.validateLaunchTemplateReference()...)
+	}
 	if r.Spec.AWSLaunchTemplate == nil {
 		return allErrs
 	}
@@ -127,6 +218,9 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 	if r.Spec.InstanceType != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "InstanceType"), r.Spec.InstanceType, "InstanceType cannot be specified when LaunchTemplate is specified"))
 	}
+	if len(r.Spec.InstanceTypes) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "instanceTypes"), r.Spec.InstanceTypes, "instanceTypes cannot be specified when LaunchTemplate is specified"))
+	}
 	if r.Spec.DiskSize != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "DiskSize"), r.Spec.DiskSize, "DiskSize cannot be specified when LaunchTemplate is specified"))
 	}
@@ -135,6 +229,69 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
 
+	// EKS selects the AMI of Bottlerocket node groups from their AMI type.
+	if amiType := r.Spec.AMIType; amiType != nil && (*amiType == Bottlerocketx86_64 || *amiType == BottlerocketArm64) {
+		lt := r.Spec.AWSLaunchTemplate
+		if lt.AMI.ID != nil || lt.AMI.EKSOptimizedLookupType != nil || lt.ImageLookupFormat != "" || lt.ImageLookupOrg != "" || lt.ImageLookupBaseOS != "" {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "awsLaunchTemplate", "ami"), lt.AMI, "AMI cannot be specified in the launch template of a Bottlerocket node group"))
+		}
+	}
+
+	// The launch templates of CAPA bootstrap Linux nodes, Windows nodes with a launch template use
+	// the CUSTOM AMI type with a Windows AMI and EKSConfig.
+	if amiType := r.Spec.AMIType; amiType != nil && windowsAMITypes[*amiType] {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "amiType"), *amiType, "Windows AMI types cannot be used with a launch template, use the CUSTOM AMI type instead"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedMachinePool) validateLaunchTemplateReference() field.ErrorList {
+	var allErrs field.ErrorList
+	refPath := field.NewPath("spec", "launchTemplate")
+
+	if r.Spec.AWSLaunchTemplate != nil {
+		allErrs = append(allErrs, field.Forbidden(refPath, "launchTemplate cannot be specified when awsLaunchTemplate is specified"))
+	}
+	if (r.Spec.LaunchTemplate.ID == nil) == (r.Spec.LaunchTemplate.Name == nil) {
+		allErrs = append(allErrs, field.Invalid(refPath, r.Spec.LaunchTemplate, "exactly one of id and name must be set"))
+	}
+	if r.Spec.InstanceType != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "InstanceType"), r.Spec.InstanceType, "InstanceType cannot be specified when LaunchTemplate is specified"))
+	}
+	if len(r.Spec.InstanceTypes) > 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "instanceTypes"), r.Spec.InstanceTypes, "instanceTypes cannot be specified when LaunchTemplate is specified"))
+	}
+	if r.Spec.DiskSize != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "DiskSize"), r.Spec.DiskSize, "DiskSize cannot be specified when LaunchTemplate is specified"))
+	}
+	if r.Spec.RemoteAccess != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "remoteAccess"), r.Spec.RemoteAccess, "remoteAccess cannot be specified when LaunchTemplate is specified"))
+	}
+
+	return allErrs
+}
+
+func (r *AWSManagedMachinePool) validateAdditionalIngressRules() field.ErrorList {
+	var allErrs field.ErrorList
+	rulesPath := field.NewPath("spec", "additionalIngressRules")
+
+	for i, rule := range r.Spec.AdditionalIngressRules {
+		rulePath := rulesPath.Index(i)
+		if len(rule.SourceSecurityGroupRoles) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("sourceSecurityGroupRoles"), "sourceSecurityGroupRoles are not supported for managed machine pools"))
+		}
+		if rule.NatGatewaysIPsSource {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("natGatewaysIPsSource"), "natGatewaysIPsSource is not supported for managed machine pools"))
+		}
+		if len(rule.PrefixListIDs) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("prefixListIds"), "prefixListIds are not supported for managed machine pools"))
+		}
+		if len(rule.CidrBlocks) == 0 && len(rule.IPv6CidrBlocks) == 0 && len(rule.SourceSecurityGroupIDs) == 0 {
+			allErrs = append(allErrs, field.Required(rulePath, "one of cidrBlocks, ipv6CidrBlocks or sourceSecurityGroupIDs must be set"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -156,9 +313,21 @@ func (r *AWSManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 	if errs := r.validateNodegroupUpdateConfig(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateTaints(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.validateInstanceTypes(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.validateInstanceTypeArchitecture(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateAdditionalIngressRules(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
@@ -193,9 +362,21 @@ func (r *AWSManagedMachinePool) ValidateUpdate(old runtime.Object) (admission.Wa
 	if errs := r.validateNodegroupUpdateConfig(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateTaints(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.validateInstanceTypes(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+	if errs := r.validateInstanceTypeArchitecture(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 	if errs := r.validateLaunchTemplate(); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if errs := r.validateAdditionalIngressRules(); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -242,6 +423,7 @@ func (r *AWSManagedMachinePool) validateImmutable(old *AWSManagedMachinePool) fi
 	appendErrorIfSetAndMutated(old.Spec.RoleName, r.Spec.RoleName, "roleName")
 	appendErrorIfMutated(old.Spec.DiskSize, r.Spec.DiskSize, "diskSize")
 	appendErrorIfMutated(old.Spec.AMIType, r.Spec.AMIType, "amiType")
+	appendErrorIfMutated(old.Spec.InstanceTypes, r.Spec.InstanceTypes, "instanceTypes")
 	appendErrorIfMutated(old.Spec.RemoteAccess, r.Spec.RemoteAccess, "remoteAccess")
 	appendErrorIfSetAndMutated(old.Spec.CapacityType, r.Spec.CapacityType, "capacityType")
 	appendErrorIfMutated(old.Spec.AvailabilityZones, r.Spec.AvailabilityZones, "availabilityZones")
@@ -256,6 +438,16 @@ func (r *AWSManagedMachinePool) validateImmutable(old *AWSManagedMachinePool) fi
 	if old.Spec.AWSLaunchTemplate != nil && r.Spec.AWSLaunchTemplate != nil {
 		appendErrorIfMutated(old.Spec.AWSLaunchTemplate.Name, r.Spec.AWSLaunchTemplate.Name, "awsLaunchTemplate.name")
 	}
+	if (old.Spec.LaunchTemplate == nil) != (r.Spec.LaunchTemplate == nil) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "launchTemplate"), r.Spec.LaunchTemplate, "field is immutable"),
+		)
+	}
+	if old.Spec.LaunchTemplate != nil && r.Spec.LaunchTemplate != nil {
+		appendErrorIfMutated(old.Spec.LaunchTemplate.ID, r.Spec.LaunchTemplate.ID, "launchTemplate.id")
+		appendErrorIfMutated(old.Spec.LaunchTemplate.Name, r.Spec.LaunchTemplate.Name, "launchTemplate.name")
+	}
 
 	return allErrs
 }
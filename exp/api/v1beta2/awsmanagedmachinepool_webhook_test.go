@@ -150,6 +150,258 @@ func TestAWSManagedMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "additional ingress rule with cidr block is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AdditionalIngressRules: []infrav1.IngressRule{
+						{
+							Description: "node exporter",
+							Protocol:    infrav1.SecurityGroupProtocolTCP,
+							FromPort:    9100,
+							ToPort:      9100,
+							CidrBlocks:  []string{"10.100.0.0/16"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional ingress rule without source is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AdditionalIngressRules: []infrav1.IngressRule{
+						{
+							Protocol: infrav1.SecurityGroupProtocolTCP,
+							FromPort: 9100,
+							ToPort:   9100,
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "additional ingress rule with source security group roles is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AdditionalIngressRules: []infrav1.IngressRule{
+						{
+							Protocol:                 infrav1.SecurityGroupProtocolTCP,
+							FromPort:                 9100,
+							ToPort:                   9100,
+							SourceSecurityGroupRoles: []infrav1.SecurityGroupRole{infrav1.SecurityGroupNode},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "taints with the same key and different effects are accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					Taints: Taints{
+						{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoSchedule},
+						{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoExecute},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "taints with the same key and effect are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					Taints: Taints{
+						{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoSchedule},
+						{Key: "dedicated", Value: "inference", Effect: TaintEffectNoSchedule},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple instance types are accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					CapacityType:     &newCapacityType,
+					InstanceTypes:    []string{"m5.large", "m5a.large", "m6i.large"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instance types with instance type are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					InstanceType:     ptr.To[string]("m5.large"),
+					InstanceTypes:    []string{"m5a.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate instance types are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					InstanceTypes:    []string{"m5.large", "m5.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance types with launch template reference are rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					InstanceTypes:    []string{"m5.large"},
+					LaunchTemplate:   &LaunchTemplateReference{ID: ptr.To[string]("lt-0123456789")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bottlerocket pool with launch template is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					AMIType:           ptr.To[ManagedMachineAMIType](Bottlerocketx86_64),
+					AWSLaunchTemplate: &AWSLaunchTemplate{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bottlerocket pool with launch template AMI is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AMIType:          ptr.To[ManagedMachineAMIType](BottlerocketArm64),
+					AWSLaunchTemplate: &AWSLaunchTemplate{
+						AMI: infrav1.AMIReference{ID: ptr.To[string]("ami-0123456789")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "windows pool is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AMIType:          ptr.To[ManagedMachineAMIType](WindowsCore2022x86_64),
+					InstanceTypes:    []string{"m5.large"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "windows pool with launch template is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					AMIType:           ptr.To[ManagedMachineAMIType](WindowsFull2019x86_64),
+					AWSLaunchTemplate: &AWSLaunchTemplate{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "graviton instance types with arm64 ami type are accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AMIType:          ptr.To[ManagedMachineAMIType](Al2Arm64),
+					InstanceTypes:    []string{"m6g.large", "c7gn.xlarge", "t4g.medium", "a1.large", "g5g.xlarge"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "x86-64 instance type with arm64 ami type is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AMIType:          ptr.To[ManagedMachineAMIType](Al2023Arm64),
+					InstanceType:     ptr.To[string]("m5.large"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "graviton instance type with x86-64 ami type is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AMIType:          ptr.To[ManagedMachineAMIType](Al2x86_64),
+					InstanceTypes:    []string{"m5.large", "m6g.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "x86-64 gpu instance type with x86-64 gpu ami type is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					AMIType:          ptr.To[ManagedMachineAMIType](Al2x86_64GPU),
+					InstanceType:     ptr.To[string]("g4dn.xlarge"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "launch template instance type is validated against the ami type",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					AMIType:           ptr.To[ManagedMachineAMIType](BottlerocketArm64),
+					AWSLaunchTemplate: &AWSLaunchTemplate{InstanceType: "m7i.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template reference by name is accepted",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					LaunchTemplate:   &LaunchTemplateReference{Name: ptr.To[string]("my-template")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "launch template reference with both id and name is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-3",
+					LaunchTemplate:   &LaunchTemplateReference{ID: ptr.To[string]("lt-0123456789"), Name: ptr.To[string]("my-template")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "launch template reference with awsLaunchTemplate is rejected",
+			pool: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName:  "eks-node-group-3",
+					LaunchTemplate:    &LaunchTemplateReference{ID: ptr.To[string]("lt-0123456789")},
+					AWSLaunchTemplate: &AWSLaunchTemplate{},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -688,6 +940,69 @@ func TestAWSManagedMachinePoolValidateUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "changing instance types is rejected",
+			old: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					InstanceTypes:    []string{"m5.large", "m5a.large"},
+				},
+			},
+			new: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					InstanceTypes:    []string{"m5.large"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "changing launch template reference version is accepted",
+			old: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					LaunchTemplate:   &LaunchTemplateReference{Name: ptr.To[string]("my-template"), Version: ptr.To[string]("1")},
+				},
+			},
+			new: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					LaunchTemplate:   &LaunchTemplateReference{Name: ptr.To[string]("my-template"), Version: ptr.To[string]("2")},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "changing launch template reference name is rejected",
+			old: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					LaunchTemplate:   &LaunchTemplateReference{Name: ptr.To[string]("my-template")},
+				},
+			},
+			new: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					LaunchTemplate:   &LaunchTemplateReference{Name: ptr.To[string]("other-template")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adding launch template reference is rejected",
+			old: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+				},
+			},
+			new: &AWSManagedMachinePool{
+				Spec: AWSManagedMachinePoolSpec{
+					EKSNodegroupName: "eks-node-group-1",
+					LaunchTemplate:   &LaunchTemplateReference{ID: ptr.To[string]("lt-0123456789")},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -69,6 +69,30 @@ const (
 	// WaitingForEKSControlPlaneReason used when the machine pool is waiting for
 	// EKS control plane infrastructure to be ready before proceeding.
 	WaitingForEKSControlPlaneReason = "WaitingForEKSControlPlane"
+	// EKSNodegroupVersionSkewReason used when the requested nodegroup version isn't within one minor version
+	// of the EKS control plane version.
+	EKSNodegroupVersionSkewReason = "EKSNodegroupVersionSkew"
+
+	// EKSNodegroupVCPUQuotaCondition reports on whether the vCPUs requested by the nodegroup fit
+	// into the EC2 vCPU service quota of the account.
+	EKSNodegroupVCPUQuotaCondition clusterv1.ConditionType = "EKSNodegroupVCPUQuota"
+	// VCPUQuotaExceededReason used when creating or scaling up the nodegroup would exceed the EC2 vCPU service quota.
+	VCPUQuotaExceededReason = "VCPUQuotaExceeded"
+
+	// EKSNodegroupUpgradedCondition reports on whether the nodegroup has been upgraded to the control plane
+	// version, if the upgrades of the nodegroups are orchestrated by the AWSManagedControlPlane.
+	EKSNodegroupUpgradedCondition clusterv1.ConditionType = "EKSNodegroupUpgraded"
+	// WaitingForNodegroupUpgradeReason used when the nodegroup waits for the control plane or the nodegroups
+	// preceding it in the upgrade order to be upgraded.
+	WaitingForNodegroupUpgradeReason = "WaitingForNodegroupUpgrade"
+	// EKSNodegroupUpgradingReason used when the nodegroup is being upgraded.
+	EKSNodegroupUpgradingReason = "EKSNodegroupUpgrading"
+
+	// EKSNodegroupConfigUpdatedCondition reports on whether the labels, taints, scaling and update config of
+	// the nodegroup have been updated to match the spec.
+	EKSNodegroupConfigUpdatedCondition clusterv1.ConditionType = "EKSNodegroupConfigUpdated"
+	// EKSNodegroupConfigUpdateFailedReason used when EKS rejected the update of the nodegroup config.
+	EKSNodegroupConfigUpdateFailedReason = "EKSNodegroupConfigUpdateFailed"
 )
 
 const (
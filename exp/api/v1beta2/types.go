@@ -146,6 +146,13 @@ type AWSLaunchTemplate struct {
 	// If marketType is not specified and spotMarketOptions is provided, the marketType defaults to "Spot".
 	// +optional
 	MarketType infrav1.MarketType `json:"marketType,omitempty"`
+
+	// NetworkInterfaceType is the interface type of the primary network interface of the instances.
+	// Setting it to efa attaches an Elastic Fabric Adapter, which requires an instance type supporting it.
+	// If not specified, AWS applies a default value.
+	// +kubebuilder:validation:Enum=interface;efa
+	// +optional
+	NetworkInterfaceType infrav1.NetworkInterfaceType `json:"networkInterfaceType,omitempty"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
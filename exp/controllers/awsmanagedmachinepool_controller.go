@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -49,6 +50,9 @@ import (
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
 
+// nodegroupUpgradeRequeueAfter is how long to wait before checking again on an orchestrated nodegroup upgrade.
+const nodegroupUpgradeRequeueAfter = time.Minute
+
 // AWSManagedMachinePoolReconciler reconciles a AWSManagedMachinePool object.
 type AWSManagedMachinePoolReconciler struct {
 	client.Client
@@ -58,6 +62,7 @@ type AWSManagedMachinePoolReconciler struct {
 	AllowAdditionalRoles         bool
 	WatchFilterValue             string
 	TagUnmanagedNetworkResources bool
+	CheckVCPUQuota               bool
 }
 
 // SetupWithManager is used to setup the controller.
@@ -136,6 +141,9 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		return reconcile.Result{}, nil
 	}
 
+	// The control plane scope is only used to read shared cluster state. It must never be closed or
+	// patched from here, so that node groups of the same cluster can be reconciled concurrently
+	// without conflicting writes to the AWSManagedControlPlane.
 	managedControlPlaneScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
 		Client:                       r.Client,
 		Logger:                       log,
@@ -163,6 +171,7 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		ManagedMachinePool:   awsPool,
 		EnableIAM:            r.EnableIAM,
 		AllowAdditionalRoles: r.AllowAdditionalRoles,
+		CheckVCPUQuota:       r.CheckVCPUQuota,
 		Endpoints:            r.Endpoints,
 		InfraCluster:         managedControlPlaneScope,
 	})
@@ -175,6 +184,7 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 			expinfrav1.EKSNodegroupReadyCondition,
 			expinfrav1.IAMNodegroupRolesReadyCondition,
 			expinfrav1.LaunchTemplateReadyCondition,
+			expinfrav1.EKSNodegroupVCPUQuotaCondition,
 		}
 
 		conditions.SetSummary(machinePoolScope.ManagedMachinePool, conditions.WithConditions(applicableConditions...), conditions.WithStepCounter())
@@ -188,7 +198,15 @@ func (r *AWSManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, r.reconcileDelete(ctx, machinePoolScope, managedControlPlaneScope)
 	}
 
-	return ctrl.Result{}, r.reconcileNormal(ctx, machinePoolScope, managedControlPlaneScope)
+	if err := r.reconcileNormal(ctx, machinePoolScope, managedControlPlaneScope); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Check on orchestrated upgrades regularly, as they progress with the upgrades of other machine pools.
+	if conditions.IsFalse(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupUpgradedCondition) {
+		return ctrl.Result{RequeueAfter: nodegroupUpgradeRequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
 }
 
 func (r *AWSManagedMachinePoolReconciler) reconcileNormal(
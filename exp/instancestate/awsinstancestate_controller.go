@@ -38,9 +38,13 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
@@ -56,10 +60,13 @@ type AwsInstanceStateReconciler struct {
 	queueURLs         sync.Map
 	Endpoints         []scope.ServiceEndpoint
 	WatchFilterValue  string
+	// PollInterval is the interval at which the cluster queues are checked for messages. Defaults to one second.
+	PollInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=awsmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;patch
 
 func (r *AwsInstanceStateReconciler) getSQSService(region string) (sqsiface.SQSAPI, error) {
 	if r.sqsServiceFactory != nil {
@@ -134,7 +141,11 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 			}
 		}
 	}
-	for range time.Tick(1 * time.Second) {
+	pollInterval := r.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	for range time.Tick(pollInterval) {
 		// go through each cluster and check for messages on its queue
 		r.queueURLs.Range(func(key, val interface{}) bool {
 			go func() {
@@ -178,7 +189,16 @@ func (r *AwsInstanceStateReconciler) watchQueuesForInstanceEvents() {
 
 // processMessage triggers a reconcile on an AWSMachine if its EC2 instance state changed.
 func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg message) {
-	if msg.Source != "aws.ec2" || msg.DetailType != instancestate.Ec2StateChangeNotification || msg.MessageDetail == nil {
+	if msg.Source != "aws.ec2" || msg.MessageDetail == nil {
+		return
+	}
+
+	if msg.DetailType == instancestate.Ec2SpotInstanceInterruptionWarning && feature.Gates.Enabled(feature.SpotInterruptionHandling) {
+		r.processSpotInterruption(ctx, msg)
+		return
+	}
+
+	if msg.DetailType != instancestate.Ec2StateChangeNotification {
 		return
 	}
 
@@ -215,6 +235,49 @@ func (r *AwsInstanceStateReconciler) processMessage(ctx context.Context, msg mes
 	}
 }
 
+// processSpotInterruption marks the Machine owning the interrupted spot instance for remediation, so that
+// a MachineHealthCheck drains and replaces it before the instance is reclaimed.
+func (r *AwsInstanceStateReconciler) processSpotInterruption(ctx context.Context, msg message) {
+	awsMachines := &infrav1.AWSMachineList{}
+	if err := r.List(ctx, awsMachines, client.MatchingFields{controllers.InstanceIDIndex: msg.MessageDetail.InstanceID}); err != nil {
+		r.Log.Error(err, "unable to list machines by instance ID", "instanceID", msg.MessageDetail.InstanceID)
+		return
+	}
+	if len(awsMachines.Items) == 0 {
+		return
+	}
+
+	awsMachine := awsMachines.Items[0]
+	if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
+		return
+	}
+
+	machine, err := util.GetOwnerMachine(ctx, r.Client, awsMachine.ObjectMeta)
+	if err != nil {
+		r.Log.Error(err, "unable to get owner machine", "awsMachine", klog.KObj(&awsMachine))
+		return
+	}
+	if machine == nil || !machine.DeletionTimestamp.IsZero() {
+		return
+	}
+	if _, ok := machine.Annotations[clusterv1.RemediateMachineAnnotation]; ok {
+		return
+	}
+
+	r.Log.Info("spot instance interruption warning received, marking machine for remediation",
+		"machine", klog.KObj(machine), "instanceID", msg.MessageDetail.InstanceID, "instanceAction", msg.MessageDetail.InstanceAction)
+
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		r.Log.Error(err, "unable to create patch helper")
+		return
+	}
+	annotations.AddAnnotations(machine, map[string]string{clusterv1.RemediateMachineAnnotation: ""})
+	if err := patchHelper.Patch(ctx, machine); err != nil {
+		r.Log.Error(err, "unable to patch machine", "machine", klog.KObj(machine))
+	}
+}
+
 // getQueueURL retrieves the SQS queue URL for a given cluster.
 func (r *AwsInstanceStateReconciler) getQueueURL(cluster *infrav1.AWSCluster) (string, error) {
 	sqsSvs, err := r.getSQSService(cluster.Spec.Region)
@@ -252,6 +315,7 @@ type message struct {
 }
 
 type messageDetail struct {
-	InstanceID string                `json:"instance-id,omitempty"`
-	State      infrav1.InstanceState `json:"state,omitempty"`
+	InstanceID     string                `json:"instance-id,omitempty"`
+	State          infrav1.InstanceState `json:"state,omitempty"`
+	InstanceAction string                `json:"instance-action,omitempty"`
 }
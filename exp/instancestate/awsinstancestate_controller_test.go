@@ -18,7 +18,9 @@ package instancestate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,15 +30,20 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/controllers"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestAWSInstanceStateController(t *testing.T) {
@@ -170,6 +177,91 @@ func TestAWSInstanceStateController(t *testing.T) {
 	})
 }
 
+func TestProcessSpotInterruption(t *testing.T) {
+	utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.SpotInterruptionHandling, true)
+
+	machineWithAWSMachine := func(name, instanceID string) (*clusterv1.Machine, *infrav1.AWSMachine) {
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       clusterv1.MachineSpec{ClusterName: "test-cluster"},
+		}
+		awsMachine := &infrav1.AWSMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Machine",
+					Name:       name,
+				}},
+			},
+			Spec: infrav1.AWSMachineSpec{
+				InstanceID:   ptr.To[string](instanceID),
+				InstanceType: "test",
+			},
+		}
+		return machine, awsMachine
+	}
+
+	testCases := []struct {
+		name             string
+		body             string
+		expectRemediated bool
+	}{
+		{
+			name:             "marks the owning machine for remediation on a spot interruption warning",
+			body:             spotInterruptionMessageBodyJSON,
+			expectRemediated: true,
+		},
+		{
+			name:             "ignores spot interruption warnings for unknown instances",
+			body:             strings.Replace(spotInterruptionMessageBodyJSON, "i-spot-instance-1", "i-unknown", 1),
+			expectRemediated: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			machine, awsMachine := machineWithAWSMachine("spot-machine", "i-spot-instance-1")
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machine, awsMachine).
+				WithIndex(&infrav1.AWSMachine{}, controllers.InstanceIDIndex, func(o client.Object) []string {
+					m := o.(*infrav1.AWSMachine)
+					if m.Spec.InstanceID != nil {
+						return []string{*m.Spec.InstanceID}
+					}
+					return nil
+				}).Build()
+			reconciler := &AwsInstanceStateReconciler{
+				Client: fakeClient,
+				Log:    ctrl.Log.WithName("controllers").WithName("AWSInstanceState"),
+			}
+
+			m := message{}
+			g.Expect(json.Unmarshal([]byte(tc.body), &m)).To(Succeed())
+			reconciler.processMessage(context.TODO(), m)
+
+			updated := &clusterv1.Machine{}
+			g.Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(machine), updated)).To(Succeed())
+			_, remediated := updated.Annotations[clusterv1.RemediateMachineAnnotation]
+			g.Expect(remediated).To(Equal(tc.expectRemediated))
+		})
+	}
+}
+
+const spotInterruptionMessageBodyJSON = `{
+	"source": "aws.ec2",
+	"detail-type": "EC2 Spot Instance Interruption Warning",
+	"detail": {
+		"instance-id": "i-spot-instance-1",
+		"instance-action": "terminate"
+	}
+}`
+
 const messageBodyJSON = `{
 	"source": "aws.ec2",
 	"detail-type": "EC2 Instance State-change Notification",
@@ -92,6 +92,28 @@ const (
 	// owner: @enxebre
 	// alpha: v2.2
 	ROSA featuregate.Feature = "ROSA"
+
+	// InstanceAdoption is used to enable the adoption of pre-existing EC2 instances referenced by AWSMachine.Spec.InstanceID
+	// owner: @pavansokkenagaraj
+	// alpha: v2.8
+	InstanceAdoption featuregate.Feature = "InstanceAdoption"
+
+	// SpotInterruptionHandling will use Event Bridge to watch for EC2 Spot Instance interruption warnings and mark the
+	// affected Machines for remediation so they are drained and replaced. Requires EventBridgeInstanceState.
+	// owner: @pavansokkenagaraj
+	// alpha: v2.8
+	SpotInterruptionHandling featuregate.Feature = "SpotInterruptionHandling"
+
+	// ClusterResourceInventory is used to write the IDs of the AWS resources managed for an AWSCluster to a ConfigMap.
+	// owner: @pavansokkenagaraj
+	// alpha: v2.8
+	ClusterResourceInventory featuregate.Feature = "ClusterResourceInventory"
+
+	// NodegroupVCPUQuotaCheck is used to check the vCPUs requested by an EKS managed node group against the EC2 vCPU
+	// service quotas of the account before creating or scaling up the node group.
+	// owner: @pavansokkenagaraj
+	// alpha: v2.8
+	NodegroupVCPUQuotaCheck featuregate.Feature = "NodegroupVCPUQuotaCheck"
 )
 
 func init() {
@@ -115,4 +137,8 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AlternativeGCStrategy:         {Default: false, PreRelease: featuregate.Alpha},
 	TagUnmanagedNetworkResources:  {Default: true, PreRelease: featuregate.Alpha},
 	ROSA:                          {Default: false, PreRelease: featuregate.Alpha},
+	InstanceAdoption:              {Default: false, PreRelease: featuregate.Alpha},
+	SpotInterruptionHandling:      {Default: false, PreRelease: featuregate.Alpha},
+	ClusterResourceInventory:      {Default: false, PreRelease: featuregate.Alpha},
+	NodegroupVCPUQuotaCheck:       {Default: false, PreRelease: featuregate.Alpha},
 }
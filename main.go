@@ -62,6 +62,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/exp/instancestate"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/endpoints"
+	awslogs "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/logs"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
@@ -93,24 +94,29 @@ func init() {
 }
 
 var (
-	enableLeaderElection        bool
-	leaderElectionLeaseDuration time.Duration
-	leaderElectionRenewDeadline time.Duration
-	leaderElectionRetryPeriod   time.Duration
-	leaderElectionNamespace     string
-	watchNamespace              string
-	watchFilterValue            string
-	profilerAddress             string
-	awsClusterConcurrency       int
-	instanceStateConcurrency    int
-	awsMachineConcurrency       int
-	waitInfraPeriod             time.Duration
-	syncPeriod                  time.Duration
-	webhookPort                 int
-	webhookCertDir              string
-	healthAddr                  string
-	serviceEndpoints            string
-	disabledControllers         []string
+	enableLeaderElection             bool
+	leaderElectionLeaseDuration      time.Duration
+	leaderElectionRenewDeadline      time.Duration
+	leaderElectionRetryPeriod        time.Duration
+	leaderElectionNamespace          string
+	watchNamespace                   string
+	watchFilterValue                 string
+	profilerAddress                  string
+	awsClusterConcurrency            int
+	instanceStateConcurrency         int
+	instanceStatePollInterval        time.Duration
+	awsMachineConcurrency            int
+	awsManagedMachinePoolConcurrency int
+	waitInfraPeriod                  time.Duration
+	syncPeriod                       time.Duration
+	webhookPort                      int
+	webhookCertDir                   string
+	healthAddr                       string
+	serviceEndpoints                 string
+	disabledControllers              []string
+	awsSDKDebugLogRegions            []string
+	defaultInstanceType              string
+	defaultRootVolumeSize            int64
 
 	// maxEKSSyncPeriod is the maximum allowed duration for the sync-period flag when using EKS. It is set to 10 minutes
 	// because during resync it will create a new AWS auth token which can a maximum life of 15 minutes and this ensures
@@ -118,6 +124,7 @@ var (
 	maxEKSSyncPeriod         = time.Minute * 10
 	errMaxSyncPeriodExceeded = errors.New("sync period greater than maximum allowed")
 	errEKSInvalidFlags       = errors.New("invalid EKS flag combination")
+	errInvalidFeatureGates   = errors.New("invalid feature gate combination")
 
 	logOptions     = logs.NewOptions()
 	managerOptions = flags.ManagerOptions{}
@@ -144,6 +151,16 @@ func main() {
 	}
 	ctrl.SetLogger(klog.Background())
 
+	if err := machineDefaults().Validate(); err != nil {
+		setupLog.Error(err, "unable to validate AWSMachine defaults")
+		os.Exit(1)
+	}
+
+	if len(awsSDKDebugLogRegions) > 0 {
+		setupLog.Info("Enabling AWS SDK request logging", "regions", awsSDKDebugLogRegions)
+		awslogs.SetDebugRegions(awsSDKDebugLogRegions)
+	}
+
 	_, metricsOptions, err := flags.GetManagerOptions(managerOptions)
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager: invalid flags")
@@ -356,6 +373,11 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		}
 	}
 
+	if feature.Gates.Enabled(feature.SpotInterruptionHandling) && !feature.Gates.Enabled(feature.EventBridgeInstanceState) {
+		setupLog.Error(errInvalidFeatureGates, "cannot use SpotInterruptionHandling without EventBridgeInstanceState")
+		os.Exit(1)
+	}
+
 	if feature.Gates.Enabled(feature.EventBridgeInstanceState) {
 		setupLog.Info("EventBridge notifications enabled. enabling AWSInstanceStateController")
 		if err := (&instancestate.AwsInstanceStateReconciler{
@@ -363,6 +385,7 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 			Log:              ctrl.Log.WithName("controllers").WithName("AWSInstanceStateController"),
 			Endpoints:        awsServiceEndpoints,
 			WatchFilterValue: watchFilterValue,
+			PollInterval:     instanceStatePollInterval,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSInstanceStateController")
 			os.Exit(1)
@@ -382,7 +405,7 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		}
 	}
 
-	if err := (&infrav1.AWSMachineTemplateWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&infrav1.AWSMachineTemplateWebhook{MachineDefaults: machineDefaults()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachineTemplate")
 		os.Exit(1)
 	}
@@ -406,7 +429,7 @@ func setupReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsServi
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSClusterStaticIdentity")
 		os.Exit(1)
 	}
-	if err := (&infrav1.AWSMachine{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&infrav1.AWSMachineWebhook{MachineDefaults: machineDefaults()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "AWSMachine")
 		os.Exit(1)
 	}
@@ -494,7 +517,8 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 			Recorder:                     mgr.GetEventRecorderFor("awsmanagedmachinepool-reconciler"),
 			WatchFilterValue:             watchFilterValue,
 			TagUnmanagedNetworkResources: feature.Gates.Enabled(feature.TagUnmanagedNetworkResources),
-		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: instanceStateConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+			CheckVCPUQuota:               feature.Gates.Enabled(feature.NodegroupVCPUQuotaCheck),
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsManagedMachinePoolConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "AWSManagedMachinePool")
 			os.Exit(1)
 		}
@@ -511,6 +535,14 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	}
 }
 
+// machineDefaults returns the defaults of AWSMachines configured with the flags.
+func machineDefaults() infrav1.MachineDefaults {
+	return infrav1.MachineDefaults{
+		InstanceType:   defaultInstanceType,
+		RootVolumeSize: defaultRootVolumeSize,
+	}
+}
+
 func initFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(
 		&enableLeaderElection,
@@ -573,12 +605,24 @@ func initFlags(fs *pflag.FlagSet) {
 		"Number of concurrent watches for instance state changes",
 	)
 
+	fs.DurationVar(&instanceStatePollInterval,
+		"instance-state-poll-interval",
+		time.Second,
+		"The interval at which cluster queues are polled for instance state change notifications when EventBridgeInstanceState is enabled",
+	)
+
 	fs.IntVar(&awsMachineConcurrency,
 		"awsmachine-concurrency",
 		10,
 		"Number of AWSMachines to process simultaneously",
 	)
 
+	fs.IntVar(&awsManagedMachinePoolConcurrency,
+		"awsmanagedmachinepool-concurrency",
+		5,
+		"Number of AWSManagedMachinePools to process simultaneously",
+	)
+
 	fs.DurationVar(&waitInfraPeriod,
 		"wait-infra-period",
 		1*time.Minute,
@@ -591,6 +635,18 @@ func initFlags(fs *pflag.FlagSet) {
 		fmt.Sprintf("The minimum interval at which watched resources are reconciled. If EKS is enabled the maximum allowed is %s", maxEKSSyncPeriod),
 	)
 
+	fs.StringVar(&defaultInstanceType,
+		"default-instance-type",
+		"",
+		"The instance type of new AWSMachines which don't specify one. If unset, the instance type is required.",
+	)
+
+	fs.Int64Var(&defaultRootVolumeSize,
+		"default-root-volume-size",
+		0,
+		"The size (in Gi) of the root volume of new AWSMachines which don't specify one. If unset, the size of the AMI's root volume is used.",
+	)
+
 	fs.IntVar(&webhookPort,
 		"webhook-port",
 		9443,
@@ -626,6 +682,13 @@ func initFlags(fs *pflag.FlagSet) {
 		fmt.Sprintf("Sets of controllers that should be disabled for this instance of the controller manager in a comma-separated list. Options are: %q", strings.Join(controllers.GetValidNames(), ",")),
 	)
 
+	fs.StringSliceVar(
+		&awsSDKDebugLogRegions,
+		"aws-sdk-debug-log-regions",
+		nil,
+		fmt.Sprintf("Comma-separated list of AWS regions for which AWS SDK requests and responses, including HTTP bodies, are logged with credentials and signatures redacted. Logging can also be enabled for a single cluster with the %s=true annotation.", infrav1.AWSSDKDebugLogAnnotation),
+	)
+
 	logs.AddFlags(fs, logs.SkipLoggingConfigurationFlags())
 	v1.AddFlags(logOptions, fs)
 
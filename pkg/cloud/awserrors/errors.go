@@ -19,6 +19,7 @@ package awserrors
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -39,6 +40,7 @@ const (
 	InvalidAccessKeyID                = "InvalidAccessKeyId"
 	InvalidClientTokenID              = "InvalidClientTokenId"
 	InvalidInstanceID                 = "InvalidInstanceID.NotFound"
+	InvalidParameterValue             = "InvalidParameterValue"
 	InvalidSubnet                     = "InvalidSubnet"
 	LaunchTemplateNameNotFound        = "InvalidLaunchTemplateName.NotFoundException"
 	LoadBalancerNotFound              = "LoadBalancerNotFound"
@@ -54,6 +56,7 @@ const (
 	UnrecognizedClientException             = "UnrecognizedClientException"
 	UnauthorizedOperation                   = "UnauthorizedOperation"
 	VPCNotFound                             = "InvalidVpcID.NotFound"
+	VPCPeeringConnectionNotFound            = "InvalidVpcPeeringConnectionID.NotFound"
 	VPCMissingParameter                     = "MissingParameter"
 	ErrCodeRepositoryAlreadyExistsException = "RepositoryAlreadyExistsException"
 	ASGNotFound                             = "AutoScalingGroup.NotFound"
@@ -190,6 +193,16 @@ func IsPermissionsError(err error) bool {
 	return false
 }
 
+// IsInvalidIAMInstanceProfile returns true when EC2 rejects a request because the IAM instance profile
+// cannot be used yet. This is usually transient and caused by IAM propagation delays right after the
+// instance profile has been created.
+func IsInvalidIAMInstanceProfile(err error) bool {
+	if code, ok := Code(err); ok && code == InvalidParameterValue {
+		return strings.Contains(strings.ToLower(Message(err)), "iam instance profile")
+	}
+	return false
+}
+
 // ReasonForError returns the HTTP status for a particular error.
 func ReasonForError(err error) int {
 	if t, ok := err.(*EC2Error); ok {
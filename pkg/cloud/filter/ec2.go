@@ -129,6 +129,30 @@ func (ec2Filters) NATGatewayStates(states ...string) *ec2.Filter {
 	}
 }
 
+// TransitGatewayAttachmentStates returns a filter based on the list of states passed in.
+func (ec2Filters) TransitGatewayAttachmentStates(states ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("state"),
+		Values: aws.StringSlice(states),
+	}
+}
+
+// VPCPeeringConnectionStatusCodes returns a filter based on the list of status codes passed in.
+func (ec2Filters) VPCPeeringConnectionStatusCodes(codes ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("status-code"),
+		Values: aws.StringSlice(codes),
+	}
+}
+
+// RequesterVPC returns a filter based on the id of the VPC requesting a peering connection.
+func (ec2Filters) RequesterVPC(vpcID string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("requester-vpc-info.vpc-id"),
+		Values: aws.StringSlice([]string{vpcID}),
+	}
+}
+
 // InstanceStates returns a filter based on the list of states passed in.
 func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -137,6 +161,22 @@ func (ec2Filters) InstanceStates(states ...string) *ec2.Filter {
 	}
 }
 
+// InstanceIDs returns a filter based on the list of instance IDs passed in.
+func (ec2Filters) InstanceIDs(ids ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("instance-id"),
+		Values: aws.StringSlice(ids),
+	}
+}
+
+// FlowLogResourceID returns a filter based on the ID of the resource a flow log captures the traffic of.
+func (ec2Filters) FlowLogResourceID(id string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("resource-id"),
+		Values: aws.StringSlice([]string{id}),
+	}
+}
+
 // VPCStates returns a filter based on the list of states passed in.
 func (ec2Filters) VPCStates(states ...string) *ec2.Filter {
 	return &ec2.Filter{
@@ -160,6 +200,14 @@ func (ec2Filters) AvailabilityZone(zone string) *ec2.Filter {
 	}
 }
 
+// ZoneIDs returns a filter based on the IDs of availability zones.
+func (ec2Filters) ZoneIDs(zoneIDs ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String("zone-id"),
+		Values: aws.StringSlice(zoneIDs),
+	}
+}
+
 func (ec2Filters) IgnoreLocalZones() *ec2.Filter {
 	return &ec2.Filter{
 		Name:   aws.String("opt-in-status"),
@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	corev1 "k8s.io/api/core/v1"
 
@@ -70,6 +71,9 @@ func GetAssumeRoleCredentials(roleIdentityProvider *AWSRolePrincipalTypeProvider
 		if roleIdentityProvider.Principal.Spec.InlinePolicy != "" {
 			p.Policy = aws.String(roleIdentityProvider.Principal.Spec.InlinePolicy)
 		}
+		for _, policyARN := range roleIdentityProvider.Principal.Spec.PolicyARNs {
+			p.PolicyArns = append(p.PolicyArns, &sts.PolicyDescriptorType{Arn: aws.String(policyARN)})
+		}
 		p.Duration = time.Duration(roleIdentityProvider.Principal.Spec.DurationSeconds) * time.Second
 		// For testing
 		if roleIdentityProvider.stsClient != nil {
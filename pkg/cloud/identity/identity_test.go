@@ -84,6 +84,25 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 		stsClient:      stsMock,
 	}
 
+	roleIdentityWithPolicies := &infrav1.AWSClusterRoleIdentity{
+		Spec: infrav1.AWSClusterRoleIdentitySpec{
+			AWSRoleSpec: infrav1.AWSRoleSpec{
+				RoleArn:         "arn:*:iam::*:role/aws-role/policyroleprovider",
+				SessionName:     "policy-role-provider-session",
+				DurationSeconds: 900,
+				PolicyARNs:      []string{"arn:aws:iam::123456789012:policy/eks-cluster-creation"},
+			},
+		},
+	}
+
+	roleProviderWithPolicies := &AWSRolePrincipalTypeProvider{
+		credentials:    nil,
+		Principal:      roleIdentityWithPolicies,
+		region:         "us-west-2",
+		sourceProvider: staticProvider,
+		stsClient:      stsMock,
+	}
+
 	testCases := []struct {
 		name      string
 		provider  AWSPrincipalTypeProvider
@@ -180,6 +199,34 @@ func TestAWSStaticPrincipalTypeProvider(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		{
+			name:     "Role provider with session policy ARNs passes them when assuming the role",
+			provider: roleProviderWithPolicies,
+			expect: func(m *mock_stsiface.MockSTSAPIMockRecorder) {
+				m.AssumeRoleWithContext(gomock.Any(), &sts.AssumeRoleInput{
+					RoleArn:         aws.String(roleIdentityWithPolicies.Spec.RoleArn),
+					RoleSessionName: aws.String(roleIdentityWithPolicies.Spec.SessionName),
+					DurationSeconds: ptr.To[int64](int64(roleIdentityWithPolicies.Spec.DurationSeconds)),
+					PolicyArns: []*sts.PolicyDescriptorType{
+						{Arn: aws.String("arn:aws:iam::123456789012:policy/eks-cluster-creation")},
+					},
+				}).Return(&sts.AssumeRoleOutput{
+					Credentials: &sts.Credentials{
+						AccessKeyId:     aws.String("assumedAccessKeyId3"),
+						SecretAccessKey: aws.String("assumedSecretAccessKey3"),
+						SessionToken:    aws.String("assumedSessionToken3"),
+						Expiration:      aws.Time(time.Now()),
+					},
+				}, nil)
+			},
+			expectErr: false,
+			value: credentials.Value{
+				AccessKeyID:     "assumedAccessKeyId3",
+				SecretAccessKey: "assumedSecretAccessKey3",
+				SessionToken:    "assumedSessionToken3",
+				ProviderName:    "AssumeRoleProvider",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
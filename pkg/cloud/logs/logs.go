@@ -18,6 +18,10 @@ limitations under the License.
 package logs
 
 import (
+	"regexp"
+	"strings"
+	"sync"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/go-logr/logr"
 )
@@ -40,6 +44,52 @@ func GetAWSLogLevel(logger logr.Logger) aws.LogLevelType {
 	return aws.LogOff
 }
 
+var (
+	debugRegionsMu sync.RWMutex
+	debugRegions   = map[string]bool{}
+)
+
+// SetDebugRegions sets the AWS regions for which AWS SDK request and response
+// logging, including HTTP bodies, is enabled regardless of the logger verbosity.
+func SetDebugRegions(regions []string) {
+	debugRegionsMu.Lock()
+	defer debugRegionsMu.Unlock()
+
+	debugRegions = make(map[string]bool, len(regions))
+	for _, region := range regions {
+		if region = strings.TrimSpace(region); region != "" {
+			debugRegions[region] = true
+		}
+	}
+}
+
+// IsDebugRegion returns true if AWS SDK debug logging has been enabled for the region.
+func IsDebugRegion(region string) bool {
+	debugRegionsMu.RLock()
+	defer debugRegionsMu.RUnlock()
+
+	return debugRegions[region]
+}
+
+// redactions is the list of patterns matching credentials and request signatures
+// that must never be written to the logs.
+var redactions = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(Authorization:\s*)[^\r\n]+`),
+	regexp.MustCompile(`(?i)(X-Amz-Security-Token:\s*)[^\r\n]+`),
+	regexp.MustCompile(`(?i)(X-Amz-(?:Security-Token|Signature|Credential)=)[^&\s]+`),
+	regexp.MustCompile(`(<(?:SecretAccessKey|SessionToken|AccessKeyId)>)[^<]*`),
+	regexp.MustCompile(`("(?:SecretAccessKey|SessionToken|AccessKeyId|SecretString)"\s*:\s*")[^"]*`),
+	regexp.MustCompile(`((?:SecretAccessKey|SessionToken|SecretString|Password)=)[^&\s]+`),
+}
+
+// Redact replaces credentials and request signatures in an AWS SDK log message.
+func Redact(msg string) string {
+	for _, r := range redactions {
+		msg = r.ReplaceAllString(msg, "${1}[REDACTED]")
+	}
+	return msg
+}
+
 // NewWrapLogr will create an AWS Logger wrapper.
 func NewWrapLogr(logger logr.Logger) aws.Logger {
 	return &logrWrapper{
@@ -56,8 +106,8 @@ func (l *logrWrapper) Log(msgs ...interface{}) {
 	case 0:
 		return
 	case 1:
-		l.log.Info(msgs[0].(string))
+		l.log.Info(Redact(msgs[0].(string)))
 	default:
-		l.log.Info(msgs[0].(string), msgs[:1]...)
+		l.log.Info(Redact(msgs[0].(string)), msgs[1:]...)
 	}
 }
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/gomega"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{
+			name:     "request headers",
+			msg:      "POST / HTTP/1.1\r\nAuthorization: AWS4-HMAC-SHA256 Credential=AKIA/20240101/us-east-1/ec2/aws4_request, Signature=abc\r\nX-Amz-Security-Token: token\r\n",
+			expected: "POST / HTTP/1.1\r\nAuthorization: [REDACTED]\r\nX-Amz-Security-Token: [REDACTED]\r\n",
+		},
+		{
+			name:     "presigned query string",
+			msg:      "GET /?X-Amz-Credential=AKIA%2F20240101&X-Amz-Signature=abc&X-Amz-Security-Token=token&Action=GetCallerIdentity",
+			expected: "GET /?X-Amz-Credential=[REDACTED]&X-Amz-Signature=[REDACTED]&X-Amz-Security-Token=[REDACTED]&Action=GetCallerIdentity",
+		},
+		{
+			name:     "STS response body",
+			msg:      "<Credentials><AccessKeyId>ASIA</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken></Credentials>",
+			expected: "<Credentials><AccessKeyId>[REDACTED]</AccessKeyId><SecretAccessKey>[REDACTED]</SecretAccessKey><SessionToken>[REDACTED]</SessionToken></Credentials>",
+		},
+		{
+			name:     "JSON response body",
+			msg:      `{"ARN":"arn","SecretString":"secret"}`,
+			expected: `{"ARN":"arn","SecretString":"[REDACTED]"}`,
+		},
+		{
+			name:     "message without credentials",
+			msg:      "DEBUG: Request ec2/DescribeInstances Details:",
+			expected: "DEBUG: Request ec2/DescribeInstances Details:",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(Redact(tc.msg)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestLogrWrapper(t *testing.T) {
+	g := NewWithT(t)
+
+	var out []string
+	logger := funcr.New(func(prefix, args string) {
+		out = append(out, args)
+	}, funcr.Options{})
+
+	NewWrapLogr(logger).Log("Authorization: AWS4-HMAC-SHA256 Signature=abc", "request-id", "1234")
+
+	g.Expect(out).To(HaveLen(1))
+	g.Expect(out[0]).To(ContainSubstring("Authorization: [REDACTED]"))
+	g.Expect(out[0]).To(ContainSubstring(`"request-id"="1234"`))
+	g.Expect(out[0]).NotTo(ContainSubstring("Signature=abc"))
+}
+
+func TestSetDebugRegions(t *testing.T) {
+	g := NewWithT(t)
+	defer SetDebugRegions(nil)
+
+	SetDebugRegions([]string{"us-east-1", " eu-west-1 ", ""})
+	g.Expect(IsDebugRegion("us-east-1")).To(BeTrue())
+	g.Expect(IsDebugRegion("eu-west-1")).To(BeTrue())
+	g.Expect(IsDebugRegion("us-west-2")).To(BeFalse())
+	g.Expect(IsDebugRegion("")).To(BeFalse())
+
+	SetDebugRegions(nil)
+	g.Expect(IsDebugRegion("us-east-1")).To(BeFalse())
+}
@@ -164,6 +164,11 @@ func (s *ClusterScope) AllSecondaryCidrBlocks() []infrav1.VpcCidrBlock {
 	return s.SecondaryCidrBlocks()
 }
 
+// PodSubnetIDs returns the IDs of the subnets dedicated to the pods, which are only set up for EKS clusters.
+func (s *ClusterScope) PodSubnetIDs() []string {
+	return nil
+}
+
 // Name returns the CAPI cluster name.
 func (s *ClusterScope) Name() string {
 	return s.Cluster.Name
@@ -230,6 +235,16 @@ func (s *ClusterScope) Bucket() *infrav1.S3Bucket {
 	return s.AWSCluster.Spec.S3Bucket
 }
 
+// APIServerPrivateDNSRecord returns the record of the API server in a private hosted zone, if any.
+func (s *ClusterScope) APIServerPrivateDNSRecord() *infrav1.PrivateDNSRecord {
+	return s.AWSCluster.Spec.APIServerPrivateDNSRecord
+}
+
+// APIServerDNSName returns the DNS name of the control plane load balancer.
+func (s *ClusterScope) APIServerDNSName() string {
+	return s.AWSCluster.Status.Network.APIServerELB.DNSName
+}
+
 // ControlPlaneConfigMapName returns the name of the ConfigMap used to
 // coordinate the bootstrapping of control plane nodes.
 func (s *ClusterScope) ControlPlaneConfigMapName() string {
@@ -257,11 +272,25 @@ func (s *ClusterScope) PatchObject() error {
 	if s.VPC().IsManaged(s.Name()) {
 		applicableConditions = append(applicableConditions,
 			infrav1.InternetGatewayReadyCondition,
-			infrav1.NatGatewaysReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.VpcEndpointsReadyCondition,
 		)
 
+		if s.VPC().IsNatInstanceEnabled() {
+			applicableConditions = append(applicableConditions, infrav1.NatInstancesReadyCondition)
+		} else {
+			applicableConditions = append(applicableConditions, infrav1.NatGatewaysReadyCondition)
+		}
+		if s.VPC().FlowLogs != nil {
+			applicableConditions = append(applicableConditions, infrav1.VpcFlowLogsReadyCondition)
+		}
+		if s.VPC().DHCPOptions != nil {
+			applicableConditions = append(applicableConditions, infrav1.DhcpOptionsReadyCondition)
+		}
+		if s.NetworkACLs() != nil {
+			applicableConditions = append(applicableConditions, infrav1.NetworkACLsReadyCondition)
+		}
+
 		if s.AWSCluster.Spec.Bastion.Enabled {
 			applicableConditions = append(applicableConditions, infrav1.BastionHostReadyCondition)
 		}
@@ -286,8 +315,12 @@ func (s *ClusterScope) PatchObject() error {
 			infrav1.InternetGatewayReadyCondition,
 			infrav1.EgressOnlyInternetGatewayReadyCondition,
 			infrav1.NatGatewaysReadyCondition,
+			infrav1.NatInstancesReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.VpcEndpointsReadyCondition,
+			infrav1.VpcFlowLogsReadyCondition,
+			infrav1.DhcpOptionsReadyCondition,
+			infrav1.NetworkACLsReadyCondition,
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.LoadBalancerReadyCondition,
@@ -364,9 +397,52 @@ func (s *ClusterScope) Bastion() *infrav1.Bastion {
 	return &s.AWSCluster.Spec.Bastion
 }
 
+// ExternallyManagedNetwork returns whether the network is owned by another party and only validated.
+func (s *ClusterScope) ExternallyManagedNetwork() bool {
+	return s.AWSCluster.Spec.NetworkSpec.IsExternallyManaged()
+}
+
+// SkipSecurityGroupCreation returns whether the security groups must all be provided as overrides.
+func (s *ClusterScope) SkipSecurityGroupCreation() bool {
+	return s.AWSCluster.Spec.NetworkSpec.SkipSecurityGroupCreation
+}
+
+// DualStack returns whether the VPC carries both IPv4 and IPv6 traffic.
+// IPv6 is always added on top of IPv4 for self-managed clusters.
+func (s *ClusterScope) DualStack() bool {
+	return s.VPC().IsIPv6Enabled()
+}
+
 // TagUnmanagedNetworkResources returns if the feature flag tag unmanaged network resources is set.
+// An externally managed network is never tagged.
 func (s *ClusterScope) TagUnmanagedNetworkResources() bool {
-	return s.tagUnmanagedNetworkResources
+	return s.tagUnmanagedNetworkResources && !s.ExternallyManagedNetwork()
+}
+
+// CreateVPCEndpoints returns whether the VPC endpoints for private clusters are created.
+// For AWSCluster this is always false, the S3 gateway endpoint of the bucket is created on its own.
+func (s *ClusterScope) CreateVPCEndpoints() bool {
+	return false
+}
+
+// VPCEndpoints returns the VPC endpoints of AWS services to create in a managed VPC.
+func (s *ClusterScope) VPCEndpoints() infrav1.VPCEndpoints {
+	return s.AWSCluster.Spec.NetworkSpec.VPCEndpoints
+}
+
+// TransitGatewayAttachment returns the attachment of the VPC to a transit gateway.
+func (s *ClusterScope) TransitGatewayAttachment() *infrav1.TransitGatewayAttachmentSpec {
+	return s.AWSCluster.Spec.NetworkSpec.TransitGatewayAttachment
+}
+
+// VPCPeeringConnections returns the peering connections of the VPC to other VPCs.
+func (s *ClusterScope) VPCPeeringConnections() infrav1.VPCPeeringConnections {
+	return s.AWSCluster.Spec.NetworkSpec.VPCPeeringConnections
+}
+
+// NetworkACLs returns the custom network ACLs of the subnets.
+func (s *ClusterScope) NetworkACLs() *infrav1.NetworkACLsSpec {
+	return s.AWSCluster.Spec.NetworkSpec.NetworkACLs
 }
 
 // SetBastionInstance sets the bastion instance in the status of the cluster.
@@ -400,6 +476,14 @@ func (s *ClusterScope) ImageLookupBaseOS() string {
 	return s.AWSCluster.Spec.ImageLookupBaseOS
 }
 
+// ControlPlaneAZSpread returns how control plane instances are spread across availability zones.
+func (s *ClusterScope) ControlPlaneAZSpread() infrav1.ControlPlaneAZSpreadPolicy {
+	if s.AWSCluster.Spec.ControlPlaneAZSpread == "" {
+		return infrav1.ControlPlaneAZSpreadPolicyNone
+	}
+	return s.AWSCluster.Spec.ControlPlaneAZSpread
+}
+
 // Partition returns the cluster partition.
 func (s *ClusterScope) Partition() string {
 	if s.AWSCluster.Spec.Partition == "" {
@@ -423,3 +507,8 @@ func (s *ClusterScope) UnstructuredControlPlane() (*unstructured.Unstructured, e
 func (s *ClusterScope) NodePortIngressRuleCidrBlocks() []string {
 	return s.AWSCluster.Spec.NetworkSpec.DeepCopy().NodePortIngressRuleCidrBlocks
 }
+
+// AllowNodeEFATraffic returns whether the node security group allows the traffic of the Elastic Fabric Adapter.
+func (s *ClusterScope) AllowNodeEFATraffic() bool {
+	return s.AWSCluster.Spec.NetworkSpec.AllowNodeEFATraffic
+}
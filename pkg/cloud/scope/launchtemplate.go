@@ -40,6 +40,7 @@ type LaunchTemplateScope interface {
 	GetRawBootstrapData() ([]byte, *types.NamespacedName, error)
 
 	IsEKSManaged() bool
+	UsesEKSSelectedAMI() bool
 	AdditionalTags() infrav1.Tags
 
 	GetObjectMeta() *metav1.ObjectMeta
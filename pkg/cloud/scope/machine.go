@@ -130,9 +130,9 @@ func (m *MachineScope) IsMachinePoolMachine() bool {
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
-		return "control-plane"
+		return infrav1.ControlPlaneRoleTagValue
 	}
-	return "node"
+	return infrav1.NodeRoleTagValue
 }
 
 // GetInstanceID returns the AWSMachine instance id by parsing Spec.ProviderID.
@@ -325,6 +325,7 @@ func (m *MachineScope) PatchObject() error {
 			infrav1.InstanceReadyCondition,
 			infrav1.SecurityGroupsReadyCondition,
 			infrav1.ELBAttachedCondition,
+			infrav1.ControlPlaneAZSpreadCondition,
 		}})
 }
 
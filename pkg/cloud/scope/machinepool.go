@@ -276,6 +276,11 @@ func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
 }
 
+// UsesEKSSelectedAMI returns false as the AMI of an AWSMachinePool is always set in its launch template.
+func (m *MachinePoolScope) UsesEKSSelectedAMI() bool {
+	return false
+}
+
 // SubnetIDs returns the machine pool subnet IDs.
 func (m *MachinePoolScope) SubnetIDs(subnetIDs []string) ([]string, error) {
 	strategy, err := newDefaultSubnetPlacementStrategy(&m.Logger)
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	amazoncni "github.com/aws/amazon-vpc-cni-k8s/pkg/apis/crd/v1alpha1"
@@ -230,6 +231,11 @@ func (s *ManagedControlPlaneScope) AllSecondaryCidrBlocks() []infrav1.VpcCidrBlo
 	return secondaryCidrBlocks
 }
 
+// PodSubnetIDs returns the IDs of the subnets dedicated to the pods with the custom networking of the VPC CNI.
+func (s *ManagedControlPlaneScope) PodSubnetIDs() []string {
+	return s.ControlPlane.Spec.VpcCni.PodSubnetIDs
+}
+
 // SecurityGroupOverrides returns the security groups that are overrides in the ControlPlane spec.
 func (s *ManagedControlPlaneScope) SecurityGroupOverrides() map[infrav1.SecurityGroupRole]string {
 	return s.ControlPlane.Spec.NetworkSpec.SecurityGroupOverrides
@@ -273,14 +279,19 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1.ClusterSecurityGroupsReadyCondition,
 			infrav1.InternetGatewayReadyCondition,
 			infrav1.NatGatewaysReadyCondition,
+			infrav1.NatInstancesReadyCondition,
 			infrav1.RouteTablesReadyCondition,
 			infrav1.VpcEndpointsReadyCondition,
+			infrav1.VpcFlowLogsReadyCondition,
+			infrav1.DhcpOptionsReadyCondition,
+			infrav1.NetworkACLsReadyCondition,
 			infrav1.BastionHostReadyCondition,
 			infrav1.EgressOnlyInternetGatewayReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneCreatingCondition,
 			ekscontrolplanev1.EKSControlPlaneReadyCondition,
 			ekscontrolplanev1.EKSControlPlaneUpdatingCondition,
 			ekscontrolplanev1.IAMControlPlaneRolesReadyCondition,
+			ekscontrolplanev1.EKSNodegroupsUpgradedCondition,
 		}})
 }
 
@@ -331,15 +342,73 @@ func (s *ManagedControlPlaneScope) Bastion() *infrav1.Bastion {
 	return &s.ControlPlane.Spec.Bastion
 }
 
+// APIServerPrivateDNSRecord returns the record of the API server in a private hosted zone, if any.
+func (s *ManagedControlPlaneScope) APIServerPrivateDNSRecord() *infrav1.PrivateDNSRecord {
+	return s.ControlPlane.Spec.APIServerPrivateDNSRecord
+}
+
+// APIServerDNSName returns the host name of the EKS API server endpoint.
+func (s *ManagedControlPlaneScope) APIServerDNSName() string {
+	// The EKS endpoint is a URL, e.g. https://0123456789ABCDEF.gr7.eu-west-1.eks.amazonaws.com.
+	host := s.ControlPlane.Spec.ControlPlaneEndpoint.Host
+	if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return host
+}
+
 // Bucket returns the bucket details.
 // For ManagedControlPlane this is always nil, as we don't support S3 buckets for managed clusters.
 func (s *ManagedControlPlaneScope) Bucket() *infrav1.S3Bucket {
 	return nil
 }
 
+// ExternallyManagedNetwork returns whether the network is owned by another party and only validated.
+func (s *ManagedControlPlaneScope) ExternallyManagedNetwork() bool {
+	return s.ControlPlane.Spec.NetworkSpec.IsExternallyManaged()
+}
+
+// SkipSecurityGroupCreation returns whether the security groups must all be provided as overrides.
+func (s *ManagedControlPlaneScope) SkipSecurityGroupCreation() bool {
+	return s.ControlPlane.Spec.NetworkSpec.SkipSecurityGroupCreation
+}
+
+// DualStack returns whether the VPC carries both IPv4 and IPv6 traffic.
+// EKS clusters with IPv6 enabled are IPv6 only.
+func (s *ManagedControlPlaneScope) DualStack() bool {
+	return false
+}
+
 // TagUnmanagedNetworkResources returns if the feature flag tag unmanaged network resources is set.
+// An externally managed network is never tagged.
 func (s *ManagedControlPlaneScope) TagUnmanagedNetworkResources() bool {
-	return s.tagUnmanagedNetworkResources
+	return s.tagUnmanagedNetworkResources && !s.ExternallyManagedNetwork()
+}
+
+// CreateVPCEndpoints returns whether the VPC endpoints nodes in private subnets need to join the
+// privately accessible EKS cluster are created.
+func (s *ManagedControlPlaneScope) CreateVPCEndpoints() bool {
+	return s.ControlPlane.Spec.EndpointAccess.CreateVPCEndpoints
+}
+
+// VPCEndpoints returns the VPC endpoints of AWS services to create in a managed VPC.
+func (s *ManagedControlPlaneScope) VPCEndpoints() infrav1.VPCEndpoints {
+	return s.ControlPlane.Spec.NetworkSpec.VPCEndpoints
+}
+
+// TransitGatewayAttachment returns the attachment of the VPC to a transit gateway.
+func (s *ManagedControlPlaneScope) TransitGatewayAttachment() *infrav1.TransitGatewayAttachmentSpec {
+	return s.ControlPlane.Spec.NetworkSpec.TransitGatewayAttachment
+}
+
+// VPCPeeringConnections returns the peering connections of the VPC to other VPCs.
+func (s *ManagedControlPlaneScope) VPCPeeringConnections() infrav1.VPCPeeringConnections {
+	return s.ControlPlane.Spec.NetworkSpec.VPCPeeringConnections
+}
+
+// NetworkACLs returns the custom network ACLs of the subnets.
+func (s *ManagedControlPlaneScope) NetworkACLs() *infrav1.NetworkACLsSpec {
+	return s.ControlPlane.Spec.NetworkSpec.NetworkACLs
 }
 
 // SetBastionInstance sets the bastion instance in the status of the cluster.
@@ -367,6 +436,16 @@ func (s *ManagedControlPlaneScope) TokenMethod() ekscontrolplanev1.EKSTokenMetho
 	return ekscontrolplanev1.EKSTokenMethodIAMAuthenticator
 }
 
+// KubeconfigRefreshInterval returns the minimum interval at which the tokens embedded in the kubeconfig
+// secrets are regenerated, or zero if they are to be regenerated on every reconciliation.
+func (s *ManagedControlPlaneScope) KubeconfigRefreshInterval() time.Duration {
+	if s.ControlPlane.Spec.KubeconfigRefreshInterval != nil {
+		return s.ControlPlane.Spec.KubeconfigRefreshInterval.Duration
+	}
+
+	return 0
+}
+
 // KubernetesClusterName is the name of the Kubernetes cluster. For the managed
 // scope this is the different to the CAPI cluster name and is the EKS cluster name.
 func (s *ManagedControlPlaneScope) KubernetesClusterName() string {
@@ -398,6 +477,12 @@ func (s *ManagedControlPlaneScope) ImageLookupBaseOS() string {
 	return s.ControlPlane.Spec.ImageLookupBaseOS
 }
 
+// ControlPlaneAZSpread returns how control plane instances are spread across availability zones.
+// EKS manages the control plane, so the spread is never enforced.
+func (s *ManagedControlPlaneScope) ControlPlaneAZSpread() infrav1.ControlPlaneAZSpreadPolicy {
+	return infrav1.ControlPlaneAZSpreadPolicyNone
+}
+
 // IAMAuthConfig returns the IAM authenticator config. The returned value will never be nil.
 func (s *ManagedControlPlaneScope) IAMAuthConfig() *ekscontrolplanev1.IAMAuthenticatorConfig {
 	if s.ControlPlane.Spec.IAMAuthenticatorConfig == nil {
@@ -490,3 +575,9 @@ func (s *ManagedControlPlaneScope) UnstructuredControlPlane() (*unstructured.Uns
 func (s *ManagedControlPlaneScope) NodePortIngressRuleCidrBlocks() []string {
 	return nil
 }
+
+// AllowNodeEFATraffic returns whether the additional node security group allows the traffic of the Elastic
+// Fabric Adapter. The cluster security group created by EKS already allows all the traffic between the nodes.
+func (s *ManagedControlPlaneScope) AllowNodeEFATraffic() bool {
+	return s.ControlPlane.Spec.NetworkSpec.AllowNodeEFATraffic
+}
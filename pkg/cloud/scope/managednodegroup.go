@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	awsclient "github.com/aws/aws-sdk-go/aws/client"
 	"github.com/pkg/errors"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/util/system"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
@@ -56,6 +58,7 @@ type ManagedMachinePoolScopeParams struct {
 
 	EnableIAM            bool
 	AllowAdditionalRoles bool
+	CheckVCPUQuota       bool
 
 	InfraCluster EC2Scope
 }
@@ -114,6 +117,7 @@ func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedM
 		controllerName:       params.ControllerName,
 		enableIAM:            params.EnableIAM,
 		allowAdditionalRoles: params.AllowAdditionalRoles,
+		checkVCPUQuota:       params.CheckVCPUQuota,
 	}, nil
 }
 
@@ -136,6 +140,7 @@ type ManagedMachinePoolScope struct {
 
 	enableIAM            bool
 	allowAdditionalRoles bool
+	checkVCPUQuota       bool
 }
 
 // ManagedPoolName returns the managed machine pool name.
@@ -166,6 +171,12 @@ func (s *ManagedMachinePoolScope) AllowAdditionalRoles() bool {
 	return s.allowAdditionalRoles
 }
 
+// CheckVCPUQuota indicates that the vCPUs requested by the nodegroup should be checked against the
+// EC2 vCPU service quotas before creating or scaling up the nodegroup.
+func (s *ManagedMachinePoolScope) CheckVCPUQuota() bool {
+	return s.checkVCPUQuota
+}
+
 // Partition returns the machine pool subnet IDs.
 func (s *ManagedMachinePoolScope) Partition() string {
 	return system.GetPartitionFromRegion(s.ControlPlane.Spec.Region)
@@ -194,9 +205,21 @@ func (s *ManagedMachinePoolScope) RoleName() string {
 	return s.ManagedMachinePool.Spec.RoleName
 }
 
-// Version returns the nodegroup Kubernetes version.
+// Version returns the nodegroup Kubernetes version. The AWSManagedMachinePool version takes
+// precedence over the MachinePool version, and the control plane version is used when neither is set.
+// If the control plane orchestrates the nodegroup upgrades, the control plane version is used instead
+// of the MachinePool version.
 func (s *ManagedMachinePoolScope) Version() *string {
-	return s.MachinePool.Spec.Template.Spec.Version
+	if s.ManagedMachinePool.Spec.Version != nil {
+		return s.ManagedMachinePool.Spec.Version
+	}
+	if s.ControlPlane.Spec.NodegroupUpgrade != nil {
+		return s.ControlPlane.Spec.Version
+	}
+	if s.MachinePool.Spec.Template.Spec.Version != nil {
+		return s.MachinePool.Spec.Template.Spec.Version
+	}
+	return s.ControlPlane.Spec.Version
 }
 
 // ControlPlaneSubnets returns the control plane subnets.
@@ -270,6 +293,8 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			expinfrav1.EKSNodegroupReadyCondition,
 			expinfrav1.IAMNodegroupRolesReadyCondition,
+			expinfrav1.EKSNodegroupUpgradedCondition,
+			expinfrav1.EKSNodegroupConfigUpdatedCondition,
 		}})
 }
 
@@ -317,6 +342,16 @@ func (s *ManagedMachinePoolScope) NodegroupName() string {
 	return s.ManagedMachinePool.Spec.EKSNodegroupName
 }
 
+// ReplicasExternallyManaged returns whether the desired size of the nodegroup is managed by an external
+// autoscaler, as indicated by an annotation on either the MachinePool or the AWSManagedMachinePool.
+func (s *ManagedMachinePoolScope) ReplicasExternallyManaged() bool {
+	if annotations.ReplicasManagedByExternalAutoscaler(s.MachinePool) {
+		return true
+	}
+	externalScaling, err := strconv.ParseBool(s.ManagedMachinePool.GetAnnotations()[expinfrav1.ExternalScalingAnnotation])
+	return err == nil && externalScaling
+}
+
 // Name returns the name of the AWSManagedMachinePool.
 func (s *ManagedMachinePoolScope) Name() string {
 	return s.ManagedMachinePool.Name
@@ -368,6 +403,13 @@ func (s *ManagedMachinePoolScope) IsEKSManaged() bool {
 	return true
 }
 
+// UsesEKSSelectedAMI returns true if EKS selects the AMI of the nodegroup from its AMI type, so that
+// the launch template must not specify one. This is the case for the Bottlerocket AMI types.
+func (s *ManagedMachinePoolScope) UsesEKSSelectedAMI() bool {
+	amiType := s.ManagedMachinePool.Spec.AMIType
+	return amiType != nil && (*amiType == expinfrav1.Bottlerocketx86_64 || *amiType == expinfrav1.BottlerocketArm64)
+}
+
 // GetLaunchTemplateIDStatus returns the launch template ID status.
 func (s *ManagedMachinePoolScope) GetLaunchTemplateIDStatus() string {
 	if s.ManagedMachinePool.Status.LaunchTemplateID != nil {
@@ -29,6 +29,8 @@ type NetworkScope interface {
 	Network() *infrav1.NetworkStatus
 	// VPC returns the cluster VPC.
 	VPC() *infrav1.VPCSpec
+	// ExternallyManagedNetwork returns whether the network is owned by another party and only validated.
+	ExternallyManagedNetwork() bool
 	// Subnets returns the cluster subnets.
 	Subnets() infrav1.Subnets
 	// SetSubnets updates the clusters subnets.
@@ -45,6 +47,8 @@ type NetworkScope interface {
 	// AllSecondaryCidrBlocks returns a unique list of all secondary CIDR blocks (combining `SecondaryCidrBlock` and
 	// `SecondaryCidrBlocks`).
 	AllSecondaryCidrBlocks() []infrav1.VpcCidrBlock
+	// PodSubnetIDs returns the IDs of the existing subnets dedicated to the pods, which are tagged as secondary subnets.
+	PodSubnetIDs() []string
 
 	// Bastion returns the bastion details for the cluster.
 	Bastion() *infrav1.Bastion
@@ -55,6 +59,21 @@ type NetworkScope interface {
 	// TagUnmanagedNetworkResources returns is tagging unmanaged network resources is set.
 	TagUnmanagedNetworkResources() bool
 
+	// CreateVPCEndpoints returns whether the VPC endpoints nodes in private subnets need to join the cluster are created.
+	CreateVPCEndpoints() bool
+
+	// VPCEndpoints returns the VPC endpoints of AWS services to create in a managed VPC.
+	VPCEndpoints() infrav1.VPCEndpoints
+
+	// TransitGatewayAttachment returns the attachment of the VPC to a transit gateway.
+	TransitGatewayAttachment() *infrav1.TransitGatewayAttachmentSpec
+
+	// VPCPeeringConnections returns the peering connections of the VPC to other VPCs.
+	VPCPeeringConnections() infrav1.VPCPeeringConnections
+
+	// NetworkACLs returns the custom network ACLs of the subnets.
+	NetworkACLs() *infrav1.NetworkACLsSpec
+
 	// SetNatGatewaysIPs sets the Nat Gateways Public IPs.
 	SetNatGatewaysIPs(ips []string)
 	// GetNatGatewaysIPs gets the Nat Gateways Public IPs.
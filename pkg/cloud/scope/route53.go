@@ -0,0 +1,32 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+)
+
+// Route53Scope is the interface for the scope to be used with the Route53 service.
+type Route53Scope interface {
+	cloud.ClusterScoper
+
+	// APIServerPrivateDNSRecord returns the record of the API server in a private hosted zone, if any.
+	APIServerPrivateDNSRecord() *infrav1.PrivateDNSRecord
+	// APIServerDNSName returns the DNS name of the API server endpoint the record points at.
+	APIServerDNSName() string
+}
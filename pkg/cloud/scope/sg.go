@@ -36,6 +36,14 @@ type SGScope interface {
 
 	// VPC returns the cluster VPC.
 	VPC() *infrav1.VPCSpec
+	// ExternallyManagedNetwork returns whether the network is owned by another party and only validated.
+	ExternallyManagedNetwork() bool
+
+	// SkipSecurityGroupCreation returns whether the security groups must all be provided as overrides.
+	SkipSecurityGroupCreation() bool
+
+	// DualStack returns whether the VPC carries both IPv4 and IPv6 traffic, as opposed to IPv6 only clusters.
+	DualStack() bool
 
 	// CNIIngressRules returns the CNI spec ingress rules.
 	CNIIngressRules() infrav1.CNIIngressRules
@@ -62,4 +70,7 @@ type SGScope interface {
 
 	// NodePortIngressRuleCidrBlocks returns the CIDR blocks for the node NodePort ingress rules.
 	NodePortIngressRuleCidrBlocks() []string
+
+	// AllowNodeEFATraffic returns whether the node security group allows the traffic of the Elastic Fabric Adapter.
+	AllowNodeEFATraffic() bool
 }
@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	ec2service "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ec2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	"sigs.k8s.io/cluster-api/util/annotations"
 )
@@ -162,6 +163,9 @@ func (s *Service) CreateASG(machinePoolScope *scope.MachinePoolScope) (*expinfra
 	if err != nil {
 		return nil, fmt.Errorf("getting subnets for ASG: %w", err)
 	}
+	if err := s.validateOutpostInstanceTypes(machinePoolScope, subnets); err != nil {
+		return nil, err
+	}
 
 	input := &expinfrav1.AutoScalingGroup{
 		Name:                  machinePoolScope.Name(),
@@ -294,6 +298,9 @@ func (s *Service) UpdateASG(machinePoolScope *scope.MachinePoolScope) error {
 	if err != nil {
 		return fmt.Errorf("getting subnets for ASG: %w", err)
 	}
+	if err := s.validateOutpostInstanceTypes(machinePoolScope, subnetIDs); err != nil {
+		return err
+	}
 
 	input := &autoscaling.UpdateAutoScalingGroupInput{
 		AutoScalingGroupName: aws.String(machinePoolScope.Name()), // TODO: define dynamically - borrow logic from ec2
@@ -518,6 +525,33 @@ func mapToTags(input map[string]string, resourceID *string) []*autoscaling.Tag {
 	return tags
 }
 
+// validateOutpostInstanceTypes makes sure that the instance types of the AWSMachinePool are available
+// on the Outposts of its subnets.
+func (s *Service) validateOutpostInstanceTypes(scope *scope.MachinePoolScope, subnetIDs []string) error {
+	var instanceTypes []string
+	if instanceType := scope.AWSMachinePool.Spec.AWSLaunchTemplate.InstanceType; instanceType != "" {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	if scope.AWSMachinePool.Spec.MixedInstancesPolicy != nil {
+		for _, override := range scope.AWSMachinePool.Spec.MixedInstancesPolicy.Overrides {
+			instanceTypes = append(instanceTypes, override.InstanceType)
+		}
+	}
+
+	for _, id := range subnetIDs {
+		subnet := scope.InfraCluster.Subnets().FindByID(id)
+		if subnet == nil || !subnet.IsOutpost() {
+			continue
+		}
+		if err := ec2service.ValidateOutpostInstanceTypes(s.EC2Client, *subnet.OutpostARN, instanceTypes); err != nil {
+			record.Warnf(scope.AWSMachinePool, "FailedCreate", "Failed to place machine pool on Outpost: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // SubnetIDs return subnet IDs of a AWSMachinePool based on given subnetIDs and filters.
 func (s *Service) SubnetIDs(scope *scope.MachinePoolScope) ([]string, error) {
 	subnetIDs := make([]string, 0)
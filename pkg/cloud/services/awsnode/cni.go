@@ -40,6 +40,17 @@ import (
 const (
 	awsNodeName      = "aws-node"
 	awsNodeNamespace = "kube-system"
+
+	// vpcCNIConfigMapName is the name of the ConfigMap with the configuration of the VPC CNI, which
+	// is read by the VPC resource controller of EKS.
+	vpcCNIConfigMapName  = "amazon-vpc-cni"
+	enableWindowsIPAMKey = "enable-windows-ipam"
+
+	// customNetworkCfgEnv enables the custom networking of the VPC CNI, which assigns the pod IP addresses
+	// from the subnet of the ENIConfig of the node instead of the subnet of the node.
+	customNetworkCfgEnv = "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"
+	// eniConfigLabelDefEnv is the node label whose value is the name of the ENIConfig of the node.
+	eniConfigLabelDefEnv = "ENI_CONFIG_LABEL_DEF"
 )
 
 // ReconcileCNI will reconcile the CNI of a service.
@@ -67,19 +78,26 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 		return ErrCNIMissing
 	}
 
+	if s.scope.VpcCni().EnableWindowsIPAM {
+		if err := s.reconcileWindowsIPAM(ctx, remoteClient); err != nil {
+			return fmt.Errorf("enabling windows ipam: %w", err)
+		}
+	}
+
+	secondarySubnets := s.secondarySubnets()
+
 	var needsUpdate bool
-	if len(s.scope.VpcCni().Env) > 0 {
+	if env := s.environment(len(secondarySubnets) > 0); len(env) > 0 {
 		s.scope.Info("updating aws-node daemonset environment variables", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
 		for i := range ds.Spec.Template.Spec.Containers {
 			container := &ds.Spec.Template.Spec.Containers[i]
 			if container.Name == "aws-node" {
-				container.Env, needsUpdate = s.applyUserProvidedEnvironmentProperties(container.Env)
+				container.Env, needsUpdate = applyEnvironmentProperties(container.Env, env)
 			}
 		}
 	}
 
-	secondarySubnets := s.secondarySubnets()
 	if len(secondarySubnets) == 0 {
 		if needsUpdate {
 			s.scope.Info("adding environment properties to vpc-cni", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
@@ -169,6 +187,39 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 	return remoteClient.Update(ctx, &ds, &client.UpdateOptions{})
 }
 
+// reconcileWindowsIPAM enables the IP address management for Windows nodes in the configuration
+// of the VPC CNI, which is required to schedule pods on Windows nodes.
+func (s *Service) reconcileWindowsIPAM(ctx context.Context, remoteClient client.Client) error {
+	var cm corev1.ConfigMap
+	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: awsNodeNamespace, Name: vpcCNIConfigMapName}, &cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		s.scope.Info("Creating vpc-cni ConfigMap", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: awsNodeNamespace,
+				Name:      vpcCNIConfigMapName,
+			},
+			Data: map[string]string{
+				enableWindowsIPAMKey: "true",
+			},
+		}
+		return remoteClient.Create(ctx, &cm, &client.CreateOptions{})
+	}
+
+	if cm.Data[enableWindowsIPAMKey] == "true" {
+		return nil
+	}
+
+	s.scope.Info("Updating vpc-cni ConfigMap", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[enableWindowsIPAMKey] = "true"
+	return remoteClient.Update(ctx, &cm, &client.UpdateOptions{})
+}
+
 func (s *Service) getSecurityGroups() ([]string, error) {
 	sgRoles := []infrav1.SecurityGroupRole{
 		infrav1.SecurityGroupNode,
@@ -185,13 +236,27 @@ func (s *Service) getSecurityGroups() ([]string, error) {
 	return sgs, nil
 }
 
-// applyUserProvidedEnvironmentProperties takes a container environment and applies user provided values to it.
-func (s *Service) applyUserProvidedEnvironmentProperties(containerEnv []corev1.EnvVar) ([]corev1.EnvVar, bool) {
+// environment returns the environment variables to apply to the aws-node DaemonSet. The custom networking is
+// enabled when there are secondary subnets for the pods, which the ENIConfigs named after the availability
+// zones of the nodes select. The user provided values take precedence.
+func (s *Service) environment(customNetworking bool) []corev1.EnvVar {
+	env := []corev1.EnvVar{}
+	if customNetworking {
+		env = append(env,
+			corev1.EnvVar{Name: customNetworkCfgEnv, Value: "true"},
+			corev1.EnvVar{Name: eniConfigLabelDefEnv, Value: corev1.LabelTopologyZone},
+		)
+	}
+	return append(env, s.scope.VpcCni().Env...)
+}
+
+// applyEnvironmentProperties takes a container environment and applies the given values to it.
+func applyEnvironmentProperties(containerEnv []corev1.EnvVar, env []corev1.EnvVar) ([]corev1.EnvVar, bool) {
 	var (
 		envVars     = make(map[string]corev1.EnvVar)
 		needsUpdate = false
 	)
-	for _, e := range s.scope.VpcCni().Env {
+	for _, e := range env {
 		envVars[e.Name] = e
 	}
 	// Handle the case where we overwrite an existing value if it's not already the desired value.
@@ -27,7 +27,9 @@ import (
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
@@ -252,7 +254,80 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 			ds, ok := mockClient.updateChain[1].(*v1.DaemonSet)
 			g.Expect(ok).To(BeTrue())
 			g.Expect(ds.Spec.Template.Spec.Containers).NotTo(BeEmpty())
-			g.Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(tc.consistsOf))
+			// the custom networking is enabled for the ENIConfigs of the secondary subnets
+			g.Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(append([]corev1.EnvVar{
+				{
+					Name:  "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG",
+					Value: "true",
+				},
+				{
+					Name:  "ENI_CONFIG_LABEL_DEF",
+					Value: "topology.kubernetes.io/zone",
+				},
+			}, tc.consistsOf...)))
+		})
+	}
+}
+
+func TestReconcileCniWindowsIPAM(t *testing.T) {
+	tests := []struct {
+		name         string
+		configMap    *corev1.ConfigMap
+		expectedData map[string]string
+	}{
+		{
+			name:         "creates the vpc-cni ConfigMap",
+			expectedData: map[string]string{enableWindowsIPAMKey: "true"},
+		},
+		{
+			name: "updates the existing vpc-cni ConfigMap",
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      vpcCNIConfigMapName,
+					Namespace: awsNodeNamespace,
+				},
+				Data: map[string]string{
+					enableWindowsIPAMKey:               "false",
+					"enable-network-policy-controller": "true",
+				},
+			},
+			expectedData: map[string]string{
+				enableWindowsIPAMKey:               "true",
+				"enable-network-policy-controller": "true",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := []client.Object{
+				&v1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      awsNodeName,
+						Namespace: awsNodeNamespace,
+					},
+				},
+			}
+			if tc.configMap != nil {
+				objs = append(objs, tc.configMap)
+			}
+			remoteClient := fake.NewClientBuilder().WithObjects(objs...).Build()
+			m := &mockScope{
+				client: remoteClient,
+				cni: ekscontrolplanev1.VpcCni{
+					EnableWindowsIPAM: true,
+				},
+			}
+			s := NewService(m)
+
+			err := s.ReconcileCNI(context.Background())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			var cm corev1.ConfigMap
+			g.Expect(remoteClient.Get(context.Background(), types.NamespacedName{Namespace: awsNodeNamespace, Name: vpcCNIConfigMapName}, &cm)).To(Succeed())
+			g.Expect(cm.Data).To(Equal(tc.expectedData))
 		})
 	}
 }
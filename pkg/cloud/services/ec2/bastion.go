@@ -28,6 +28,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
@@ -92,6 +93,9 @@ func (s *Service) ReconcileBastion() error {
 		s.scope.Info("Created new bastion host", "id", instance.ID)
 	} else if err != nil {
 		return err
+	} else if err := s.reconcileBastionTags(instance); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedTagBastion", "Failed to tag bastion instance %q: %v", instance.ID, err)
+		return err
 	}
 
 	// TODO(vincepri): check for possible changes between the default spec and the instance.
@@ -168,7 +172,6 @@ func (s *Service) describeBastionInstance() (*infrav1.Instance, error) {
 }
 
 func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance, error) {
-	name := fmt.Sprintf("%s-bastion", s.scope.Name())
 	userData, _ := userdata.NewBastion(&userdata.BastionInput{})
 
 	// If SSHKeyName WAS NOT provided, use the defaultSSHKeyName
@@ -204,14 +207,57 @@ func (s *Service) getDefaultBastion(instanceType, ami string) (*infrav1.Instance
 		SecurityGroupIDs: []string{
 			s.scope.Network().SecurityGroups[infrav1.SecurityGroupBastion].ID,
 		},
-		Tags: infrav1.Build(infrav1.BuildParams{
-			ClusterName: s.scope.Name(),
-			Lifecycle:   infrav1.ResourceLifecycleOwned,
-			Name:        aws.String(name),
-			Role:        aws.String(infrav1.BastionRoleTagValue),
-			Additional:  s.scope.AdditionalTags(),
-		}),
+		Tags: infrav1.Build(s.bastionTagParams()),
 	}
 
 	return i, nil
 }
+
+// bastionTagParams returns the parameters to build the tags of the bastion instance and its volumes.
+func (s *Service) bastionTagParams() infrav1.BuildParams {
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(fmt.Sprintf("%s-bastion", s.scope.Name())),
+		Role:        aws.String(infrav1.BastionRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
+
+// reconcileBastionTags makes sure the bastion instance and its volumes carry the cluster tags, including the
+// additional tags of the cluster. Tags are only added or updated, tags removed from the additional tags are kept.
+func (s *Service) reconcileBastionTags(instance *infrav1.Instance) error {
+	want := infrav1.Build(s.bastionTagParams())
+
+	var resources []string
+	if len(want.Difference(instance.Tags)) > 0 {
+		resources = append(resources, instance.ID)
+	}
+
+	if len(instance.VolumeIDs) > 0 {
+		out, err := s.EC2Client.DescribeVolumesWithContext(context.TODO(), &ec2.DescribeVolumesInput{
+			VolumeIds: aws.StringSlice(instance.VolumeIDs),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe volumes of bastion instance %q", instance.ID)
+		}
+		for _, volume := range out.Volumes {
+			if len(want.Difference(converters.TagsToMap(volume.Tags))) > 0 {
+				resources = append(resources, aws.StringValue(volume.VolumeId))
+			}
+		}
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+
+	s.scope.Debug("Updating tags of bastion host", "resources", resources)
+	if _, err := s.EC2Client.CreateTagsWithContext(context.TODO(), &ec2.CreateTagsInput{
+		Resources: aws.StringSlice(resources),
+		Tags:      converters.MapToTags(want),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to tag bastion resources %v", resources)
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
@@ -258,9 +259,56 @@ func TestServiceReconcileBastion(t *testing.T) {
 		},
 	}
 
+	bastionTags := []*ec2.Tag{
+		{Key: aws.String("Name"), Value: aws.String("cluster-bastion")},
+		{Key: aws.String("cost-center"), Value: aws.String("platform")},
+		{Key: aws.String(infrav1.ClusterTagKey(clusterName)), Value: aws.String(string(infrav1.ResourceLifecycleOwned))},
+		{Key: aws.String(infrav1.NameAWSClusterAPIRole), Value: aws.String(infrav1.BastionRoleTagValue)},
+	}
+	existingBastion := func(tags []*ec2.Tag) *ec2.DescribeInstancesOutput {
+		return &ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{
+				{
+					Instances: []*ec2.Instance{
+						{
+							InstanceId:     aws.String("id123"),
+							State:          &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+							InstanceType:   aws.String("t3.micro"),
+							SubnetId:       aws.String("subnet-2"),
+							ImageId:        aws.String("ubuntu-ami-id-latest"),
+							RootDeviceName: aws.String("device-1"),
+							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+								{
+									DeviceName: aws.String("device-1"),
+									Ebs:        &ec2.EbsInstanceBlockDevice{VolumeId: aws.String("volume-1")},
+								},
+							},
+							Placement: &ec2.Placement{AvailabilityZone: aws.String("us-east-1")},
+							Tags:      tags,
+						},
+					},
+				},
+			},
+		}
+	}
+	existingBastionStatus := func(tags []*ec2.Tag) *infrav1.Instance {
+		return &infrav1.Instance{
+			ID:               "id123",
+			State:            "running",
+			Type:             "t3.micro",
+			SubnetID:         "subnet-2",
+			ImageID:          "ubuntu-ami-id-latest",
+			Addresses:        []clusterv1.MachineAddress{},
+			AvailabilityZone: "us-east-1",
+			VolumeIDs:        []string{"volume-1"},
+			Tags:             converters.TagsToMap(tags),
+		}
+	}
+
 	tests := []struct {
 		name           string
 		bastionEnabled bool
+		additionalTags infrav1.Tags
 		expect         func(m *mocks.MockEC2APIMockRecorder)
 		expectError    bool
 		bastionStatus  *infrav1.Instance
@@ -379,6 +427,34 @@ func TestServiceReconcileBastion(t *testing.T) {
 				VolumeIDs:        []string{"volume-1"},
 			},
 		},
+		{
+			name:           "Should tag an existing bastion and its volumes with the additional tags",
+			bastionEnabled: true,
+			additionalTags: infrav1.Tags{"cost-center": "platform"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(existingBastion(bastionTags[2:]), nil)
+				m.DescribeVolumesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVolumesInput{VolumeIds: aws.StringSlice([]string{"volume-1"})})).
+					Return(&ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{{VolumeId: aws.String("volume-1")}}}, nil)
+				m.CreateTagsWithContext(context.TODO(), gomock.Eq(&ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"id123", "volume-1"}),
+					Tags:      bastionTags,
+				})).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+			bastionStatus: existingBastionStatus(bastionTags[2:]),
+		},
+		{
+			name:           "Should not tag an existing bastion whose tags are up to date",
+			bastionEnabled: true,
+			additionalTags: infrav1.Tags{"cost-center": "platform"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Eq(describeInput)).
+					Return(existingBastion(bastionTags), nil)
+				m.DescribeVolumesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVolumesInput{VolumeIds: aws.StringSlice([]string{"volume-1"})})).
+					Return(&ec2.DescribeVolumesOutput{Volumes: []*ec2.Volume{{VolumeId: aws.String("volume-1"), Tags: bastionTags}}}, nil)
+			},
+			bastionStatus: existingBastionStatus(bastionTags),
+		},
 	}
 
 	for _, tc := range tests {
@@ -414,7 +490,8 @@ func TestServiceReconcileBastion(t *testing.T) {
 								},
 							},
 						},
-						Bastion: infrav1.Bastion{Enabled: tc.bastionEnabled},
+						Bastion:        infrav1.Bastion{Enabled: tc.bastionEnabled},
+						AdditionalTags: tc.additionalTags,
 					},
 				}
 
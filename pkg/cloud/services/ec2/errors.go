@@ -24,4 +24,8 @@ var (
 
 	// ErrDescribeInstance defines an error for when AWS SDK returns error when describing instances.
 	ErrDescribeInstance = errors.New("failed to describe instance by id")
+
+	// ErrAvailabilityZoneOccupied defines an error for when a control plane instance can't be launched, as its
+	// availability zone already hosts another control plane instance of the cluster.
+	ErrAvailabilityZoneOccupied = errors.New("availability zone already hosts a control plane instance")
 )
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
+)
+
+// hostnameHashLength is the length of the hash suffixed to hostnames that had to be truncated.
+const hostnameHashLength = 8
+
+// hostnameTemplateData holds the values available to the hostname template of an AWSMachine.
+type hostnameTemplateData struct {
+	MachineName string
+	ClusterName string
+}
+
+// RenderHostname renders the hostname template of the AWSMachine for its Machine, returning an empty
+// string when no template is set. Hostnames longer than a DNS label are truncated and suffixed with a hash
+// of the rendered hostname, so that the hostnames of machines with long names stay unique.
+func RenderHostname(scope *scope.MachineScope) (string, error) {
+	hostnameTemplate := scope.AWSMachine.Spec.HostnameTemplate
+	if hostnameTemplate == nil {
+		return "", nil
+	}
+
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(*hostnameTemplate)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse hostname template")
+	}
+
+	var buf bytes.Buffer
+	data := hostnameTemplateData{
+		MachineName: scope.Machine.Name,
+		ClusterName: scope.Machine.Spec.ClusterName,
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "failed to render hostname template")
+	}
+
+	hostname := buf.String()
+	if len(hostname) > validation.DNS1123LabelMaxLength {
+		suffix, err := hash.Base36TruncatedHash(hostname, hostnameHashLength)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to hash hostname")
+		}
+		hostname = fmt.Sprintf("%s-%s", hostname[:validation.DNS1123LabelMaxLength-hostnameHashLength-1], suffix)
+	}
+
+	if errs := validation.IsDNS1123Label(hostname); len(errs) > 0 {
+		return "", errors.Errorf("rendered hostname %q is not a valid node name: %s", hostname, strings.Join(errs, ", "))
+	}
+
+	return hostname, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestRenderHostname(t *testing.T) {
+	longMachineName := "md-" + strings.Repeat("a", 60)
+
+	tests := []struct {
+		name             string
+		hostnameTemplate *string
+		machineName      string
+		expected         string
+		expectErr        bool
+	}{
+		{
+			name:        "no hostname template",
+			machineName: "machine-0",
+		},
+		{
+			name:             "renders the machine and cluster names",
+			hostnameTemplate: ptr.To[string]("{{ .ClusterName }}-{{ .MachineName }}"),
+			machineName:      "machine-0",
+			expected:         "test-cluster-machine-0",
+		},
+		{
+			name:             "rejects an invalid node name",
+			hostnameTemplate: ptr.To[string]("{{ .MachineName }}_aws"),
+			machineName:      "machine-0",
+			expectErr:        true,
+		},
+		{
+			name:             "rejects an unknown field",
+			hostnameTemplate: ptr.To[string]("{{ .Namespace }}"),
+			machineName:      "machine-0",
+			expectErr:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hostname, err := RenderHostname(newHostnameMachineScope(tc.hostnameTemplate, tc.machineName))
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(hostname).To(Equal(tc.expected))
+		})
+	}
+
+	t.Run("truncates hostnames longer than a DNS label", func(t *testing.T) {
+		g := NewWithT(t)
+
+		hostname, err := RenderHostname(newHostnameMachineScope(ptr.To[string]("{{ .MachineName }}-aws"), longMachineName))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(hostname).To(HaveLen(63))
+		g.Expect(hostname).To(HavePrefix("md-aaaa"))
+
+		other, err := RenderHostname(newHostnameMachineScope(ptr.To[string]("{{ .MachineName }}-aws"), longMachineName+"b"))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(other).To(HaveLen(63))
+		g.Expect(other).NotTo(Equal(hostname))
+	})
+}
+
+func newHostnameMachineScope(hostnameTemplate *string, machineName string) *scope.MachineScope {
+	return &scope.MachineScope{
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: machineName},
+			Spec:       clusterv1.MachineSpec{ClusterName: "test-cluster"},
+		},
+		AWSMachine: &infrav1.AWSMachine{
+			Spec: infrav1.AWSMachineSpec{HostnameTemplate: hostnameTemplate},
+		},
+	}
+}
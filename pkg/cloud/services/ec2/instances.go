@@ -27,6 +27,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -105,6 +107,104 @@ func (s *Service) InstanceIfExists(id *string) (*infrav1.Instance, error) {
 	return nil, ErrInstanceNotFoundByID
 }
 
+// AdoptInstance takes ownership of a pre-existing instance for the given machine.
+// The instance must match the key attributes of the AWSMachine spec, it is then
+// tagged the same way as the instances created by CreateInstance.
+func (s *Service) AdoptInstance(scope *scope.MachineScope, instance *infrav1.Instance) error {
+	if err := validateAdoptedInstance(&scope.AWSMachine.Spec, instance); err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAdoptInstance", "Failed to adopt instance %q: %v", instance.ID, err)
+		return err
+	}
+	if err := s.validateAdoptedInstanceOwnership(scope, instance); err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAdoptInstance", "Failed to adopt instance %q: %v", instance.ID, err)
+		return err
+	}
+
+	if infrav1.Tags(instance.Tags).HasOwned(s.scope.KubernetesClusterName()) {
+		return nil
+	}
+
+	s.scope.Debug("Adopting existing instance", "instance-id", instance.ID)
+	tags := s.machineInstanceTags(scope)
+	if err := s.UpdateResourceTags(aws.String(instance.ID), tags, nil); err != nil {
+		record.Warnf(scope.AWSMachine, "FailedAdoptInstance", "Failed to tag instance %q: %v", instance.ID, err)
+		return errors.Wrapf(err, "failed to tag adopted instance %q", instance.ID)
+	}
+
+	if instance.Tags == nil {
+		instance.Tags = infrav1.Tags{}
+	}
+	for k, v := range tags {
+		instance.Tags[k] = v
+	}
+
+	record.Eventf(scope.AWSMachine, "SuccessfulAdoptInstance", "Adopted existing instance %q", instance.ID)
+	return nil
+}
+
+// validateAdoptedInstance checks that an instance can be adopted by an AWSMachine with the given spec.
+func validateAdoptedInstance(spec *infrav1.AWSMachineSpec, instance *infrav1.Instance) error {
+	switch instance.State {
+	case infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated:
+		return errors.Errorf("instance %q is in %q state", instance.ID, instance.State)
+	}
+
+	if instance.Type != spec.InstanceType {
+		return errors.Errorf("instance %q has type %q, expected %q", instance.ID, instance.Type, spec.InstanceType)
+	}
+
+	if spec.AMI.ID != nil && instance.ImageID != *spec.AMI.ID {
+		return errors.Errorf("instance %q uses AMI %q, expected %q", instance.ID, instance.ImageID, *spec.AMI.ID)
+	}
+
+	if spec.Subnet != nil && spec.Subnet.ID != nil && instance.SubnetID != *spec.Subnet.ID {
+		return errors.Errorf("instance %q is in subnet %q, expected %q", instance.ID, instance.SubnetID, *spec.Subnet.ID)
+	}
+
+	if spec.SSHKeyName != nil && *spec.SSHKeyName != aws.StringValue(instance.SSHKeyName) {
+		return errors.Errorf("instance %q uses SSH key %q, expected %q", instance.ID, aws.StringValue(instance.SSHKeyName), *spec.SSHKeyName)
+	}
+
+	return nil
+}
+
+// validateAdoptedInstanceOwnership checks that an instance is neither part of another cluster nor of another
+// machine, and that it runs in the VPC of the cluster.
+func (s *Service) validateAdoptedInstanceOwnership(scope *scope.MachineScope, instance *infrav1.Instance) error {
+	clusterName := s.scope.KubernetesClusterName()
+	for key := range instance.Tags {
+		for _, prefix := range []string{infrav1.NameAWSProviderOwned, infrav1.NameKubernetesAWSCloudProviderPrefix} {
+			if owner, ok := strings.CutPrefix(key, prefix); ok && owner != clusterName {
+				return errors.Errorf("instance %q belongs to cluster %q", instance.ID, owner)
+			}
+		}
+	}
+
+	machineName := types.NamespacedName{Namespace: scope.Machine.Namespace, Name: scope.Machine.Name}.String()
+	if owner, ok := instance.Tags[infrav1.MachineNameTagKey]; ok && owner != machineName {
+		return errors.Errorf("instance %q belongs to machine %q", instance.ID, owner)
+	}
+
+	if vpcID := s.scope.VPC().ID; vpcID != "" && instance.VPCID != vpcID {
+		return errors.Errorf("instance %q is in vpc %q, expected %q", instance.ID, instance.VPCID, vpcID)
+	}
+
+	return nil
+}
+
+// machineInstanceTags returns the tags of an instance owned by the given machine.
+func (s *Service) machineInstanceTags(scope *scope.MachineScope) infrav1.Tags {
+	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
+	additionalTags := scope.AdditionalTags()
+	return infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.KubernetesClusterName(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(scope.Name()),
+		Role:        aws.String(scope.Role()),
+		Additional:  additionalTags,
+	}.WithCloudProvider(s.scope.KubernetesClusterName()).WithMachineName(scope.Machine))
+}
+
 // CreateInstance runs an ec2 instance.
 //
 //nolint:gocyclo // this function has multiple processes to perform
@@ -118,17 +218,11 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 		NonRootVolumes:       scope.AWSMachine.Spec.NonRootVolumes,
 		NetworkInterfaces:    scope.AWSMachine.Spec.NetworkInterfaces,
 		NetworkInterfaceType: scope.AWSMachine.Spec.NetworkInterfaceType,
+
+		SecondaryNetworkInterfaces: scope.AWSMachine.Spec.SecondaryNetworkInterfaces,
 	}
 
-	// Make sure to use the MachineScope here to get the merger of AWSCluster and AWSMachine tags
-	additionalTags := scope.AdditionalTags()
-	input.Tags = infrav1.Build(infrav1.BuildParams{
-		ClusterName: s.scope.KubernetesClusterName(),
-		Lifecycle:   infrav1.ResourceLifecycleOwned,
-		Name:        aws.String(scope.Name()),
-		Role:        aws.String(scope.Role()),
-		Additional:  additionalTags,
-	}.WithCloudProvider(s.scope.KubernetesClusterName()).WithMachineName(scope.Machine))
+	input.Tags = s.machineInstanceTags(scope)
 
 	var err error
 
@@ -180,8 +274,21 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	if err != nil {
 		return nil, err
 	}
+	if scope.IsControlPlane() && s.scope.ControlPlaneAZSpread() == infrav1.ControlPlaneAZSpreadPolicyStrict {
+		subnetID, err = s.spreadControlPlaneSubnet(scope, subnetID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	input.SubnetID = subnetID
 
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil && subnet.IsOutpost() {
+		if err := ValidateOutpostInstanceTypes(s.EC2Client, *subnet.OutpostARN, []string{input.Type}); err != nil {
+			record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to place machine on Outpost: %v", err)
+			return nil, err
+		}
+	}
+
 	// Preserve user-defined PublicIp option.
 	input.PublicIPOnLaunch = scope.AWSMachine.Spec.PublicIP
 
@@ -261,14 +368,26 @@ func (s *Service) CreateInstance(scope *scope.MachineScope, userData []byte, use
 	if err != nil {
 		// Only record the failure event if the error is not related to failed dependencies.
 		// This is to avoid spamming failure events since the machine will be requeued by the actuator.
-		if !awserrors.IsFailedDependency(errors.Cause(err)) {
+		// IAM instance profile propagation errors are transient as well and are surfaced through conditions.
+		if !awserrors.IsFailedDependency(errors.Cause(err)) && !awserrors.IsInvalidIAMInstanceProfile(errors.Cause(err)) {
 			record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to create instance: %v", err)
 		}
 		return nil, err
 	}
 
-	// Set the providerID and instanceID as soon as we create an instance so that we keep it in case of errors afterward
-	scope.SetProviderID(out.ID, out.AvailabilityZone)
+	// RunInstances doesn't always report the placement of a pending instance, fall back to the
+	// availability zone of the subnet so the providerID has the aws:///<az>/<instance-id> format.
+	if out.AvailabilityZone == "" {
+		if subnet := s.scope.Subnets().FindByID(out.SubnetID); subnet != nil {
+			out.AvailabilityZone = subnet.AvailabilityZone
+		}
+	}
+
+	// Set the providerID and instanceID as soon as we create an instance so that we keep it in case of errors afterward.
+	// The providerID is set later in the reconcile loop if the availability zone isn't known yet.
+	if out.AvailabilityZone != "" {
+		scope.SetProviderID(out.ID, out.AvailabilityZone)
+	}
 	scope.SetInstanceID(out.ID)
 
 	if len(input.NetworkInterfaces) > 0 {
@@ -387,6 +506,9 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	case failureDomain != nil:
 		if scope.AWSMachine.Spec.PublicIP != nil && *scope.AWSMachine.Spec.PublicIP {
 			subnets := s.scope.Subnets().FilterPublic().FilterNonCni().FilterByZone(*failureDomain)
+			if len(subnets) == 0 {
+				subnets = edgeSubnetsInZone(s.scope.Subnets(), *failureDomain, true)
+			}
 			if len(subnets) == 0 {
 				errMessage := fmt.Sprintf("failed to run machine %q with public IP, no public subnets available in availability zone %q",
 					scope.Name(), *failureDomain)
@@ -397,6 +519,9 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 		}
 
 		subnets := s.scope.Subnets().FilterPrivate().FilterNonCni().FilterByZone(*failureDomain)
+		if len(subnets) == 0 {
+			subnets = edgeSubnetsInZone(s.scope.Subnets(), *failureDomain, false)
+		}
 		if len(subnets) == 0 {
 			errMessage := fmt.Sprintf("failed to run machine %q, no subnets available in availability zone %q",
 				scope.Name(), *failureDomain)
@@ -427,6 +552,99 @@ func (s *Service) findSubnet(scope *scope.MachineScope) (string, error) {
 	}
 }
 
+// edgeSubnetsInZone returns the public or private subnets in the given AWS Local Zone or Wavelength Zone.
+func edgeSubnetsInZone(subnets infrav1.Subnets, zone string, public bool) (res infrav1.Subnets) {
+	for _, sn := range subnets.FilterEdge().FilterByZone(zone) {
+		if sn.IsPublic == public {
+			res = append(res, sn)
+		}
+	}
+	return res
+}
+
+// spreadControlPlaneSubnet makes sure a control plane instance isn't launched into an availability zone which
+// already hosts another control plane instance of the cluster. If the machine isn't bound to an availability zone
+// by its failure domain or subnet, a subnet in a free zone is returned instead of the given one.
+func (s *Service) spreadControlPlaneSubnet(scope *scope.MachineScope, subnetID string) (string, error) {
+	occupied, err := s.controlPlaneAvailabilityZones(scope)
+	if err != nil {
+		return "", err
+	}
+
+	zone, err := s.subnetAvailabilityZone(subnetID)
+	if err != nil {
+		return "", err
+	}
+	if !occupied[zone] {
+		return subnetID, nil
+	}
+
+	boundToZone := scope.Machine.Spec.FailureDomain != nil ||
+		(scope.AWSMachine.Spec.Subnet != nil && (scope.AWSMachine.Spec.Subnet.ID != nil || scope.AWSMachine.Spec.Subnet.Filters != nil))
+	if !boundToZone {
+		subnets := s.scope.Subnets().FilterPrivate().FilterNonCni()
+		if ptr.Deref(scope.AWSMachine.Spec.PublicIP, false) {
+			subnets = s.scope.Subnets().FilterPublic().FilterNonCni()
+		}
+		for _, subnet := range subnets {
+			if !occupied[subnet.AvailabilityZone] {
+				record.Eventf(scope.AWSMachine, "ControlPlaneAZSpread", "Launching control plane instance in availability zone %q, as %q already hosts a control plane instance",
+					subnet.AvailabilityZone, zone)
+				return subnet.GetResourceID(), nil
+			}
+		}
+	}
+
+	record.Warnf(scope.AWSMachine, "FailedCreate", "Failed to run control plane machine %q, availability zone %q already hosts a control plane instance", scope.Name(), zone)
+	return "", errors.Wrapf(ErrAvailabilityZoneOccupied, "failed to run control plane machine %q in availability zone %q", scope.Name(), zone)
+}
+
+// controlPlaneAvailabilityZones returns the availability zones hosting control plane instances of the cluster,
+// other than the one of the given machine.
+func (s *Service) controlPlaneAvailabilityZones(scope *scope.MachineScope) (map[string]bool, error) {
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ProviderRole(scope.Role()),
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped),
+		},
+	}
+
+	zones := map[string]bool{}
+	err := s.EC2Client.DescribeInstancesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, res := range out.Reservations {
+			for _, inst := range res.Instances {
+				if converters.TagsToMap(inst.Tags)["Name"] == scope.Name() || inst.Placement == nil {
+					continue
+				}
+				zones[aws.StringValue(inst.Placement.AvailabilityZone)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeInstances", "Failed to describe control plane instances: %v", err)
+		return nil, errors.Wrap(err, "failed to describe control plane instances")
+	}
+	return zones, nil
+}
+
+// subnetAvailabilityZone returns the availability zone of the subnet with the given ID.
+func (s *Service) subnetAvailabilityZone(subnetID string) (string, error) {
+	if subnet := s.scope.Subnets().FindByID(subnetID); subnet != nil && subnet.AvailabilityZone != "" {
+		return subnet.AvailabilityZone, nil
+	}
+
+	subnets, err := s.getFilteredSubnets(&ec2.Filter{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{subnetID})})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe subnet %q", subnetID)
+	}
+	if len(subnets) == 0 {
+		return "", errors.Errorf("subnet %q not found", subnetID)
+	}
+	return aws.StringValue(subnets[0].AvailabilityZone), nil
+}
+
 // getFilteredSubnets fetches subnets filtered based on the criteria passed.
 func (s *Service) getFilteredSubnets(criteria ...*ec2.Filter) ([]*ec2.Subnet, error) {
 	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{Filters: criteria})
@@ -460,12 +678,12 @@ func (s *Service) GetCoreSecurityGroups(scope *scope.MachineScope) ([]string, er
 	}
 
 	switch scope.Role() {
-	case "node":
+	case infrav1.NodeRoleTagValue:
 		// Just the common security groups above
 		if scope.IsEKSManaged() {
 			sgRoles = append(sgRoles, infrav1.SecurityGroupEKSNodeAdditional)
 		}
-	case "control-plane":
+	case infrav1.ControlPlaneRoleTagValue:
 		sgRoles = append(sgRoles, infrav1.SecurityGroupControlPlane)
 	default:
 		return nil, errors.Errorf("Unknown node role %q", scope.Role())
@@ -588,6 +806,39 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		input.NetworkInterfaces[0].InterfaceType = aws.String(string(i.NetworkInterfaceType))
 	}
 
+	// The secondary network interfaces are created with the instance and deleted when it is terminated.
+	for _, eni := range i.SecondaryNetworkInterfaces {
+		subnetID := eni.SubnetID
+		if subnetID == "" {
+			subnetID = i.SubnetID
+		}
+		groups := eni.SecurityGroupIDs
+		if len(groups) == 0 {
+			groups = i.SecurityGroupIDs
+		}
+		netInterface := &ec2.InstanceNetworkInterfaceSpecification{
+			DeviceIndex:         aws.Int64(eni.DeviceIndex),
+			SubnetId:            aws.String(subnetID),
+			Groups:              aws.StringSlice(groups),
+			DeleteOnTermination: aws.Bool(true),
+		}
+		if eni.Description != "" {
+			netInterface.Description = aws.String(eni.Description)
+		}
+		input.NetworkInterfaces = append(input.NetworkInterfaces, netInterface)
+	}
+	if len(i.SecondaryNetworkInterfaces) > 0 {
+		// The public IP option cannot be given at all for an instance launched with multiple network interfaces.
+		input.NetworkInterfaces[0].AssociatePublicIpAddress = nil
+	}
+
+	// Instances in Wavelength Zones are reachable from the carrier network through a carrier IP, public IPs
+	// aren't supported there.
+	if subnet := s.scope.Subnets().FindByID(i.SubnetID); subnet != nil && subnet.IsEdgeWavelength() && ptr.Deref(i.PublicIPOnLaunch, false) {
+		input.NetworkInterfaces[0].AssociatePublicIpAddress = nil
+		input.NetworkInterfaces[0].AssociateCarrierIpAddress = aws.Bool(true)
+	}
+
 	if i.IAMProfile != "" {
 		input.IamInstanceProfile = &ec2.IamInstanceProfileSpecification{
 			Name: aws.String(i.IAMProfile),
@@ -619,6 +870,9 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	}
 
 	if len(blockdeviceMappings) != 0 {
+		if err := s.applyEBSEncryptionByDefault(blockdeviceMappings); err != nil {
+			return nil, err
+		}
 		input.BlockDeviceMappings = blockdeviceMappings
 	}
 
@@ -674,8 +928,29 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 		}
 	}
 
-	out, err := s.EC2Client.RunInstancesWithContext(context.TODO(), input)
-	if err != nil {
+	// A freshly created IAM instance profile is not immediately usable by EC2, retry for a short while
+	// instead of failing the whole reconciliation. If it is still not usable afterwards the error is
+	// returned, and the machine is requeued until the instance profile has propagated.
+	backoff := s.InstanceProfilePropagationBackoff
+	if backoff.Steps < 1 {
+		backoff.Steps = 1
+	}
+	var out *ec2.Reservation
+	var runErr error
+	if err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		out, runErr = s.EC2Client.RunInstancesWithContext(context.TODO(), input)
+		if runErr == nil {
+			return true, nil
+		}
+		if i.IAMProfile != "" && awserrors.IsInvalidIAMInstanceProfile(runErr) {
+			s.scope.Debug("IAM instance profile is not usable yet, retrying", "instance-profile", i.IAMProfile)
+			return false, nil
+		}
+		return false, runErr
+	}); err != nil {
+		if runErr != nil {
+			return nil, errors.Wrap(runErr, "failed to run instance")
+		}
 		return nil, errors.Wrap(err, "failed to run instance")
 	}
 
@@ -686,6 +961,42 @@ func (s *Service) runInstance(role string, i *infrav1.Instance) (*infrav1.Instan
 	return s.SDKToInstance(out.Instances[0])
 }
 
+// applyEBSEncryptionByDefault explicitly requests encrypted volumes when EBS encryption by default is enabled
+// for the account in the region, so that policies conditioned on ec2:Encrypted allow the launch. Volumes that
+// explicitly disable encryption are rejected, as EC2 does not allow unencrypted volumes in that case.
+func (s *Service) applyEBSEncryptionByDefault(mappings []*ec2.BlockDeviceMapping) error {
+	needsEncryption := false
+	for _, m := range mappings {
+		if m.Ebs != nil && !aws.BoolValue(m.Ebs.Encrypted) {
+			needsEncryption = true
+			break
+		}
+	}
+	if !needsEncryption {
+		return nil
+	}
+
+	out, err := s.EC2Client.GetEbsEncryptionByDefaultWithContext(context.TODO(), &ec2.GetEbsEncryptionByDefaultInput{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get EBS encryption by default status")
+	}
+	if !aws.BoolValue(out.EbsEncryptionByDefault) {
+		return nil
+	}
+
+	for _, m := range mappings {
+		if m.Ebs == nil {
+			continue
+		}
+		if m.Ebs.Encrypted != nil && !*m.Ebs.Encrypted {
+			return errors.Errorf("volume %q has encryption disabled, but EBS encryption by default is enabled for the account in region %q", aws.StringValue(m.DeviceName), s.scope.Region())
+		}
+		m.Ebs.Encrypted = aws.Bool(true)
+	}
+
+	return nil
+}
+
 func volumeToBlockDeviceMapping(v *infrav1.Volume) *ec2.BlockDeviceMapping {
 	ebsDevice := &ec2.EbsBlockDevice{
 		DeleteOnTermination: aws.Bool(true),
@@ -726,6 +1037,9 @@ func (s *Service) GetInstanceSecurityGroups(instanceID string) (map[string][]str
 
 	out := make(map[string][]string)
 	for _, eni := range enis {
+		if !hasInstanceSecurityGroups(eni) {
+			continue
+		}
 		var groups []string
 		for _, group := range eni.Groups {
 			groups = append(groups, aws.StringValue(group.GroupId))
@@ -748,6 +1062,9 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 	s.scope.Debug("Found ENIs on instance", "number-of-enis", len(enis), "instance-id", instanceID)
 
 	for _, eni := range enis {
+		if !hasInstanceSecurityGroups(eni) {
+			continue
+		}
 		if err := s.attachSecurityGroupsToNetworkInterface(ids, aws.StringValue(eni.NetworkInterfaceId)); err != nil {
 			return errors.Wrapf(err, "failed to modify network interfaces on instance %q", instanceID)
 		}
@@ -756,6 +1073,16 @@ func (s *Service) UpdateInstanceSecurityGroups(instanceID string, ids []string)
 	return nil
 }
 
+// hasInstanceSecurityGroups returns whether the security groups of the network interface follow the ones of the
+// instance. The secondary network interfaces created with the instance, which are the only ones besides the
+// primary network interface to be deleted on termination, keep their own security groups.
+func hasInstanceSecurityGroups(eni *ec2.NetworkInterface) bool {
+	if eni.Attachment == nil {
+		return true
+	}
+	return aws.Int64Value(eni.Attachment.DeviceIndex) == 0 || !aws.BoolValue(eni.Attachment.DeleteOnTermination)
+}
+
 // UpdateResourceTags updates the tags for an instance.
 // This will be called if there is anything to create (update) or delete.
 // We may not always have to perform each action, so we check what we're
@@ -878,6 +1205,7 @@ func (s *Service) SDKToInstance(v *ec2.Instance) (*infrav1.Instance, error) {
 		State:        infrav1.InstanceState(*v.State.Name),
 		Type:         aws.StringValue(v.InstanceType),
 		SubnetID:     aws.StringValue(v.SubnetId),
+		VPCID:        aws.StringValue(v.VpcId),
 		ImageID:      aws.StringValue(v.ImageId),
 		SSHKeyName:   v.KeyName,
 		PrivateIP:    v.PrivateIpAddress,
@@ -957,6 +1285,14 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 
 		addresses = append(addresses, privateDNSAddress, privateIPAddress)
 
+		// Instances in dual-stack subnets are assigned IPv6 addresses as well.
+		for _, ipv6Address := range eni.Ipv6Addresses {
+			addresses = append(addresses, clusterv1.MachineAddress{
+				Type:    clusterv1.MachineInternalIP,
+				Address: aws.StringValue(ipv6Address.Ipv6Address),
+			})
+		}
+
 		if domainName != nil {
 			// Add secondary private DNS Name with domain name set in DHCP Option Set
 			additionalPrivateDNSAddress := clusterv1.MachineAddress{
@@ -966,8 +1302,14 @@ func (s *Service) getInstanceAddresses(instance *ec2.Instance) []clusterv1.Machi
 			addresses = append(addresses, additionalPrivateDNSAddress)
 		}
 
-		// An elastic IP is attached if association is non nil pointer
-		if eni.Association != nil {
+		// A carrier IP is attached to instances in Wavelength Zones instead of a public IP.
+		if eni.Association != nil && eni.Association.CarrierIp != nil {
+			addresses = append(addresses, clusterv1.MachineAddress{
+				Type:    clusterv1.MachineExternalIP,
+				Address: aws.StringValue(eni.Association.CarrierIp),
+			})
+		} else if eni.Association != nil {
+			// An elastic IP is attached if association is non nil pointer
 			publicDNSAddress := clusterv1.MachineAddress{
 				Type:    clusterv1.MachineExternalDNS,
 				Address: aws.StringValue(eni.Association.PublicDnsName),
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -33,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -274,6 +276,448 @@ func TestTerminateInstance(t *testing.T) {
 	}
 }
 
+func TestAdoptInstance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testCases := []struct {
+		name      string
+		instance  *infrav1.Instance
+		expect    func(m *mocks.MockEC2APIMockRecorder)
+		expectErr bool
+	}{
+		{
+			name: "instance matching the spec is tagged",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.CreateTagsWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateTagsInput, _ ...request.Option) (*ec2.CreateTagsOutput, error) {
+						if got := aws.StringValue(input.Resources[0]); got != "i-existing" {
+							t.Fatalf("expected tags on instance %q, got %q", "i-existing", got)
+						}
+						tags := map[string]string{}
+						for _, tag := range input.Tags {
+							tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+						}
+						if tags[infrav1.ClusterTagKey("test-cluster")] != string(infrav1.ResourceLifecycleOwned) {
+							t.Fatalf("expected instance to be tagged as owned by the cluster, got %v", tags)
+						}
+						return &ec2.CreateTagsOutput{}, nil
+					})
+			},
+		},
+		{
+			name: "instance already owned by the cluster is not tagged again",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+				Tags: map[string]string{
+					infrav1.ClusterTagKey("test-cluster"): string(infrav1.ResourceLifecycleOwned),
+					infrav1.MachineNameTagKey:             "default/test1",
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "instance with a different instance type",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "t3.small",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "instance with a different AMI",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-2",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "instance in a different subnet",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-2",
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "instance in a different vpc",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-2",
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "instance owned by another cluster",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+				Tags: map[string]string{
+					infrav1.ClusterTagKey("other-cluster"): string(infrav1.ResourceLifecycleOwned),
+				},
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "instance of the cloud provider of another cluster",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+				Tags: map[string]string{
+					infrav1.ClusterAWSCloudProviderTagKey("other-cluster"): string(infrav1.ResourceLifecycleOwned),
+				},
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "instance of another machine of the cluster",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateRunning,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+				Tags: map[string]string{
+					infrav1.ClusterTagKey("test-cluster"): string(infrav1.ResourceLifecycleOwned),
+					infrav1.MachineNameTagKey:             "default/test2",
+				},
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+		{
+			name: "terminated instance",
+			instance: &infrav1.Instance{
+				ID:       "i-existing",
+				State:    infrav1.InstanceStateTerminated,
+				Type:     "m5.large",
+				ImageID:  "ami-1",
+				SubnetID: "subnet-1",
+				VPCID:    "vpc-1",
+			},
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			}
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test1", Namespace: "default"},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-test1"},
+				Spec: infrav1.AWSMachineSpec{
+					InstanceType: "m5.large",
+					AMI:          infrav1.AMIReference{ID: aws.String("ami-1")},
+					Subnet:       &infrav1.AWSResourceReference{ID: aws.String("subnet-1")},
+					InstanceID:   aws.String("i-existing"),
+				},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: cluster,
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-1"}},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      machine,
+				AWSMachine:   awsMachine,
+				InfraCluster: clusterScope,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create test context: %v", err)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			err = s.AdoptInstance(machineScope, tc.instance)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect error: %v", err)
+			}
+			if !infrav1.Tags(tc.instance.Tags).HasOwned("test-cluster") {
+				t.Fatalf("expected adopted instance to be owned by the cluster, got tags %v", tc.instance.Tags)
+			}
+		})
+	}
+}
+
+func TestSpreadControlPlaneSubnet(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-a", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-b", AvailabilityZone: "us-east-1b"},
+	}
+	controlPlaneInstance := func(name, zone string) *ec2.Instance {
+		return &ec2.Instance{
+			InstanceId: aws.String("i-" + name),
+			Placement:  &ec2.Placement{AvailabilityZone: aws.String(zone)},
+			Tags:       []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		failureDomain *string
+		instances     []*ec2.Instance
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name:      "keeps the subnet if its availability zone is free",
+			instances: []*ec2.Instance{controlPlaneInstance("cp-1", "us-east-1b")},
+			expected:  "subnet-a",
+		},
+		{
+			name:      "ignores the instance of the machine itself",
+			instances: []*ec2.Instance{controlPlaneInstance("aws-test1", "us-east-1a")},
+			expected:  "subnet-a",
+		},
+		{
+			name:      "picks a subnet in a free availability zone",
+			instances: []*ec2.Instance{controlPlaneInstance("cp-1", "us-east-1a")},
+			expected:  "subnet-b",
+		},
+		{
+			name:          "refuses an occupied failure domain",
+			failureDomain: aws.String("us-east-1a"),
+			instances:     []*ec2.Instance{controlPlaneInstance("cp-1", "us-east-1a")},
+			expectErr:     true,
+		},
+		{
+			name:      "refuses if all availability zones are occupied",
+			instances: []*ec2.Instance{controlPlaneInstance("cp-1", "us-east-1a"), controlPlaneInstance("cp-2", "us-east-1b")},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			}
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test1",
+					Labels: map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+				},
+				Spec: clusterv1.MachineSpec{FailureDomain: tc.failureDomain},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-test1"},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: cluster,
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec:          infrav1.NetworkSpec{Subnets: subnets},
+						ControlPlaneAZSpread: infrav1.ControlPlaneAZSpreadPolicyStrict,
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      machine,
+				AWSMachine:   awsMachine,
+				InfraCluster: clusterScope,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock.EXPECT().DescribeInstancesPagesWithContext(context.TODO(), &ec2.DescribeInstancesInput{
+				Filters: []*ec2.Filter{
+					filter.EC2.ClusterOwned("test-cluster"),
+					filter.EC2.ProviderRole("control-plane"),
+					filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped),
+				},
+			}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+				fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: tc.instances}}}, true)
+				return nil
+			})
+
+			s := NewService(clusterScope)
+			s.EC2Client = ec2Mock
+
+			subnetID, err := s.spreadControlPlaneSubnet(machineScope, "subnet-a")
+			if tc.expectErr {
+				g.Expect(errors.Is(err, ErrAvailabilityZoneOccupied)).To(BeTrue())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subnetID).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestFindSubnetEdgeZone(t *testing.T) {
+	subnets := infrav1.Subnets{
+		{ID: "subnet-private-az", AvailabilityZone: "us-east-1a"},
+		{ID: "subnet-public-az", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ID: "subnet-private-lz", AvailabilityZone: "us-east-1-nyc-1a", ZoneType: ptr.To(infrav1.ZoneTypeLocalZone), ParentZoneName: aws.String("us-east-1a")},
+		{ID: "subnet-public-lz", AvailabilityZone: "us-east-1-nyc-1a", ZoneType: ptr.To(infrav1.ZoneTypeLocalZone), ParentZoneName: aws.String("us-east-1a"), IsPublic: true},
+	}
+
+	testCases := []struct {
+		name          string
+		failureDomain *string
+		publicIP      *bool
+		expected      string
+	}{
+		{
+			name:     "machine without failure domain is never placed in an edge zone",
+			expected: "subnet-private-az",
+		},
+		{
+			name:          "machine with an availability zone as failure domain",
+			failureDomain: aws.String("us-east-1a"),
+			expected:      "subnet-private-az",
+		},
+		{
+			name:          "machine with a local zone as failure domain",
+			failureDomain: aws.String("us-east-1-nyc-1a"),
+			expected:      "subnet-private-lz",
+		},
+		{
+			name:          "machine with public IP and a local zone as failure domain",
+			failureDomain: aws.String("us-east-1-nyc-1a"),
+			publicIP:      aws.Bool(true),
+			expected:      "subnet-public-lz",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = clusterv1.AddToScheme(scheme)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+			}
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Name: "test1"},
+				Spec:       clusterv1.MachineSpec{FailureDomain: tc.failureDomain},
+			}
+			awsMachine := &infrav1.AWSMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-test1"},
+				Spec:       infrav1.AWSMachineSpec{PublicIP: tc.publicIP},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client:  client,
+				Cluster: cluster,
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec:       infrav1.AWSClusterSpec{NetworkSpec: infrav1.NetworkSpec{Subnets: subnets}},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:       client,
+				Cluster:      cluster,
+				Machine:      machine,
+				AWSMachine:   awsMachine,
+				InfraCluster: clusterScope,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(clusterScope)
+
+			subnetID, err := s.findSubnet(machineScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(subnetID).To(Equal(tc.expected))
+		})
+	}
+}
+
 func TestCreateInstance(t *testing.T) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -304,6 +748,7 @@ func TestCreateInstance(t *testing.T) {
 		awsCluster    *infrav1.AWSCluster
 		expect        func(m *mocks.MockEC2APIMockRecorder)
 		check         func(instance *infrav1.Instance, err error)
+		checkMachine  func(awsMachine *infrav1.AWSMachine)
 	}{
 		{
 			name: "simple",
@@ -420,9 +865,17 @@ func TestCreateInstance(t *testing.T) {
 					t.Fatalf("did not expect error: %v", err)
 				}
 			},
+			checkMachine: func(awsMachine *infrav1.AWSMachine) {
+				if got, want := ptr.Deref(awsMachine.Spec.ProviderID, ""), "aws:///test-zone-1a/two"; got != want {
+					t.Fatalf("expected providerID %q, got %q", want, got)
+				}
+				if got := ptr.Deref(awsMachine.Spec.InstanceID, ""); got != "two" {
+					t.Fatalf("expected instanceID %q, got %q", "two", got)
+				}
+			},
 		},
 		{
-			name: "with availability zone",
+			name: "sets the providerID from the subnet availability zone when the placement is not reported",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -431,14 +884,13 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1c"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.2xlarge",
+				InstanceType: "m5.large",
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -447,23 +899,11 @@ func TestCreateInstance(t *testing.T) {
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
 								ID:               "subnet-1",
-								AvailabilityZone: "us-east-1a",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-2",
-								AvailabilityZone: "us-east-1b",
-								IsPublic:         false,
-							},
-							infrav1.SubnetSpec{
-								ID:               "subnet-3",
-								AvailabilityZone: "us-east-1c",
+								AvailabilityZone: "test-zone-1b",
 								IsPublic:         false,
 							},
 							infrav1.SubnetSpec{
-								ID:               "subnet-3-public",
-								AvailabilityZone: "us-east-1c",
-								IsPublic:         true,
+								IsPublic: false,
 							},
 						},
 						VPC: infrav1.VPCSpec{
@@ -494,7 +934,7 @@ func TestCreateInstance(t *testing.T) {
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m5.2xlarge"),
+							aws.String("m5.large"),
 						},
 					})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -508,7 +948,7 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
+				m. // TODO: Restore these parameters, but with the tags as well
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -521,9 +961,10 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-3"),
+								SubnetId:       aws.String("subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
+								Placement:      &ec2.Placement{},
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
 									{
 										DeviceName: aws.String("device-1"),
@@ -532,9 +973,6 @@ func TestCreateInstance(t *testing.T) {
 										},
 									},
 								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
 							},
 						},
 					}, nil)
@@ -549,47 +987,387 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
-
-				if instance.SubnetID != "subnet-3" {
-					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
+			},
+			checkMachine: func(awsMachine *infrav1.AWSMachine) {
+				if got, want := ptr.Deref(awsMachine.Spec.ProviderID, ""), "aws:///test-zone-1b/two"; got != want {
+					t.Fatalf("expected providerID %q, got %q", want, got)
 				}
 			},
 		},
 		{
-			name: "when multiple subnets match filters, subnets in the cluster vpc are preferred",
+			name: "retries while the IAM instance profile propagates",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
 				},
 				Spec: clusterv1.MachineSpec{
 					Bootstrap: clusterv1.Bootstrap{
-						DataSecretName: aws.String("bootstrap-data"),
+						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1c"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.2xlarge",
-				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{
-						{
-							Name:   "availability-zone",
-							Values: []string{"us-east-1c"},
-						},
-					},
-				},
-				UncompressedUserData: &isUncompressedFalse,
+				InstanceType:       "m5.large",
+				IAMInstanceProfile: "new-profile",
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-foo",
-						},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.InvalidParameterValue, "Value (new-profile) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name", nil))
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "returns the IAM instance profile error once retries are exhausted",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType:       "m5.large",
+				IAMInstanceProfile: "new-profile",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(awserrors.InvalidParameterValue, "Value (new-profile) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name", nil)).
+					Times(3)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if !awserrors.IsInvalidIAMInstanceProfile(errors.Cause(err)) {
+					t.Fatalf("expected IAM instance profile error, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "with availability zone",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1c"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.2xlarge",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:               "subnet-1",
+								AvailabilityZone: "us-east-1a",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-2",
+								AvailabilityZone: "us-east-1b",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-3",
+								AvailabilityZone: "us-east-1c",
+								IsPublic:         false,
+							},
+							infrav1.SubnetSpec{
+								ID:               "subnet-3-public",
+								AvailabilityZone: "us-east-1c",
+								IsPublic:         true,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.2xlarge"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-3"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-3" {
+					t.Fatalf("expected subnet-3 from availability zone us-east-1c, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "when multiple subnets match filters, subnets in the cluster vpc are preferred",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: aws.String("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1c"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.2xlarge",
+				Subnet: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{
+						{
+							Name:   "availability-zone",
+							Values: []string{"us-east-1c"},
+						},
+					},
+				},
+				UncompressedUserData: &isUncompressedFalse,
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-foo",
+						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
 								ID:               "subnet-1",
@@ -995,62 +1773,219 @@ func TestCreateInstance(t *testing.T) {
 								},
 							},
 							{
-								ResourceType: aws.String("network-interface"),
-								Tags: []*ec2.Tag{
-									{
-										Key:   aws.String("MachineName"),
-										Value: aws.String("/"),
-									},
-									{
-										Key:   aws.String("Name"),
-										Value: aws.String("aws-test1"),
-									},
-									{
-										Key:   aws.String("kubernetes.io/cluster/test1"),
-										Value: aws.String("owned"),
-									},
-									{
-										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
-										Value: aws.String("owned"),
-									},
-									{
-										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
-										Value: aws.String("node"),
+								ResourceType: aws.String("network-interface"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("MachineName"),
+										Value: aws.String("/"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("aws-test1"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test1"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("node"),
+									},
+								},
+							},
+						},
+						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
+						MaxCount: aws.Int64(1),
+						MinCount: aws.Int64(1),
+					}).Return(&ec2.Reservation{
+					Instances: []*ec2.Instance{
+						{
+							State: &ec2.InstanceState{
+								Name: aws.String(ec2.InstanceStateNamePending),
+							},
+							IamInstanceProfile: &ec2.IamInstanceProfile{
+								Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+							},
+							InstanceId:     aws.String("two"),
+							InstanceType:   aws.String("m5.large"),
+							SubnetId:       aws.String("subnet-5"),
+							ImageId:        aws.String("ami-1"),
+							RootDeviceName: aws.String("device-1"),
+							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+								{
+									DeviceName: aws.String("device-1"),
+									Ebs: &ec2.EbsInstanceBlockDevice{
+										VolumeId: aws.String("volume-1"),
+									},
+								},
+							},
+							Placement: &ec2.Placement{
+								AvailabilityZone: &az,
+							},
+						},
+					},
+				}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+
+				if instance.SubnetID != "subnet-5" {
+					t.Fatalf("expected subnet-5 from availability zone us-east-1c, got %q", instance.SubnetID)
+				}
+			},
+		},
+		{
+			name: "with ImageLookupOrg specified at the machine level",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					Version: ptr.To[string]("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				ImageLookupOrg: "test-org-123",
+				InstanceType:   "m6g.large",
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-24.04", "1.16.1")
+				if err != nil {
+					t.Fatalf("Failed to process ami format: %v", err)
+				}
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m6g.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("arm64"),
 									},
 								},
 							},
 						},
-						UserData: aws.String(base64.StdEncoding.EncodeToString(userDataCompressed)),
-						MaxCount: aws.Int64(1),
-						MinCount: aws.Int64(1),
-					}).Return(&ec2.Reservation{
-					Instances: []*ec2.Instance{
-						{
-							State: &ec2.InstanceState{
-								Name: aws.String(ec2.InstanceStateNamePending),
+					}, nil)
+				// verify that the ImageLookupOrg is used when finding AMIs
+				m.
+					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+						Filters: []*ec2.Filter{
+							{
+								Name:   aws.String("owner-id"),
+								Values: []*string{aws.String("test-org-123")},
 							},
-							IamInstanceProfile: &ec2.IamInstanceProfile{
-								Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+							{
+								Name:   aws.String("name"),
+								Values: []*string{aws.String(amiName)},
 							},
-							InstanceId:     aws.String("two"),
-							InstanceType:   aws.String("m5.large"),
-							SubnetId:       aws.String("subnet-5"),
-							ImageId:        aws.String("ami-1"),
-							RootDeviceName: aws.String("device-1"),
-							BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-								{
-									DeviceName: aws.String("device-1"),
-									Ebs: &ec2.EbsInstanceBlockDevice{
-										VolumeId: aws.String("volume-1"),
+							{
+								Name:   aws.String("architecture"),
+								Values: []*string{aws.String("arm64")},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("available")},
+							},
+							{
+								Name:   aws.String("virtualization-type"),
+								Values: []*string{aws.String("hvm")},
+							},
+						},
+					})).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
 									},
 								},
-							},
-							Placement: &ec2.Placement{
-								AvailabilityZone: &az,
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
 							},
 						},
-					},
-				}, nil)
+					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -1062,14 +1997,10 @@ func TestCreateInstance(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
-
-				if instance.SubnetID != "subnet-5" {
-					t.Fatalf("expected subnet-5 from availability zone us-east-1c, got %q", instance.SubnetID)
-				}
 			},
 		},
 		{
-			name: "with ImageLookupOrg specified at the machine level",
+			name: "with ImageLookupOrg specified at the cluster-level",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1082,8 +2013,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				ImageLookupOrg: "test-org-123",
-				InstanceType:   "m6g.large",
+				InstanceType: "m5.large",
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1102,6 +2032,7 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-test",
 						},
 					},
+					ImageLookupOrg: "cluster-level-image-lookup-org",
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -1130,7 +2061,7 @@ func TestCreateInstance(t *testing.T) {
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m6g.large"),
+							aws.String("m5.large"),
 						},
 					})).
 					Return(&ec2.DescribeInstanceTypesOutput{
@@ -1138,7 +2069,7 @@ func TestCreateInstance(t *testing.T) {
 							{
 								ProcessorInfo: &ec2.ProcessorInfo{
 									SupportedArchitectures: []*string{
-										aws.String("arm64"),
+										aws.String("x86_64"),
 									},
 								},
 							},
@@ -1150,7 +2081,7 @@ func TestCreateInstance(t *testing.T) {
 						Filters: []*ec2.Filter{
 							{
 								Name:   aws.String("owner-id"),
-								Values: []*string{aws.String("test-org-123")},
+								Values: []*string{aws.String("cluster-level-image-lookup-org")},
 							},
 							{
 								Name:   aws.String("name"),
@@ -1158,7 +2089,7 @@ func TestCreateInstance(t *testing.T) {
 							},
 							{
 								Name:   aws.String("architecture"),
-								Values: []*string{aws.String("arm64")},
+								Values: []*string{aws.String("x86_64")},
 							},
 							{
 								Name:   aws.String("state"),
@@ -1222,7 +2153,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with ImageLookupOrg specified at the cluster-level",
+			name: "AWSMachine ImageLookupOrg overrides AWSCluster ImageLookupOrg",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1235,7 +2166,8 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType: "m5.large",
+				InstanceType:   "m5.large",
+				ImageLookupOrg: "machine-level-image-lookup-org",
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1303,7 +2235,7 @@ func TestCreateInstance(t *testing.T) {
 						Filters: []*ec2.Filter{
 							{
 								Name:   aws.String("owner-id"),
-								Values: []*string{aws.String("cluster-level-image-lookup-org")},
+								Values: []*string{aws.String("machine-level-image-lookup-org")},
 							},
 							{
 								Name:   aws.String("name"),
@@ -1323,15 +2255,142 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+		{
+			name: "subnet filter and failureDomain defined",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					FailureDomain: aws.String("us-east-1b"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{{
+						Name:   "tag:some-tag",
+						Values: []string{"some-value"},
+					}},
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("filtered-subnet-1"),
+							AvailabilityZone: aws.String("us-east-1b"),
+						}},
+					}, nil)
+				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -1375,7 +2434,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "AWSMachine ImageLookupOrg overrides AWSCluster ImageLookupOrg",
+			name: "with subnet ID that belongs to Cluster",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1384,31 +2443,28 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					Version: ptr.To[string]("v1.16.1"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
-				InstanceType:   "m5.large",
-				ImageLookupOrg: "machine-level-image-lookup-org",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("matching-subnet"),
+				},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						Subnets: infrav1.Subnets{
-							infrav1.SubnetSpec{
-								ID:       "subnet-1",
-								IsPublic: false,
-							},
-							infrav1.SubnetSpec{
-								IsPublic: false,
-							},
-						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
+							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID: "matching-subnet",
+						}},
 					},
-					ImageLookupOrg: "cluster-level-image-lookup-org",
 				},
 				Status: infrav1.AWSClusterStatus{
 					Network: infrav1.NetworkStatus{
@@ -1430,10 +2486,19 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				amiName, err := GenerateAmiName("capa-ami-{{.BaseOS}}-?{{.K8sVersion}}-*", "ubuntu-24.04", "1.16.1")
-				if err != nil {
-					t.Fatalf("Failed to process ami format: %v", err)
-				}
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:         aws.String("matching-subnet"),
+							AvailabilityZone: aws.String("us-east-1b"),
+						}},
+					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -1451,41 +2516,7 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				// verify that the ImageLookupOrg is used when finding AMIs
 				m.
-					DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
-						Filters: []*ec2.Filter{
-							{
-								Name:   aws.String("owner-id"),
-								Values: []*string{aws.String("machine-level-image-lookup-org")},
-							},
-							{
-								Name:   aws.String("name"),
-								Values: []*string{aws.String(amiName)},
-							},
-							{
-								Name:   aws.String("architecture"),
-								Values: []*string{aws.String("x86_64")},
-							},
-							{
-								Name:   aws.String("state"),
-								Values: []*string{aws.String("available")},
-							},
-							{
-								Name:   aws.String("virtualization-type"),
-								Values: []*string{aws.String("hvm")},
-							},
-						},
-					})).
-					Return(&ec2.DescribeImagesOutput{
-						Images: []*ec2.Image{
-							{
-								Name:         aws.String("ami-1"),
-								CreationDate: aws.String("2006-01-02T15:04:05.000Z"),
-							},
-						},
-					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -1498,7 +2529,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("matching-subnet"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1529,7 +2560,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "subnet filter and failureDomain defined",
+			name: "with subnet ID that does not exist",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1538,7 +2569,6 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -1547,10 +2577,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{{
-						Name:   "tag:some-tag",
-						Values: []string{"some-value"},
-					}},
+					ID: aws.String("non-matching-subnet"),
 				},
 			},
 			awsCluster: &infrav1.AWSCluster{
@@ -1560,6 +2587,9 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
+						Subnets: infrav1.Subnets{{
+							ID: "subnet-1",
+						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -1603,15 +2633,77 @@ func TestCreateInstance(t *testing.T) {
 					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
 						Filters: []*ec2.Filter{
 							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"non-matching-subnet"})},
 						},
 					}).
 					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:         aws.String("filtered-subnet-1"),
-							AvailabilityZone: aws.String("us-east-1b"),
-						}},
+						Subnets: []*ec2.Subnet{},
 					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				expectedErrMsg := "failed to run machine \"aws-test1\", no subnets available matching criteria"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				}
+			},
+		},
+		{
+			name: "with subnet ID that does not belong to Cluster",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				AMI: infrav1.AMIReference{
+					ID: aws.String("abc"),
+				},
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					ID: aws.String("matching-subnet"),
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
+						Subnets: infrav1.Subnets{{
+							ID: "subnet-1",
+						}},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
@@ -1625,7 +2717,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("matching-subnet"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1642,6 +2734,35 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId: aws.String("matching-subnet"),
+						}},
+					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -1656,7 +2777,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "with subnet ID that belongs to Cluster",
+			name: "subnet id and failureDomain don't match",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1665,6 +2786,7 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
+					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -1673,7 +2795,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("matching-subnet"),
+					ID: aws.String("subnet-1"),
 				},
 			},
 			awsCluster: &infrav1.AWSCluster{
@@ -1684,7 +2806,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID: "matching-subnet",
+							ID:               "subnet-1",
+							AvailabilityZone: "us-west-1b",
 						}},
 					},
 				},
@@ -1712,77 +2835,46 @@ func TestCreateInstance(t *testing.T) {
 					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
 						Filters: []*ec2.Filter{
 							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"subnet-1"})},
 						},
 					}).
 					Return(&ec2.DescribeSubnetsOutput{
 						Subnets: []*ec2.Subnet{{
-							SubnetId:         aws.String("matching-subnet"),
-							AvailabilityZone: aws.String("us-east-1b"),
+							SubnetId:         aws.String("subnet-1"),
+							AvailabilityZone: aws.String("us-west-1b"),
 						}},
 					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
-							aws.String("m5.large"),
-						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
-							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
-									},
-								},
-							},
-						},
-					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("matching-subnet"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
 									},
 								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
 							},
 						},
 					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
-					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"subnet-1\" availability zone \"us-west-1b\" does not match failure domain \"us-east-1b\""
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "with subnet ID that does not exist",
+			name: "public IP true and failureDomain doesn't have public subnet",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1791,6 +2883,7 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
+					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -1798,9 +2891,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("non-matching-subnet"),
-				},
+				PublicIP:     aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1810,7 +2901,9 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID: "subnet-1",
+							ID:               "private-subnet-1",
+							AvailabilityZone: "us-east-1b",
+							IsPublic:         false,
 						}},
 					},
 				},
@@ -1851,30 +2944,20 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"non-matching-subnet"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{},
-					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", no subnets available matching criteria"
+				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available in availability zone \"us-east-1b\""
 				if err == nil {
 					t.Fatalf("Expected error, but got nil")
 				}
 
 				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "with subnet ID that does not belong to Cluster",
+			name: "public IP true and public subnet ID given",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -1891,8 +2974,9 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("matching-subnet"),
+					ID: aws.String("public-subnet-1"),
 				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -1902,7 +2986,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID: "subnet-1",
+							ID:       "public-subnet-1",
+							IsPublic: true,
 						}},
 					},
 				},
@@ -1926,6 +3011,37 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(true),
+						}},
+					}, nil)
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
@@ -1939,7 +3055,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("matching-subnet"),
+								SubnetId:       aws.String("public-subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -1956,35 +3072,6 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
-						},
-					})).
-					Return(&ec2.DescribeInstanceTypesOutput{
-						InstanceTypes: []*ec2.InstanceTypeInfo{
-							{
-								ProcessorInfo: &ec2.ProcessorInfo{
-									SupportedArchitectures: []*string{
-										aws.String("x86_64"),
-									},
-								},
-							},
-						},
-					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"matching-subnet"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId: aws.String("matching-subnet"),
-						}},
-					}, nil)
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -1999,7 +3086,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "subnet id and failureDomain don't match",
+			name: "public IP true, public subnet ID given and MapPublicIpOnLaunch is false",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2008,7 +3095,6 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -2017,8 +3103,9 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("subnet-1"),
+					ID: aws.String("public-subnet-1"),
 				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2028,8 +3115,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID:               "subnet-1",
-							AvailabilityZone: "us-west-1b",
+							ID:       "public-subnet-1",
+							IsPublic: true,
 						}},
 					},
 				},
@@ -2057,13 +3144,14 @@ func TestCreateInstance(t *testing.T) {
 					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
 						Filters: []*ec2.Filter{
 							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"subnet-1"})},
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
 						},
 					}).
 					Return(&ec2.DescribeSubnetsOutput{
 						Subnets: []*ec2.Subnet{{
-							SubnetId:         aws.String("subnet-1"),
-							AvailabilityZone: aws.String("us-west-1b"),
+							SubnetId:            aws.String("public-subnet-1"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(false),
 						}},
 					}, nil)
 				m.
@@ -2083,20 +3171,65 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
+						if len(in.NetworkInterfaces) == 0 {
+							t.Fatalf("expected a NetworkInterface to be defined")
+						}
+						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
+							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
+						}
+						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
+							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
+						}
+						if in.NetworkInterfaces[0].Groups == nil {
+							t.Fatalf("expected security groups to be set")
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("public-subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"subnet-1\" availability zone \"us-west-1b\" does not match failure domain \"us-east-1b\""
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "public IP true and failureDomain doesn't have public subnet",
+			name: "efa interface type",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2105,28 +3238,31 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
-					FailureDomain: aws.String("us-east-1b"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType: "m5.large",
-				PublicIP:     aws.Bool(true),
+				InstanceType:         "m5.large",
+				NetworkInterfaceType: infrav1.NetworkInterfaceTypeEFAWithENAInterface,
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
 						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
+							ID: "vpc-test",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:               "private-subnet-1",
-							AvailabilityZone: "us-east-1b",
-							IsPublic:         false,
-						}},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2166,20 +3302,62 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
+						if len(in.NetworkInterfaces) == 0 {
+							t.Fatalf("expected a NetworkInterface to be defined")
+						}
+						if in.NetworkInterfaces[0].Groups == nil {
+							t.Fatalf("expected security groups to be set")
+						}
+						if interfaceType := aws.StringValue(in.NetworkInterfaces[0].InterfaceType); interfaceType != "efa" {
+							t.Fatalf("expected interface type to be \"efa\": got %q", interfaceType)
+						}
+					}).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available in availability zone \"us-east-1b\""
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "public IP true and public subnet ID given",
+			name: "public IP true and private subnet ID given",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2196,7 +3374,7 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("public-subnet-1"),
+					ID: aws.String("private-subnet-1"),
 				},
 				PublicIP: aws.Bool(true),
 			},
@@ -2208,8 +3386,8 @@ func TestCreateInstance(t *testing.T) {
 							ID: "vpc-id",
 						},
 						Subnets: infrav1.Subnets{{
-							ID:       "public-subnet-1",
-							IsPublic: true,
+							ID:       "private-subnet-1",
+							IsPublic: false,
 						}},
 					},
 				},
@@ -2237,14 +3415,14 @@ func TestCreateInstance(t *testing.T) {
 					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
 						Filters: []*ec2.Filter{
 							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
+							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"private-subnet-1"})},
 						},
 					}).
 					Return(&ec2.DescribeSubnetsOutput{
 						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
+							SubnetId:            aws.String("private-subnet-1"),
 							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(true),
+							MapPublicIpOnLaunch: aws.Bool(false),
 						}},
 					}, nil)
 				m.
@@ -2264,51 +3442,20 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
-									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
-							},
-						},
-					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
-					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"private-subnet-1\" is a private subnet."
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "public IP true, public subnet ID given and MapPublicIpOnLaunch is false",
+			name: "both public IP and subnet filter defined",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2325,7 +3472,10 @@ func TestCreateInstance(t *testing.T) {
 				},
 				InstanceType: "m5.large",
 				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("public-subnet-1"),
+					Filters: []infrav1.Filter{{
+						Name:   "tag:some-tag",
+						Values: []string{"some-value"},
+					}},
 				},
 				PublicIP: aws.Bool(true),
 			},
@@ -2336,10 +3486,16 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:       "public-subnet-1",
-							IsPublic: true,
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								ID:       "public-subnet-1",
+								IsPublic: true,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2362,20 +3518,6 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"public-subnet-1"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						}},
-					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -2394,21 +3536,20 @@ func TestCreateInstance(t *testing.T) {
 						},
 					}, nil)
 				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
-						if len(in.NetworkInterfaces) == 0 {
-							t.Fatalf("expected a NetworkInterface to be defined")
-						}
-						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
-							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
-						}
-						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
-							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
-						}
-						if in.NetworkInterfaces[0].Groups == nil {
-							t.Fatalf("expected security groups to be set")
-						}
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
+						},
 					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							MapPublicIpOnLaunch: aws.Bool(true),
+						}},
+					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -2451,7 +3592,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "efa interface type",
+			name: "both public IP, subnet filter defined and MapPublicIpOnLaunch is false",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2466,25 +3607,32 @@ func TestCreateInstance(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("abc"),
 				},
-				InstanceType:         "m5.large",
-				NetworkInterfaceType: infrav1.NetworkInterfaceTypeEFAWithENAInterface,
+				InstanceType: "m5.large",
+				Subnet: &infrav1.AWSResourceReference{
+					Filters: []infrav1.Filter{{
+						Name:   "tag:some-tag",
+						Values: []string{"some-value"},
+					}},
+				},
+				PublicIP: aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-id",
+						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "subnet-1",
+								ID:       "private-subnet-1",
 								IsPublic: false,
 							},
 							infrav1.SubnetSpec{
-								IsPublic: false,
+								ID:       "public-subnet-1",
+								IsPublic: true,
 							},
 						},
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-test",
-						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2524,18 +3672,34 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
+						Filters: []*ec2.Filter{
+							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
+							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
+						},
+					}).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{{
+							SubnetId:            aws.String("public-subnet-1"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						}},
+					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
 						if len(in.NetworkInterfaces) == 0 {
 							t.Fatalf("expected a NetworkInterface to be defined")
 						}
+						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
+							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
+						}
+						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
+							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
+						}
 						if in.NetworkInterfaces[0].Groups == nil {
 							t.Fatalf("expected security groups to be set")
 						}
-						if interfaceType := aws.StringValue(in.NetworkInterfaces[0].InterfaceType); interfaceType != "efa" {
-							t.Fatalf("expected interface type to be \"efa\": got %q", interfaceType)
-						}
 					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
@@ -2548,7 +3712,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
+								SubnetId:       aws.String("public-subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -2579,7 +3743,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true and private subnet ID given",
+			name: "public IP true and public subnet exists",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2595,10 +3759,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					ID: aws.String("private-subnet-1"),
-				},
-				PublicIP: aws.Bool(true),
+				PublicIP:     aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2607,10 +3768,16 @@ func TestCreateInstance(t *testing.T) {
 						VPC: infrav1.VPCSpec{
 							ID: "vpc-id",
 						},
-						Subnets: infrav1.Subnets{{
-							ID:       "private-subnet-1",
-							IsPublic: false,
-						}},
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "private-subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								ID:       "public-subnet-1",
+								IsPublic: true,
+							},
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -2633,20 +3800,6 @@ func TestCreateInstance(t *testing.T) {
 				},
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("subnet-id"), Values: aws.StringSlice([]string{"private-subnet-1"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("private-subnet-1"),
-							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						}},
-					}, nil)
 				m.
 					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
 						InstanceTypes: []*string{
@@ -2664,20 +3817,51 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.Reservation{
+						Instances: []*ec2.Instance{
+							{
+								State: &ec2.InstanceState{
+									Name: aws.String(ec2.InstanceStateNamePending),
+								},
+								IamInstanceProfile: &ec2.IamInstanceProfile{
+									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+								},
+								InstanceId:     aws.String("two"),
+								InstanceType:   aws.String("m5.large"),
+								SubnetId:       aws.String("public-subnet-1"),
+								ImageId:        aws.String("ami-1"),
+								RootDeviceName: aws.String("device-1"),
+								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+									{
+										DeviceName: aws.String("device-1"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-1"),
+										},
+									},
+								},
+								Placement: &ec2.Placement{
+									AvailabilityZone: &az,
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\", found 1 subnets matching criteria but post-filtering failed. subnet \"private-subnet-1\" is a private subnet."
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: `%s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "both public IP and subnet filter defined",
+			name: "public IP true and failureDomain is a wavelength zone, associates a carrier IP",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2686,6 +3870,7 @@ func TestCreateInstance(t *testing.T) {
 					Bootstrap: clusterv1.Bootstrap{
 						DataSecretName: ptr.To[string]("bootstrap-data"),
 					},
+					FailureDomain: aws.String("us-east-1-wl1-bos-wlz-1"),
 				},
 			},
 			machineConfig: &infrav1.AWSMachineSpec{
@@ -2693,13 +3878,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{{
-						Name:   "tag:some-tag",
-						Values: []string{"some-value"},
-					}},
-				},
-				PublicIP: aws.Bool(true),
+				PublicIP:     aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2714,8 +3893,10 @@ func TestCreateInstance(t *testing.T) {
 								IsPublic: false,
 							},
 							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
+								ID:               "public-subnet-wl-1",
+								AvailabilityZone: "us-east-1-wl1-bos-wlz-1",
+								ZoneType:         ptr.To(infrav1.ZoneTypeWavelengthZone),
+								IsPublic:         true,
 							},
 						},
 					},
@@ -2757,49 +3938,41 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							MapPublicIpOnLaunch: aws.Bool(true),
-						}},
-					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
+					DoAndReturn(func(_ context.Context, input *ec2.RunInstancesInput, _ ...request.Option) (*ec2.Reservation, error) {
+						if !aws.BoolValue(input.NetworkInterfaces[0].AssociateCarrierIpAddress) || input.NetworkInterfaces[0].AssociatePublicIpAddress != nil {
+							t.Fatalf("expected a carrier IP instead of a public IP, got network interface %v", input.NetworkInterfaces[0])
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("public-subnet-wl-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
 										},
 									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
 								},
 							},
-						},
-					}, nil)
+						}, nil
+					})
 				m.
 					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeNetworkInterfacesOutput{
@@ -2814,7 +3987,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "both public IP, subnet filter defined and MapPublicIpOnLaunch is false",
+			name: "public IP true, public subnet exists and MapPublicIpOnLaunch is false",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2830,13 +4003,7 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				Subnet: &infrav1.AWSResourceReference{
-					Filters: []infrav1.Filter{{
-						Name:   "tag:some-tag",
-						Values: []string{"some-value"},
-					}},
-				},
-				PublicIP: aws.Bool(true),
+				PublicIP:     aws.Bool(true),
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -2894,19 +4061,6 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					DescribeSubnetsWithContext(context.TODO(), &ec2.DescribeSubnetsInput{
-						Filters: []*ec2.Filter{
-							filter.EC2.SubnetStates(ec2.SubnetStatePending, ec2.SubnetStateAvailable),
-							{Name: aws.String("tag:some-tag"), Values: aws.StringSlice([]string{"some-value"})},
-						},
-					}).
-					Return(&ec2.DescribeSubnetsOutput{
-						Subnets: []*ec2.Subnet{{
-							SubnetId:            aws.String("public-subnet-1"),
-							MapPublicIpOnLaunch: aws.Bool(false),
-						}},
-					}, nil)
 				m.
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
 					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
@@ -2965,7 +4119,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true and public subnet exists",
+			name: "public IP true and no public subnet exists",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -2995,10 +4149,6 @@ func TestCreateInstance(t *testing.T) {
 								ID:       "private-subnet-1",
 								IsPublic: false,
 							},
-							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
-							},
 						},
 					},
 				},
@@ -3039,51 +4189,20 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m.
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
-									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
-							},
-						},
-					}, nil)
-				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
-					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available"
+				if err == nil {
+					t.Fatalf("Expected error, but got nil")
+				}
+
+				if !strings.Contains(err.Error(), expectedErrMsg) {
+					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
 				}
 			},
 		},
 		{
-			name: "public IP true, public subnet exists and MapPublicIpOnLaunch is false",
+			name: "with multiple block device mappings",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -3099,25 +4218,27 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				PublicIP:     aws.Bool(true),
+				NonRootVolumes: []infrav1.Volume{{
+					DeviceName: "device-2",
+					Size:       8,
+				}},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
 								IsPublic: false,
 							},
 							infrav1.SubnetSpec{
-								ID:       "public-subnet-1",
-								IsPublic: true,
+								IsPublic: false,
 							},
 						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -3158,21 +4279,10 @@ func TestCreateInstance(t *testing.T) {
 						},
 					}, nil)
 				m.
+					GetEbsEncryptionByDefaultWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(false)}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
 					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Do(func(_ context.Context, in *ec2.RunInstancesInput, _ ...request.Option) {
-						if len(in.NetworkInterfaces) == 0 {
-							t.Fatalf("expected a NetworkInterface to be defined")
-						}
-						if !aws.BoolValue(in.NetworkInterfaces[0].AssociatePublicIpAddress) {
-							t.Fatalf("expected AssociatePublicIpAddress to be set and true")
-						}
-						if subnet := aws.StringValue(in.NetworkInterfaces[0].SubnetId); subnet != "public-subnet-1" {
-							t.Fatalf("expected subnet ID to be \"public-subnet-1\", got %q", subnet)
-						}
-						if in.NetworkInterfaces[0].Groups == nil {
-							t.Fatalf("expected security groups to be set")
-						}
-					}).
 					Return(&ec2.Reservation{
 						Instances: []*ec2.Instance{
 							{
@@ -3184,7 +4294,7 @@ func TestCreateInstance(t *testing.T) {
 								},
 								InstanceId:     aws.String("two"),
 								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("public-subnet-1"),
+								SubnetId:       aws.String("subnet-1"),
 								ImageId:        aws.String("ami-1"),
 								RootDeviceName: aws.String("device-1"),
 								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
@@ -3194,6 +4304,12 @@ func TestCreateInstance(t *testing.T) {
 											VolumeId: aws.String("volume-1"),
 										},
 									},
+									{
+										DeviceName: aws.String("device-2"),
+										Ebs: &ec2.EbsInstanceBlockDevice{
+											VolumeId: aws.String("volume-2"),
+										},
+									},
 								},
 								Placement: &ec2.Placement{
 									AvailabilityZone: &az,
@@ -3215,7 +4331,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 		},
 		{
-			name: "public IP true and no public subnet exists",
+			name: "with EBS encryption by default enabled, volumes are explicitly encrypted",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -3231,21 +4347,27 @@ func TestCreateInstance(t *testing.T) {
 					ID: aws.String("abc"),
 				},
 				InstanceType: "m5.large",
-				PublicIP:     aws.Bool(true),
+				NonRootVolumes: []infrav1.Volume{{
+					DeviceName: "device-2",
+					Size:       8,
+				}},
 			},
 			awsCluster: &infrav1.AWSCluster{
 				ObjectMeta: metav1.ObjectMeta{Name: "test"},
 				Spec: infrav1.AWSClusterSpec{
 					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: "vpc-id",
-						},
 						Subnets: infrav1.Subnets{
 							infrav1.SubnetSpec{
-								ID:       "private-subnet-1",
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
 								IsPublic: false,
 							},
 						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
 					},
 				},
 				Status: infrav1.AWSClusterStatus{
@@ -3283,22 +4405,69 @@ func TestCreateInstance(t *testing.T) {
 									},
 								},
 							},
-						},
+						},
+					}, nil)
+				m.
+					GetEbsEncryptionByDefaultWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(true)}, nil)
+				m.
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input *ec2.RunInstancesInput, requestOptions ...request.Option) (*ec2.Reservation, error) {
+						for _, bdm := range input.BlockDeviceMappings {
+							if !aws.BoolValue(bdm.Ebs.Encrypted) {
+								t.Fatalf("Expected volume %q to be encrypted", aws.StringValue(bdm.DeviceName))
+							}
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+										{
+											DeviceName: aws.String("device-2"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-2"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
 					}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				expectedErrMsg := "failed to run machine \"aws-test1\" with public IP, no public subnets available"
-				if err == nil {
-					t.Fatalf("Expected error, but got nil")
-				}
-
-				if !strings.Contains(err.Error(), expectedErrMsg) {
-					t.Fatalf("Expected error: %s\nInstead got: %s", expectedErrMsg, err.Error())
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
 				}
 			},
 		},
 		{
-			name: "with multiple block device mappings",
+			name: "with EBS encryption by default enabled, volumes with encryption disabled are rejected",
 			machine: &clusterv1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{"set": "node"},
@@ -3317,6 +4486,7 @@ func TestCreateInstance(t *testing.T) {
 				NonRootVolumes: []infrav1.Volume{{
 					DeviceName: "device-2",
 					Size:       8,
+					Encrypted:  aws.Bool(false),
 				}},
 			},
 			awsCluster: &infrav1.AWSCluster{
@@ -3358,11 +4528,7 @@ func TestCreateInstance(t *testing.T) {
 			},
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
 				m.
-					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
-						InstanceTypes: []*string{
-							aws.String("m5.large"),
-						},
-					})).
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Any()).
 					Return(&ec2.DescribeInstanceTypesOutput{
 						InstanceTypes: []*ec2.InstanceTypeInfo{
 							{
@@ -3374,52 +4540,13 @@ func TestCreateInstance(t *testing.T) {
 							},
 						},
 					}, nil)
-				m. // TODO: Restore these parameters, but with the tags as well
-					RunInstancesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.Reservation{
-						Instances: []*ec2.Instance{
-							{
-								State: &ec2.InstanceState{
-									Name: aws.String(ec2.InstanceStateNamePending),
-								},
-								IamInstanceProfile: &ec2.IamInstanceProfile{
-									Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
-								},
-								InstanceId:     aws.String("two"),
-								InstanceType:   aws.String("m5.large"),
-								SubnetId:       aws.String("subnet-1"),
-								ImageId:        aws.String("ami-1"),
-								RootDeviceName: aws.String("device-1"),
-								BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
-									{
-										DeviceName: aws.String("device-1"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-1"),
-										},
-									},
-									{
-										DeviceName: aws.String("device-2"),
-										Ebs: &ec2.EbsInstanceBlockDevice{
-											VolumeId: aws.String("volume-2"),
-										},
-									},
-								},
-								Placement: &ec2.Placement{
-									AvailabilityZone: &az,
-								},
-							},
-						},
-					}, nil)
 				m.
-					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
-					Return(&ec2.DescribeNetworkInterfacesOutput{
-						NetworkInterfaces: []*ec2.NetworkInterface{},
-						NextToken:         nil,
-					}, nil)
+					GetEbsEncryptionByDefaultWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.GetEbsEncryptionByDefaultOutput{EbsEncryptionByDefault: aws.Bool(true)}, nil)
 			},
 			check: func(instance *infrav1.Instance, err error) {
-				if err != nil {
-					t.Fatalf("did not expect error: %v", err)
+				if err == nil {
+					t.Fatalf("expected an error when a volume disables encryption")
 				}
 			},
 		},
@@ -4499,6 +5626,167 @@ func TestCreateInstance(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with secondary network interfaces",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"set": "node"},
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string]("bootstrap-data"),
+					},
+					Version: ptr.To[string]("v1.16.1"),
+				},
+			},
+			machineConfig: &infrav1.AWSMachineSpec{
+				InstanceType: "m5.large",
+				PublicIP:     ptr.To(false),
+				SecondaryNetworkInterfaces: []infrav1.SecondaryNetworkInterface{
+					{
+						DeviceIndex:      1,
+						SubnetID:         "subnet-2",
+						SecurityGroupIDs: []string{"sg-secondary"},
+						Description:      "storage",
+					},
+					{
+						DeviceIndex: 2,
+					},
+				},
+			},
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{
+							infrav1.SubnetSpec{
+								ID:       "subnet-1",
+								IsPublic: false,
+							},
+							infrav1.SubnetSpec{
+								IsPublic: false,
+							},
+						},
+						VPC: infrav1.VPCSpec{
+							ID: "vpc-test",
+						},
+					},
+				},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							infrav1.SecurityGroupControlPlane: {
+								ID: "1",
+							},
+							infrav1.SecurityGroupNode: {
+								ID: "2",
+							},
+							infrav1.SecurityGroupLB: {
+								ID: "3",
+							},
+						},
+						APIServerELB: infrav1.LoadBalancer{
+							DNSName: "test-apiserver.us-east-1.aws",
+						},
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.
+					DescribeInstanceTypesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstanceTypesInput{
+						InstanceTypes: []*string{
+							aws.String("m5.large"),
+						},
+					})).
+					Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{
+							{
+								ProcessorInfo: &ec2.ProcessorInfo{
+									SupportedArchitectures: []*string{
+										aws.String("x86_64"),
+									},
+								},
+							},
+						},
+					}, nil)
+				m.
+					DescribeImagesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeImagesOutput{
+						Images: []*ec2.Image{
+							{
+								Name:         aws.String("ami-1"),
+								CreationDate: aws.String("2011-02-08T17:02:31.000Z"),
+							},
+						},
+					}, nil)
+				m. // TODO: Restore these parameters, but with the tags as well
+					RunInstancesWithContext(context.TODO(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, input *ec2.RunInstancesInput, requestOptions ...request.Option) (*ec2.Reservation, error) {
+						if len(input.NetworkInterfaces) != 3 {
+							t.Fatalf("Expected 3 network interfaces, got %d", len(input.NetworkInterfaces))
+						}
+						if input.NetworkInterfaces[0].AssociatePublicIpAddress != nil {
+							t.Fatal("Expected the public IP option to be unset with multiple network interfaces")
+						}
+						if diff := cmp.Diff(&ec2.InstanceNetworkInterfaceSpecification{
+							DeviceIndex:         aws.Int64(1),
+							SubnetId:            aws.String("subnet-2"),
+							Groups:              aws.StringSlice([]string{"sg-secondary"}),
+							Description:         aws.String("storage"),
+							DeleteOnTermination: aws.Bool(true),
+						}, input.NetworkInterfaces[1]); diff != "" {
+							t.Fatalf("Unexpected secondary network interface: %s", diff)
+						}
+						if diff := cmp.Diff(&ec2.InstanceNetworkInterfaceSpecification{
+							DeviceIndex:         aws.Int64(2),
+							SubnetId:            input.NetworkInterfaces[0].SubnetId,
+							Groups:              input.NetworkInterfaces[0].Groups,
+							DeleteOnTermination: aws.Bool(true),
+						}, input.NetworkInterfaces[2]); diff != "" {
+							t.Fatalf("Unexpected secondary network interface with defaults: %s", diff)
+						}
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{
+								{
+									State: &ec2.InstanceState{
+										Name: aws.String(ec2.InstanceStateNamePending),
+									},
+									IamInstanceProfile: &ec2.IamInstanceProfile{
+										Arn: aws.String("arn:aws:iam::123456789012:instance-profile/foo"),
+									},
+									InstanceId:     aws.String("two"),
+									InstanceType:   aws.String("m5.large"),
+									SubnetId:       aws.String("subnet-1"),
+									ImageId:        aws.String("ami-1"),
+									RootDeviceName: aws.String("device-1"),
+									BlockDeviceMappings: []*ec2.InstanceBlockDeviceMapping{
+										{
+											DeviceName: aws.String("device-1"),
+											Ebs: &ec2.EbsInstanceBlockDevice{
+												VolumeId: aws.String("volume-1"),
+											},
+										},
+									},
+									Placement: &ec2.Placement{
+										AvailabilityZone: &az,
+									},
+								},
+							},
+						}, nil
+					})
+				m.
+					DescribeNetworkInterfacesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeNetworkInterfacesOutput{
+						NetworkInterfaces: []*ec2.NetworkInterface{},
+						NextToken:         nil,
+					}, nil)
+			},
+			check: func(instance *infrav1.Instance, err error) {
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
 		{
 			name: "expect to use the cluster level ssh key name when no machine key name is provided",
 			machine: &clusterv1.Machine{
@@ -5719,9 +7007,13 @@ func TestCreateInstance(t *testing.T) {
 
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
+			s.InstanceProfilePropagationBackoff = wait.Backoff{Duration: time.Millisecond, Steps: 3}
 
 			instance, err := s.CreateInstance(machineScope, data, "")
 			tc.check(instance, err)
+			if tc.checkMachine != nil {
+				tc.checkMachine(machineScope.AWSMachine)
+			}
 		})
 	}
 }
@@ -6181,6 +7473,82 @@ func mockedGetPrivateDNSDomainNameFromDHCPOptionsEmptyCalls(m *mocks.MockEC2APIM
 	}, nil)
 }
 
+func TestGetInstanceAddresses(t *testing.T) {
+	testsCases := []struct {
+		name              string
+		networkInterfaces []*ec2.InstanceNetworkInterface
+		expectedAddresses []clusterv1.MachineAddress
+	}{
+		{
+			name: "IPv4 only instance",
+			networkInterfaces: []*ec2.InstanceNetworkInterface{
+				{
+					PrivateIpAddress: aws.String("10.0.0.10"),
+					PrivateDnsName:   aws.String("ip-10-0-0-10.ec2.internal"),
+				},
+			},
+			expectedAddresses: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-10.ec2.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.10"},
+			},
+		},
+		{
+			name: "dual-stack instance",
+			networkInterfaces: []*ec2.InstanceNetworkInterface{
+				{
+					PrivateIpAddress: aws.String("10.0.0.10"),
+					PrivateDnsName:   aws.String("ip-10-0-0-10.ec2.internal"),
+					Ipv6Addresses: []*ec2.InstanceIpv6Address{
+						{Ipv6Address: aws.String("2001:db8:1234:1a00::10")},
+					},
+				},
+			},
+			expectedAddresses: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-10.ec2.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.10"},
+				{Type: clusterv1.MachineInternalIP, Address: "2001:db8:1234:1a00::10"},
+			},
+		},
+		{
+			name: "instance with a carrier IP",
+			networkInterfaces: []*ec2.InstanceNetworkInterface{
+				{
+					PrivateIpAddress: aws.String("10.0.0.10"),
+					PrivateDnsName:   aws.String("ip-10-0-0-10.ec2.internal"),
+					Association: &ec2.InstanceNetworkInterfaceAssociation{
+						CarrierIp: aws.String("155.146.0.10"),
+					},
+				},
+			},
+			expectedAddresses: []clusterv1.MachineAddress{
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-10.ec2.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.10"},
+				{Type: clusterv1.MachineExternalIP, Address: "155.146.0.10"},
+			},
+		},
+	}
+	for _, tc := range testsCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scheme, err := setupScheme()
+			g.Expect(err).ToNot(HaveOccurred())
+
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			cs, err := scope.NewClusterScope(
+				scope.ClusterScopeParams{
+					Client:     client,
+					Cluster:    &clusterv1.Cluster{},
+					AWSCluster: &infrav1.AWSCluster{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+				})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ec2Svc := NewService(cs)
+			addresses := ec2Svc.getInstanceAddresses(&ec2.Instance{NetworkInterfaces: tc.networkInterfaces})
+			g.Expect(addresses).To(Equal(tc.expectedAddresses))
+		})
+	}
+}
+
 func TestGetCapacityReservationSpecification(t *testing.T) {
 	mockCapacityReservationID := "cr-123"
 	mockCapacityReservationIDPtr := &mockCapacityReservationID
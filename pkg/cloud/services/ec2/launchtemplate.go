@@ -68,6 +68,13 @@ func (s *Service) ReconcileLaunchTemplate(
 		record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
 		return err
 	}
+	if scope.UsesEKSSelectedAMI() {
+		bootstrapData, err = userdata.BottlerocketUserData(bootstrapData)
+		if err != nil {
+			record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
+			return err
+		}
+	}
 	bootstrapDataHash := userdata.ComputeHash(bootstrapData)
 
 	scope.Info("checking for existing launch template")
@@ -132,7 +139,7 @@ func (s *Service) ReconcileLaunchTemplate(
 		return err
 	}
 
-	amiChanged := *imageID != *launchTemplate.AMI.ID
+	amiChanged := aws.StringValue(imageID) != aws.StringValue(launchTemplate.AMI.ID)
 
 	// `launchTemplateUserDataSecretKey` can be nil since it comes from a tag on the launch template
 	// which may not exist in older launch templates created by older CAPA versions.
@@ -516,6 +523,19 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	}
 	data.SecurityGroupIds = append(data.SecurityGroupIds, aws.StringSlice(securityGroupIDs)...)
 
+	// The interface type can only be set on a network interface specification, which must then carry
+	// the security groups of the instances as well.
+	if lt.NetworkInterfaceType != "" {
+		data.NetworkInterfaces = []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			{
+				DeviceIndex:   aws.Int64(0),
+				InterfaceType: aws.String(string(lt.NetworkInterfaceType)),
+				Groups:        data.SecurityGroupIds,
+			},
+		}
+		data.SecurityGroupIds = nil
+	}
+
 	// set the AMI ID
 	data.ImageId = imageID
 
@@ -529,7 +549,13 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 	blockDeviceMappings := []*ec2.LaunchTemplateBlockDeviceMappingRequest{}
 
 	// Set up root volume
-	if lt.RootVolume != nil {
+	if lt.RootVolume != nil && data.ImageId == nil {
+		// The device name of the root volume can't be looked up without an AMI.
+		if lt.RootVolume.DeviceName == "" {
+			return nil, errors.New("rootVolume.deviceName must be set if the AMI is selected by EKS")
+		}
+		blockDeviceMappings = append(blockDeviceMappings, volumeToLaunchTemplateBlockDeviceMappingRequest(lt.RootVolume))
+	} else if lt.RootVolume != nil {
 		rootDeviceName, err := s.checkRootVolume(lt.RootVolume, *data.ImageId)
 		if err != nil {
 			return nil, err
@@ -728,7 +754,16 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
-	for _, id := range v.SecurityGroupIds {
+	securityGroupIDs := v.SecurityGroupIds
+	for _, ni := range v.NetworkInterfaces {
+		// The primary network interface is only specified when its interface type is set.
+		if aws.Int64Value(ni.DeviceIndex) == 0 {
+			i.NetworkInterfaceType = infrav1.NetworkInterfaceType(aws.StringValue(ni.InterfaceType))
+			securityGroupIDs = append(securityGroupIDs, ni.Groups...)
+		}
+	}
+
+	for _, id := range securityGroupIDs {
 		// FIXME(dlipovetsky): This will include the core security groups as well, making the
 		// "Additional" a bit dishonest. However, including the core groups drastically simplifies
 		// comparison with the incoming security groups.
@@ -778,6 +813,10 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
+	if incoming.NetworkInterfaceType != existing.NetworkInterfaceType {
+		return true, nil
+	}
+
 	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
@@ -807,6 +846,10 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*string, error) {
 	lt := scope.GetLaunchTemplate()
 
+	if scope.UsesEKSSelectedAMI() {
+		return nil, nil
+	}
+
 	if lt.AMI.ID != nil {
 		return lt.AMI.ID, nil
 	}
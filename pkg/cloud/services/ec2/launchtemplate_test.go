@@ -407,6 +407,41 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: &types.NamespacedName{Namespace: "bootstrap-secret-ns", Name: "bootstrap-secret"},
 		},
+		{
+			name: "elastic fabric adapter",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId:      aws.String("foo-image"),
+					InstanceType: aws.String("p4d.24xlarge"),
+					NetworkInterfaces: []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex:   aws.Int64(0),
+							InterfaceType: aws.String("efa"),
+							Groups:        []*string{aws.String("sg-111"), aws.String("sg-999")},
+						},
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				InstanceType:         "p4d.24xlarge",
+				VersionNumber:        aws.Int64(1),
+				NetworkInterfaceType: infrav1.NetworkInterfaceTypeEFAWithENAInterface,
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-999")},
+				},
+			},
+			wantHash:          testUserDataHash,
+			wantDataSecretKey: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -544,6 +579,15 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			want:     true,
 			wantErr:  false,
 		},
+		{
+			name: "new network interface type",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NetworkInterfaceType: infrav1.NetworkInterfaceTypeEFAWithENAInterface,
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+			wantErr:  false,
+		},
 		{
 			name:     "new launch template instance metadata options, removing IMDSv2 requirement",
 			incoming: &expinfrav1.AWSLaunchTemplate{},
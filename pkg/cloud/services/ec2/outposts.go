@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateOutpostInstanceTypes returns an error if one of the given instance types is not available on the Outpost.
+func ValidateOutpostInstanceTypes(client ec2iface.EC2API, outpostARN string, instanceTypes []string) error {
+	if len(instanceTypes) == 0 {
+		return nil
+	}
+
+	available := sets.New[string]()
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeOutpost),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("location"),
+				Values: aws.StringSlice([]string{outpostARN}),
+			},
+			{
+				Name:   aws.String("instance-type"),
+				Values: aws.StringSlice(instanceTypes),
+			},
+		},
+	}
+	if err := client.DescribeInstanceTypeOfferingsPagesWithContext(context.TODO(), input, func(out *ec2.DescribeInstanceTypeOfferingsOutput, _ bool) bool {
+		for _, offering := range out.InstanceTypeOfferings {
+			available.Insert(aws.StringValue(offering.InstanceType))
+		}
+		return true
+	}); err != nil {
+		return errors.Wrapf(err, "failed to describe the instance types available on Outpost %q", outpostARN)
+	}
+
+	for _, instanceType := range instanceTypes {
+		if !available.Has(instanceType) {
+			return errors.Errorf("instance type %q is not available on Outpost %q", instanceType, outpostARN)
+		}
+	}
+
+	return nil
+}
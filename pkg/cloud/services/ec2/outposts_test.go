@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ec2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestValidateOutpostInstanceTypes(t *testing.T) {
+	outpostARN := "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"
+
+	testCases := []struct {
+		name          string
+		instanceTypes []string
+		offerings     []string
+		describeErr   error
+		expectErr     bool
+	}{
+		{
+			name:          "all instance types are available",
+			instanceTypes: []string{"m5.large", "c5.xlarge"},
+			offerings:     []string{"m5.large", "c5.xlarge"},
+		},
+		{
+			name:          "an instance type is not available",
+			instanceTypes: []string{"m5.large", "m6i.large"},
+			offerings:     []string{"m5.large"},
+			expectErr:     true,
+		},
+		{
+			name:          "describing the offerings fails",
+			instanceTypes: []string{"m5.large"},
+			describeErr:   errors.New("unauthorized"),
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			ec2Mock.EXPECT().DescribeInstanceTypeOfferingsPagesWithContext(context.TODO(), &ec2.DescribeInstanceTypeOfferingsInput{
+				LocationType: aws.String(ec2.LocationTypeOutpost),
+				Filters: []*ec2.Filter{
+					{Name: aws.String("location"), Values: aws.StringSlice([]string{outpostARN})},
+					{Name: aws.String("instance-type"), Values: aws.StringSlice(tc.instanceTypes)},
+				},
+			}, gomock.Any()).DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool, _ ...request.Option) error {
+				out := &ec2.DescribeInstanceTypeOfferingsOutput{}
+				for _, instanceType := range tc.offerings {
+					out.InstanceTypeOfferings = append(out.InstanceTypeOfferings, &ec2.InstanceTypeOffering{
+						InstanceType: aws.String(instanceType),
+						LocationType: aws.String(ec2.LocationTypeOutpost),
+						Location:     aws.String(outpostARN),
+					})
+				}
+				fn(out, true)
+				return tc.describeErr
+			})
+
+			err := ValidateOutpostInstanceTypes(ec2Mock, outpostARN, tc.instanceTypes)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
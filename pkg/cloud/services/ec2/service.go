@@ -18,8 +18,11 @@ limitations under the License.
 package ec2
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/network"
@@ -35,6 +38,22 @@ type Service struct {
 
 	// SSMClient is used to look up the official EKS AMI ID
 	SSMClient ssmiface.SSMAPI
+
+	// InstanceProfilePropagationBackoff controls how long RunInstances is retried when EC2
+	// reports that the IAM instance profile is not usable yet, e.g. right after its creation.
+	InstanceProfilePropagationBackoff wait.Backoff
+}
+
+// DefaultInstanceProfilePropagationBackoff returns the backoff used when waiting for a newly
+// created IAM instance profile to propagate. It is kept short, ~7s in total, so that the worker
+// isn't blocked for long. The machine is requeued if the instance profile is still not usable.
+func DefaultInstanceProfilePropagationBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: time.Second,
+		Factor:   2,
+		Steps:    4,
+		Jitter:   0.2,
+	}
 }
 
 // NewService returns a new service given the ec2 api client.
@@ -44,5 +63,7 @@ func NewService(clusterScope scope.EC2Scope) *Service {
 		EC2Client:  scope.NewEC2Client(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		SSMClient:  scope.NewSSMClient(clusterScope, clusterScope, clusterScope, clusterScope.InfraCluster()),
 		netService: network.NewService(clusterScope.(scope.NetworkScope)),
+
+		InstanceProfilePropagationBackoff: DefaultInstanceProfilePropagationBackoff(),
 	}
 }
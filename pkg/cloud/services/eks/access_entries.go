@@ -0,0 +1,328 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// accessEntriesEnabled returns whether the current authentication mode of the cluster allows access entries.
+func (s *Service) accessEntriesEnabled() bool {
+	mode := s.scope.ControlPlane.Status.AuthenticationMode
+	return mode == ekscontrolplanev1.EKSAuthenticationModeAPI || mode == ekscontrolplanev1.EKSAuthenticationModeAPIAndConfigMap
+}
+
+// reconcileAccessConfig updates the authentication mode of the EKS cluster if it differs from the spec.
+// The authentication mode is left as is if the spec doesn't configure it. The webhook only allows changing
+// the authentication mode to the next one EKS allows, so the update is applied as is.
+func (s *Service) reconcileAccessConfig(accessConfig *eks.AccessConfigResponse) error {
+	if s.scope.ControlPlane.Spec.AccessConfig == nil {
+		return nil
+	}
+
+	desiredMode := string(s.scope.ControlPlane.Spec.AccessConfig.AuthenticationMode)
+	if accessConfig != nil && aws.StringValue(accessConfig.AuthenticationMode) == desiredMode {
+		return nil
+	}
+
+	input := &eks.UpdateClusterConfigInput{
+		Name: aws.String(s.scope.KubernetesClusterName()),
+		AccessConfig: &eks.UpdateAccessConfigRequest{
+			AuthenticationMode: aws.String(desiredMode),
+		},
+	}
+	if _, err := s.EKSClient.UpdateClusterConfig(input); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSAuthenticationMode", "Failed to update authentication mode of EKS control plane to %s: %v", desiredMode, err)
+		return errors.Wrapf(err, "failed to update authentication mode of EKS cluster to %s", desiredMode)
+	}
+	record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEKSAuthenticationMode", "Initiated update of authentication mode of EKS control plane %s to %s", s.scope.KubernetesClusterName(), desiredMode)
+
+	return nil
+}
+
+// reconcileAccessEntries creates, updates and deletes the access entries of the EKS cluster and their associated
+// access policies to match the spec. The access entries are compared with the ones last applied, which are
+// recorded in the status, so only the access entries that changed are described and updated. Only access
+// entries that were part of the spec are deleted, so the entries EKS creates for node roles or the cluster
+// creator are left untouched.
+func (s *Service) reconcileAccessEntries(ctx context.Context) error {
+	s.scope.Debug("Reconciling EKS access entries")
+
+	current, err := s.listAccessEntries(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list access entries")
+	}
+
+	applied := map[string]ekscontrolplanev1.AccessEntry{}
+	for _, entry := range s.scope.ControlPlane.Status.AccessEntries {
+		applied[entry.PrincipalARN] = entry
+	}
+	defer func() {
+		s.scope.ControlPlane.Status.AccessEntries = nil
+		for _, entry := range applied {
+			s.scope.ControlPlane.Status.AccessEntries = append(s.scope.ControlPlane.Status.AccessEntries, entry)
+		}
+		slices.SortFunc(s.scope.ControlPlane.Status.AccessEntries, func(a, b ekscontrolplanev1.AccessEntry) int {
+			return strings.Compare(a.PrincipalARN, b.PrincipalARN)
+		})
+	}()
+
+	desired := map[string]bool{}
+	for _, entry := range s.scope.ControlPlane.Spec.AccessEntries {
+		desired[entry.PrincipalARN] = true
+		exists := slices.Contains(current, entry.PrincipalARN)
+		if last, ok := applied[entry.PrincipalARN]; ok && exists && accessEntriesEqual(last, entry) {
+			continue
+		}
+		if err := s.reconcileAccessEntry(ctx, entry, exists); err != nil {
+			return errors.Wrapf(err, "failed to reconcile access entry for %s", entry.PrincipalARN)
+		}
+		applied[entry.PrincipalARN] = entry
+	}
+
+	for principalARN := range applied {
+		if desired[principalARN] {
+			continue
+		}
+		if slices.Contains(current, principalARN) {
+			if err := s.deleteAccessEntry(ctx, principalARN); err != nil {
+				return errors.Wrapf(err, "failed to delete access entry for %s", principalARN)
+			}
+		}
+		delete(applied, principalARN)
+	}
+
+	return nil
+}
+
+// listAccessEntries returns the principal ARNs of the access entries of the EKS cluster.
+func (s *Service) listAccessEntries(ctx context.Context) ([]string, error) {
+	var principalARNs []string
+	input := &eks.ListAccessEntriesInput{ClusterName: aws.String(s.scope.KubernetesClusterName())}
+	if err := s.EKSClient.ListAccessEntriesPagesWithContext(ctx, input, func(out *eks.ListAccessEntriesOutput, _ bool) bool {
+		principalARNs = append(principalARNs, aws.StringValueSlice(out.AccessEntries)...)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return principalARNs, nil
+}
+
+func (s *Service) reconcileAccessEntry(ctx context.Context, entry ekscontrolplanev1.AccessEntry, exists bool) error {
+	if !exists {
+		if err := s.createAccessEntry(ctx, entry); err != nil {
+			return err
+		}
+		return s.reconcileAccessPolicies(ctx, entry)
+	}
+
+	out, err := s.EKSClient.DescribeAccessEntryWithContext(ctx, &eks.DescribeAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe access entry")
+	}
+	current := out.AccessEntry
+
+	// The type of an access entry can't be updated, so the entry is created again with the new type.
+	if aws.StringValue(current.Type) != string(accessEntryType(entry)) {
+		if err := s.deleteAccessEntry(ctx, entry.PrincipalARN); err != nil {
+			return err
+		}
+		if err := s.createAccessEntry(ctx, entry); err != nil {
+			return err
+		}
+		return s.reconcileAccessPolicies(ctx, entry)
+	}
+
+	groupsChanged := !stringSetsEqual(aws.StringValueSlice(current.KubernetesGroups), entry.KubernetesGroups)
+	usernameChanged := entry.Username != "" && entry.Username != aws.StringValue(current.Username)
+	if groupsChanged || usernameChanged {
+		input := &eks.UpdateAccessEntryInput{
+			ClusterName:      aws.String(s.scope.KubernetesClusterName()),
+			PrincipalArn:     aws.String(entry.PrincipalARN),
+			KubernetesGroups: aws.StringSlice(entry.KubernetesGroups),
+		}
+		if entry.Username != "" {
+			input.Username = aws.String(entry.Username)
+		}
+		if _, err := s.EKSClient.UpdateAccessEntryWithContext(ctx, input); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSAccessEntry", "Failed to update access entry for %s: %v", entry.PrincipalARN, err)
+			return errors.Wrap(err, "failed to update access entry")
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSAccessEntry", "Updated access entry for %s", entry.PrincipalARN)
+	}
+
+	return s.reconcileAccessPolicies(ctx, entry)
+}
+
+func (s *Service) createAccessEntry(ctx context.Context, entry ekscontrolplanev1.AccessEntry) error {
+	tags := map[string]*string{}
+	for k, v := range s.scope.AdditionalTags() {
+		tags[k] = aws.String(v)
+	}
+	tags[infrav1.ClusterTagKey(s.scope.Name())] = aws.String(string(infrav1.ResourceLifecycleOwned))
+
+	input := &eks.CreateAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+		Type:         aws.String(string(accessEntryType(entry))),
+		Tags:         tags,
+	}
+	if len(entry.KubernetesGroups) > 0 {
+		input.KubernetesGroups = aws.StringSlice(entry.KubernetesGroups)
+	}
+	if entry.Username != "" {
+		input.Username = aws.String(entry.Username)
+	}
+
+	if _, err := s.EKSClient.CreateAccessEntryWithContext(ctx, input); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSAccessEntry", "Failed to create access entry for %s: %v", entry.PrincipalARN, err)
+		return errors.Wrap(err, "failed to create access entry")
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSAccessEntry", "Created access entry for %s", entry.PrincipalARN)
+
+	return nil
+}
+
+func (s *Service) deleteAccessEntry(ctx context.Context, principalARN string) error {
+	if _, err := s.EKSClient.DeleteAccessEntryWithContext(ctx, &eks.DeleteAccessEntryInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(principalARN),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedDeleteEKSAccessEntry", "Failed to delete access entry for %s: %v", principalARN, err)
+		return errors.Wrap(err, "failed to delete access entry")
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEKSAccessEntry", "Deleted access entry for %s", principalARN)
+
+	return nil
+}
+
+// reconcileAccessPolicies associates the access policies of the spec with the access entry, updating their
+// access scope if needed, and disassociates any other access policy.
+func (s *Service) reconcileAccessPolicies(ctx context.Context, entry ekscontrolplanev1.AccessEntry) error {
+	if accessEntryType(entry) != ekscontrolplanev1.AccessEntryTypeStandard {
+		return nil
+	}
+
+	associated := map[string]*eks.AccessScope{}
+	input := &eks.ListAssociatedAccessPoliciesInput{
+		ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+		PrincipalArn: aws.String(entry.PrincipalARN),
+	}
+	if err := s.EKSClient.ListAssociatedAccessPoliciesPagesWithContext(ctx, input, func(out *eks.ListAssociatedAccessPoliciesOutput, _ bool) bool {
+		for _, policy := range out.AssociatedAccessPolicies {
+			associated[aws.StringValue(policy.PolicyArn)] = policy.AccessScope
+		}
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "failed to list associated access policies")
+	}
+
+	desired := map[string]bool{}
+	for _, policy := range entry.AccessPolicies {
+		desired[policy.PolicyARN] = true
+		if scope, ok := associated[policy.PolicyARN]; ok && accessScopeEqual(scope, policy.AccessScope) {
+			continue
+		}
+
+		accessScope := &eks.AccessScope{Type: aws.String(string(policy.AccessScope.Type))}
+		if len(policy.AccessScope.Namespaces) > 0 {
+			accessScope.Namespaces = aws.StringSlice(policy.AccessScope.Namespaces)
+		}
+		// Associating an already associated access policy replaces its access scope.
+		if _, err := s.EKSClient.AssociateAccessPolicyWithContext(ctx, &eks.AssociateAccessPolicyInput{
+			ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+			PolicyArn:    aws.String(policy.PolicyARN),
+			AccessScope:  accessScope,
+		}); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedAssociateEKSAccessPolicy", "Failed to associate access policy %s with access entry for %s: %v", policy.PolicyARN, entry.PrincipalARN, err)
+			return errors.Wrapf(err, "failed to associate access policy %s", policy.PolicyARN)
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulAssociateEKSAccessPolicy", "Associated access policy %s with access entry for %s", policy.PolicyARN, entry.PrincipalARN)
+	}
+
+	for policyARN := range associated {
+		if desired[policyARN] {
+			continue
+		}
+		if _, err := s.EKSClient.DisassociateAccessPolicyWithContext(ctx, &eks.DisassociateAccessPolicyInput{
+			ClusterName:  aws.String(s.scope.KubernetesClusterName()),
+			PrincipalArn: aws.String(entry.PrincipalARN),
+			PolicyArn:    aws.String(policyARN),
+		}); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedDisassociateEKSAccessPolicy", "Failed to disassociate access policy %s from access entry for %s: %v", policyARN, entry.PrincipalARN, err)
+			return errors.Wrapf(err, "failed to disassociate access policy %s", policyARN)
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulDisassociateEKSAccessPolicy", "Disassociated access policy %s from access entry for %s", policyARN, entry.PrincipalARN)
+	}
+
+	return nil
+}
+
+// accessEntryType returns the type of the access entry, which defaults to STANDARD.
+func accessEntryType(entry ekscontrolplanev1.AccessEntry) ekscontrolplanev1.AccessEntryType {
+	if entry.Type == "" {
+		return ekscontrolplanev1.AccessEntryTypeStandard
+	}
+	return entry.Type
+}
+
+// accessEntriesEqual returns whether two access entries of the spec configure the same access entry and access policies.
+func accessEntriesEqual(a, b ekscontrolplanev1.AccessEntry) bool {
+	if accessEntryType(a) != accessEntryType(b) || a.Username != b.Username ||
+		!stringSetsEqual(a.KubernetesGroups, b.KubernetesGroups) || len(a.AccessPolicies) != len(b.AccessPolicies) {
+		return false
+	}
+	for _, policy := range a.AccessPolicies {
+		i := slices.IndexFunc(b.AccessPolicies, func(p ekscontrolplanev1.AccessPolicyReference) bool {
+			return p.PolicyARN == policy.PolicyARN
+		})
+		if i < 0 || b.AccessPolicies[i].AccessScope.Type != policy.AccessScope.Type ||
+			!stringSetsEqual(b.AccessPolicies[i].AccessScope.Namespaces, policy.AccessScope.Namespaces) {
+			return false
+		}
+	}
+	return true
+}
+
+func accessScopeEqual(current *eks.AccessScope, desired ekscontrolplanev1.AccessScope) bool {
+	if current == nil {
+		return false
+	}
+	return aws.StringValue(current.Type) == string(desired.Type) &&
+		stringSetsEqual(aws.StringValueSlice(current.Namespaces), desired.Namespaces)
+}
+
+func stringSetsEqual(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
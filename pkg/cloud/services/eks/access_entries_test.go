@@ -0,0 +1,283 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileAccessEntries(t *testing.T) {
+	const (
+		clusterName = "cluster-test"
+		adminARN    = "arn:aws:iam::123456789012:role/admin"
+		devARN      = "arn:aws:iam::123456789012:role/dev"
+		nodeARN     = "arn:aws:iam::123456789012:role/node"
+		adminPolicy = "arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"
+		viewPolicy  = "arn:aws:eks::aws:cluster-access-policy/AmazonEKSViewPolicy"
+		ownedTagKey = "sigs.k8s.io/cluster-api-provider-aws/cluster/capi-name"
+	)
+
+	listEntries := func(m *mock_eksiface.MockEKSAPIMockRecorder, principalARNs ...string) {
+		m.ListAccessEntriesPagesWithContext(gomock.Any(), &eks.ListAccessEntriesInput{ClusterName: aws.String(clusterName)}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListAccessEntriesInput, fn func(*eks.ListAccessEntriesOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListAccessEntriesOutput{AccessEntries: aws.StringSlice(principalARNs)}, true)
+				return nil
+			})
+	}
+	describeEntry := func(m *mock_eksiface.MockEKSAPIMockRecorder, entry *eks.AccessEntry) {
+		m.DescribeAccessEntryWithContext(gomock.Any(), &eks.DescribeAccessEntryInput{ClusterName: aws.String(clusterName), PrincipalArn: entry.PrincipalArn}).
+			Return(&eks.DescribeAccessEntryOutput{AccessEntry: entry}, nil)
+	}
+	listPolicies := func(m *mock_eksiface.MockEKSAPIMockRecorder, principalARN string, policies ...*eks.AssociatedAccessPolicy) {
+		m.ListAssociatedAccessPoliciesPagesWithContext(gomock.Any(), &eks.ListAssociatedAccessPoliciesInput{ClusterName: aws.String(clusterName), PrincipalArn: aws.String(principalARN)}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListAssociatedAccessPoliciesInput, fn func(*eks.ListAssociatedAccessPoliciesOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListAssociatedAccessPoliciesOutput{AssociatedAccessPolicies: policies}, true)
+				return nil
+			})
+	}
+
+	adminEntry := ekscontrolplanev1.AccessEntry{
+		PrincipalARN:   adminARN,
+		AccessPolicies: []ekscontrolplanev1.AccessPolicyReference{{PolicyARN: adminPolicy, AccessScope: ekscontrolplanev1.AccessScope{Type: ekscontrolplanev1.AccessScopeTypeCluster}}},
+	}
+
+	tests := []struct {
+		name            string
+		accessEntries   []ekscontrolplanev1.AccessEntry
+		appliedEntries  []ekscontrolplanev1.AccessEntry
+		expect          func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedApplied []ekscontrolplanev1.AccessEntry
+	}{
+		{
+			name:          "creates a missing access entry and associates its access policy",
+			accessEntries: []ekscontrolplanev1.AccessEntry{adminEntry},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				// The access entry of the node role isn't part of the spec and is left as is.
+				listEntries(m, nodeARN)
+				m.CreateAccessEntryWithContext(gomock.Any(), &eks.CreateAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(adminARN),
+					Type:         aws.String("STANDARD"),
+					Tags:         map[string]*string{ownedTagKey: aws.String("owned")},
+				}).Return(&eks.CreateAccessEntryOutput{}, nil)
+				listPolicies(m, adminARN)
+				m.AssociateAccessPolicyWithContext(gomock.Any(), &eks.AssociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(adminARN),
+					PolicyArn:    aws.String(adminPolicy),
+					AccessScope:  &eks.AccessScope{Type: aws.String("cluster")},
+				}).Return(&eks.AssociateAccessPolicyOutput{}, nil)
+			},
+			expectedApplied: []ekscontrolplanev1.AccessEntry{adminEntry},
+		},
+		{
+			name:           "leaves an access entry unchanged since it was last applied as is",
+			accessEntries:  []ekscontrolplanev1.AccessEntry{adminEntry},
+			appliedEntries: []ekscontrolplanev1.AccessEntry{adminEntry},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, adminARN, nodeARN)
+			},
+			expectedApplied: []ekscontrolplanev1.AccessEntry{adminEntry},
+		},
+		{
+			name: "updates an access entry and disassociates a removed access policy",
+			accessEntries: []ekscontrolplanev1.AccessEntry{
+				{PrincipalARN: devARN, KubernetesGroups: []string{"developers"}},
+			},
+			appliedEntries: []ekscontrolplanev1.AccessEntry{
+				{
+					PrincipalARN:   devARN,
+					AccessPolicies: []ekscontrolplanev1.AccessPolicyReference{{PolicyARN: viewPolicy, AccessScope: ekscontrolplanev1.AccessScope{Type: ekscontrolplanev1.AccessScopeTypeCluster}}},
+				},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, devARN)
+				describeEntry(m, &eks.AccessEntry{PrincipalArn: aws.String(devARN), Type: aws.String("STANDARD")})
+				m.UpdateAccessEntryWithContext(gomock.Any(), &eks.UpdateAccessEntryInput{
+					ClusterName:      aws.String(clusterName),
+					PrincipalArn:     aws.String(devARN),
+					KubernetesGroups: aws.StringSlice([]string{"developers"}),
+				}).Return(&eks.UpdateAccessEntryOutput{}, nil)
+				listPolicies(m, devARN, &eks.AssociatedAccessPolicy{PolicyArn: aws.String(viewPolicy), AccessScope: &eks.AccessScope{Type: aws.String("cluster")}})
+				m.DisassociateAccessPolicyWithContext(gomock.Any(), &eks.DisassociateAccessPolicyInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(devARN),
+					PolicyArn:    aws.String(viewPolicy),
+				}).Return(&eks.DisassociateAccessPolicyOutput{}, nil)
+			},
+			expectedApplied: []ekscontrolplanev1.AccessEntry{
+				{PrincipalARN: devARN, KubernetesGroups: []string{"developers"}},
+			},
+		},
+		{
+			name:           "deletes an access entry once removed from the spec",
+			appliedEntries: []ekscontrolplanev1.AccessEntry{{PrincipalARN: devARN}},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listEntries(m, devARN, nodeARN)
+				m.DeleteAccessEntryWithContext(gomock.Any(), &eks.DeleteAccessEntryInput{
+					ClusterName:  aws.String(clusterName),
+					PrincipalArn: aws.String(devARN),
+				}).Return(&eks.DeleteAccessEntryOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: clusterName,
+					AccessConfig:   &ekscontrolplanev1.AccessConfig{AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI},
+					AccessEntries:  tc.accessEntries,
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+					AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI,
+					AccessEntries:      tc.appliedEntries,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileAccessEntries(context.TODO())).To(Succeed())
+			g.Expect(controlPlane.Status.AccessEntries).To(Equal(tc.expectedApplied))
+		})
+	}
+}
+
+func TestReconcileAccessConfig(t *testing.T) {
+	const clusterName = "cluster-test"
+
+	updateMode := func(m *mock_eksiface.MockEKSAPIMockRecorder, mode ekscontrolplanev1.EKSAuthenticationMode) {
+		m.UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+			Name:         aws.String(clusterName),
+			AccessConfig: &eks.UpdateAccessConfigRequest{AuthenticationMode: aws.String(string(mode))},
+		}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+	}
+
+	tests := []struct {
+		name         string
+		accessConfig *ekscontrolplanev1.AccessConfig
+		current      *eks.AccessConfigResponse
+		expect       func(m *mock_eksiface.MockEKSAPIMockRecorder)
+	}{
+		{
+			name:    "leaves the authentication mode as is without access config",
+			current: &eks.AccessConfigResponse{AuthenticationMode: aws.String(eks.AuthenticationModeConfigMap)},
+		},
+		{
+			name:         "doesn't update an authentication mode matching the spec",
+			accessConfig: &ekscontrolplanev1.AccessConfig{AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI},
+			current:      &eks.AccessConfigResponse{AuthenticationMode: aws.String(eks.AuthenticationModeApi)},
+		},
+		{
+			name:         "updates the authentication mode from API_AND_CONFIG_MAP to API",
+			accessConfig: &ekscontrolplanev1.AccessConfig{AuthenticationMode: ekscontrolplanev1.EKSAuthenticationModeAPI},
+			current:      &eks.AccessConfigResponse{AuthenticationMode: aws.String(eks.AuthenticationModeApiAndConfigMap)},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				updateMode(m, ekscontrolplanev1.EKSAuthenticationModeAPI)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: clusterName,
+					AccessConfig:   tc.accessConfig,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).WithStatusSubresource(controlPlane).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileAccessConfig(tc.current)).To(Succeed())
+		})
+	}
+}
@@ -19,15 +19,19 @@ package eks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
+	corev1 "k8s.io/api/core/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func (s *Service) reconcileAddons(ctx context.Context) error {
@@ -49,8 +53,14 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 		return fmt.Errorf("getting installed eks addons: %w", err)
 	}
 
+	// Create the IAM roles of the addons which configure a service account role
+	roleARNs, err := s.reconcileAddonIAMRoles()
+	if err != nil {
+		return fmt.Errorf("reconciling eks addon iam roles: %w", err)
+	}
+
 	// Get the addons from the spec we want for the cluster
-	desiredAddons := s.translateAPIToAddon(s.scope.Addons())
+	desiredAddons := s.translateAPIToAddon(s.scope.Addons(), roleARNs)
 
 	// If there are no addons desired or installed then do nothing
 	if len(installed) == 0 && len(desiredAddons) == 0 {
@@ -77,6 +87,11 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 		}
 	}
 
+	// Delete the IAM roles of the addons which don't configure a service account role anymore
+	if err := s.deleteRemovedAddonIAMRoles(installed); err != nil {
+		return fmt.Errorf("deleting removed eks addon iam roles: %w", err)
+	}
+
 	// Update status with addons installed details
 	// Note: we are not relying on the computed state from the operations as we still want
 	// to update the state even if there are no operations to capture things like status changes
@@ -86,6 +101,7 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 		return fmt.Errorf("getting installed state of eks addons: %w", err)
 	}
 	s.scope.ControlPlane.Status.Addons = addonState
+	s.setAddonUpdatePendingCondition()
 
 	// Persist status and record event
 	if err := s.scope.PatchObject(); err != nil {
@@ -97,6 +113,39 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	return nil
 }
 
+// setAddonUpdatePendingCondition reports the addons of the spec which are not installed with their desired
+// version yet. Addons are only updated once the control plane is active, so addons are pending while a version
+// update of the control plane is in progress.
+func (s *Service) setAddonUpdatePendingCondition() {
+	addons := s.scope.Addons()
+	if len(addons) == 0 {
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonUpdatePendingCondition)
+		return
+	}
+
+	installed := map[string]string{}
+	for _, state := range s.scope.ControlPlane.Status.Addons {
+		installed[state.Name] = state.Version
+	}
+
+	pending := []string{}
+	for _, addon := range addons {
+		if installed[addon.Name] != addon.Version {
+			pending = append(pending, addon.Name)
+		}
+	}
+
+	if len(pending) == 0 {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSAddonUpdatePendingCondition, ekscontrolplanev1.EKSAddonsUpToDateReason, clusterv1.ConditionSeverityInfo, "")
+		return
+	}
+	conditions.Set(s.scope.ControlPlane, &clusterv1.Condition{
+		Type:    ekscontrolplanev1.EKSAddonUpdatePendingCondition,
+		Status:  corev1.ConditionTrue,
+		Message: fmt.Sprintf("Addons pending an update: %s", strings.Join(pending, ", ")),
+	})
+}
+
 func (s *Service) getClusterAddonsInstalled(eksClusterName string, addonNames []*string) ([]*eksaddons.EKSAddon, error) {
 	s.Debug("getting eks addons installed")
 
@@ -189,7 +238,7 @@ func (s *Service) listAddons(eksClusterName string) ([]*string, error) {
 	return addons, nil
 }
 
-func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksaddons.EKSAddon {
+func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon, roleARNs map[string]string) []*eksaddons.EKSAddon {
 	converted := []*eksaddons.EKSAddon{}
 
 	for i := range addons {
@@ -202,6 +251,9 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 			ResolveConflict:       convertConflictResolution(*addon.ConflictResolution),
 			ServiceAccountRoleARN: addon.ServiceAccountRoleArn,
 		}
+		if roleARN, ok := roleARNs[addon.Name]; ok {
+			convertedAddon.ServiceAccountRoleARN = aws.String(roleARN)
+		}
 
 		converted = append(converted, convertedAddon)
 	}
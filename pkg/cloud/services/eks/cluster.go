@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,6 +30,7 @@ import (
 	"github.com/blang/semver"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
@@ -81,9 +83,10 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed to set status")
 	}
 
-	// Wait for our cluster to be ready if necessary
+	// Wait for our cluster to be ready if necessary. An update isn't waited for, the control plane is requeued
+	// until it has completed instead.
 	switch *cluster.Status {
-	case eks.ClusterStatusUpdating, eks.ClusterStatusCreating:
+	case eks.ClusterStatusCreating:
 		cluster, err = s.waitForClusterActive()
 	default:
 		break
@@ -103,10 +106,25 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		Port: 443,
 	}
 
+	if cluster.CertificateAuthority != nil {
+		s.scope.ControlPlane.Status.CertificateAuthorityData = cluster.CertificateAuthority.Data
+	}
+	if cluster.KubernetesNetworkConfig != nil {
+		if cluster.KubernetesNetworkConfig.ServiceIpv4Cidr != nil {
+			s.scope.ControlPlane.Status.ServiceCIDR = cluster.KubernetesNetworkConfig.ServiceIpv4Cidr
+		} else {
+			s.scope.ControlPlane.Status.ServiceCIDR = cluster.KubernetesNetworkConfig.ServiceIpv6Cidr
+		}
+	}
+
 	if err := s.reconcileSecurityGroups(cluster); err != nil {
 		return errors.Wrap(err, "failed reconciling security groups")
 	}
 
+	if err := s.reconcileClusterSecurityGroupIngressRules(); err != nil {
+		return errors.Wrap(err, "failed reconciling cluster security group ingress rules")
+	}
+
 	if err := s.reconcileKubeconfig(ctx, cluster); err != nil {
 		return errors.Wrap(err, "failed reconciling kubeconfig")
 	}
@@ -115,6 +133,14 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling additional kubeconfigs")
 	}
 
+	// The configuration of the cluster can't be changed while an update is in progress. The kubeconfig is
+	// regenerated above on every requeue, so it points at the endpoint of the new configuration once the update,
+	// e.g. of the endpoint access, has completed.
+	if aws.StringValue(cluster.Status) == eks.ClusterStatusUpdating {
+		s.scope.Debug("EKS control plane is updating, skipping the reconciliation of its configuration", "cluster", klog.KRef("", eksClusterName))
+		return nil
+	}
+
 	if err := s.reconcileClusterVersion(cluster); err != nil {
 		return errors.Wrap(err, "failed reconciling cluster version")
 	}
@@ -127,10 +153,18 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		return errors.Wrap(err, "failed reconciling logging")
 	}
 
+	if err := s.reconcileUpgradePolicy(cluster.UpgradePolicy); err != nil {
+		return errors.Wrap(err, "failed reconciling upgrade policy")
+	}
+
 	if err := s.reconcileEKSEncryptionConfig(cluster.EncryptionConfig); err != nil {
 		return errors.Wrap(err, "failed reconciling eks encryption config")
 	}
 
+	if err := s.reconcileAccessConfig(cluster.AccessConfig); err != nil {
+		return errors.Wrap(err, "failed reconciling access config")
+	}
+
 	if err := s.reconcileTags(cluster); err != nil {
 		return errors.Wrap(err, "failed updating cluster tags")
 	}
@@ -189,6 +223,18 @@ func (s *Service) setStatus(cluster *eks.Cluster) error {
 	// Set the current Kubernetes control plane version in the status.
 	s.scope.ControlPlane.Status.Version = computeCurrentStatusVersion(s.scope.ControlPlane.Spec.Version, cluster.Version)
 
+	s.scope.ControlPlane.Status.PlatformVersion = aws.StringValue(cluster.PlatformVersion)
+
+	if err := s.reconcileVersionUpdateStatus(); err != nil {
+		return errors.Wrap(err, "failed to get the progress of the version update")
+	}
+	s.setAddonUpdatePendingCondition()
+
+	// Set the current authentication mode in the control plane status.
+	if cluster.AccessConfig != nil && cluster.AccessConfig.AuthenticationMode != nil {
+		s.scope.ControlPlane.Status.AuthenticationMode = ekscontrolplanev1.EKSAuthenticationMode(*cluster.AccessConfig.AuthenticationMode)
+	}
+
 	// Set the current cluster status in the control plane status.
 	switch *cluster.Status {
 	case eks.ClusterStatusDeleting:
@@ -225,6 +271,47 @@ func (s *Service) setStatus(cluster *eks.Cluster) error {
 	return nil
 }
 
+// reconcileVersionUpdateStatus refreshes the progress of the version update initiated by the controller
+// with the EKS DescribeUpdate API, and reports its outcome on the EKSVersionUpdateInProgress condition.
+func (s *Service) reconcileVersionUpdateStatus() error {
+	versionUpdate := s.scope.ControlPlane.Status.VersionUpdate
+	if versionUpdate == nil || versionUpdate.ID == "" {
+		return nil
+	}
+	// The outcome of a completed update doesn't change anymore.
+	if versionUpdate.Phase != "" && versionUpdate.Phase != eks.UpdateStatusInProgress {
+		return nil
+	}
+
+	out, err := s.EKSClient.DescribeUpdate(&eks.DescribeUpdateInput{
+		Name:     aws.String(s.scope.KubernetesClusterName()),
+		UpdateId: aws.String(versionUpdate.ID),
+	})
+	if err != nil {
+		return err
+	}
+
+	versionUpdate.Phase = aws.StringValue(out.Update.Status)
+	versionUpdate.Errors = nil
+	for _, updateErr := range out.Update.Errors {
+		versionUpdate.Errors = append(versionUpdate.Errors, fmt.Sprintf("%s: %s", aws.StringValue(updateErr.ErrorCode), aws.StringValue(updateErr.ErrorMessage)))
+	}
+
+	switch versionUpdate.Phase {
+	case eks.UpdateStatusInProgress:
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSVersionUpdateInProgressCondition)
+	case eks.UpdateStatusSuccessful:
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSVersionUpdateInProgressCondition, ekscontrolplanev1.EKSVersionUpdateSucceededReason, clusterv1.ConditionSeverityInfo,
+			"Updated to version %s", versionUpdate.Version)
+	default:
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSVersionUpdateInProgressCondition, ekscontrolplanev1.EKSVersionUpdateFailedReason, clusterv1.ConditionSeverityError,
+			"Update to version %s is %s: %s", versionUpdate.Version, versionUpdate.Phase, strings.Join(versionUpdate.Errors, "; "))
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "Update of EKS control plane %s to version %s is %s", s.scope.KubernetesClusterName(), versionUpdate.Version, versionUpdate.Phase)
+	}
+
+	return nil
+}
+
 // deleteCluster deletes an EKS cluster.
 func (s *Service) deleteCluster() error {
 	eksClusterName := s.scope.KubernetesClusterName()
@@ -478,6 +565,19 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	if !s.scope.BootstrapSelfManagedAddons() {
 		input.BootstrapSelfManagedAddons = aws.Bool(false)
 	}
+	if outpostConfig := s.scope.ControlPlane.Spec.OutpostConfig; outpostConfig != nil {
+		input.OutpostConfig = makeEksOutpostConfig(outpostConfig)
+	}
+	if upgradePolicy := s.scope.ControlPlane.Spec.UpgradePolicy; upgradePolicy != nil {
+		input.UpgradePolicy = &eks.UpgradePolicyRequest{
+			SupportType: aws.String(string(upgradePolicy.SupportType)),
+		}
+	}
+	if accessConfig := s.scope.ControlPlane.Spec.AccessConfig; accessConfig != nil {
+		input.AccessConfig = &eks.CreateAccessConfigRequest{
+			AuthenticationMode: aws.String(string(accessConfig.AuthenticationMode)),
+		}
+	}
 
 	var out *eks.CreateClusterOutput
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
@@ -491,6 +591,9 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 		record.Eventf(s.scope.ControlPlane, "InitiatedCreateEKSControlPlane", "Initiated creation of a new EKS control plane %s", s.scope.KubernetesClusterName())
 		return true, nil
 	}, awserrors.ResourceNotFound); err != nil { // TODO: change the error that can be retried
+		if keyErr := s.encryptionKeyAccessError(err); keyErr != nil {
+			err = keyErr
+		}
 		record.Warnf(s.scope.ControlPlane, "FailedCreateEKSControlPlane", "Failed to initiate creation of a new EKS control plane: %v", err)
 		return nil, errors.Wrapf(err, "failed to create EKS cluster")
 	}
@@ -499,6 +602,20 @@ func (s *Service) createCluster(eksClusterName string) (*eks.Cluster, error) {
 	return out.Cluster, nil
 }
 
+// makeEksOutpostConfig returns the configuration to create a local cluster on an Outpost.
+func makeEksOutpostConfig(outpostConfig *ekscontrolplanev1.OutpostConfig) *eks.OutpostConfigRequest {
+	request := &eks.OutpostConfigRequest{
+		OutpostArns:              aws.StringSlice(outpostConfig.OutpostARNs),
+		ControlPlaneInstanceType: aws.String(outpostConfig.ControlPlaneInstanceType),
+	}
+	if outpostConfig.ControlPlanePlacement != nil {
+		request.ControlPlanePlacement = &eks.ControlPlanePlacementRequest{
+			GroupName: aws.String(outpostConfig.ControlPlanePlacement.GroupName),
+		}
+	}
+	return request
+}
+
 func (s *Service) waitForClusterActive() (*eks.Cluster, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
 	req := eks.DescribeClusterInput{
@@ -553,18 +670,38 @@ func (s *Service) reconcileClusterConfig(cluster *eks.Cluster) error {
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "Failed to update the EKS control plane: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
+
+		if endpointAccessChanged(cluster.ResourcesVpcConfig, input.ResourcesVpcConfig) {
+			record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEndpointAccess", "Initiated update of endpoint access of EKS control plane %s", s.scope.KubernetesClusterName())
+		}
 	}
 	return nil
 }
 
+// endpointAccessChanged returns whether an update of the vpc config toggles the public or private endpoint access.
+func endpointAccessChanged(current *eks.VpcConfigResponse, update *eks.VpcConfigRequest) bool {
+	if update == nil {
+		return false
+	}
+	return !tristate.EqualWithDefault(false, current.EndpointPrivateAccess, update.EndpointPrivateAccess) ||
+		!tristate.EqualWithDefault(true, current.EndpointPublicAccess, update.EndpointPublicAccess)
+}
+
+// reconcileLogging updates the logging configuration of the EKS cluster if it differs from the spec,
+// which also reverts log types enabled or disabled outside of CAPA. All log types are disabled if
+// the spec doesn't configure logging.
 func (s *Service) reconcileLogging(logging *eks.Logging) error {
 	input := eks.UpdateClusterConfigInput{Name: aws.String(s.scope.KubernetesClusterName())}
 
+	loggingSpec := s.scope.ControlPlane.Spec.Logging
+	if loggingSpec == nil {
+		loggingSpec = &ekscontrolplanev1.ControlPlaneLoggingSpec{}
+	}
 	for _, logSetup := range logging.ClusterLogging {
 		for _, l := range logSetup.Types {
-			enabled := s.scope.ControlPlane.Spec.Logging.IsLogEnabled(*l)
+			enabled := loggingSpec.IsLogEnabled(*l)
 			if enabled != *logSetup.Enabled {
-				input.Logging = makeEksLogging(s.scope.ControlPlane.Spec.Logging)
+				input.Logging = makeEksLogging(loggingSpec)
 			}
 		}
 	}
@@ -593,6 +730,45 @@ func (s *Service) reconcileLogging(logging *eks.Logging) error {
 	return nil
 }
 
+// reconcileUpgradePolicy updates the support type of the EKS cluster if it differs from the spec. The
+// support type of EKS is kept if the spec doesn't configure an upgrade policy.
+func (s *Service) reconcileUpgradePolicy(upgradePolicy *eks.UpgradePolicyResponse) error {
+	upgradePolicySpec := s.scope.ControlPlane.Spec.UpgradePolicy
+	if upgradePolicySpec == nil {
+		return nil
+	}
+	if upgradePolicy != nil && aws.StringValue(upgradePolicy.SupportType) == string(upgradePolicySpec.SupportType) {
+		return nil
+	}
+
+	input := eks.UpdateClusterConfigInput{
+		Name: aws.String(s.scope.KubernetesClusterName()),
+		UpgradePolicy: &eks.UpgradePolicyRequest{
+			SupportType: aws.String(string(upgradePolicySpec.SupportType)),
+		},
+	}
+	if err := input.Validate(); err != nil {
+		return errors.Wrap(err, "created invalid UpdateClusterConfigInput")
+	}
+
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		if _, err := s.EKSClient.UpdateClusterConfig(&input); err != nil {
+			if aerr, ok := err.(awserr.Error); ok {
+				return false, aerr
+			}
+			return false, err
+		}
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition)
+		record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEKSControlPlane", "Initiated upgrade policy update for EKS control plane %s", s.scope.KubernetesClusterName())
+		return true, nil
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "Failed to update EKS control plane upgrade policy: %v", err)
+		return errors.Wrapf(err, "failed to update EKS cluster")
+	}
+
+	return nil
+}
+
 func publicAccessCIDRsEqual(as []*string, bs []*string) bool {
 	all := "0.0.0.0/0"
 	if len(as) == 0 {
@@ -644,23 +820,61 @@ func (s *Service) reconcileEKSEncryptionConfig(currentClusterConfig []*eks.Encry
 
 	if compareEncryptionConfig(currentClusterConfig, updatedEncryptionConfigs) {
 		s.Debug("encryption configuration unchanged, no action")
+		if len(updatedEncryptionConfigs) > 0 {
+			if conditions.GetReason(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition) == ekscontrolplanev1.EKSEncryptionConfigAssociatingReason {
+				record.Eventf(s.scope.ControlPlane, "SuccessfulAssociateEncryptionConfig", "Associated encryption config with EKS control plane %s", s.scope.KubernetesClusterName())
+			}
+			conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition)
+		}
 		return nil
 	}
 
+	// Associating an encryption config is a one-way operation, it can neither be changed nor removed afterwards.
 	if len(currentClusterConfig) == 0 && len(updatedEncryptionConfigs) > 0 {
 		s.Debug("enabling encryption for eks cluster", "cluster", s.scope.KubernetesClusterName())
 		if err := s.updateEncryptionConfig(updatedEncryptionConfigs); err != nil {
+			reason := ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason
+			if keyErr := s.encryptionKeyAccessError(err); keyErr != nil {
+				err = keyErr
+				reason = ekscontrolplanev1.EKSEncryptionKeyAccessDeniedReason
+			}
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition, reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 			record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane encryption configuration: %v", err)
 			return errors.Wrapf(err, "failed to update EKS cluster")
 		}
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition, ekscontrolplanev1.EKSEncryptionConfigAssociatingReason, clusterv1.ConditionSeverityInfo,
+			"associating encryption config with EKS control plane %s", s.scope.KubernetesClusterName())
 
 		return nil
 	}
 
+	conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition, ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason, clusterv1.ConditionSeverityError,
+		"changing or disabling EKS encryption is not allowed after it has been enabled")
 	record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSControlPlane", "failed to update the EKS control plane: disabling EKS encryption is not allowed after it has been enabled")
 	return errors.Errorf("failed to update the EKS control plane: disabling EKS encryption is not allowed after it has been enabled")
 }
 
+// encryptionKeyAccessError returns an error explaining the requirements on the KMS key of the encryption config,
+// if err reports that EKS can't use the key, e.g. because the key policy doesn't allow the cluster role to use it.
+// It returns nil for any other error.
+func (s *Service) encryptionKeyAccessError(err error) error {
+	var aerr awserr.Error
+	if s.scope.ControlPlane.Spec.EncryptionConfig == nil || !errors.As(err, &aerr) {
+		return nil
+	}
+	switch aerr.Code() {
+	case eks.ErrCodeInvalidParameterException, eks.ErrCodeInvalidRequestException, eks.ErrCodeClientException, "AccessDeniedException":
+	default:
+		return nil
+	}
+	if !strings.Contains(strings.ToLower(aerr.Message()), "kms") {
+		return nil
+	}
+
+	return errors.Wrapf(ErrEncryptionKeyAccessDenied, "%s: the key %q must be an enabled symmetric KMS key in region %s, and its key policy must allow the cluster role %q to use it",
+		aerr.Message(), aws.StringValue(s.scope.ControlPlane.Spec.EncryptionConfig.Provider), s.scope.Region(), aws.StringValue(s.scope.ControlPlane.Spec.RoleName))
+}
+
 func parseEKSVersion(raw string) (*version.Version, error) {
 	v, err := version.ParseGeneric(raw)
 	if err != nil {
@@ -696,12 +910,23 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 		}
 
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-			if _, err := s.EKSClient.UpdateClusterVersion(input); err != nil {
+			out, err := s.EKSClient.UpdateClusterVersion(input)
+			if err != nil {
 				if aerr, ok := err.(awserr.Error); ok {
 					return false, aerr
 				}
 				return false, err
 			}
+			if out.Update != nil {
+				s.scope.ControlPlane.Status.VersionUpdate = &ekscontrolplanev1.VersionUpdateStatus{
+					ID:      aws.StringValue(out.Update.Id),
+					Version: nextVersionString,
+					Phase:   aws.StringValue(out.Update.Status),
+				}
+				if out.Update.CreatedAt != nil {
+					s.scope.ControlPlane.Status.VersionUpdate.StartedAt = &metav1.Time{Time: *out.Update.CreatedAt}
+				}
+			}
 
 			// Wait until status transitions to UPDATING because there's a short
 			// window after UpdateClusterVersion returns where the cluster
@@ -714,6 +939,7 @@ func (s *Service) reconcileClusterVersion(cluster *eks.Cluster) error {
 			}
 
 			conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition)
+			conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSVersionUpdateInProgressCondition)
 			record.Eventf(s.scope.ControlPlane, "InitiatedUpdateEKSControlPlane", "Initiated update of EKS control plane %s to version %s", s.scope.KubernetesClusterName(), nextVersionString)
 
 			return true, nil
@@ -20,11 +20,13 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -37,6 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestMakeEKSEncryptionConfigs(t *testing.T) {
@@ -73,6 +76,46 @@ func TestMakeEKSEncryptionConfigs(t *testing.T) {
 	}
 }
 
+func TestMakeEKSOutpostConfig(t *testing.T) {
+	outpostARN := "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"
+	testCases := []struct {
+		name   string
+		input  *ekscontrolplanev1.OutpostConfig
+		expect *eks.OutpostConfigRequest
+	}{
+		{
+			name: "without placement",
+			input: &ekscontrolplanev1.OutpostConfig{
+				OutpostARNs:              []string{outpostARN},
+				ControlPlaneInstanceType: "m5d.large",
+			},
+			expect: &eks.OutpostConfigRequest{
+				OutpostArns:              aws.StringSlice([]string{outpostARN}),
+				ControlPlaneInstanceType: aws.String("m5d.large"),
+			},
+		},
+		{
+			name: "with placement group",
+			input: &ekscontrolplanev1.OutpostConfig{
+				OutpostARNs:              []string{outpostARN},
+				ControlPlaneInstanceType: "m5d.large",
+				ControlPlanePlacement:    &ekscontrolplanev1.ControlPlanePlacement{GroupName: "control-plane"},
+			},
+			expect: &eks.OutpostConfigRequest{
+				OutpostArns:              aws.StringSlice([]string{outpostARN}),
+				ControlPlaneInstanceType: aws.String("m5d.large"),
+				ControlPlanePlacement:    &eks.ControlPlanePlacementRequest{GroupName: aws.String("control-plane")},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(makeEksOutpostConfig(tc.input)).To(Equal(tc.expect))
+		})
+	}
+}
+
 func TestParseEKSVersion(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -357,6 +400,171 @@ func TestMakeEKSLogging(t *testing.T) {
 	}
 }
 
+func TestReconcileLogging(t *testing.T) {
+	clusterName := "default.cluster"
+	allTypes := []*string{
+		aws.String(eks.LogTypeApi),
+		aws.String(eks.LogTypeAudit),
+		aws.String(eks.LogTypeAuthenticator),
+		aws.String(eks.LogTypeControllerManager),
+		aws.String(eks.LogTypeScheduler),
+	}
+
+	tests := []struct {
+		name          string
+		loggingSpec   *ekscontrolplanev1.ControlPlaneLoggingSpec
+		current       *eks.Logging
+		expectLogging *eks.Logging
+	}{
+		{
+			name:        "no update if logging matches the spec",
+			loggingSpec: &ekscontrolplanev1.ControlPlaneLoggingSpec{APIServer: true},
+			current: &eks.Logging{ClusterLogging: []*eks.LogSetup{
+				{Enabled: aws.Bool(true), Types: allTypes[:1]},
+				{Enabled: aws.Bool(false), Types: allTypes[1:]},
+			}},
+		},
+		{
+			name:        "enables a log type enabled in the spec",
+			loggingSpec: &ekscontrolplanev1.ControlPlaneLoggingSpec{APIServer: true},
+			current: &eks.Logging{ClusterLogging: []*eks.LogSetup{
+				{Enabled: aws.Bool(false), Types: allTypes},
+			}},
+			expectLogging: &eks.Logging{ClusterLogging: []*eks.LogSetup{
+				{Enabled: aws.Bool(true), Types: allTypes[:1]},
+				{Enabled: aws.Bool(false), Types: allTypes[1:]},
+			}},
+		},
+		{
+			name: "disables a log type enabled outside of the spec",
+			current: &eks.Logging{ClusterLogging: []*eks.LogSetup{
+				{Enabled: aws.Bool(true), Types: allTypes[1:2]},
+				{Enabled: aws.Bool(false), Types: append([]*string{allTypes[0]}, allTypes[2:]...)},
+			}},
+			expectLogging: &eks.Logging{ClusterLogging: []*eks.LogSetup{
+				{Enabled: aws.Bool(false), Types: allTypes},
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      clusterName,
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: "default-cluster",
+						Version:        aws.String("1.16"),
+						Logging:        tc.loggingSpec,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			if tc.expectLogging != nil {
+				eksMock.EXPECT().UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+					Name:    aws.String("default-cluster"),
+					Logging: tc.expectLogging,
+				}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			}
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileLogging(tc.current)).To(Succeed())
+		})
+	}
+}
+
+func TestReconcileUpgradePolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		upgradePolicySpec *ekscontrolplanev1.UpgradePolicy
+		current           *eks.UpgradePolicyResponse
+		expectSupportType *string
+	}{
+		{
+			name:    "no update without an upgrade policy in the spec",
+			current: &eks.UpgradePolicyResponse{SupportType: aws.String(eks.SupportTypeExtended)},
+		},
+		{
+			name:              "no update if the support type matches the spec",
+			upgradePolicySpec: &ekscontrolplanev1.UpgradePolicy{SupportType: ekscontrolplanev1.UpgradeSupportTypeStandard},
+			current:           &eks.UpgradePolicyResponse{SupportType: aws.String(eks.SupportTypeStandard)},
+		},
+		{
+			name:              "updates the support type if it differs from the spec",
+			upgradePolicySpec: &ekscontrolplanev1.UpgradePolicy{SupportType: ekscontrolplanev1.UpgradeSupportTypeStandard},
+			current:           &eks.UpgradePolicyResponse{SupportType: aws.String(eks.SupportTypeExtended)},
+			expectSupportType: aws.String(eks.SupportTypeStandard),
+		},
+		{
+			name:              "sets the support type if the cluster has no upgrade policy",
+			upgradePolicySpec: &ekscontrolplanev1.UpgradePolicy{SupportType: ekscontrolplanev1.UpgradeSupportTypeExtended},
+			expectSupportType: aws.String(eks.SupportTypeExtended),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "default.cluster",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: "default-cluster",
+						UpgradePolicy:  tc.upgradePolicySpec,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			if tc.expectSupportType != nil {
+				eksMock.EXPECT().UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+					Name:          aws.String("default-cluster"),
+					UpgradePolicy: &eks.UpgradePolicyRequest{SupportType: tc.expectSupportType},
+				}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			}
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileUpgradePolicy(tc.current)).To(Succeed())
+		})
+	}
+}
+
 func TestReconcileClusterVersion(t *testing.T) {
 	clusterName := "default.cluster"
 	tests := []struct {
@@ -463,6 +671,190 @@ func TestReconcileClusterVersion(t *testing.T) {
 	}
 }
 
+func TestReconcileVersionUpdateStatus(t *testing.T) {
+	clusterName := "default.cluster"
+	tests := []struct {
+		name              string
+		versionUpdate     *ekscontrolplanev1.VersionUpdateStatus
+		expect            func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedPhase     string
+		expectedCondition *clusterv1.Condition
+	}{
+		{
+			name: "no version update initiated",
+		},
+		{
+			name:          "completed version update is not described again",
+			versionUpdate: &ekscontrolplanev1.VersionUpdateStatus{ID: "update-1", Version: "1.30", Phase: eks.UpdateStatusSuccessful},
+			expectedPhase: eks.UpdateStatusSuccessful,
+		},
+		{
+			name:          "version update in progress",
+			versionUpdate: &ekscontrolplanev1.VersionUpdateStatus{ID: "update-1", Version: "1.30", Phase: eks.UpdateStatusInProgress},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(&eks.DescribeUpdateInput{Name: aws.String(clusterName), UpdateId: aws.String("update-1")}).
+					Return(&eks.DescribeUpdateOutput{Update: &eks.Update{Id: aws.String("update-1"), Status: aws.String(eks.UpdateStatusInProgress)}}, nil)
+			},
+			expectedPhase:     eks.UpdateStatusInProgress,
+			expectedCondition: conditions.TrueCondition(ekscontrolplanev1.EKSVersionUpdateInProgressCondition),
+		},
+		{
+			name:          "version update succeeded",
+			versionUpdate: &ekscontrolplanev1.VersionUpdateStatus{ID: "update-1", Version: "1.30", Phase: eks.UpdateStatusInProgress},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(&eks.DescribeUpdateInput{Name: aws.String(clusterName), UpdateId: aws.String("update-1")}).
+					Return(&eks.DescribeUpdateOutput{Update: &eks.Update{Id: aws.String("update-1"), Status: aws.String(eks.UpdateStatusSuccessful)}}, nil)
+			},
+			expectedPhase: eks.UpdateStatusSuccessful,
+			expectedCondition: conditions.FalseCondition(ekscontrolplanev1.EKSVersionUpdateInProgressCondition, ekscontrolplanev1.EKSVersionUpdateSucceededReason,
+				clusterv1.ConditionSeverityInfo, "Updated to version 1.30"),
+		},
+		{
+			name:          "version update failed",
+			versionUpdate: &ekscontrolplanev1.VersionUpdateStatus{ID: "update-1", Version: "1.30", Phase: eks.UpdateStatusInProgress},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeUpdate(&eks.DescribeUpdateInput{Name: aws.String(clusterName), UpdateId: aws.String("update-1")}).
+					Return(&eks.DescribeUpdateOutput{Update: &eks.Update{
+						Id:     aws.String("update-1"),
+						Status: aws.String(eks.UpdateStatusFailed),
+						Errors: []*eks.ErrorDetail{{ErrorCode: aws.String(eks.ErrorCodeSubnetNotFound), ErrorMessage: aws.String("subnet not found")}},
+					}}, nil)
+			},
+			expectedPhase: eks.UpdateStatusFailed,
+			expectedCondition: conditions.FalseCondition(ekscontrolplanev1.EKSVersionUpdateInProgressCondition, ekscontrolplanev1.EKSVersionUpdateFailedReason,
+				clusterv1.ConditionSeverityError, "Update to version 1.30 is Failed: SubnetNotFound: subnet not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName: clusterName,
+					},
+					Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+						VersionUpdate: tc.versionUpdate,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileVersionUpdateStatus()).To(Succeed())
+			if tc.versionUpdate != nil {
+				g.Expect(scope.ControlPlane.Status.VersionUpdate.Phase).To(Equal(tc.expectedPhase))
+			}
+			condition := conditions.Get(scope.ControlPlane, ekscontrolplanev1.EKSVersionUpdateInProgressCondition)
+			if tc.expectedCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectedCondition.Reason))
+			g.Expect(condition.Severity).To(Equal(tc.expectedCondition.Severity))
+			g.Expect(condition.Message).To(Equal(tc.expectedCondition.Message))
+		})
+	}
+}
+
+func TestSetAddonUpdatePendingCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		addons         *[]ekscontrolplanev1.Addon
+		installed      []ekscontrolplanev1.AddonState
+		expectedStatus corev1.ConditionStatus
+		expectedMsg    string
+	}{
+		{
+			name: "no addons in the spec",
+		},
+		{
+			name:           "addons installed with the desired version",
+			addons:         &[]ekscontrolplanev1.Addon{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}},
+			installed:      []ekscontrolplanev1.AddonState{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}},
+			expectedStatus: corev1.ConditionFalse,
+		},
+		{
+			name: "addons pending an update",
+			addons: &[]ekscontrolplanev1.Addon{
+				{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"},
+				{Name: "coredns", Version: "v1.11.1-eksbuild.9"},
+				{Name: "kube-proxy", Version: "v1.30.0-eksbuild.3"},
+			},
+			installed: []ekscontrolplanev1.AddonState{
+				{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"},
+				{Name: "coredns", Version: "v1.11.1-eksbuild.4"},
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedMsg:    "Addons pending an update: coredns, kube-proxy",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						Addons: tc.addons,
+					},
+					Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+						Addons: tc.installed,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			s.setAddonUpdatePendingCondition()
+
+			condition := conditions.Get(scope.ControlPlane, ekscontrolplanev1.EKSAddonUpdatePendingCondition)
+			if tc.expectedStatus == "" {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Message).To(Equal(tc.expectedMsg))
+		})
+	}
+}
+
 func TestCreateCluster(t *testing.T) {
 	clusterName := "cluster.default"
 	version := aws.String("1.24")
@@ -570,6 +962,7 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 		newEncryptionConfig *ekscontrolplanev1.EncryptionConfig
 		expect              func(m *mock_eksiface.MockEKSAPIMockRecorder)
 		expectError         bool
+		expectCondition     *clusterv1.Condition
 	}{
 		{
 			name:                "no upgrade necessary - encryption disabled",
@@ -588,8 +981,9 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 				Provider:  ptr.To[string]("provider"),
 				Resources: []*string{ptr.To[string]("foo"), ptr.To[string]("bar")},
 			},
-			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
-			expectError: false,
+			expect:          func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectError:     false,
+			expectCondition: &clusterv1.Condition{Status: corev1.ConditionTrue},
 		},
 		{
 			name:                "needs upgrade",
@@ -604,7 +998,22 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 				).Return(nil)
 				m.AssociateEncryptionConfig(gomock.AssignableToTypeOf(&eks.AssociateEncryptionConfigInput{})).Return(&eks.AssociateEncryptionConfigOutput{}, nil)
 			},
-			expectError: false,
+			expectError:     false,
+			expectCondition: &clusterv1.Condition{Status: corev1.ConditionFalse, Reason: ekscontrolplanev1.EKSEncryptionConfigAssociatingReason},
+		},
+		{
+			name:                "association fails",
+			oldEncryptionConfig: nil,
+			newEncryptionConfig: &ekscontrolplanev1.EncryptionConfig{
+				Provider:  ptr.To[string]("provider"),
+				Resources: []*string{ptr.To[string]("secrets")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.AssociateEncryptionConfig(gomock.AssignableToTypeOf(&eks.AssociateEncryptionConfigInput{})).
+					Return(nil, awserr.New(eks.ErrCodeInvalidParameterException, "unsupported resource", nil))
+			},
+			expectError:     true,
+			expectCondition: &clusterv1.Condition{Status: corev1.ConditionFalse, Reason: ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason},
 		},
 		{
 			name: "upgrade not allowed if encryption config updated as nil",
@@ -626,8 +1035,9 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 				Provider:  ptr.To[string]("new-provider"),
 				Resources: []*string{ptr.To[string]("foo"), ptr.To[string]("bar")},
 			},
-			expect:      func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
-			expectError: true,
+			expect:          func(m *mock_eksiface.MockEKSAPIMockRecorder) {},
+			expectError:     true,
+			expectCondition: &clusterv1.Condition{Status: corev1.ConditionFalse, Reason: ekscontrolplanev1.EKSEncryptionConfigAssociationFailedReason},
 		},
 	}
 
@@ -666,6 +1076,12 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 			s.EKSClient = eksMock
 
 			err = s.reconcileEKSEncryptionConfig(makeEksEncryptionConfigs(tc.oldEncryptionConfig))
+			if tc.expectCondition != nil {
+				condition := conditions.Get(scope.ControlPlane, ekscontrolplanev1.EKSEncryptionConfigAssociatedCondition)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(tc.expectCondition.Status))
+				g.Expect(condition.Reason).To(Equal(tc.expectCondition.Reason))
+			}
 			if tc.expectError {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -675,6 +1091,83 @@ func TestReconcileEKSEncryptionConfig(t *testing.T) {
 	}
 }
 
+func TestEncryptionKeyAccessError(t *testing.T) {
+	encryptionConfig := &ekscontrolplanev1.EncryptionConfig{
+		Provider:  ptr.To[string]("arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"),
+		Resources: []*string{ptr.To[string]("secrets")},
+	}
+
+	tests := []struct {
+		name             string
+		encryptionConfig *ekscontrolplanev1.EncryptionConfig
+		err              error
+		expectKeyError   bool
+	}{
+		{
+			name:             "key policy denies the cluster role",
+			encryptionConfig: encryptionConfig,
+			err:              errors.Wrap(awserr.New(eks.ErrCodeInvalidParameterException, "Cluster role does not have permission to use KMS key", nil), "failed"),
+			expectKeyError:   true,
+		},
+		{
+			name:             "key is disabled",
+			encryptionConfig: encryptionConfig,
+			err:              awserr.New("AccessDeniedException", "KMS key is disabled", nil),
+			expectKeyError:   true,
+		},
+		{
+			name:             "unrelated invalid parameter",
+			encryptionConfig: encryptionConfig,
+			err:              awserr.New(eks.ErrCodeInvalidParameterException, "Subnets specified must be in at least two different AZs", nil),
+		},
+		{
+			name: "no encryption config",
+			err:  awserr.New(eks.ErrCodeInvalidParameterException, "Cluster role does not have permission to use KMS key", nil),
+		},
+		{
+			name:             "not an AWS error",
+			encryptionConfig: encryptionConfig,
+			err:              errors.New("kms"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "default.cluster",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						RoleName:         ptr.To[string]("eks-controlplane"),
+						EncryptionConfig: tc.encryptionConfig,
+					},
+				},
+			})
+			g.Expect(err).To(BeNil())
+
+			s := NewService(scope)
+			keyErr := s.encryptionKeyAccessError(tc.err)
+			if !tc.expectKeyError {
+				g.Expect(keyErr).To(BeNil())
+				return
+			}
+			g.Expect(errors.Is(keyErr, ErrEncryptionKeyAccessDenied)).To(BeTrue())
+			g.Expect(keyErr.Error()).To(ContainSubstring(`policy must allow the cluster role "eks-controlplane"`))
+		})
+	}
+}
+
 func TestCreateIPv6Cluster(t *testing.T) {
 	g := NewWithT(t)
 
@@ -774,3 +1267,97 @@ func TestCreateIPv6Cluster(t *testing.T) {
 	_, err = s.createCluster("cluster-name")
 	g.Expect(err).To(BeNil())
 }
+
+func TestReconcileClusterConfigEndpointAccess(t *testing.T) {
+	clusterName := "cluster-foo"
+	publicCluster := &eks.Cluster{
+		Name: aws.String(clusterName),
+		ResourcesVpcConfig: &eks.VpcConfigResponse{
+			EndpointPublicAccess:  aws.Bool(true),
+			EndpointPrivateAccess: aws.Bool(false),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		endpointAccess ekscontrolplanev1.EndpointAccess
+		expect         func(m *mock_eksiface.MockEKSAPIMockRecorder)
+	}{
+		{
+			name: "public to private transition initiates the update without waiting for it",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				Public:  aws.Bool(false),
+				Private: aws.Bool(true),
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(&eks.UpdateClusterConfigInput{
+					Name: aws.String(clusterName),
+					ResourcesVpcConfig: &eks.VpcConfigRequest{
+						EndpointPublicAccess:  aws.Bool(false),
+						EndpointPrivateAccess: aws.Bool(true),
+					},
+				}).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+		},
+		{
+			name: "public access CIDR changes initiate the update",
+			endpointAccess: ekscontrolplanev1.EndpointAccess{
+				Public:      aws.Bool(true),
+				Private:     aws.Bool(false),
+				PublicCIDRs: []*string{aws.String("10.0.0.0/8")},
+			},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateClusterConfig(gomock.AssignableToTypeOf(&eks.UpdateClusterConfigInput{})).Return(&eks.UpdateClusterConfigOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      "capi-cluster-foo",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: clusterName,
+					EndpointAccess: tc.endpointAccess,
+					NetworkSpec: infrav1.NetworkSpec{
+						Subnets: []infrav1.SubnetSpec{
+							{ID: "sub-1", AvailabilityZone: "us-east-2a"},
+							{ID: "sub-2", AvailabilityZone: "us-east-2b"},
+						},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+			managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-cluster-foo",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(eksMock.EXPECT())
+			s := NewService(managedScope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileClusterConfig(publicCluster)).To(Succeed())
+			// The control plane is requeued while it's updating, and the kubeconfig regenerated once it's active again.
+			g.Expect(conditions.IsTrue(managedScope.ControlPlane, ekscontrolplanev1.EKSControlPlaneUpdatingCondition)).To(BeTrue())
+		})
+	}
+}
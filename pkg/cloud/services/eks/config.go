@@ -17,11 +17,13 @@ limitations under the License.
 package eks
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/pkg/errors"
@@ -48,6 +50,10 @@ const (
 
 	relativeKubeconfigKey = "relative"
 	relativeTokenFileKey  = "token-file"
+
+	// tokenRefreshedAnnotation records when the token embedded in a kubeconfig secret was generated, so
+	// that it is only regenerated once the kubeconfig refresh interval of the control plane has passed.
+	tokenRefreshedAnnotation = "aws.cluster.x-k8s.io/kubeconfig-token-refreshed"
 )
 
 func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *eks.Cluster) error {
@@ -90,21 +96,22 @@ func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *e
 		Namespace: s.scope.Cluster.Namespace,
 	}
 
-	// Create the additional kubeconfig for users. This doesn't need updating on every sync
-	_, err := secret.GetFromNamespacedName(ctx, s.scope.Client, clusterRef, secret.Kubeconfig)
+	// Create the additional kubeconfig for users. This only needs updating if it embeds a token or the
+	// token method has changed.
+	configSecret, err := secret.GetFromNamespacedName(ctx, s.scope.Client, clusterRef, secret.Kubeconfig)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get kubeconfig (user) secret")
 		}
 
-		createErr := s.createUserKubeconfigSecret(
-			ctx,
-			cluster,
-			&clusterRef,
-		)
-		if createErr != nil {
-			return err
+		if createErr := s.createUserKubeconfigSecret(ctx, cluster, &clusterRef); createErr != nil {
+			return fmt.Errorf("creating kubeconfig (user) secret: %w", createErr)
 		}
+		return nil
+	}
+
+	if updateErr := s.updateUserKubeconfigSecret(ctx, configSecret, cluster); updateErr != nil {
+		return fmt.Errorf("updating kubeconfig (user) secret: %w", updateErr)
 	}
 
 	return nil
@@ -122,7 +129,7 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *eks.C
 	}
 	clusterConfig := config.DeepCopy()
 
-	token, err := s.generateToken()
+	token, err := s.generateToken(s.tokenClusterID(cluster))
 	if err != nil {
 		return fmt.Errorf("generating presigned token: %w", err)
 	}
@@ -154,6 +161,7 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *eks.C
 	secretData[relativeTokenFileKey] = []byte(token)
 
 	kubeconfigSecret := generateSecretWithOwner(*clusterRef, secretData, controllerOwnerRef)
+	setTokenRefreshed(kubeconfigSecret)
 	if err := s.scope.Client.Create(ctx, kubeconfigSecret); err != nil {
 		return errors.Wrap(err, "failed to create kubeconfig secret")
 	}
@@ -178,7 +186,23 @@ func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *
 	}
 	clusterConfig := config.DeepCopy()
 
-	token, err := s.generateToken()
+	config.AuthInfos = map[string]*api.AuthInfo{
+		userName: {
+			TokenFile: "./" + relativeTokenFileKey,
+		},
+	}
+	relativeOut, err := clientcmd.Write(*config)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize config to yaml")
+	}
+
+	// The relative kubeconfig doesn't contain the token, so it only changes with the cluster.
+	if !s.tokenRefreshDue(configSecret) && bytes.Equal(configSecret.Data[relativeKubeconfigKey], relativeOut) {
+		s.scope.Debug("Kubeconfig token not due for refresh", "cluster-name", clusterName)
+		return nil
+	}
+
+	token, err := s.generateToken(s.tokenClusterID(cluster))
 	if err != nil {
 		return fmt.Errorf("generating presigned token: %w", err)
 	}
@@ -194,18 +218,9 @@ func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *
 		return errors.Wrap(err, "failed to serialize config to yaml")
 	}
 	configSecret.Data[secret.KubeconfigDataName] = out
-
-	config.AuthInfos = map[string]*api.AuthInfo{
-		userName: {
-			TokenFile: "./" + relativeTokenFileKey,
-		},
-	}
-	out, err = clientcmd.Write(*config)
-	if err != nil {
-		return errors.Wrap(err, "failed to serialize config to yaml")
-	}
-	configSecret.Data[relativeKubeconfigKey] = out
+	configSecret.Data[relativeKubeconfigKey] = relativeOut
 	configSecret.Data[relativeTokenFileKey] = []byte(token)
+	setTokenRefreshed(configSecret)
 
 	err = s.scope.Client.Update(ctx, configSecret)
 	if err != nil {
@@ -218,18 +233,76 @@ func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *
 func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *eks.Cluster, clusterRef *types.NamespacedName) error {
 	controllerOwnerRef := *metav1.NewControllerRef(s.scope.ControlPlane, ekscontrolplanev1.GroupVersion.WithKind("AWSManagedControlPlane"))
 
+	out, err := s.userKubeconfig(cluster)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
+	if s.scope.TokenMethod() == ekscontrolplanev1.EKSTokenMethodEmbedded {
+		setTokenRefreshed(kubeconfigSecret)
+	}
+	if err := s.scope.Client.Create(ctx, kubeconfigSecret); err != nil {
+		return errors.Wrap(err, "failed to create kubeconfig secret")
+	}
+
+	record.Eventf(s.scope.ControlPlane, "SucessfulCreateUserKubeconfig", "Created user kubeconfig for cluster %q", s.scope.Name())
+	return nil
+}
+
+func (s *Service) updateUserKubeconfigSecret(ctx context.Context, configSecret *corev1.Secret, cluster *eks.Cluster) error {
+	controllerOwnerRef := *metav1.NewControllerRef(s.scope.ControlPlane, ekscontrolplanev1.GroupVersion.WithKind("AWSManagedControlPlane"))
+	if !util.HasOwnerRef(configSecret.OwnerReferences, controllerOwnerRef) {
+		s.scope.Debug("Skipping update of user kubeconfig not owned by the control plane", "secret", configSecret.Name)
+		return nil
+	}
+
+	// The annotation is only set on secrets embedding a token.
+	_, embedded := configSecret.Annotations[tokenRefreshedAnnotation]
+	if s.scope.TokenMethod() == ekscontrolplanev1.EKSTokenMethodEmbedded && embedded && !s.tokenRefreshDue(configSecret) {
+		return nil
+	}
+
+	out, err := s.userKubeconfig(cluster)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(configSecret.Data[secret.KubeconfigDataName], out) {
+		return nil
+	}
+
+	if configSecret.Data == nil {
+		configSecret.Data = map[string][]byte{}
+	}
+	configSecret.Data[secret.KubeconfigDataName] = out
+	if s.scope.TokenMethod() == ekscontrolplanev1.EKSTokenMethodEmbedded {
+		setTokenRefreshed(configSecret)
+	} else {
+		delete(configSecret.Annotations, tokenRefreshedAnnotation)
+	}
+
+	if err := s.scope.Client.Update(ctx, configSecret); err != nil {
+		return fmt.Errorf("updating kubeconfig secret: %w", err)
+	}
+
+	return nil
+}
+
+// userKubeconfig returns the kubeconfig for users, authenticating with the token method of the control plane.
+func (s *Service) userKubeconfig(cluster *eks.Cluster) ([]byte, error) {
 	clusterName := s.scope.KubernetesClusterName()
 	userName := s.getKubeConfigUserName(clusterName, true)
 
 	cfg, err := s.createBaseKubeConfig(cluster, userName)
 	if err != nil {
-		return fmt.Errorf("creating base kubeconfig: %w", err)
+		return nil, fmt.Errorf("creating base kubeconfig: %w", err)
 	}
 
 	// Version v1alpha1 was removed in Kubernetes v1.23.
 	// Version v1 was released in Kubernetes v1.23.
 	// Version v1beta1 was selected as it has the widest range of support
 	// This should be changed to v1 once EKS no longer supports Kubernetes <v1.23
+	authInfo := &api.AuthInfo{}
 	execConfig := &api.ExecConfig{APIVersion: "client.authentication.k8s.io/v1beta1"}
 	switch s.scope.TokenMethod() {
 	case ekscontrolplanev1.EKSTokenMethodIAMAuthenticator:
@@ -237,8 +310,9 @@ func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *eks.C
 		execConfig.Args = []string{
 			"token",
 			"-i",
-			clusterName,
+			s.tokenClusterID(cluster),
 		}
+		authInfo.Exec = execConfig
 	case ekscontrolplanev1.EKSTokenMethodAWSCli:
 		execConfig.Command = "aws"
 		execConfig.Args = []string{
@@ -247,27 +321,34 @@ func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *eks.C
 			"--cluster-name",
 			clusterName,
 		}
+		if cluster.OutpostConfig != nil {
+			execConfig.Args = []string{
+				"eks",
+				"get-token",
+				"--cluster-id",
+				s.tokenClusterID(cluster),
+			}
+		}
+		authInfo.Exec = execConfig
+	case ekscontrolplanev1.EKSTokenMethodEmbedded:
+		token, err := s.generateToken(s.tokenClusterID(cluster))
+		if err != nil {
+			return nil, fmt.Errorf("generating presigned token: %w", err)
+		}
+		authInfo.Token = token
 	default:
-		return fmt.Errorf("using token method %s: %w", s.scope.TokenMethod(), ErrUnknownTokenMethod)
+		return nil, fmt.Errorf("using token method %s: %w", s.scope.TokenMethod(), ErrUnknownTokenMethod)
 	}
 	cfg.AuthInfos = map[string]*api.AuthInfo{
-		userName: {
-			Exec: execConfig,
-		},
+		userName: authInfo,
 	}
 
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
-		return errors.Wrap(err, "failed to serialize config to yaml")
+		return nil, errors.Wrap(err, "failed to serialize config to yaml")
 	}
 
-	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
-	if err := s.scope.Client.Create(ctx, kubeconfigSecret); err != nil {
-		return errors.Wrap(err, "failed to create kubeconfig secret")
-	}
-
-	record.Eventf(s.scope.ControlPlane, "SucessfulCreateUserKubeconfig", "Created user kubeconfig for cluster %q", s.scope.Name())
-	return nil
+	return out, nil
 }
 
 func (s *Service) createBaseKubeConfig(cluster *eks.Cluster, userName string) (*api.Config, error) {
@@ -299,11 +380,19 @@ func (s *Service) createBaseKubeConfig(cluster *eks.Cluster, userName string) (*
 	return cfg, nil
 }
 
-func (s *Service) generateToken() (string, error) {
-	eksClusterName := s.scope.KubernetesClusterName()
+// tokenClusterID returns the ID identifying the cluster in authentication tokens. Local clusters on
+// Outposts are identified by their cluster ID instead of their name.
+func (s *Service) tokenClusterID(cluster *eks.Cluster) string {
+	if cluster.OutpostConfig != nil && aws.StringValue(cluster.Id) != "" {
+		return aws.StringValue(cluster.Id)
+	}
+
+	return s.scope.KubernetesClusterName()
+}
 
+func (s *Service) generateToken(clusterID string) (string, error) {
 	req, output := s.STSClient.GetCallerIdentityRequest(&sts.GetCallerIdentityInput{})
-	req.HTTPRequest.Header.Add(clusterNameHeader, eksClusterName)
+	req.HTTPRequest.Header.Add(clusterNameHeader, clusterID)
 	s.Trace("generating token for AWS identity", "user", output.UserId, "account", output.Account, "arn", output.Arn)
 
 	presignedURL, err := req.Presign(tokenAgeMins * time.Minute)
@@ -315,6 +404,26 @@ func (s *Service) generateToken() (string, error) {
 	return fmt.Sprintf("%s%s", tokenPrefix, encodedURL), nil
 }
 
+// tokenRefreshDue returns whether the token embedded in the kubeconfig secret is to be regenerated,
+// i.e. whether the kubeconfig refresh interval of the control plane has passed since it was generated.
+func (s *Service) tokenRefreshDue(configSecret *corev1.Secret) bool {
+	interval := s.scope.KubeconfigRefreshInterval()
+	if interval == 0 {
+		return true
+	}
+
+	refreshed, err := time.Parse(time.RFC3339, configSecret.Annotations[tokenRefreshedAnnotation])
+	return err != nil || time.Since(refreshed) >= interval
+}
+
+// setTokenRefreshed records on the kubeconfig secret that its token has been regenerated.
+func setTokenRefreshed(configSecret *corev1.Secret) {
+	if configSecret.Annotations == nil {
+		configSecret.Annotations = map[string]string{}
+	}
+	configSecret.Annotations[tokenRefreshedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+}
+
 func (s *Service) getKubeConfigUserName(clusterName string, isUser bool) string {
 	if isUser {
 		return fmt.Sprintf("%s-user", clusterName)
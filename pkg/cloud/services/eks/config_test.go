@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
@@ -16,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
@@ -266,3 +268,213 @@ func Test_updateCAPIKubeconfigSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_tokenRefreshDue(t *testing.T) {
+	testCases := []struct {
+		name      string
+		interval  *metav1.Duration
+		refreshed string
+		expected  bool
+	}{
+		{
+			name:      "always due without refresh interval",
+			refreshed: time.Now().UTC().Format(time.RFC3339),
+			expected:  true,
+		},
+		{
+			name:     "due without refresh time",
+			interval: &metav1.Duration{Duration: 5 * time.Minute},
+			expected: true,
+		},
+		{
+			name:      "not due within the refresh interval",
+			interval:  &metav1.Duration{Duration: 5 * time.Minute},
+			refreshed: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+			expected:  false,
+		},
+		{
+			name:      "due once the refresh interval has passed",
+			interval:  &metav1.Duration{Duration: 5 * time.Minute},
+			refreshed: time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339),
+			expected:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			service := &Service{
+				scope: &scope.ManagedControlPlaneScope{
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{KubeconfigRefreshInterval: tc.interval},
+					},
+				},
+			}
+			configSecret := &corev1.Secret{}
+			if tc.refreshed != "" {
+				configSecret.Annotations = map[string]string{tokenRefreshedAnnotation: tc.refreshed}
+			}
+
+			g.Expect(service.tokenRefreshDue(configSecret)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func Test_updateUserKubeconfigSecret(t *testing.T) {
+	cluster := &eks.Cluster{
+		Name:                 aws.String("cluster-foo"),
+		CertificateAuthority: &eks.Certificate{Data: aws.String("")},
+		Endpoint:             aws.String("https://F00BA4.gr4.us-east-2.eks.amazonaws.com"),
+	}
+
+	testCases := []struct {
+		name        string
+		tokenMethod ekscontrolplanev1.EKSTokenMethod
+		annotations map[string]string
+		expectToken bool
+	}{
+		{
+			name:        "embeds a token when switching to the embedded token method",
+			tokenMethod: ekscontrolplanev1.EKSTokenMethodEmbedded,
+			expectToken: true,
+		},
+		{
+			name:        "keeps the embedded token within the refresh interval",
+			tokenMethod: ekscontrolplanev1.EKSTokenMethodEmbedded,
+			annotations: map[string]string{tokenRefreshedAnnotation: time.Now().UTC().Format(time.RFC3339)},
+		},
+		{
+			name:        "uses the AWS CLI when switching to the aws-cli token method",
+			tokenMethod: ekscontrolplanev1.EKSTokenMethodAWSCli,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			stsMock := mock_stsiface.NewMockSTSAPI(mockCtrl)
+			if tc.expectToken {
+				op := request.Request{
+					Operation: &request.Operation{Name: "GetCallerIdentity",
+						HTTPMethod: "POST",
+						HTTPPath:   "/",
+					},
+					HTTPRequest: &http.Request{
+						Header: make(http.Header),
+						URL: &url.URL{
+							Scheme: "https",
+							Host:   "F00BA4.gr4.us-east-2.eks.amazonaws.com",
+						},
+					},
+				}
+				stsMock.EXPECT().GetCallerIdentityRequest(gomock.Any()).Return(&op, &sts.GetCallerIdentityOutput{})
+			}
+
+			configSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns",
+					Name:        "capi-cluster-foo-user-kubeconfig",
+					Annotations: tc.annotations,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "controlplane.cluster.x-k8s.io/v1beta2",
+							Kind:       "AWSManagedControlPlane",
+							Name:       "capi-cluster-foo",
+							UID:        "1",
+							Controller: aws.Bool(true),
+						},
+					},
+				},
+				Data: map[string][]byte{secret.KubeconfigDataName: []byte("previous")},
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configSecret).Build()
+			managedScope, _ := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-cluster-foo",
+					},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-cluster-foo",
+						UID:       "1",
+					},
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:            "cluster-foo",
+						TokenMethod:               &tc.tokenMethod,
+						KubeconfigRefreshInterval: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			})
+			service := NewService(managedScope)
+			service.STSClient = stsMock
+
+			g.Expect(service.updateUserKubeconfigSecret(context.TODO(), configSecret, cluster)).To(Succeed())
+
+			var updated corev1.Secret
+			g.Expect(client.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "capi-cluster-foo-user-kubeconfig"}, &updated)).To(Succeed())
+			if tc.annotations != nil {
+				g.Expect(string(updated.Data[secret.KubeconfigDataName])).To(Equal("previous"))
+				return
+			}
+
+			config, err := clientcmd.Load(updated.Data[secret.KubeconfigDataName])
+			g.Expect(err).NotTo(HaveOccurred())
+			authInfo := config.AuthInfos["cluster-foo-user"]
+			g.Expect(authInfo).NotTo(BeNil())
+			if tc.expectToken {
+				g.Expect(authInfo.Token).To(HavePrefix(tokenPrefix))
+				g.Expect(updated.Annotations).To(HaveKey(tokenRefreshedAnnotation))
+			} else {
+				g.Expect(authInfo.Exec.Command).To(Equal("aws"))
+				g.Expect(updated.Annotations).NotTo(HaveKey(tokenRefreshedAnnotation))
+			}
+		})
+	}
+}
+
+func Test_tokenClusterID(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cluster  *eks.Cluster
+		expected string
+	}{
+		{
+			name:     "uses the cluster name for clusters in the region",
+			cluster:  &eks.Cluster{Id: aws.String("0123-abcd")},
+			expected: "cluster-foo",
+		},
+		{
+			name:     "uses the cluster ID for local clusters on Outposts",
+			cluster:  &eks.Cluster{Id: aws.String("0123-abcd"), OutpostConfig: &eks.OutpostConfigResponse{}},
+			expected: "0123-abcd",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			service := &Service{
+				scope: &scope.ManagedControlPlaneScope{
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster-foo"},
+					},
+				},
+			}
+
+			g.Expect(service.tokenClusterID(tc.cluster)).To(Equal(tc.expected))
+		})
+	}
+}
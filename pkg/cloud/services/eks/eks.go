@@ -44,7 +44,11 @@ func (s *Service) ReconcileControlPlane(ctx context.Context) error {
 
 	// EKS Cluster
 	if err := s.reconcileCluster(ctx); err != nil {
-		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition, ekscontrolplanev1.EKSControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		reason := ekscontrolplanev1.EKSControlPlaneReconciliationFailedReason
+		if errors.Is(err, ErrEncryptionKeyAccessDenied) {
+			reason = ekscontrolplanev1.EKSEncryptionKeyAccessDeniedReason
+		}
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition, reason, clusterv1.ConditionSeverityError, "%s", err.Error())
 		return err
 	}
 	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSControlPlaneReadyCondition)
@@ -63,6 +67,29 @@ func (s *Service) ReconcileControlPlane(ctx context.Context) error {
 	}
 	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSIdentityProviderConfiguredCondition)
 
+	// EKS Access Entries
+	switch {
+	case s.accessEntriesEnabled():
+		if err := s.reconcileAccessEntries(ctx); err != nil {
+			conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSAccessEntriesConfiguredCondition, ekscontrolplanev1.EKSAccessEntriesConfiguredFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+			return errors.Wrap(err, "failed reconciling eks access entries")
+		}
+		conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSAccessEntriesConfiguredCondition)
+	case len(s.scope.ControlPlane.Spec.AccessEntries) > 0:
+		// The authentication mode of the cluster is still being updated to one allowing access entries.
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSAccessEntriesConfiguredCondition, ekscontrolplanev1.WaitingForAuthenticationModeReason, clusterv1.ConditionSeverityInfo,
+			"authentication mode %s doesn't allow access entries", s.scope.ControlPlane.Status.AuthenticationMode)
+	default:
+		conditions.Delete(s.scope.ControlPlane, ekscontrolplanev1.EKSAccessEntriesConfiguredCondition)
+	}
+
+	// EKS Pod Identity Associations
+	if err := s.reconcilePodIdentityAssociations(ctx); err != nil {
+		conditions.MarkFalse(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return errors.Wrap(err, "failed reconciling eks pod identity associations")
+	}
+	conditions.MarkTrue(s.scope.ControlPlane, ekscontrolplanev1.EKSPodIdentityAssociationsConfiguredCondition)
+
 	s.scope.Debug("Reconcile EKS control plane completed successfully")
 	return nil
 }
@@ -81,6 +108,11 @@ func (s *Service) DeleteControlPlane() (err error) {
 		return err
 	}
 
+	// Addon IAM roles
+	if err := s.deleteAddonIAMRoles(); err != nil {
+		return err
+	}
+
 	// OIDC Provider
 	if err := s.deleteOIDCProvider(); err != nil {
 		return err
@@ -94,6 +126,33 @@ func (s *Service) DeleteControlPlane() (err error) {
 func (s *NodegroupService) ReconcilePool(ctx context.Context) error {
 	s.scope.Debug("Reconciling EKS nodegroup")
 
+	if s.scope.ControlPlane.Spec.OutpostConfig != nil {
+		err := errors.New("EKS managed node groups are not supported by local clusters on AWS Outposts")
+		conditions.MarkFalse(
+			s.scope.ManagedMachinePool,
+			expinfrav1.EKSNodegroupReadyCondition,
+			expinfrav1.EKSNodegroupReconciliationFailedReason,
+			clusterv1.ConditionSeverityError,
+			"%s",
+			err.Error(),
+		)
+		record.Warnf(s.scope.ManagedMachinePool, "FailedCreateEKSNodegroup", "%s", err.Error())
+		return err
+	}
+
+	if err := s.validateNodegroupVersionSkew(); err != nil {
+		conditions.MarkFalse(
+			s.scope.ManagedMachinePool,
+			expinfrav1.EKSNodegroupReadyCondition,
+			expinfrav1.EKSNodegroupVersionSkewReason,
+			clusterv1.ConditionSeverityError,
+			"%s",
+			err.Error(),
+		)
+		record.Warnf(s.scope.ManagedMachinePool, "InvalidEKSNodegroupVersion", "%s", err.Error())
+		return err
+	}
+
 	if err := s.reconcileNodegroupIAMRole(); err != nil {
 		conditions.MarkFalse(
 			s.scope.ManagedMachinePool,
@@ -142,6 +201,10 @@ func (s *NodegroupService) ReconcilePoolDelete() error {
 		return nil
 	}
 
+	if err := s.deleteNodegroupSecurityGroupRules(); err != nil {
+		return errors.Wrap(err, "failed to delete nodegroup security group rules")
+	}
+
 	if err := s.deleteNodegroupAndWait(); err != nil {
 		return errors.Wrap(err, "failed to delete nodegroup")
 	}
@@ -35,6 +35,13 @@ var (
 	// ErrCannotUseAdditionalRoles is an error if the spec contains additional role and the
 	// EKSAllowAddRoles feature flag isn't enabled.
 	ErrCannotUseAdditionalRoles = errors.New("additional rules cannot be added as this has been disabled")
+	// ErrCannotCreateAddonRoles is an error if an addon configures a service account role and the
+	// EKSEnableIAM feature flag isn't enabled.
+	ErrCannotCreateAddonRoles = errors.New("addon service account roles cannot be created as EKS IAM is disabled")
 	// ErrNoSecurityGroup is an error when no security group is found for an EKS cluster.
 	ErrNoSecurityGroup = errors.New("no security group for EKS cluster")
+	// ErrVCPUQuotaExceeded is an error if creating or scaling up a nodegroup would exceed the EC2 vCPU service quota.
+	ErrVCPUQuotaExceeded = errors.New("EC2 vCPU service quota exceeded")
+	// ErrEncryptionKeyAccessDenied is an error if EKS can't use the KMS key of the encryption config.
+	ErrEncryptionKeyAccessDenied = errors.New("EKS can't use the KMS key of the encryption config")
 )
@@ -25,15 +25,21 @@ import (
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/pkg/errors"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// maxProfileNameLength is the maximum length of the name of an EKS fargate profile.
+const maxProfileNameLength = 100
+
 func requeueProfileUpdating() reconcile.Result {
 	return reconcile.Result{RequeueAfter: 10 * time.Second}
 }
@@ -86,15 +92,15 @@ func (s *FargateService) Reconcile() (reconcile.Result, error) {
 }
 
 func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
-	profileName := s.scope.FargateProfile.Spec.ProfileName
+	profileName := s.profileName()
 
-	profile, err := s.describeFargateProfile()
+	profile, err := s.describeFargateProfile(profileName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to describe profile")
 	}
 
 	if eksClusterName := s.scope.KubernetesClusterName(); profile == nil {
-		profile, err = s.createFargateProfile()
+		profile, err = s.createFargateProfile(profileName)
 		if err != nil {
 			return false, errors.Wrap(err, "failed to create profile")
 		}
@@ -109,14 +115,128 @@ func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
 		}
 		s.scope.Debug("Found owned EKS fargate profile", "cluster-name", eksClusterName, "profile-name", profileName)
 	}
+	s.scope.FargateProfile.Status.ProfileName = profileName
 
 	if err := s.reconcileTags(profile); err != nil {
 		return false, errors.Wrapf(err, "failed to reconcile profile tags")
 	}
 
+	if aws.StringValue(profile.Status) == eks.FargateProfileStatusActive {
+		requeue, err := s.reconcileProfileReplacement(profile)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to replace profile")
+		}
+		if requeue {
+			return true, nil
+		}
+	}
+
 	return s.handleStatus(profile), nil
 }
 
+// profileName returns the name of the EKS fargate profile in use, which differs from
+// the name in the spec once the profile has been replaced.
+func (s *FargateService) profileName() string {
+	if s.scope.FargateProfile.Status.ProfileName != "" {
+		return s.scope.FargateProfile.Status.ProfileName
+	}
+	return s.scope.FargateProfile.Spec.ProfileName
+}
+
+// reconcileProfileReplacement replaces the active profile according to the update strategy if
+// its selectors or subnets don't match the spec, as fargate profiles can't be updated.
+func (s *FargateService) reconcileProfileReplacement(profile *eks.FargateProfile) (requeue bool, err error) {
+	profileName := aws.StringValue(profile.FargateProfileName)
+
+	if replaced := s.scope.FargateProfile.Status.ReplacedProfileName; replaced != "" {
+		deleted, err := s.deleteProfile(replaced)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to delete replaced profile %s", replaced)
+		}
+		if !deleted {
+			return true, nil
+		}
+		s.scope.FargateProfile.Status.ReplacedProfileName = ""
+		record.Eventf(s.scope.FargateProfile, "SuccessfulReplaceEKSFargateProfile", "Replaced EKS fargate profile %s with %s", replaced, profileName)
+	}
+
+	if !s.profileDrifted(profile) {
+		return false, nil
+	}
+
+	switch s.scope.FargateProfile.Spec.UpdateStrategy {
+	case expinfrav1.FargateProfileUpdateStrategyCreateBeforeDelete:
+		replacementName, err := s.replacementProfileName(profileName)
+		if err != nil {
+			return false, err
+		}
+		if _, err := s.createFargateProfile(replacementName); err != nil {
+			return false, errors.Wrapf(err, "failed to create replacement profile %s", replacementName)
+		}
+		record.Eventf(s.scope.FargateProfile, "InitiatedReplaceEKSFargateProfile", "Started replacing EKS fargate profile %s with %s", profileName, replacementName)
+		s.scope.FargateProfile.Status.ProfileName = replacementName
+		s.scope.FargateProfile.Status.ReplacedProfileName = profileName
+		// Persist the new profile name right away, so that the profile isn't created twice.
+		if err := s.scope.PatchObject(); err != nil {
+			return false, errors.Wrap(err, "failed to record replacement profile")
+		}
+		return true, nil
+	case expinfrav1.FargateProfileUpdateStrategyDeleteBeforeCreate:
+		if _, err := s.deleteProfile(profileName); err != nil {
+			return false, err
+		}
+		record.Eventf(s.scope.FargateProfile, "InitiatedReplaceEKSFargateProfile", "Started deleting EKS fargate profile %s to create it again", profileName)
+		return true, nil
+	default:
+		record.Warnf(s.scope.FargateProfile, "EKSFargateProfileDrift", "EKS fargate profile %s doesn't match the selectors or subnets of the spec, set an update strategy to replace it", profileName)
+		return false, nil
+	}
+}
+
+// profileDrifted returns whether the selectors or the subnets of the profile differ from the spec.
+// Subnets are only compared if set in the spec.
+func (s *FargateService) profileDrifted(profile *eks.FargateProfile) bool {
+	if specSubnets := s.scope.FargateProfile.Spec.SubnetIDs; len(specSubnets) > 0 {
+		subnets := aws.StringValueSlice(profile.Subnets)
+		if !sets.New(subnets...).Equal(sets.New(specSubnets...)) {
+			return true
+		}
+	}
+
+	selectorKey := func(namespace string, labels map[string]string) string {
+		return fmt.Sprintf("%s/%s", namespace, k8slabels.Set(labels).String())
+	}
+	current := sets.New[string]()
+	for _, selector := range profile.Selectors {
+		current.Insert(selectorKey(aws.StringValue(selector.Namespace), aws.StringValueMap(selector.Labels)))
+	}
+	desired := sets.New[string]()
+	for _, selector := range s.scope.FargateProfile.Spec.Selectors {
+		desired.Insert(selectorKey(selector.Namespace, selector.Labels))
+	}
+	return !current.Equal(desired)
+}
+
+// replacementProfileName returns the name of the profile replacing the current one, derived from the
+// name in the spec and the desired selectors and subnets.
+func (s *FargateService) replacementProfileName(current string) (string, error) {
+	spec := s.scope.FargateProfile.Spec
+	suffix, err := hash.Base36TruncatedHash(fmt.Sprintf("%v/%v", spec.Selectors, spec.SubnetIDs), 6)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate replacement profile name")
+	}
+
+	name := spec.ProfileName
+	if len(name)+len(suffix)+1 > maxProfileNameLength {
+		name = name[:maxProfileNameLength-len(suffix)-1]
+	}
+	name = fmt.Sprintf("%s-%s", name, suffix)
+	if name == current {
+		return spec.ProfileName, nil
+	}
+	return name, nil
+}
+
 func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool) {
 	s.Debug("fargate profile", "status", *profile.Status)
 	switch *profile.Status {
@@ -194,9 +314,8 @@ func (s *FargateService) ReconcileDelete() (reconcile.Result, error) {
 	return reconcile.Result{}, err
 }
 
-func (s *FargateService) describeFargateProfile() (*eks.FargateProfile, error) {
+func (s *FargateService) describeFargateProfile(profileName string) (*eks.FargateProfile, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
-	profileName := s.scope.FargateProfile.Spec.ProfileName
 	input := &eks.DescribeFargateProfileInput{
 		ClusterName:        aws.String(eksClusterName),
 		FargateProfileName: aws.String(profileName),
@@ -213,9 +332,8 @@ func (s *FargateService) describeFargateProfile() (*eks.FargateProfile, error) {
 	return out.FargateProfile, nil
 }
 
-func (s *FargateService) createFargateProfile() (*eks.FargateProfile, error) {
+func (s *FargateService) createFargateProfile(profileName string) (*eks.FargateProfile, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
-	profileName := s.scope.FargateProfile.Spec.ProfileName
 
 	additionalTags := s.scope.AdditionalTags()
 
@@ -264,9 +382,20 @@ func (s *FargateService) createFargateProfile() (*eks.FargateProfile, error) {
 
 func (s *FargateService) deleteFargateProfile() (requeue bool, err error) {
 	eksClusterName := s.scope.KubernetesClusterName()
-	profileName := s.scope.FargateProfile.Spec.ProfileName
+	profileName := s.profileName()
+
+	if replaced := s.scope.FargateProfile.Status.ReplacedProfileName; replaced != "" {
+		deleted, err := s.deleteProfile(replaced)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to delete replaced profile %s", replaced)
+		}
+		if !deleted {
+			return true, nil
+		}
+		s.scope.FargateProfile.Status.ReplacedProfileName = ""
+	}
 
-	profile, err := s.describeFargateProfile()
+	profile, err := s.describeFargateProfile(profileName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to describe profile")
 	}
@@ -304,6 +433,30 @@ func (s *FargateService) deleteFargateProfile() (requeue bool, err error) {
 	return s.handleStatus(profile), nil
 }
 
+// deleteProfile deletes a profile that isn't tracked by the conditions of the AWSFargateProfile. It returns
+// whether the profile is gone.
+func (s *FargateService) deleteProfile(profileName string) (deleted bool, err error) {
+	profile, err := s.describeFargateProfile(profileName)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to describe profile")
+	}
+	if profile == nil {
+		return true, nil
+	}
+	if aws.StringValue(profile.Status) != eks.FargateProfileStatusActive && aws.StringValue(profile.Status) != eks.FargateProfileStatusCreateFailed {
+		return false, nil
+	}
+
+	if _, err := s.EKSClient.DeleteFargateProfile(&eks.DeleteFargateProfileInput{
+		ClusterName:        aws.String(s.scope.KubernetesClusterName()),
+		FargateProfileName: aws.String(profileName),
+	}); err != nil {
+		return false, errors.Wrapf(err, "failed to delete fargate profile %s", profileName)
+	}
+	s.scope.Info("Deleting EKS fargate profile", "profile-name", profileName)
+	return false, nil
+}
+
 func (s *FargateService) roleArn() (*string, error) {
 	var role *iam.Role
 	if s.scope.RoleName() != "" {
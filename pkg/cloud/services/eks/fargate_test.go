@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+)
+
+func TestFargateProfileDrifted(t *testing.T) {
+	profile := &eks.FargateProfile{
+		Subnets: aws.StringSlice([]string{"subnet-1", "subnet-2"}),
+		Selectors: []*eks.FargateProfileSelector{
+			{Namespace: aws.String("default")},
+			{Namespace: aws.String("apps"), Labels: aws.StringMap(map[string]string{"app": "web", "tier": "frontend"})},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		subnetIDs []string
+		selectors []expinfrav1.FargateSelector
+		expected  bool
+	}{
+		{
+			name:      "selectors and subnets in a different order",
+			subnetIDs: []string{"subnet-2", "subnet-1"},
+			selectors: []expinfrav1.FargateSelector{
+				{Namespace: "apps", Labels: map[string]string{"tier": "frontend", "app": "web"}},
+				{Namespace: "default"},
+			},
+		},
+		{
+			name: "subnets not set in the spec",
+			selectors: []expinfrav1.FargateSelector{
+				{Namespace: "default"},
+				{Namespace: "apps", Labels: map[string]string{"app": "web", "tier": "frontend"}},
+			},
+		},
+		{
+			name:      "changed selector labels",
+			subnetIDs: []string{"subnet-1", "subnet-2"},
+			selectors: []expinfrav1.FargateSelector{
+				{Namespace: "default"},
+				{Namespace: "apps", Labels: map[string]string{"app": "api"}},
+			},
+			expected: true,
+		},
+		{
+			name:      "changed subnets",
+			subnetIDs: []string{"subnet-1", "subnet-3"},
+			selectors: []expinfrav1.FargateSelector{
+				{Namespace: "default"},
+				{Namespace: "apps", Labels: map[string]string{"app": "web", "tier": "frontend"}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &FargateService{
+				scope: &scope.FargateProfileScope{
+					FargateProfile: &expinfrav1.AWSFargateProfile{
+						Spec: expinfrav1.FargateProfileSpec{SubnetIDs: tc.subnetIDs, Selectors: tc.selectors},
+					},
+				},
+			}
+			g.Expect(s.profileDrifted(profile)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestReconcileProfileReplacement(t *testing.T) {
+	drifted := &eks.FargateProfile{
+		FargateProfileName: aws.String("profile"),
+		Status:             aws.String(eks.FargateProfileStatusActive),
+		Selectors:          []*eks.FargateProfileSelector{{Namespace: aws.String("default")}},
+	}
+	deleteInput := func(name string) *eks.DeleteFargateProfileInput {
+		return &eks.DeleteFargateProfileInput{ClusterName: aws.String("cluster"), FargateProfileName: aws.String(name)}
+	}
+	describeInput := func(name string) *eks.DescribeFargateProfileInput {
+		return &eks.DescribeFargateProfileInput{ClusterName: aws.String("cluster"), FargateProfileName: aws.String(name)}
+	}
+
+	testCases := []struct {
+		name             string
+		updateStrategy   expinfrav1.FargateProfileUpdateStrategy
+		replacedProfile  string
+		expect           func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectRequeue    bool
+		expectedReplaced string
+	}{
+		{
+			name: "leaves the profile as is without update strategy",
+		},
+		{
+			name:           "deletes the profile to create it again",
+			updateStrategy: expinfrav1.FargateProfileUpdateStrategyDeleteBeforeCreate,
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeFargateProfile(describeInput("profile")).Return(&eks.DescribeFargateProfileOutput{FargateProfile: drifted}, nil)
+				m.DeleteFargateProfile(deleteInput("profile")).Return(&eks.DeleteFargateProfileOutput{}, nil)
+			},
+			expectRequeue: true,
+		},
+		{
+			name:            "waits for the replaced profile to be deleted",
+			updateStrategy:  expinfrav1.FargateProfileUpdateStrategyCreateBeforeDelete,
+			replacedProfile: "profile-old",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.DescribeFargateProfile(describeInput("profile-old")).Return(&eks.DescribeFargateProfileOutput{
+					FargateProfile: &eks.FargateProfile{Status: aws.String(eks.FargateProfileStatusDeleting)},
+				}, nil)
+			},
+			expectRequeue:    true,
+			expectedReplaced: "profile-old",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+
+			s := &FargateService{
+				scope: &scope.FargateProfileScope{
+					Logger: *logger.NewLogger(logr.Discard()),
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+					},
+					FargateProfile: &expinfrav1.AWSFargateProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile", Namespace: "default"},
+						Spec: expinfrav1.FargateProfileSpec{
+							ProfileName:    "profile",
+							Selectors:      []expinfrav1.FargateSelector{{Namespace: "apps"}},
+							UpdateStrategy: tc.updateStrategy,
+						},
+						Status: expinfrav1.FargateProfileStatus{ReplacedProfileName: tc.replacedProfile},
+					},
+				},
+				EKSClient: eksMock,
+			}
+
+			requeue, err := s.reconcileProfileReplacement(drifted)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requeue).To(Equal(tc.expectRequeue))
+			g.Expect(s.scope.FargateProfile.Status.ReplacedProfileName).To(Equal(tc.expectedReplaced))
+		})
+	}
+}
+
+func TestReplacementProfileName(t *testing.T) {
+	g := NewWithT(t)
+
+	s := &FargateService{
+		scope: &scope.FargateProfileScope{
+			FargateProfile: &expinfrav1.AWSFargateProfile{
+				Spec: expinfrav1.FargateProfileSpec{
+					ProfileName: "profile",
+					Selectors:   []expinfrav1.FargateSelector{{Namespace: "apps"}},
+				},
+			},
+		},
+	}
+
+	name, err := s.replacementProfileName("profile")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(HavePrefix("profile-"))
+	g.Expect(name).To(HaveLen(len("profile-") + 6))
+
+	// Replacing the replacement switches back to the profile name.
+	name, err = s.replacementProfileName(name)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(Equal("profile"))
+}
@@ -22,12 +22,14 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
@@ -310,11 +312,73 @@ func (s *IAMService) detachAllPoliciesForRole(name string) error {
 	return nil
 }
 
+// EnsureInlinePolicy will ensure the role has an inline policy with the given name and policy document.
+func (s *IAMService) EnsureInlinePolicy(roleName, policyName string, policy *iamv1.PolicyDocument) error {
+	out, err := s.IAMClient.GetRolePolicy(&iam.GetRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(policyName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != iam.ErrCodeNoSuchEntityException {
+			return errors.Wrapf(err, "error getting inline policy %s of role %s", policyName, roleName)
+		}
+	} else {
+		currentRaw, err := url.PathUnescape(aws.StringValue(out.PolicyDocument))
+		if err != nil {
+			return errors.Wrap(err, "couldn't decode inline policy document")
+		}
+		var current iamv1.PolicyDocument
+		if err := json.Unmarshal([]byte(currentRaw), &current); err != nil {
+			return errors.Wrap(err, "couldn't unmarshal inline policy document")
+		}
+		if cmp.Equal(*policy, current) {
+			return nil
+		}
+	}
+
+	policyJSON, err := converters.IAMPolicyDocumentToJSON(*policy)
+	if err != nil {
+		return errors.Wrap(err, "error converting inline policy to json")
+	}
+	s.Debug("Putting inline policy", "role", roleName, "policy", policyName)
+	if _, err := s.IAMClient.PutRolePolicy(&iam.PutRolePolicyInput{
+		RoleName:       aws.String(roleName),
+		PolicyName:     aws.String(policyName),
+		PolicyDocument: aws.String(policyJSON),
+	}); err != nil {
+		return errors.Wrapf(err, "error putting inline policy %s on role %s", policyName, roleName)
+	}
+
+	return nil
+}
+
+func (s *IAMService) deleteAllInlinePoliciesForRole(name string) error {
+	s.Debug("Deleting all inline policies for role", "role", name)
+	out, err := s.IAMClient.ListRolePolicies(&iam.ListRolePoliciesInput{
+		RoleName: aws.String(name),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error listing inline policies for role %s", name)
+	}
+	for _, policyName := range out.PolicyNames {
+		if _, err := s.IAMClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+			RoleName:   aws.String(name),
+			PolicyName: policyName,
+		}); err != nil {
+			return errors.Wrapf(err, "error deleting inline policy %s of role %s", *policyName, name)
+		}
+	}
+	return nil
+}
+
 // DeleteRole will delete a role from the IAMService.
 func (s *IAMService) DeleteRole(name string) error {
 	if err := s.detachAllPoliciesForRole(name); err != nil {
 		return errors.Wrapf(err, "error detaching policies for role %s", name)
 	}
+	if err := s.deleteAllInlinePoliciesForRole(name); err != nil {
+		return errors.Wrapf(err, "error deleting inline policies for role %s", name)
+	}
 
 	input := &iam.DeleteRoleInput{
 		RoleName: aws.String(name),
@@ -405,6 +469,62 @@ func NodegroupTrustRelationship() *iamv1.PolicyDocument {
 	return policy
 }
 
+// ServiceAccountTrustRelationship will generate a PolicyDocument which allows the given service account
+// to assume a role through the OIDC provider of the cluster (IRSA).
+func ServiceAccountTrustRelationship(oidcProviderARN, namespace, name string) *iamv1.PolicyDocument {
+	issuer := oidcProviderARN[strings.Index(oidcProviderARN, "/")+1:]
+
+	return &iamv1.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect: "Allow",
+				Action: []string{
+					"sts:AssumeRoleWithWebIdentity",
+				},
+				Principal: iamv1.Principals{
+					iamv1.PrincipalFederated: iamv1.PrincipalID{oidcProviderARN},
+				},
+				Condition: iamv1.Conditions{
+					// The values are interfaces, so that the document compares equal to the one unmarshaled from the role.
+					"StringEquals": map[string]interface{}{
+						issuer + ":sub": fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+						issuer + ":aud": "sts.amazonaws.com",
+					},
+				},
+			},
+		},
+	}
+}
+
+// NodegroupIPv6CNIPolicy will generate the PolicyDocument which allows the VPC CNI plugin
+// to assign IPv6 addresses to pods, which isn't covered by the AmazonEKS_CNI_Policy.
+func NodegroupIPv6CNIPolicy(partition string) *iamv1.PolicyDocument {
+	return &iamv1.PolicyDocument{
+		Version: "2012-10-17",
+		Statement: []iamv1.StatementEntry{
+			{
+				Effect: iamv1.EffectAllow,
+				Action: iamv1.Actions{
+					"ec2:AssignIpv6Addresses",
+					"ec2:DescribeInstances",
+					"ec2:DescribeTags",
+					"ec2:DescribeNetworkInterfaces",
+					"ec2:DescribeInstanceTypes",
+				},
+				Resource: iamv1.Resources{"*"},
+			},
+			{
+				Effect: iamv1.EffectAllow,
+				Action: iamv1.Actions{
+					"ec2:CreateTags",
+				},
+				Resource: iamv1.Resources{"arn:" + partition + ":ec2:*:*:network-interface/*"},
+			},
+		},
+	}
+}
+
 func findStringInSlice(slice []*string, toFind string) bool {
 	for _, item := range slice {
 		if *item == toFind {
@@ -31,7 +31,8 @@ import (
 
 func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 	s.scope.Info("reconciling oidc identity provider")
-	if s.scope.OIDCIdentityProviderConfig() == nil {
+	// Without a config, only an identity provider associated before needs to be disassociated.
+	if s.scope.OIDCIdentityProviderConfig() == nil && s.scope.ControlPlane.Status.IdentityProviderStatus.ARN == "" {
 		s.scope.Info("no oidc provider config, skipping reconcile")
 		return nil
 	}
@@ -46,7 +47,7 @@ func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 
 	if desired == nil && current == nil {
 		s.scope.Info("no identity provider required or installed, no action needed")
-		return nil
+		return s.updateIdentityProviderStatus(nil)
 	}
 
 	s.scope.Debug("creating oidc provider plan", "desired", desired, "current", current)
@@ -79,21 +80,25 @@ func (s *Service) reconcileIdentityProvider(ctx context.Context) error {
 		return errors.Wrap(err, "getting associated identity provider")
 	}
 
-	if latest == nil {
-		return nil
+	return s.updateIdentityProviderStatus(latest)
+}
+
+// updateIdentityProviderStatus records the associated identity provider in the status, which is cleared
+// if no identity provider is associated.
+func (s *Service) updateIdentityProviderStatus(latest *identityprovider.OidcIdentityProviderConfig) error {
+	status := ekscontrolplanev1.IdentityProviderStatus{}
+	if latest != nil {
+		status.ARN = latest.IdentityProviderConfigArn
+		status.Status = latest.Status
 	}
 
 	// don't patch if arn/status is the same
-	if latest.IdentityProviderConfigArn == s.scope.ControlPlane.Status.IdentityProviderStatus.ARN &&
-		latest.Status == s.scope.ControlPlane.Status.IdentityProviderStatus.Status {
+	if status == s.scope.ControlPlane.Status.IdentityProviderStatus {
 		return nil
 	}
 
 	// idp status has changed, patch the control plane
-	s.scope.ControlPlane.Status.IdentityProviderStatus = ekscontrolplanev1.IdentityProviderStatus{
-		ARN:    latest.IdentityProviderConfigArn,
-		Status: latest.Status,
-	}
+	s.scope.ControlPlane.Status.IdentityProviderStatus = status
 
 	if err := s.scope.PatchObject(); err != nil {
 		return errors.Wrap(err, "updating identity provider status")
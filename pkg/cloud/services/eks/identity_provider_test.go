@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcileIdentityProviderRemoval(t *testing.T) {
+	const (
+		clusterName = "cluster-test"
+		configARN   = "arn:aws:eks:us-east-1:123456789012:identityproviderconfig/cluster-test/oidc/dex/1"
+	)
+
+	listConfigs := func(m *mock_eksiface.MockEKSAPIMockRecorder, configs ...*eks.IdentityProviderConfig) {
+		m.ListIdentityProviderConfigsWithContext(gomock.Any(), &eks.ListIdentityProviderConfigsInput{ClusterName: aws.String(clusterName)}).
+			Return(&eks.ListIdentityProviderConfigsOutput{IdentityProviderConfigs: configs}, nil)
+	}
+	describeConfig := func(m *mock_eksiface.MockEKSAPIMockRecorder, status string) {
+		m.DescribeIdentityProviderConfigWithContext(gomock.Any(), gomock.Any()).
+			Return(&eks.DescribeIdentityProviderConfigOutput{
+				IdentityProviderConfig: &eks.IdentityProviderConfigResponse{
+					Oidc: &eks.OidcIdentityProviderConfig{
+						IdentityProviderConfigArn:  aws.String(configARN),
+						IdentityProviderConfigName: aws.String("dex"),
+						Status:                     aws.String(status),
+					},
+				},
+			}, nil)
+	}
+	dex := &eks.IdentityProviderConfig{Name: aws.String("dex"), Type: aws.String("oidc")}
+
+	tests := []struct {
+		name           string
+		status         ekscontrolplanev1.IdentityProviderStatus
+		expect         func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedStatus ekscontrolplanev1.IdentityProviderStatus
+	}{
+		{
+			name: "no identity provider configured or associated",
+		},
+		{
+			name:   "disassociates the identity provider once the config is removed",
+			status: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusActive},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listConfigs(m, dex)
+				describeConfig(m, eks.ConfigStatusActive)
+				m.DisassociateIdentityProviderConfigWithContext(gomock.Any(), &eks.DisassociateIdentityProviderConfigInput{
+					ClusterName:            aws.String(clusterName),
+					IdentityProviderConfig: dex,
+				}).Return(&eks.DisassociateIdentityProviderConfigOutput{}, nil)
+				listConfigs(m, dex)
+				describeConfig(m, eks.ConfigStatusDeleting)
+			},
+			expectedStatus: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusDeleting},
+		},
+		{
+			name:   "clears the status once the identity provider is gone",
+			status: ekscontrolplanev1.IdentityProviderStatus{ARN: configARN, Status: eks.ConfigStatusDeleting},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listConfigs(m)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: clusterName,
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+					IdentityProviderStatus: tc.status,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).WithStatusSubresource(controlPlane).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcileIdentityProvider(context.TODO())).To(Succeed())
+			g.Expect(scope.ControlPlane.Status.IdentityProviderStatus).To(Equal(tc.expectedStatus))
+		})
+	}
+}
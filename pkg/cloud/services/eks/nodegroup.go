@@ -19,6 +19,8 @@ package eks
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -37,7 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
-	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func (s *NodegroupService) describeNodegroup() (*eks.Nodegroup, error) {
@@ -109,12 +111,29 @@ func (s *NodegroupService) scalingConfig() *eks.NodegroupScalingConfig {
 	if scaling.MaxSize != nil {
 		cfg.MaxSize = aws.Int64(int64(*scaling.MaxSize))
 	}
-	if scaling.MaxSize != nil {
+	if scaling.MinSize != nil {
 		cfg.MinSize = aws.Int64(int64(*scaling.MinSize))
 	}
 	return &cfg
 }
 
+// externalScalingConfig returns the scaling configuration for a nodegroup whose
+// desired size is managed by an external autoscaler. Only min and max are set, the
+// desired size is left alone unless it falls outside of the new bounds.
+func (s *NodegroupService) externalScalingConfig(ng *eks.Nodegroup) *eks.NodegroupScalingConfig {
+	cfg := s.scalingConfig()
+	cfg.DesiredSize = nil
+
+	desired := aws.Int64Value(ng.ScalingConfig.DesiredSize)
+	switch {
+	case cfg.MinSize != nil && desired < *cfg.MinSize:
+		cfg.DesiredSize = aws.Int64(*cfg.MinSize)
+	case cfg.MaxSize != nil && desired > *cfg.MaxSize:
+		cfg.DesiredSize = aws.Int64(*cfg.MaxSize)
+	}
+	return cfg
+}
+
 func (s *NodegroupService) updateConfig() *eks.NodegroupUpdateConfig {
 	updateConfig := s.scope.ManagedMachinePool.Spec.UpdateConfig
 
@@ -139,6 +158,27 @@ func ngTags(key string, additionalTags infrav1.Tags) map[string]string {
 	return tags
 }
 
+// launchTemplate returns the launch template of the nodegroup, which is either the one created from
+// AWSLaunchTemplate or the one referenced by LaunchTemplate.
+func (s *NodegroupService) launchTemplate() *eks.LaunchTemplateSpecification {
+	pool := s.scope.ManagedMachinePool
+	switch {
+	case pool.Spec.AWSLaunchTemplate != nil:
+		return &eks.LaunchTemplateSpecification{
+			Id:      pool.Status.LaunchTemplateID,
+			Version: pool.Status.LaunchTemplateVersion,
+		}
+	case pool.Spec.LaunchTemplate != nil:
+		return &eks.LaunchTemplateSpecification{
+			Id:      pool.Spec.LaunchTemplate.ID,
+			Name:    pool.Spec.LaunchTemplate.Name,
+			Version: pool.Spec.LaunchTemplate.Version,
+		}
+	default:
+		return nil
+	}
+}
+
 func (s *NodegroupService) remoteAccess() (*eks.RemoteAccessConfig, error) {
 	pool := s.scope.ManagedMachinePool.Spec
 	if pool.RemoteAccess == nil {
@@ -179,6 +219,10 @@ func (s *NodegroupService) remoteAccess() (*eks.RemoteAccessConfig, error) {
 	if sshKeyName == nil {
 		sshKeyName = controlPlane.Spec.SSHKeyName
 	}
+	// EKS rejects remote access without an EC2 key pair.
+	if aws.StringValue(sshKeyName) == "" {
+		return nil, errors.New("remote access requires an SSH key name on the machine pool or the control plane")
+	}
 
 	return &eks.RemoteAccessConfig{
 		SourceSecurityGroups: aws.StringSlice(sSGs),
@@ -218,7 +262,14 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		RemoteAccess:  remoteAccess,
 		UpdateConfig:  s.updateConfig(),
 	}
-	if managedPool.AMIType != nil && (managedPool.AWSLaunchTemplate == nil || managedPool.AWSLaunchTemplate.AMI.ID == nil) {
+	if requestedVersion := s.requestedVersion(); requestedVersion != nil {
+		ngVersion, err := parseEKSVersion(*requestedVersion)
+		if err != nil {
+			return nil, fmt.Errorf("parsing EKS version from spec: %w", err)
+		}
+		input.Version = aws.String(versionToEKS(ngVersion))
+	}
+	if managedPool.AMIType != nil && *managedPool.AMIType != expinfrav1.Custom && (managedPool.AWSLaunchTemplate == nil || managedPool.AWSLaunchTemplate.AMI.ID == nil) {
 		input.AmiType = aws.String(string(*managedPool.AMIType))
 	}
 	if managedPool.DiskSize != nil {
@@ -227,6 +278,9 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 	if managedPool.InstanceType != nil {
 		input.InstanceTypes = []*string{managedPool.InstanceType}
 	}
+	if len(managedPool.InstanceTypes) > 0 {
+		input.InstanceTypes = aws.StringSlice(managedPool.InstanceTypes)
+	}
 	if len(managedPool.Taints) > 0 {
 		s.Info("adding taints to nodegroup", "nodegroup", nodegroupName)
 		taints, err := converters.TaintsToSDK(managedPool.Taints)
@@ -242,16 +296,14 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		}
 		input.CapacityType = aws.String(capacityType)
 	}
-	if managedPool.AWSLaunchTemplate != nil {
-		input.LaunchTemplate = &eks.LaunchTemplateSpecification{
-			Id:      s.scope.ManagedMachinePool.Status.LaunchTemplateID,
-			Version: s.scope.ManagedMachinePool.Status.LaunchTemplateVersion,
-		}
-	}
+	input.LaunchTemplate = s.launchTemplate()
 
 	if err := input.Validate(); err != nil {
 		return nil, errors.Wrap(err, "created invalid CreateNodegroupInput")
 	}
+	if err := s.checkVCPUQuota(context.TODO(), 0, aws.Int64Value(input.ScalingConfig.DesiredSize)); err != nil {
+		return nil, err
+	}
 
 	out, err := s.EKSClient.CreateNodegroup(input)
 	if err != nil {
@@ -324,7 +376,53 @@ func (s *NodegroupService) deleteNodegroupAndWait() (reterr error) {
 	return nil
 }
 
-func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
+// requestedVersion returns the nodegroup version explicitly requested by the AWSManagedMachinePool or the
+// MachinePool, if any. Nodegroups without a requested version are created with the control plane version.
+func (s *NodegroupService) requestedVersion() *string {
+	if s.scope.ManagedMachinePool.Spec.Version != nil {
+		return s.scope.ManagedMachinePool.Spec.Version
+	}
+	if s.orchestratedUpgrade() {
+		return nil
+	}
+	return s.scope.MachinePool.Spec.Template.Spec.Version
+}
+
+// validateNodegroupVersionSkew checks that an explicitly requested nodegroup version is
+// at most one minor version behind and never ahead of the control plane version.
+func (s *NodegroupService) validateNodegroupVersionSkew() error {
+	requestedVersion := s.requestedVersion()
+	if requestedVersion == nil {
+		return nil
+	}
+	nodegroupVersion, err := parseEKSVersion(*requestedVersion)
+	if err != nil {
+		return fmt.Errorf("parsing EKS version from spec: %w", err)
+	}
+
+	controlPlaneRawVersion := s.scope.ControlPlane.Status.Version
+	if controlPlaneRawVersion == nil {
+		controlPlaneRawVersion = s.scope.ControlPlane.Spec.Version
+	}
+	if controlPlaneRawVersion == nil {
+		return nil
+	}
+	controlPlaneVersion, err := parseEKSVersion(*controlPlaneRawVersion)
+	if err != nil {
+		return fmt.Errorf("parsing EKS control plane version: %w", err)
+	}
+
+	if nodegroupVersion.Major() != controlPlaneVersion.Major() ||
+		nodegroupVersion.Minor() > controlPlaneVersion.Minor() ||
+		nodegroupVersion.Minor()+1 < controlPlaneVersion.Minor() {
+		return fmt.Errorf("nodegroup version %s must be within one minor version of the control plane version %s",
+			versionToEKS(nodegroupVersion), versionToEKS(controlPlaneVersion))
+	}
+
+	return nil
+}
+
+func (s *NodegroupService) reconcileNodegroupVersion(ctx context.Context, ng *eks.Nodegroup) error {
 	var specVersion *version.Version
 	if s.scope.Version() != nil {
 		var err error
@@ -341,14 +439,15 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 	ngVersion := version.MustParseGeneric(*ng.Version)
 	specAMI := s.scope.ManagedMachinePool.Spec.AMIVersion
 	ngAMI := *ng.ReleaseVersion
-	statusLaunchTemplateVersion := s.scope.ManagedMachinePool.Status.LaunchTemplateVersion
-	var ngLaunchTemplateVersion *string
+	launchTemplate := s.launchTemplate()
+	var ngLaunchTemplateVersion string
 	if ng.LaunchTemplate != nil {
-		ngLaunchTemplateVersion = ng.LaunchTemplate.Version
+		ngLaunchTemplateVersion = aws.StringValue(ng.LaunchTemplate.Version)
 	}
+	launchTemplateChanged := launchTemplate != nil && launchTemplate.Version != nil && *launchTemplate.Version != ngLaunchTemplateVersion
 
 	eksClusterName := s.scope.KubernetesClusterName()
-	if (specVersion != nil && ngVersion.LessThan(specVersion)) || (specAMI != nil && *specAMI != ngAMI) || (statusLaunchTemplateVersion != nil && *statusLaunchTemplateVersion != *ngLaunchTemplateVersion) {
+	if (specVersion != nil && ngVersion.LessThan(specVersion)) || (specAMI != nil && *specAMI != ngAMI) || launchTemplateChanged {
 		input := &eks.UpdateNodegroupVersionInput{
 			ClusterName:   aws.String(eksClusterName),
 			NodegroupName: aws.String(s.scope.NodegroupName()),
@@ -357,22 +456,36 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 		var updateMsg string
 		// Either update k8s version or AMI version
 		switch {
-		case statusLaunchTemplateVersion != nil && *statusLaunchTemplateVersion != *ngLaunchTemplateVersion:
-			input.LaunchTemplate = &eks.LaunchTemplateSpecification{
-				Id:      s.scope.ManagedMachinePool.Status.LaunchTemplateID,
-				Version: statusLaunchTemplateVersion,
-			}
-			updateMsg = fmt.Sprintf("to launch template version %s", *statusLaunchTemplateVersion)
+		case launchTemplateChanged:
+			input.LaunchTemplate = launchTemplate
+			updateMsg = fmt.Sprintf("to launch template version %s", *launchTemplate.Version)
 		case specVersion != nil && ngVersion.LessThan(specVersion):
 			// NOTE: you can only upgrade increments of minor versions. If you want to upgrade 1.14 to 1.16 we
 			// need to go 1.14-> 1.15 and then 1.15 -> 1.16.
-			input.Version = aws.String(versionToEKS(ngVersion.WithMinor(ngVersion.Minor() + 1)))
+			nextVersion := ngVersion.WithMinor(ngVersion.Minor() + 1)
+			if s.orchestratedUpgrade() {
+				blocker, err := s.nodegroupUpgradeBlocker(ctx, nextVersion)
+				if err != nil {
+					return err
+				}
+				if blocker != "" {
+					s.scope.Info("Postponing nodegroup upgrade", "version", versionToEKS(nextVersion), "reason", blocker)
+					conditions.MarkFalse(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupUpgradedCondition, expinfrav1.WaitingForNodegroupUpgradeReason, clusterv1.ConditionSeverityInfo, "%s", blocker)
+					return nil
+				}
+			}
+			input.Version = aws.String(versionToEKS(nextVersion))
 			updateMsg = fmt.Sprintf("to version %s", *input.Version)
 		case specAMI != nil && *specAMI != ngAMI:
 			input.ReleaseVersion = specAMI
 			updateMsg = fmt.Sprintf("to AMI version %s", *input.ReleaseVersion)
 		}
 
+		forceUpdate := s.scope.ManagedMachinePool.Spec.ForceUpdate
+		if forceUpdate {
+			input.Force = aws.Bool(true)
+		}
+
 		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 			if _, err := s.EKSClient.UpdateNodegroupVersion(input); err != nil {
 				if aerr, ok := err.(awserr.Error); ok {
@@ -381,12 +494,24 @@ func (s *NodegroupService) reconcileNodegroupVersion(ng *eks.Nodegroup) error {
 				return false, err
 			}
 			record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroup", "Updated EKS nodegroup %s %s", eksClusterName, updateMsg)
+			if forceUpdate {
+				record.Warnf(s.scope.ManagedMachinePool, "ForcedUpdateEKSNodegroup", "Forced update of EKS nodegroup %s %s, pod disruption budgets are not respected", eksClusterName, updateMsg)
+			}
 			return true, nil
 		}); err != nil {
 			record.Warnf(s.scope.ManagedMachinePool, "FailedUpdateEKSNodegroup", "failed to update the EKS nodegroup %s %s: %v", eksClusterName, updateMsg, err)
 			return errors.Wrapf(err, "failed to update EKS nodegroup")
 		}
 	}
+
+	switch {
+	case !s.orchestratedUpgrade():
+		conditions.Delete(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupUpgradedCondition)
+	case specVersion != nil && ngVersion.LessThan(specVersion):
+		conditions.MarkFalse(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupUpgradedCondition, expinfrav1.EKSNodegroupUpgradingReason, clusterv1.ConditionSeverityInfo, "upgrading to version %s", versionToEKS(specVersion))
+	default:
+		conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupUpgradedCondition)
+	}
 	return nil
 }
 
@@ -400,17 +525,48 @@ func createLabelUpdate(specLabels map[string]string, ng *eks.Nodegroup) *eks.Upd
 			payload.AddOrUpdateLabels[k] = aws.String(v)
 		}
 	}
+	var removed []string
 	for k := range current {
 		if _, ok := specLabels[k]; !ok {
-			payload.RemoveLabels = append(payload.RemoveLabels, aws.String(k))
+			removed = append(removed, k)
 		}
 	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		payload.RemoveLabels = aws.StringSlice(removed)
+	}
 	if len(payload.AddOrUpdateLabels) > 0 || len(payload.RemoveLabels) > 0 {
 		return &payload
 	}
 	return nil
 }
 
+// describeLabelUpdate summarizes the labels added, updated and removed by a label update payload.
+func describeLabelUpdate(payload *eks.UpdateLabelsPayload, ng *eks.Nodegroup) string {
+	var added, updated []string
+	for k := range payload.AddOrUpdateLabels {
+		if _, ok := ng.Labels[k]; ok {
+			updated = append(updated, k)
+		} else {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(updated)
+
+	var changes []string
+	if len(added) > 0 {
+		changes = append(changes, "added "+strings.Join(added, ", "))
+	}
+	if len(updated) > 0 {
+		changes = append(changes, "updated "+strings.Join(updated, ", "))
+	}
+	if len(payload.RemoveLabels) > 0 {
+		changes = append(changes, "removed "+strings.Join(aws.StringValueSlice(payload.RemoveLabels), ", "))
+	}
+	return strings.Join(changes, "; ")
+}
+
 func (s *NodegroupService) createTaintsUpdate(specTaints expinfrav1.Taints, ng *eks.Nodegroup) (*eks.UpdateTaintsPayload, error) {
 	s.Debug("Creating taints update for node group", "name", *ng.NodegroupName, "num_current", len(ng.Taints), "num_required", len(specTaints))
 	current, err := converters.TaintsFromSDK(ng.Taints)
@@ -430,7 +586,8 @@ func (s *NodegroupService) createTaintsUpdate(specTaints expinfrav1.Taints, ng *
 	}
 	for _, currentTaint := range current {
 		ct := currentTaint.DeepCopy()
-		if !specTaints.Contains(ct) {
+		// EKS identifies taints by key and effect, so a taint whose value changed is only added or updated.
+		if !specTaints.Contains(ct) && !containsTaintKeyEffect(specTaints, ct) {
 			sdkTaint, err := converters.TaintToSDK(*ct)
 			if err != nil {
 				return nil, fmt.Errorf("converting taint to sdk: %w", err)
@@ -447,6 +604,15 @@ func (s *NodegroupService) createTaintsUpdate(specTaints expinfrav1.Taints, ng *
 	return nil, nil
 }
 
+func containsTaintKeyEffect(taints expinfrav1.Taints, taint *expinfrav1.Taint) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && t.Effect == taint.Effect {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 	eksClusterName := s.scope.KubernetesClusterName()
 	s.Debug("reconciling node group config", "cluster", eksClusterName, "name", *ng.NodegroupName)
@@ -471,7 +637,13 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		input.Taints = taintsPayload
 		needsUpdate = true
 	}
-	if machinePool := s.scope.MachinePool.Spec; machinePool.Replicas == nil {
+	externallyScaled := s.scope.ReplicasExternallyManaged()
+	if machinePool := s.scope.MachinePool.Spec; externallyScaled {
+		if machinePool.Replicas != nil && int64(*machinePool.Replicas) != aws.Int64Value(ng.ScalingConfig.DesiredSize) {
+			s.Debug("Nodegroup desired size was changed externally, leaving it to the autoscaler", "nodegroup", ng.NodegroupName,
+				"replicas", *machinePool.Replicas, "desired", aws.Int64Value(ng.ScalingConfig.DesiredSize))
+		}
+	} else if machinePool.Replicas == nil {
 		if ng.ScalingConfig.DesiredSize != nil && *ng.ScalingConfig.DesiredSize != 1 {
 			s.Debug("Nodegroup desired size differs from spec, updating scaling configuration", "nodegroup", ng.NodegroupName)
 			input.ScalingConfig = s.scalingConfig()
@@ -485,17 +657,31 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 	if managedPool.Scaling != nil && ((aws.Int64Value(ng.ScalingConfig.MaxSize) != int64(aws.Int32Value(managedPool.Scaling.MaxSize))) ||
 		(aws.Int64Value(ng.ScalingConfig.MinSize) != int64(aws.Int32Value(managedPool.Scaling.MinSize)))) {
 		s.Debug("Nodegroup min/max differ from spec, updating scaling configuration", "nodegroup", ng.NodegroupName)
-		input.ScalingConfig = s.scalingConfig()
+		if externallyScaled {
+			input.ScalingConfig = s.externalScalingConfig(ng)
+		} else {
+			input.ScalingConfig = s.scalingConfig()
+		}
 		needsUpdate = true
 	}
+	// A nodegroup always has an update config in EKS, so an unset one in the spec leaves it as is.
 	currentUpdateConfig := converters.NodegroupUpdateconfigFromSDK(ng.UpdateConfig)
-	if !cmp.Equal(managedPool.UpdateConfig, currentUpdateConfig) {
+	if managedPool.UpdateConfig != nil && !cmp.Equal(managedPool.UpdateConfig, currentUpdateConfig) {
 		s.Debug("Nodegroup update configuration differs from spec, updating the nodegroup update config", "nodegroup", ng.NodegroupName)
 		input.UpdateConfig = s.updateConfig()
 		needsUpdate = true
 	}
+	// The quota is checked without a scale up as well, which clears the condition of a scale up refused before.
+	desiredSize := aws.Int64Value(ng.ScalingConfig.DesiredSize)
+	if input.ScalingConfig != nil && input.ScalingConfig.DesiredSize != nil {
+		desiredSize = *input.ScalingConfig.DesiredSize
+	}
+	if err := s.checkVCPUQuota(context.TODO(), aws.Int64Value(ng.ScalingConfig.DesiredSize), desiredSize); err != nil {
+		return err
+	}
 	if !needsUpdate {
 		s.Debug("node group config update not needed", "cluster", eksClusterName, "name", *ng.NodegroupName)
+		conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupConfigUpdatedCondition)
 		return nil
 	}
 	if err := input.Validate(); err != nil {
@@ -504,8 +690,15 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 
 	_, err = s.EKSClient.UpdateNodegroupConfig(input)
 	if err != nil {
+		record.Warnf(s.scope.ManagedMachinePool, "FailedUpdateEKSNodegroupConfig", "Failed to update the config of EKS nodegroup %s: %v", *ng.NodegroupName, err)
+		conditions.MarkFalse(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupConfigUpdatedCondition, expinfrav1.EKSNodegroupConfigUpdateFailedReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
 		return errors.Wrap(err, "failed to update nodegroup config")
 	}
+	record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroupConfig", "Updated the config of EKS nodegroup %s", *ng.NodegroupName)
+	if input.Labels != nil {
+		record.Eventf(s.scope.ManagedMachinePool, "SuccessfulUpdateEKSNodegroupLabels", "Converged the labels of EKS nodegroup %s to the spec: %s", *ng.NodegroupName, describeLabelUpdate(input.Labels, ng))
+	}
+	conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupConfigUpdatedCondition)
 
 	return nil
 }
@@ -542,7 +735,7 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		break
 	}
 
-	if annotations.ReplicasManagedByExternalAutoscaler(s.scope.MachinePool) {
+	if s.scope.ReplicasExternallyManaged() {
 		// Set MachinePool replicas to the node group DesiredCapacity
 		ngDesiredCapacity := int32(aws.Int64Value(ng.ScalingConfig.DesiredSize)) //#nosec G115
 		if *s.scope.MachinePool.Spec.Replicas != ngDesiredCapacity {
@@ -560,7 +753,7 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		return errors.Wrap(err, "failed to wait for nodegroup to be active")
 	}
 
-	if err := s.reconcileNodegroupVersion(ng); err != nil {
+	if err := s.reconcileNodegroupVersion(ctx, ng); err != nil {
 		return errors.Wrap(err, "failed to reconcile nodegroup version")
 	}
 
@@ -576,6 +769,10 @@ func (s *NodegroupService) reconcileNodegroup(ctx context.Context) error {
 		return errors.Wrapf(err, "failed to reconcile asg tags")
 	}
 
+	if err := s.reconcileNodegroupSecurityGroupRules(); err != nil {
+		return errors.Wrap(err, "failed to reconcile nodegroup security group rules")
+	}
+
 	return nil
 }
 
@@ -621,6 +818,7 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 		managedPool.Spec.ProviderIDList = providerIDList
 		managedPool.Status.Replicas = replicas
 	}
+	managedPool.Status.SecurityGroupID = s.nodegroupSecurityGroupID(ng)
 	if err := s.scope.PatchObject(); err != nil {
 		return errors.Wrap(err, "failed to update nodegroup")
 	}
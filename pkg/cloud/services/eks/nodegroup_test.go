@@ -0,0 +1,848 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestValidateNodegroupVersionSkew(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nodegroupVersion    *string
+		machinePoolVersion  *string
+		controlPlaneSpec    *string
+		controlPlaneStatus  *string
+		orchestratedUpgrade bool
+		expectErr           bool
+	}{
+		{
+			name:               "same version as control plane",
+			nodegroupVersion:   ptr.To[string]("v1.30.2"),
+			controlPlaneStatus: ptr.To[string]("1.30"),
+		},
+		{
+			name:               "one minor behind control plane",
+			nodegroupVersion:   ptr.To[string]("1.29"),
+			controlPlaneStatus: ptr.To[string]("1.30"),
+		},
+		{
+			name:               "two minors behind control plane",
+			nodegroupVersion:   ptr.To[string]("1.28"),
+			controlPlaneStatus: ptr.To[string]("1.30"),
+			expectErr:          true,
+		},
+		{
+			name:               "newer than control plane",
+			nodegroupVersion:   ptr.To[string]("1.31"),
+			controlPlaneStatus: ptr.To[string]("1.30"),
+			expectErr:          true,
+		},
+		{
+			name:             "falls back to control plane spec version",
+			nodegroupVersion: ptr.To[string]("1.28"),
+			controlPlaneSpec: ptr.To[string]("v1.30.0"),
+			expectErr:        true,
+		},
+		{
+			name:               "machine pool version two minors behind control plane",
+			machinePoolVersion: ptr.To[string]("v1.28.0"),
+			controlPlaneStatus: ptr.To[string]("1.30"),
+			expectErr:          true,
+		},
+		{
+			name:                "machine pool version ignored by orchestrated upgrades",
+			machinePoolVersion:  ptr.To[string]("v1.28.0"),
+			controlPlaneStatus:  ptr.To[string]("1.30"),
+			orchestratedUpgrade: true,
+		},
+		{
+			name:               "no requested version",
+			controlPlaneStatus: ptr.To[string]("1.30"),
+		},
+		{
+			name:             "unknown control plane version",
+			nodegroupVersion: ptr.To[string]("1.28"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				Spec:   ekscontrolplanev1.AWSManagedControlPlaneSpec{Version: tc.controlPlaneSpec},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{Version: tc.controlPlaneStatus},
+			}
+			if tc.orchestratedUpgrade {
+				controlPlane.Spec.NodegroupUpgrade = &ekscontrolplanev1.NodegroupUpgrade{}
+			}
+			machinePool := &expclusterv1.MachinePool{}
+			machinePool.Spec.Template.Spec.Version = tc.machinePoolVersion
+			s := &NodegroupService{
+				scope: &scope.ManagedMachinePoolScope{
+					ControlPlane: controlPlane,
+					MachinePool:  machinePool,
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						Spec: expinfrav1.AWSManagedMachinePoolSpec{Version: tc.nodegroupVersion},
+					},
+				},
+			}
+
+			err := s.validateNodegroupVersionSkew()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestReconcileNodegroupConfigExternalScaling(t *testing.T) {
+	// The autoscaler scaled the nodegroup up from 3 to 5 nodes behind CAPA's back.
+	scaledUp := &eks.Nodegroup{
+		NodegroupName: aws.String("ng"),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(5),
+			MinSize:     aws.Int64(1),
+			MaxSize:     aws.Int64(10),
+		},
+	}
+
+	testCases := []struct {
+		name                          string
+		annotations                   map[string]string
+		managedMachinePoolAnnotations map[string]string
+		scaling                       *expinfrav1.ManagedMachinePoolScaling
+		expected                      *eks.NodegroupScalingConfig
+	}{
+		{
+			name:     "resets the desired size without the autoscaler annotation",
+			scaling:  &expinfrav1.ManagedMachinePoolScaling{MinSize: ptr.To[int32](1), MaxSize: ptr.To[int32](10)},
+			expected: &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MinSize: aws.Int64(1), MaxSize: aws.Int64(10)},
+		},
+		{
+			name:        "leaves the desired size to the autoscaler",
+			annotations: map[string]string{clusterv1.ReplicasManagedByAnnotation: "external-autoscaler"},
+			scaling:     &expinfrav1.ManagedMachinePoolScaling{MinSize: ptr.To[int32](1), MaxSize: ptr.To[int32](10)},
+		},
+		{
+			name:                          "leaves the desired size to the autoscaler with the external scaling annotation",
+			managedMachinePoolAnnotations: map[string]string{expinfrav1.ExternalScalingAnnotation: "true"},
+			scaling:                       &expinfrav1.ManagedMachinePoolScaling{MinSize: ptr.To[int32](1), MaxSize: ptr.To[int32](10)},
+		},
+		{
+			name:                          "resets the desired size when the external scaling annotation is false",
+			managedMachinePoolAnnotations: map[string]string{expinfrav1.ExternalScalingAnnotation: "false"},
+			scaling:                       &expinfrav1.ManagedMachinePoolScaling{MinSize: ptr.To[int32](1), MaxSize: ptr.To[int32](10)},
+			expected:                      &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(3), MinSize: aws.Int64(1), MaxSize: aws.Int64(10)},
+		},
+		{
+			name:        "only updates min and max with the autoscaler annotation",
+			annotations: map[string]string{clusterv1.ReplicasManagedByAnnotation: "external-autoscaler"},
+			scaling:     &expinfrav1.ManagedMachinePoolScaling{MinSize: ptr.To[int32](2), MaxSize: ptr.To[int32](20)},
+			expected:    &eks.NodegroupScalingConfig{MinSize: aws.Int64(2), MaxSize: aws.Int64(20)},
+		},
+		{
+			name:        "clamps the desired size to the new bounds with the autoscaler annotation",
+			annotations: map[string]string{clusterv1.ReplicasManagedByAnnotation: "external-autoscaler"},
+			scaling:     &expinfrav1.ManagedMachinePoolScaling{MinSize: ptr.To[int32](1), MaxSize: ptr.To[int32](4)},
+			expected:    &eks.NodegroupScalingConfig{DesiredSize: aws.Int64(4), MinSize: aws.Int64(1), MaxSize: aws.Int64(4)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+
+			if tc.expected != nil {
+				eksMock.EXPECT().UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					ScalingConfig: tc.expected,
+				}).Return(&eks.UpdateNodegroupConfigOutput{}, nil)
+			}
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+					Spec:       expclusterv1.MachinePoolSpec{Replicas: ptr.To[int32](3)},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Annotations: tc.managedMachinePoolAnnotations},
+					Spec:       expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng", Scaling: tc.scaling},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			g.Expect(s.reconcileNodegroupConfig(scaledUp)).To(Succeed())
+		})
+	}
+}
+
+func TestReconcilePoolOutposts(t *testing.T) {
+	g := NewWithT(t)
+
+	machinePoolScope := &scope.ManagedMachinePoolScope{
+		Logger: *logger.NewLogger(logr.Discard()),
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				EKSClusterName: "cluster",
+				OutpostConfig: &ekscontrolplanev1.OutpostConfig{
+					OutpostARNs:              []string{"arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"},
+					ControlPlaneInstanceType: "m5d.large",
+				},
+			},
+		},
+		ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+			Spec: expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng"},
+		},
+	}
+	s := &NodegroupService{scope: machinePoolScope}
+
+	g.Expect(s.ReconcilePool(context.TODO())).NotTo(Succeed())
+	g.Expect(conditions.IsFalse(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupReadyCondition)).To(BeTrue())
+}
+
+func TestReconcilePoolVersionSkew(t *testing.T) {
+	g := NewWithT(t)
+
+	machinePoolScope := &scope.ManagedMachinePoolScope{
+		Logger: *logger.NewLogger(logr.Discard()),
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			Spec:   ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+			Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{Version: ptr.To[string]("1.30")},
+		},
+		MachinePool: &expclusterv1.MachinePool{},
+		ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+			Spec: expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng", Version: ptr.To[string]("1.28")},
+		},
+	}
+	s := &NodegroupService{scope: machinePoolScope}
+
+	g.Expect(s.ReconcilePool(context.TODO())).NotTo(Succeed())
+	condition := conditions.Get(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupReadyCondition)
+	g.Expect(condition).NotTo(BeNil())
+	g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(expinfrav1.EKSNodegroupVersionSkewReason))
+}
+
+func TestReconcileNodegroupConfigUpdateConfig(t *testing.T) {
+	nodegroup := &eks.Nodegroup{
+		NodegroupName: aws.String("ng"),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(3),
+		},
+		UpdateConfig: &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(1)},
+	}
+
+	testCases := []struct {
+		name         string
+		updateConfig *expinfrav1.UpdateConfig
+		expected     *eks.NodegroupUpdateConfig
+	}{
+		{
+			name:         "leaves an unchanged update config as is",
+			updateConfig: &expinfrav1.UpdateConfig{MaxUnavailable: ptr.To[int](1)},
+		},
+		{
+			name: "leaves the update config as is when unset in the spec",
+		},
+		{
+			name:         "updates a changed maxUnavailable",
+			updateConfig: &expinfrav1.UpdateConfig{MaxUnavailable: ptr.To[int](3)},
+			expected:     &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(3)},
+		},
+		{
+			name:         "switches to maxUnavailablePercentage",
+			updateConfig: &expinfrav1.UpdateConfig{MaxUnavailablePercentage: ptr.To[int](25)},
+			expected:     &eks.NodegroupUpdateConfig{MaxUnavailablePercentage: aws.Int64(25)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+
+			if tc.expected != nil {
+				eksMock.EXPECT().UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					UpdateConfig:  tc.expected,
+				}).Return(&eks.UpdateNodegroupConfigOutput{}, nil)
+			}
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					Spec: expclusterv1.MachinePoolSpec{Replicas: ptr.To[int32](3)},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng", UpdateConfig: tc.updateConfig},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			g.Expect(s.reconcileNodegroupConfig(nodegroup)).To(Succeed())
+		})
+	}
+}
+
+func TestReconcileNodegroupConfigTaints(t *testing.T) {
+	nodegroup := &eks.Nodegroup{
+		NodegroupName: aws.String("ng"),
+		ScalingConfig: &eks.NodegroupScalingConfig{
+			DesiredSize: aws.Int64(3),
+		},
+		Taints: []*eks.Taint{
+			{Key: aws.String("dedicated"), Value: aws.String("gpu"), Effect: aws.String(eks.TaintEffectNoSchedule)},
+			{Key: aws.String("spot"), Value: aws.String("true"), Effect: aws.String(eks.TaintEffectPreferNoSchedule)},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		taints        expinfrav1.Taints
+		expected      *eks.UpdateTaintsPayload
+		updateErr     error
+		expectedState corev1.ConditionStatus
+	}{
+		{
+			name: "leaves unchanged taints as is",
+			taints: expinfrav1.Taints{
+				{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule},
+				{Key: "spot", Value: "true", Effect: expinfrav1.TaintEffectPreferNoSchedule},
+			},
+			expectedState: corev1.ConditionTrue,
+		},
+		{
+			name: "adds a new taint and removes a deleted one",
+			taints: expinfrav1.Taints{
+				{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule},
+				{Key: "team", Value: "ml", Effect: expinfrav1.TaintEffectNoExecute},
+			},
+			expected: &eks.UpdateTaintsPayload{
+				AddOrUpdateTaints: []*eks.Taint{{Key: aws.String("team"), Value: aws.String("ml"), Effect: aws.String(eks.TaintEffectNoExecute)}},
+				RemoveTaints:      []*eks.Taint{{Key: aws.String("spot"), Value: aws.String("true"), Effect: aws.String(eks.TaintEffectPreferNoSchedule)}},
+			},
+			expectedState: corev1.ConditionTrue,
+		},
+		{
+			name: "only updates a taint whose value changed",
+			taints: expinfrav1.Taints{
+				{Key: "dedicated", Value: "inference", Effect: expinfrav1.TaintEffectNoSchedule},
+				{Key: "spot", Value: "true", Effect: expinfrav1.TaintEffectPreferNoSchedule},
+			},
+			expected: &eks.UpdateTaintsPayload{
+				AddOrUpdateTaints: []*eks.Taint{{Key: aws.String("dedicated"), Value: aws.String("inference"), Effect: aws.String(eks.TaintEffectNoSchedule)}},
+			},
+			expectedState: corev1.ConditionTrue,
+		},
+		{
+			name: "reports a rejected update",
+			taints: expinfrav1.Taints{
+				{Key: "dedicated", Value: "gpu", Effect: expinfrav1.TaintEffectNoSchedule},
+			},
+			expected: &eks.UpdateTaintsPayload{
+				RemoveTaints: []*eks.Taint{{Key: aws.String("spot"), Value: aws.String("true"), Effect: aws.String(eks.TaintEffectPreferNoSchedule)}},
+			},
+			updateErr:     awserr.New(eks.ErrCodeInvalidParameterException, "invalid taints", nil),
+			expectedState: corev1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+
+			if tc.expected != nil {
+				eksMock.EXPECT().UpdateNodegroupConfig(&eks.UpdateNodegroupConfigInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					Taints:        tc.expected,
+				}).Return(&eks.UpdateNodegroupConfigOutput{}, tc.updateErr)
+			}
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					Spec: expclusterv1.MachinePoolSpec{Replicas: ptr.To[int32](3)},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng", Taints: tc.taints},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			err := s.reconcileNodegroupConfig(nodegroup)
+			if tc.updateErr != nil {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			condition := conditions.Get(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupConfigUpdatedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedState))
+		})
+	}
+}
+
+func TestRemoteAccess(t *testing.T) {
+	testCases := []struct {
+		name               string
+		remoteAccess       *expinfrav1.ManagedRemoteAccess
+		controlPlaneSSHKey *string
+		bastion            bool
+		expected           *eks.RemoteAccessConfig
+		expectErr          bool
+	}{
+		{
+			name: "no remote access",
+		},
+		{
+			name:         "restricts access to the source and cluster security groups",
+			remoteAccess: &expinfrav1.ManagedRemoteAccess{SSHKeyName: ptr.To[string]("pool-key"), SourceSecurityGroups: []string{"sg-source"}},
+			expected: &eks.RemoteAccessConfig{
+				Ec2SshKey:            aws.String("pool-key"),
+				SourceSecurityGroups: aws.StringSlice([]string{"sg-source", "sg-cluster"}),
+			},
+		},
+		{
+			name:               "allows access from the bastion with the key of the control plane",
+			remoteAccess:       &expinfrav1.ManagedRemoteAccess{},
+			controlPlaneSSHKey: ptr.To[string]("cluster-key"),
+			bastion:            true,
+			expected: &eks.RemoteAccessConfig{
+				Ec2SshKey:            aws.String("cluster-key"),
+				SourceSecurityGroups: aws.StringSlice([]string{"sg-cluster", "sg-bastion"}),
+			},
+		},
+		{
+			name:         "opens access to the internet",
+			remoteAccess: &expinfrav1.ManagedRemoteAccess{SSHKeyName: ptr.To[string]("pool-key"), Public: true},
+			expected: &eks.RemoteAccessConfig{
+				Ec2SshKey:            aws.String("pool-key"),
+				SourceSecurityGroups: []*string{},
+			},
+		},
+		{
+			name:         "requires an SSH key",
+			remoteAccess: &expinfrav1.ManagedRemoteAccess{SourceSecurityGroups: []string{"sg-source"}},
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					SSHKeyName: tc.controlPlaneSSHKey,
+					Bastion:    infrav1.Bastion{Enabled: tc.bastion},
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+					Network: infrav1.NetworkStatus{
+						SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+							ekscontrolplanev1.SecurityGroupCluster: {ID: "sg-cluster"},
+							infrav1.SecurityGroupBastion:           {ID: "sg-bastion"},
+						},
+					},
+				},
+			}
+			s := &NodegroupService{
+				scope: &scope.ManagedMachinePoolScope{
+					ControlPlane: controlPlane,
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						Spec: expinfrav1.AWSManagedMachinePoolSpec{RemoteAccess: tc.remoteAccess},
+					},
+				},
+			}
+
+			remoteAccess, err := s.remoteAccess()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(remoteAccess).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestCreateLabelUpdate(t *testing.T) {
+	nodegroup := &eks.Nodegroup{
+		NodegroupName: aws.String("ng"),
+		Labels: map[string]*string{
+			"team":        aws.String("ml"),
+			"environment": aws.String("staging"),
+			"added-by":    aws.String("console"),
+			"debug":       aws.String("true"),
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		labels              map[string]string
+		expected            *eks.UpdateLabelsPayload
+		expectedDescription string
+	}{
+		{
+			name: "no update without drift",
+			labels: map[string]string{
+				"team":        "ml",
+				"environment": "staging",
+				"added-by":    "console",
+				"debug":       "true",
+			},
+		},
+		{
+			name: "converges labels changed out of band",
+			labels: map[string]string{
+				"team":        "ml",
+				"environment": "production",
+				"tier":        "gpu",
+			},
+			expected: &eks.UpdateLabelsPayload{
+				AddOrUpdateLabels: map[string]*string{
+					"environment": aws.String("production"),
+					"tier":        aws.String("gpu"),
+				},
+				RemoveLabels: aws.StringSlice([]string{"added-by", "debug"}),
+			},
+			expectedDescription: "added tier; updated environment; removed added-by, debug",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			payload := createLabelUpdate(tc.labels, nodegroup)
+			g.Expect(payload).To(Equal(tc.expected))
+			if payload != nil {
+				g.Expect(describeLabelUpdate(payload, nodegroup)).To(Equal(tc.expectedDescription))
+			}
+		})
+	}
+}
+
+func TestReconcileNodegroupVersionOrchestrated(t *testing.T) {
+	describeFirst := func(m *mock_eksiface.MockEKSAPIMockRecorder, version string) {
+		m.DescribeNodegroup(&eks.DescribeNodegroupInput{
+			ClusterName:   aws.String("cluster"),
+			NodegroupName: aws.String("ng-first"),
+		}).Return(&eks.DescribeNodegroupOutput{
+			Nodegroup: &eks.Nodegroup{Version: aws.String(version), Status: aws.String(eks.NodegroupStatusActive)},
+		}, nil)
+	}
+
+	testCases := []struct {
+		name               string
+		nodegroupVersion   string
+		controlPlaneStatus string
+		expect             func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedReason     string
+	}{
+		{
+			name:               "waits for the control plane upgrade",
+			nodegroupVersion:   "1.29",
+			controlPlaneStatus: "1.29",
+			expectedReason:     expinfrav1.WaitingForNodegroupUpgradeReason,
+		},
+		{
+			name:               "waits for the preceding machine pools",
+			nodegroupVersion:   "1.29",
+			controlPlaneStatus: "1.30",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				describeFirst(m, "1.29")
+			},
+			expectedReason: expinfrav1.WaitingForNodegroupUpgradeReason,
+		},
+		{
+			name:               "upgrades once the preceding machine pools are upgraded",
+			nodegroupVersion:   "1.29",
+			controlPlaneStatus: "1.30",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				describeFirst(m, "1.30")
+				m.UpdateNodegroupVersion(&eks.UpdateNodegroupVersionInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng-second"),
+					Version:       aws.String("1.30"),
+				}).Return(&eks.UpdateNodegroupVersionOutput{}, nil)
+			},
+			expectedReason: expinfrav1.EKSNodegroupUpgradingReason,
+		},
+		{
+			name:               "reports the upgraded nodegroup",
+			nodegroupVersion:   "1.30",
+			controlPlaneStatus: "1.30",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+
+			scheme := runtime.NewScheme()
+			g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+			first := &expinfrav1.AWSManagedMachinePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default"},
+				Spec:       expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng-first"},
+			}
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(first).Build(),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						EKSClusterName:   "cluster",
+						Version:          ptr.To[string]("v1.30.0"),
+						NodegroupUpgrade: &ekscontrolplanev1.NodegroupUpgrade{Order: []string{"first", "second"}},
+					},
+					Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{Version: ptr.To[string](tc.controlPlaneStatus)},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					Spec: expclusterv1.MachinePoolSpec{Template: clusterv1.MachineTemplateSpec{Spec: clusterv1.MachineSpec{Version: ptr.To[string]("v1.29.0")}}},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "default"},
+					Spec:       expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng-second"},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			ng := &eks.Nodegroup{
+				NodegroupName:  aws.String("ng-second"),
+				Version:        aws.String(tc.nodegroupVersion),
+				ReleaseVersion: aws.String("1.29.0-20240101"),
+				Status:         aws.String(eks.NodegroupStatusActive),
+			}
+			g.Expect(s.reconcileNodegroupVersion(context.TODO(), ng)).To(Succeed())
+
+			condition := conditions.Get(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupUpgradedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			if tc.expectedReason == "" {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Status).To(BeEquivalentTo("False"))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
+
+func TestReconcileNodegroupVersionForceUpdate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		forceUpdate bool
+		expectForce *bool
+	}{
+		{
+			name: "respects pod disruption budgets by default",
+		},
+		{
+			name:        "forces the update if configured",
+			forceUpdate: true,
+			expectForce: aws.Bool(true),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			eksMock.EXPECT().UpdateNodegroupVersion(&eks.UpdateNodegroupVersionInput{
+				ClusterName:    aws.String("cluster"),
+				NodegroupName:  aws.String("ng"),
+				ReleaseVersion: aws.String("1.30.0-20240201"),
+				Force:          tc.expectForce,
+			}).Return(&eks.UpdateNodegroupVersionOutput{}, nil)
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					Spec: expclusterv1.MachinePoolSpec{Template: clusterv1.MachineTemplateSpec{Spec: clusterv1.MachineSpec{Version: ptr.To[string]("v1.30.0")}}},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{
+						EKSNodegroupName: "ng",
+						AMIVersion:       aws.String("1.30.0-20240201"),
+						ForceUpdate:      tc.forceUpdate,
+					},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			ng := &eks.Nodegroup{
+				NodegroupName:  aws.String("ng"),
+				Version:        aws.String("1.30"),
+				ReleaseVersion: aws.String("1.30.0-20240101"),
+				Status:         aws.String(eks.NodegroupStatusActive),
+			}
+			g.Expect(s.reconcileNodegroupVersion(context.TODO(), ng)).To(Succeed())
+		})
+	}
+}
+
+func TestReconcileNodegroupVersionLaunchTemplateReference(t *testing.T) {
+	testCases := []struct {
+		name             string
+		referenceVersion *string
+		expect           func(m *mock_eksiface.MockEKSAPIMockRecorder)
+	}{
+		{
+			name: "keeps the launch template version without version in the reference",
+		},
+		{
+			name:             "keeps the referenced launch template version",
+			referenceVersion: ptr.To[string]("2"),
+		},
+		{
+			name:             "updates the nodegroup to the referenced launch template version",
+			referenceVersion: ptr.To[string]("3"),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UpdateNodegroupVersion(&eks.UpdateNodegroupVersionInput{
+					ClusterName:   aws.String("cluster"),
+					NodegroupName: aws.String("ng"),
+					LaunchTemplate: &eks.LaunchTemplateSpecification{
+						Name:    aws.String("custom"),
+						Version: aws.String("3"),
+					},
+				}).Return(&eks.UpdateNodegroupVersionOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+
+			machinePoolScope := &scope.ManagedMachinePoolScope{
+				Logger: *logger.NewLogger(logr.Discard()),
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster"},
+				},
+				MachinePool: &expclusterv1.MachinePool{
+					Spec: expclusterv1.MachinePoolSpec{Template: clusterv1.MachineTemplateSpec{Spec: clusterv1.MachineSpec{Version: ptr.To[string]("v1.30.0")}}},
+				},
+				ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+					Spec: expinfrav1.AWSManagedMachinePoolSpec{
+						EKSNodegroupName: "ng",
+						LaunchTemplate: &expinfrav1.LaunchTemplateReference{
+							Name:    aws.String("custom"),
+							Version: tc.referenceVersion,
+						},
+					},
+				},
+			}
+			s := &NodegroupService{
+				scope:      machinePoolScope,
+				EKSClient:  eksMock,
+				IAMService: iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			ng := &eks.Nodegroup{
+				NodegroupName:  aws.String("ng"),
+				Version:        aws.String("1.30"),
+				ReleaseVersion: aws.String("1.30.0-20240101"),
+				Status:         aws.String(eks.NodegroupStatusActive),
+				LaunchTemplate: &eks.LaunchTemplateSpecification{
+					Id:      aws.String("lt-123"),
+					Name:    aws.String("custom"),
+					Version: aws.String("2"),
+				},
+			}
+			g.Expect(s.reconcileNodegroupVersion(context.TODO(), ng)).To(Succeed())
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+)
+
+// orchestratedUpgrade returns whether the upgrades of the nodegroups are orchestrated by the control plane.
+func (s *NodegroupService) orchestratedUpgrade() bool {
+	return s.scope.ControlPlane.Spec.NodegroupUpgrade != nil
+}
+
+// precedingMachinePools returns the names of the machine pools to be upgraded before this one. Machine pools
+// not listed in the upgrade order are upgraded after all listed ones.
+func (s *NodegroupService) precedingMachinePools() []string {
+	order := s.scope.ControlPlane.Spec.NodegroupUpgrade.Order
+	for i, name := range order {
+		if name == s.scope.ManagedMachinePool.Name {
+			return order[:i]
+		}
+	}
+	return order
+}
+
+// nodegroupUpgradeBlocker returns why the nodegroup can't be upgraded to the given version yet: the control
+// plane must run the version, and the machine pools preceding this one in the upgrade order must have been
+// upgraded. An empty string is returned if the upgrade can proceed.
+func (s *NodegroupService) nodegroupUpgradeBlocker(ctx context.Context, next *version.Version) (string, error) {
+	controlPlaneRawVersion := aws.StringValue(s.scope.ControlPlane.Status.Version)
+	if controlPlaneRawVersion == "" {
+		return "waiting for the control plane version to be reported", nil
+	}
+	controlPlaneVersion, err := parseEKSVersion(controlPlaneRawVersion)
+	if err != nil {
+		return "", fmt.Errorf("parsing EKS control plane version: %w", err)
+	}
+	if controlPlaneVersion.LessThan(next) {
+		return fmt.Sprintf("waiting for the control plane to be upgraded to version %s", versionToEKS(next)), nil
+	}
+
+	for _, name := range s.precedingMachinePools() {
+		pool := &expinfrav1.AWSManagedMachinePool{}
+		if err := s.scope.Client.Get(ctx, client.ObjectKey{Namespace: s.scope.Namespace(), Name: name}, pool); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", errors.Wrapf(err, "failed to get AWSManagedMachinePool %s", name)
+		}
+
+		upgraded, err := s.machinePoolUpgraded(pool)
+		if err != nil {
+			return "", err
+		}
+		if !upgraded {
+			return fmt.Sprintf("waiting for machine pool %s to be upgraded", name), nil
+		}
+	}
+
+	return "", nil
+}
+
+// machinePoolUpgraded returns whether the nodegroup of another machine pool of the cluster is active and runs
+// the version it is upgraded to, which is its own version or the control plane version.
+func (s *NodegroupService) machinePoolUpgraded(pool *expinfrav1.AWSManagedMachinePool) (bool, error) {
+	rawVersion := pool.Spec.Version
+	if rawVersion == nil {
+		rawVersion = s.scope.ControlPlane.Spec.Version
+	}
+	if rawVersion == nil || pool.Spec.EKSNodegroupName == "" {
+		return true, nil
+	}
+	desiredVersion, err := parseEKSVersion(*rawVersion)
+	if err != nil {
+		return false, fmt.Errorf("parsing EKS version of machine pool %s: %w", pool.Name, err)
+	}
+
+	out, err := s.EKSClient.DescribeNodegroup(&eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		NodegroupName: aws.String(pool.Spec.EKSNodegroupName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			// Nodegroups not created yet are created with the desired version.
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "failed to describe nodegroup %s", pool.Spec.EKSNodegroupName)
+	}
+
+	ng := out.Nodegroup
+	if aws.StringValue(ng.Status) != eks.NodegroupStatusActive || ng.Version == nil {
+		return false, nil
+	}
+	ngVersion, err := parseEKSVersion(*ng.Version)
+	if err != nil {
+		return false, fmt.Errorf("parsing EKS version of nodegroup %s: %w", pool.Spec.EKSNodegroupName, err)
+	}
+	return !ngVersion.LessThan(desiredVersion), nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// reconcilePodIdentityAssociations creates, updates and deletes the Pod Identity associations of the EKS cluster
+// to match the spec. The associations are compared with the ones last applied, which are recorded in the status,
+// so only the associations that changed are updated. Only associations that were part of the spec are deleted,
+// and associations owned by EKS addons are left untouched.
+func (s *Service) reconcilePodIdentityAssociations(ctx context.Context) error {
+	s.scope.Debug("Reconciling EKS Pod Identity associations")
+
+	current, err := s.listPodIdentityAssociations(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list pod identity associations")
+	}
+
+	applied := map[string]ekscontrolplanev1.PodIdentityAssociation{}
+	for _, association := range s.scope.ControlPlane.Status.PodIdentityAssociations {
+		applied[podIdentityAssociationKey(association.ServiceAccountNamespace, association.ServiceAccountName)] = association
+	}
+	defer func() {
+		s.scope.ControlPlane.Status.PodIdentityAssociations = nil
+		for _, association := range applied {
+			s.scope.ControlPlane.Status.PodIdentityAssociations = append(s.scope.ControlPlane.Status.PodIdentityAssociations, association)
+		}
+		slices.SortFunc(s.scope.ControlPlane.Status.PodIdentityAssociations, func(a, b ekscontrolplanev1.PodIdentityAssociation) int {
+			return strings.Compare(podIdentityAssociationKey(a.ServiceAccountNamespace, a.ServiceAccountName),
+				podIdentityAssociationKey(b.ServiceAccountNamespace, b.ServiceAccountName))
+		})
+	}()
+
+	desired := map[string]bool{}
+	for _, association := range s.scope.ControlPlane.Spec.PodIdentityAssociations {
+		key := podIdentityAssociationKey(association.ServiceAccountNamespace, association.ServiceAccountName)
+		desired[key] = true
+
+		summary := current[key]
+		if summary != nil && summary.OwnerArn != nil {
+			s.scope.Debug("Skipping pod identity association owned by an EKS addon", "serviceAccount", key, "owner", aws.StringValue(summary.OwnerArn))
+			continue
+		}
+		if last, ok := applied[key]; ok && summary != nil && last == association {
+			continue
+		}
+		if err := s.reconcilePodIdentityAssociation(ctx, association, summary); err != nil {
+			return errors.Wrapf(err, "failed to reconcile pod identity association for %s", key)
+		}
+		applied[key] = association
+	}
+
+	for key := range applied {
+		if desired[key] {
+			continue
+		}
+		if summary := current[key]; summary != nil && summary.OwnerArn == nil {
+			if err := s.deletePodIdentityAssociation(ctx, summary); err != nil {
+				return errors.Wrapf(err, "failed to delete pod identity association for %s", key)
+			}
+		}
+		delete(applied, key)
+	}
+
+	return nil
+}
+
+// listPodIdentityAssociations returns the Pod Identity associations of the EKS cluster by their service account.
+func (s *Service) listPodIdentityAssociations(ctx context.Context) (map[string]*eks.PodIdentityAssociationSummary, error) {
+	associations := map[string]*eks.PodIdentityAssociationSummary{}
+	input := &eks.ListPodIdentityAssociationsInput{ClusterName: aws.String(s.scope.KubernetesClusterName())}
+	if err := s.EKSClient.ListPodIdentityAssociationsPagesWithContext(ctx, input, func(out *eks.ListPodIdentityAssociationsOutput, _ bool) bool {
+		for _, summary := range out.Associations {
+			associations[podIdentityAssociationKey(aws.StringValue(summary.Namespace), aws.StringValue(summary.ServiceAccount))] = summary
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return associations, nil
+}
+
+func (s *Service) reconcilePodIdentityAssociation(ctx context.Context, association ekscontrolplanev1.PodIdentityAssociation, summary *eks.PodIdentityAssociationSummary) error {
+	key := podIdentityAssociationKey(association.ServiceAccountNamespace, association.ServiceAccountName)
+
+	if summary == nil {
+		tags := map[string]*string{}
+		for k, v := range s.scope.AdditionalTags() {
+			tags[k] = aws.String(v)
+		}
+		tags[infrav1.ClusterTagKey(s.scope.Name())] = aws.String(string(infrav1.ResourceLifecycleOwned))
+
+		if _, err := s.EKSClient.CreatePodIdentityAssociationWithContext(ctx, &eks.CreatePodIdentityAssociationInput{
+			ClusterName:    aws.String(s.scope.KubernetesClusterName()),
+			Namespace:      aws.String(association.ServiceAccountNamespace),
+			ServiceAccount: aws.String(association.ServiceAccountName),
+			RoleArn:        aws.String(association.RoleARN),
+			Tags:           tags,
+		}); err != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedCreateEKSPodIdentityAssociation", "Failed to create pod identity association for %s: %v", key, err)
+			return errors.Wrap(err, "failed to create pod identity association")
+		}
+		record.Eventf(s.scope.ControlPlane, "SuccessfulCreateEKSPodIdentityAssociation", "Created pod identity association for %s", key)
+		return nil
+	}
+
+	// Updating the association with its current role is a no-op, so it doesn't need to be described first.
+	if _, err := s.EKSClient.UpdatePodIdentityAssociationWithContext(ctx, &eks.UpdatePodIdentityAssociationInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		AssociationId: summary.AssociationId,
+		RoleArn:       aws.String(association.RoleARN),
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedUpdateEKSPodIdentityAssociation", "Failed to update pod identity association for %s: %v", key, err)
+		return errors.Wrap(err, "failed to update pod identity association")
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSPodIdentityAssociation", "Updated pod identity association for %s", key)
+
+	return nil
+}
+
+func (s *Service) deletePodIdentityAssociation(ctx context.Context, summary *eks.PodIdentityAssociationSummary) error {
+	key := podIdentityAssociationKey(aws.StringValue(summary.Namespace), aws.StringValue(summary.ServiceAccount))
+
+	if _, err := s.EKSClient.DeletePodIdentityAssociationWithContext(ctx, &eks.DeletePodIdentityAssociationInput{
+		ClusterName:   aws.String(s.scope.KubernetesClusterName()),
+		AssociationId: summary.AssociationId,
+	}); err != nil {
+		record.Warnf(s.scope.ControlPlane, "FailedDeleteEKSPodIdentityAssociation", "Failed to delete pod identity association for %s: %v", key, err)
+		return errors.Wrap(err, "failed to delete pod identity association")
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulDeleteEKSPodIdentityAssociation", "Deleted pod identity association for %s", key)
+
+	return nil
+}
+
+func podIdentityAssociationKey(namespace, serviceAccount string) string {
+	return fmt.Sprintf("%s/%s", namespace, serviceAccount)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReconcilePodIdentityAssociations(t *testing.T) {
+	const (
+		clusterName = "cluster-test"
+		appRoleARN  = "arn:aws:iam::123456789012:role/app"
+		newRoleARN  = "arn:aws:iam::123456789012:role/app-new"
+		ownedTagKey = "sigs.k8s.io/cluster-api-provider-aws/cluster/capi-name"
+	)
+	app := ekscontrolplanev1.PodIdentityAssociation{ServiceAccountNamespace: "apps", ServiceAccountName: "app", RoleARN: appRoleARN}
+
+	listAssociations := func(m *mock_eksiface.MockEKSAPIMockRecorder, summaries ...*eks.PodIdentityAssociationSummary) {
+		m.ListPodIdentityAssociationsPagesWithContext(gomock.Any(), &eks.ListPodIdentityAssociationsInput{ClusterName: aws.String(clusterName)}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *eks.ListPodIdentityAssociationsInput, fn func(*eks.ListPodIdentityAssociationsOutput, bool) bool, _ ...request.Option) error {
+				fn(&eks.ListPodIdentityAssociationsOutput{Associations: summaries}, true)
+				return nil
+			})
+	}
+	summary := func(id, namespace, serviceAccount string) *eks.PodIdentityAssociationSummary {
+		return &eks.PodIdentityAssociationSummary{AssociationId: aws.String(id), Namespace: aws.String(namespace), ServiceAccount: aws.String(serviceAccount)}
+	}
+
+	addonOwned := summary("a-3", "kube-system", "aws-node")
+	addonOwned.OwnerArn = aws.String("arn:aws:eks:us-east-1:123456789012:addon/cluster-test/vpc-cni/1")
+
+	tests := []struct {
+		name                string
+		associations        []ekscontrolplanev1.PodIdentityAssociation
+		appliedAssociations []ekscontrolplanev1.PodIdentityAssociation
+		expect              func(m *mock_eksiface.MockEKSAPIMockRecorder)
+		expectedApplied     []ekscontrolplanev1.PodIdentityAssociation
+	}{
+		{
+			name:         "creates a missing association",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{app},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m)
+				m.CreatePodIdentityAssociationWithContext(gomock.Any(), &eks.CreatePodIdentityAssociationInput{
+					ClusterName:    aws.String(clusterName),
+					Namespace:      aws.String("apps"),
+					ServiceAccount: aws.String("app"),
+					RoleArn:        aws.String(appRoleARN),
+					Tags:           map[string]*string{ownedTagKey: aws.String("owned")},
+				}).Return(&eks.CreatePodIdentityAssociationOutput{}, nil)
+			},
+			expectedApplied: []ekscontrolplanev1.PodIdentityAssociation{app},
+		},
+		{
+			name:                "leaves an association unchanged since it was last applied as is",
+			associations:        []ekscontrolplanev1.PodIdentityAssociation{app},
+			appliedAssociations: []ekscontrolplanev1.PodIdentityAssociation{app},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, summary("a-1", "apps", "app"))
+			},
+			expectedApplied: []ekscontrolplanev1.PodIdentityAssociation{app},
+		},
+		{
+			name:                "updates the role of an association",
+			associations:        []ekscontrolplanev1.PodIdentityAssociation{{ServiceAccountNamespace: "apps", ServiceAccountName: "app", RoleARN: newRoleARN}},
+			appliedAssociations: []ekscontrolplanev1.PodIdentityAssociation{app},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, summary("a-1", "apps", "app"))
+				m.UpdatePodIdentityAssociationWithContext(gomock.Any(), &eks.UpdatePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+					RoleArn:       aws.String(newRoleARN),
+				}).Return(&eks.UpdatePodIdentityAssociationOutput{}, nil)
+			},
+			expectedApplied: []ekscontrolplanev1.PodIdentityAssociation{{ServiceAccountNamespace: "apps", ServiceAccountName: "app", RoleARN: newRoleARN}},
+		},
+		{
+			name:         "leaves an association owned by an EKS addon as is",
+			associations: []ekscontrolplanev1.PodIdentityAssociation{{ServiceAccountNamespace: "kube-system", ServiceAccountName: "aws-node", RoleARN: appRoleARN}},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, addonOwned)
+			},
+		},
+		{
+			name:                "deletes only removed associations that were part of the spec",
+			appliedAssociations: []ekscontrolplanev1.PodIdentityAssociation{app},
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				listAssociations(m, summary("a-1", "apps", "app"), summary("a-2", "apps", "other"), addonOwned)
+				m.DeletePodIdentityAssociationWithContext(gomock.Any(), &eks.DeletePodIdentityAssociationInput{
+					ClusterName:   aws.String(clusterName),
+					AssociationId: aws.String("a-1"),
+				}).Return(&eks.DeletePodIdentityAssociationOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName:          clusterName,
+					PodIdentityAssociations: tc.associations,
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+					PodIdentityAssociations: tc.appliedAssociations,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: controlPlane,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+			s := NewService(scope)
+			s.EKSClient = eksMock
+
+			g.Expect(s.reconcilePodIdentityAssociations(context.TODO())).To(Succeed())
+			g.Expect(controlPlane.Status.PodIdentityAssociations).To(Equal(tc.expectedApplied))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/pkg/errors"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// ec2ServiceCode is the Service Quotas code of the EC2 service.
+	ec2ServiceCode = "ec2"
+
+	// vCPUQuotaCacheTTL is the time EC2 vCPU service quotas are cached for.
+	vCPUQuotaCacheTTL = 15 * time.Minute
+)
+
+var (
+	// vCPUQuotaCache caches the values of EC2 vCPU service quotas, keyed by identity, region and quota code.
+	vCPUQuotaCache sync.Map
+
+	// instanceTypeVCPUCache caches the default number of vCPUs of EC2 instance types.
+	instanceTypeVCPUCache sync.Map
+)
+
+type cachedVCPUQuota struct {
+	value     int64
+	expiresAt time.Time
+}
+
+// vCPUQuota is an EC2 service quota limiting the vCPUs of the running instances of an instance class.
+type vCPUQuota struct {
+	code string
+	name string
+}
+
+var (
+	standardOnDemandQuota = vCPUQuota{code: "L-1216C47A", name: "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances"}
+	standardSpotQuota     = vCPUQuota{code: "L-34B43A08", name: "All Standard (A, C, D, H, I, M, R, T, Z) Spot Instance Requests"}
+
+	// vCPUQuotasByClass maps instance classes to their on-demand and spot vCPU quotas.
+	vCPUQuotasByClass = map[string][2]vCPUQuota{
+		"f":   {{code: "L-74FC7D96", name: "Running On-Demand F instances"}, {code: "L-88CF9481", name: "All F Spot Instance Requests"}},
+		"g":   {{code: "L-DB2E81BA", name: "Running On-Demand G and VT instances"}, {code: "L-3819A6DF", name: "All G and VT Spot Instance Requests"}},
+		"vt":  {{code: "L-DB2E81BA", name: "Running On-Demand G and VT instances"}, {code: "L-3819A6DF", name: "All G and VT Spot Instance Requests"}},
+		"inf": {{code: "L-1945791B", name: "Running On-Demand Inf instances"}, {code: "L-B5D1601B", name: "All Inf Spot Instance Requests"}},
+		"p":   {{code: "L-417A185B", name: "Running On-Demand P instances"}, {code: "L-7212CCBC", name: "All P Spot Instance Requests"}},
+		"x":   {{code: "L-7295265B", name: "Running On-Demand X instances"}, {code: "L-E3A00192", name: "All X Spot Instance Requests"}},
+		"trn": {{code: "L-2C3B7624", name: "Running On-Demand Trn instances"}, {code: "L-6B0D517C", name: "All Trn Spot Instance Requests"}},
+		"dl":  {{code: "L-6E869C2A", name: "Running On-Demand DL instances"}, {code: "L-85EED4F7", name: "All DL Spot Instance Requests"}},
+	}
+)
+
+// vCPUQuotaFor returns the EC2 service quota limiting the vCPUs of instances of the given type.
+// It returns false for instance types that aren't limited by a vCPU based quota, e.g. mac or high memory instances.
+func vCPUQuotaFor(instanceType string, spot bool) (vCPUQuota, bool) {
+	class := strings.ToLower(instanceType)
+	if i := strings.IndexFunc(class, func(r rune) bool { return r < 'a' || r > 'z' }); i >= 0 {
+		class = class[:i]
+	}
+
+	quotas, ok := vCPUQuotasByClass[class]
+	if !ok {
+		switch class {
+		case "a", "c", "d", "h", "i", "im", "is", "m", "r", "t", "z":
+			quotas = [2]vCPUQuota{standardOnDemandQuota, standardSpotQuota}
+		default:
+			return vCPUQuota{}, false
+		}
+	}
+	if spot {
+		return quotas[1], true
+	}
+	return quotas[0], true
+}
+
+// nodegroupInstanceTypes returns the instance types of the nodegroup, or none if they're left to EKS.
+func (s *NodegroupService) nodegroupInstanceTypes() []string {
+	managedPool := s.scope.ManagedMachinePool.Spec
+	switch {
+	case managedPool.InstanceType != nil:
+		return []string{*managedPool.InstanceType}
+	case len(managedPool.InstanceTypes) > 0:
+		return managedPool.InstanceTypes
+	case managedPool.AWSLaunchTemplate != nil && managedPool.AWSLaunchTemplate.InstanceType != "":
+		return []string{managedPool.AWSLaunchTemplate.InstanceType}
+	}
+	return nil
+}
+
+// checkVCPUQuota estimates whether growing the nodegroup from current to desired nodes fits into the EC2 vCPU
+// service quotas of the account. The vCPUs of the additional nodes are added to the vCPUs of all running instances
+// in the region counting against the same quota, and compared to the quota. As EKS may launch all the additional
+// nodes with any of the instance types of the nodegroup, the largest instance type limited by a quota is assumed
+// for this quota. Failures to look up the quota or the usage don't block the nodegroup and are only logged.
+//
+// The condition is part of the ready summary of the machine pool, so it's removed when the check doesn't apply and
+// marked true when no scale up is pending anymore, e.g. once the desired size was lowered after a refused scale up.
+func (s *NodegroupService) checkVCPUQuota(ctx context.Context, current, desired int64) error {
+	if !s.scope.CheckVCPUQuota() {
+		conditions.Delete(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)
+		return nil
+	}
+	if desired <= current {
+		conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)
+		return nil
+	}
+
+	instanceTypes := s.nodegroupInstanceTypes()
+	if len(instanceTypes) == 0 {
+		s.Debug("Nodegroup instance type is chosen by EKS, skipping vCPU quota check")
+		conditions.Delete(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)
+		return nil
+	}
+	spot := s.scope.ManagedMachinePool.Spec.CapacityType != nil && *s.scope.ManagedMachinePool.Spec.CapacityType == expinfrav1.ManagedMachinePoolCapacityTypeSpot
+
+	quotas := []vCPUQuota{}
+	nodeVCPUs := map[vCPUQuota]int64{}
+	for _, instanceType := range instanceTypes {
+		quota, ok := vCPUQuotaFor(instanceType, spot)
+		if !ok {
+			s.Debug("Instance type isn't limited by a vCPU quota, skipping vCPU quota check", "instance-type", instanceType)
+			continue
+		}
+		vCPUs, err := s.instanceTypeVCPUs(ctx, instanceType)
+		if err != nil {
+			s.Info("Failed to look up vCPUs of instance type, skipping vCPU quota check", "instance-type", instanceType, "error", err.Error())
+			return nil
+		}
+		if _, ok := nodeVCPUs[quota]; !ok {
+			quotas = append(quotas, quota)
+		}
+		nodeVCPUs[quota] = max(nodeVCPUs[quota], vCPUs)
+	}
+	if len(quotas) == 0 {
+		conditions.Delete(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)
+		return nil
+	}
+
+	for _, quota := range quotas {
+		limit, err := s.vCPUQuotaValue(ctx, quota)
+		if err != nil {
+			s.Info("Failed to look up EC2 vCPU quota, skipping vCPU quota check", "quota", quota.code, "error", err.Error())
+			return nil
+		}
+		inUse, err := s.vCPUsInUse(ctx, quota, spot)
+		if err != nil {
+			s.Info("Failed to look up vCPUs in use, skipping vCPU quota check", "quota", quota.code, "error", err.Error())
+			return nil
+		}
+
+		requested := (desired - current) * nodeVCPUs[quota]
+		if inUse+requested > limit {
+			msg := fmt.Sprintf("scaling to %d %s nodes requires up to %d additional vCPUs, but %d of the %d vCPUs allowed by the EC2 service quota %q (%s) are in use already; request a quota increase or reduce the number of nodes",
+				desired, strings.Join(instanceTypes, "/"), requested, inUse, limit, quota.name, quota.code)
+			conditions.MarkFalse(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition, expinfrav1.VCPUQuotaExceededReason, clusterv1.ConditionSeverityWarning, "%s", msg)
+			record.Warnf(s.scope.ManagedMachinePool, "VCPUQuotaExceeded", "Nodegroup %s: %s", s.scope.NodegroupName(), msg)
+			return errors.Wrap(ErrVCPUQuotaExceeded, msg)
+		}
+		s.Debug("Nodegroup fits into the EC2 vCPU quota", "quota", quota.code, "limit", limit, "in-use", inUse, "requested", requested)
+	}
+
+	conditions.MarkTrue(s.scope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)
+	return nil
+}
+
+// instanceTypeVCPUs returns the default number of vCPUs of an instance type.
+func (s *NodegroupService) instanceTypeVCPUs(ctx context.Context, instanceType string) (int64, error) {
+	if vCPUs, ok := instanceTypeVCPUCache.Load(instanceType); ok {
+		return vCPUs.(int64), nil
+	}
+
+	out, err := s.EC2Client.DescribeInstanceTypesWithContext(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: aws.StringSlice([]string{instanceType}),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to describe instance type %q", instanceType)
+	}
+	if len(out.InstanceTypes) == 0 || out.InstanceTypes[0].VCpuInfo == nil {
+		return 0, errors.Errorf("no vCPU information for instance type %q", instanceType)
+	}
+
+	vCPUs := aws.Int64Value(out.InstanceTypes[0].VCpuInfo.DefaultVCpus)
+	instanceTypeVCPUCache.Store(instanceType, vCPUs)
+	return vCPUs, nil
+}
+
+// vCPUQuotaValue returns the number of vCPUs allowed by an EC2 service quota. Values are cached per
+// identity and region for vCPUQuotaCacheTTL, as quotas rarely change.
+func (s *NodegroupService) vCPUQuotaValue(ctx context.Context, quota vCPUQuota) (int64, error) {
+	identity := "default"
+	if ref := s.scope.IdentityRef(); ref != nil {
+		identity = fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+	key := fmt.Sprintf("%s/%s/%s", identity, s.scope.ControlPlane.Spec.Region, quota.code)
+	if cached, ok := vCPUQuotaCache.Load(key); ok && time.Now().Before(cached.(cachedVCPUQuota).expiresAt) {
+		return cached.(cachedVCPUQuota).value, nil
+	}
+
+	out, err := s.ServiceQuotasClient.GetServiceQuotaWithContext(ctx, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(ec2ServiceCode),
+		QuotaCode:   aws.String(quota.code),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get service quota %q", quota.code)
+	}
+	if out.Quota == nil || out.Quota.Value == nil {
+		return 0, errors.Errorf("service quota %q has no value", quota.code)
+	}
+
+	value := int64(aws.Float64Value(out.Quota.Value))
+	vCPUQuotaCache.Store(key, cachedVCPUQuota{value: value, expiresAt: time.Now().Add(vCPUQuotaCacheTTL)})
+	return value, nil
+}
+
+// vCPUsInUse returns the vCPUs of the running on-demand or spot instances in the region that count against the quota.
+func (s *NodegroupService) vCPUsInUse(ctx context.Context, quota vCPUQuota, spot bool) (int64, error) {
+	var inUse int64
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.InstanceStates(ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning),
+		},
+	}
+	err := s.EC2Client.DescribeInstancesPagesWithContext(ctx, input, func(out *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range out.Reservations {
+			for _, instance := range reservation.Instances {
+				isSpot := aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot
+				if isSpot != spot || instance.CpuOptions == nil {
+					continue
+				}
+				if q, ok := vCPUQuotaFor(aws.StringValue(instance.InstanceType), isSpot); ok && q == quota {
+					inUse += aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to describe running instances")
+	}
+	return inUse, nil
+}
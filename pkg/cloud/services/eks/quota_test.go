@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestVCPUQuotaFor(t *testing.T) {
+	testCases := []struct {
+		instanceType string
+		spot         bool
+		expected     string
+	}{
+		{instanceType: "m5.large", expected: "L-1216C47A"},
+		{instanceType: "t3a.medium", spot: true, expected: "L-34B43A08"},
+		{instanceType: "im4gn.xlarge", expected: "L-1216C47A"},
+		{instanceType: "g5.xlarge", expected: "L-DB2E81BA"},
+		{instanceType: "vt1.3xlarge", spot: true, expected: "L-3819A6DF"},
+		{instanceType: "inf2.xlarge", expected: "L-1945791B"},
+		{instanceType: "dl1.24xlarge", expected: "L-6E869C2A"},
+		{instanceType: "mac1.metal"},
+		{instanceType: "u-6tb1.metal"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.instanceType, func(t *testing.T) {
+			g := NewWithT(t)
+
+			quota, ok := vCPUQuotaFor(tc.instanceType, tc.spot)
+			g.Expect(ok).To(Equal(tc.expected != ""))
+			g.Expect(quota.code).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestCheckVCPUQuota(t *testing.T) {
+	runningInstances := &ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{
+			Instances: []*ec2.Instance{
+				{InstanceType: aws.String("m5.xlarge"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(2)}},
+				{InstanceType: aws.String("c5.2xlarge"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(4), ThreadsPerCore: aws.Int64(2)}},
+				// Neither spot nor GPU instances count against the standard on-demand quota.
+				{InstanceType: aws.String("m5.xlarge"), InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(2)}},
+				{InstanceType: aws.String("g5.xlarge"), CpuOptions: &ec2.CpuOptions{CoreCount: aws.Int64(2), ThreadsPerCore: aws.Int64(2)}},
+			},
+		}},
+	}
+
+	testCases := []struct {
+		name             string
+		checkVCPUQuota   bool
+		instanceTypes    []string
+		current, desired int64
+		quota            float64
+		refused          bool
+		expectAWSCalls   bool
+		expectErr        bool
+		expectCondition  bool
+	}{
+		{
+			name:    "skips the check if disabled",
+			desired: 10,
+		},
+		{
+			name:    "removes the condition of a refused scale up once disabled",
+			refused: true,
+			desired: 10,
+		},
+		{
+			name:            "skips the check when scaling down",
+			checkVCPUQuota:  true,
+			current:         10,
+			desired:         5,
+			expectCondition: true,
+		},
+		{
+			name:            "recovers from a refused scale up once the desired size is lowered",
+			checkVCPUQuota:  true,
+			refused:         true,
+			current:         2,
+			desired:         2,
+			expectCondition: true,
+		},
+		{
+			name:            "allows a scale up within the quota",
+			checkVCPUQuota:  true,
+			current:         2,
+			desired:         4,
+			quota:           20,
+			expectAWSCalls:  true,
+			expectCondition: true,
+		},
+		{
+			name:           "refuses a scale up exceeding the quota",
+			checkVCPUQuota: true,
+			current:        2,
+			desired:        5,
+			quota:          20,
+			expectAWSCalls: true,
+			expectErr:      true,
+		},
+		{
+			name:           "refuses a scale up exceeding the quota with the largest of the instance types",
+			checkVCPUQuota: true,
+			instanceTypes:  []string{"m5.large", "m5.xlarge"},
+			current:        2,
+			desired:        5,
+			quota:          20,
+			expectAWSCalls: true,
+			expectErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			serviceQuotasMock := mocks.NewMockServiceQuotasAPI(mockCtrl)
+			vCPUQuotaCache = sync.Map{}
+			instanceTypeVCPUCache = sync.Map{}
+
+			instanceTypes := tc.instanceTypes
+			if len(instanceTypes) == 0 {
+				instanceTypes = []string{"m5.xlarge"}
+			}
+			if tc.expectAWSCalls {
+				vCPUs := map[string]int64{"m5.large": 2, "m5.xlarge": 4}
+				for _, instanceType := range instanceTypes {
+					ec2Mock.EXPECT().DescribeInstanceTypesWithContext(gomock.Any(), &ec2.DescribeInstanceTypesInput{
+						InstanceTypes: aws.StringSlice([]string{instanceType}),
+					}).Return(&ec2.DescribeInstanceTypesOutput{
+						InstanceTypes: []*ec2.InstanceTypeInfo{{VCpuInfo: &ec2.VCpuInfo{DefaultVCpus: aws.Int64(vCPUs[instanceType])}}},
+					}, nil)
+				}
+				serviceQuotasMock.EXPECT().GetServiceQuotaWithContext(gomock.Any(), &servicequotas.GetServiceQuotaInput{
+					ServiceCode: aws.String("ec2"),
+					QuotaCode:   aws.String("L-1216C47A"),
+				}).Return(&servicequotas.GetServiceQuotaOutput{Quota: &servicequotas.ServiceQuota{Value: aws.Float64(tc.quota)}}, nil)
+				ec2Mock.EXPECT().DescribeInstancesPagesWithContext(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...interface{}) error {
+						fn(runningInstances, true)
+						return nil
+					})
+			}
+
+			machinePoolScope := newQuotaTestScope(g, tc.checkVCPUQuota)
+			if tc.refused {
+				conditions.MarkFalse(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition, expinfrav1.VCPUQuotaExceededReason, clusterv1.ConditionSeverityWarning, "")
+			}
+			if len(tc.instanceTypes) > 0 {
+				machinePoolScope.ManagedMachinePool.Spec.InstanceType = nil
+				machinePoolScope.ManagedMachinePool.Spec.InstanceTypes = tc.instanceTypes
+			}
+			s := &NodegroupService{
+				scope:               machinePoolScope,
+				EC2Client:           ec2Mock,
+				ServiceQuotasClient: serviceQuotasMock,
+				IAMService:          iam.IAMService{Wrapper: &machinePoolScope.Logger},
+			}
+
+			err := s.checkVCPUQuota(context.TODO(), tc.current, tc.desired)
+			condition := conditions.Get(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)
+			switch {
+			case tc.expectErr:
+				g.Expect(errors.Is(err, ErrVCPUQuotaExceeded)).To(BeTrue())
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(BeEquivalentTo("False"))
+				g.Expect(condition.Reason).To(Equal(expinfrav1.VCPUQuotaExceededReason))
+			case tc.expectCondition:
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(conditions.IsTrue(machinePoolScope.ManagedMachinePool, expinfrav1.EKSNodegroupVCPUQuotaCondition)).To(BeTrue())
+			default:
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(condition).To(BeNil())
+			}
+		})
+	}
+}
+
+func newQuotaTestScope(g *WithT, checkVCPUQuota bool) *scope.ManagedMachinePoolScope {
+	scheme := runtime.NewScheme()
+	g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(expclusterv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(ekscontrolplanev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	machinePoolScope, err := scope.NewManagedMachinePoolScope(scope.ManagedMachinePoolScopeParams{
+		Client:  fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Logger:  logger.NewLogger(logr.Discard()),
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"}},
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-control-plane", Namespace: "default"},
+			Spec:       ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: "cluster", Region: "us-east-1"},
+		},
+		MachinePool: &expclusterv1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}},
+		ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+			Spec:       expinfrav1.AWSManagedMachinePoolSpec{EKSNodegroupName: "ng", InstanceType: ptr.To[string]("m5.xlarge")},
+		},
+		CheckVCPUQuota: checkVCPUQuota,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	return machinePoolScope
+}
@@ -30,12 +30,16 @@ import (
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
 	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
+	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 const (
 	maxIAMRoleNameLength = 64
+
+	// nodegroupIPv6CNIPolicyName is the name of the inline policy of nodegroup roles in IPv6 clusters.
+	nodegroupIPv6CNIPolicyName = "AmazonEKS_CNI_IPv6_Policy"
 )
 
 // NodegroupRolePolicies gives the policies required for a nodegroup role.
@@ -115,6 +119,11 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 		aws.String(fmt.Sprintf("arn:%s:iam::aws:policy/AmazonEKSClusterPolicy", s.scope.Partition())),
 	}
 
+	// The VPC resource controller manages the IP addresses of Windows nodes.
+	if s.scope.VpcCni().EnableWindowsIPAM {
+		policies = append(policies, aws.String(fmt.Sprintf("arn:%s:iam::aws:policy/AmazonEKSVPCResourceController", s.scope.Partition())))
+	}
+
 	if s.scope.ControlPlane.Spec.RoleAdditionalPolicies != nil {
 		if !s.scope.AllowAdditionalRoles() && len(*s.scope.ControlPlane.Spec.RoleAdditionalPolicies) > 0 {
 			return ErrCannotUseAdditionalRoles
@@ -133,6 +142,173 @@ func (s *Service) reconcileControlPlaneIAMRole() error {
 	return nil
 }
 
+// addonServiceAccountRolePolicies gives the policies required by the service account of an addon CAPA knows.
+func addonServiceAccountRolePolicies(partition, addonName string) []string {
+	_, policyNames, _ := ekscontrolplanev1.KnownAddonServiceAccountRole(addonName)
+	policies := make([]string, 0, len(policyNames))
+	for _, policyName := range policyNames {
+		policies = append(policies, fmt.Sprintf("arn:%s:iam::aws:policy/%s", partition, policyName))
+	}
+	return policies
+}
+
+// addonServiceAccountRoleName gives the name of the IAM role created for the service account of an addon.
+func (s *Service) addonServiceAccountRoleName(addonName string) (string, error) {
+	return eks.GenerateEKSName(
+		fmt.Sprintf("%s-iam-service-role", addonName),
+		s.scope.KubernetesClusterName(),
+		maxIAMRoleNameLength,
+	)
+}
+
+// reconcileAddonIAMRoles creates the IAM roles of the addons configuring a service account role and ensures
+// their trust relationship and policies. It returns the ARNs of the roles by addon name.
+func (s *Service) reconcileAddonIAMRoles() (map[string]string, error) {
+	roleARNs := map[string]string{}
+	for _, addon := range s.scope.Addons() {
+		if addon.ServiceAccountRole == nil {
+			continue
+		}
+		if !s.scope.EnableIAM() {
+			return nil, ErrCannotCreateAddonRoles
+		}
+		if s.scope.ControlPlane.Status.OIDCProvider.ARN == "" {
+			return nil, errors.Errorf("creating the service account role of addon %s requires an associated OIDC provider", addon.Name)
+		}
+
+		s.scope.Debug("Reconciling EKS addon IAM role", "addon", addon.Name)
+
+		roleName, err := s.addonServiceAccountRoleName(addon.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate IAM role name")
+		}
+
+		serviceAccountRole := addon.ServiceAccountRole
+		trustRelationship := eksiam.ServiceAccountTrustRelationship(
+			s.scope.ControlPlane.Status.OIDCProvider.ARN,
+			serviceAccountRole.ServiceAccountNamespace,
+			serviceAccountRole.ServiceAccountName,
+		)
+
+		role, err := s.GetIAMRole(roleName)
+		if err != nil {
+			if !isNotFound(err) {
+				return nil, err
+			}
+
+			role, err = s.CreateRole(roleName, s.scope.Name(), trustRelationship, s.scope.AdditionalTags())
+			if err != nil {
+				record.Warnf(s.scope.ControlPlane, "FailedIAMRoleCreation", "Failed to create IAM role %q of addon %s: %v", roleName, addon.Name, err)
+				return nil, fmt.Errorf("creating role %s: %w", roleName, err)
+			}
+			record.Eventf(s.scope.ControlPlane, "SuccessfulIAMRoleCreation", "Created IAM role %q of addon %s", roleName, addon.Name)
+		}
+		roleARNs[addon.Name] = aws.StringValue(role.Arn)
+
+		if s.IsUnmanaged(role, s.scope.Name()) {
+			s.scope.Debug("Skipping, EKS addon role policy assignment as role is unmanaged", "addon", addon.Name)
+			continue
+		}
+
+		if _, err := s.EnsureTagsAndPolicy(role, s.scope.Name(), trustRelationship, s.scope.AdditionalTags()); err != nil {
+			return nil, errors.Wrapf(err, "error ensuring tags and policy document are set on addon role %s", roleName)
+		}
+
+		policyARNs := serviceAccountRole.PolicyARNs
+		if len(policyARNs) == 0 {
+			policyARNs = addonServiceAccountRolePolicies(s.scope.Partition(), addon.Name)
+		}
+		if _, err := s.EnsurePoliciesAttached(role, aws.StringSlice(policyARNs)); err != nil {
+			return nil, errors.Wrapf(err, "error ensuring policies are attached: %v", policyARNs)
+		}
+	}
+
+	return roleARNs, nil
+}
+
+// deleteAddonIAMRoles deletes the IAM roles created for the service accounts of addons.
+func (s *Service) deleteAddonIAMRoles() error {
+	if !s.scope.EnableIAM() {
+		s.scope.Debug("EKS IAM disabled, skipping deleting EKS addon IAM roles")
+		return nil
+	}
+
+	for _, addon := range s.scope.Addons() {
+		if addon.ServiceAccountRole == nil {
+			continue
+		}
+		if err := s.deleteAddonIAMRole(addon.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteRemovedAddonIAMRoles deletes the IAM roles created for the service accounts of installed addons
+// which are removed from the spec or don't configure a service account role anymore.
+func (s *Service) deleteRemovedAddonIAMRoles(installed []*eksaddons.EKSAddon) error {
+	if !s.scope.EnableIAM() {
+		return nil
+	}
+
+	configured := map[string]bool{}
+	for _, addon := range s.scope.Addons() {
+		configured[addon.Name] = addon.ServiceAccountRole != nil
+	}
+
+	for _, addon := range installed {
+		addonName := aws.StringValue(addon.Name)
+		if configured[addonName] || aws.StringValue(addon.ServiceAccountRoleARN) == "" {
+			continue
+		}
+
+		roleName, err := s.addonServiceAccountRoleName(addonName)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate IAM role name")
+		}
+		// Roles which CAPA didn't create for the addon are left alone.
+		if !strings.HasSuffix(aws.StringValue(addon.ServiceAccountRoleARN), "/"+roleName) {
+			continue
+		}
+
+		if err := s.deleteAddonIAMRole(addonName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAddonIAMRole deletes the IAM role created for the service account of an addon unless it's unmanaged.
+func (s *Service) deleteAddonIAMRole(addonName string) error {
+	roleName, err := s.addonServiceAccountRoleName(addonName)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate IAM role name")
+	}
+
+	role, err := s.GetIAMRole(roleName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "getting eks addon iam role %s", roleName)
+	}
+
+	if s.IsUnmanaged(role, s.scope.Name()) {
+		s.Debug("Skipping, EKS addon iam role deletion as role is unmanaged", "addon", addonName)
+		return nil
+	}
+
+	if err := s.DeleteRole(roleName); err != nil {
+		record.Eventf(s.scope.ControlPlane, "FailedIAMRoleDeletion", "Failed to delete IAM role %q of addon %s: %v", roleName, addonName, err)
+		return err
+	}
+	record.Eventf(s.scope.ControlPlane, "SuccessfulIAMRoleDeletion", "Deleted IAM role %q of addon %s", roleName, addonName)
+
+	return nil
+}
+
 func (s *Service) deleteControlPlaneIAMRole() error {
 	if s.scope.ControlPlane.Spec.RoleName == nil {
 		return nil
@@ -240,6 +416,12 @@ func (s *NodegroupService) reconcileNodegroupIAMRole() error {
 		return errors.Wrapf(err, "error ensuring policies are attached: %v", policies)
 	}
 
+	if s.scope.ControlPlane.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+		if err := s.EnsureInlinePolicy(s.scope.RoleName(), nodegroupIPv6CNIPolicyName, eksiam.NodegroupIPv6CNIPolicy(s.scope.Partition())); err != nil {
+			return errors.Wrap(err, "error ensuring the IPv6 CNI policy is set on node role")
+		}
+	}
+
 	return nil
 }
 
@@ -337,6 +519,11 @@ func (s *FargateService) reconcileFargateIAMRole() (requeue bool, err error) {
 		record.Eventf(s.scope.FargateProfile, "SuccessfulIAMRoleCreation", "Created fargate IAM role %q", s.scope.RoleName())
 	}
 
+	if s.IsUnmanaged(role, s.scope.ClusterName()) {
+		s.scope.Debug("Skipping, EKS fargate role policy assignment as role is unmanaged")
+		return false, nil
+	}
+
 	updatedRole, err := s.EnsureTagsAndPolicy(role, s.scope.ClusterName(), eksiam.FargateTrustRelationship(), s.scope.AdditionalTags())
 	if err != nil {
 		return updatedRole, errors.Wrapf(err, "error ensuring tags and policy document are set on fargate role")
@@ -379,7 +566,7 @@ func (s *FargateService) deleteFargateIAMRole() (reterr error) {
 
 	s.scope.Debug("Deleting EKS fargate IAM Role")
 
-	_, err := s.GetIAMRole(roleName)
+	role, err := s.GetIAMRole(roleName)
 	if err != nil {
 		if isNotFound(err) {
 			s.Debug("EKS fargate IAM Role already deleted")
@@ -389,6 +576,11 @@ func (s *FargateService) deleteFargateIAMRole() (reterr error) {
 		return errors.Wrap(err, "getting EKS fargate iam role")
 	}
 
+	if s.IsUnmanaged(role, s.scope.ClusterName()) {
+		s.Debug("Skipping, EKS fargate iam role deletion as role is unmanaged")
+		return nil
+	}
+
 	err = s.DeleteRole(s.scope.RoleName())
 	if err != nil {
 		record.Eventf(s.scope.FargateProfile, "FailedIAMRoleDeletion", "Failed to delete fargate IAM role %q: %v", s.scope.RoleName(), err)
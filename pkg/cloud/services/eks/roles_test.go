@@ -0,0 +1,481 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/iamauth/mock_iamauth"
+	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestEnsureIPv6CNIPolicy(t *testing.T) {
+	const roleName = "nodegroup-role"
+	policy := eksiam.NodegroupIPv6CNIPolicy("aws")
+	policyJSON, err := converters.IAMPolicyDocumentToJSON(*policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outdatedJSON, err := converters.IAMPolicyDocumentToJSON(*eksiam.NodegroupIPv6CNIPolicy("aws-us-gov"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	putPolicy := func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+		m.PutRolePolicy(&iam.PutRolePolicyInput{
+			RoleName:       aws.String(roleName),
+			PolicyName:     aws.String(nodegroupIPv6CNIPolicyName),
+			PolicyDocument: aws.String(policyJSON),
+		}).Return(&iam.PutRolePolicyOutput{}, nil)
+	}
+
+	tests := []struct {
+		name   string
+		expect func(m *mock_iamauth.MockIAMAPIMockRecorder)
+	}{
+		{
+			name: "puts a missing policy",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRolePolicy(gomock.Any()).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil))
+				putPolicy(m)
+			},
+		},
+		{
+			name: "leaves an up to date policy as is",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				// IAM returns the policy document URL encoded.
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.PathEscape(policyJSON))}, nil)
+			},
+		},
+		{
+			name: "updates an outdated policy",
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.PathEscape(outdatedJSON))}, nil)
+				putPolicy(m)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockControl := gomock.NewController(t)
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+			tc.expect(iamMock.EXPECT())
+
+			log := logger.NewLogger(logr.Discard())
+			s := &eksiam.IAMService{Wrapper: log, IAMClient: iamMock}
+
+			g.Expect(s.EnsureInlinePolicy(roleName, nodegroupIPv6CNIPolicyName, policy)).To(Succeed())
+		})
+	}
+}
+
+func TestReconcileAddonIAMRoles(t *testing.T) {
+	const (
+		clusterName = "cluster-test"
+		roleName    = "cluster-test_vpc-cni-iam-service-role"
+		roleARN     = "arn:aws:iam::123456789012:role/" + roleName
+		providerARN = "arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-east-1.amazonaws.com/id/ABCDEF"
+		cniPolicy   = "arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy"
+		ownedTagKey = "kubernetes.io/cluster/capi-name"
+	)
+	trustRelationship, err := converters.IAMPolicyDocumentToJSON(*eksiam.ServiceAccountTrustRelationship(providerARN, "kube-system", "aws-node"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownedRole := &iam.Role{
+		RoleName:                 aws.String(roleName),
+		Arn:                      aws.String(roleARN),
+		AssumeRolePolicyDocument: aws.String(url.PathEscape(trustRelationship)),
+		Tags:                     []*iam.Tag{{Key: aws.String(ownedTagKey), Value: aws.String("owned")}},
+	}
+	vpcCNI := ekscontrolplanev1.Addon{
+		Name:    "vpc-cni",
+		Version: "v1.18.0",
+		ServiceAccountRole: &ekscontrolplanev1.AddonServiceAccountRole{
+			ServiceAccountNamespace: "kube-system",
+			ServiceAccountName:      "aws-node",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		addons           []ekscontrolplanev1.Addon
+		disableIAM       bool
+		expect           func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectedRoleARNs map[string]string
+		expectErr        error
+	}{
+		{
+			name:             "doesn't create roles for addons without a service account role",
+			addons:           []ekscontrolplanev1.Addon{{Name: "coredns", Version: "v1.11.1"}},
+			expectedRoleARNs: map[string]string{},
+		},
+		{
+			name:   "creates a missing role with the policies required by the addon",
+			addons: []ekscontrolplanev1.Addon{vpcCNI},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil))
+				m.CreateRole(&iam.CreateRoleInput{
+					RoleName:                 aws.String(roleName),
+					AssumeRolePolicyDocument: aws.String(trustRelationship),
+					Tags:                     []*iam.Tag{{Key: aws.String(ownedTagKey), Value: aws.String("owned")}},
+				}).Return(&iam.CreateRoleOutput{Role: ownedRole}, nil)
+				m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)}).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(cniPolicy)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String(roleName), PolicyArn: aws.String(cniPolicy)}).Return(&iam.AttachRolePolicyOutput{}, nil)
+			},
+			expectedRoleARNs: map[string]string{"vpc-cni": roleARN},
+		},
+		{
+			name:   "leaves the policies of an unmanaged role as is",
+			addons: []ekscontrolplanev1.Addon{vpcCNI},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(&iam.GetRoleOutput{
+					Role: &iam.Role{RoleName: aws.String(roleName), Arn: aws.String(roleARN)},
+				}, nil)
+			},
+			expectedRoleARNs: map[string]string{"vpc-cni": roleARN},
+		},
+		{
+			name:       "fails if EKS IAM is disabled",
+			addons:     []ekscontrolplanev1.Addon{vpcCNI},
+			disableIAM: true,
+			expectErr:  ErrCannotCreateAddonRoles,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: clusterName,
+					Region:         "us-east-1",
+					Addons:         &tc.addons,
+				},
+				Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+					OIDCProvider: ekscontrolplanev1.OIDCProviderStatus{ARN: providerARN},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: controlPlane,
+				EnableIAM:    !tc.disableIAM,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(iamMock.EXPECT())
+			}
+			s := NewService(scope)
+			s.IAMClient = iamMock
+
+			roleARNs, err := s.reconcileAddonIAMRoles()
+			if tc.expectErr != nil {
+				g.Expect(err).To(MatchError(tc.expectErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(roleARNs).To(Equal(tc.expectedRoleARNs))
+		})
+	}
+}
+
+func TestDeleteRemovedAddonIAMRoles(t *testing.T) {
+	const (
+		clusterName = "cluster-test"
+		roleName    = "cluster-test_vpc-cni-iam-service-role"
+		roleARN     = "arn:aws:iam::123456789012:role/" + roleName
+		ownedTagKey = "kubernetes.io/cluster/capi-name"
+	)
+	ownedRole := &iam.Role{
+		RoleName: aws.String(roleName),
+		Arn:      aws.String(roleARN),
+		Tags:     []*iam.Tag{{Key: aws.String(ownedTagKey), Value: aws.String("owned")}},
+	}
+	installedVPCCNI := &eksaddons.EKSAddon{
+		Name:                  aws.String("vpc-cni"),
+		Version:               aws.String("v1.18.0"),
+		ServiceAccountRoleARN: aws.String(roleARN),
+	}
+	expectRoleDeleted := func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+		m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(&iam.GetRoleOutput{Role: ownedRole}, nil)
+		m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)}).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+		m.ListRolePolicies(&iam.ListRolePoliciesInput{RoleName: aws.String(roleName)}).Return(&iam.ListRolePoliciesOutput{}, nil)
+		m.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String(roleName)}).Return(&iam.DeleteRoleOutput{}, nil)
+	}
+
+	tests := []struct {
+		name      string
+		addons    []ekscontrolplanev1.Addon
+		installed []*eksaddons.EKSAddon
+		expect    func(m *mock_iamauth.MockIAMAPIMockRecorder)
+	}{
+		{
+			name: "keeps the role of an addon which configures a service account role",
+			addons: []ekscontrolplanev1.Addon{{
+				Name:               "vpc-cni",
+				Version:            "v1.18.0",
+				ServiceAccountRole: &ekscontrolplanev1.AddonServiceAccountRole{ServiceAccountName: "aws-node"},
+			}},
+			installed: []*eksaddons.EKSAddon{installedVPCCNI},
+		},
+		{
+			name:      "deletes the role of an addon whose service account role is removed",
+			addons:    []ekscontrolplanev1.Addon{{Name: "vpc-cni", Version: "v1.18.0"}},
+			installed: []*eksaddons.EKSAddon{installedVPCCNI},
+			expect:    expectRoleDeleted,
+		},
+		{
+			name:      "deletes the role of an addon which is removed",
+			installed: []*eksaddons.EKSAddon{installedVPCCNI},
+			expect:    expectRoleDeleted,
+		},
+		{
+			name:   "leaves a role which wasn't created for the addon",
+			addons: []ekscontrolplanev1.Addon{{Name: "vpc-cni", Version: "v1.18.0"}},
+			installed: []*eksaddons.EKSAddon{{
+				Name:                  aws.String("vpc-cni"),
+				Version:               aws.String("v1.18.0"),
+				ServiceAccountRoleARN: aws.String("arn:aws:iam::123456789012:role/my-cni-role"),
+			}},
+		},
+		{
+			name:      "leaves an unmanaged role",
+			addons:    []ekscontrolplanev1.Addon{{Name: "vpc-cni", Version: "v1.18.0"}},
+			installed: []*eksaddons.EKSAddon{installedVPCCNI},
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(&iam.GetRoleOutput{
+					Role: &iam.Role{RoleName: aws.String(roleName), Arn: aws.String(roleARN)},
+				}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: clusterName,
+					Region:         "us-east-1",
+					Addons:         &tc.addons,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane).Build()
+			scope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane: controlPlane,
+				EnableIAM:    true,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(iamMock.EXPECT())
+			}
+			s := NewService(scope)
+			s.IAMClient = iamMock
+
+			g.Expect(s.deleteRemovedAddonIAMRoles(tc.installed)).To(Succeed())
+		})
+	}
+}
+
+func TestReconcileFargateIAMRole(t *testing.T) {
+	const (
+		roleName      = "fargate-role"
+		fargatePolicy = "arn:aws:iam::aws:policy/AmazonEKSFargatePodExecutionRolePolicy"
+		ownedTagKey   = "kubernetes.io/cluster/capi-name"
+	)
+	trustRelationship, err := converters.IAMPolicyDocumentToJSON(*eksiam.FargateTrustRelationship())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownedRole := &iam.Role{
+		RoleName:                 aws.String(roleName),
+		AssumeRolePolicyDocument: aws.String(url.PathEscape(trustRelationship)),
+		Tags:                     []*iam.Tag{{Key: aws.String(ownedTagKey), Value: aws.String("owned")}},
+	}
+
+	tests := []struct {
+		name          string
+		roleName      string
+		disableIAM    bool
+		expect        func(m *mock_iamauth.MockIAMAPIMockRecorder)
+		expectRequeue bool
+		expectErr     error
+	}{
+		{
+			name:          "generates the role name if none is specified",
+			expectRequeue: true,
+		},
+		{
+			name:     "creates a missing role with the fargate trust relationship and policy",
+			roleName: roleName,
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil))
+				m.CreateRole(&iam.CreateRoleInput{
+					RoleName:                 aws.String(roleName),
+					AssumeRolePolicyDocument: aws.String(trustRelationship),
+					Tags:                     []*iam.Tag{{Key: aws.String(ownedTagKey), Value: aws.String("owned")}},
+				}).Return(&iam.CreateRoleOutput{Role: ownedRole}, nil)
+				m.ListAttachedRolePolicies(&iam.ListAttachedRolePoliciesInput{RoleName: aws.String(roleName)}).Return(&iam.ListAttachedRolePoliciesOutput{}, nil)
+				m.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(fargatePolicy)}).Return(&iam.GetPolicyOutput{}, nil)
+				m.AttachRolePolicy(&iam.AttachRolePolicyInput{RoleName: aws.String(roleName), PolicyArn: aws.String(fargatePolicy)}).Return(&iam.AttachRolePolicyOutput{}, nil)
+			},
+			expectRequeue: true,
+		},
+		{
+			name:     "leaves an unmanaged role as is",
+			roleName: roleName,
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(&iam.GetRoleOutput{
+					Role: &iam.Role{RoleName: aws.String(roleName)},
+				}, nil)
+			},
+		},
+		{
+			name:       "fails if the role is missing and EKS IAM is disabled",
+			roleName:   roleName,
+			disableIAM: true,
+			expect: func(m *mock_iamauth.MockIAMAPIMockRecorder) {
+				m.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)}).Return(nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil))
+			},
+			expectErr: ErrFargateRoleNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			_ = expinfrav1.AddToScheme(scheme)
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-source",
+					Namespace: "ns",
+				},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: "cluster-test",
+					Region:         "us-east-1",
+				},
+			}
+			profile := &expinfrav1.AWSFargateProfile{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "profile",
+					Namespace: "ns",
+				},
+				Spec: expinfrav1.FargateProfileSpec{
+					ProfileName: "profile",
+					RoleName:    tc.roleName,
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane, profile).Build()
+			scope, err := scope.NewFargateProfileScope(scope.FargateProfileScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "ns",
+						Name:      "capi-name",
+					},
+				},
+				ControlPlane:   controlPlane,
+				FargateProfile: profile,
+				EnableIAM:      !tc.disableIAM,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			iamMock := mock_iamauth.NewMockIAMAPI(mockControl)
+			if tc.expect != nil {
+				tc.expect(iamMock.EXPECT())
+			}
+			s := NewFargateService(scope)
+			s.IAMClient = iamMock
+
+			requeue, err := s.reconcileFargateIAMRole()
+			if tc.expectErr != nil {
+				g.Expect(err).To(MatchError(tc.expectErr))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requeue).To(Equal(tc.expectRequeue))
+			g.Expect(scope.RoleName()).NotTo(BeEmpty())
+		})
+	}
+}
@@ -22,12 +22,15 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 )
 
 func (s *Service) reconcileSecurityGroups(cluster *eks.Cluster) error {
@@ -62,6 +65,11 @@ func (s *Service) reconcileSecurityGroups(cluster *eks.Cluster) error {
 	}
 	s.scope.ControlPlane.Status.Network.SecurityGroups[infrav1.SecurityGroupNode] = sg
 
+	// EKS doesn't create a cluster security group for local clusters on Outposts.
+	if aws.StringValue(cluster.ResourcesVpcConfig.ClusterSecurityGroupId) == "" {
+		return nil
+	}
+
 	input = &ec2.DescribeSecurityGroupsInput{
 		GroupIds: []*string{
 			cluster.ResourcesVpcConfig.ClusterSecurityGroupId,
@@ -81,3 +89,267 @@ func (s *Service) reconcileSecurityGroups(cluster *eks.Cluster) error {
 
 	return nil
 }
+
+// NodegroupIngressRuleOwnerTagKey is the tag set on the security group rules added for the
+// additional ingress rules of a managed machine pool. Its value is the namespaced name of the pool.
+const NodegroupIngressRuleOwnerTagKey = infrav1.NameAWSProviderPrefix + "managed-machine-pool"
+
+// ingressPermission is a single security group rule, i.e. an ingress rule with exactly one source.
+type ingressPermission struct {
+	protocol      string
+	fromPort      int64
+	toPort        int64
+	cidr          string
+	ipv6Cidr      string
+	sourceGroupID string
+	description   string
+}
+
+func (s *NodegroupService) nodegroupSecurityGroupID(ng *eks.Nodegroup) *string {
+	if s.scope.ManagedMachinePool.Spec.RemoteAccess != nil {
+		if ng.Resources == nil {
+			return nil
+		}
+		return ng.Resources.RemoteAccessSecurityGroup
+	}
+
+	clusterSG, ok := s.scope.ControlPlane.Status.Network.SecurityGroups[ekscontrolplanev1.SecurityGroupCluster]
+	if !ok || clusterSG.ID == "" {
+		return nil
+	}
+	return aws.String(clusterSG.ID)
+}
+
+func (s *NodegroupService) nodegroupIngressRuleOwner() string {
+	return fmt.Sprintf("%s/%s", s.scope.ManagedMachinePool.Namespace, s.scope.ManagedMachinePool.Name)
+}
+
+func (s *NodegroupService) reconcileNodegroupSecurityGroupRules() error {
+	groupID := aws.StringValue(s.scope.ManagedMachinePool.Status.SecurityGroupID)
+	if len(s.scope.ManagedMachinePool.Spec.AdditionalIngressRules) > 0 && groupID == "" {
+		return errors.New("security group of the nodegroup is not known yet")
+	}
+
+	return reconcileOwnedIngressRules(s.EC2Client, s.scope, groupID, NodegroupIngressRuleOwnerTagKey, s.nodegroupIngressRuleOwner(), s.scope.ManagedMachinePool.Spec.AdditionalIngressRules)
+}
+
+func (s *NodegroupService) deleteNodegroupSecurityGroupRules() error {
+	existing, err := describeOwnedIngressRules(s.EC2Client, NodegroupIngressRuleOwnerTagKey, s.nodegroupIngressRuleOwner())
+	if err != nil {
+		return err
+	}
+
+	revoke := map[string][]*string{}
+	for _, rule := range existing {
+		revoke[aws.StringValue(rule.GroupId)] = append(revoke[aws.StringValue(rule.GroupId)], rule.SecurityGroupRuleId)
+	}
+
+	return revokeIngressRules(s.EC2Client, s.scope, revoke)
+}
+
+// ClusterIngressRuleOwnerTagKey is the tag set on the security group rules added to the EKS cluster
+// security group for the cluster security group ingress rules of a managed control plane. Its value
+// is the namespaced name of the control plane.
+const ClusterIngressRuleOwnerTagKey = infrav1.NameAWSProviderPrefix + "managed-control-plane"
+
+func (s *Service) clusterIngressRuleOwner() string {
+	return fmt.Sprintf("%s/%s", s.scope.ControlPlane.Namespace, s.scope.ControlPlane.Name)
+}
+
+// reconcileClusterSecurityGroupIngressRules applies the cluster security group ingress rules of the
+// control plane to the EKS cluster security group. The rules aren't revoked on deletion, as EKS deletes
+// the cluster security group along with the cluster.
+func (s *Service) reconcileClusterSecurityGroupIngressRules() error {
+	clusterSG, ok := s.scope.ControlPlane.Status.Network.SecurityGroups[ekscontrolplanev1.SecurityGroupCluster]
+	if !ok || clusterSG.ID == "" {
+		if len(s.scope.ControlPlane.Spec.ClusterSecurityGroupIngressRules) == 0 {
+			return nil
+		}
+		return errors.Errorf("%s security group not found on control plane", ekscontrolplanev1.SecurityGroupCluster)
+	}
+
+	return reconcileOwnedIngressRules(s.EC2Client, s.scope, clusterSG.ID, ClusterIngressRuleOwnerTagKey, s.clusterIngressRuleOwner(), s.scope.ControlPlane.Spec.ClusterSecurityGroupIngressRules)
+}
+
+// reconcileOwnedIngressRules makes the ingress rules tagged with the owner match the desired rules:
+// missing rules are authorized on the security group with the owner tag, and owned rules which are
+// no longer desired or belong to another security group are revoked.
+func reconcileOwnedIngressRules(ec2Client ec2iface.EC2API, log logger.Wrapper, groupID, tagKey, owner string, rules []infrav1.IngressRule) error {
+	desired := ingressPermissions(rules)
+	existing, err := describeOwnedIngressRules(ec2Client, tagKey, owner)
+	if err != nil {
+		return err
+	}
+
+	revoke := map[string][]*string{}
+	found := map[ingressPermission]bool{}
+	for _, rule := range existing {
+		permission := ingressPermissionFromSDK(rule)
+		if aws.StringValue(rule.GroupId) == groupID && desired[permission] && !found[permission] {
+			found[permission] = true
+			continue
+		}
+		revoke[aws.StringValue(rule.GroupId)] = append(revoke[aws.StringValue(rule.GroupId)], rule.SecurityGroupRuleId)
+	}
+
+	if err := revokeIngressRules(ec2Client, log, revoke); err != nil {
+		return err
+	}
+
+	input := &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: aws.String(groupID),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSecurityGroupRule),
+				Tags: []*ec2.Tag{
+					{
+						Key:   aws.String(tagKey),
+						Value: aws.String(owner),
+					},
+				},
+			},
+		},
+	}
+	for permission := range desired {
+		if !found[permission] {
+			input.IpPermissions = append(input.IpPermissions, permission.toSDK())
+		}
+	}
+	if len(input.IpPermissions) == 0 {
+		return nil
+	}
+
+	if _, err := ec2Client.AuthorizeSecurityGroupIngressWithContext(context.TODO(), input); err != nil {
+		return errors.Wrapf(err, "failed to authorize ingress rules for security group %q", groupID)
+	}
+	log.Debug("Authorized additional ingress rules", "security-group-id", groupID, "count", len(input.IpPermissions))
+
+	return nil
+}
+
+// describeOwnedIngressRules returns the ingress rules tagged with the owner, regardless of the
+// security group they belong to.
+func describeOwnedIngressRules(ec2Client ec2iface.EC2API, tagKey, owner string) ([]*ec2.SecurityGroupRule, error) {
+	input := &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + tagKey),
+				Values: aws.StringSlice([]string{owner}),
+			},
+		},
+	}
+
+	var rules []*ec2.SecurityGroupRule
+	err := ec2Client.DescribeSecurityGroupRulesPagesWithContext(context.TODO(), input, func(out *ec2.DescribeSecurityGroupRulesOutput, _ bool) bool {
+		for _, rule := range out.SecurityGroupRules {
+			if !aws.BoolValue(rule.IsEgress) {
+				rules = append(rules, rule)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe security group rules")
+	}
+
+	return rules, nil
+}
+
+func revokeIngressRules(ec2Client ec2iface.EC2API, log logger.Wrapper, ruleIDsByGroup map[string][]*string) error {
+	for groupID, ruleIDs := range ruleIDsByGroup {
+		input := &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              aws.String(groupID),
+			SecurityGroupRuleIds: ruleIDs,
+		}
+		if _, err := ec2Client.RevokeSecurityGroupIngressWithContext(context.TODO(), input); err != nil {
+			return errors.Wrapf(err, "failed to revoke ingress rules from security group %q", groupID)
+		}
+		log.Debug("Revoked additional ingress rules", "security-group-id", groupID, "count", len(ruleIDs))
+	}
+
+	return nil
+}
+
+func ingressPermissions(rules []infrav1.IngressRule) map[ingressPermission]bool {
+	permissions := map[ingressPermission]bool{}
+	for _, rule := range rules {
+		base := ingressPermission{
+			protocol:    string(rule.Protocol),
+			fromPort:    -1,
+			toPort:      -1,
+			description: rule.Description,
+		}
+		if protocolHasPorts(rule.Protocol) {
+			base.fromPort, base.toPort = rule.FromPort, rule.ToPort
+		}
+
+		for _, cidr := range rule.CidrBlocks {
+			permission := base
+			permission.cidr = cidr
+			permissions[permission] = true
+		}
+		for _, cidr := range rule.IPv6CidrBlocks {
+			permission := base
+			permission.ipv6Cidr = cidr
+			permissions[permission] = true
+		}
+		for _, groupID := range rule.SourceSecurityGroupIDs {
+			permission := base
+			permission.sourceGroupID = groupID
+			permissions[permission] = true
+		}
+	}
+	return permissions
+}
+
+func ingressPermissionFromSDK(rule *ec2.SecurityGroupRule) ingressPermission {
+	permission := ingressPermission{
+		protocol:    aws.StringValue(rule.IpProtocol),
+		fromPort:    aws.Int64Value(rule.FromPort),
+		toPort:      aws.Int64Value(rule.ToPort),
+		cidr:        aws.StringValue(rule.CidrIpv4),
+		ipv6Cidr:    aws.StringValue(rule.CidrIpv6),
+		description: aws.StringValue(rule.Description),
+	}
+	if rule.ReferencedGroupInfo != nil {
+		permission.sourceGroupID = aws.StringValue(rule.ReferencedGroupInfo.GroupId)
+	}
+	return permission
+}
+
+func (p ingressPermission) toSDK() *ec2.IpPermission {
+	permission := &ec2.IpPermission{
+		IpProtocol: aws.String(p.protocol),
+	}
+	if protocolHasPorts(infrav1.SecurityGroupProtocol(p.protocol)) {
+		permission.FromPort = aws.Int64(p.fromPort)
+		permission.ToPort = aws.Int64(p.toPort)
+	}
+
+	var description *string
+	if p.description != "" {
+		description = aws.String(p.description)
+	}
+	switch {
+	case p.cidr != "":
+		permission.IpRanges = []*ec2.IpRange{{CidrIp: aws.String(p.cidr), Description: description}}
+	case p.ipv6Cidr != "":
+		permission.Ipv6Ranges = []*ec2.Ipv6Range{{CidrIpv6: aws.String(p.ipv6Cidr), Description: description}}
+	case p.sourceGroupID != "":
+		permission.UserIdGroupPairs = []*ec2.UserIdGroupPair{{GroupId: aws.String(p.sourceGroupID), Description: description}}
+	}
+	return permission
+}
+
+// protocolHasPorts returns whether the from and to ports of a rule apply to the protocol.
+func protocolHasPorts(protocol infrav1.SecurityGroupProtocol) bool {
+	switch protocol {
+	case infrav1.SecurityGroupProtocolTCP,
+		infrav1.SecurityGroupProtocolUDP,
+		infrav1.SecurityGroupProtocolICMP,
+		infrav1.SecurityGroupProtocolICMPv6:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+)
+
+func TestNodegroupSecurityGroupID(t *testing.T) {
+	testCases := []struct {
+		name         string
+		remoteAccess *expinfrav1.ManagedRemoteAccess
+		resources    *eks.NodegroupResources
+		expected     *string
+	}{
+		{
+			name:     "uses the cluster security group without remote access",
+			expected: aws.String("sg-cluster"),
+		},
+		{
+			name:         "uses the remote access security group with remote access",
+			remoteAccess: &expinfrav1.ManagedRemoteAccess{Public: true},
+			resources:    &eks.NodegroupResources{RemoteAccessSecurityGroup: aws.String("sg-remote")},
+			expected:     aws.String("sg-remote"),
+		},
+		{
+			name:         "waits for the remote access security group to be created",
+			remoteAccess: &expinfrav1.ManagedRemoteAccess{Public: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &NodegroupService{
+				scope: &scope.ManagedMachinePoolScope{
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+							Network: infrav1.NetworkStatus{
+								SecurityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+									ekscontrolplanev1.SecurityGroupCluster: {ID: "sg-cluster"},
+								},
+							},
+						},
+					},
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						Spec: expinfrav1.AWSManagedMachinePoolSpec{RemoteAccess: tc.remoteAccess},
+					},
+				},
+			}
+
+			g.Expect(s.nodegroupSecurityGroupID(&eks.Nodegroup{Resources: tc.resources})).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestReconcileNodegroupSecurityGroupRules(t *testing.T) {
+	ownerFilter := &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + NodegroupIngressRuleOwnerTagKey),
+				Values: aws.StringSlice([]string{"default/pool"}),
+			},
+		},
+	}
+	nodeExporterRule := infrav1.IngressRule{
+		Description: "node exporter",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    9100,
+		ToPort:      9100,
+		CidrBlocks:  []string{"10.100.0.0/16"},
+	}
+	nodeExporterSDKRule := &ec2.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String("sgr-1"),
+		GroupId:             aws.String("sg-cluster"),
+		IpProtocol:          aws.String("tcp"),
+		FromPort:            aws.Int64(9100),
+		ToPort:              aws.Int64(9100),
+		CidrIpv4:            aws.String("10.100.0.0/16"),
+		Description:         aws.String("node exporter"),
+		IsEgress:            aws.Bool(false),
+	}
+
+	testCases := []struct {
+		name          string
+		rules         []infrav1.IngressRule
+		existing      []*ec2.SecurityGroupRule
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		expectErr     bool
+		securityGroup *string
+	}{
+		{
+			name:          "authorizes missing rules with the owner tag",
+			rules:         []infrav1.IngressRule{nodeExporterRule},
+			securityGroup: aws.String("sg-cluster"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.AuthorizeSecurityGroupIngressWithContext(context.TODO(), &ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-cluster"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(9100),
+							ToPort:     aws.Int64(9100),
+							IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.100.0.0/16"), Description: aws.String("node exporter")}},
+						},
+					},
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String(ec2.ResourceTypeSecurityGroupRule),
+							Tags:         []*ec2.Tag{{Key: aws.String(NodegroupIngressRuleOwnerTagKey), Value: aws.String("default/pool")}},
+						},
+					},
+				}).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+			},
+		},
+		{
+			name:          "does nothing when the rules are up to date",
+			rules:         []infrav1.IngressRule{nodeExporterRule},
+			existing:      []*ec2.SecurityGroupRule{nodeExporterSDKRule},
+			securityGroup: aws.String("sg-cluster"),
+		},
+		{
+			name:          "revokes owned rules which are no longer desired",
+			existing:      []*ec2.SecurityGroupRule{nodeExporterSDKRule},
+			securityGroup: aws.String("sg-cluster"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.RevokeSecurityGroupIngressWithContext(context.TODO(), &ec2.RevokeSecurityGroupIngressInput{
+					GroupId:              aws.String("sg-cluster"),
+					SecurityGroupRuleIds: aws.StringSlice([]string{"sgr-1"}),
+				}).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+			},
+		},
+		{
+			name:      "fails when the security group is not known yet",
+			rules:     []infrav1.IngressRule{nodeExporterRule},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			if !tc.expectErr {
+				ec2Mock.EXPECT().DescribeSecurityGroupRulesPagesWithContext(context.TODO(), ownerFilter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeSecurityGroupRulesInput, fn func(*ec2.DescribeSecurityGroupRulesOutput, bool) bool, _ ...interface{}) error {
+						fn(&ec2.DescribeSecurityGroupRulesOutput{SecurityGroupRules: tc.existing}, true)
+						return nil
+					})
+			}
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := &NodegroupService{
+				EC2Client: ec2Mock,
+				scope: &scope.ManagedMachinePoolScope{
+					Logger: *logger.NewLogger(logr.Discard()),
+					ManagedMachinePool: &expinfrav1.AWSManagedMachinePool{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pool"},
+						Spec:       expinfrav1.AWSManagedMachinePoolSpec{AdditionalIngressRules: tc.rules},
+						Status:     expinfrav1.AWSManagedMachinePoolStatus{SecurityGroupID: tc.securityGroup},
+					},
+				},
+			}
+
+			err := s.reconcileNodegroupSecurityGroupRules()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestReconcileClusterSecurityGroupIngressRules(t *testing.T) {
+	ownerFilter := &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + ClusterIngressRuleOwnerTagKey),
+				Values: aws.StringSlice([]string{"default/cp"}),
+			},
+		},
+	}
+	apiServerRule := infrav1.IngressRule{
+		Description: "management VPC",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    443,
+		ToPort:      443,
+		CidrBlocks:  []string{"10.200.0.0/16"},
+	}
+
+	testCases := []struct {
+		name          string
+		rules         []infrav1.IngressRule
+		existing      []*ec2.SecurityGroupRule
+		expect        func(m *mocks.MockEC2APIMockRecorder)
+		expectErr     bool
+		securityGroup string
+	}{
+		{
+			name:          "authorizes missing rules on the cluster security group",
+			rules:         []infrav1.IngressRule{apiServerRule},
+			securityGroup: "sg-cluster",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.AuthorizeSecurityGroupIngressWithContext(context.TODO(), &ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-cluster"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(443),
+							ToPort:     aws.Int64(443),
+							IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.200.0.0/16"), Description: aws.String("management VPC")}},
+						},
+					},
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String(ec2.ResourceTypeSecurityGroupRule),
+							Tags:         []*ec2.Tag{{Key: aws.String(ClusterIngressRuleOwnerTagKey), Value: aws.String("default/cp")}},
+						},
+					},
+				}).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+			},
+		},
+		{
+			name:          "revokes owned rules which are no longer desired",
+			securityGroup: "sg-cluster",
+			existing: []*ec2.SecurityGroupRule{
+				{
+					SecurityGroupRuleId: aws.String("sgr-1"),
+					GroupId:             aws.String("sg-cluster"),
+					IpProtocol:          aws.String("tcp"),
+					FromPort:            aws.Int64(443),
+					ToPort:              aws.Int64(443),
+					CidrIpv4:            aws.String("10.200.0.0/16"),
+					IsEgress:            aws.Bool(false),
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.RevokeSecurityGroupIngressWithContext(context.TODO(), &ec2.RevokeSecurityGroupIngressInput{
+					GroupId:              aws.String("sg-cluster"),
+					SecurityGroupRuleIds: aws.StringSlice([]string{"sgr-1"}),
+				}).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+			},
+		},
+		{
+			name:      "fails when the cluster security group is not known",
+			rules:     []infrav1.IngressRule{apiServerRule},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			if !tc.expectErr {
+				ec2Mock.EXPECT().DescribeSecurityGroupRulesPagesWithContext(context.TODO(), ownerFilter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *ec2.DescribeSecurityGroupRulesInput, fn func(*ec2.DescribeSecurityGroupRulesOutput, bool) bool, _ ...interface{}) error {
+						fn(&ec2.DescribeSecurityGroupRulesOutput{SecurityGroupRules: tc.existing}, true)
+						return nil
+					})
+			}
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			securityGroups := map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{}
+			if tc.securityGroup != "" {
+				securityGroups[ekscontrolplanev1.SecurityGroupCluster] = infrav1.SecurityGroup{ID: tc.securityGroup}
+			}
+			s := &Service{
+				EC2Client: ec2Mock,
+				scope: &scope.ManagedControlPlaneScope{
+					Logger: *logger.NewLogger(logr.Discard()),
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cp"},
+						Spec:       ekscontrolplanev1.AWSManagedControlPlaneSpec{ClusterSecurityGroupIngressRules: tc.rules},
+						Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+							Network: infrav1.NetworkStatus{SecurityGroups: securityGroups},
+						},
+					},
+				},
+			}
+
+			err := s.reconcileClusterSecurityGroupIngressRules()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
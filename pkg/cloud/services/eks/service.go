@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
@@ -89,9 +90,11 @@ func NewService(controlPlaneScope *scope.ManagedControlPlaneScope, opts ...Servi
 // The interfaces are broken down like this to group functions together.
 // One alternative is to have a large list of functions from the ec2 client.
 type NodegroupService struct {
-	scope             *scope.ManagedMachinePoolScope
-	AutoscalingClient autoscalingiface.AutoScalingAPI
-	EKSClient         eksiface.EKSAPI
+	scope               *scope.ManagedMachinePoolScope
+	AutoscalingClient   autoscalingiface.AutoScalingAPI
+	EC2Client           ec2iface.EC2API
+	EKSClient           eksiface.EKSAPI
+	ServiceQuotasClient servicequotasiface.ServiceQuotasAPI
 	iam.IAMService
 	STSClient stsiface.STSAPI
 }
@@ -99,9 +102,11 @@ type NodegroupService struct {
 // NewNodegroupService returns a new service given the api clients.
 func NewNodegroupService(machinePoolScope *scope.ManagedMachinePoolScope) *NodegroupService {
 	return &NodegroupService{
-		scope:             machinePoolScope,
-		AutoscalingClient: scope.NewASGClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
-		EKSClient:         scope.NewEKSClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		scope:               machinePoolScope,
+		AutoscalingClient:   scope.NewASGClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		EC2Client:           scope.NewEC2Client(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		EKSClient:           scope.NewEKSClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		ServiceQuotasClient: scope.NewServiceQuotasClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
 		IAMService: iam.IAMService{
 			Wrapper:   &machinePoolScope.Logger,
 			IAMClient: scope.NewIAMClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
@@ -18,7 +18,10 @@ package eks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -28,8 +31,8 @@ import (
 	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
-	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 const (
@@ -38,13 +41,85 @@ const (
 	eksClusterAutoscalerEnabledTag = "k8s.io/cluster-autoscaler/enabled"
 )
 
+// ClusterTagsLastAppliedAnnotation is the key for the AWSManagedControlPlane annotation which tracks
+// the tags applied to the EKS cluster, so that tags removed from the spec are removed from the cluster
+// while tags added by other tools are left untouched.
+const ClusterTagsLastAppliedAnnotation = "sigs.k8s.io/cluster-api-provider-aws-last-applied-tags"
+
 func (s *Service) reconcileTags(cluster *eks.Cluster) error {
-	clusterTags := converters.MapPtrToMap(cluster.Tags)
-	buildParams := s.getEKSTagParams(*cluster.Arn)
-	tagsBuilder := tags.New(buildParams, tags.WithEKS(s.EKSClient))
-	if err := tagsBuilder.Ensure(clusterTags); err != nil {
-		return fmt.Errorf("failed ensuring tags on cluster: %w", err)
+	clusterName := s.scope.KubernetesClusterName()
+	current := aws.StringValueMap(cluster.Tags)
+	desired := map[string]string{}
+	for key, value := range infrav1.Build(*s.getEKSTagParams(*cluster.Arn)) {
+		// Tag keys starting with `aws:` are reserved for internal AWS use.
+		if !strings.HasPrefix(key, tags.AwsInternalTagPrefix) {
+			desired[key] = value
+		}
+	}
+
+	lastApplied := map[string]string{}
+	if annotation, ok := s.scope.ControlPlane.Annotations[ClusterTagsLastAppliedAnnotation]; ok {
+		if err := json.Unmarshal([]byte(annotation), &lastApplied); err != nil {
+			return errors.Wrapf(err, "failed to parse %s annotation", ClusterTagsLastAppliedAnnotation)
+		}
+	}
+
+	newTags := map[string]string{}
+	var changedOutOfBand []string
+	for key, value := range desired {
+		if currentValue, ok := current[key]; ok && currentValue == value {
+			continue
+		}
+		newTags[key] = value
+		// A tag applied before which no longer matches has been changed or removed outside of CAPA.
+		if lastValue, ok := lastApplied[key]; ok && lastValue == value {
+			changedOutOfBand = append(changedOutOfBand, key)
+		}
+	}
+	var untagKeys []string
+	for key := range lastApplied {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		if _, ok := current[key]; ok {
+			untagKeys = append(untagKeys, key)
+		}
+	}
+	sort.Strings(changedOutOfBand)
+	sort.Strings(untagKeys)
+
+	if len(changedOutOfBand) > 0 {
+		record.Warnf(s.scope.ControlPlane, "EKSClusterTagsChanged", "Tags %s of EKS cluster %s were changed outside of CAPA, restoring them", strings.Join(changedOutOfBand, ", "), clusterName)
+	}
+
+	if len(newTags) > 0 {
+		if _, err := s.EKSClient.TagResource(&eks.TagResourceInput{
+			ResourceArn: cluster.Arn,
+			Tags:        aws.StringMap(newTags),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to tag eks cluster %q", clusterName)
+		}
+	}
+	if len(untagKeys) > 0 {
+		if _, err := s.EKSClient.UntagResource(&eks.UntagResourceInput{
+			ResourceArn: cluster.Arn,
+			TagKeys:     aws.StringSlice(untagKeys),
+		}); err != nil {
+			return errors.Wrapf(err, "failed to untag eks cluster %q", clusterName)
+		}
+	}
+	if len(newTags) > 0 || len(untagKeys) > 0 {
+		record.Eventf(s.scope.ControlPlane, "SuccessfulUpdateEKSClusterTags", "Updated tags of EKS cluster %s", clusterName)
+	}
+
+	annotation, err := json.Marshal(desired)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode applied tags")
+	}
+	if s.scope.ControlPlane.Annotations == nil {
+		s.scope.ControlPlane.Annotations = map[string]string{}
 	}
+	s.scope.ControlPlane.Annotations[ClusterTagsLastAppliedAnnotation] = string(annotation)
 
 	return nil
 }
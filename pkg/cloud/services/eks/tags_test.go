@@ -20,7 +20,16 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
 )
 
 func TestGetTagUpdates(t *testing.T) {
@@ -52,3 +61,100 @@ func TestGetTagUpdates(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileClusterTags(t *testing.T) {
+	clusterARN := aws.String("arn:aws:eks:us-east-1:123456789012:cluster/test-cluster")
+	capaTags := map[string]string{
+		"Name": "test-cluster",
+		"sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster": "owned",
+		"sigs.k8s.io/cluster-api-provider-aws/role":                 "common",
+	}
+	withTags := func(extra map[string]string) map[string]string {
+		tags := map[string]string{}
+		for k, v := range capaTags {
+			tags[k] = v
+		}
+		for k, v := range extra {
+			tags[k] = v
+		}
+		return tags
+	}
+
+	testCases := []struct {
+		name           string
+		additionalTags infrav1.Tags
+		lastApplied    string
+		current        map[string]string
+		expect         func(m *mock_eksiface.MockEKSAPIMockRecorder)
+	}{
+		{
+			name:           "does nothing when the tags are up to date",
+			additionalTags: infrav1.Tags{"team": "a"},
+			current:        withTags(map[string]string{"team": "a", "external": "x"}),
+		},
+		{
+			name:           "adds and updates tags",
+			additionalTags: infrav1.Tags{"team": "b", "env": "prod"},
+			current:        withTags(map[string]string{"team": "a"}),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.TagResource(&eks.TagResourceInput{
+					ResourceArn: clusterARN,
+					Tags:        aws.StringMap(map[string]string{"team": "b", "env": "prod"}),
+				}).Return(&eks.TagResourceOutput{}, nil)
+			},
+		},
+		{
+			name:        "removes tags which were applied before but leaves external tags",
+			lastApplied: `{"team":"a"}`,
+			current:     withTags(map[string]string{"team": "a", "external": "x"}),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.UntagResource(&eks.UntagResourceInput{
+					ResourceArn: clusterARN,
+					TagKeys:     aws.StringSlice([]string{"team"}),
+				}).Return(&eks.UntagResourceOutput{}, nil)
+			},
+		},
+		{
+			name:           "restores tags changed outside of CAPA",
+			additionalTags: infrav1.Tags{"team": "a"},
+			lastApplied:    `{"team":"a"}`,
+			current:        withTags(map[string]string{"team": "changed"}),
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.TagResource(&eks.TagResourceInput{
+					ResourceArn: clusterARN,
+					Tags:        aws.StringMap(map[string]string{"team": "a"}),
+				}).Return(&eks.TagResourceOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			eksMock := mock_eksiface.NewMockEKSAPI(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(eksMock.EXPECT())
+			}
+
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"},
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+					EKSClusterName: "test-cluster",
+					AdditionalTags: tc.additionalTags,
+				},
+			}
+			if tc.lastApplied != "" {
+				controlPlane.Annotations = map[string]string{ClusterTagsLastAppliedAnnotation: tc.lastApplied}
+			}
+			s := &Service{
+				EKSClient: eksMock,
+				scope:     &scope.ManagedControlPlaneScope{ControlPlane: controlPlane},
+			}
+
+			err := s.reconcileTags(&eks.Cluster{Arn: clusterARN, Tags: aws.StringMap(tc.current)})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(controlPlane.Annotations).To(HaveKey(ClusterTagsLastAppliedAnnotation))
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/pkg/errors"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
@@ -78,6 +79,12 @@ func IsNotFound(err error) bool {
 	return false
 }
 
+// isLBNotFound returns true if the error reports that a v2 load balancer doesn't exist.
+func isLBNotFound(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException
+}
+
 // IsAccessDenied returns true if the error is AccessDenied.
 func IsAccessDenied(err error) bool {
 	if code, ok := awserrors.Code(errors.Cause(err)); ok {
@@ -40,6 +40,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
@@ -64,6 +65,10 @@ const apiServerTargetGroupPrefix = "apiserver-target-"
 // listeners.
 const additionalTargetGroupPrefix = "additional-listener-"
 
+// defaultClassicELBAccessLogsEmitInterval is the interval, in minutes, at which classic load balancers publish
+// their access logs when no interval is configured.
+const defaultClassicELBAccessLogsEmitInterval = 60
+
 // cantAttachSGToNLBRegions is a set of regions that do not support Security Groups in NLBs.
 var cantAttachSGToNLBRegions = sets.New("us-iso-east-1", "us-iso-west-1", "us-isob-east-1")
 
@@ -105,6 +110,21 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 	}
 	lb, err := s.describeLB(name, lbSpec)
 	switch {
+	case IsNotFound(err) && lbSpec.IsExisting():
+		// The load balancer is managed by the user, never create it.
+		return errors.Wrapf(err, "existing load balancer %q referenced by the AWSCluster %s not found", *lbSpec.ARN, s.scope.InfraClusterName())
+	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid() && s.isLBSchemeChange(name, desiredLB.Scheme):
+		// The scheme of the primary load balancer changed, create the replacement next to the current load balancer.
+		lb, err = s.createLB(desiredLB, lbSpec)
+		if err != nil {
+			s.scope.Error(err, "failed to create replacement LB")
+			return err
+		}
+
+		s.scope.Info("Created load balancer to replace the apiserver load balancer after a scheme change", "api-server-lb-name", lb.Name,
+			"replaced-lb-name", s.scope.Network().APIServerELB.Name, "scheme", lb.Scheme)
+		record.Eventf(s.scope.InfraCluster(), "CreatedReplacementLoadBalancer", "Created load balancer %s to replace %s after a scheme change to %s",
+			lb.Name, s.scope.Network().APIServerELB.Name, lb.Scheme)
 	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid():
 		// if elb is not found and owner cluster ControlPlaneEndpoint is already populated, then we should not recreate the elb.
 		return errors.Wrapf(err, "no loadbalancer exists for the AWSCluster %s, the cluster has become unrecoverable and should be deleted manually", s.scope.InfraClusterName())
@@ -134,7 +154,7 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 
 	// set up the type for later processing
 	lb.LoadBalancerType = lbSpec.LoadBalancerType
-	if lb.IsManaged(s.scope.Name()) {
+	if lb.IsManaged(s.scope.Name()) && !lbSpec.IsExisting() {
 		// Reconcile the target groups and listeners from the spec and the ones currently attached to the load balancer.
 		// Pass in the ARN that AWS gave us, as well as the rest of the desired specification.
 		_, _, err := s.reconcileTargetGroupsAndListeners(lb.ARN, desiredLB, lbSpec)
@@ -142,6 +162,16 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 			return errors.Wrapf(err, "failed to create target groups/listeners for load balancer %q", lb.Name)
 		}
 
+		if err := s.pruneStaleTargets(lb.ARN); err != nil {
+			return errors.Wrapf(err, "failed to remove stale control plane instances from load balancer %q", lb.Name)
+		}
+
+		// Disable the access logs that were enabled by a previous configuration.
+		if _, ok := desiredLB.ELBAttributes[infrav1.LoadBalancerAttributeAccessLogsS3Enabled]; !ok &&
+			aws.StringValue(lb.ELBAttributes[infrav1.LoadBalancerAttributeAccessLogsS3Enabled]) == "true" {
+			desiredLB.ELBAttributes[infrav1.LoadBalancerAttributeAccessLogsS3Enabled] = aws.String("false")
+		}
+
 		if !cmp.Equal(desiredLB.ELBAttributes, lb.ELBAttributes) {
 			if err := s.configureLBAttributes(lb.ARN, desiredLB.ELBAttributes); err != nil {
 				return err
@@ -179,20 +209,165 @@ func (s *Service) reconcileV2LB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
 		}
 	} else {
 		s.scope.Trace("Unmanaged control plane load balancer, skipping load balancer configuration", "api-server-elb", lb)
+
+		if lbSpec.IsExisting() {
+			if err := s.pruneStaleTargets(lb.ARN); err != nil {
+				return errors.Wrapf(err, "failed to remove stale control plane instances from load balancer %q", lb.Name)
+			}
+		}
 	}
 
 	if s.scope.ControlPlaneLoadBalancers()[1] != nil && lb.Name == *s.scope.ControlPlaneLoadBalancers()[1].Name {
 		lb.DeepCopyInto(&s.scope.Network().SecondaryAPIServerELB)
 	} else {
+		if err := s.reconcileLBReplacement(lb); err != nil {
+			return err
+		}
 		lb.DeepCopyInto(&s.scope.Network().APIServerELB)
 	}
 
 	return nil
 }
 
+// allowsLBSchemeChange returns true if the user allowed replacing the primary load balancer to change its scheme.
+func (s *Service) allowsLBSchemeChange() bool {
+	return s.scope.InfraCluster().GetAnnotations()[infrav1.AllowControlPlaneLoadBalancerSchemeChangeAnnotation] == "true"
+}
+
+// isLBSchemeChange returns true if the primary load balancer with the given name and scheme is meant to replace
+// the current api server load balancer after a scheme change.
+func (s *Service) isLBSchemeChange(name string, scheme infrav1.ELBScheme) bool {
+	current := s.scope.Network().APIServerELB
+	return s.allowsLBSchemeChange() && current.Name != "" && current.Name != name && current.Scheme != scheme
+}
+
+// reconcileLBReplacement switches the cluster over from the current api server load balancer to the given one,
+// which replaces it after a scheme change, once the replacement has healthy api server targets. Until then,
+// the control plane endpoint keeps pointing to the current load balancer. The replaced load balancer is kept
+// until the scheme change annotation is removed, as nodes may still use the previous endpoint.
+func (s *Service) reconcileLBReplacement(lb *infrav1.LoadBalancer) error {
+	network := s.scope.Network()
+	if current := network.APIServerELB; current.Name != "" && current.Name != lb.Name {
+		if current.ARN != "" {
+			if err := s.copyLBTargets(current.ARN, lb.ARN); err != nil {
+				return errors.Wrapf(err, "failed to register control plane instances with load balancer %q", lb.Name)
+			}
+		}
+		healthy, err := s.hasHealthyAPIServerTargets(lb.ARN)
+		if err != nil {
+			return err
+		}
+		if !healthy {
+			return errors.Errorf("waiting for load balancer %q replacing %q to report healthy api server targets", lb.Name, current.Name)
+		}
+
+		network.ReplacedAPIServerELB = current.DeepCopy()
+		s.scope.Info("Replaced the apiserver load balancer after a scheme change", "api-server-lb-name", lb.Name, "replaced-lb-name", current.Name)
+		record.Eventf(s.scope.InfraCluster(), "ReplacedLoadBalancer", "Replaced load balancer %s by %s, the control plane endpoint changes to %s",
+			current.Name, lb.Name, lb.DNSName)
+	}
+
+	if network.ReplacedAPIServerELB != nil && !s.allowsLBSchemeChange() {
+		if err := s.deleteReplacedLB(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteReplacedLB deletes the api server load balancer that was replaced after a scheme change, if any.
+func (s *Service) deleteReplacedLB() error {
+	replaced := s.scope.Network().ReplacedAPIServerELB
+	if replaced == nil {
+		return nil
+	}
+
+	if replaced.ARN != "" {
+		if err := s.deleteLB(replaced.ARN); err != nil && !isLBNotFound(err) {
+			return errors.Wrapf(err, "failed to delete replaced load balancer %q", replaced.Name)
+		}
+	}
+	s.scope.Info("Deleted the replaced apiserver load balancer", "name", replaced.Name)
+	record.Eventf(s.scope.InfraCluster(), "DeletedReplacedLoadBalancer", "Deleted load balancer %s replaced after a scheme change", replaced.Name)
+	s.scope.Network().ReplacedAPIServerELB = nil
+	return nil
+}
+
+// copyLBTargets registers the targets of the target groups of one load balancer with the target groups
+// of another load balancer forwarding to the same port.
+func (s *Service) copyLBTargets(fromARN, toARN string) error {
+	from, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(fromARN)})
+	if err != nil {
+		if isLBNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to describe target groups for load balancer %q", fromARN)
+	}
+	to, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(toARN)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe target groups for load balancer %q", toARN)
+	}
+
+	for _, fromGroup := range from.TargetGroups {
+		out, err := s.ELBV2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: fromGroup.TargetGroupArn})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe target health for target group %q", aws.StringValue(fromGroup.TargetGroupName))
+		}
+		var targets []*elbv2.TargetDescription
+		for _, desc := range out.TargetHealthDescriptions {
+			if desc.Target != nil {
+				targets = append(targets, &elbv2.TargetDescription{Id: desc.Target.Id, Port: desc.Target.Port})
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+
+		for _, toGroup := range to.TargetGroups {
+			if aws.Int64Value(toGroup.Port) != aws.Int64Value(fromGroup.Port) {
+				continue
+			}
+			if _, err := s.ELBV2Client.RegisterTargets(&elbv2.RegisterTargetsInput{
+				TargetGroupArn: toGroup.TargetGroupArn,
+				Targets:        targets,
+			}); err != nil {
+				return errors.Wrapf(err, "failed to register targets with target group %q", aws.StringValue(toGroup.TargetGroupName))
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasHealthyAPIServerTargets returns true if the api server target group of a load balancer has a healthy target.
+func (s *Service) hasHealthyAPIServerTargets(lbARN string) (bool, error) {
+	groups, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lbARN)})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to describe target groups for load balancer %q", lbARN)
+	}
+
+	for _, tg := range groups.TargetGroups {
+		if aws.Int64Value(tg.Port) != infrav1.DefaultAPIServerPort {
+			continue
+		}
+		out, err := s.ELBV2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: tg.TargetGroupArn})
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to describe target health for target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+		for _, desc := range out.TargetHealthDescriptions {
+			if desc.TargetHealth != nil && aws.StringValue(desc.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
 // getAPITargetGroupHealthCheck creates the health check for the Kube apiserver target group,
-// limiting the customization for the health check probe counters (skipping standarized/reserved
-// fields: Protocol, Port or Path). To customize the health check protocol, use HealthCheckProtocol instead.
+// limiting the customization for the health check probe counters, path and port (skipping the
+// standarized/reserved Protocol field). To customize the health check protocol, use HealthCheckProtocol instead.
 func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSpec) *infrav1.TargetGroupHealthCheck {
 	apiHealthCheckProtocol := infrav1.ELBProtocolTCP.String()
 	if lbSpec != nil && lbSpec.HealthCheckProtocol != nil {
@@ -209,7 +384,7 @@ func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSp
 		UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 	}
 	if apiHealthCheckProtocol == infrav1.ELBProtocolHTTP.String() || apiHealthCheckProtocol == infrav1.ELBProtocolHTTPS.String() {
-		apiHealthCheck.Path = aws.String(infrav1.DefaultAPIServerHealthCheckPath)
+		apiHealthCheck.Path = aws.String(apiServerHealthCheckPath(lbSpec))
 	}
 
 	if lbSpec != nil && lbSpec.HealthCheck != nil {
@@ -226,6 +401,9 @@ func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSp
 		if lbSpec.HealthCheck.UnhealthyThresholdCount != nil {
 			apiHealthCheck.UnhealthyThresholdCount = lbSpec.HealthCheck.UnhealthyThresholdCount
 		}
+		if lbSpec.HealthCheck.Port != nil {
+			apiHealthCheck.Port = aws.String(strconv.FormatInt(*lbSpec.HealthCheck.Port, 10))
+		}
 	}
 	return apiHealthCheck
 }
@@ -234,7 +412,7 @@ func (s *Service) getAPITargetGroupHealthCheck(lbSpec *infrav1.AWSLoadBalancerSp
 // Additional listeners allows to set customized attributes for health check.
 func (s *Service) getAdditionalTargetGroupHealthCheck(ln infrav1.AdditionalListenerSpec) *infrav1.TargetGroupHealthCheck {
 	healthCheck := &infrav1.TargetGroupHealthCheck{
-		Port:                    aws.String(fmt.Sprintf("%d", ln.Port)),
+		Port:                    aws.String(fmt.Sprintf("%d", ln.GetTargetPort())),
 		Protocol:                aws.String(ln.Protocol.String()),
 		Path:                    nil,
 		IntervalSeconds:         aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
@@ -309,7 +487,7 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 		for _, listener := range lbSpec.AdditionalListeners {
 			lnHealthCheck := &infrav1.TargetGroupHealthCheck{
 				Protocol: aws.String(string(listener.Protocol)),
-				Port:     aws.String(strconv.FormatInt(listener.Port, 10)),
+				Port:     aws.String(strconv.FormatInt(listener.GetTargetPort(), 10)),
 			}
 			if listener.HealthCheck != nil {
 				s.scope.Trace("Found health check override in the additional listener spec, applying it to the Target Group", listener.HealthCheck)
@@ -320,7 +498,7 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 				Port:     listener.Port,
 				TargetGroup: infrav1.TargetGroupSpec{
 					Name:        names.SimpleNameGenerator.GenerateName(additionalTargetGroupPrefix),
-					Port:        listener.Port,
+					Port:        listener.GetTargetPort(),
 					Protocol:    listener.Protocol,
 					VpcID:       s.scope.VPC().ID,
 					HealthCheck: lnHealthCheck,
@@ -331,33 +509,40 @@ func (s *Service) getAPIServerLBSpec(elbName string, lbSpec *infrav1.AWSLoadBala
 
 	if lbSpec != nil && lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeNLB {
 		res.ELBAttributes[infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds] = aws.String(infrav1.LoadBalancerAttributeIdleTimeDefaultTimeoutSecondsInSeconds)
+		if lbSpec.IdleTimeout != nil {
+			res.ELBAttributes[infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds] = aws.String(strconv.FormatInt(*lbSpec.IdleTimeout, 10))
+		}
 	}
 
-	if lbSpec != nil {
+	// Cross-zone load balancing is always enabled for application load balancers and can't be configured.
+	if lbSpec != nil && lbSpec.LoadBalancerType != infrav1.LoadBalancerTypeALB {
 		isCrossZoneLB := lbSpec.CrossZoneLoadBalancing
 		res.ELBAttributes[infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone] = aws.String(strconv.FormatBool(isCrossZoneLB))
 	}
 
+	if lbSpec != nil && lbSpec.AccessLogs != nil {
+		res.ELBAttributes[infrav1.LoadBalancerAttributeAccessLogsS3Enabled] = aws.String("true")
+		res.ELBAttributes[infrav1.LoadBalancerAttributeAccessLogsS3Bucket] = aws.String(lbSpec.AccessLogs.Bucket)
+		res.ELBAttributes[infrav1.LoadBalancerAttributeAccessLogsS3Prefix] = aws.String(lbSpec.AccessLogs.Prefix)
+	}
+
 	res.Tags = infrav1.Build(infrav1.BuildParams{
 		ClusterName: s.scope.Name(),
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
 		Name:        aws.String(elbName),
 		Role:        aws.String(infrav1.APIServerRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		Additional:  s.additionalLBTags(lbSpec),
 	})
 
-	// If subnet IDs have been specified for this load balancer
-	if lbSpec != nil && len(lbSpec.Subnets) > 0 {
+	// If subnets have been specified for this load balancer
+	if hasSubnetSelection(lbSpec) {
 		// This set of subnets may not match the subnets specified on the Cluster, so we may not have already discovered them
 		// We need to call out to AWS to describe them just in case
-		input := &ec2.DescribeSubnetsInput{
-			SubnetIds: aws.StringSlice(lbSpec.Subnets),
-		}
-		out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+		lbSubnets, err := s.describeLoadBalancerSubnets(lbSpec)
 		if err != nil {
 			return nil, err
 		}
-		for _, sn := range out.Subnets {
+		for _, sn := range lbSubnets {
 			res.AvailabilityZones = append(res.AvailabilityZones, *sn.AvailabilityZone)
 			res.SubnetIDs = append(res.SubnetIDs, *sn.SubnetId)
 		}
@@ -448,6 +633,7 @@ func (s *Service) createLB(spec *infrav1.LoadBalancer, lbSpec *infrav1.AWSLoadBa
 	s.scope.Debug("applying load balancer DNS to result", "dns", *out.LoadBalancers[0].DNSName)
 	res.DNSName = *out.LoadBalancers[0].DNSName
 	res.ARN = *out.LoadBalancers[0].LoadBalancerArn
+	res.CanonicalHostedZoneID = aws.StringValue(out.LoadBalancers[0].CanonicalHostedZoneId)
 	return res, nil
 }
 
@@ -524,6 +710,9 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 
 	apiELB, err := s.describeClassicELB(spec.Name)
 	switch {
+	case IsNotFound(err) && s.scope.ControlPlaneLoadBalancer().IsExisting():
+		// The load balancer is managed by the user, never create it.
+		return errors.Wrapf(err, "existing load balancer %q referenced by the AWSCluster %s not found", *s.scope.ControlPlaneLoadBalancer().ARN, s.scope.InfraClusterName())
 	case IsNotFound(err) && s.scope.ControlPlaneEndpoint().IsValid():
 		// if elb is not found and owner cluster ControlPlaneEndpoint is already populated, then we should not recreate the elb.
 		return errors.Wrapf(err, "no loadbalancer exists for the AWSCluster %s, the cluster has become unrecoverable and should be deleted manually", s.scope.InfraClusterName())
@@ -538,7 +727,7 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 		return err
 	}
 
-	if apiELB.IsManaged(s.scope.Name()) {
+	if apiELB.IsManaged(s.scope.Name()) && !s.scope.ControlPlaneLoadBalancer().IsExisting() {
 		if !cmp.Equal(spec.ClassicElbAttributes, apiELB.ClassicElbAttributes) {
 			err := s.configureAttributes(apiELB.Name, spec.ClassicElbAttributes)
 			if err != nil {
@@ -584,6 +773,10 @@ func (s *Service) reconcileClassicLoadBalancer() error {
 		s.scope.Trace("Unmanaged control plane load balancer, skipping load balancer configuration", "api-server-elb", apiELB)
 	}
 
+	if err := s.pruneStaleClassicELBInstances(apiELB.Name); err != nil {
+		return errors.Wrapf(err, "failed to remove stale control plane instances from load balancer %q", apiELB.Name)
+	}
+
 	if len(apiELB.AvailabilityZones) != len(spec.AvailabilityZones) {
 		apiELB.AvailabilityZones = spec.AvailabilityZones
 	}
@@ -615,6 +808,12 @@ func (s *Service) configureHealthCheck(name string, healthCheck *infrav1.Classic
 func (s *Service) deleteAPIServerELB() error {
 	s.scope.Debug("Deleting control plane load balancer")
 
+	if s.scope.ControlPlaneLoadBalancer().IsExisting() {
+		s.scope.Debug("Control plane load balancer is an existing load balancer, skipping deletion")
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.LoadBalancerReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
 	elbName, err := ELBName(s.scope)
 	if err != nil {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
@@ -709,6 +908,10 @@ func (s *Service) DeleteLoadbalancers() error {
 		return errors.Wrap(err, "failed to delete AWS cloud provider load balancer(s)")
 	}
 
+	if err := s.deleteReplacedLB(); err != nil {
+		return errors.Wrap(err, "failed to delete replaced control plane load balancer")
+	}
+
 	return nil
 }
 
@@ -726,6 +929,11 @@ func (s *Service) deleteExistingNLBs() error {
 }
 
 func (s *Service) deleteExistingNLB(lbSpec *infrav1.AWSLoadBalancerSpec) error {
+	if lbSpec.IsExisting() {
+		s.scope.Debug("Control plane load balancer is an existing load balancer, skipping deletion", "arn", *lbSpec.ARN)
+		return nil
+	}
+
 	name, err := LBName(s.scope, lbSpec)
 	if err != nil {
 		return errors.Wrap(err, "failed to get control plane load balancer name")
@@ -864,7 +1072,7 @@ func (s *Service) RegisterInstanceWithAPIServerELB(i *infrav1.Instance) error {
 	}
 	instanceAZ := instanceSubnet.AvailabilityZone
 
-	if s.scope.ControlPlaneLoadBalancer() != nil && len(s.scope.ControlPlaneLoadBalancer().Subnets) > 0 {
+	if hasSubnetSelection(s.scope.ControlPlaneLoadBalancer()) {
 		subnets, err = s.getControlPlaneLoadBalancerSubnets()
 		if err != nil {
 			return err
@@ -938,15 +1146,12 @@ func (s *Service) RegisterInstanceWithAPIServerLB(instance *infrav1.Instance, lb
 func (s *Service) getControlPlaneLoadBalancerSubnets() (infrav1.Subnets, error) {
 	var subnets infrav1.Subnets
 
-	input := &ec2.DescribeSubnetsInput{
-		SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
-	}
-	res, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+	lbSubnets, err := s.describeLoadBalancerSubnets(s.scope.ControlPlaneLoadBalancer())
 	if err != nil {
 		return nil, err
 	}
 
-	for _, sn := range res.Subnets {
+	for _, sn := range lbSubnets {
 		lbSn := infrav1.SubnetSpec{
 			AvailabilityZone: *sn.AvailabilityZone,
 			ID:               *sn.SubnetId,
@@ -958,6 +1163,182 @@ func (s *Service) getControlPlaneLoadBalancerSubnets() (infrav1.Subnets, error)
 	return subnets, nil
 }
 
+// hasSubnetSelection returns true if the load balancer subnets have been selected by ID or by filters
+// instead of being discovered from the cluster subnets.
+func hasSubnetSelection(lbSpec *infrav1.AWSLoadBalancerSpec) bool {
+	return lbSpec != nil && (len(lbSpec.Subnets) > 0 || len(lbSpec.SubnetFilters) > 0)
+}
+
+// describeLoadBalancerSubnets describes the subnets selected for a load balancer by ID or by filters,
+// and validates that they belong to the cluster VPC with at most one subnet per availability zone.
+func (s *Service) describeLoadBalancerSubnets(lbSpec *infrav1.AWSLoadBalancerSpec) ([]*ec2.Subnet, error) {
+	input := &ec2.DescribeSubnetsInput{}
+	if len(lbSpec.Subnets) > 0 {
+		input.SubnetIds = aws.StringSlice(lbSpec.Subnets)
+	} else {
+		if s.scope.VPC().ID != "" {
+			input.Filters = append(input.Filters, filter.EC2.VPC(s.scope.VPC().ID))
+		}
+		for _, f := range lbSpec.SubnetFilters {
+			input.Filters = append(input.Filters, &ec2.Filter{
+				Name:   aws.String(f.Name),
+				Values: aws.StringSlice(f.Values),
+			})
+		}
+	}
+
+	out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe load balancer subnets")
+	}
+	if len(out.Subnets) == 0 {
+		return nil, errors.New("no subnets found for the load balancer")
+	}
+
+	zones := make(map[string]string, len(out.Subnets))
+	for _, sn := range out.Subnets {
+		subnetID := aws.StringValue(sn.SubnetId)
+		if vpcID := s.scope.VPC().ID; vpcID != "" && aws.StringValue(sn.VpcId) != vpcID {
+			return nil, errors.Errorf("load balancer subnet %q belongs to VPC %q, expected %q", subnetID, aws.StringValue(sn.VpcId), vpcID)
+		}
+		az := aws.StringValue(sn.AvailabilityZone)
+		if other, ok := zones[az]; ok {
+			return nil, errors.Errorf("load balancer subnets %q and %q are both in availability zone %q, only one subnet per availability zone is allowed", other, subnetID, az)
+		}
+		zones[az] = subnetID
+	}
+
+	return out.Subnets, nil
+}
+
+// pruneStaleClassicELBInstances deregisters the control plane instances of the cluster that are out of service
+// on the classic ELB because they are no longer running, for example after an instance or availability zone failure.
+func (s *Service) pruneStaleClassicELBInstances(name string) error {
+	out, err := s.ELBClient.DescribeInstanceHealth(&elb.DescribeInstanceHealthInput{
+		LoadBalancerName: aws.String(name),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe instance health for load balancer %q", name)
+	}
+
+	// Only instances reported as unhealthy because of the instance itself can be stopped or terminated.
+	var outOfService []string
+	for _, state := range out.InstanceStates {
+		if aws.StringValue(state.State) != "InService" && aws.StringValue(state.ReasonCode) == "Instance" {
+			outOfService = append(outOfService, aws.StringValue(state.InstanceId))
+		}
+	}
+
+	stale, err := s.staleControlPlaneInstanceIDs(outOfService)
+	if err != nil || len(stale) == 0 {
+		return err
+	}
+
+	instances := make([]*elb.Instance, 0, len(stale))
+	for _, id := range sets.List(stale) {
+		instances = append(instances, &elb.Instance{InstanceId: aws.String(id)})
+	}
+	if _, err := s.ELBClient.DeregisterInstancesFromLoadBalancer(&elb.DeregisterInstancesFromLoadBalancerInput{
+		Instances:        instances,
+		LoadBalancerName: aws.String(name),
+	}); err != nil {
+		return err
+	}
+	s.scope.Info("Deregistered stale control plane instances from load balancer", "name", name, "instances", sets.List(stale))
+
+	return nil
+}
+
+// pruneStaleTargets deregisters the control plane instances of the cluster that are no longer running
+// from the target groups of a v2 load balancer.
+func (s *Service) pruneStaleTargets(lbARN string) error {
+	targetGroups, err := s.ELBV2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
+		LoadBalancerArn: aws.String(lbARN),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe target groups for load balancer %q", lbARN)
+	}
+
+	for _, tg := range targetGroups.TargetGroups {
+		out, err := s.ELBV2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe target health for target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+
+		var invalid []string
+		for _, desc := range out.TargetHealthDescriptions {
+			if desc.Target == nil || desc.TargetHealth == nil {
+				continue
+			}
+			// Targets of stopped or terminated instances are reported with the invalid state reason.
+			if aws.StringValue(desc.TargetHealth.Reason) == elbv2.TargetHealthReasonEnumTargetInvalidState {
+				invalid = append(invalid, aws.StringValue(desc.Target.Id))
+			}
+		}
+
+		stale, err := s.staleControlPlaneInstanceIDs(invalid)
+		if err != nil {
+			return err
+		}
+		if len(stale) == 0 {
+			continue
+		}
+
+		var targets []*elbv2.TargetDescription
+		for _, desc := range out.TargetHealthDescriptions {
+			if desc.Target != nil && stale.Has(aws.StringValue(desc.Target.Id)) {
+				targets = append(targets, desc.Target)
+			}
+		}
+		if _, err := s.ELBV2Client.DeregisterTargets(&elbv2.DeregisterTargetsInput{
+			TargetGroupArn: tg.TargetGroupArn,
+			Targets:        targets,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to deregister targets from target group %q", aws.StringValue(tg.TargetGroupName))
+		}
+		s.scope.Info("Deregistered stale control plane instances from target group", "target-group", aws.StringValue(tg.TargetGroupName), "instances", sets.List(stale))
+	}
+
+	return nil
+}
+
+// staleControlPlaneInstanceIDs returns the subset of the given instance IDs that are control plane instances
+// owned by the cluster and are no longer running. Instances that CAPA does not recognize as control plane
+// members of the cluster are never returned.
+func (s *Service) staleControlPlaneInstanceIDs(instanceIDs []string) (sets.Set[string], error) {
+	stale := sets.New[string]()
+	if len(instanceIDs) == 0 {
+		return stale, nil
+	}
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.InstanceIDs(instanceIDs...),
+			filter.EC2.ClusterOwned(s.scope.KubernetesClusterName()),
+			filter.EC2.ProviderRole(infrav1.ControlPlaneRoleTagValue),
+			filter.EC2.InstanceStates(
+				ec2.InstanceStateNameShuttingDown,
+				ec2.InstanceStateNameTerminated,
+				ec2.InstanceStateNameStopping,
+				ec2.InstanceStateNameStopped,
+			),
+		},
+	}
+	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe load balancer instances")
+	}
+	for _, res := range out.Reservations {
+		for _, instance := range res.Instances {
+			stale.Insert(aws.StringValue(instance.InstanceId))
+		}
+	}
+
+	return stale, nil
+}
+
 // DeregisterInstanceFromAPIServerELB de-registers an instance from a classic ELB.
 func (s *Service) DeregisterInstanceFromAPIServerELB(i *infrav1.Instance) error {
 	name, err := ELBName(s.scope)
@@ -1011,10 +1392,13 @@ func (s *Service) DeregisterInstanceFromAPIServerLB(targetGroupArn string, i *in
 	return err
 }
 
-// ELBName returns the user-defined API Server ELB name, or a generated default if the user has not defined the ELB
-// name.
+// ELBName returns the name of the existing API Server ELB, the user-defined API Server ELB name, or a generated
+// default if the user has not defined the ELB name.
 // This is only for the primary load balancer.
 func ELBName(s scope.ELBScope) (string, error) {
+	if lbSpec := s.ControlPlaneLoadBalancer(); lbSpec.IsExisting() {
+		return existingLBName(lbSpec)
+	}
 	if userDefinedName := s.ControlPlaneLoadBalancerName(); userDefinedName != nil {
 		return *userDefinedName, nil
 	}
@@ -1025,10 +1409,13 @@ func ELBName(s scope.ELBScope) (string, error) {
 	return name, nil
 }
 
-// LBName returns the user-defined API Server LB name, or a generated default if the user has not defined the LB
-// name.
+// LBName returns the name of the existing API Server LB, the user-defined API Server LB name, or a generated
+// default if the user has not defined the LB name.
 // This is used for both the primary and secondary load balancers.
 func LBName(s scope.ELBScope, lbSpec *infrav1.AWSLoadBalancerSpec) (string, error) {
+	if lbSpec.IsExisting() {
+		return existingLBName(lbSpec)
+	}
 	if lbSpec != nil && lbSpec.Name != nil {
 		return *lbSpec.Name, nil
 	}
@@ -1039,6 +1426,15 @@ func LBName(s scope.ELBScope, lbSpec *infrav1.AWSLoadBalancerSpec) (string, erro
 	return name, nil
 }
 
+// existingLBName returns the name of the existing load balancer referenced by the spec.
+func existingLBName(lbSpec *infrav1.AWSLoadBalancerSpec) (string, error) {
+	name, _, err := infrav1.ParseLoadBalancerARN(*lbSpec.ARN)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse existing load balancer ARN")
+	}
+	return name, nil
+}
+
 // GenerateELBName generates a formatted ELB name via either
 // concatenating the cluster name to the "-apiserver" suffix
 // or computing a hash for clusters with names above 32 characters.
@@ -1119,6 +1515,33 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 
 	if s.scope.ControlPlaneLoadBalancer() != nil {
 		res.ClassicElbAttributes.CrossZoneLoadBalancing = s.scope.ControlPlaneLoadBalancer().CrossZoneLoadBalancing
+		if idleTimeout := s.scope.ControlPlaneLoadBalancer().IdleTimeout; idleTimeout != nil {
+			res.ClassicElbAttributes.IdleTimeout = time.Duration(*idleTimeout) * time.Second
+		}
+		if accessLogs := s.scope.ControlPlaneLoadBalancer().AccessLogs; accessLogs != nil {
+			res.ClassicElbAttributes.AccessLogs = &infrav1.LoadBalancerAccessLogs{
+				Bucket:              accessLogs.Bucket,
+				Prefix:              accessLogs.Prefix,
+				EmitIntervalMinutes: aws.Int64(defaultClassicELBAccessLogsEmitInterval),
+			}
+			if accessLogs.EmitIntervalMinutes != nil {
+				res.ClassicElbAttributes.AccessLogs.EmitIntervalMinutes = accessLogs.EmitIntervalMinutes
+			}
+		}
+		if healthCheck := s.scope.ControlPlaneLoadBalancer().HealthCheck; healthCheck != nil {
+			if healthCheck.IntervalSeconds != nil {
+				res.HealthCheck.Interval = time.Duration(*healthCheck.IntervalSeconds) * time.Second
+			}
+			if healthCheck.TimeoutSeconds != nil {
+				res.HealthCheck.Timeout = time.Duration(*healthCheck.TimeoutSeconds) * time.Second
+			}
+			if healthCheck.ThresholdCount != nil {
+				res.HealthCheck.HealthyThreshold = *healthCheck.ThresholdCount
+			}
+			if healthCheck.UnhealthyThresholdCount != nil {
+				res.HealthCheck.UnhealthyThreshold = *healthCheck.UnhealthyThresholdCount
+			}
+		}
 	}
 
 	res.Tags = infrav1.Build(infrav1.BuildParams{
@@ -1126,21 +1549,18 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 		Lifecycle:   infrav1.ResourceLifecycleOwned,
 		Name:        aws.String(elbName),
 		Role:        aws.String(infrav1.APIServerRoleTagValue),
-		Additional:  s.scope.AdditionalTags(),
+		Additional:  s.additionalLBTags(s.scope.ControlPlaneLoadBalancer()),
 	})
 
-	// If subnet IDs have been specified for this load balancer
-	if s.scope.ControlPlaneLoadBalancer() != nil && len(s.scope.ControlPlaneLoadBalancer().Subnets) > 0 {
+	// If subnets have been specified for this load balancer
+	if hasSubnetSelection(s.scope.ControlPlaneLoadBalancer()) {
 		// This set of subnets may not match the subnets specified on the Cluster, so we may not have already discovered them
 		// We need to call out to AWS to describe them just in case
-		input := &ec2.DescribeSubnetsInput{
-			SubnetIds: aws.StringSlice(s.scope.ControlPlaneLoadBalancer().Subnets),
-		}
-		out, err := s.EC2Client.DescribeSubnetsWithContext(context.TODO(), input)
+		lbSubnets, err := s.describeLoadBalancerSubnets(s.scope.ControlPlaneLoadBalancer())
 		if err != nil {
 			return nil, err
 		}
-		for _, sn := range out.Subnets {
+		for _, sn := range lbSubnets {
 			res.AvailabilityZones = append(res.AvailabilityZones, *sn.AvailabilityZone)
 			res.SubnetIDs = append(res.SubnetIDs, *sn.SubnetId)
 		}
@@ -1170,6 +1590,15 @@ func (s *Service) getAPIServerClassicELBSpec(elbName string) (*infrav1.LoadBalan
 	return res, nil
 }
 
+// additionalLBTags returns the additional tags of the cluster, merged with the ones of the load balancer.
+func (s *Service) additionalLBTags(lbSpec *infrav1.AWSLoadBalancerSpec) infrav1.Tags {
+	additional := s.scope.AdditionalTags()
+	if lbSpec != nil {
+		additional.Merge(lbSpec.AdditionalTags)
+	}
+	return additional
+}
+
 func (s *Service) createClassicELB(spec *infrav1.LoadBalancer) (*infrav1.LoadBalancer, error) {
 	input := &elb.CreateLoadBalancerInput{
 		LoadBalancerName: aws.String(spec.Name),
@@ -1236,6 +1665,19 @@ func (s *Service) configureAttributes(name string, attributes infrav1.ClassicELB
 		}
 	}
 
+	if attributes.AccessLogs != nil {
+		attrs.LoadBalancerAttributes.AccessLog = &elb.AccessLog{
+			Enabled:        aws.Bool(true),
+			S3BucketName:   aws.String(attributes.AccessLogs.Bucket),
+			S3BucketPrefix: aws.String(attributes.AccessLogs.Prefix),
+			EmitInterval:   attributes.AccessLogs.EmitIntervalMinutes,
+		}
+	} else {
+		attrs.LoadBalancerAttributes.AccessLog = &elb.AccessLog{
+			Enabled: aws.Bool(false),
+		}
+	}
+
 	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 		if _, err := s.ELBClient.ModifyLoadBalancerAttributes(attrs); err != nil {
 			return false, err
@@ -1656,6 +2098,8 @@ func (s *Service) reconcileTargetGroupsAndListeners(lbARN string, spec *infrav1.
 					return nil, nil, errors.Wrapf(err, "failed to modify target group attribute")
 				}
 			}
+		} else if err := s.reconcileTargetGroupHealthCheck(group, tgSpec.HealthCheck); err != nil {
+			return nil, nil, err
 		}
 
 		var listener *elbv2.Listener
@@ -1706,6 +2150,52 @@ func (s *Service) createListener(ln infrav1.Listener, group *elbv2.TargetGroup,
 	return listener.Listeners[0], nil
 }
 
+// reconcileTargetGroupHealthCheck updates the health check of an existing Target Group
+// when its settings have drifted from the desired ones.
+func (s *Service) reconcileTargetGroupHealthCheck(group *elbv2.TargetGroup, healthCheck *infrav1.TargetGroupHealthCheck) error {
+	if healthCheck == nil {
+		return nil
+	}
+
+	input := &elbv2.ModifyTargetGroupInput{
+		TargetGroupArn: group.TargetGroupArn,
+	}
+	needsUpdate := false
+	if healthCheck.Port != nil && group.HealthCheckPort != nil && *healthCheck.Port != *group.HealthCheckPort {
+		input.HealthCheckPort = healthCheck.Port
+		needsUpdate = true
+	}
+	if healthCheck.Path != nil && group.HealthCheckPath != nil && *healthCheck.Path != *group.HealthCheckPath {
+		input.HealthCheckPath = healthCheck.Path
+		needsUpdate = true
+	}
+	if healthCheck.IntervalSeconds != nil && group.HealthCheckIntervalSeconds != nil && *healthCheck.IntervalSeconds != *group.HealthCheckIntervalSeconds {
+		input.HealthCheckIntervalSeconds = healthCheck.IntervalSeconds
+		needsUpdate = true
+	}
+	if healthCheck.TimeoutSeconds != nil && group.HealthCheckTimeoutSeconds != nil && *healthCheck.TimeoutSeconds != *group.HealthCheckTimeoutSeconds {
+		input.HealthCheckTimeoutSeconds = healthCheck.TimeoutSeconds
+		needsUpdate = true
+	}
+	if healthCheck.ThresholdCount != nil && group.HealthyThresholdCount != nil && *healthCheck.ThresholdCount != *group.HealthyThresholdCount {
+		input.HealthyThresholdCount = healthCheck.ThresholdCount
+		needsUpdate = true
+	}
+	if healthCheck.UnhealthyThresholdCount != nil && group.UnhealthyThresholdCount != nil && *healthCheck.UnhealthyThresholdCount != *group.UnhealthyThresholdCount {
+		input.UnhealthyThresholdCount = healthCheck.UnhealthyThresholdCount
+		needsUpdate = true
+	}
+	if !needsUpdate {
+		return nil
+	}
+
+	s.scope.Debug("updating target group health check", "group", aws.StringValue(group.TargetGroupName), "input", input)
+	if _, err := s.ELBV2Client.ModifyTargetGroup(input); err != nil {
+		return errors.Wrapf(err, "failed to modify health check of target group %q", aws.StringValue(group.TargetGroupName))
+	}
+	return nil
+}
+
 // createTargetGroup creates a single Target Group.
 func (s *Service) createTargetGroup(ln infrav1.Listener, tags map[string]string) (*elbv2.TargetGroup, error) {
 	targetGroupInput := &elbv2.CreateTargetGroupInput{
@@ -1719,9 +2209,8 @@ func (s *Service) createTargetGroup(ln infrav1.Listener, tags map[string]string)
 		HealthyThresholdCount:      aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
 		UnhealthyThresholdCount:    aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 	}
-	if s.scope.VPC().IsIPv6Enabled() {
-		targetGroupInput.IpAddressType = aws.String("ipv6")
-	}
+	// Instances are registered by ID over their primary IPv4 address, so the target group stays on IPv4 in
+	// dual-stack VPCs.
 	if ln.TargetGroup.HealthCheck != nil {
 		targetGroupInput.HealthCheckEnabled = aws.Bool(true)
 		targetGroupInput.HealthCheckProtocol = ln.TargetGroup.HealthCheck.Protocol
@@ -1762,21 +2251,38 @@ func (s *Service) getHealthCheckTarget() string {
 	if controlPlaneELB != nil && controlPlaneELB.HealthCheckProtocol != nil {
 		protocol = controlPlaneELB.HealthCheckProtocol
 		if protocol.String() == infrav1.ELBProtocolHTTP.String() || protocol.String() == infrav1.ELBProtocolHTTPS.String() {
-			return fmt.Sprintf("%v:%d%s", protocol, infrav1.DefaultAPIServerPort, infrav1.DefaultAPIServerHealthCheckPath)
+			return fmt.Sprintf("%v:%d%s", protocol, apiServerHealthCheckPort(controlPlaneELB), apiServerHealthCheckPath(controlPlaneELB))
 		}
 	}
-	return fmt.Sprintf("%v:%d", protocol, infrav1.DefaultAPIServerPort)
+	return fmt.Sprintf("%v:%d", protocol, apiServerHealthCheckPort(controlPlaneELB))
+}
+
+// apiServerHealthCheckPort returns the port used by health checks of the API server.
+func apiServerHealthCheckPort(lbSpec *infrav1.AWSLoadBalancerSpec) int64 {
+	if lbSpec != nil && lbSpec.HealthCheck != nil && lbSpec.HealthCheck.Port != nil {
+		return *lbSpec.HealthCheck.Port
+	}
+	return infrav1.DefaultAPIServerPort
+}
+
+// apiServerHealthCheckPath returns the path used by HTTP and HTTPS health checks of the API server.
+func apiServerHealthCheckPath(lbSpec *infrav1.AWSLoadBalancerSpec) string {
+	if lbSpec != nil && lbSpec.HealthCheck != nil && lbSpec.HealthCheck.Path != nil {
+		return *lbSpec.HealthCheck.Path
+	}
+	return infrav1.DefaultAPIServerHealthCheckPath
 }
 
 func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBalancerAttributes, tags []*elb.Tag) *infrav1.LoadBalancer {
 	res := &infrav1.LoadBalancer{
-		Name:             aws.StringValue(v.LoadBalancerName),
-		Scheme:           infrav1.ELBScheme(*v.Scheme),
-		SubnetIDs:        aws.StringValueSlice(v.Subnets),
-		SecurityGroupIDs: aws.StringValueSlice(v.SecurityGroups),
-		DNSName:          aws.StringValue(v.DNSName),
-		Tags:             converters.ELBTagsToMap(tags),
-		LoadBalancerType: infrav1.LoadBalancerTypeClassic,
+		Name:                  aws.StringValue(v.LoadBalancerName),
+		Scheme:                infrav1.ELBScheme(*v.Scheme),
+		SubnetIDs:             aws.StringValueSlice(v.Subnets),
+		SecurityGroupIDs:      aws.StringValueSlice(v.SecurityGroups),
+		DNSName:               aws.StringValue(v.DNSName),
+		Tags:                  converters.ELBTagsToMap(tags),
+		LoadBalancerType:      infrav1.LoadBalancerTypeClassic,
+		CanonicalHostedZoneID: aws.StringValue(v.CanonicalHostedZoneNameID),
 	}
 
 	if attrs.ConnectionSettings != nil && attrs.ConnectionSettings.IdleTimeout != nil {
@@ -1785,6 +2291,14 @@ func fromSDKTypeToClassicELB(v *elb.LoadBalancerDescription, attrs *elb.LoadBala
 
 	res.ClassicElbAttributes.CrossZoneLoadBalancing = aws.BoolValue(attrs.CrossZoneLoadBalancing.Enabled)
 
+	if attrs.AccessLog != nil && aws.BoolValue(attrs.AccessLog.Enabled) {
+		res.ClassicElbAttributes.AccessLogs = &infrav1.LoadBalancerAccessLogs{
+			Bucket:              aws.StringValue(attrs.AccessLog.S3BucketName),
+			Prefix:              aws.StringValue(attrs.AccessLog.S3BucketPrefix),
+			EmitIntervalMinutes: attrs.AccessLog.EmitInterval,
+		}
+	}
+
 	return res
 }
 
@@ -1796,14 +2310,15 @@ func fromSDKTypeToLB(v *elbv2.LoadBalancer, attrs []*elbv2.LoadBalancerAttribute
 		availabilityZones[i] = az.ZoneName
 	}
 	res := &infrav1.LoadBalancer{
-		ARN:               aws.StringValue(v.LoadBalancerArn),
-		Name:              aws.StringValue(v.LoadBalancerName),
-		Scheme:            infrav1.ELBScheme(aws.StringValue(v.Scheme)),
-		SubnetIDs:         aws.StringValueSlice(subnetIDs),
-		SecurityGroupIDs:  aws.StringValueSlice(v.SecurityGroups),
-		AvailabilityZones: aws.StringValueSlice(availabilityZones),
-		DNSName:           aws.StringValue(v.DNSName),
-		Tags:              converters.V2TagsToMap(tags),
+		ARN:                   aws.StringValue(v.LoadBalancerArn),
+		Name:                  aws.StringValue(v.LoadBalancerName),
+		Scheme:                infrav1.ELBScheme(aws.StringValue(v.Scheme)),
+		SubnetIDs:             aws.StringValueSlice(subnetIDs),
+		SecurityGroupIDs:      aws.StringValueSlice(v.SecurityGroups),
+		AvailabilityZones:     aws.StringValueSlice(availabilityZones),
+		DNSName:               aws.StringValue(v.DNSName),
+		Tags:                  converters.V2TagsToMap(tags),
+		CanonicalHostedZoneID: aws.StringValue(v.CanonicalHostedZoneId),
 	}
 
 	infraAttrs := make(map[string]*string, len(attrs))
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -89,6 +90,21 @@ func TestELBName(t *testing.T) {
 			},
 			expected: "myapiserver",
 		},
+		{
+			name: "existing load balancer is referenced by arn, so use its name",
+			awsCluster: &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						ARN: ptr.To[string]("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/my-existing-elb"),
+					},
+				},
+			},
+			expected: "my-existing-elb",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -196,6 +212,67 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "load balancer config without idle timeout",
+			lb:    &infrav1.AWSLoadBalancerSpec{},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicElbAttributes.IdleTimeout).To(Equal(10 * time.Minute))
+			},
+		},
+		{
+			name: "load balancer config with idle timeout",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				IdleTimeout: aws.Int64(3600),
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicElbAttributes.IdleTimeout).To(Equal(time.Hour))
+			},
+		},
+		{
+			name: "load balancer config with access logs",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				AccessLogs: &infrav1.LoadBalancerAccessLogs{
+					Bucket: "access-logs",
+					Prefix: "apiserver",
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ClassicElbAttributes.AccessLogs).To(Equal(&infrav1.LoadBalancerAccessLogs{
+					Bucket:              "access-logs",
+					Prefix:              "apiserver",
+					EmitIntervalMinutes: aws.Int64(60),
+				}))
+			},
+		},
+		{
+			name: "load balancer config with custom health check",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Port:                    aws.Int64(8443),
+					IntervalSeconds:         aws.Int64(5),
+					TimeoutSeconds:          aws.Int64(3),
+					ThresholdCount:          aws.Int64(2),
+					UnhealthyThresholdCount: aws.Int64(2),
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.HealthCheck).To(Equal(&infrav1.ClassicELBHealthCheck{
+					Target:             "TCP:8443",
+					Interval:           5 * time.Second,
+					Timeout:            3 * time.Second,
+					HealthyThreshold:   2,
+					UnhealthyThreshold: 2,
+				}))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -266,6 +343,23 @@ func TestGetAPIServerClassicELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				g.Expect(expectedTarget).To(Equal(res.HealthCheck.Target))
 			},
 		},
+		{
+			name: "load balancer config with additional tags",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				AdditionalTags: infrav1.Tags{
+					"cost-center": "1234",
+					"Name":        "platform-prod-apiserver",
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.Tags).To(HaveKeyWithValue("cost-center", "1234"))
+				g.Expect(res.Tags).To(HaveKeyWithValue("Name", "platform-prod-apiserver"))
+				g.Expect(res.Tags).To(HaveKeyWithValue(infrav1.ClusterTagKey("bar"), string(infrav1.ResourceLifecycleOwned)))
+				g.Expect(res.Tags).To(HaveKeyWithValue(infrav1.NameAWSClusterAPIRole, infrav1.APIServerRoleTagValue))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -345,6 +439,58 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "application load balancer config with cross zone enabled",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:       infrav1.LoadBalancerTypeALB,
+				CrossZoneLoadBalancing: true,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).NotTo(HaveKey(infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone))
+			},
+		},
+		{
+			name: "network load balancer config with cross zone disabled",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeEnableLoadBalancingCrossZone, aws.String("false")))
+			},
+		},
+		{
+			name: "application load balancer config with idle timeout",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeALB,
+				IdleTimeout:      aws.Int64(3600),
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeIdleTimeTimeoutSeconds, aws.String("3600")))
+			},
+		},
+		{
+			name: "load balancer config with access logs",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				AccessLogs: &infrav1.LoadBalancerAccessLogs{
+					Bucket: "access-logs",
+					Prefix: "apiserver",
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeAccessLogsS3Enabled, aws.String("true")))
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeAccessLogsS3Bucket, aws.String("access-logs")))
+				g.Expect(res.ELBAttributes).To(HaveKeyWithValue(infrav1.LoadBalancerAttributeAccessLogsS3Prefix, aws.String("apiserver")))
+			},
+		},
 		{
 			name: "load balancer config with subnets specified",
 			lb: &infrav1.AWSLoadBalancerSpec{
@@ -426,6 +572,44 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "An additional listener forwards traffic to its target port",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{
+					{
+						Port:       8132,
+						Protocol:   infrav1.ELBProtocolTCP,
+						TargetPort: aws.Int64(8133),
+					},
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.ELBListeners).To(HaveLen(2))
+				g.Expect(res.ELBListeners[1].Port).To(Equal(int64(8132)))
+				g.Expect(res.ELBListeners[1].TargetGroup.Port).To(Equal(int64(8133)))
+				g.Expect(res.ELBListeners[1].TargetGroup.HealthCheck.Port).To(Equal(aws.String("8133")))
+			},
+		},
+		{
+			name: "load balancer config with additional tags",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				AdditionalTags: infrav1.Tags{
+					"cost-center": "1234",
+					"Name":        "platform-prod-apiserver",
+				},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {},
+			expect: func(t *testing.T, g *WithT, res *infrav1.LoadBalancer) {
+				t.Helper()
+				g.Expect(res.Tags).To(HaveKeyWithValue("cost-center", "1234"))
+				g.Expect(res.Tags).To(HaveKeyWithValue("Name", "platform-prod-apiserver"))
+				g.Expect(res.Tags).To(HaveKeyWithValue(infrav1.ClusterTagKey("bar"), string(infrav1.ResourceLifecycleOwned)))
+				g.Expect(res.Tags).To(HaveKeyWithValue(infrav1.NameAWSClusterAPIRole, infrav1.APIServerRoleTagValue))
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -474,6 +658,162 @@ func TestGetAPIServerV2ELBSpecControlPlaneLoadBalancer(t *testing.T) {
 	}
 }
 
+func TestAdditionalLBTags(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				AdditionalTags: infrav1.Tags{
+					"team":        "platform",
+					"environment": "prod",
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := &Service{scope: clusterScope}
+
+	g.Expect(s.additionalLBTags(nil)).To(Equal(infrav1.Tags{"team": "platform", "environment": "prod"}))
+	g.Expect(s.additionalLBTags(&infrav1.AWSLoadBalancerSpec{
+		AdditionalTags: infrav1.Tags{"team": "kubernetes", "cost-center": "1234"},
+	})).To(Equal(infrav1.Tags{"team": "kubernetes", "environment": "prod", "cost-center": "1234"}))
+	// The tags of the cluster are left untouched.
+	g.Expect(clusterScope.AdditionalTags()).To(Equal(infrav1.Tags{"team": "platform", "environment": "prod"}))
+}
+
+func TestDescribeLoadBalancerSubnets(t *testing.T) {
+	tests := []struct {
+		name        string
+		lb          *infrav1.AWSLoadBalancerSpec
+		mocks       func(m *mocks.MockEC2APIMockRecorder)
+		expectErr   bool
+		expectedIDs []string
+	}{
+		{
+			name: "subnets selected by filters are restricted to the cluster VPC",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				SubnetFilters: []infrav1.Filter{{Name: "tag:lb-placement", Values: []string{"control-plane"}}},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-1"})},
+						{Name: aws.String("tag:lb-placement"), Values: aws.StringSlice([]string{"control-plane"})},
+					},
+				})).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1a")},
+						{SubnetId: aws.String("subnet-2"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1b")},
+					},
+				}, nil)
+			},
+			expectedIDs: []string{"subnet-1", "subnet-2"},
+		},
+		{
+			name: "subnets outside the cluster VPC are rejected",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				Subnets: []string{"subnet-1"},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-2"), AvailabilityZone: aws.String("us-east-1a")},
+					},
+				}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name: "more than one subnet per availability zone is rejected",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				Subnets: []string{"subnet-1", "subnet-2"},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeSubnetsOutput{
+					Subnets: []*ec2.Subnet{
+						{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1a")},
+						{SubnetId: aws.String("subnet-2"), VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-east-1a")},
+					},
+				}, nil)
+			},
+			expectErr: true,
+		},
+		{
+			name: "filters matching no subnets are rejected",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				SubnetFilters: []infrav1.Filter{{Name: "tag:lb-placement", Values: []string{"missing"}}},
+			},
+			mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeSubnetsOutput{}, nil)
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						ControlPlaneLoadBalancer: tc.lb,
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{ID: "vpc-1"},
+						},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.mocks(ec2Mock.EXPECT())
+
+			s := &Service{
+				scope:     clusterScope,
+				EC2Client: ec2Mock,
+			}
+
+			subnets, err := s.describeLoadBalancerSubnets(tc.lb)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			ids := make([]string, 0, len(subnets))
+			for _, sn := range subnets {
+				ids = append(ids, aws.StringValue(sn.SubnetId))
+			}
+			g.Expect(ids).To(Equal(tc.expectedIDs))
+		})
+	}
+}
+
 func TestRegisterInstanceWithAPIServerELB(t *testing.T) {
 	const (
 		namespace       = "foo"
@@ -1216,7 +1556,7 @@ func TestCreateNLB(t *testing.T) {
 			},
 		},
 		{
-			name: "created with ipv6 vpc",
+			name: "created as a dualstack load balancer in a dual-stack vpc",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
 				return spec
 			},
@@ -1597,7 +1937,7 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 			},
 		},
 		{
-			name: "created with ipv6 vpc",
+			name: "created with an ipv4 target group in a dual-stack vpc",
 			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
 				return spec
 			},
@@ -1615,11 +1955,10 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 					TargetGroups: []*elbv2.TargetGroup{},
 				}, nil)
 				m.CreateTargetGroup(gomock.Eq(&elbv2.CreateTargetGroupInput{
-					Name:          aws.String("name"),
-					Port:          aws.Int64(infrav1.DefaultAPIServerPort),
-					Protocol:      aws.String("TCP"),
-					VpcId:         aws.String(vpcID),
-					IpAddressType: aws.String("ipv6"),
+					Name:     aws.String("name"),
+					Port:     aws.Int64(infrav1.DefaultAPIServerPort),
+					Protocol: aws.String("TCP"),
+					VpcId:    aws.String(vpcID),
 					Tags: []*elbv2.Tag{
 						{
 							Key:   aws.String("test"),
@@ -1643,7 +1982,6 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 							UnhealthyThresholdCount:    aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 							HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
 							HealthCheckTimeoutSeconds:  aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
-							IpAddressType:              aws.String("ipv6"),
 						},
 					},
 				}, nil)
@@ -1690,11 +2028,8 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 				if err != nil {
 					t.Fatalf("did not expect error: %v", err)
 				}
-				tg := tgs[0]
-				got := *tg.IpAddressType
-				want := "ipv6"
-				if got != want {
-					t.Fatalf("did not set ip address type to ipv6")
+				if tgs[0].IpAddressType != nil {
+					t.Fatalf("expected the target group to use the default ipv4 address type, got %q", *tgs[0].IpAddressType)
 				}
 			},
 		},
@@ -2123,31 +2458,138 @@ func TestReconcileTargetGroupsAndListeners(t *testing.T) {
 				}
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			mockCtrl := gomock.NewController(t)
-			defer mockCtrl.Finish()
-			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
-
-			scheme, err := setupScheme()
-			if err != nil {
-				t.Fatal(err)
-			}
-			awsCluster := &infrav1.AWSCluster{
-				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
-				Spec: infrav1.AWSClusterSpec{
-					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
-						Name:             aws.String(elbName),
-						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
-					},
-					NetworkSpec: infrav1.NetworkSpec{
-						VPC: infrav1.VPCSpec{
-							ID: vpcID,
+		{
+			name: "updates the health check of an existing target group",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners[0].TargetGroup.Name = "apiserver-target-1"
+				spec.ELBListeners[0].TargetGroup.HealthCheck.IntervalSeconds = aws.Int64(5)
+				spec.ELBListeners[0].TargetGroup.HealthCheck.UnhealthyThresholdCount = aws.Int64(2)
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:             aws.String(tgArn),
+							TargetGroupName:            aws.String("apiserver-target-0"),
+							Port:                       aws.Int64(infrav1.DefaultAPIServerPort),
+							Protocol:                   aws.String("TCP"),
+							HealthCheckPort:            aws.String(infrav1.DefaultAPIServerPortString),
+							HealthyThresholdCount:      aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+							UnhealthyThresholdCount:    aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
+							HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+							HealthCheckTimeoutSeconds:  aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
 						},
 					},
-				},
+				}, nil)
+				m.ModifyTargetGroup(gomock.Eq(&elbv2.ModifyTargetGroupInput{
+					TargetGroupArn:             aws.String(tgArn),
+					HealthCheckIntervalSeconds: aws.Int64(5),
+					UnhealthyThresholdCount:    aws.Int64(2),
+				})).Return(&elbv2.ModifyTargetGroupOutput{}, nil)
+				m.DescribeListeners(gomock.Eq(&elbv2.DescribeListenersInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							ListenerArn: aws.String("listener::arn"),
+							DefaultActions: []*elbv2.Action{
+								{
+									TargetGroupArn: aws.String(tgArn),
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if len(tgs) != 0 || len(listeners) != 0 {
+					t.Fatalf("did not expect any target group or listener to be created")
+				}
+			},
+		},
+		{
+			name: "leaves an existing target group with an up to date health check untouched",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				spec.ELBListeners[0].TargetGroup.Name = "apiserver-target-1"
+				spec.ELBListeners[0].TargetGroup.HealthCheck.IntervalSeconds = aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec)
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeTargetGroupsOutput{
+					TargetGroups: []*elbv2.TargetGroup{
+						{
+							TargetGroupArn:             aws.String(tgArn),
+							TargetGroupName:            aws.String("apiserver-target-0"),
+							Port:                       aws.Int64(infrav1.DefaultAPIServerPort),
+							Protocol:                   aws.String("TCP"),
+							HealthCheckPort:            aws.String(infrav1.DefaultAPIServerPortString),
+							HealthCheckIntervalSeconds: aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+						},
+					},
+				}, nil)
+				m.ModifyTargetGroup(gomock.Any()).Times(0)
+				m.DescribeListeners(gomock.Eq(&elbv2.DescribeListenersInput{
+					LoadBalancerArn: aws.String(elbArn),
+				})).Return(&elbv2.DescribeListenersOutput{
+					Listeners: []*elbv2.Listener{
+						{
+							ListenerArn: aws.String("listener::arn"),
+							DefaultActions: []*elbv2.Action{
+								{
+									TargetGroupArn: aws.String(tgArn),
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+			check: func(t *testing.T, tgs []*elbv2.TargetGroup, listeners []*elbv2.Listener, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			if err != nil {
+				t.Fatal(err)
+			}
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						Name:             aws.String(elbName),
+						LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+					},
+					NetworkSpec: infrav1.NetworkSpec{
+						VPC: infrav1.VPCSpec{
+							ID: vpcID,
+						},
+					},
+				},
 			}
 			client := fake.NewClientBuilder().WithScheme(scheme).Build()
 			cluster := tc.awsCluster(*awsCluster)
@@ -2216,6 +2658,7 @@ func TestReconcileV2LB(t *testing.T) {
 		tgArn           = "arn::target-group"
 		vpcID           = "vpc-id"
 		az              = "us-west-1a"
+		existingLBARN   = "arn:aws:elasticloadbalancing:us-west-1:123456789012:loadbalancer/net/my-nlb/0123456789abcdef"
 	)
 
 	tests := []struct {
@@ -2335,7 +2778,7 @@ func TestReconcileV2LB(t *testing.T) {
 								TargetGroupName:    aws.String("targetGroup"),
 							},
 						},
-					}, nil)
+					}, nil).Times(2)
 				m.ModifyLoadBalancerAttributes(&elbv2.ModifyLoadBalancerAttributesInput{
 					LoadBalancerArn: aws.String(elbArn),
 					Attributes: []*elbv2.LoadBalancerAttribute{
@@ -2397,6 +2840,10 @@ func TestReconcileV2LB(t *testing.T) {
 					},
 				})).Return(nil, nil)
 
+				m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String(tgArn),
+				})).Return(&elbv2.DescribeTargetHealthOutput{}, nil)
+
 				m.DescribeListeners(gomock.Eq(&elbv2.DescribeListenersInput{
 					LoadBalancerArn: aws.String(elbArn),
 				})).
@@ -2498,6 +2945,117 @@ func TestReconcileV2LB(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "existing NLB is not created when it is not found",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.Name = nil
+				acl.Spec.ControlPlaneLoadBalancer.ARN = aws.String(existingLBARN)
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+					Names: aws.StringSlice([]string{"my-nlb"}),
+				})).
+					Return(nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil))
+			},
+			check: func(t *testing.T, lb *infrav1.LoadBalancer, err error) {
+				t.Helper()
+				if err == nil {
+					t.Fatal("expected an error for a missing existing load balancer")
+				}
+			},
+		},
+		{
+			name: "existing NLB is not modified even when tagged as owned",
+			spec: func(spec infrav1.LoadBalancer) infrav1.LoadBalancer {
+				return spec
+			},
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer.Name = nil
+				acl.Spec.ControlPlaneLoadBalancer.ARN = aws.String(existingLBARN)
+				acl.Spec.ControlPlaneLoadBalancer.CrossZoneLoadBalancing = true
+				return acl
+			},
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeLoadBalancers(gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+					Names: aws.StringSlice([]string{"my-nlb"}),
+				})).
+					Return(&elbv2.DescribeLoadBalancersOutput{
+						LoadBalancers: []*elbv2.LoadBalancer{
+							{
+								LoadBalancerArn:  aws.String(existingLBARN),
+								LoadBalancerName: aws.String("my-nlb"),
+								Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+								DNSName:          aws.String("my-nlb.elb.amazonaws.com"),
+								AvailabilityZones: []*elbv2.AvailabilityZone{
+									{
+										SubnetId: aws.String(clusterSubnetID),
+										ZoneName: aws.String(az),
+									},
+								},
+								VpcId: aws.String(vpcID),
+							},
+						},
+					}, nil)
+				m.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(existingLBARN)}).Return(
+					&elbv2.DescribeLoadBalancerAttributesOutput{
+						Attributes: []*elbv2.LoadBalancerAttribute{
+							{
+								Key:   aws.String("load_balancing.cross_zone.enabled"),
+								Value: aws.String("false"),
+							},
+						},
+					},
+					nil,
+				)
+				m.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(existingLBARN)}}).Return(
+					&elbv2.DescribeTagsOutput{
+						TagDescriptions: []*elbv2.TagDescription{
+							{
+								ResourceArn: aws.String(existingLBARN),
+								Tags: []*elbv2.Tag{
+									{
+										Key:   aws.String(infrav1.ClusterTagKey(clusterName)),
+										Value: aws.String(string(infrav1.ResourceLifecycleOwned)),
+									},
+								},
+							},
+						},
+					},
+					nil,
+				)
+				m.WaitUntilLoadBalancerAvailableWithContext(gomock.Any(), gomock.Eq(&elbv2.DescribeLoadBalancersInput{
+					LoadBalancerArns: aws.StringSlice([]string{existingLBARN}),
+				})).Return(nil)
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{
+					LoadBalancerArn: aws.String(existingLBARN),
+				})).
+					Return(&elbv2.DescribeTargetGroupsOutput{
+						TargetGroups: []*elbv2.TargetGroup{
+							{
+								TargetGroupArn:  aws.String(tgArn),
+								TargetGroupName: aws.String("targetGroup"),
+							},
+						},
+					}, nil)
+				m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String(tgArn),
+				})).
+					Return(&elbv2.DescribeTargetHealthOutput{}, nil)
+			},
+			check: func(t *testing.T, lb *infrav1.LoadBalancer, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("did not expect error: %v", err)
+				}
+				if lb.DNSName != "my-nlb.elb.amazonaws.com" {
+					t.Errorf("Expected the status to contain the DNS name of the existing load balancer, got %q", lb.DNSName)
+				}
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2746,6 +3304,312 @@ func TestReconcileLoadbalancers(t *testing.T) {
 	}
 }
 
+func TestPruneStaleControlPlaneInstances(t *testing.T) {
+	const (
+		lbArn = "arn::load-balancer"
+		tgArn = "arn::target-group"
+	)
+
+	describeTargetGroups := func(m *mocks.MockELBV2APIMockRecorder) {
+		m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lbArn)})).
+			Return(&elbv2.DescribeTargetGroupsOutput{
+				TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(tgArn)}},
+			}, nil)
+	}
+
+	staleInstancesQuery := func(ids ...string) *ec2.DescribeInstancesInput {
+		return &ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("instance-id"), Values: aws.StringSlice(ids)},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/bar"), Values: aws.StringSlice([]string{"owned"})},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/role"), Values: aws.StringSlice([]string{"control-plane"})},
+				{Name: aws.String("instance-state-name"), Values: aws.StringSlice([]string{"shutting-down", "terminated", "stopping", "stopped"})},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		classic       bool
+		ec2Mocks      func(m *mocks.MockEC2APIMockRecorder)
+		elbAPIMocks   func(m *mocks.MockELBAPIMockRecorder)
+		elbV2APIMocks func(m *mocks.MockELBV2APIMockRecorder)
+	}{
+		{
+			name: "deregisters terminated control plane targets",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describeTargetGroups(m)
+				m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})).
+					Return(&elbv2.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+							{
+								Target:       &elbv2.TargetDescription{Id: aws.String("i-healthy"), Port: aws.Int64(6443)},
+								TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2.TargetDescription{Id: aws.String("i-failing"), Port: aws.Int64(6443)},
+								TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumUnhealthy), Reason: aws.String(elbv2.TargetHealthReasonEnumTargetFailedHealthChecks)},
+							},
+							{
+								Target:       &elbv2.TargetDescription{Id: aws.String("i-terminated"), Port: aws.Int64(6443)},
+								TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumUnused), Reason: aws.String(elbv2.TargetHealthReasonEnumTargetInvalidState)},
+							},
+							{
+								Target:       &elbv2.TargetDescription{Id: aws.String("i-unknown"), Port: aws.Int64(6443)},
+								TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumUnused), Reason: aws.String(elbv2.TargetHealthReasonEnumTargetInvalidState)},
+							},
+						},
+					}, nil)
+				m.DeregisterTargets(gomock.Eq(&elbv2.DeregisterTargetsInput{
+					TargetGroupArn: aws.String(tgArn),
+					Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-terminated"), Port: aws.Int64(6443)}},
+				})).Return(&elbv2.DeregisterTargetsOutput{}, nil)
+			},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Eq(staleInstancesQuery("i-terminated", "i-unknown"))).
+					Return(&ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{InstanceId: aws.String("i-terminated")}}}},
+					}, nil)
+			},
+		},
+		{
+			name: "does not look up instances when no target is in an invalid state",
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describeTargetGroups(m)
+				m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})).
+					Return(&elbv2.DescribeTargetHealthOutput{
+						TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+							{
+								Target:       &elbv2.TargetDescription{Id: aws.String("i-healthy"), Port: aws.Int64(6443)},
+								TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+							},
+							{
+								Target:       &elbv2.TargetDescription{Id: aws.String("i-failing"), Port: aws.Int64(6443)},
+								TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumUnhealthy), Reason: aws.String(elbv2.TargetHealthReasonEnumTargetFailedHealthChecks)},
+							},
+						},
+					}, nil)
+			},
+		},
+		{
+			name:    "deregisters terminated control plane instances from a classic ELB",
+			classic: true,
+			elbAPIMocks: func(m *mocks.MockELBAPIMockRecorder) {
+				m.DescribeInstanceHealth(gomock.Eq(&elb.DescribeInstanceHealthInput{LoadBalancerName: aws.String("bar-apiserver")})).
+					Return(&elb.DescribeInstanceHealthOutput{
+						InstanceStates: []*elb.InstanceState{
+							{InstanceId: aws.String("i-healthy"), State: aws.String("InService")},
+							{InstanceId: aws.String("i-registering"), State: aws.String("OutOfService"), ReasonCode: aws.String("ELB")},
+							{InstanceId: aws.String("i-terminated"), State: aws.String("OutOfService"), ReasonCode: aws.String("Instance")},
+						},
+					}, nil)
+				m.DeregisterInstancesFromLoadBalancer(gomock.Eq(&elb.DeregisterInstancesFromLoadBalancerInput{
+					Instances:        []*elb.Instance{{InstanceId: aws.String("i-terminated")}},
+					LoadBalancerName: aws.String("bar-apiserver"),
+				})).Return(&elb.DeregisterInstancesFromLoadBalancerOutput{}, nil)
+			},
+			ec2Mocks: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeInstancesWithContext(context.TODO(), gomock.Eq(staleInstancesQuery("i-terminated"))).
+					Return(&ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{InstanceId: aws.String("i-terminated")}}}},
+					}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			elbAPIMocks := mocks.NewMockELBAPI(mockCtrl)
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.ec2Mocks != nil {
+				tc.ec2Mocks(ec2Mock.EXPECT())
+			}
+			if tc.elbAPIMocks != nil {
+				tc.elbAPIMocks(elbAPIMocks.EXPECT())
+			}
+			if tc.elbV2APIMocks != nil {
+				tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+			}
+
+			s := &Service{
+				scope:       clusterScope,
+				EC2Client:   ec2Mock,
+				ELBClient:   elbAPIMocks,
+				ELBV2Client: elbV2APIMocks,
+			}
+
+			if tc.classic {
+				err = s.pruneStaleClassicELBInstances("bar-apiserver")
+			} else {
+				err = s.pruneStaleTargets(lbArn)
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestReconcileLBReplacement(t *testing.T) {
+	const (
+		oldLBArn = "arn::old-load-balancer"
+		newLBArn = "arn::new-load-balancer"
+		oldTGArn = "arn::old-target-group"
+		newTGArn = "arn::new-target-group"
+	)
+
+	oldLB := infrav1.LoadBalancer{Name: "bar-apiserver", ARN: oldLBArn, Scheme: infrav1.ELBSchemeInternal, LoadBalancerType: infrav1.LoadBalancerTypeNLB}
+	newLB := &infrav1.LoadBalancer{Name: "bar-apiserver-public", ARN: newLBArn, Scheme: infrav1.ELBSchemeInternetFacing, LoadBalancerType: infrav1.LoadBalancerTypeNLB}
+	target := &elbv2.TargetDescription{Id: aws.String("i-cp"), Port: aws.Int64(6443)}
+
+	describeTargetGroups := func(m *mocks.MockELBV2APIMockRecorder, lbArn, tgArn string) {
+		m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(lbArn)})).
+			Return(&elbv2.DescribeTargetGroupsOutput{
+				TargetGroups: []*elbv2.TargetGroup{{TargetGroupArn: aws.String(tgArn), Port: aws.Int64(6443)}},
+			}, nil)
+	}
+	describeTargetHealth := func(m *mocks.MockELBV2APIMockRecorder, tgArn, state string) {
+		m.DescribeTargetHealth(gomock.Eq(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})).
+			Return(&elbv2.DescribeTargetHealthOutput{
+				TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+					{Target: target, TargetHealth: &elbv2.TargetHealth{State: aws.String(state)}},
+				},
+			}, nil)
+	}
+
+	tests := []struct {
+		name             string
+		annotated        bool
+		current          infrav1.LoadBalancer
+		replaced         *infrav1.LoadBalancer
+		elbV2APIMocks    func(m *mocks.MockELBV2APIMockRecorder)
+		expectErr        bool
+		expectedReplaced *infrav1.LoadBalancer
+	}{
+		{
+			name:      "switches to the replacement once it has healthy targets",
+			annotated: true,
+			current:   oldLB,
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describeTargetGroups(m, oldLBArn, oldTGArn)
+				describeTargetGroups(m, newLBArn, newTGArn)
+				describeTargetHealth(m, oldTGArn, elbv2.TargetHealthStateEnumHealthy)
+				m.RegisterTargets(gomock.Eq(&elbv2.RegisterTargetsInput{
+					TargetGroupArn: aws.String(newTGArn),
+					Targets:        []*elbv2.TargetDescription{target},
+				})).Return(&elbv2.RegisterTargetsOutput{}, nil)
+				describeTargetGroups(m, newLBArn, newTGArn)
+				describeTargetHealth(m, newTGArn, elbv2.TargetHealthStateEnumHealthy)
+			},
+			expectedReplaced: &oldLB,
+		},
+		{
+			name:      "keeps the current load balancer while the replacement has no healthy targets",
+			annotated: true,
+			current:   oldLB,
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				describeTargetGroups(m, oldLBArn, oldTGArn)
+				describeTargetGroups(m, newLBArn, newTGArn)
+				describeTargetHealth(m, oldTGArn, elbv2.TargetHealthStateEnumHealthy)
+				m.RegisterTargets(gomock.Any()).Return(&elbv2.RegisterTargetsOutput{}, nil)
+				describeTargetGroups(m, newLBArn, newTGArn)
+				describeTargetHealth(m, newTGArn, elbv2.TargetHealthStateEnumInitial)
+			},
+			expectErr: true,
+		},
+		{
+			name:             "keeps the replaced load balancer while the annotation is set",
+			annotated:        true,
+			current:          *newLB,
+			replaced:         &oldLB,
+			expectedReplaced: &oldLB,
+		},
+		{
+			name:     "deletes the replaced load balancer once the annotation is removed",
+			current:  *newLB,
+			replaced: &oldLB,
+			elbV2APIMocks: func(m *mocks.MockELBV2APIMockRecorder) {
+				m.DescribeTargetGroups(gomock.Eq(&elbv2.DescribeTargetGroupsInput{LoadBalancerArn: aws.String(oldLBArn)})).
+					Return(nil, awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found", nil))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Status: infrav1.AWSClusterStatus{
+					Network: infrav1.NetworkStatus{
+						APIServerELB:         tc.current,
+						ReplacedAPIServerELB: tc.replaced.DeepCopy(),
+					},
+				},
+			}
+			if tc.annotated {
+				awsCluster.Annotations = map[string]string{infrav1.AllowControlPlaneLoadBalancerSchemeChangeAnnotation: "true"}
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: awsCluster,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.elbV2APIMocks != nil {
+				tc.elbV2APIMocks(elbV2APIMocks.EXPECT())
+			}
+
+			s := &Service{
+				scope:       clusterScope,
+				ELBV2Client: elbV2APIMocks,
+			}
+
+			err = s.reconcileLBReplacement(newLB)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(clusterScope.Network().ReplacedAPIServerELB).To(Equal(tc.expectedReplaced))
+		})
+	}
+}
+
 func TestDeleteAPIServerELB(t *testing.T) {
 	clusterName := "bar"
 	elbName := "bar-apiserver"
@@ -2940,6 +3804,70 @@ func TestDeleteAPIServerELB(t *testing.T) {
 	}
 }
 
+func TestDeleteExistingLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name   string
+		arn    string
+		lbType infrav1.LoadBalancerType
+	}{
+		{
+			name:   "existing classic load balancer",
+			arn:    "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/my-elb",
+			lbType: infrav1.LoadBalancerTypeClassic,
+		},
+		{
+			name:   "existing network load balancer",
+			arn:    "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/my-nlb/0123456789abcdef",
+			lbType: infrav1.LoadBalancerTypeNLB,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			// No calls are expected: the load balancer is neither described nor deleted.
+			elbapiMock := mocks.NewMockELBAPI(mockCtrl)
+			elbV2APIMocks := mocks.NewMockELBV2API(mockCtrl)
+
+			scheme, err := setupScheme()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+						ARN:              aws.String(tc.arn),
+						LoadBalancerType: tc.lbType,
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				AWSCluster: awsCluster,
+				Client:     client,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := &Service{
+				scope:       clusterScope,
+				ELBClient:   elbapiMock,
+				ELBV2Client: elbV2APIMocks,
+			}
+
+			g.Expect(s.deleteAPIServerELB()).To(Succeed())
+			g.Expect(s.deleteExistingNLBs()).To(Succeed())
+		})
+	}
+}
+
 func TestDeleteNLB(t *testing.T) {
 	clusterName := "bar"
 	elbName := "bar-apiserver"
@@ -3444,6 +4372,79 @@ func TestDescribeV2Loadbalancers(t *testing.T) {
 	}
 }
 
+func TestConfigureClassicELBAttributes(t *testing.T) {
+	const elbName = "bar-apiserver"
+	tests := []struct {
+		name       string
+		attributes infrav1.ClassicELBAttributes
+		expect     func(m *mocks.MockELBAPIMockRecorder)
+	}{
+		{
+			name: "sets the configured idle timeout",
+			attributes: infrav1.ClassicELBAttributes{
+				IdleTimeout: time.Hour,
+			},
+			expect: func(m *mocks.MockELBAPIMockRecorder) {
+				m.ModifyLoadBalancerAttributes(gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
+					LoadBalancerName: aws.String(elbName),
+					LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+						CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(false)},
+						ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(3600)},
+						AccessLog:              &elb.AccessLog{Enabled: aws.Bool(false)},
+					},
+				})).Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+			},
+		},
+		{
+			name: "leaves the idle timeout untouched when it is not set",
+			attributes: infrav1.ClassicELBAttributes{
+				CrossZoneLoadBalancing: true,
+			},
+			expect: func(m *mocks.MockELBAPIMockRecorder) {
+				m.ModifyLoadBalancerAttributes(gomock.Eq(&elb.ModifyLoadBalancerAttributesInput{
+					LoadBalancerName: aws.String(elbName),
+					LoadBalancerAttributes: &elb.LoadBalancerAttributes{
+						CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(true)},
+						AccessLog:              &elb.AccessLog{Enabled: aws.Bool(false)},
+					},
+				})).Return(&elb.ModifyLoadBalancerAttributesOutput{}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			elbAPIMocks := mocks.NewMockELBAPI(mockCtrl)
+			tc.expect(elbAPIMocks.EXPECT())
+
+			s := &Service{
+				ELBClient: elbAPIMocks,
+			}
+			g.Expect(s.configureAttributes(elbName, tc.attributes)).To(Succeed())
+		})
+	}
+}
+
+func TestFromSDKTypeToClassicELBIdleTimeout(t *testing.T) {
+	g := NewWithT(t)
+
+	lb := fromSDKTypeToClassicELB(&elb.LoadBalancerDescription{
+		LoadBalancerName: aws.String("bar-apiserver"),
+		Scheme:           aws.String(string(infrav1.ELBSchemeInternetFacing)),
+	}, &elb.LoadBalancerAttributes{
+		CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{Enabled: aws.Bool(false)},
+		ConnectionSettings:     &elb.ConnectionSettings{IdleTimeout: aws.Int64(60)},
+	}, nil)
+
+	// A spec requesting a different idle timeout must not match the observed attributes,
+	// so that the reconciliation updates the load balancer.
+	g.Expect(lb.ClassicElbAttributes.IdleTimeout).To(Equal(time.Minute))
+	g.Expect(lb.ClassicElbAttributes).NotTo(Equal(infrav1.ClassicELBAttributes{IdleTimeout: time.Hour}))
+}
+
 func TestChunkELBs(t *testing.T) {
 	base := "loadbalancer"
 	names := make([]string, 0, 25)
@@ -3504,6 +4505,16 @@ func TestGetHealthCheckProtocol(t *testing.T) {
 			},
 			"HTTPS:6443/readyz",
 		},
+		{
+			"protocol https with custom path",
+			&infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &testHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Path: aws.String("/healthz"),
+				},
+			},
+			"HTTPS:6443/healthz",
+		},
 		{
 			"protocol tcp",
 			&infrav1.AWSLoadBalancerSpec{
@@ -3511,6 +4522,16 @@ func TestGetHealthCheckProtocol(t *testing.T) {
 			},
 			"TCP:6443",
 		},
+		{
+			"protocol https with custom port",
+			&infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &testHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Port: aws.Int64(8443),
+				},
+			},
+			"HTTPS:8443/readyz",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.testName, func(t *testing.T) {
@@ -3663,6 +4684,45 @@ func TestService_getAPITargetGroupHealthCheck(t *testing.T) {
 				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
 			},
 		},
+		{
+			name: "custom path, API health check HTTPS",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{
+				HealthCheckProtocol: &infrav1.ELBProtocolHTTPS,
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Path: aws.String("/healthz"),
+				},
+			},
+			want: &infrav1.TargetGroupHealthCheck{
+				Protocol:                aws.String("HTTPS"),
+				Port:                    aws.String("6443"),
+				Path:                    aws.String("/healthz"),
+				IntervalSeconds:         aws.Int64(infrav1.DefaultAPIServerHealthCheckIntervalSec),
+				TimeoutSeconds:          aws.Int64(infrav1.DefaultAPIServerHealthCheckTimeoutSec),
+				ThresholdCount:          aws.Int64(infrav1.DefaultAPIServerHealthThresholdCount),
+				UnhealthyThresholdCount: aws.Int64(infrav1.DefaultAPIServerUnhealthThresholdCount),
+			},
+		},
+		{
+			name: "custom port and probe counters, API health check TCP",
+			lbSpec: &infrav1.AWSLoadBalancerSpec{
+				HealthCheck: &infrav1.TargetGroupHealthCheckAPISpec{
+					Port:                    aws.Int64(8443),
+					IntervalSeconds:         aws.Int64(5),
+					TimeoutSeconds:          aws.Int64(3),
+					ThresholdCount:          aws.Int64(2),
+					UnhealthyThresholdCount: aws.Int64(2),
+				},
+			},
+			want: &infrav1.TargetGroupHealthCheck{
+				Protocol:                aws.String("TCP"),
+				Port:                    aws.String("8443"),
+				Path:                    nil,
+				IntervalSeconds:         aws.Int64(5),
+				TimeoutSeconds:          aws.Int64(3),
+				ThresholdCount:          aws.Int64(2),
+				UnhealthyThresholdCount: aws.Int64(2),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,7 +18,9 @@ package iamauth
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
@@ -38,8 +41,17 @@ const (
 
 	roleKey  = "mapRoles"
 	usersKey = "mapUsers"
+
+	// managedMappingsAnnotation records the ARNs of the roles and users whose mappings are managed by CAPA.
+	managedMappingsAnnotation = "aws.cluster.x-k8s.io/managed-aws-auth-mappings"
 )
 
+// managedMappings holds the ARNs of the roles and users whose mappings are managed by CAPA.
+type managedMappings struct {
+	RoleARNs []string `json:"mapRoles,omitempty"`
+	UserARNs []string `json:"mapUsers,omitempty"`
+}
+
 type configMapBackend struct {
 	client crclient.Client
 }
@@ -63,7 +75,7 @@ func (b *configMapBackend) MapRole(mapping ekscontrolplanev1.RoleMapping) error
 
 	authConfig.RoleMappings = append(authConfig.RoleMappings, mapping)
 
-	return b.saveAuthConfig(authConfig)
+	return b.saveAuthConfig(authConfig, nil)
 }
 
 func (b *configMapBackend) MapUser(mapping ekscontrolplanev1.UserMapping) error {
@@ -85,7 +97,97 @@ func (b *configMapBackend) MapUser(mapping ekscontrolplanev1.UserMapping) error
 
 	authConfig.UserMappings = append(authConfig.UserMappings, mapping)
 
-	return b.saveAuthConfig(authConfig)
+	return b.saveAuthConfig(authConfig, nil)
+}
+
+func (b *configMapBackend) SetMappings(roleMappings []ekscontrolplanev1.RoleMapping, userMappings []ekscontrolplanev1.UserMapping) error {
+	var errs []error
+	for _, mapping := range roleMappings {
+		errs = append(errs, mapping.Validate()...)
+	}
+	for _, mapping := range userMappings {
+		errs = append(errs, mapping.Validate()...)
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs)
+	}
+
+	authConfig, err := b.getAuthConfig()
+	if err != nil {
+		return fmt.Errorf("getting auth config: %w", err)
+	}
+	previous, err := b.getManagedMappings()
+	if err != nil {
+		return fmt.Errorf("getting managed mappings: %w", err)
+	}
+
+	// Mappings of the ARNs managed before or now are replaced by the desired mappings.
+	managed := &managedMappings{}
+	replacedRoles := sets.New[string](previous.RoleARNs...)
+	for _, mapping := range roleMappings {
+		replacedRoles.Insert(mapping.RoleARN)
+		managed.RoleARNs = append(managed.RoleARNs, mapping.RoleARN)
+	}
+	replacedUsers := sets.New[string](previous.UserARNs...)
+	for _, mapping := range userMappings {
+		replacedUsers.Insert(mapping.UserARN)
+		managed.UserARNs = append(managed.UserARNs, mapping.UserARN)
+	}
+
+	// Mappings already present keep their position, so the config map is only updated on changes.
+	desired := &ekscontrolplanev1.IAMAuthenticatorConfig{
+		RoleMappings: []ekscontrolplanev1.RoleMapping{},
+		UserMappings: []ekscontrolplanev1.UserMapping{},
+	}
+	for _, mapping := range authConfig.RoleMappings {
+		if !replacedRoles.Has(mapping.RoleARN) || slices.ContainsFunc(roleMappings, func(m ekscontrolplanev1.RoleMapping) bool { return cmp.Equal(m, mapping) }) {
+			desired.RoleMappings = append(desired.RoleMappings, mapping)
+		}
+	}
+	for _, mapping := range roleMappings {
+		if !slices.ContainsFunc(desired.RoleMappings, func(m ekscontrolplanev1.RoleMapping) bool { return cmp.Equal(m, mapping) }) {
+			desired.RoleMappings = append(desired.RoleMappings, mapping)
+		}
+	}
+	for _, mapping := range authConfig.UserMappings {
+		if !replacedUsers.Has(mapping.UserARN) || slices.ContainsFunc(userMappings, func(m ekscontrolplanev1.UserMapping) bool { return cmp.Equal(m, mapping) }) {
+			desired.UserMappings = append(desired.UserMappings, mapping)
+		}
+	}
+	for _, mapping := range userMappings {
+		if !slices.ContainsFunc(desired.UserMappings, func(m ekscontrolplanev1.UserMapping) bool { return cmp.Equal(m, mapping) }) {
+			desired.UserMappings = append(desired.UserMappings, mapping)
+		}
+	}
+	slices.Sort(managed.RoleARNs)
+	managed.RoleARNs = slices.Compact(managed.RoleARNs)
+	slices.Sort(managed.UserARNs)
+	managed.UserARNs = slices.Compact(managed.UserARNs)
+
+	if cmp.Equal(authConfig, desired) && cmp.Equal(previous, managed) {
+		return nil
+	}
+
+	return b.saveAuthConfig(desired, managed)
+}
+
+func (b *configMapBackend) getManagedMappings() (*managedMappings, error) {
+	authConfigMap := &corev1.ConfigMap{}
+	err := b.client.Get(context.Background(), types.NamespacedName{Name: configMapName, Namespace: configMapNS}, authConfigMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting %s/%s config map: %w", configMapName, configMapNS, err)
+	}
+
+	managed := &managedMappings{}
+	value, ok := authConfigMap.Annotations[managedMappingsAnnotation]
+	if !ok {
+		return managed, nil
+	}
+	if err := json.Unmarshal([]byte(value), managed); err != nil {
+		return nil, fmt.Errorf("unmarshalling managed mappings: %w", err)
+	}
+
+	return managed, nil
 }
 
 func (b *configMapBackend) getAuthConfig() (*ekscontrolplanev1.IAMAuthenticatorConfig, error) {
@@ -126,7 +228,8 @@ func (b *configMapBackend) getAuthConfig() (*ekscontrolplanev1.IAMAuthenticatorC
 	return authConfig, nil
 }
 
-func (b *configMapBackend) saveAuthConfig(authConfig *ekscontrolplanev1.IAMAuthenticatorConfig) error {
+// saveAuthConfig writes the mappings to the aws-auth config map, recording the managed mappings if set.
+func (b *configMapBackend) saveAuthConfig(authConfig *ekscontrolplanev1.IAMAuthenticatorConfig, managed *managedMappings) error {
 	ctx := context.Background()
 
 	configMapRef := types.NamespacedName{
@@ -165,6 +268,17 @@ func (b *configMapBackend) saveAuthConfig(authConfig *ekscontrolplanev1.IAMAuthe
 		authConfigMap.Data[usersKey] = string(userMappings)
 	}
 
+	if managed != nil {
+		value, err := json.Marshal(managed)
+		if err != nil {
+			return fmt.Errorf("marshalling managed mappings: %w", err)
+		}
+		if authConfigMap.Annotations == nil {
+			authConfigMap.Annotations = map[string]string{}
+		}
+		authConfigMap.Annotations[managedMappingsAnnotation] = string(value)
+	}
+
 	if authConfigMap.UID == "" {
 		authConfigMap.Name = configMapName
 		authConfigMap.Namespace = configMapNS
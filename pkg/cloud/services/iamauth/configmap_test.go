@@ -315,6 +315,72 @@ func TestAddUserMappingCM(t *testing.T) {
 	}
 }
 
+func TestSetMappingsCM(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	client := fake.NewClientBuilder().WithObjects(createFakeConfigMap(existingNodeRoleMap, existingUserMap)).Build()
+	backend, err := NewBackend(BackendTypeConfigMap, client)
+	g.Expect(err).To(BeNil())
+
+	nodeRole := ekscontrolplanev1.RoleMapping{
+		RoleARN: "arn:aws:iam::000000000000:role/KubernetesNode",
+		KubernetesMapping: ekscontrolplanev1.KubernetesMapping{
+			UserName: "system:node:{{EC2PrivateDNSName}}",
+			Groups:   []string{"system:bootstrappers", "system:nodes"},
+		},
+	}
+	alice := ekscontrolplanev1.UserMapping{
+		UserARN: "arn:aws:iam::000000000000:user/Alice",
+		KubernetesMapping: ekscontrolplanev1.KubernetesMapping{
+			UserName: "alice",
+			Groups:   []string{"system:masters"},
+		},
+	}
+	admin := ekscontrolplanev1.RoleMapping{
+		RoleARN: "arn:aws:iam::000000000000:role/Admin",
+		KubernetesMapping: ekscontrolplanev1.KubernetesMapping{
+			UserName: "admin",
+			Groups:   []string{"system:masters"},
+		},
+	}
+	bob := ekscontrolplanev1.UserMapping{
+		UserARN: "arn:aws:iam::000000000000:user/Bob",
+		KubernetesMapping: ekscontrolplanev1.KubernetesMapping{
+			UserName: "bob",
+			Groups:   []string{"developers"},
+		},
+	}
+
+	getConfigMap := func() (*corev1.ConfigMap, []ekscontrolplanev1.RoleMapping, []ekscontrolplanev1.UserMapping) {
+		cm := &corev1.ConfigMap{}
+		g.Expect(client.Get(context.TODO(), types.NamespacedName{Name: "aws-auth", Namespace: "kube-system"}, cm)).To(Succeed())
+		roles := []ekscontrolplanev1.RoleMapping{}
+		g.Expect(yaml.Unmarshal([]byte(cm.Data["mapRoles"]), &roles)).To(Succeed())
+		users := []ekscontrolplanev1.UserMapping{}
+		g.Expect(yaml.Unmarshal([]byte(cm.Data["mapUsers"]), &users)).To(Succeed())
+		return cm, roles, users
+	}
+
+	// Mappings not managed by CAPA are kept.
+	g.Expect(backend.SetMappings([]ekscontrolplanev1.RoleMapping{admin}, []ekscontrolplanev1.UserMapping{bob})).To(Succeed())
+	cm, roles, users := getConfigMap()
+	g.Expect(roles).To(Equal([]ekscontrolplanev1.RoleMapping{nodeRole, admin}))
+	g.Expect(users).To(Equal([]ekscontrolplanev1.UserMapping{alice, bob}))
+	g.Expect(cm.Annotations).To(HaveKeyWithValue(managedMappingsAnnotation, `{"mapRoles":["arn:aws:iam::000000000000:role/Admin"],"mapUsers":["arn:aws:iam::000000000000:user/Bob"]}`))
+
+	// Changed mappings are updated and removed mappings are deleted.
+	admin.Groups = []string{"admins"}
+	g.Expect(backend.SetMappings([]ekscontrolplanev1.RoleMapping{admin}, nil)).To(Succeed())
+	cm, roles, users = getConfigMap()
+	g.Expect(roles).To(Equal([]ekscontrolplanev1.RoleMapping{nodeRole, admin}))
+	g.Expect(users).To(Equal([]ekscontrolplanev1.UserMapping{alice}))
+
+	// The config map isn't updated without changes.
+	g.Expect(backend.SetMappings([]ekscontrolplanev1.RoleMapping{admin}, nil)).To(Succeed())
+	unchanged, _, _ := getConfigMap()
+	g.Expect(unchanged.ResourceVersion).To(Equal(cm.ResourceVersion))
+}
+
 func createFakeConfigMap(roleMappings string, userMappings string) *corev1.ConfigMap {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
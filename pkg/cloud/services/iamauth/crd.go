@@ -19,7 +19,10 @@ package iamauth
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	iamauthv1 "sigs.k8s.io/aws-iam-authenticator/pkg/mapper/crd/apis/iamauthenticator/v1alpha1"
@@ -28,6 +31,9 @@ import (
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 )
 
+// crdMappingPrefix is the name prefix of the IAMIdentityMappings created by CAPA.
+const crdMappingPrefix = "capa-iamauth-"
+
 type crdBackend struct {
 	client crclient.Client
 }
@@ -56,7 +62,7 @@ func (b *crdBackend) MapRole(mapping ekscontrolplanev1.RoleMapping) error {
 	iamMapping := &iamauthv1.IAMIdentityMapping{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:    metav1.NamespaceSystem,
-			GenerateName: "capa-iamauth-",
+			GenerateName: crdMappingPrefix,
 		},
 		Spec: iamauthv1.IAMIdentityMappingSpec{
 			ARN:      mapping.RoleARN,
@@ -92,7 +98,7 @@ func (b *crdBackend) MapUser(mapping ekscontrolplanev1.UserMapping) error {
 	iamMapping := &iamauthv1.IAMIdentityMapping{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:    metav1.NamespaceSystem,
-			GenerateName: "capa-iamauth-",
+			GenerateName: crdMappingPrefix,
 		},
 		Spec: iamauthv1.IAMIdentityMappingSpec{
 			ARN:      mapping.UserARN,
@@ -104,6 +110,53 @@ func (b *crdBackend) MapUser(mapping ekscontrolplanev1.UserMapping) error {
 	return b.client.Create(ctx, iamMapping)
 }
 
+func (b *crdBackend) SetMappings(roleMappings []ekscontrolplanev1.RoleMapping, userMappings []ekscontrolplanev1.UserMapping) error {
+	ctx := context.TODO()
+
+	var errs []error
+	for _, mapping := range roleMappings {
+		errs = append(errs, mapping.Validate()...)
+	}
+	for _, mapping := range userMappings {
+		errs = append(errs, mapping.Validate()...)
+	}
+	if len(errs) > 0 {
+		return kerrors.NewAggregate(errs)
+	}
+
+	mappingList := iamauthv1.IAMIdentityMappingList{}
+	if err := b.client.List(ctx, &mappingList); err != nil {
+		return fmt.Errorf("getting list of mappings: %w", err)
+	}
+
+	for i := range mappingList.Items {
+		existing := &mappingList.Items[i]
+		if !strings.HasPrefix(existing.Name, crdMappingPrefix) {
+			continue
+		}
+		desired := slices.ContainsFunc(roleMappings, func(m ekscontrolplanev1.RoleMapping) bool { return roleMappingMatchesIAMMap(m, existing) }) ||
+			slices.ContainsFunc(userMappings, func(m ekscontrolplanev1.UserMapping) bool { return userMappingMatchesIAMMap(m, existing) })
+		if !desired {
+			if err := b.client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("deleting mapping %s: %w", existing.Name, err)
+			}
+		}
+	}
+
+	for _, mapping := range roleMappings {
+		if err := b.MapRole(mapping); err != nil {
+			return err
+		}
+	}
+	for _, mapping := range userMappings {
+		if err := b.MapUser(mapping); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func roleMappingMatchesIAMMap(mapping ekscontrolplanev1.RoleMapping, iamMapping *iamauthv1.IAMIdentityMapping) bool {
 	if mapping.RoleARN != iamMapping.Spec.ARN {
 		return false
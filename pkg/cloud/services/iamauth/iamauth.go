@@ -38,6 +38,9 @@ type AuthenticatorBackend interface {
 	MapRole(mapping ekscontrolplanev1.RoleMapping) error
 	// MapUser is used to map a user ARN to a user and set of groups
 	MapUser(mapping ekscontrolplanev1.UserMapping) error
+	// SetMappings is used to set the role and user mappings managed by CAPA. Mappings managed before
+	// that are not passed anymore are removed, mappings not managed by CAPA are left untouched.
+	SetMappings(roleMappings []ekscontrolplanev1.RoleMapping, userMappings []ekscontrolplanev1.UserMapping) error
 }
 
 // BackendType is a type that represents the different aws-iam-authenticator backends.
@@ -53,6 +53,7 @@ func (s *Service) ReconcileIAMAuthenticator(ctx context.Context) error {
 		s.scope.Error(err, "getting roles for remote workers")
 		return fmt.Errorf("getting roles for remote workers: %w", err)
 	}
+	roleMappings := []ekscontrolplanev1.RoleMapping{}
 	for roleName := range nodeRoles {
 		roleARN, err := s.getARNForRole(roleName)
 		if err != nil {
@@ -66,25 +67,19 @@ func (s *Service) ReconcileIAMAuthenticator(ctx context.Context) error {
 			},
 		}
 		s.scope.Debug("Mapping node IAM role", "iam-role", nodesRoleMapping.RoleARN, "user", nodesRoleMapping.UserName)
-		if err := authBackend.MapRole(nodesRoleMapping); err != nil {
-			return fmt.Errorf("mapping iam node role: %w", err)
-		}
+		roleMappings = append(roleMappings, nodesRoleMapping)
 	}
 
 	s.scope.Debug("Mapping additional IAM roles and users")
 	iamCfg := s.scope.IAMAuthConfig()
 	for _, roleMapping := range iamCfg.RoleMappings {
 		s.scope.Debug("Mapping IAM role", "iam-role", roleMapping.RoleARN, "user", roleMapping.UserName)
-		if err := authBackend.MapRole(roleMapping); err != nil {
-			return fmt.Errorf("mapping iam role: %w", err)
-		}
+		roleMappings = append(roleMappings, roleMapping)
 	}
 
-	for _, userMapping := range iamCfg.UserMappings {
-		s.scope.Debug("Mapping IAM user", "iam-user", userMapping.UserARN, "user", userMapping.UserName)
-		if err := authBackend.MapUser(userMapping); err != nil {
-			return fmt.Errorf("mapping iam user: %w", err)
-		}
+	// Mappings removed from the spec, or of node roles no longer used, are removed as well.
+	if err := authBackend.SetMappings(roleMappings, iamCfg.UserMappings); err != nil {
+		return fmt.Errorf("mapping iam roles and users: %w", err)
 	}
 
 	s.scope.Info("Reconciled aws-iam-authenticator configuration", "cluster", klog.KRef("", s.scope.Name()))
@@ -111,9 +106,39 @@ func (s *Service) getRolesForWorkers(ctx context.Context) (map[string]struct{},
 	if err := s.getRolesForMachinePools(ctx, allRoles); err != nil {
 		return nil, fmt.Errorf("failed to get roles from machine pools %w", err)
 	}
+	if err := s.getRolesForMachines(ctx, allRoles); err != nil {
+		return nil, fmt.Errorf("failed to get roles from machines %w", err)
+	}
 	return allRoles, nil
 }
 
+// getRolesForMachines adds the roles of the existing worker machines, so that the role of a machine deployment
+// stays mapped while it rolls out to another one, until no machine uses it anymore.
+func (s *Service) getRolesForMachines(ctx context.Context, allRoles map[string]struct{}) error {
+	awsMachineList := &infrav1.AWSMachineList{}
+	selectors := []client.ListOption{
+		client.InNamespace(s.scope.Namespace()),
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel: s.scope.Name(),
+		},
+	}
+	err := s.client.List(ctx, awsMachineList, selectors...)
+	if err != nil {
+		return fmt.Errorf("failed to list aws machines for cluster %s/%s: %w", s.scope.Namespace(), s.scope.Name(), err)
+	}
+
+	for _, awsMachine := range awsMachineList.Items {
+		if _, ok := awsMachine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+			continue
+		}
+		instanceProfile := awsMachine.Spec.IAMInstanceProfile
+		if _, ok := allRoles[instanceProfile]; !ok && instanceProfile != "" {
+			allRoles[instanceProfile] = struct{}{}
+		}
+	}
+	return nil
+}
+
 func (s *Service) getRolesForMachineDeployments(ctx context.Context, allRoles map[string]struct{}) error {
 	deploymentList := &clusterv1.MachineDeploymentList{}
 	selectors := []client.ListOption{
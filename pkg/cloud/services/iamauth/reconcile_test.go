@@ -81,9 +81,14 @@ func TestReconcileIAMAuth(t *testing.T) {
 		md := createMachineDeploymentForCluster(name, ns, eksCluster.Name, infraRefForMD)
 		g.Expect(testEnv.Create(ctx, md)).To(Succeed())
 
+		// A machine of the machine deployment which wasn't rolled out to the new template yet.
+		awsMachine := createAWSMachineForClusterWithInstanceProfile(name, ns, eksCluster.Name, "old-nodes.cluster-api-provider-aws.sigs.k8s.io")
+		g.Expect(testEnv.Create(ctx, awsMachine)).To(Succeed())
+
 		expectedRoles := map[string]struct{}{
 			"nodes.cluster-api-provider-aws.sigs.k8s.io":     {},
 			"eks-nodes.cluster-api-provider-aws.sigs.k8s.io": {},
+			"old-nodes.cluster-api-provider-aws.sigs.k8s.io": {},
 		}
 
 		controllerIdentity := createControllerIdentity()
@@ -105,7 +110,7 @@ func TestReconcileIAMAuth(t *testing.T) {
 		g.Expect(gotRoles).To(BeEquivalentTo(expectedRoles), "did not get correct roles for workers")
 		defer teardown()
 		defer t.Cleanup(func() {
-			g.Expect(testEnv.Cleanup(ctx, namespace, eksCluster, awsMP, mp, awsMachineTemplate, md, controllerIdentity)).To(Succeed())
+			g.Expect(testEnv.Cleanup(ctx, namespace, eksCluster, awsMP, mp, awsMachineTemplate, md, awsMachine, controllerIdentity)).To(Succeed())
 		})
 	})
 }
@@ -192,6 +197,23 @@ func createAWSMachineTemplateForClusterWithInstanceProfile(name, namespace, clus
 	return mt
 }
 
+func createAWSMachineForClusterWithInstanceProfile(name, namespace, clusterName, instanceProfile string) *infrav1.AWSMachine {
+	awsMachine := &infrav1.AWSMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: clusterName,
+			},
+		},
+		Spec: infrav1.AWSMachineSpec{
+			IAMInstanceProfile: instanceProfile,
+			InstanceType:       "m5.xlarge",
+		},
+	}
+	return awsMachine
+}
+
 func createMachineDeploymentForCluster(name, namespace, clusterName string, infrastructureRef corev1.ObjectReference) *clusterv1.MachineDeployment {
 	md := &clusterv1.MachineDeployment{
 		ObjectMeta: metav1.ObjectMeta{
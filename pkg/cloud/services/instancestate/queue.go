@@ -66,6 +66,11 @@ func (s *Service) deleteSQSQueue() error {
 
 func (s *Service) createPolicyForRule(input *createPolicyForRuleInput) error {
 	attrs := make(map[string]string)
+	// a single rule is referenced as a plain string to keep the policy unchanged for existing queues
+	var sourceArn interface{} = input.RuleArns
+	if len(input.RuleArns) == 1 {
+		sourceArn = input.RuleArns[0]
+	}
 	policy := iamv1.PolicyDocument{
 		Version: iamv1.CurrentVersion,
 		ID:      input.QueueArn,
@@ -77,7 +82,7 @@ func (s *Service) createPolicyForRule(input *createPolicyForRuleInput) error {
 				Action:    iamv1.Actions{"sqs:SendMessage"},
 				Resource:  iamv1.Resources{input.QueueArn},
 				Condition: iamv1.Conditions{
-					"ArnEquals": map[string]string{"aws:SourceArn": input.RuleArn},
+					"ArnEquals": map[string]interface{}{"aws:SourceArn": sourceArn},
 				},
 			},
 		},
@@ -114,5 +119,5 @@ func queueNotFoundError(err error) bool {
 type createPolicyForRuleInput struct {
 	QueueArn string
 	QueueURL string
-	RuleArn  string
+	RuleArns []string
 }
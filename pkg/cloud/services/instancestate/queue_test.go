@@ -19,6 +19,7 @@ package instancestate
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -202,7 +203,7 @@ func TestCreatePolicyForRule(t *testing.T) {
 			input: &createPolicyForRuleInput{
 				QueueArn: "test-cluster-queue-arn",
 				QueueURL: "test-cluster-queue-url",
-				RuleArn:  "test-cluster-rule-arn",
+				RuleArns: []string{"test-cluster-rule-arn"},
 			},
 			expect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
 				buffer := new(bytes.Buffer)
@@ -216,6 +217,27 @@ func TestCreatePolicyForRule(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "creates a policy for multiple rules",
+			input: &createPolicyForRuleInput{
+				QueueArn: "test-cluster-queue-arn",
+				QueueURL: "test-cluster-queue-url",
+				RuleArns: []string{"test-cluster-rule-arn", "test-cluster-spot-rule-arn"},
+			},
+			expect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				buffer := new(bytes.Buffer)
+				_ = json.Compact(buffer, []byte(strings.Replace(expectedPolicyJSON,
+					`"aws:SourceArn": "test-cluster-rule-arn"`,
+					`"aws:SourceArn": ["test-cluster-rule-arn", "test-cluster-spot-rule-arn"]`, 1)))
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNamePolicy] = buffer.String()
+				m.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+					QueueUrl:   aws.String("test-cluster-queue-url"),
+					Attributes: aws.StringMap(attrs),
+				}).Return(nil, nil)
+			},
+			expectErr: false,
+		},
 	}
 
 	for _, tc := range testCases {
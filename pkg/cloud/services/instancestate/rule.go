@@ -19,6 +19,7 @@ package instancestate
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -32,6 +33,16 @@ import (
 // Ec2StateChangeNotification defines the EC2 instance's state change notification.
 const Ec2StateChangeNotification = "EC2 Instance State-change Notification"
 
+// trackedInstanceStates are the EC2 instance states which trigger a reconcile of the corresponding AWSMachine,
+// so that stopped, started and terminated instances are detected without waiting for the next resync.
+var trackedInstanceStates = []infrav1.InstanceState{
+	infrav1.InstanceStateShuttingDown,
+	infrav1.InstanceStateTerminated,
+	infrav1.InstanceStateStopping,
+	infrav1.InstanceStateStopped,
+	infrav1.InstanceStateRunning,
+}
+
 // reconcileRules creates rules and attaches the queue as a target.
 func (s Service) reconcileRules() error {
 	var ruleNotFound bool
@@ -61,12 +72,35 @@ func (s Service) reconcileRules() error {
 		}
 	}
 
+	queueURL, queueAttrs, err := s.reconcileQueueTarget(ruleResp)
+	if err != nil {
+		return err
+	}
+
+	if queueAttrs[sqs.QueueAttributeNamePolicy] == nil {
+		// add a policy for the rule so the rule is authorized to emit messages to the queue
+		err = s.createPolicyForRule(&createPolicyForRuleInput{
+			QueueArn: *queueAttrs[sqs.QueueAttributeNameQueueArn],
+			QueueURL: queueURL,
+			RuleArns: []string{*ruleResp.Arn},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileQueueTarget adds the cluster's queue as a target of the given rule if it isn't already,
+// returning the queue URL and its ARN and policy attributes.
+func (s Service) reconcileQueueTarget(rule *eventbridge.DescribeRuleOutput) (string, map[string]*string, error) {
 	queueURLResp, err := s.SQSClient.GetQueueUrl(&sqs.GetQueueUrlInput{
 		QueueName: aws.String(GenerateQueueName(s.scope.Name())),
 	})
 
 	if err != nil {
-		return errors.Wrap(err, "unable to get queue URL")
+		return "", nil, errors.Wrap(err, "unable to get queue URL")
 	}
 	queueAttrs, err := s.SQSClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
 		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn, sqs.QueueAttributeNamePolicy}),
@@ -74,14 +108,14 @@ func (s Service) reconcileRules() error {
 	})
 
 	if err != nil {
-		return errors.Wrap(err, "unable to get queue attributes")
+		return "", nil, errors.Wrap(err, "unable to get queue attributes")
 	}
 
 	targetsResp, err := s.EventBridgeClient.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{
-		Rule: aws.String(s.getEC2RuleName()),
+		Rule: rule.Name,
 	})
 	if err != nil {
-		return errors.Wrapf(err, "unable to list targets for rule %s", s.getEC2RuleName())
+		return "", nil, errors.Wrapf(err, "unable to list targets for rule %s", *rule.Name)
 	}
 
 	targetFound := false
@@ -94,7 +128,7 @@ func (s Service) reconcileRules() error {
 
 	if !targetFound {
 		_, err = s.EventBridgeClient.PutTargets(&eventbridge.PutTargetsInput{
-			Rule: ruleResp.Name,
+			Rule: rule.Name,
 			Targets: []*eventbridge.Target{{
 				Arn: queueAttrs.Attributes[sqs.QueueAttributeNameQueueArn],
 				Id:  aws.String(GenerateQueueName(s.scope.Name())),
@@ -102,23 +136,11 @@ func (s Service) reconcileRules() error {
 		})
 
 		if err != nil {
-			return errors.Wrapf(err, "unable to add SQS target %s to rule %s", GenerateQueueName(s.scope.Name()), s.getEC2RuleName())
+			return "", nil, errors.Wrapf(err, "unable to add SQS target %s to rule %s", GenerateQueueName(s.scope.Name()), *rule.Name)
 		}
 	}
 
-	if queueAttrs.Attributes[sqs.QueueAttributeNamePolicy] == nil {
-		// add a policy for the rule so the rule is authorized to emit messages to the queue
-		err = s.createPolicyForRule(&createPolicyForRuleInput{
-			QueueArn: *queueAttrs.Attributes[sqs.QueueAttributeNameQueueArn],
-			QueueURL: *queueURLResp.QueueUrl,
-			RuleArn:  *ruleResp.Arn,
-		})
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return *queueURLResp.QueueUrl, queueAttrs.Attributes, nil
 }
 
 func (s Service) createRule() error {
@@ -126,7 +148,7 @@ func (s Service) createRule() error {
 		Source:     []string{"aws.ec2"},
 		DetailType: []string{Ec2StateChangeNotification},
 		EventDetail: &eventDetail{
-			States: []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+			States: trackedInstanceStates,
 		},
 	}
 	data, err := json.Marshal(eventPattern)
@@ -145,15 +167,20 @@ func (s Service) createRule() error {
 }
 
 func (s Service) deleteRules() error {
+	return s.deleteRule(s.getEC2RuleName())
+}
+
+// deleteRule removes the cluster's queue as a target of the given rule and deletes the rule.
+func (s Service) deleteRule(ruleName string) error {
 	_, err := s.EventBridgeClient.RemoveTargets(&eventbridge.RemoveTargetsInput{
-		Rule: aws.String(s.getEC2RuleName()),
+		Rule: aws.String(ruleName),
 		Ids:  aws.StringSlice([]string{GenerateQueueName(s.scope.Name())}),
 	})
 	if err != nil && !resourceNotFoundError(err) {
-		return errors.Wrapf(err, "unable to remove target %s for rule %s", GenerateQueueName(s.scope.Name()), s.getEC2RuleName())
+		return errors.Wrapf(err, "unable to remove target %s for rule %s", GenerateQueueName(s.scope.Name()), ruleName)
 	}
 	_, err = s.EventBridgeClient.DeleteRule(&eventbridge.DeleteRuleInput{
-		Name: aws.String(s.getEC2RuleName()),
+		Name: aws.String(ruleName),
 	})
 
 	if err != nil && resourceNotFoundError(err) {
@@ -177,15 +204,22 @@ func (s Service) AddInstanceToEventPattern(instanceID string) error {
 		return err
 	}
 	e.DetailType = []string{Ec2StateChangeNotification}
+	if e.EventDetail == nil {
+		e.EventDetail = &eventDetail{}
+	}
 
-	for _, r := range e.EventDetail.InstanceIDs {
-		if r == instanceID {
-			// instance is already tracked by rule
-			return nil
-		}
+	// rules created by older versions only track a subset of the instance states
+	statesChanged := !slices.Equal(e.EventDetail.States, trackedInstanceStates)
+	e.EventDetail.States = trackedInstanceStates
+
+	if !statesChanged && slices.Contains(e.EventDetail.InstanceIDs, instanceID) {
+		// instance is already tracked by rule
+		return nil
 	}
 
-	e.EventDetail.InstanceIDs = append(e.EventDetail.InstanceIDs, instanceID)
+	if !slices.Contains(e.EventDetail.InstanceIDs, instanceID) {
+		e.EventDetail.InstanceIDs = append(e.EventDetail.InstanceIDs, instanceID)
+	}
 	eventData, err := json.Marshal(e)
 	if err != nil {
 		return err
@@ -55,7 +55,13 @@ func TestReconcileRules(t *testing.T) {
 					Source:     []string{"aws.ec2"},
 					DetailType: []string{Ec2StateChangeNotification},
 					EventDetail: &eventDetail{
-						States: []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+						States: []infrav1.InstanceState{
+							infrav1.InstanceStateShuttingDown,
+							infrav1.InstanceStateTerminated,
+							infrav1.InstanceStateStopping,
+							infrav1.InstanceStateStopped,
+							infrav1.InstanceStateRunning,
+						},
 					},
 				}
 				data, err := json.Marshal(e)
@@ -241,12 +247,24 @@ func TestAddInstanceToRule(t *testing.T) {
 		Source:     []string{"aws.ec2"},
 		EventDetail: &eventDetail{
 			InstanceIDs: []string{"instance-a"},
+			States:      trackedInstanceStates,
 		},
 	}
 	patternData, err := json.Marshal(pattern)
 	if err != nil {
 		t.Fatalf("got an unexpected error: %v", err)
 	}
+	legacyPatternData, err := json.Marshal(eventPattern{
+		DetailType: []string{Ec2StateChangeNotification},
+		Source:     []string{"aws.ec2"},
+		EventDetail: &eventDetail{
+			InstanceIDs: []string{"instance-a"},
+			States:      []infrav1.InstanceState{infrav1.InstanceStateShuttingDown, infrav1.InstanceStateTerminated},
+		},
+	})
+	if err != nil {
+		t.Fatalf("got an unexpected error: %v", err)
+	}
 
 	testCases := []struct {
 		name              string
@@ -289,6 +307,34 @@ func TestAddInstanceToRule(t *testing.T) {
 			newInstanceID: "instance-a",
 			expectErr:     false,
 		},
+		{
+			name: "updates tracked instance states of an existing event pattern",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				}).Return(&eventbridge.DescribeRuleOutput{
+					EventPattern: aws.String(string(legacyPatternData)),
+				}, nil)
+				expectedData, err := json.Marshal(eventPattern{
+					DetailType: []string{Ec2StateChangeNotification},
+					Source:     []string{"aws.ec2"},
+					EventDetail: &eventDetail{
+						InstanceIDs: []string{"instance-a"},
+						States:      trackedInstanceStates,
+					},
+				})
+				if err != nil {
+					t.Fatalf("got an unexpected error: %v", err)
+				}
+				m.PutRule(&eventbridge.PutRuleInput{
+					Name:         aws.String("test-cluster-ec2-rule"),
+					EventPattern: aws.String(string(expectedData)),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+				}).Return(nil, nil)
+			},
+			newInstanceID: "instance-a",
+			expectErr:     false,
+		},
 	}
 
 	for _, tc := range testCases {
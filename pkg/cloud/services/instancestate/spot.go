@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/pkg/errors"
+)
+
+// Ec2SpotInstanceInterruptionWarning defines the EC2 Spot Instance interruption warning notification.
+const Ec2SpotInstanceInterruptionWarning = "EC2 Spot Instance Interruption Warning"
+
+// ReconcileSpotInterruptionEvents will reconcile a rule forwarding EC2 Spot Instance interruption
+// warnings to the Service's queue. The queue is expected to be created by ReconcileEC2Events.
+func (s Service) ReconcileSpotInterruptionEvents() error {
+	ruleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(s.getSpotInterruptionRuleName()),
+	})
+	if err != nil {
+		if !resourceNotFoundError(err) {
+			return errors.Wrapf(err, "unable to describe rule %s", s.getSpotInterruptionRuleName())
+		}
+
+		if err := s.createSpotInterruptionRule(); err != nil {
+			return errors.Wrap(err, "unable to create spot interruption rule")
+		}
+		// fetch newly created rule
+		ruleResp, err = s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+			Name: aws.String(s.getSpotInterruptionRuleName()),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "unable to describe new rule %s", s.getSpotInterruptionRuleName())
+		}
+	}
+
+	queueURL, queueAttrs, err := s.reconcileQueueTarget(ruleResp)
+	if err != nil {
+		return err
+	}
+
+	policy := queueAttrs[sqs.QueueAttributeNamePolicy]
+	if policy != nil && strings.Contains(*policy, *ruleResp.Arn) {
+		return nil
+	}
+
+	// the queue policy has to authorize both the instance state rule and the spot interruption rule
+	stateRuleResp, err := s.EventBridgeClient.DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: aws.String(s.getEC2RuleName()),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to describe rule %s", s.getEC2RuleName())
+	}
+
+	return s.createPolicyForRule(&createPolicyForRuleInput{
+		QueueArn: *queueAttrs[sqs.QueueAttributeNameQueueArn],
+		QueueURL: queueURL,
+		RuleArns: []string{*stateRuleResp.Arn, *ruleResp.Arn},
+	})
+}
+
+// DeleteSpotInterruptionEvents will delete the Service's EC2 Spot Instance interruption warning rule.
+func (s Service) DeleteSpotInterruptionEvents() error {
+	return s.deleteRule(s.getSpotInterruptionRuleName())
+}
+
+func (s Service) createSpotInterruptionRule() error {
+	data, err := json.Marshal(eventPattern{
+		Source:     []string{"aws.ec2"},
+		DetailType: []string{Ec2SpotInstanceInterruptionWarning},
+	})
+	if err != nil {
+		return err
+	}
+
+	// interruption warnings for instances not managed by the cluster are ignored when the queue is processed
+	_, err = s.EventBridgeClient.PutRule(&eventbridge.PutRuleInput{
+		Name:         aws.String(s.getSpotInterruptionRuleName()),
+		EventPattern: aws.String(string(data)),
+		State:        aws.String(eventbridge.RuleStateEnabled),
+	})
+
+	return err
+}
+
+func (s Service) getSpotInterruptionRuleName() string {
+	return fmt.Sprintf("%s-spot-interruption-rule", s.scope.Name())
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancestate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_eventbridgeiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/instancestate/mock_sqsiface"
+)
+
+func TestReconcileSpotInterruptionEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ruleName := "test-cluster-spot-interruption-rule"
+
+	testCases := []struct {
+		name              string
+		eventBridgeExpect func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder)
+		sqsExpect         func(m *mock_sqsiface.MockSQSAPIMockRecorder)
+		expectErr         bool
+	}{
+		{
+			name: "creates missing rule, adds the queue as target and authorizes both rules in the queue policy",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				gomock.InOrder(
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil)),
+					m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+						Name: aws.String(ruleName),
+					})).Return(&eventbridge.DescribeRuleOutput{Name: aws.String(ruleName), Arn: aws.String("spot-rule-arn")}, nil),
+				)
+				data, err := json.Marshal(&eventPattern{
+					Source:     []string{"aws.ec2"},
+					DetailType: []string{Ec2SpotInstanceInterruptionWarning},
+				})
+				if err != nil {
+					t.Fatalf("got an unexpected error: %v", err)
+				}
+				m.PutRule(gomock.Eq(&eventbridge.PutRuleInput{
+					Name:         aws.String(ruleName),
+					State:        aws.String(eventbridge.RuleStateEnabled),
+					EventPattern: aws.String(string(data)),
+				}))
+				m.ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{
+					Rule: aws.String(ruleName),
+				}).Return(&eventbridge.ListTargetsByRuleOutput{}, nil)
+				m.PutTargets(gomock.Eq(&eventbridge.PutTargetsInput{
+					Rule: aws.String(ruleName),
+					Targets: []*eventbridge.Target{{
+						Arn: aws.String("test-cluster-queue-arn"),
+						Id:  aws.String("test-cluster-queue"),
+					}},
+				}))
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String("test-cluster-ec2-rule"),
+				})).Return(&eventbridge.DescribeRuleOutput{Name: aws.String("test-cluster-ec2-rule"), Arn: aws.String("rule-arn")}, nil)
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.Eq(&sqs.GetQueueUrlInput{
+					QueueName: aws.String("test-cluster-queue"),
+				})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNameQueueArn] = "test-cluster-queue-arn"
+				attrs[sqs.QueueAttributeNamePolicy] = `{"Condition":{"ArnEquals":{"aws:SourceArn":"rule-arn"}}}`
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(attrs)}, nil)
+				m.SetQueueAttributes(gomock.AssignableToTypeOf(&sqs.SetQueueAttributesInput{})).DoAndReturn(func(input *sqs.SetQueueAttributesInput) (*sqs.SetQueueAttributesOutput, error) {
+					policy := aws.StringValue(input.Attributes[sqs.QueueAttributeNamePolicy])
+					if !json.Valid([]byte(policy)) {
+						t.Fatalf("expected a valid JSON policy, got %s", policy)
+					}
+					NewWithT(t).Expect(policy).To(ContainSubstring(`"aws:SourceArn":["rule-arn","spot-rule-arn"]`))
+					return nil, nil
+				})
+			},
+		},
+		{
+			name: "skips creating target and queue policy if they already exist",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String(ruleName),
+				})).Return(&eventbridge.DescribeRuleOutput{Name: aws.String(ruleName), Arn: aws.String("spot-rule-arn")}, nil)
+				m.ListTargetsByRule(gomock.AssignableToTypeOf(&eventbridge.ListTargetsByRuleInput{})).Return(&eventbridge.ListTargetsByRuleOutput{
+					Targets: []*eventbridge.Target{{
+						Id:  aws.String("test-cluster-queue"),
+						Arn: aws.String("test-cluster-queue-arn"),
+					}},
+				}, nil)
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {
+				m.GetQueueUrl(gomock.AssignableToTypeOf(&sqs.GetQueueUrlInput{})).Return(&sqs.GetQueueUrlOutput{QueueUrl: aws.String("test-cluster-queue-url")}, nil)
+				attrs := make(map[string]string)
+				attrs[sqs.QueueAttributeNameQueueArn] = "test-cluster-queue-arn"
+				attrs[sqs.QueueAttributeNamePolicy] = `{"Condition":{"ArnEquals":{"aws:SourceArn":["rule-arn","spot-rule-arn"]}}}`
+				m.GetQueueAttributes(gomock.AssignableToTypeOf(&sqs.GetQueueAttributesInput{})).Return(&sqs.GetQueueAttributesOutput{Attributes: aws.StringMap(attrs)}, nil)
+			},
+		},
+		{
+			name: "returns error if DescribeRule runs into unexpected error",
+			eventBridgeExpect: func(m *mock_eventbridgeiface.MockEventBridgeAPIMockRecorder) {
+				m.DescribeRule(gomock.Eq(&eventbridge.DescribeRuleInput{
+					Name: aws.String(ruleName),
+				})).Return(nil, errors.New("some error"))
+			},
+			sqsExpect: func(m *mock_sqsiface.MockSQSAPIMockRecorder) {},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+			sqsMock := mock_sqsiface.NewMockSQSAPI(mockCtrl)
+			clusterScope, err := setupCluster("test-cluster")
+			g.Expect(err).To(Not(HaveOccurred()))
+			tc.sqsExpect(sqsMock.EXPECT())
+			tc.eventBridgeExpect(eventbridgeMock.EXPECT())
+
+			s := NewService(clusterScope)
+			s.EventBridgeClient = eventbridgeMock
+			s.SQSClient = sqsMock
+
+			err = s.ReconcileSpotInterruptionEvents()
+			if tc.expectErr {
+				g.Expect(err).NotTo(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestDeleteSpotInterruptionEvents(t *testing.T) {
+	g := NewWithT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	eventbridgeMock := mock_eventbridgeiface.NewMockEventBridgeAPI(mockCtrl)
+	eventbridgeMock.EXPECT().RemoveTargets(gomock.Eq(&eventbridge.RemoveTargetsInput{
+		Rule: aws.String("test-cluster-spot-interruption-rule"),
+		Ids:  aws.StringSlice([]string{"test-cluster-queue"}),
+	})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+	eventbridgeMock.EXPECT().DeleteRule(gomock.Eq(&eventbridge.DeleteRuleInput{
+		Name: aws.String("test-cluster-spot-interruption-rule"),
+	})).Return(nil, awserr.New(eventbridge.ErrCodeResourceNotFoundException, "", nil))
+
+	clusterScope, err := setupCluster("test-cluster")
+	g.Expect(err).To(Not(HaveOccurred()))
+	s := NewService(clusterScope)
+	s.EventBridgeClient = eventbridgeMock
+
+	g.Expect(s.DeleteSpotInterruptionEvents()).To(Succeed())
+}
@@ -59,6 +59,7 @@ type EC2Interface interface {
 	TerminateInstance(id string) error
 	CreateInstance(scope *scope.MachineScope, userData []byte, userDataFormat string) (*infrav1.Instance, error)
 	GetRunningInstanceByTags(scope *scope.MachineScope) (*infrav1.Instance, error)
+	AdoptInstance(scope *scope.MachineScope, instance *infrav1.Instance) error
 
 	GetAdditionalSecurityGroupsIDs(securityGroup []infrav1.AWSResourceReference) ([]string, error)
 	GetCoreSecurityGroups(machine *scope.MachineScope) ([]string, error)
@@ -120,8 +121,11 @@ type ELBInterface interface {
 // NetworkInterface encapsulates the methods exposed to the cluster
 // controller.
 type NetworkInterface interface {
+	DeleteNatInstances() error
 	DeleteNetwork() error
+	DeleteVPCEndpoints() error
 	ReconcileNetwork() error
+	ReconcileVPCEndpoints() error
 }
 
 // SecurityGroupInterface encapsulates the methods exposed to the cluster
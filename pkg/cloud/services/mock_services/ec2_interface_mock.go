@@ -53,6 +53,20 @@ func (m *MockEC2Interface) EXPECT() *MockEC2InterfaceMockRecorder {
 	return m.recorder
 }
 
+// AdoptInstance mocks base method.
+func (m *MockEC2Interface) AdoptInstance(arg0 *scope.MachineScope, arg1 *v1beta2.Instance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptInstance", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdoptInstance indicates an expected call of AdoptInstance.
+func (mr *MockEC2InterfaceMockRecorder) AdoptInstance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptInstance", reflect.TypeOf((*MockEC2Interface)(nil).AdoptInstance), arg0, arg1)
+}
+
 // CreateInstance mocks base method.
 func (m *MockEC2Interface) CreateInstance(arg0 *scope.MachineScope, arg1 []byte, arg2 string) (*v1beta2.Instance, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +49,20 @@ func (m *MockNetworkInterface) EXPECT() *MockNetworkInterfaceMockRecorder {
 	return m.recorder
 }
 
+// DeleteNatInstances mocks base method.
+func (m *MockNetworkInterface) DeleteNatInstances() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNatInstances")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNatInstances indicates an expected call of DeleteNatInstances.
+func (mr *MockNetworkInterfaceMockRecorder) DeleteNatInstances() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNatInstances", reflect.TypeOf((*MockNetworkInterface)(nil).DeleteNatInstances))
+}
+
 // DeleteNetwork mocks base method.
 func (m *MockNetworkInterface) DeleteNetwork() error {
 	m.ctrl.T.Helper()
@@ -63,6 +77,20 @@ func (mr *MockNetworkInterfaceMockRecorder) DeleteNetwork() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetwork", reflect.TypeOf((*MockNetworkInterface)(nil).DeleteNetwork))
 }
 
+// DeleteVPCEndpoints mocks base method.
+func (m *MockNetworkInterface) DeleteVPCEndpoints() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVPCEndpoints")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVPCEndpoints indicates an expected call of DeleteVPCEndpoints.
+func (mr *MockNetworkInterfaceMockRecorder) DeleteVPCEndpoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVPCEndpoints", reflect.TypeOf((*MockNetworkInterface)(nil).DeleteVPCEndpoints))
+}
+
 // ReconcileNetwork mocks base method.
 func (m *MockNetworkInterface) ReconcileNetwork() error {
 	m.ctrl.T.Helper()
@@ -76,3 +104,17 @@ func (mr *MockNetworkInterfaceMockRecorder) ReconcileNetwork() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileNetwork", reflect.TypeOf((*MockNetworkInterface)(nil).ReconcileNetwork))
 }
+
+// ReconcileVPCEndpoints mocks base method.
+func (m *MockNetworkInterface) ReconcileVPCEndpoints() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileVPCEndpoints")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileVPCEndpoints indicates an expected call of ReconcileVPCEndpoints.
+func (mr *MockNetworkInterfaceMockRecorder) ReconcileVPCEndpoints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileVPCEndpoints", reflect.TypeOf((*MockNetworkInterface)(nil).ReconcileVPCEndpoints))
+}
@@ -0,0 +1,330 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	dhcpOptionsDomainName        = "domain-name"
+	dhcpOptionsDomainNameServers = "domain-name-servers"
+	dhcpOptionsNTPServers        = "ntp-servers"
+
+	// amazonProvidedDNS is the DNS server of the default DHCP options set of a region.
+	amazonProvidedDNS = "AmazonProvidedDNS"
+)
+
+func (s *Service) reconcileDHCPOptions() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping DHCP options reconcile in unmanaged mode")
+		return nil
+	}
+
+	if s.scope.VPC().DHCPOptions == nil {
+		// The condition is only set if DHCP options were configured. Once they are removed, the VPC is associated
+		// with a DHCP options set using the Amazon provided DNS again and the one of the cluster is deleted.
+		if !conditions.Has(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition) {
+			return nil
+		}
+		s.scope.Debug("Deleting removed DHCP options")
+		if err := s.deleteDHCPOptions(); err != nil {
+			return err
+		}
+		conditions.Delete(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition)
+		return nil
+	}
+
+	s.scope.Debug("Reconciling DHCP options")
+
+	existing, err := s.describeDHCPOptions()
+	if err != nil {
+		return err
+	}
+
+	// EC2 has no API to change the configurations of a DHCP options set. The owned set matching the spec is
+	// associated with the VPC, or a new one is created if none matches, and the other owned sets are deleted.
+	want := s.getDHCPConfigurations()
+	id := ""
+	outdated := []string{}
+	for _, dhcpOptions := range existing {
+		if id == "" && dhcpConfigurationsMatch(dhcpOptions.DhcpConfigurations, want) {
+			id = aws.StringValue(dhcpOptions.DhcpOptionsId)
+			continue
+		}
+		outdated = append(outdated, aws.StringValue(dhcpOptions.DhcpOptionsId))
+	}
+
+	if id == "" {
+		id, err = s.createDHCPOptions(want)
+		if err != nil {
+			return err
+		}
+	}
+
+	associated, err := s.getVPCDHCPOptionsID()
+	if err != nil {
+		return err
+	}
+	if associated != id {
+		if err := s.associateDHCPOptions(id); err != nil {
+			return err
+		}
+	}
+
+	// The outdated DHCP options sets can only be deleted once they are no longer associated with the VPC.
+	for _, outdatedID := range outdated {
+		if err := s.deleteDHCPOptionsByID(outdatedID); err != nil {
+			return err
+		}
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition)
+	return nil
+}
+
+func (s *Service) deleteDHCPOptions() error {
+	existing, err := s.describeDHCPOptions()
+	if err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	// Associating the "default" ID would leave the VPC without any DHCP options, and so without DNS resolution.
+	// The VPC falls back to a DHCP options set with the settings of the default one of the region instead, so
+	// that its own can be deleted.
+	associated, err := s.getVPCDHCPOptionsID()
+	if err != nil {
+		return err
+	}
+	for _, dhcpOptions := range existing {
+		if associated != aws.StringValue(dhcpOptions.DhcpOptionsId) {
+			continue
+		}
+		id, err := s.getAmazonProvidedDHCPOptionsID()
+		if err != nil {
+			return err
+		}
+		if err := s.associateDHCPOptions(id); err != nil {
+			return err
+		}
+		break
+	}
+
+	for _, dhcpOptions := range existing {
+		if err := s.deleteDHCPOptionsByID(aws.StringValue(dhcpOptions.DhcpOptionsId)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) describeDHCPOptions() ([]*ec2.DhcpOptions, error) {
+	out, err := s.EC2Client.DescribeDhcpOptionsWithContext(context.TODO(), &ec2.DescribeDhcpOptionsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.ClusterOwned(s.scope.Name()),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeDhcpOptions", "Failed to describe DHCP options sets: %v", err)
+		return nil, errors.Wrap(err, "failed to describe dhcp options sets")
+	}
+	return out.DhcpOptions, nil
+}
+
+// getAmazonProvidedDHCPOptionsID returns the ID of a DHCP options set with the settings of the default one of the
+// region, creating it if the account has none.
+func (s *Service) getAmazonProvidedDHCPOptionsID() (string, error) {
+	want := s.getAmazonProvidedDHCPConfigurations()
+
+	out, err := s.EC2Client.DescribeDhcpOptionsWithContext(context.TODO(), &ec2.DescribeDhcpOptionsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("key"), Values: aws.StringSlice([]string{dhcpOptionsDomainNameServers})},
+			{Name: aws.String("value"), Values: aws.StringSlice([]string{amazonProvidedDNS})},
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeDhcpOptions", "Failed to describe DHCP options sets: %v", err)
+		return "", errors.Wrap(err, "failed to describe dhcp options sets")
+	}
+	for _, dhcpOptions := range out.DhcpOptions {
+		if dhcpConfigurationsMatch(dhcpOptions.DhcpConfigurations, want) {
+			return aws.StringValue(dhcpOptions.DhcpOptionsId), nil
+		}
+	}
+
+	// The set is not tagged as owned by the cluster, as other VPCs may be associated with it later on.
+	created, err := s.EC2Client.CreateDhcpOptionsWithContext(context.TODO(), &ec2.CreateDhcpOptionsInput{
+		DhcpConfigurations: want,
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateDhcpOptions", "Failed to create DHCP options set: %v", err)
+		return "", errors.Wrap(err, "failed to create dhcp options set")
+	}
+
+	id := aws.StringValue(created.DhcpOptions.DhcpOptionsId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateDhcpOptions", "Created DHCP options set %q", id)
+	return id, nil
+}
+
+func (s *Service) getVPCDHCPOptionsID() (string, error) {
+	out, err := s.EC2Client.DescribeVpcsWithContext(context.TODO(), &ec2.DescribeVpcsInput{
+		VpcIds: aws.StringSlice([]string{s.scope.VPC().ID}),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe vpc %q", s.scope.VPC().ID)
+	}
+	if len(out.Vpcs) == 0 {
+		return "", errors.Errorf("could not find vpc %q", s.scope.VPC().ID)
+	}
+	return aws.StringValue(out.Vpcs[0].DhcpOptionsId), nil
+}
+
+func (s *Service) createDHCPOptions(configurations []*ec2.NewDhcpConfiguration) (string, error) {
+	out, err := s.EC2Client.CreateDhcpOptionsWithContext(context.TODO(), &ec2.CreateDhcpOptionsInput{
+		DhcpConfigurations: configurations,
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeDhcpOptions, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-dhcp-options", s.scope.Name())),
+				Role:        aws.String(infrav1.CommonRoleTagValue),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateDhcpOptions", "Failed to create DHCP options set: %v", err)
+		return "", errors.Wrap(err, "failed to create dhcp options set")
+	}
+
+	id := aws.StringValue(out.DhcpOptions.DhcpOptionsId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateDhcpOptions", "Created DHCP options set %q", id)
+	return id, nil
+}
+
+func (s *Service) associateDHCPOptions(id string) error {
+	if _, err := s.EC2Client.AssociateDhcpOptionsWithContext(context.TODO(), &ec2.AssociateDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+		VpcId:         aws.String(s.scope.VPC().ID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAssociateDhcpOptions", "Failed to associate DHCP options set %q with VPC %q: %v", id, s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to associate dhcp options set %q with vpc %q", id, s.scope.VPC().ID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateDhcpOptions", "Associated DHCP options set %q with VPC %q", id, s.scope.VPC().ID)
+	return nil
+}
+
+func (s *Service) deleteDHCPOptionsByID(id string) error {
+	if _, err := s.EC2Client.DeleteDhcpOptionsWithContext(context.TODO(), &ec2.DeleteDhcpOptionsInput{
+		DhcpOptionsId: aws.String(id),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteDhcpOptions", "Failed to delete DHCP options set %q: %v", id, err)
+		return errors.Wrapf(err, "failed to delete dhcp options set %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteDhcpOptions", "Deleted DHCP options set %q", id)
+	return nil
+}
+
+// getDHCPConfigurations returns the DHCP configurations of the spec.
+func (s *Service) getDHCPConfigurations() []*ec2.NewDhcpConfiguration {
+	spec := s.scope.VPC().DHCPOptions
+
+	configurations := []*ec2.NewDhcpConfiguration{}
+	if spec.DomainName != "" {
+		configurations = append(configurations, &ec2.NewDhcpConfiguration{
+			Key:    aws.String(dhcpOptionsDomainName),
+			Values: aws.StringSlice([]string{spec.DomainName}),
+		})
+	}
+	if len(spec.DomainNameServers) > 0 {
+		configurations = append(configurations, &ec2.NewDhcpConfiguration{
+			Key:    aws.String(dhcpOptionsDomainNameServers),
+			Values: aws.StringSlice(spec.DomainNameServers),
+		})
+	}
+	if len(spec.NTPServers) > 0 {
+		configurations = append(configurations, &ec2.NewDhcpConfiguration{
+			Key:    aws.String(dhcpOptionsNTPServers),
+			Values: aws.StringSlice(spec.NTPServers),
+		})
+	}
+	return configurations
+}
+
+// getAmazonProvidedDHCPConfigurations returns the DHCP configurations of the default DHCP options set of the region.
+func (s *Service) getAmazonProvidedDHCPConfigurations() []*ec2.NewDhcpConfiguration {
+	// The default domain name is 'ec2.internal' in us-east-1 and 'region.compute.internal' in the other regions.
+	domainName := fmt.Sprintf("%s.compute.internal", s.scope.Region())
+	if s.scope.Region() == "us-east-1" {
+		domainName = "ec2.internal"
+	}
+
+	return []*ec2.NewDhcpConfiguration{
+		{
+			Key:    aws.String(dhcpOptionsDomainName),
+			Values: aws.StringSlice([]string{domainName}),
+		},
+		{
+			Key:    aws.String(dhcpOptionsDomainNameServers),
+			Values: aws.StringSlice([]string{amazonProvidedDNS}),
+		},
+	}
+}
+
+// dhcpConfigurationsMatch returns true if the configurations of an existing DHCP options set are the wanted ones,
+// the order of the servers included.
+func dhcpConfigurationsMatch(existing []*ec2.DhcpConfiguration, want []*ec2.NewDhcpConfiguration) bool {
+	if len(existing) != len(want) {
+		return false
+	}
+
+	values := map[string][]string{}
+	for _, configuration := range existing {
+		for _, value := range configuration.Values {
+			values[aws.StringValue(configuration.Key)] = append(values[aws.StringValue(configuration.Key)], aws.StringValue(value.Value))
+		}
+	}
+
+	for _, configuration := range want {
+		existingValues := values[aws.StringValue(configuration.Key)]
+		if len(existingValues) != len(configuration.Values) {
+			return false
+		}
+		for i, value := range configuration.Values {
+			if existingValues[i] != aws.StringValue(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileDHCPOptions(t *testing.T) {
+	describeDHCPOptions := func(m *mocks.MockEC2APIMockRecorder, dhcpOptions ...*ec2.DhcpOptions) {
+		m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeDhcpOptionsInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+			},
+		})).Return(&ec2.DescribeDhcpOptionsOutput{DhcpOptions: dhcpOptions}, nil)
+	}
+	describeVPC := func(m *mocks.MockEC2APIMockRecorder, dhcpOptionsID string) {
+		m.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+			VpcIds: aws.StringSlice([]string{"vpc-dhcp"}),
+		})).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String(dhcpOptionsID)}},
+		}, nil)
+	}
+	associate := func(m *mocks.MockEC2APIMockRecorder, dhcpOptionsID string) {
+		m.AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+			DhcpOptionsId: aws.String(dhcpOptionsID),
+			VpcId:         aws.String("vpc-dhcp"),
+		})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil)
+	}
+	describeAmazonProvidedDHCPOptions := func(m *mocks.MockEC2APIMockRecorder, dhcpOptions ...*ec2.DhcpOptions) {
+		m.DescribeDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeDhcpOptionsInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("key"), Values: aws.StringSlice([]string{"domain-name-servers"})},
+				{Name: aws.String("value"), Values: aws.StringSlice([]string{"AmazonProvidedDNS"})},
+			},
+		})).Return(&ec2.DescribeDhcpOptionsOutput{DhcpOptions: dhcpOptions}, nil)
+	}
+	currentDHCPOptions := &ec2.DhcpOptions{
+		DhcpOptionsId: aws.String("dopt-current"),
+		DhcpConfigurations: []*ec2.DhcpConfiguration{
+			{Key: aws.String("domain-name"), Values: []*ec2.AttributeValue{{Value: aws.String("corp.example.com")}}},
+			{Key: aws.String("domain-name-servers"), Values: []*ec2.AttributeValue{{Value: aws.String("10.10.0.2")}, {Value: aws.String("10.10.0.3")}}},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		dhcpOptions *infrav1.DHCPOptionsSpec
+		unmanaged   bool
+		ready       bool
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectReady bool
+	}{
+		{
+			name:   "dhcp options disabled",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:  "associates the amazon provided dhcp options set and deletes the one of the cluster once removed",
+			ready: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m, currentDHCPOptions)
+				describeVPC(m, "dopt-current")
+				describeAmazonProvidedDHCPOptions(m,
+					&ec2.DhcpOptions{
+						DhcpOptionsId: aws.String("dopt-other"),
+						DhcpConfigurations: []*ec2.DhcpConfiguration{
+							{Key: aws.String("domain-name-servers"), Values: []*ec2.AttributeValue{{Value: aws.String("AmazonProvidedDNS")}}},
+						},
+					},
+					&ec2.DhcpOptions{
+						DhcpOptionsId: aws.String("dopt-amazon"),
+						DhcpConfigurations: []*ec2.DhcpConfiguration{
+							{Key: aws.String("domain-name"), Values: []*ec2.AttributeValue{{Value: aws.String("eu-west-1.compute.internal")}}},
+							{Key: aws.String("domain-name-servers"), Values: []*ec2.AttributeValue{{Value: aws.String("AmazonProvidedDNS")}}},
+						},
+					},
+				)
+				associate(m, "dopt-amazon")
+				m.DeleteDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-current"),
+				})).Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+		},
+		{
+			name:  "only deletes the dhcp options set of the cluster once removed if it is no longer associated",
+			ready: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m, currentDHCPOptions)
+				describeVPC(m, "dopt-amazon")
+				m.DeleteDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-current"),
+				})).Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+		},
+		{
+			name:        "unmanaged vpc",
+			dhcpOptions: &infrav1.DHCPOptionsSpec{DomainName: "corp.example.com"},
+			unmanaged:   true,
+			expect:      func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "creates and associates a dhcp options set",
+			dhcpOptions: &infrav1.DHCPOptionsSpec{
+				DomainName:        "corp.example.com",
+				DomainNameServers: []string{"10.10.0.2", "10.10.0.3"},
+				NTPServers:        []string{"169.254.169.123"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m)
+				m.CreateDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateDhcpOptionsInput, _ ...interface{}) (*ec2.CreateDhcpOptionsOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.DhcpConfigurations).To(Equal([]*ec2.NewDhcpConfiguration{
+							{Key: aws.String("domain-name"), Values: aws.StringSlice([]string{"corp.example.com"})},
+							{Key: aws.String("domain-name-servers"), Values: aws.StringSlice([]string{"10.10.0.2", "10.10.0.3"})},
+							{Key: aws.String("ntp-servers"), Values: aws.StringSlice([]string{"169.254.169.123"})},
+						}))
+						g.Expect(input.TagSpecifications[0].ResourceType).To(Equal(aws.String("dhcp-options")))
+						return &ec2.CreateDhcpOptionsOutput{DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-new")}}, nil
+					})
+				describeVPC(m, "dopt-default")
+				associate(m, "dopt-new")
+			},
+			expectReady: true,
+		},
+		{
+			name: "keeps the associated dhcp options set matching the spec",
+			dhcpOptions: &infrav1.DHCPOptionsSpec{
+				DomainName:        "corp.example.com",
+				DomainNameServers: []string{"10.10.0.2", "10.10.0.3"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m, currentDHCPOptions)
+				describeVPC(m, "dopt-current")
+			},
+			expectReady: true,
+		},
+		{
+			name: "replaces the dhcp options set not matching the spec",
+			dhcpOptions: &infrav1.DHCPOptionsSpec{
+				DomainName:        "corp.example.com",
+				DomainNameServers: []string{"10.10.0.3", "10.10.0.2"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeDHCPOptions(m, currentDHCPOptions)
+				m.CreateDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateDhcpOptionsInput{})).
+					Return(&ec2.CreateDhcpOptionsOutput{DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-new")}}, nil)
+				describeVPC(m, "dopt-current")
+				associate(m, "dopt-new")
+				m.DeleteDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteDhcpOptionsInput{
+					DhcpOptionsId: aws.String("dopt-current"),
+				})).Return(&ec2.DeleteDhcpOptionsOutput{}, nil)
+			},
+			expectReady: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			vpc := infrav1.VPCSpec{
+				ID:        "vpc-dhcp",
+				CidrBlock: "10.0.0.0/16",
+				Tags: infrav1.Tags{
+					infrav1.ClusterTagKey("test-cluster"): "owned",
+				},
+				DHCPOptions: tc.dhcpOptions,
+			}
+			if tc.unmanaged {
+				vpc.Tags = nil
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						Region:      "eu-west-1",
+						NetworkSpec: infrav1.NetworkSpec{VPC: vpc},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.ready {
+				conditions.MarkTrue(scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileDHCPOptions()).To(Succeed())
+			g.Expect(conditions.IsTrue(scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition)).To(Equal(tc.expectReady))
+		})
+	}
+}
+
+func TestDeleteDHCPOptions(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				Region: "us-east-1",
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: "vpc-dhcp"},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	gomock.InOrder(
+		ec2Mock.EXPECT().DescribeDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+			Return(&ec2.DescribeDhcpOptionsOutput{
+				DhcpOptions: []*ec2.DhcpOptions{{DhcpOptionsId: aws.String("dopt-current")}},
+			}, nil),
+		ec2Mock.EXPECT().DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+			Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-dhcp"), DhcpOptionsId: aws.String("dopt-current")}},
+			}, nil),
+		ec2Mock.EXPECT().DescribeDhcpOptionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeDhcpOptionsInput{})).
+			Return(&ec2.DescribeDhcpOptionsOutput{}, nil),
+		ec2Mock.EXPECT().CreateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.CreateDhcpOptionsInput{
+			DhcpConfigurations: []*ec2.NewDhcpConfiguration{
+				{Key: aws.String("domain-name"), Values: aws.StringSlice([]string{"ec2.internal"})},
+				{Key: aws.String("domain-name-servers"), Values: aws.StringSlice([]string{"AmazonProvidedDNS"})},
+			},
+		})).Return(&ec2.CreateDhcpOptionsOutput{DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-amazon")}}, nil),
+		ec2Mock.EXPECT().AssociateDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.AssociateDhcpOptionsInput{
+			DhcpOptionsId: aws.String("dopt-amazon"),
+			VpcId:         aws.String("vpc-dhcp"),
+		})).Return(&ec2.AssociateDhcpOptionsOutput{}, nil),
+		ec2Mock.EXPECT().DeleteDhcpOptionsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteDhcpOptionsInput{
+			DhcpOptionsId: aws.String("dopt-current"),
+		})).Return(&ec2.DeleteDhcpOptionsOutput{}, nil),
+	)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.deleteDHCPOptions()).To(Succeed())
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	infrautilconditions "sigs.k8s.io/cluster-api-provider-aws/v2/util/conditions"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileExternallyManagedNetwork validates the network owned by another party and populates the status of the
+// cluster from it, without creating, tagging or modifying any network resource.
+func (s *Service) reconcileExternallyManagedNetwork() error {
+	s.scope.Debug("Validating externally managed network")
+
+	// VPC.
+	if err := s.reconcileVPC(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.VpcReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+	if err := s.validateExternallyManagedVPC(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.ExternallyManagedNetworkInvalidReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return err
+	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcReadyCondition)
+
+	// Subnets.
+	if err := s.reconcileSubnets(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.SubnetsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+	if err := s.validateExternallyManagedSubnets(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.ExternallyManagedNetworkInvalidReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+		return err
+	}
+
+	// The IPs of the NAT gateways of the VPC are reported in the status, as for any unmanaged VPC.
+	if _, err := s.updateNatGatewayIPs(false); err != nil {
+		return err
+	}
+
+	s.scope.Debug("Validated externally managed network")
+	return nil
+}
+
+// validateExternallyManagedVPC ensures the attributes of the VPC the instances of the cluster rely on are enabled.
+func (s *Service) validateExternallyManagedVPC() error {
+	for _, attribute := range []string{ec2.VpcAttributeNameEnableDnsSupport, ec2.VpcAttributeNameEnableDnsHostnames} {
+		out, err := s.EC2Client.DescribeVpcAttributeWithContext(context.TODO(), &ec2.DescribeVpcAttributeInput{
+			VpcId:     aws.String(s.scope.VPC().ID),
+			Attribute: aws.String(attribute),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe %s attribute of vpc %q", attribute, s.scope.VPC().ID)
+		}
+
+		var enabled *ec2.AttributeBooleanValue
+		if attribute == ec2.VpcAttributeNameEnableDnsSupport {
+			enabled = out.EnableDnsSupport
+		} else {
+			enabled = out.EnableDnsHostnames
+		}
+		if enabled == nil || !aws.BoolValue(enabled.Value) {
+			record.Warnf(s.scope.InfraCluster(), "InvalidExternallyManagedNetwork", "The %s attribute of externally managed VPC %q must be enabled", attribute, s.scope.VPC().ID)
+			return fmt.Errorf("the %s attribute of externally managed vpc %q must be enabled", attribute, s.scope.VPC().ID)
+		}
+	}
+
+	return nil
+}
+
+// validateExternallyManagedSubnets ensures the subnets can host the instances of the cluster.
+func (s *Service) validateExternallyManagedSubnets() error {
+	if len(s.scope.Subnets().FilterPrivate().FilterNonCni()) == 0 {
+		record.Warnf(s.scope.InfraCluster(), "InvalidExternallyManagedNetwork", "Expected at least 1 private subnet in externally managed VPC %q but got 0", s.scope.VPC().ID)
+		return fmt.Errorf("expected at least 1 private subnet in externally managed vpc %q but got 0", s.scope.VPC().ID)
+	}
+
+	return nil
+}
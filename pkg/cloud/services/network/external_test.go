@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileExternallyManagedNetwork(t *testing.T) {
+	describeVPC := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).
+			Return(&ec2.DescribeVpcsOutput{
+				Vpcs: []*ec2.Vpc{{VpcId: aws.String("vpc-external"), CidrBlock: aws.String("10.0.0.0/16"), State: aws.String(ec2.VpcStateAvailable)}},
+			}, nil)
+	}
+	describeVPCAttributes := func(m *mocks.MockEC2APIMockRecorder, dnsHostnames bool) {
+		m.DescribeVpcAttributeWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcAttributeInput{
+			VpcId:     aws.String("vpc-external"),
+			Attribute: aws.String(ec2.VpcAttributeNameEnableDnsSupport),
+		})).Return(&ec2.DescribeVpcAttributeOutput{EnableDnsSupport: &ec2.AttributeBooleanValue{Value: aws.Bool(true)}}, nil)
+		m.DescribeVpcAttributeWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcAttributeInput{
+			VpcId:     aws.String("vpc-external"),
+			Attribute: aws.String(ec2.VpcAttributeNameEnableDnsHostnames),
+		})).Return(&ec2.DescribeVpcAttributeOutput{EnableDnsHostnames: &ec2.AttributeBooleanValue{Value: aws.Bool(dnsHostnames)}}, nil)
+	}
+	describeSubnets := func(m *mocks.MockEC2APIMockRecorder) {
+		m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+			Return(&ec2.DescribeSubnetsOutput{
+				Subnets: []*ec2.Subnet{
+					{VpcId: aws.String("vpc-external"), SubnetId: aws.String("subnet-private"), AvailabilityZone: aws.String("us-east-1a"), CidrBlock: aws.String("10.0.0.0/24")},
+					{VpcId: aws.String("vpc-external"), SubnetId: aws.String("subnet-public"), AvailabilityZone: aws.String("us-east-1a"), CidrBlock: aws.String("10.0.1.0/24")},
+				},
+			}, nil)
+		m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+			Return(&ec2.DescribeRouteTablesOutput{
+				RouteTables: []*ec2.RouteTable{
+					{
+						VpcId:        aws.String("vpc-external"),
+						Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public"), RouteTableId: aws.String("rtb-public")}},
+						Routes:       []*ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-external")}},
+					},
+				},
+			}, nil)
+		m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *ec2.DescribeNatGatewaysInput, fn func(*ec2.DescribeNatGatewaysOutput, bool) bool, _ ...request.Option) error {
+				fn(&ec2.DescribeNatGatewaysOutput{
+					NatGateways: []*ec2.NatGateway{{
+						NatGatewayId:        aws.String("nat-external"),
+						SubnetId:            aws.String("subnet-public"),
+						NatGatewayAddresses: []*ec2.NatGatewayAddress{{PublicIp: aws.String("203.0.113.10")}},
+					}},
+				}, true)
+				return nil
+			}).Times(2)
+		m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+			Return(&ec2.DescribeAvailabilityZonesOutput{
+				AvailabilityZones: []*ec2.AvailabilityZone{{ZoneName: aws.String("us-east-1a"), ZoneType: aws.String("availability-zone")}},
+			}, nil).AnyTimes()
+	}
+
+	testCases := []struct {
+		name           string
+		subnets        infrav1.Subnets
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantErr        bool
+		expectedNatIPs []string
+	}{
+		{
+			name:    "valid network, populates the status without modifying the network",
+			subnets: infrav1.Subnets{{ID: "subnet-private"}, {ID: "subnet-public"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVPC(m)
+				describeVPCAttributes(m, true)
+				describeSubnets(m)
+			},
+			expectedNatIPs: []string{"203.0.113.10"},
+		},
+		{
+			name:    "dns hostnames disabled in the vpc, returns an error",
+			subnets: infrav1.Subnets{{ID: "subnet-private"}, {ID: "subnet-public"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVPC(m)
+				describeVPCAttributes(m, false)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no private subnet, returns an error",
+			subnets: infrav1.Subnets{{ID: "subnet-public"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVPC(m)
+				describeVPCAttributes(m, true)
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{VpcId: aws.String("vpc-external"), SubnetId: aws.String("subnet-public"), AvailabilityZone: aws.String("us-east-1a"), CidrBlock: aws.String("10.0.1.0/24")},
+						},
+					}, nil)
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								VpcId:        aws.String("vpc-external"),
+								Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public"), RouteTableId: aws.String("rtb-public")}},
+								Routes:       []*ec2.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-external")}},
+							},
+						},
+					}, nil)
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{{ZoneName: aws.String("us-east-1a"), ZoneType: aws.String("availability-zone")}},
+					}, nil).AnyTimes()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			mode := infrav1.NetworkModeExternallyManaged
+			awsCluster := &infrav1.AWSCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: infrav1.AWSClusterSpec{
+					NetworkSpec: infrav1.NetworkSpec{
+						Mode:    &mode,
+						VPC:     infrav1.VPCSpec{ID: "vpc-external"},
+						Subnets: tc.subnets,
+					},
+				},
+			}
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster:                   awsCluster,
+				TagUnmanagedNetworkResources: true,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.ReconcileNetwork()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(conditions.IsTrue(scope.InfraCluster(), infrav1.VpcReadyCondition)).To(BeTrue())
+			g.Expect(conditions.IsTrue(scope.InfraCluster(), infrav1.SubnetsReadyCondition)).To(BeTrue())
+			g.Expect(scope.Subnets().FilterPrivate().IDs()).To(Equal([]string{"subnet-private"}))
+			g.Expect(scope.GetNatGatewaysIPs()).To(Equal(tc.expectedNatIPs))
+		})
+	}
+}
+
+func TestDeleteExternallyManagedNetwork(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	mode := infrav1.NetworkModeExternallyManaged
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					Mode: &mode,
+					VPC:  infrav1.VPCSpec{ID: "vpc-external"},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	// No call to the EC2 API is expected.
+	g.Expect(s.DeleteNetwork()).To(Succeed())
+}
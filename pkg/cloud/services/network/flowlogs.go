@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// defaultFlowLogsMaxAggregationInterval is the maximum aggregation interval of a flow log when none is set in the spec.
+const defaultFlowLogsMaxAggregationInterval = 600
+
+func (s *Service) reconcileFlowLogs() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping VPC flow logs reconcile in unmanaged mode")
+		return nil
+	}
+
+	if s.scope.VPC().FlowLogs == nil {
+		// The condition is only set if flow logs were configured, which are deleted once disabled.
+		if !conditions.Has(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition) {
+			return nil
+		}
+		s.scope.Debug("Deleting disabled VPC flow logs")
+		if err := s.deleteFlowLogs(); err != nil {
+			return err
+		}
+		conditions.Delete(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition)
+		return nil
+	}
+
+	s.scope.Debug("Reconciling VPC flow logs")
+
+	existing, err := s.describeFlowLogs()
+	if err != nil {
+		return err
+	}
+
+	// A flow log can't be modified, the outdated ones are replaced.
+	want := s.getFlowLogsInput()
+	upToDate := false
+	outdated := []string{}
+	for _, flowLog := range existing {
+		if !upToDate && flowLogMatches(flowLog, want) {
+			upToDate = true
+			continue
+		}
+		outdated = append(outdated, aws.StringValue(flowLog.FlowLogId))
+	}
+
+	if len(outdated) > 0 {
+		if err := s.deleteFlowLogsByID(outdated); err != nil {
+			return err
+		}
+	}
+
+	if !upToDate {
+		if err := s.createFlowLogs(want); err != nil {
+			return err
+		}
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition)
+	return nil
+}
+
+func (s *Service) deleteFlowLogs() error {
+	existing, err := s.describeFlowLogs()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	for _, flowLog := range existing {
+		ids = append(ids, aws.StringValue(flowLog.FlowLogId))
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.deleteFlowLogsByID(ids)
+}
+
+func (s *Service) describeFlowLogs() ([]*ec2.FlowLog, error) {
+	out, err := s.EC2Client.DescribeFlowLogsWithContext(context.TODO(), &ec2.DescribeFlowLogsInput{
+		Filter: []*ec2.Filter{
+			filter.EC2.FlowLogResourceID(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeFlowLogs", "Failed to describe flow logs of VPC %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe flow logs of vpc %q", s.scope.VPC().ID)
+	}
+	return out.FlowLogs, nil
+}
+
+func (s *Service) createFlowLogs(input *ec2.CreateFlowLogsInput) error {
+	out, err := s.EC2Client.CreateFlowLogsWithContext(context.TODO(), input)
+	if err == nil && len(out.Unsuccessful) > 0 {
+		err = errors.New(aws.StringValue(out.Unsuccessful[0].Error.Message))
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateFlowLogs", "Failed to create flow log of VPC %q: %v", s.scope.VPC().ID, err)
+		return errors.Wrapf(err, "failed to create flow log of vpc %q", s.scope.VPC().ID)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateFlowLogs", "Created flow log %q of VPC %q", aws.StringValue(out.FlowLogIds[0]), s.scope.VPC().ID)
+	return nil
+}
+
+func (s *Service) deleteFlowLogsByID(ids []string) error {
+	out, err := s.EC2Client.DeleteFlowLogsWithContext(context.TODO(), &ec2.DeleteFlowLogsInput{
+		FlowLogIds: aws.StringSlice(ids),
+	})
+	if err == nil && len(out.Unsuccessful) > 0 {
+		err = errors.New(aws.StringValue(out.Unsuccessful[0].Error.Message))
+	}
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteFlowLogs", "Failed to delete flow logs %v: %v", ids, err)
+		return errors.Wrapf(err, "failed to delete flow logs %v", ids)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteFlowLogs", "Deleted flow logs %v", ids)
+	return nil
+}
+
+// getFlowLogsInput returns the flow log to create for the spec, with the defaults applied.
+func (s *Service) getFlowLogsInput() *ec2.CreateFlowLogsInput {
+	spec := s.scope.VPC().FlowLogs
+
+	input := &ec2.CreateFlowLogsInput{
+		ResourceIds:            aws.StringSlice([]string{s.scope.VPC().ID}),
+		ResourceType:           aws.String(ec2.FlowLogsResourceTypeVpc),
+		LogDestinationType:     aws.String(string(spec.DestinationType)),
+		LogDestination:         aws.String(spec.Destination),
+		TrafficType:            aws.String(string(spec.TrafficType)),
+		MaxAggregationInterval: aws.Int64(spec.MaxAggregationInterval),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpcFlowLog, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-flow-logs", s.scope.Name())),
+				Role:        aws.String(infrav1.CommonRoleTagValue),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	}
+	if spec.DestinationType == "" {
+		input.LogDestinationType = aws.String(string(infrav1.FlowLogsDestinationTypeCloudWatchLogs))
+	}
+	if spec.TrafficType == "" {
+		input.TrafficType = aws.String(string(infrav1.FlowLogsTrafficTypeAll))
+	}
+	if spec.MaxAggregationInterval == 0 {
+		input.MaxAggregationInterval = aws.Int64(defaultFlowLogsMaxAggregationInterval)
+	}
+	if spec.DeliverLogsPermissionARN != "" {
+		input.DeliverLogsPermissionArn = aws.String(spec.DeliverLogsPermissionARN)
+	}
+	return input
+}
+
+// flowLogMatches returns true if the flow log publishes the traffic described by the input to the same destination.
+func flowLogMatches(flowLog *ec2.FlowLog, input *ec2.CreateFlowLogsInput) bool {
+	if aws.StringValue(flowLog.LogDestinationType) != aws.StringValue(input.LogDestinationType) ||
+		aws.StringValue(flowLog.TrafficType) != aws.StringValue(input.TrafficType) ||
+		aws.Int64Value(flowLog.MaxAggregationInterval) != aws.Int64Value(input.MaxAggregationInterval) ||
+		aws.StringValue(flowLog.DeliverLogsPermissionArn) != aws.StringValue(input.DeliverLogsPermissionArn) {
+		return false
+	}
+
+	destination := strings.TrimSuffix(aws.StringValue(input.LogDestination), "/")
+	if strings.TrimSuffix(aws.StringValue(flowLog.LogDestination), "/") == destination {
+		return true
+	}
+	// The flow logs publishing to CloudWatch Logs may only report the name of their log group,
+	// arn:<partition>:logs:<region>:<account>:log-group:<name>[:*].
+	idx := strings.Index(destination, ":log-group:")
+	if flowLog.LogGroupName == nil || idx < 0 {
+		return false
+	}
+	return strings.TrimSuffix(destination[idx+len(":log-group:"):], ":*") == aws.StringValue(flowLog.LogGroupName)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	testFlowLogsLogGroup = "arn:aws:logs:us-east-1:123456789012:log-group:vpc-flow-logs"
+	testFlowLogsRole     = "arn:aws:iam::123456789012:role/vpc-flow-logs"
+	testFlowLogsBucket   = "arn:aws:s3:::vpc-flow-logs"
+)
+
+func TestReconcileFlowLogs(t *testing.T) {
+	describeFlowLogs := func(m *mocks.MockEC2APIMockRecorder, flowLogs ...*ec2.FlowLog) {
+		m.DescribeFlowLogsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeFlowLogsInput{
+			Filter: []*ec2.Filter{
+				{Name: aws.String("resource-id"), Values: aws.StringSlice([]string{"vpc-flow"})},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+			},
+		})).Return(&ec2.DescribeFlowLogsOutput{FlowLogs: flowLogs}, nil)
+	}
+
+	testCases := []struct {
+		name        string
+		flowLogs    *infrav1.VPCFlowLogsSpec
+		unmanaged   bool
+		ready       bool
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectReady bool
+	}{
+		{
+			name:   "flow logs disabled",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:  "deletes the flow logs once disabled",
+			ready: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeFlowLogs(m, &ec2.FlowLog{FlowLogId: aws.String("fl-current")})
+				m.DeleteFlowLogsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteFlowLogsInput{
+					FlowLogIds: aws.StringSlice([]string{"fl-current"}),
+				})).Return(&ec2.DeleteFlowLogsOutput{}, nil)
+			},
+		},
+		{
+			name:      "unmanaged vpc",
+			flowLogs:  &infrav1.VPCFlowLogsSpec{Destination: testFlowLogsLogGroup, DeliverLogsPermissionARN: testFlowLogsRole},
+			unmanaged: true,
+			expect:    func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:     "creates a flow log publishing to cloudwatch logs with the defaults",
+			flowLogs: &infrav1.VPCFlowLogsSpec{Destination: testFlowLogsLogGroup, DeliverLogsPermissionARN: testFlowLogsRole},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeFlowLogs(m)
+				m.CreateFlowLogsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateFlowLogsInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateFlowLogsInput, _ ...interface{}) (*ec2.CreateFlowLogsOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.ResourceIds).To(Equal(aws.StringSlice([]string{"vpc-flow"})))
+						g.Expect(input.ResourceType).To(Equal(aws.String("VPC")))
+						g.Expect(input.LogDestinationType).To(Equal(aws.String("cloud-watch-logs")))
+						g.Expect(input.LogDestination).To(Equal(aws.String(testFlowLogsLogGroup)))
+						g.Expect(input.DeliverLogsPermissionArn).To(Equal(aws.String(testFlowLogsRole)))
+						g.Expect(input.TrafficType).To(Equal(aws.String("ALL")))
+						g.Expect(input.MaxAggregationInterval).To(Equal(aws.Int64(600)))
+						g.Expect(input.TagSpecifications[0].ResourceType).To(Equal(aws.String("vpc-flow-log")))
+						return &ec2.CreateFlowLogsOutput{FlowLogIds: aws.StringSlice([]string{"fl-new"})}, nil
+					})
+			},
+			expectReady: true,
+		},
+		{
+			name:     "keeps the flow log matching the spec",
+			flowLogs: &infrav1.VPCFlowLogsSpec{Destination: testFlowLogsLogGroup, DeliverLogsPermissionARN: testFlowLogsRole},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeFlowLogs(m, &ec2.FlowLog{
+					FlowLogId:                aws.String("fl-current"),
+					LogDestinationType:       aws.String("cloud-watch-logs"),
+					LogGroupName:             aws.String("vpc-flow-logs"),
+					DeliverLogsPermissionArn: aws.String(testFlowLogsRole),
+					TrafficType:              aws.String("ALL"),
+					MaxAggregationInterval:   aws.Int64(600),
+				})
+			},
+			expectReady: true,
+		},
+		{
+			name: "replaces the flow log not matching the spec",
+			flowLogs: &infrav1.VPCFlowLogsSpec{
+				DestinationType:        infrav1.FlowLogsDestinationTypeS3,
+				Destination:            testFlowLogsBucket,
+				TrafficType:            infrav1.FlowLogsTrafficTypeReject,
+				MaxAggregationInterval: 60,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeFlowLogs(m, &ec2.FlowLog{
+					FlowLogId:              aws.String("fl-outdated"),
+					LogDestinationType:     aws.String("s3"),
+					LogDestination:         aws.String(testFlowLogsBucket),
+					TrafficType:            aws.String("ALL"),
+					MaxAggregationInterval: aws.Int64(60),
+				})
+				m.DeleteFlowLogsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteFlowLogsInput{
+					FlowLogIds: aws.StringSlice([]string{"fl-outdated"}),
+				})).Return(&ec2.DeleteFlowLogsOutput{}, nil)
+				m.CreateFlowLogsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateFlowLogsInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateFlowLogsInput, _ ...interface{}) (*ec2.CreateFlowLogsOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.LogDestinationType).To(Equal(aws.String("s3")))
+						g.Expect(input.LogDestination).To(Equal(aws.String(testFlowLogsBucket)))
+						g.Expect(input.DeliverLogsPermissionArn).To(BeNil())
+						g.Expect(input.TrafficType).To(Equal(aws.String("REJECT")))
+						g.Expect(input.MaxAggregationInterval).To(Equal(aws.Int64(60)))
+						return &ec2.CreateFlowLogsOutput{FlowLogIds: aws.StringSlice([]string{"fl-new"})}, nil
+					})
+			},
+			expectReady: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			vpc := infrav1.VPCSpec{
+				ID:        "vpc-flow",
+				CidrBlock: "10.0.0.0/16",
+				Tags: infrav1.Tags{
+					infrav1.ClusterTagKey("test-cluster"): "owned",
+				},
+				FlowLogs: tc.flowLogs,
+			}
+			if tc.unmanaged {
+				vpc.Tags = nil
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{VPC: vpc},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.ready {
+				conditions.MarkTrue(scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileFlowLogs()).To(Succeed())
+			g.Expect(conditions.IsTrue(scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition)).To(Equal(tc.expectReady))
+		})
+	}
+}
+
+func TestDeleteFlowLogs(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: "vpc-flow"},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().DescribeFlowLogsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeFlowLogsInput{})).
+		Return(&ec2.DescribeFlowLogsOutput{
+			FlowLogs: []*ec2.FlowLog{{FlowLogId: aws.String("fl-1")}, {FlowLogId: aws.String("fl-2")}},
+		}, nil)
+	ec2Mock.EXPECT().DeleteFlowLogsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteFlowLogsInput{
+		FlowLogIds: aws.StringSlice([]string{"fl-1", "fl-2"}),
+	})).Return(&ec2.DeleteFlowLogsOutput{}, nil)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.deleteFlowLogs()).To(Succeed())
+}
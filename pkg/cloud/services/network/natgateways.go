@@ -48,6 +48,11 @@ func (s *Service) reconcileNatGateways() error {
 		return nil
 	}
 
+	if s.scope.VPC().IsNatInstanceEnabled() {
+		s.scope.Trace("Skipping NAT gateway reconcile, NAT instances are used instead")
+		return nil
+	}
+
 	s.scope.Debug("Reconciling NAT gateways")
 
 	if len(s.scope.Subnets().FilterPrivate().FilterNonCni()) == 0 {
@@ -112,6 +117,7 @@ func (s *Service) updateNatGatewayIPs(updateTags bool) ([]string, error) {
 
 	natGatewaysIPs := []string{}
 	subnetIDs := []string{}
+	hasNatGateway := false
 
 	for _, sn := range s.scope.Subnets().FilterPublic().FilterNonCni() {
 		if sn.GetResourceID() == "" {
@@ -119,6 +125,7 @@ func (s *Service) updateNatGatewayIPs(updateTags bool) ([]string, error) {
 		}
 
 		if ngw, ok := existing[sn.GetResourceID()]; ok {
+			hasNatGateway = true
 			if len(ngw.NatGatewayAddresses) > 0 && ngw.NatGatewayAddresses[0].PublicIp != nil {
 				natGatewaysIPs = append(natGatewaysIPs, *ngw.NatGatewayAddresses[0].PublicIp)
 			}
@@ -143,10 +150,44 @@ func (s *Service) updateNatGatewayIPs(updateTags bool) ([]string, error) {
 		subnetIDs = append(subnetIDs, sn.GetResourceID())
 	}
 
+	if s.scope.VPC().IsSingleNatGateway() {
+		// A single NAT gateway is shared by all the private subnets, only create it in the public
+		// subnet of the first availability zone when none exists yet.
+		subnetIDs = []string{}
+		if sn := s.getSingleNatGatewaySubnet(); !hasNatGateway && sn != nil {
+			subnetIDs = append(subnetIDs, sn.GetResourceID())
+		}
+	}
+
 	s.scope.SetNatGatewaysIPs(natGatewaysIPs)
 	return subnetIDs, nil
 }
 
+// getSingleNatGatewaySubnet returns the public subnet hosting the NAT gateway when a single NAT gateway is
+// shared by all the private subnets, preferring the first availability zone over the edge zones.
+func (s *Service) getSingleNatGatewaySubnet() *infrav1.SubnetSpec {
+	var candidates []infrav1.SubnetSpec
+	for _, sn := range s.scope.Subnets().FilterPublic().FilterNonCni() {
+		if sn.GetResourceID() != "" {
+			candidates = append(candidates, sn)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].IsEdge() != candidates[j].IsEdge() {
+			return !candidates[i].IsEdge()
+		}
+		if candidates[i].AvailabilityZone != candidates[j].AvailabilityZone {
+			return candidates[i].AvailabilityZone < candidates[j].AvailabilityZone
+		}
+		return candidates[i].GetResourceID() < candidates[j].GetResourceID()
+	})
+	return &candidates[0]
+}
+
 func (s *Service) deleteNatGateways() error {
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
 		s.scope.Trace("Skipping NAT gateway deletion in unmanaged mode")
@@ -236,7 +277,7 @@ func (s *Service) getNatGatewayTagParams(id string) infrav1.BuildParams {
 }
 
 func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatGateway, err error) {
-	eips, err := s.getOrAllocateAddresses(len(subnetIDs), infrav1.CommonRoleTagValue, s.scope.VPC().GetElasticIPPool())
+	eips, err := s.getNatGatewayAddresses(len(subnetIDs))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create one or more IP addresses for NAT gateways")
 	}
@@ -263,6 +304,44 @@ func (s *Service) createNatGateways(subnetIDs []string) (natgateways []*ec2.NatG
 	return natgateways, nil
 }
 
+// getNatGatewayAddresses returns the allocation IDs of the Elastic IPs to assign to the NAT gateways to create,
+// picking the unassociated pre-allocated Elastic IPs when configured.
+func (s *Service) getNatGatewayAddresses(num int) ([]string, error) {
+	allocationIDs := s.scope.VPC().NatGatewayElasticIPAllocationIDs
+	if len(allocationIDs) == 0 {
+		return s.getOrAllocateAddresses(num, infrav1.CommonRoleTagValue, s.scope.VPC().GetElasticIPPool())
+	}
+
+	out, err := s.EC2Client.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+		AllocationIds: aws.StringSlice(allocationIDs),
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeAddresses", "Failed to query the Elastic IPs of the NAT gateways: %v", err)
+		return nil, errors.Wrap(err, "failed to query the Elastic IPs of the NAT gateways")
+	}
+
+	unassociated := make(map[string]bool)
+	for _, address := range out.Addresses {
+		if address.AssociationId == nil {
+			unassociated[aws.StringValue(address.AllocationId)] = true
+		}
+	}
+
+	// Keep the order of the spec so the same Elastic IPs are picked on every attempt.
+	eips := []string{}
+	for _, id := range allocationIDs {
+		if unassociated[id] && len(eips) < num {
+			eips = append(eips, id)
+		}
+	}
+	if len(eips) < num {
+		record.Warnf(s.scope.InfraCluster(), "FailedAllocateAddress", "Not enough unassociated Elastic IPs for NAT gateways, need %d, found %d", num, len(eips))
+		return nil, errors.Errorf("not enough unassociated Elastic IPs in natGatewayElasticIpAllocationIds for NAT gateways, need %d, found %d", num, len(eips))
+	}
+
+	return eips, nil
+}
+
 func (s *Service) createNatGateway(subnetID, ip string) (*ec2.NatGateway, error) {
 	var out *ec2.CreateNatGatewayOutput
 	var err error
@@ -349,11 +428,15 @@ func (s *Service) getNatGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error)
 	azGateways := make(map[string]string)
 	azNames := []string{}
 	for _, psn := range s.scope.Subnets().FilterPublic() {
-		if psn.NatGatewayID == nil {
+		natGatewayID := psn.NatGatewayID
+		if s.scope.VPC().IsNatInstanceEnabled() {
+			natGatewayID = psn.NatInstanceID
+		}
+		if natGatewayID == nil {
 			continue
 		}
 		if _, ok := azGateways[psn.AvailabilityZone]; !ok {
-			azGateways[psn.AvailabilityZone] = *psn.NatGatewayID
+			azGateways[psn.AvailabilityZone] = *natGatewayID
 			azNames = append(azNames, psn.AvailabilityZone)
 		}
 	}
@@ -362,6 +445,13 @@ func (s *Service) getNatGatewayForSubnet(sn *infrav1.SubnetSpec) (string, error)
 		return gws, nil
 	}
 
+	// the private subnets of every zone share the NAT gateway when a single one is provisioned.
+	if s.scope.VPC().IsSingleNatGateway() && len(azNames) > 0 {
+		sort.Strings(azNames)
+		s.scope.Debug("Assigning single NAT gateway", "gateway ID", azGateways[azNames[0]], "source zone", azNames[0], "target zone", sn.AvailabilityZone)
+		return azGateways[azNames[0]], nil
+	}
+
 	// return error when no gateway found for regular zones, availability-zone zone type.
 	if !sn.IsEdge() {
 		return "", errors.Errorf("no nat gateways available in %q for private subnet %q", sn.AvailabilityZone, sn.GetResourceID())
@@ -46,9 +46,12 @@ func TestReconcileNatGateways(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	testCases := []struct {
-		name   string
-		input  []infrav1.SubnetSpec
-		expect func(m *mocks.MockEC2APIMockRecorder)
+		name                   string
+		input                  []infrav1.SubnetSpec
+		natGatewayMode         *infrav1.NatGatewayMode
+		elasticIPAllocationIDs []string
+		expect                 func(m *mocks.MockEC2APIMockRecorder)
+		expectErr              bool
 	}{
 		{
 			name: "single private subnet exists, should create no NAT gateway",
@@ -340,6 +343,219 @@ func TestReconcileNatGateways(t *testing.T) {
 				m.CreateNatGatewayWithContext(context.TODO(), gomock.Any()).Times(0)
 			},
 		},
+		{
+			name: "single NAT gateway, public & private subnets in two zones, should create 1 NAT gateway in the first zone",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.13.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-4",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.14.0/24",
+					IsPublic:         false,
+				},
+			},
+			natGatewayMode: &infrav1.NatGatewayModeSingle,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
+
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAddressesOutput{}, nil)
+
+				m.AllocateAddressWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.AllocateAddressInput{})).
+					Return(&ec2.AllocateAddressOutput{
+						AllocationId: aws.String(ElasticIPAllocationID),
+					}, nil).Times(1)
+
+				m.CreateNatGatewayWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateNatGatewayInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateNatGatewayInput, _ ...request.Option) (*ec2.CreateNatGatewayOutput, error) {
+						if aws.StringValue(input.SubnetId) != "subnet-3" {
+							t.Fatalf("expected the NAT gateway in subnet-3, got %q", aws.StringValue(input.SubnetId))
+						}
+						return &ec2.CreateNatGatewayOutput{
+							NatGateway: &ec2.NatGateway{
+								NatGatewayId: aws.String("natgateway"),
+								SubnetId:     aws.String("subnet-3"),
+							},
+						}, nil
+					}).Times(1)
+
+				m.WaitUntilNatGatewayAvailableWithContext(context.TODO(), &ec2.DescribeNatGatewaysInput{
+					NatGatewayIds: []*string{aws.String("natgateway")},
+				}).Return(nil)
+			},
+		},
+		{
+			name: "single NAT gateway, two public & private subnets, and one NAT gateway exists",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.13.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-4",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.14.0/24",
+					IsPublic:         false,
+				},
+			},
+			natGatewayMode: &infrav1.NatGatewayModeSingle,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, _, y interface{}, requestOptions ...request.Option) {
+						funct := y.(func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool)
+						funct(&ec2.DescribeNatGatewaysOutput{NatGateways: []*ec2.NatGateway{{
+							NatGatewayId: aws.String("gateway"),
+							SubnetId:     aws.String("subnet-1"),
+						}}}, true)
+					}).Return(nil)
+
+				m.CreateTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil).Times(1)
+
+				m.DescribeAddressesWithContext(context.TODO(), gomock.Any()).Times(0)
+				m.AllocateAddressWithContext(context.TODO(), gomock.Any()).Times(0)
+				m.CreateNatGatewayWithContext(context.TODO(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "pre-allocated elastic IPs, should create NAT gateways with the unassociated elastic IPs",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.13.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-4",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.14.0/24",
+					IsPublic:         false,
+				},
+			},
+			elasticIPAllocationIDs: []string{"eipalloc-1", "eipalloc-2", "eipalloc-3"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
+
+				m.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+					AllocationIds: aws.StringSlice([]string{"eipalloc-1", "eipalloc-2", "eipalloc-3"}),
+				}).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1")},
+						{AllocationId: aws.String("eipalloc-2")},
+						{AllocationId: aws.String("eipalloc-3")},
+					},
+				}, nil)
+
+				m.AllocateAddressWithContext(context.TODO(), gomock.Any()).Times(0)
+
+				m.CreateNatGatewayWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateNatGatewayInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateNatGatewayInput, _ ...request.Option) (*ec2.CreateNatGatewayOutput, error) {
+						expected := map[string]string{"subnet-1": "eipalloc-2", "subnet-3": "eipalloc-3"}
+						if expected[aws.StringValue(input.SubnetId)] != aws.StringValue(input.AllocationId) {
+							t.Fatalf("unexpected elastic IP %q for the NAT gateway in %q", aws.StringValue(input.AllocationId), aws.StringValue(input.SubnetId))
+						}
+						return &ec2.CreateNatGatewayOutput{
+							NatGateway: &ec2.NatGateway{
+								NatGatewayId: aws.String("natgateway-" + aws.StringValue(input.SubnetId)),
+								SubnetId:     input.SubnetId,
+							},
+						}, nil
+					}).Times(2)
+
+				m.WaitUntilNatGatewayAvailableWithContext(context.TODO(), gomock.Any()).Return(nil).Times(2)
+			},
+		},
+		{
+			name: "pre-allocated elastic IPs, not enough unassociated elastic IPs",
+			input: []infrav1.SubnetSpec{
+				{
+					ID:               "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-2",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.12.0/24",
+					IsPublic:         false,
+				},
+				{
+					ID:               "subnet-3",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.13.0/24",
+					IsPublic:         true,
+				},
+				{
+					ID:               "subnet-4",
+					AvailabilityZone: "us-east-1b",
+					CidrBlock:        "10.0.14.0/24",
+					IsPublic:         false,
+				},
+			},
+			elasticIPAllocationIDs: []string{"eipalloc-1", "eipalloc-2", "eipalloc-3"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.Any(), gomock.Any()).Return(nil)
+
+				m.DescribeAddressesWithContext(context.TODO(), &ec2.DescribeAddressesInput{
+					AllocationIds: aws.StringSlice([]string{"eipalloc-1", "eipalloc-2", "eipalloc-3"}),
+				}).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{
+						{AllocationId: aws.String("eipalloc-1"), AssociationId: aws.String("eipassoc-1")},
+						{AllocationId: aws.String("eipalloc-2")},
+						{AllocationId: aws.String("eipalloc-3"), AssociationId: aws.String("eipassoc-3")},
+					},
+				}, nil)
+
+				m.AllocateAddressWithContext(context.TODO(), gomock.Any()).Times(0)
+				m.CreateNatGatewayWithContext(context.TODO(), gomock.Any()).Times(0)
+			},
+			expectErr: true,
+		},
 		{
 			name: "public & private subnet declared, but don't exist yet",
 			input: []infrav1.SubnetSpec{
@@ -378,6 +594,8 @@ func TestReconcileNatGateways(t *testing.T) {
 							Tags: infrav1.Tags{
 								infrav1.ClusterTagKey("test-cluster"): "owned",
 							},
+							NatGatewayMode:                   tc.natGatewayMode,
+							NatGatewayElasticIPAllocationIDs: tc.elasticIPAllocationIDs,
 						},
 						Subnets: tc.input,
 					},
@@ -401,7 +619,11 @@ func TestReconcileNatGateways(t *testing.T) {
 			s := NewService(clusterScope)
 			s.EC2Client = ec2Mock
 
-			if err := s.reconcileNatGateways(); err != nil {
+			err = s.reconcileNatGateways()
+			if tc.expectErr && err == nil {
+				t.Fatal("expected error but got no error")
+			}
+			if !tc.expectErr && err != nil {
 				t.Fatalf("got an unexpected error: %v", err)
 			}
 		})
@@ -999,3 +1221,65 @@ func TestGetdNatGatewayForEdgeSubnet(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNatGatewayForSubnetWithSingleNatGateway(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	awsCluster := &infrav1.AWSCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: infrav1.AWSClusterSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					NatGatewayMode: &infrav1.NatGatewayModeSingle,
+				},
+				Subnets: infrav1.Subnets{
+					{
+						ID:               "subnet-az-1a-public",
+						AvailabilityZone: "us-east-1a",
+						IsPublic:         true,
+						NatGatewayID:     aws.String("natgw-az-1a"),
+					},
+					{
+						ID:               "subnet-az-1a-private",
+						AvailabilityZone: "us-east-1a",
+						IsPublic:         false,
+					},
+					{
+						ID:               "subnet-az-1b-public",
+						AvailabilityZone: "us-east-1b",
+						IsPublic:         true,
+					},
+					{
+						ID:               "subnet-az-1b-private",
+						AvailabilityZone: "us-east-1b",
+						IsPublic:         false,
+					},
+				},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(awsCluster).WithStatusSubresource(awsCluster).Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: awsCluster,
+		Client:     client,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s := NewService(clusterScope)
+
+	for _, sn := range clusterScope.Subnets().FilterPrivate() {
+		id, err := s.getNatGatewayForSubnet(&sn)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(id).To(Equal("natgw-az-1a"))
+	}
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// defaultNatInstanceType is the instance type of the NAT instances when none is set in the spec.
+const defaultNatInstanceType = "t3.micro"
+
+// natInstanceStates are the states of a NAT instance which isn't terminated or being terminated.
+var natInstanceStates = []string{
+	ec2.InstanceStateNamePending,
+	ec2.InstanceStateNameRunning,
+	ec2.InstanceStateNameStopping,
+	ec2.InstanceStateNameStopped,
+}
+
+func (s *Service) reconcileNatInstances() error {
+	if !s.scope.VPC().IsNatInstanceEnabled() {
+		return nil
+	}
+
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping NAT instances reconcile in unmanaged mode")
+		return nil
+	}
+
+	s.scope.Debug("Reconciling NAT instances")
+
+	if len(s.scope.Subnets().FilterPrivate().FilterNonCni()) == 0 || len(s.scope.Subnets().FilterPublic().FilterNonCni()) == 0 {
+		s.scope.Debug("No private or public subnets available, skipping NAT instances")
+		conditions.MarkFalse(
+			s.scope.InfraCluster(),
+			infrav1.NatInstancesReadyCondition,
+			infrav1.NatInstancesReconciliationFailedReason,
+			clusterv1.ConditionSeverityWarning,
+			"No private or public subnets available, skipping NAT instances")
+		return nil
+	}
+
+	securityGroupID, err := s.reconcileNatInstanceSecurityGroup()
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.describeNatInstancesBySubnet()
+	if err != nil {
+		return err
+	}
+
+	subnets := s.scope.Subnets()
+	defer func() {
+		s.scope.SetSubnets(subnets)
+	}()
+
+	natInstancesIPs := []string{}
+	notRunning := []string{}
+	for _, subnetID := range s.getNatInstanceSubnetIDs(existing) {
+		instance, ok := existing[subnetID]
+		if !ok {
+			instance, err = s.runNatInstance(subnets.FindByID(subnetID), securityGroupID)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The source/destination check is enabled on launch, and can only be disabled once the instance is
+		// running or stopped.
+		if !ok || aws.BoolValue(instance.SourceDestCheck) {
+			switch natInstanceState(instance) {
+			case ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopped:
+				if err := s.disableNatInstanceSourceDestCheck(instance); err != nil {
+					return err
+				}
+			default:
+				notRunning = append(notRunning, aws.StringValue(instance.InstanceId))
+			}
+		}
+
+		subnets.FindByID(subnetID).NatInstanceID = instance.InstanceId
+		if instance.PublicIpAddress != nil {
+			natInstancesIPs = append(natInstancesIPs, aws.StringValue(instance.PublicIpAddress))
+		}
+	}
+
+	// The NAT instances are the source of the egress traffic of the cluster, like NAT gateways.
+	s.scope.SetNatGatewaysIPs(natInstancesIPs)
+	if len(notRunning) > 0 {
+		return errors.Errorf("NAT instances %v are not running yet", notRunning)
+	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.NatInstancesReadyCondition)
+	return nil
+}
+
+// getNatInstanceSubnetIDs returns the IDs of the public subnets to run a NAT instance in. The public subnets of the
+// edge zones are skipped, their private subnets route through the NAT instance of the parent zone.
+func (s *Service) getNatInstanceSubnetIDs(existing map[string]*ec2.Instance) []string {
+	if s.scope.VPC().IsSingleNatGateway() {
+		// Keep the NAT instance which is already running, if any.
+		for _, sn := range s.scope.Subnets().FilterPublic().FilterNonCni() {
+			if _, ok := existing[sn.GetResourceID()]; ok {
+				return []string{sn.GetResourceID()}
+			}
+		}
+		if sn := s.getSingleNatGatewaySubnet(); sn != nil {
+			return []string{sn.GetResourceID()}
+		}
+		return nil
+	}
+
+	subnetIDs := []string{}
+	for _, sn := range s.scope.Subnets().FilterPublic().FilterNonCni() {
+		if sn.GetResourceID() == "" || sn.IsEdge() {
+			continue
+		}
+		subnetIDs = append(subnetIDs, sn.GetResourceID())
+	}
+	return subnetIDs
+}
+
+func (s *Service) describeNatInstancesBySubnet() (map[string]*ec2.Instance, error) {
+	out, err := s.EC2Client.DescribeInstancesWithContext(context.TODO(), &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.ProviderRole(infrav1.NatInstanceRoleTagValue),
+			filter.EC2.InstanceStates(natInstanceStates...),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeNatInstances", "Failed to describe NAT instances with VPC ID %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe NAT instances in vpc %q", s.scope.VPC().ID)
+	}
+
+	instances := make(map[string]*ec2.Instance)
+	for _, reservation := range out.Reservations {
+		for _, instance := range reservation.Instances {
+			instances[aws.StringValue(instance.SubnetId)] = instance
+		}
+	}
+	return instances, nil
+}
+
+func (s *Service) runNatInstance(sn *infrav1.SubnetSpec, securityGroupID string) (*ec2.Instance, error) {
+	spec := s.scope.VPC().NatInstance
+	instanceType := spec.InstanceType
+	if instanceType == "" {
+		instanceType = defaultNatInstanceType
+	}
+
+	out, err := s.EC2Client.RunInstancesWithContext(context.TODO(), &ec2.RunInstancesInput{
+		ImageId:      aws.String(spec.AMI),
+		InstanceType: aws.String(instanceType),
+		MinCount:     aws.Int64(1),
+		MaxCount:     aws.Int64(1),
+		NetworkInterfaces: []*ec2.InstanceNetworkInterfaceSpecification{
+			{
+				DeviceIndex:              aws.Int64(0),
+				SubnetId:                 aws.String(sn.GetResourceID()),
+				AssociatePublicIpAddress: aws.Bool(true),
+				Groups:                   aws.StringSlice([]string{securityGroupID}),
+			},
+		},
+		MetadataOptions: &ec2.InstanceMetadataOptionsRequest{
+			HttpTokens: aws.String(ec2.HttpTokensStateRequired),
+		},
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeInstance, s.getNatInstanceTagParams(fmt.Sprintf("%s-nat-%s", s.scope.Name(), sn.AvailabilityZone))),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateNatInstance", "Failed to create new NAT instance in subnet %q: %v", sn.GetResourceID(), err)
+		return nil, errors.Wrapf(err, "failed to create NAT instance in subnet %q", sn.GetResourceID())
+	}
+	if len(out.Instances) == 0 {
+		return nil, errors.Errorf("no NAT instance returned for subnet %q", sn.GetResourceID())
+	}
+
+	instance := out.Instances[0]
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNatInstance", "Created new NAT instance %q", aws.StringValue(instance.InstanceId))
+	return instance, nil
+}
+
+// natInstanceState returns the name of the state of a NAT instance.
+func natInstanceState(instance *ec2.Instance) string {
+	if instance.State == nil {
+		return ""
+	}
+	return aws.StringValue(instance.State.Name)
+}
+
+// disableNatInstanceSourceDestCheck allows the NAT instance to forward the traffic of the other instances of the VPC,
+// once it is running.
+func (s *Service) disableNatInstanceSourceDestCheck(instance *ec2.Instance) error {
+	instanceID := aws.StringValue(instance.InstanceId)
+	if _, err := s.EC2Client.ModifyInstanceAttributeWithContext(context.TODO(), &ec2.ModifyInstanceAttributeInput{
+		InstanceId:      aws.String(instanceID),
+		SourceDestCheck: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedModifyNatInstance", "Failed to disable source/destination check of NAT instance %q: %v", instanceID, err)
+		return errors.Wrapf(err, "failed to disable source/destination check of NAT instance %q", instanceID)
+	}
+	return nil
+}
+
+// reconcileNatInstanceSecurityGroup makes sure the security group of the NAT instances exists and allows all the
+// traffic from the CIDR blocks of the VPC, and returns its ID.
+func (s *Service) reconcileNatInstanceSecurityGroup() (string, error) {
+	name := s.getNatInstanceSecurityGroupName()
+	out, err := s.EC2Client.DescribeSecurityGroupsWithContext(context.TODO(), &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.SecurityGroupName(name),
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to describe security group %q", name)
+	}
+
+	var securityGroupID string
+	authorized := sets.New[string]()
+	if len(out.SecurityGroups) > 0 {
+		securityGroupID = aws.StringValue(out.SecurityGroups[0].GroupId)
+		for _, permission := range out.SecurityGroups[0].IpPermissions {
+			if aws.StringValue(permission.IpProtocol) != "-1" {
+				continue
+			}
+			for _, ipRange := range permission.IpRanges {
+				authorized.Insert(aws.StringValue(ipRange.CidrIp))
+			}
+		}
+	} else {
+		created, err := s.EC2Client.CreateSecurityGroupWithContext(context.TODO(), &ec2.CreateSecurityGroupInput{
+			VpcId:       aws.String(s.scope.VPC().ID),
+			GroupName:   aws.String(name),
+			Description: aws.String(fmt.Sprintf("Kubernetes cluster %s: NAT instances", s.scope.Name())),
+			TagSpecifications: []*ec2.TagSpecification{
+				tags.BuildParamsToTagSpecification(ec2.ResourceTypeSecurityGroup, s.getNatInstanceTagParams(name)),
+			},
+		})
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateSecurityGroup", "Failed to create security group %q for NAT instances: %v", name, err)
+			return "", errors.Wrapf(err, "failed to create security group %q", name)
+		}
+		securityGroupID = aws.StringValue(created.GroupId)
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateSecurityGroup", "Created security group %q for NAT instances", securityGroupID)
+	}
+
+	ipRanges := []*ec2.IpRange{}
+	for _, cidr := range s.getVPCCidrBlocks() {
+		if !authorized.Has(cidr) {
+			ipRanges = append(ipRanges, &ec2.IpRange{CidrIp: aws.String(cidr), Description: aws.String("VPC traffic to NAT")})
+		}
+	}
+	if len(ipRanges) == 0 {
+		return securityGroupID, nil
+	}
+
+	if _, err := s.EC2Client.AuthorizeSecurityGroupIngressWithContext(context.TODO(), &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: aws.String(securityGroupID),
+		IpPermissions: []*ec2.IpPermission{
+			{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   ipRanges,
+			},
+		},
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAuthorizeSecurityGroupIngressRules", "Failed to authorize VPC traffic for SecurityGroup %q: %v", securityGroupID, err)
+		return "", errors.Wrapf(err, "failed to authorize security group %q ingress rules", securityGroupID)
+	}
+	return securityGroupID, nil
+}
+
+// getVPCCidrBlocks returns the IPv4 CIDR blocks of the VPC.
+func (s *Service) getVPCCidrBlocks() []string {
+	cidrs := []string{}
+	if s.scope.VPC().CidrBlock != "" {
+		cidrs = append(cidrs, s.scope.VPC().CidrBlock)
+	}
+	for _, block := range s.scope.VPC().SecondaryCidrBlocks {
+		cidrs = append(cidrs, block.IPv4CidrBlock)
+	}
+	return cidrs
+}
+
+// DeleteNatInstances terminates the NAT instances owned by the cluster. The NAT instances have to be terminated
+// before the security groups of the cluster are deleted, as they use one of them.
+func (s *Service) DeleteNatInstances() error {
+	if !s.scope.VPC().IsNatInstanceEnabled() || s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
+		return nil
+	}
+
+	existing, err := s.describeNatInstancesBySubnet()
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	for _, instance := range existing {
+		ids = append(ids, aws.StringValue(instance.InstanceId))
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := s.EC2Client.TerminateInstancesWithContext(context.TODO(), &ec2.TerminateInstancesInput{
+		InstanceIds: aws.StringSlice(ids),
+	}); err != nil && !awserrors.IsNotFound(err) {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteNatInstance", "Failed to delete NAT instances %v: %v", ids, err)
+		return errors.Wrapf(err, "failed to delete NAT instances %v", ids)
+	}
+
+	if err := s.EC2Client.WaitUntilInstanceTerminatedWithContext(context.TODO(), &ec2.DescribeInstancesInput{
+		InstanceIds: aws.StringSlice(ids),
+	}); err != nil {
+		return errors.Wrapf(err, "failed to wait for NAT instances %v to be terminated", ids)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteNatInstance", "Deleted NAT instances %v", ids)
+	return nil
+}
+
+func (s *Service) getNatInstanceSecurityGroupName() string {
+	return fmt.Sprintf("%s-nat-instance", s.scope.Name())
+}
+
+func (s *Service) getNatInstanceTagParams(name string) infrav1.BuildParams {
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.NatInstanceRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
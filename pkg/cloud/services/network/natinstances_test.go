@@ -0,0 +1,350 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileNatInstances(t *testing.T) {
+	describeInstances := func(m *mocks.MockEC2APIMockRecorder, instances ...*ec2.Instance) {
+		m.DescribeInstancesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-nat"})},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/role"), Values: aws.StringSlice([]string{"nat-instance"})},
+				{Name: aws.String("instance-state-name"), Values: aws.StringSlice(natInstanceStates)},
+			},
+		})).Return(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, nil)
+	}
+	subnets := infrav1.Subnets{
+		{ResourceID: "subnet-public-1b", AvailabilityZone: "us-east-1b", IsPublic: true},
+		{ResourceID: "subnet-private-1b", AvailabilityZone: "us-east-1b"},
+		{ResourceID: "subnet-public-1a", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ResourceID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+	}
+
+	testCases := []struct {
+		name                  string
+		natInstance           *infrav1.NatInstanceSpec
+		natGatewayMode        *infrav1.NatGatewayMode
+		expect                func(m *mocks.MockEC2APIMockRecorder)
+		expectedNatInstances  map[string]string
+		expectedNatGatewayIPs []string
+		expectErr             bool
+	}{
+		{
+			name:   "nat instances disabled",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:        "launches a nat instance in the public subnet of each availability zone and requeues until they are running",
+			natInstance: &infrav1.NatInstanceSpec{AMI: "ami-nat"},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+				m.CreateSecurityGroupWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateSecurityGroupInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateSecurityGroupInput, _ ...interface{}) (*ec2.CreateSecurityGroupOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.GroupName).To(Equal(aws.String("test-cluster-nat-instance")))
+						g.Expect(input.VpcId).To(Equal(aws.String("vpc-nat")))
+						return &ec2.CreateSecurityGroupOutput{GroupId: aws.String("sg-nat")}, nil
+					})
+				m.AuthorizeSecurityGroupIngressWithContext(context.TODO(), gomock.Eq(&ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: aws.String("sg-nat"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("-1"),
+							IpRanges: []*ec2.IpRange{
+								{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("VPC traffic to NAT")},
+								{CidrIp: aws.String("10.1.0.0/16"), Description: aws.String("VPC traffic to NAT")},
+							},
+						},
+					},
+				})).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+				describeInstances(m)
+				m.RunInstancesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.RunInstancesInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.RunInstancesInput, _ ...interface{}) (*ec2.Reservation, error) {
+						g := NewWithT(t)
+						g.Expect(input.ImageId).To(Equal(aws.String("ami-nat")))
+						g.Expect(input.InstanceType).To(Equal(aws.String("t3.micro")))
+						g.Expect(input.NetworkInterfaces[0].AssociatePublicIpAddress).To(Equal(aws.Bool(true)))
+						g.Expect(input.NetworkInterfaces[0].Groups).To(Equal(aws.StringSlice([]string{"sg-nat"})))
+						subnetID := aws.StringValue(input.NetworkInterfaces[0].SubnetId)
+						return &ec2.Reservation{
+							Instances: []*ec2.Instance{{
+								InstanceId:      aws.String("i-" + subnetID),
+								SubnetId:        aws.String(subnetID),
+								SourceDestCheck: aws.Bool(true),
+								State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNamePending)},
+							}},
+						}, nil
+					}).Times(2)
+			},
+			expectedNatInstances: map[string]string{
+				"subnet-public-1a": "i-subnet-public-1a",
+				"subnet-public-1b": "i-subnet-public-1b",
+			},
+			expectedNatGatewayIPs: []string{},
+			expectErr:             true,
+		},
+		{
+			name:           "disables the source/destination check of a running nat instance",
+			natInstance:    &infrav1.NatInstanceSpec{AMI: "ami-nat"},
+			natGatewayMode: &infrav1.NatGatewayModeSingle,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{
+							{
+								GroupId: aws.String("sg-nat"),
+								IpPermissions: []*ec2.IpPermission{
+									{
+										IpProtocol: aws.String("-1"),
+										IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}, {CidrIp: aws.String("10.1.0.0/16")}},
+									},
+								},
+							},
+						},
+					}, nil)
+				describeInstances(m, &ec2.Instance{
+					InstanceId:      aws.String("i-nat"),
+					SubnetId:        aws.String("subnet-public-1b"),
+					PublicIpAddress: aws.String("203.0.113.10"),
+					SourceDestCheck: aws.Bool(true),
+					State:           &ec2.InstanceState{Name: aws.String(ec2.InstanceStateNameRunning)},
+				})
+				m.ModifyInstanceAttributeWithContext(context.TODO(), gomock.Eq(&ec2.ModifyInstanceAttributeInput{
+					InstanceId:      aws.String("i-nat"),
+					SourceDestCheck: &ec2.AttributeBooleanValue{Value: aws.Bool(false)},
+				})).Return(&ec2.ModifyInstanceAttributeOutput{}, nil)
+			},
+			expectedNatInstances: map[string]string{
+				"subnet-public-1b": "i-nat",
+			},
+			expectedNatGatewayIPs: []string{"203.0.113.10"},
+		},
+		{
+			name:           "keeps the single nat instance which is already running",
+			natInstance:    &infrav1.NatInstanceSpec{AMI: "ami-nat", InstanceType: "t4g.nano"},
+			natGatewayMode: &infrav1.NatGatewayModeSingle,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{
+							{
+								GroupId: aws.String("sg-nat"),
+								IpPermissions: []*ec2.IpPermission{
+									{
+										IpProtocol: aws.String("-1"),
+										IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}, {CidrIp: aws.String("10.1.0.0/16")}},
+									},
+								},
+							},
+						},
+					}, nil)
+				describeInstances(m, &ec2.Instance{
+					InstanceId:      aws.String("i-nat"),
+					SubnetId:        aws.String("subnet-public-1b"),
+					PublicIpAddress: aws.String("203.0.113.10"),
+					SourceDestCheck: aws.Bool(false),
+				})
+			},
+			expectedNatInstances: map[string]string{
+				"subnet-public-1b": "i-nat",
+			},
+			expectedNatGatewayIPs: []string{"203.0.113.10"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{
+								ID:                  "vpc-nat",
+								CidrBlock:           "10.0.0.0/16",
+								SecondaryCidrBlocks: []infrav1.VpcCidrBlock{{IPv4CidrBlock: "10.1.0.0/16"}},
+								Tags: infrav1.Tags{
+									infrav1.ClusterTagKey("test-cluster"): "owned",
+								},
+								NatGatewayMode: tc.natGatewayMode,
+								NatInstance:    tc.natInstance,
+							},
+							Subnets: subnets.DeepCopy(),
+						},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.reconcileNatInstances()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			natInstances := map[string]string{}
+			for _, sn := range scope.Subnets() {
+				if sn.NatInstanceID != nil {
+					natInstances[sn.GetResourceID()] = *sn.NatInstanceID
+				}
+			}
+			if tc.expectedNatInstances == nil {
+				g.Expect(natInstances).To(BeEmpty())
+				g.Expect(conditions.Get(scope.InfraCluster(), infrav1.NatInstancesReadyCondition)).To(BeNil())
+				return
+			}
+			g.Expect(natInstances).To(Equal(tc.expectedNatInstances))
+			g.Expect(scope.AWSCluster.Status.Network.NatGatewaysIPs).To(Equal(tc.expectedNatGatewayIPs))
+			g.Expect(conditions.IsTrue(scope.InfraCluster(), infrav1.NatInstancesReadyCondition)).To(Equal(!tc.expectErr))
+		})
+	}
+}
+
+func TestGetRoutesToPrivateSubnetWithNatInstances(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-nat",
+						Tags: infrav1.Tags{
+							infrav1.ClusterTagKey("test-cluster"): "owned",
+						},
+						NatInstance: &infrav1.NatInstanceSpec{AMI: "ami-nat"},
+					},
+					Subnets: infrav1.Subnets{
+						{ResourceID: "subnet-public-1a", AvailabilityZone: "us-east-1a", IsPublic: true, NatGatewayID: aws.String("nat-1a"), NatInstanceID: aws.String("i-nat-1a")},
+						{ResourceID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	s := NewService(scope)
+
+	routes, err := s.getRoutesToPrivateSubnet(scope.Subnets().FindByID("subnet-private-1a"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(routes).To(Equal([]*ec2.CreateRouteInput{
+		{InstanceId: aws.String("i-nat-1a"), DestinationCidrBlock: aws.String("0.0.0.0/0")},
+	}))
+}
+
+func TestDeleteNatInstances(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-nat",
+						Tags: infrav1.Tags{
+							infrav1.ClusterTagKey("test-cluster"): "owned",
+						},
+						NatInstance: &infrav1.NatInstanceSpec{AMI: "ami-nat"},
+					},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	gomock.InOrder(
+		ec2Mock.EXPECT().DescribeInstancesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeInstancesInput{})).
+			Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{
+					{Instances: []*ec2.Instance{{InstanceId: aws.String("i-nat"), SubnetId: aws.String("subnet-public-1a")}}},
+				},
+			}, nil),
+		ec2Mock.EXPECT().TerminateInstancesWithContext(context.TODO(), gomock.Eq(&ec2.TerminateInstancesInput{
+			InstanceIds: aws.StringSlice([]string{"i-nat"}),
+		})).Return(&ec2.TerminateInstancesOutput{}, nil),
+		ec2Mock.EXPECT().WaitUntilInstanceTerminatedWithContext(context.TODO(), gomock.Eq(&ec2.DescribeInstancesInput{
+			InstanceIds: aws.StringSlice([]string{"i-nat"}),
+		})).Return(nil),
+	)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.DeleteNatInstances()).To(Succeed())
+}
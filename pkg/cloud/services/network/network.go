@@ -30,6 +30,10 @@ import (
 func (s *Service) ReconcileNetwork() (err error) {
 	s.scope.Debug("Reconciling network for cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
 
+	if s.scope.ExternallyManagedNetwork() {
+		return s.reconcileExternallyManagedNetwork()
+	}
+
 	// VPC.
 	if err := s.reconcileVPC(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, infrav1.VpcReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
@@ -43,6 +47,18 @@ func (s *Service) ReconcileNetwork() (err error) {
 		return err
 	}
 
+	// VPC flow logs.
+	if err := s.reconcileFlowLogs(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition, infrav1.VpcFlowLogsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+
+	// DHCP options.
+	if err := s.reconcileDHCPOptions(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition, infrav1.DhcpOptionsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+
 	// Subnets.
 	if err := s.reconcileSubnets(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, infrav1.SubnetsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
@@ -73,19 +89,50 @@ func (s *Service) ReconcileNetwork() (err error) {
 		return err
 	}
 
+	// NAT instances.
+	if err := s.reconcileNatInstances(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NatInstancesReadyCondition, infrav1.NatInstancesReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+
 	// Routing tables.
 	if err := s.reconcileRouteTables(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, infrav1.RouteTableReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
 		return err
 	}
 
-	// VPC Endpoints.
+	// Network ACLs.
+	if err := s.reconcileNetworkACLs(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NetworkACLsReadyCondition, infrav1.NetworkACLsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+
+	// Transit Gateway attachment.
+	if err := s.reconcileTransitGatewayAttachment(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition, infrav1.TransitGatewayAttachmentReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+
+	// VPC peering connections.
+	if err := s.reconcileVPCPeeringConnections(); err != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition, infrav1.VpcPeeringConnectionsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
+		return err
+	}
+
+	s.scope.Debug("Reconcile network completed successfully")
+	return nil
+}
+
+// ReconcileVPCEndpoints reconciles the VPC endpoints of the cluster. The interface endpoints use one of
+// the security groups of the cluster, so they are reconciled after the network and the security groups.
+func (s *Service) ReconcileVPCEndpoints() error {
+	s.scope.Debug("Reconciling VPC endpoints")
+
 	if err := s.reconcileVPCEndpoints(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, infrav1.VpcEndpointsReconciliationFailedReason, infrautilconditions.ErrorConditionAfterInit(s.scope.ClusterObj()), "%s", err.Error())
 		return err
 	}
 
-	s.scope.Debug("Reconcile network completed successfully")
 	return nil
 }
 
@@ -93,6 +140,11 @@ func (s *Service) ReconcileNetwork() (err error) {
 func (s *Service) DeleteNetwork() (err error) {
 	s.scope.Debug("Deleting network")
 
+	if s.scope.ExternallyManagedNetwork() {
+		s.scope.Debug("Skipping network deletion, the network is externally managed")
+		return nil
+	}
+
 	vpc := &infrav1.VPCSpec{}
 	// Get VPC used for the cluster
 	if s.scope.VPC().ID != "" {
@@ -109,6 +161,9 @@ func (s *Service) DeleteNetwork() (err error) {
 		s.scope.Error(err, "non-fatal: VPC ID is missing, ")
 	}
 
+	// The VPC description doesn't carry the flow logs and DHCP options specs.
+	flowLogs := s.scope.VPC().FlowLogs != nil
+	dhcpOptions := s.scope.VPC().DHCPOptions != nil
 	vpc.DeepCopyInto(s.scope.VPC())
 
 	// VPC Endpoints.
@@ -117,12 +172,40 @@ func (s *Service) DeleteNetwork() (err error) {
 		return err
 	}
 
-	if err := s.deleteVPCEndpoints(); err != nil {
+	if err := s.DeleteVPCEndpoints(); err != nil {
 		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, "%s", err.Error())
 		return err
 	}
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 
+	// Transit Gateway attachment.
+	if s.scope.TransitGatewayAttachment() != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+		if err := s.scope.PatchObject(); err != nil {
+			return err
+		}
+
+		if err := s.deleteTransitGatewayAttachments(); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return err
+		}
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
+	// VPC peering connections.
+	if len(s.scope.VPCPeeringConnections()) > 0 {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+		if err := s.scope.PatchObject(); err != nil {
+			return err
+		}
+
+		if err := s.deleteVPCPeeringConnections(); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return err
+		}
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
 	// Routing tables.
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.RouteTablesReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
@@ -197,6 +280,20 @@ func (s *Service) DeleteNetwork() (err error) {
 	}
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SubnetsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 
+	// Network ACLs.
+	if s.scope.NetworkACLs() != nil {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NetworkACLsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+		if err := s.scope.PatchObject(); err != nil {
+			return err
+		}
+
+		if err := s.deleteNetworkACLs(); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NetworkACLsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return err
+		}
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.NetworkACLsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
 	// Secondary CIDR.
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.SecondaryCidrsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.disassociateSecondaryCidrs(); err != nil {
@@ -204,6 +301,34 @@ func (s *Service) DeleteNetwork() (err error) {
 		return err
 	}
 
+	// VPC flow logs.
+	if flowLogs {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+		if err := s.scope.PatchObject(); err != nil {
+			return err
+		}
+
+		if err := s.deleteFlowLogs(); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return err
+		}
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcFlowLogsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
+	// DHCP options.
+	if dhcpOptions {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+		if err := s.scope.PatchObject(); err != nil {
+			return err
+		}
+
+		if err := s.deleteDHCPOptions(); err != nil {
+			conditions.MarkFalse(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition, "DeletingFailed", clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			return err
+		}
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.DhcpOptionsReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
 	// VPC.
 	conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 	if err := s.scope.PatchObject(); err != nil {
@@ -0,0 +1,322 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// defaultNetworkACLRuleNumber is the number of the catch-all deny rule AWS adds to every network ACL.
+	defaultNetworkACLRuleNumber = 32767
+	// defaultIPv6NetworkACLRuleNumber is the number of the catch-all deny rule AWS adds to the network ACLs of
+	// a VPC with an IPv6 CIDR block.
+	defaultIPv6NetworkACLRuleNumber = 32768
+)
+
+// networkACLProtocolNumbers are the IP protocol numbers network ACL entries are described with.
+var networkACLProtocolNumbers = map[infrav1.SecurityGroupProtocol]string{
+	infrav1.SecurityGroupProtocolTCP:  "6",
+	infrav1.SecurityGroupProtocolUDP:  "17",
+	infrav1.SecurityGroupProtocolICMP: "1",
+}
+
+func (s *Service) reconcileNetworkACLs() error {
+	spec := s.scope.NetworkACLs()
+	if spec == nil {
+		return nil
+	}
+
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping network ACLs reconcile in unmanaged mode")
+		return nil
+	}
+
+	s.scope.Debug("Reconciling network ACLs")
+
+	existing, err := s.describeNetworkACLs()
+	if err != nil {
+		return err
+	}
+
+	for _, role := range []string{infrav1.PublicRoleTagValue, infrav1.PrivateRoleTagValue} {
+		acl := spec.Public
+		if role == infrav1.PrivateRoleTagValue {
+			acl = spec.Private
+		}
+		if acl == nil {
+			continue
+		}
+
+		networkACL := s.getNetworkACL(existing, role)
+		if networkACL == nil {
+			networkACL, err = s.createNetworkACL(role)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.reconcileNetworkACLEntries(networkACL, acl); err != nil {
+			return err
+		}
+
+		if err := s.associateNetworkACL(existing, networkACL, role == infrav1.PublicRoleTagValue); err != nil {
+			return err
+		}
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.NetworkACLsReadyCondition)
+	return nil
+}
+
+func (s *Service) deleteNetworkACLs() error {
+	out, err := s.EC2Client.DescribeNetworkAclsWithContext(context.TODO(), &ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to describe network acls of vpc %q", s.scope.VPC().ID)
+	}
+
+	// The subnets are deleted first, which leaves the network ACLs without associations.
+	for _, networkACL := range out.NetworkAcls {
+		id := aws.StringValue(networkACL.NetworkAclId)
+		if _, err := s.EC2Client.DeleteNetworkAclWithContext(context.TODO(), &ec2.DeleteNetworkAclInput{
+			NetworkAclId: networkACL.NetworkAclId,
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteNetworkAcl", "Failed to delete network ACL %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete network acl %q", id)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteNetworkAcl", "Deleted network ACL %q", id)
+	}
+	return nil
+}
+
+// describeNetworkACLs returns all the network ACLs of the VPC, their associations with the subnets included.
+func (s *Service) describeNetworkACLs() ([]*ec2.NetworkAcl, error) {
+	out, err := s.EC2Client.DescribeNetworkAclsWithContext(context.TODO(), &ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeNetworkAcls", "Failed to describe network ACLs of VPC %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe network acls of vpc %q", s.scope.VPC().ID)
+	}
+	return out.NetworkAcls, nil
+}
+
+// getNetworkACL returns the network ACL owned by the cluster for the role, or nil.
+func (s *Service) getNetworkACL(networkACLs []*ec2.NetworkAcl, role string) *ec2.NetworkAcl {
+	for _, networkACL := range networkACLs {
+		tags := converters.TagsToMap(networkACL.Tags)
+		if tags.HasOwned(s.scope.Name()) && tags.GetRole() == role {
+			return networkACL
+		}
+	}
+	return nil
+}
+
+func (s *Service) createNetworkACL(role string) (*ec2.NetworkAcl, error) {
+	out, err := s.EC2Client.CreateNetworkAclWithContext(context.TODO(), &ec2.CreateNetworkAclInput{
+		VpcId: aws.String(s.scope.VPC().ID),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeNetworkAcl, infrav1.BuildParams{
+				ClusterName: s.scope.Name(),
+				Lifecycle:   infrav1.ResourceLifecycleOwned,
+				Name:        aws.String(fmt.Sprintf("%s-nacl-%s", s.scope.Name(), role)),
+				Role:        aws.String(role),
+				Additional:  s.scope.AdditionalTags(),
+			}),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateNetworkAcl", "Failed to create %s network ACL: %v", role, err)
+		return nil, errors.Wrapf(err, "failed to create %s network acl", role)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateNetworkAcl", "Created %s network ACL %q", role, aws.StringValue(out.NetworkAcl.NetworkAclId))
+	return out.NetworkAcl, nil
+}
+
+// reconcileNetworkACLEntries creates, replaces and deletes the entries of the network ACL to match the rules.
+func (s *Service) reconcileNetworkACLEntries(networkACL *ec2.NetworkAcl, spec *infrav1.NetworkACLSpec) error {
+	id := aws.StringValue(networkACL.NetworkAclId)
+
+	type entryKey struct {
+		ruleNumber int64
+		egress     bool
+	}
+	existing := map[entryKey]*ec2.NetworkAclEntry{}
+	for _, entry := range networkACL.Entries {
+		// The default entries can neither be modified nor deleted.
+		if ruleNumber := aws.Int64Value(entry.RuleNumber); ruleNumber == defaultNetworkACLRuleNumber || ruleNumber == defaultIPv6NetworkACLRuleNumber {
+			continue
+		}
+		existing[entryKey{aws.Int64Value(entry.RuleNumber), aws.BoolValue(entry.Egress)}] = entry
+	}
+
+	want := map[entryKey]bool{}
+	entries := []*ec2.NetworkAclEntry{}
+	for _, rule := range spec.Ingress {
+		entries = append(entries, getNetworkACLEntry(rule, false))
+	}
+	for _, rule := range spec.Egress {
+		entries = append(entries, getNetworkACLEntry(rule, true))
+	}
+
+	for _, entry := range entries {
+		key := entryKey{aws.Int64Value(entry.RuleNumber), aws.BoolValue(entry.Egress)}
+		want[key] = true
+
+		current, ok := existing[key]
+		if ok && networkACLEntriesEqual(current, entry) {
+			continue
+		}
+
+		var err error
+		if ok {
+			_, err = s.EC2Client.ReplaceNetworkAclEntryWithContext(context.TODO(), &ec2.ReplaceNetworkAclEntryInput{
+				NetworkAclId:  aws.String(id),
+				RuleNumber:    entry.RuleNumber,
+				Egress:        entry.Egress,
+				Protocol:      entry.Protocol,
+				RuleAction:    entry.RuleAction,
+				CidrBlock:     entry.CidrBlock,
+				Ipv6CidrBlock: entry.Ipv6CidrBlock,
+				PortRange:     entry.PortRange,
+				IcmpTypeCode:  entry.IcmpTypeCode,
+			})
+		} else {
+			_, err = s.EC2Client.CreateNetworkAclEntryWithContext(context.TODO(), &ec2.CreateNetworkAclEntryInput{
+				NetworkAclId:  aws.String(id),
+				RuleNumber:    entry.RuleNumber,
+				Egress:        entry.Egress,
+				Protocol:      entry.Protocol,
+				RuleAction:    entry.RuleAction,
+				CidrBlock:     entry.CidrBlock,
+				Ipv6CidrBlock: entry.Ipv6CidrBlock,
+				PortRange:     entry.PortRange,
+				IcmpTypeCode:  entry.IcmpTypeCode,
+			})
+		}
+		if err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedUpdateNetworkAcl", "Failed to set rule %d of network ACL %q: %v", key.ruleNumber, id, err)
+			return errors.Wrapf(err, "failed to set rule %d of network acl %q", key.ruleNumber, id)
+		}
+	}
+
+	for key := range existing {
+		if want[key] {
+			continue
+		}
+		if _, err := s.EC2Client.DeleteNetworkAclEntryWithContext(context.TODO(), &ec2.DeleteNetworkAclEntryInput{
+			NetworkAclId: aws.String(id),
+			RuleNumber:   aws.Int64(key.ruleNumber),
+			Egress:       aws.Bool(key.egress),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedUpdateNetworkAcl", "Failed to delete rule %d of network ACL %q: %v", key.ruleNumber, id, err)
+			return errors.Wrapf(err, "failed to delete rule %d of network acl %q", key.ruleNumber, id)
+		}
+	}
+
+	return nil
+}
+
+// associateNetworkACL associates the network ACL with the managed public or private subnets.
+func (s *Service) associateNetworkACL(networkACLs []*ec2.NetworkAcl, networkACL *ec2.NetworkAcl, public bool) error {
+	id := aws.StringValue(networkACL.NetworkAclId)
+
+	// Every subnet is associated with a network ACL, the default one of the VPC unless replaced.
+	associations := map[string]*ec2.NetworkAclAssociation{}
+	for _, acl := range networkACLs {
+		for _, association := range acl.Associations {
+			associations[aws.StringValue(association.SubnetId)] = association
+		}
+	}
+
+	for _, subnet := range s.scope.Subnets() {
+		if subnet.IsPublic != public {
+			continue
+		}
+		association, ok := associations[subnet.GetResourceID()]
+		if !ok || aws.StringValue(association.NetworkAclId) == id {
+			continue
+		}
+
+		if _, err := s.EC2Client.ReplaceNetworkAclAssociationWithContext(context.TODO(), &ec2.ReplaceNetworkAclAssociationInput{
+			AssociationId: association.NetworkAclAssociationId,
+			NetworkAclId:  aws.String(id),
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedAssociateNetworkAcl", "Failed to associate network ACL %q with subnet %q: %v", id, subnet.GetResourceID(), err)
+			return errors.Wrapf(err, "failed to associate network acl %q with subnet %q", id, subnet.GetResourceID())
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateNetworkAcl", "Associated network ACL %q with subnet %q", id, subnet.GetResourceID())
+	}
+	return nil
+}
+
+// getNetworkACLEntry returns the network ACL entry of the rule, as described by AWS.
+func getNetworkACLEntry(rule infrav1.NetworkACLRule, egress bool) *ec2.NetworkAclEntry {
+	entry := &ec2.NetworkAclEntry{
+		RuleNumber: aws.Int64(rule.RuleNumber),
+		Egress:     aws.Bool(egress),
+		Protocol:   aws.String(string(rule.Protocol)),
+		RuleAction: aws.String(string(rule.Action)),
+	}
+	if number, ok := networkACLProtocolNumbers[rule.Protocol]; ok {
+		entry.Protocol = aws.String(number)
+	}
+	if rule.CidrBlock != "" {
+		entry.CidrBlock = aws.String(rule.CidrBlock)
+	} else {
+		entry.Ipv6CidrBlock = aws.String(rule.IPv6CidrBlock)
+	}
+
+	switch rule.Protocol {
+	case infrav1.SecurityGroupProtocolTCP, infrav1.SecurityGroupProtocolUDP:
+		entry.PortRange = &ec2.PortRange{From: aws.Int64(rule.FromPort), To: aws.Int64(rule.ToPort)}
+	case infrav1.SecurityGroupProtocolICMP, infrav1.SecurityGroupProtocolICMPv6:
+		// All the ICMP types and codes.
+		entry.IcmpTypeCode = &ec2.IcmpTypeCode{Type: aws.Int64(-1), Code: aws.Int64(-1)}
+	}
+	return entry
+}
+
+func networkACLEntriesEqual(a, b *ec2.NetworkAclEntry) bool {
+	return aws.StringValue(a.Protocol) == aws.StringValue(b.Protocol) &&
+		aws.StringValue(a.RuleAction) == aws.StringValue(b.RuleAction) &&
+		aws.StringValue(a.CidrBlock) == aws.StringValue(b.CidrBlock) &&
+		aws.StringValue(a.Ipv6CidrBlock) == aws.StringValue(b.Ipv6CidrBlock) &&
+		(a.PortRange == nil) == (b.PortRange == nil) &&
+		(a.PortRange == nil || aws.Int64Value(a.PortRange.From) == aws.Int64Value(b.PortRange.From) &&
+			aws.Int64Value(a.PortRange.To) == aws.Int64Value(b.PortRange.To))
+}
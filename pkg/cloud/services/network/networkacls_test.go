@@ -0,0 +1,311 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileNetworkACLs(t *testing.T) {
+	describeNetworkACLs := func(m *mocks.MockEC2APIMockRecorder, networkACLs ...*ec2.NetworkAcl) {
+		m.DescribeNetworkAclsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNetworkAclsInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-nacl"})},
+			},
+		})).Return(&ec2.DescribeNetworkAclsOutput{NetworkAcls: networkACLs}, nil)
+	}
+	defaultNetworkACL := &ec2.NetworkAcl{
+		NetworkAclId: aws.String("acl-default"),
+		IsDefault:    aws.Bool(true),
+		Associations: []*ec2.NetworkAclAssociation{
+			{NetworkAclAssociationId: aws.String("aclassoc-public"), NetworkAclId: aws.String("acl-default"), SubnetId: aws.String("subnet-public")},
+			{NetworkAclAssociationId: aws.String("aclassoc-private"), NetworkAclId: aws.String("acl-default"), SubnetId: aws.String("subnet-private")},
+		},
+	}
+	ownedTags := func(role string) []*ec2.Tag {
+		return []*ec2.Tag{
+			{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+			{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String(role)},
+		}
+	}
+	denySSH := infrav1.NetworkACLRule{
+		RuleNumber: 100,
+		Action:     infrav1.NetworkACLRuleActionDeny,
+		Protocol:   infrav1.SecurityGroupProtocolTCP,
+		FromPort:   22,
+		ToPort:     22,
+		CidrBlock:  "0.0.0.0/0",
+	}
+	allowAll := infrav1.NetworkACLRule{
+		RuleNumber: 200,
+		Action:     infrav1.NetworkACLRuleActionAllow,
+		Protocol:   infrav1.SecurityGroupProtocolAll,
+		CidrBlock:  "0.0.0.0/0",
+	}
+
+	testCases := []struct {
+		name        string
+		networkACLs *infrav1.NetworkACLsSpec
+		unmanaged   bool
+		expect      func(m *mocks.MockEC2APIMockRecorder)
+		expectReady bool
+	}{
+		{
+			name:   "network acls disabled",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:        "unmanaged vpc",
+			networkACLs: &infrav1.NetworkACLsSpec{Private: &infrav1.NetworkACLSpec{Ingress: []infrav1.NetworkACLRule{allowAll}}},
+			unmanaged:   true,
+			expect:      func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name: "creates the network acl of the private subnets",
+			networkACLs: &infrav1.NetworkACLsSpec{Private: &infrav1.NetworkACLSpec{
+				Ingress: []infrav1.NetworkACLRule{denySSH, allowAll},
+				Egress:  []infrav1.NetworkACLRule{allowAll},
+			}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkACLs(m, defaultNetworkACL)
+				m.CreateNetworkAclWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateNetworkAclInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkAclInput, _ ...interface{}) (*ec2.CreateNetworkAclOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.VpcId).To(Equal(aws.String("vpc-nacl")))
+						g.Expect(input.TagSpecifications[0].ResourceType).To(Equal(aws.String("network-acl")))
+						return &ec2.CreateNetworkAclOutput{NetworkAcl: &ec2.NetworkAcl{
+							NetworkAclId: aws.String("acl-private"),
+							Entries: []*ec2.NetworkAclEntry{
+								{RuleNumber: aws.Int64(32767), Egress: aws.Bool(false), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), CidrBlock: aws.String("0.0.0.0/0")},
+								{RuleNumber: aws.Int64(32767), Egress: aws.Bool(true), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), CidrBlock: aws.String("0.0.0.0/0")},
+							},
+						}}, nil
+					})
+				m.CreateNetworkAclEntryWithContext(context.TODO(), gomock.Eq(&ec2.CreateNetworkAclEntryInput{
+					NetworkAclId: aws.String("acl-private"),
+					RuleNumber:   aws.Int64(100),
+					Egress:       aws.Bool(false),
+					Protocol:     aws.String("6"),
+					RuleAction:   aws.String("deny"),
+					CidrBlock:    aws.String("0.0.0.0/0"),
+					PortRange:    &ec2.PortRange{From: aws.Int64(22), To: aws.Int64(22)},
+				})).Return(&ec2.CreateNetworkAclEntryOutput{}, nil)
+				for _, egress := range []bool{false, true} {
+					m.CreateNetworkAclEntryWithContext(context.TODO(), gomock.Eq(&ec2.CreateNetworkAclEntryInput{
+						NetworkAclId: aws.String("acl-private"),
+						RuleNumber:   aws.Int64(200),
+						Egress:       aws.Bool(egress),
+						Protocol:     aws.String("-1"),
+						RuleAction:   aws.String("allow"),
+						CidrBlock:    aws.String("0.0.0.0/0"),
+					})).Return(&ec2.CreateNetworkAclEntryOutput{}, nil)
+				}
+				m.ReplaceNetworkAclAssociationWithContext(context.TODO(), gomock.Eq(&ec2.ReplaceNetworkAclAssociationInput{
+					AssociationId: aws.String("aclassoc-private"),
+					NetworkAclId:  aws.String("acl-private"),
+				})).Return(&ec2.ReplaceNetworkAclAssociationOutput{}, nil)
+			},
+			expectReady: true,
+		},
+		{
+			name: "keeps the default entries of an existing network acl in an ipv6 vpc",
+			networkACLs: &infrav1.NetworkACLsSpec{Public: &infrav1.NetworkACLSpec{
+				Ingress: []infrav1.NetworkACLRule{allowAll},
+			}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkACLs(m,
+					&ec2.NetworkAcl{
+						NetworkAclId: aws.String("acl-public"),
+						Tags:         ownedTags("public"),
+						Associations: []*ec2.NetworkAclAssociation{
+							{NetworkAclAssociationId: aws.String("aclassoc-public"), NetworkAclId: aws.String("acl-public"), SubnetId: aws.String("subnet-public")},
+						},
+						Entries: []*ec2.NetworkAclEntry{
+							{RuleNumber: aws.Int64(200), Egress: aws.Bool(false), Protocol: aws.String("-1"), RuleAction: aws.String("allow"), CidrBlock: aws.String("0.0.0.0/0")},
+							{RuleNumber: aws.Int64(32767), Egress: aws.Bool(false), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), CidrBlock: aws.String("0.0.0.0/0")},
+							{RuleNumber: aws.Int64(32767), Egress: aws.Bool(true), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), CidrBlock: aws.String("0.0.0.0/0")},
+							{RuleNumber: aws.Int64(32768), Egress: aws.Bool(false), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), Ipv6CidrBlock: aws.String("::/0")},
+							{RuleNumber: aws.Int64(32768), Egress: aws.Bool(true), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), Ipv6CidrBlock: aws.String("::/0")},
+						},
+					},
+				)
+			},
+			expectReady: true,
+		},
+		{
+			name: "updates the rules of the existing network acl",
+			networkACLs: &infrav1.NetworkACLsSpec{Public: &infrav1.NetworkACLSpec{
+				Ingress: []infrav1.NetworkACLRule{denySSH, allowAll},
+			}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeNetworkACLs(m,
+					&ec2.NetworkAcl{
+						NetworkAclId: aws.String("acl-default"),
+						IsDefault:    aws.Bool(true),
+						Associations: []*ec2.NetworkAclAssociation{
+							{NetworkAclAssociationId: aws.String("aclassoc-private"), NetworkAclId: aws.String("acl-default"), SubnetId: aws.String("subnet-private")},
+						},
+					},
+					&ec2.NetworkAcl{
+						NetworkAclId: aws.String("acl-public"),
+						Tags:         ownedTags("public"),
+						Associations: []*ec2.NetworkAclAssociation{
+							{NetworkAclAssociationId: aws.String("aclassoc-public"), NetworkAclId: aws.String("acl-public"), SubnetId: aws.String("subnet-public")},
+						},
+						Entries: []*ec2.NetworkAclEntry{
+							{RuleNumber: aws.Int64(100), Egress: aws.Bool(false), Protocol: aws.String("6"), RuleAction: aws.String("allow"), CidrBlock: aws.String("0.0.0.0/0"), PortRange: &ec2.PortRange{From: aws.Int64(22), To: aws.Int64(22)}},
+							{RuleNumber: aws.Int64(200), Egress: aws.Bool(false), Protocol: aws.String("-1"), RuleAction: aws.String("allow"), CidrBlock: aws.String("0.0.0.0/0")},
+							{RuleNumber: aws.Int64(300), Egress: aws.Bool(true), Protocol: aws.String("-1"), RuleAction: aws.String("allow"), CidrBlock: aws.String("0.0.0.0/0")},
+							{RuleNumber: aws.Int64(32767), Egress: aws.Bool(false), Protocol: aws.String("-1"), RuleAction: aws.String("deny"), CidrBlock: aws.String("0.0.0.0/0")},
+						},
+					},
+				)
+				m.ReplaceNetworkAclEntryWithContext(context.TODO(), gomock.Eq(&ec2.ReplaceNetworkAclEntryInput{
+					NetworkAclId: aws.String("acl-public"),
+					RuleNumber:   aws.Int64(100),
+					Egress:       aws.Bool(false),
+					Protocol:     aws.String("6"),
+					RuleAction:   aws.String("deny"),
+					CidrBlock:    aws.String("0.0.0.0/0"),
+					PortRange:    &ec2.PortRange{From: aws.Int64(22), To: aws.Int64(22)},
+				})).Return(&ec2.ReplaceNetworkAclEntryOutput{}, nil)
+				m.DeleteNetworkAclEntryWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNetworkAclEntryInput{
+					NetworkAclId: aws.String("acl-public"),
+					RuleNumber:   aws.Int64(300),
+					Egress:       aws.Bool(true),
+				})).Return(&ec2.DeleteNetworkAclEntryOutput{}, nil)
+			},
+			expectReady: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			vpc := infrav1.VPCSpec{
+				ID:        "vpc-nacl",
+				CidrBlock: "10.0.0.0/16",
+				Tags: infrav1.Tags{
+					infrav1.ClusterTagKey("test-cluster"): "owned",
+				},
+			}
+			if tc.unmanaged {
+				vpc.Tags = nil
+			}
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: vpc,
+							Subnets: infrav1.Subnets{
+								{ID: "subnet-public", ResourceID: "subnet-public", AvailabilityZone: "us-east-1a", IsPublic: true},
+								{ID: "subnet-private", ResourceID: "subnet-private", AvailabilityZone: "us-east-1a"},
+							},
+							NetworkACLs: tc.networkACLs,
+						},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileNetworkACLs()).To(Succeed())
+			g.Expect(conditions.IsTrue(scope.InfraCluster(), infrav1.NetworkACLsReadyCondition)).To(Equal(tc.expectReady))
+		})
+	}
+}
+
+func TestDeleteNetworkACLs(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{ID: "vpc-nacl"},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().DescribeNetworkAclsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-nacl"})},
+			{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+		},
+	})).Return(&ec2.DescribeNetworkAclsOutput{
+		NetworkAcls: []*ec2.NetworkAcl{{NetworkAclId: aws.String("acl-public")}, {NetworkAclId: aws.String("acl-private")}},
+	}, nil)
+	for _, id := range []string{"acl-public", "acl-private"} {
+		ec2Mock.EXPECT().DeleteNetworkAclWithContext(context.TODO(), gomock.Eq(&ec2.DeleteNetworkAclInput{
+			NetworkAclId: aws.String(id),
+		})).Return(&ec2.DeleteNetworkAclOutput{}, nil)
+	}
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.deleteNetworkACLs()).To(Succeed())
+}
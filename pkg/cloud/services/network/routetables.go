@@ -59,6 +59,14 @@ func (s *Service) reconcileRouteTables() error {
 
 	for i := range subnets {
 		sn := &subnets[i]
+		// The routes of an existing route table are up to its owner, the subnet only needs to be associated with it.
+		if sn.ExistingRouteTableID != "" {
+			if err := s.associateExistingRouteTable(sn); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// We need to compile the minimum routes for this subnet first, so we can compare it or create them.
 		routes, err := s.getRoutesForSubnet(sn)
 		if err != nil {
@@ -83,6 +91,12 @@ func (s *Service) reconcileRouteTables() error {
 				}
 			}
 
+			// IPv6 can be enabled after the route tables were created, in which case the IPv6 default routes,
+			// e.g. through the egress only internet gateway for private subnets, are still missing.
+			if err := s.createMissingIPv6Routes(routes, rt); err != nil {
+				return err
+			}
+
 			// Make sure tags are up-to-date.
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				buildParams := s.getRouteTableTagParams(*rt.RouteTableId, sn.IsPublic, sn.AvailabilityZone)
@@ -131,20 +145,23 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentR
 		if (currentRoute.DestinationCidrBlock != nil &&
 			*currentRoute.DestinationCidrBlock == *specRoute.DestinationCidrBlock) &&
 			((currentRoute.GatewayId != nil && *currentRoute.GatewayId != *specRoute.GatewayId) ||
-				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != *specRoute.NatGatewayId)) {
+				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != *specRoute.NatGatewayId) ||
+				(currentRoute.InstanceId != nil && specRoute.InstanceId != nil && *currentRoute.InstanceId != *specRoute.InstanceId)) {
 			input = &ec2.ReplaceRouteInput{
 				RouteTableId:         rt.RouteTableId,
 				DestinationCidrBlock: specRoute.DestinationCidrBlock,
 				GatewayId:            specRoute.GatewayId,
 				NatGatewayId:         specRoute.NatGatewayId,
+				InstanceId:           specRoute.InstanceId,
 			}
 		}
 	}
 	if specRoute.DestinationIpv6CidrBlock != nil {
 		if (currentRoute.DestinationIpv6CidrBlock != nil &&
 			*currentRoute.DestinationIpv6CidrBlock == *specRoute.DestinationIpv6CidrBlock) &&
-			((currentRoute.GatewayId != nil && *currentRoute.GatewayId != *specRoute.GatewayId) ||
-				(currentRoute.NatGatewayId != nil && *currentRoute.NatGatewayId != *specRoute.NatGatewayId)) {
+			(aws.StringValue(currentRoute.GatewayId) != aws.StringValue(specRoute.GatewayId) ||
+				aws.StringValue(currentRoute.NatGatewayId) != aws.StringValue(specRoute.NatGatewayId) ||
+				aws.StringValue(currentRoute.EgressOnlyInternetGatewayId) != aws.StringValue(specRoute.EgressOnlyInternetGatewayId)) {
 			input = &ec2.ReplaceRouteInput{
 				RouteTableId:                rt.RouteTableId,
 				DestinationIpv6CidrBlock:    specRoute.DestinationIpv6CidrBlock,
@@ -169,6 +186,42 @@ func (s *Service) fixMismatchedRouting(specRoute *ec2.CreateRouteInput, currentR
 	return nil
 }
 
+// createMissingIPv6Routes creates the IPv6 routes of the spec that have no route with the same destination
+// in the given route table.
+func (s *Service) createMissingIPv6Routes(specRoutes []*ec2.CreateRouteInput, rt *ec2.RouteTable) error {
+	for i := range specRoutes {
+		route := specRoutes[i]
+		if route.DestinationIpv6CidrBlock == nil {
+			continue
+		}
+
+		found := false
+		for _, currentRoute := range rt.Routes {
+			if aws.StringValue(currentRoute.DestinationIpv6CidrBlock) == *route.DestinationIpv6CidrBlock {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			route.RouteTableId = rt.RouteTableId
+			if _, err := s.EC2Client.CreateRouteWithContext(context.TODO(), route); err != nil {
+				return false, err
+			}
+			return true, nil
+		}, awserrors.RouteTableNotFound, awserrors.GatewayNotFound); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedCreateRoute", "Failed to create route %s for RouteTable %q: %v", route.GoString(), *rt.RouteTableId, err)
+			return errors.Wrapf(err, "failed to create route in route table %q: %s", *rt.RouteTableId, route.GoString())
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateRoute", "Created route %s for RouteTable %q", route.GoString(), *rt.RouteTableId)
+	}
+
+	return nil
+}
+
 func (s *Service) describeVpcRouteTablesBySubnet() (map[string]*ec2.RouteTable, error) {
 	rts, err := s.describeVpcRouteTables()
 	if err != nil {
@@ -312,6 +365,50 @@ func (s *Service) associateRouteTable(rt *infrav1.RouteTable, subnetID string) e
 	return nil
 }
 
+// associateExistingRouteTable associates the subnet with its existing route table, which isn't managed by the
+// provider, replacing the association of the subnet with another route table if needed.
+func (s *Service) associateExistingRouteTable(sn *infrav1.SubnetSpec) error {
+	out, err := s.EC2Client.DescribeRouteTablesWithContext(context.TODO(), &ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			{
+				Name:   aws.String("association.subnet-id"),
+				Values: aws.StringSlice([]string{sn.GetResourceID()}),
+			},
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeVPCRouteTable", "Failed to describe route table of subnet %q: %v", sn.GetResourceID(), err)
+		return errors.Wrapf(err, "failed to describe route table of subnet %q", sn.GetResourceID())
+	}
+
+	for _, rt := range out.RouteTables {
+		for _, as := range rt.Associations {
+			if aws.StringValue(as.SubnetId) != sn.GetResourceID() {
+				continue
+			}
+			if aws.StringValue(rt.RouteTableId) != sn.ExistingRouteTableID {
+				if _, err := s.EC2Client.ReplaceRouteTableAssociationWithContext(context.TODO(), &ec2.ReplaceRouteTableAssociationInput{
+					AssociationId: as.RouteTableAssociationId,
+					RouteTableId:  aws.String(sn.ExistingRouteTableID),
+				}); err != nil {
+					record.Warnf(s.scope.InfraCluster(), "FailedReplaceRouteTableAssociation", "Failed to associate RouteTable %q with subnet %q: %v", sn.ExistingRouteTableID, sn.GetResourceID(), err)
+					return errors.Wrapf(err, "failed to associate route table %q to subnet %q", sn.ExistingRouteTableID, sn.GetResourceID())
+				}
+				record.Eventf(s.scope.InfraCluster(), "SuccessfulReplaceRouteTableAssociation", "Associated RouteTable %q with subnet %q instead of RouteTable %q", sn.ExistingRouteTableID, sn.GetResourceID(), *rt.RouteTableId)
+			}
+			sn.RouteTableID = aws.String(sn.ExistingRouteTableID)
+			return nil
+		}
+	}
+
+	if err := s.associateRouteTable(&infrav1.RouteTable{ID: sn.ExistingRouteTableID}, sn.GetResourceID()); err != nil {
+		return err
+	}
+	sn.RouteTableID = aws.String(sn.ExistingRouteTableID)
+	return nil
+}
+
 func (s *Service) getNatGatewayPrivateRoute(natGatewayID string) *ec2.CreateRouteInput {
 	return &ec2.CreateRouteInput{
 		NatGatewayId:         aws.String(natGatewayID),
@@ -319,6 +416,13 @@ func (s *Service) getNatGatewayPrivateRoute(natGatewayID string) *ec2.CreateRout
 	}
 }
 
+func (s *Service) getNatInstancePrivateRoute(instanceID string) *ec2.CreateRouteInput {
+	return &ec2.CreateRouteInput{
+		InstanceId:           aws.String(instanceID),
+		DestinationCidrBlock: aws.String(services.AnyIPv4CidrBlock),
+	}
+}
+
 func (s *Service) getEgressOnlyInternetGateway() *ec2.CreateRouteInput {
 	return &ec2.CreateRouteInput{
 		DestinationIpv6CidrBlock:    aws.String(services.AnyIPv6CidrBlock),
@@ -411,7 +515,11 @@ func (s *Service) getRoutesToPrivateSubnet(sn *infrav1.SubnetSpec) (routes []*ec
 		return routes, err
 	}
 
-	routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
+	if s.scope.VPC().IsNatInstanceEnabled() {
+		routes = append(routes, s.getNatInstancePrivateRoute(natGatewayID))
+	} else {
+		routes = append(routes, s.getNatGatewayPrivateRoute(natGatewayID))
+	}
 	if sn.IsIPv6 {
 		if !s.scope.VPC().IsIPv6Enabled() {
 			// Safety net because EgressOnlyInternetGateway needs the ID from the ipv6 block.
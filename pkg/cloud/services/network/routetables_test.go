@@ -421,6 +421,131 @@ func TestReconcileRouteTables(t *testing.T) {
 					Return(nil, nil)
 			},
 		},
+		{
+			name: "routes exist, but the ipv6 routes are outdated or missing, replaces and creates them",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-routetables",
+					InternetGatewayID: aws.String("igw-01"),
+					IPv6: &infrav1.IPv6{
+						EgressOnlyInternetGatewayID: aws.String("eigw-01"),
+						CidrBlock:                   "2001:db8:1234::/56",
+						PoolID:                      "my-pool",
+					},
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-routetables-private",
+						IsPublic:         false,
+						IsIPv6:           true,
+						IPv6CidrBlock:    "2001:db8:1234:1::/64",
+						AvailabilityZone: "us-east-1a",
+					},
+					infrav1.SubnetSpec{
+						ID:               "subnet-routetables-public",
+						IsPublic:         true,
+						IsIPv6:           true,
+						IPv6CidrBlock:    "2001:db8:1234:2::/64",
+						NatGatewayID:     aws.String("nat-01"),
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("route-table-private"),
+								Associations: []*ec2.RouteTableAssociation{
+									{
+										SubnetId: aws.String("subnet-routetables-private"),
+									},
+								},
+								Routes: []*ec2.Route{
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										NatGatewayId:         aws.String("nat-01"),
+									},
+									{
+										DestinationIpv6CidrBlock:    aws.String("::/0"),
+										EgressOnlyInternetGatewayId: aws.String("outdated-eigw-01"),
+									},
+								},
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("common"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("test-cluster-rt-private-us-east-1a"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+							{
+								RouteTableId: aws.String("route-table-public"),
+								Associations: []*ec2.RouteTableAssociation{
+									{
+										SubnetId: aws.String("subnet-routetables-public"),
+									},
+								},
+								Routes: []*ec2.Route{
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										GatewayId:            aws.String("igw-01"),
+									},
+								},
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("common"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("test-cluster-rt-public-us-east-1a"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.ReplaceRouteWithContext(context.TODO(), gomock.Eq(
+					&ec2.ReplaceRouteInput{
+						DestinationIpv6CidrBlock:    aws.String("::/0"),
+						RouteTableId:                aws.String("route-table-private"),
+						EgressOnlyInternetGatewayId: aws.String("eigw-01"),
+					},
+				)).
+					Return(nil, nil)
+
+				m.CreateRouteWithContext(context.TODO(), gomock.Eq(&ec2.CreateRouteInput{
+					DestinationIpv6CidrBlock: aws.String("::/0"),
+					GatewayId:                aws.String("igw-01"),
+					RouteTableId:             aws.String("route-table-public"),
+				})).
+					Return(&ec2.CreateRouteOutput{}, nil)
+			},
+		},
 		{
 			name: "extra routes exist, do nothing",
 			input: &infrav1.NetworkSpec{
@@ -559,6 +684,81 @@ func TestReconcileRouteTables(t *testing.T) {
 			},
 			err: errors.New(`failed to create route in route table "rt-1"`),
 		},
+		{
+			name: "subnet with an existing route table, associated with another route table, is associated with the existing route table",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-routetables",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:                   "subnet-routetables-private",
+						IsPublic:             false,
+						AvailabilityZone:     "us-east-1a",
+						ExistingRouteTableID: "rtb-shared",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeRouteTablesInput{
+					Filters: []*ec2.Filter{
+						{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-routetables"})},
+						{Name: aws.String("association.subnet-id"), Values: aws.StringSlice([]string{"subnet-routetables-private"})},
+					},
+				})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rt-1"),
+								Associations: []*ec2.RouteTableAssociation{
+									{SubnetId: aws.String("subnet-routetables-private"), RouteTableAssociationId: aws.String("rtbassoc-1")},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.ReplaceRouteTableAssociationWithContext(context.TODO(), gomock.Eq(&ec2.ReplaceRouteTableAssociationInput{
+					AssociationId: aws.String("rtbassoc-1"),
+					RouteTableId:  aws.String("rtb-shared"),
+				})).
+					Return(&ec2.ReplaceRouteTableAssociationOutput{}, nil)
+			},
+		},
+		{
+			name: "subnet with an existing route table is associated with it without creating routes",
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: "vpc-routetables",
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:                   "subnet-routetables-private",
+						IsPublic:             false,
+						AvailabilityZone:     "us-east-1a",
+						ExistingRouteTableID: "rtb-shared",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil).Times(2)
+
+				m.AssociateRouteTableWithContext(context.TODO(), gomock.Eq(&ec2.AssociateRouteTableInput{
+					RouteTableId: aws.String("rtb-shared"),
+					SubnetId:     aws.String("subnet-routetables-private"),
+				})).
+					Return(&ec2.AssociateRouteTableOutput{}, nil)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
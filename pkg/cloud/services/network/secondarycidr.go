@@ -19,8 +19,10 @@ package network
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
@@ -29,9 +31,12 @@ func isVPCPresent(vpcs *ec2.DescribeVpcsOutput) bool {
 	return vpcs != nil && len(vpcs.Vpcs) > 0
 }
 
+// associateSecondaryCidrs associates the secondary CIDR blocks of the spec with the VPC. For a managed VPC,
+// secondary CIDR blocks which were removed from the spec are disassociated as well.
 func (s *Service) associateSecondaryCidrs() error {
 	secondaryCidrBlocks := s.scope.AllSecondaryCidrBlocks()
-	if len(secondaryCidrBlocks) == 0 {
+	managed := !s.scope.VPC().IsUnmanaged(s.scope.Name())
+	if len(secondaryCidrBlocks) == 0 && !managed {
 		return nil
 	}
 
@@ -46,8 +51,6 @@ func (s *Service) associateSecondaryCidrs() error {
 		return errors.Errorf("failed to associateSecondaryCidr as there are no VPCs present")
 	}
 
-	// We currently only *add* associations. Here, we do not reconcile exactly against the provided list
-	// (i.e. disassociate what isn't listed in the spec).
 	existingAssociations := vpcs.Vpcs[0].CidrBlockAssociationSet
 	for _, desiredCidrBlock := range secondaryCidrBlocks {
 		found := false
@@ -75,6 +78,33 @@ func (s *Service) associateSecondaryCidrs() error {
 		record.Eventf(s.scope.InfraCluster(), "SuccessfulAssociateSecondaryCidr", "Associated secondary CIDR %q with VPC %q", desiredCidrBlock.IPv4CidrBlock, *out.CidrBlockAssociation.AssociationId)
 	}
 
+	if !managed {
+		return nil
+	}
+
+	// Disassociate the CIDR blocks which were removed from the spec. This fails as long as subnets still use them.
+	desiredCidrBlocks := sets.New[string]()
+	for _, desiredCidrBlock := range secondaryCidrBlocks {
+		desiredCidrBlocks.Insert(desiredCidrBlock.IPv4CidrBlock)
+	}
+	for _, existing := range existingAssociations {
+		cidrBlock := aws.StringValue(existing.CidrBlock)
+		if cidrBlock == aws.StringValue(vpcs.Vpcs[0].CidrBlock) || desiredCidrBlocks.Has(cidrBlock) {
+			continue
+		}
+		if existing.CidrBlockState == nil || aws.StringValue(existing.CidrBlockState.State) != ec2.VpcCidrBlockStateCodeAssociated {
+			continue
+		}
+
+		if _, err := s.EC2Client.DisassociateVpcCidrBlockWithContext(context.TODO(), &ec2.DisassociateVpcCidrBlockInput{
+			AssociationId: existing.AssociationId,
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDisassociateSecondaryCidr", "Failed disassociating secondary CIDR %q from VPC %v", cidrBlock, err)
+			return err
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDisassociateSecondaryCidr", "Disassociated secondary CIDR %q from VPC %q", cidrBlock, s.scope.VPC().ID)
+	}
+
 	return nil
 }
 
@@ -67,6 +67,7 @@ func TestServiceAssociateSecondaryCidr(t *testing.T) {
 	tests := []struct {
 		name                                    string
 		fillAWSManagedControlPlaneSecondaryCIDR bool
+		managedVPC                              bool
 		networkSecondaryCIDRBlocks              []infrav1.VpcCidrBlock
 		expect                                  func(m *mocks.MockEC2APIMockRecorder)
 		wantErr                                 bool
@@ -200,6 +201,38 @@ func TestServiceAssociateSecondaryCidr(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "Should disassociate secondary CIDR blocks removed from the spec of a managed VPC",
+			managedVPC: true,
+			networkSecondaryCIDRBlocks: []infrav1.VpcCidrBlock{
+				{
+					IPv4CidrBlock: "10.1.0.0/16",
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				associated := &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeAssociated)}
+				m.DescribeVpcsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcsInput{})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{
+						{
+							CidrBlock: aws.String("10.0.0.0/16"),
+							CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+								{AssociationId: aws.String("association-id-primary"), CidrBlock: aws.String("10.0.0.0/16"), CidrBlockState: associated},
+								{AssociationId: aws.String("association-id-desired"), CidrBlock: aws.String("10.1.0.0/16"), CidrBlockState: associated},
+								{AssociationId: aws.String("association-id-removed"), CidrBlock: aws.String("10.2.0.0/16"), CidrBlockState: associated},
+								{
+									AssociationId:  aws.String("association-id-disassociating"),
+									CidrBlock:      aws.String("10.3.0.0/16"),
+									CidrBlockState: &ec2.VpcCidrBlockState{State: aws.String(ec2.VpcCidrBlockStateCodeDisassociating)},
+								},
+							},
+						},
+					}}, nil)
+				m.DisassociateVpcCidrBlockWithContext(context.TODO(), gomock.Eq(&ec2.DisassociateVpcCidrBlockInput{
+					AssociationId: aws.String("association-id-removed"),
+				})).Return(&ec2.DisassociateVpcCidrBlockOutput{}, nil)
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -218,6 +251,11 @@ func TestServiceAssociateSecondaryCidr(t *testing.T) {
 				mcpScope.ControlPlane.Spec.SecondaryCidrBlock = nil
 			}
 			mcpScope.ControlPlane.Spec.NetworkSpec.VPC.SecondaryCidrBlocks = tt.networkSecondaryCIDRBlocks
+			if tt.managedVPC {
+				mcpScope.ControlPlane.Spec.NetworkSpec.VPC.Tags = infrav1.Tags{
+					infrav1.ClusterTagKey(mcpScope.Name()): string(infrav1.ResourceLifecycleOwned),
+				}
+			}
 
 			s := NewService(mcpScope)
 			s.EC2Client = ec2Mock
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"slices"
 	"sort"
 	"strings"
 
@@ -58,7 +59,7 @@ func (s *Service) reconcileSubnets() error {
 		existing infrav1.Subnets
 	)
 
-	unmanagedVPC := s.scope.VPC().IsUnmanaged(s.scope.Name())
+	unmanagedVPC := s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.ExternallyManagedNetwork()
 
 	if len(subnets) == 0 {
 		if unmanagedVPC {
@@ -85,6 +86,12 @@ func (s *Service) reconcileSubnets() error {
 		}
 	}
 
+	// Resolve the availability zone IDs of the subnets to the zone names used by the account.
+	if err := s.resolveZoneIDs(subnets); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedResolveZoneID", "Failed resolving availability zone IDs of subnets: %v", err)
+		return errors.Wrap(err, "failed to resolve availability zone IDs of subnets")
+	}
+
 	// Describe subnets in the vpc.
 	if existing, err = s.describeVpcSubnets(); err != nil {
 		return err
@@ -96,12 +103,15 @@ func (s *Service) reconcileSubnets() error {
 			return err
 		}
 
-		zones, err := s.getAvailableZones()
+		zones, err := s.getSubnetZones()
 		if err != nil {
 			return err
 		}
 
 		for i, sub := range subnetCIDRs {
+			if i >= len(zones) {
+				break
+			}
 			secondarySub := infrav1.SubnetSpec{
 				ID:               fmt.Sprintf("%s-subnet-%s-%s", s.scope.Name(), infrav1.SecondarySubnetTagValue, zones[i]),
 				CidrBlock:        sub.String(),
@@ -118,6 +128,12 @@ func (s *Service) reconcileSubnets() error {
 		}
 	}
 
+	podSubnets, err := s.getPodSubnets(subnets, existing)
+	if err != nil {
+		return err
+	}
+	subnets = append(subnets, podSubnets...)
+
 	for i := range subnets {
 		sub := &subnets[i]
 		existingSubnet := existing.FindEqual(sub)
@@ -130,16 +146,44 @@ func (s *Service) reconcileSubnets() error {
 
 			// Make sure tags are up-to-date.
 			subnetTags := sub.Tags
+			// The existing route table to use and the load balancer role are only known from the spec.
+			existingRouteTableID := sub.ExistingRouteTableID
+			loadBalancerRole := sub.LoadBalancerRole
 
 			// Update subnet spec with the existing subnet details
 			existingSubnet.DeepCopyInto(sub)
+			sub.ExistingRouteTableID = existingRouteTableID
+			sub.LoadBalancerRole = loadBalancerRole
+
+			// The subnets dedicated to the pods are marked as secondary subnets, which keeps the nodes out of them
+			// and generates the ENIConfigs of the VPC CNI.
+			podSubnet := slices.Contains(s.scope.PodSubnetIDs(), sub.GetResourceID())
+			if podSubnet {
+				if sub.Tags == nil {
+					sub.Tags = infrav1.Tags{}
+				}
+				sub.Tags[infrav1.NameAWSSubnetAssociation] = infrav1.SecondarySubnetTagValue
+			}
+
+			// The subnets of an externally managed network are never tagged.
+			if s.scope.ExternallyManagedNetwork() {
+				continue
+			}
 
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
-				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.GetResourceID(), existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags, existingSubnet.IsEdge())
+				buildParams := s.getSubnetTagParams(unmanagedVPC, existingSubnet.GetResourceID(), existingSubnet.IsPublic, existingSubnet.AvailabilityZone, subnetTags, existingSubnet.IsEdge(), loadBalancerRole)
+				if podSubnet {
+					buildParams.Additional[infrav1.NameAWSSubnetAssociation] = infrav1.SecondarySubnetTagValue
+				}
 				tagsBuilder := tags.New(&buildParams, tags.WithEC2(s.EC2Client))
 				if err := tagsBuilder.Ensure(existingSubnet.Tags); err != nil {
 					return false, err
 				}
+				if loadBalancerRole != nil {
+					if err := s.deleteStaleLoadBalancerTags(existingSubnet, buildParams.Additional); err != nil {
+						return false, err
+					}
+				}
 				return true, nil
 			}, awserrors.SubnetNotFound); err != nil {
 				if !unmanagedVPC {
@@ -206,6 +250,80 @@ func (s *Service) reconcileSubnets() error {
 	return nil
 }
 
+// getPodSubnets returns the subnets dedicated to the pods that are missing from the subnets of the spec.
+// The pod subnets must exist in the VPC, and there can be at most one per availability zone, since the
+// ENIConfig of the VPC CNI for the nodes in a zone is named after the zone.
+func (s *Service) getPodSubnets(subnets infrav1.Subnets, existing infrav1.Subnets) (infrav1.Subnets, error) {
+	podSubnets := infrav1.Subnets{}
+	zones := make(map[string]string)
+	for _, id := range s.scope.PodSubnetIDs() {
+		existingSubnet := existing.FindByID(id)
+		if existingSubnet == nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedMatchPodSubnet", "Failed to find pod subnet %q in vpc %q", id, s.scope.VPC().ID)
+			return nil, errors.Errorf("pod subnet %q does not exist in vpc %q", id, s.scope.VPC().ID)
+		}
+
+		if other, ok := zones[existingSubnet.AvailabilityZone]; ok {
+			record.Warnf(s.scope.InfraCluster(), "FailedPodSubnetZone", "Pod subnets %q and %q are in the same availability zone %q", other, id, existingSubnet.AvailabilityZone)
+			return nil, errors.Errorf("pod subnets %q and %q are in the same availability zone %q", other, id, existingSubnet.AvailabilityZone)
+		}
+		zones[existingSubnet.AvailabilityZone] = id
+
+		if subnets.FindByID(id) == nil {
+			podSubnets = append(podSubnets, infrav1.SubnetSpec{
+				ID:         id,
+				ResourceID: id,
+				CidrBlock:  existingSubnet.CidrBlock,
+			})
+		}
+	}
+	return podSubnets, nil
+}
+
+// resolveZoneIDs sets the availability zone of the subnets specifying an availability zone ID.
+// Zone names are mapped to physical zones independently for each account, so the name of a zone
+// is looked up by its ID in the account of the cluster.
+func (s *Service) resolveZoneIDs(subnets infrav1.Subnets) error {
+	zoneIDs := []string{}
+	for _, sn := range subnets {
+		if sn.AvailabilityZoneID != "" && !slices.Contains(zoneIDs, sn.AvailabilityZoneID) {
+			zoneIDs = append(zoneIDs, sn.AvailabilityZoneID)
+		}
+	}
+	if len(zoneIDs) == 0 {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
+		Filters: []*ec2.Filter{filter.EC2.ZoneIDs(zoneIDs...)},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe availability zones")
+	}
+	zoneNames := make(map[string]string, len(out.AvailabilityZones))
+	for _, zone := range out.AvailabilityZones {
+		zoneNames[aws.StringValue(zone.ZoneId)] = aws.StringValue(zone.ZoneName)
+	}
+
+	for i := range subnets {
+		sn := &subnets[i]
+		if sn.AvailabilityZoneID == "" {
+			continue
+		}
+		zoneName, ok := zoneNames[sn.AvailabilityZoneID]
+		if !ok {
+			return errors.Errorf("availability zone ID %q of subnet %q does not exist in region %q", sn.AvailabilityZoneID, sn.ID, s.scope.Region())
+		}
+		if sn.AvailabilityZone != "" && sn.AvailabilityZone != zoneName {
+			return errors.Errorf("availability zone %q of subnet %q does not match availability zone ID %q, which is %q in this account",
+				sn.AvailabilityZone, sn.ID, sn.AvailabilityZoneID, zoneName)
+		}
+		sn.AvailabilityZone = zoneName
+	}
+
+	return nil
+}
+
 func (s *Service) retrieveZoneInfo(zoneNames []string) ([]*ec2.AvailabilityZone, error) {
 	zones, err := s.EC2Client.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
 		ZoneNames: aws.StringSlice(zoneNames),
@@ -235,12 +353,41 @@ func (s *Service) reconcileZoneInfo(subnets infrav1.Subnets) error {
 	return nil
 }
 
-func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
+// getSubnetZones returns the available zones of the region to create subnets in, restricted to
+// the availability zones of the VPC spec if any.
+func (s *Service) getSubnetZones() ([]string, error) {
 	zones, err := s.getAvailableZones()
 	if err != nil {
 		return nil, err
 	}
 
+	allowed := s.scope.VPC().AvailabilityZones
+	if len(allowed) == 0 {
+		return zones, nil
+	}
+
+	selected := make([]string, 0, len(allowed))
+	for _, zone := range zones {
+		if slices.Contains(allowed, zone) {
+			selected = append(selected, zone)
+		}
+	}
+	for _, zone := range allowed {
+		if !slices.Contains(selected, zone) {
+			record.Warnf(s.scope.InfraCluster(), "FailedSelectAvailabilityZones", "Availability zone %q is not available in region %s", zone, s.scope.Region())
+			return nil, errors.Errorf("availability zone %q is not available in region %s", zone, s.scope.Region())
+		}
+	}
+
+	return selected, nil
+}
+
+func (s *Service) getDefaultSubnets() (infrav1.Subnets, error) {
+	zones, err := s.getSubnetZones()
+	if err != nil {
+		return nil, err
+	}
+
 	maxZones := defaultMaxNumAZs
 	if s.scope.VPC().AvailabilityZoneUsageLimit != nil {
 		maxZones = *s.scope.VPC().AvailabilityZoneUsageLimit
@@ -393,10 +540,12 @@ func (s *Service) describeVpcSubnets() (infrav1.Subnets, error) {
 	// We also look for a tag indicating that a particular subnet should be public, to try and determine whether a managed VPC's subnet should have such a route, but does not.
 	for _, ec2sn := range sns.Subnets {
 		spec := infrav1.SubnetSpec{
-			ID:               *ec2sn.SubnetId,
-			ResourceID:       *ec2sn.SubnetId,
-			AvailabilityZone: *ec2sn.AvailabilityZone,
-			Tags:             converters.TagsToMap(ec2sn.Tags),
+			ID:                 *ec2sn.SubnetId,
+			ResourceID:         *ec2sn.SubnetId,
+			AvailabilityZone:   *ec2sn.AvailabilityZone,
+			AvailabilityZoneID: aws.StringValue(ec2sn.AvailabilityZoneId),
+			OutpostARN:         ec2sn.OutpostArn,
+			Tags:               converters.TagsToMap(ec2sn.Tags),
 		}
 		// For IPv6 subnets, both, ipv4 and 6 have to be defined so pods can have ipv6 cidr ranges.
 		spec.CidrBlock = aws.StringValue(ec2sn.CidrBlock)
@@ -500,7 +649,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		TagSpecifications: []*ec2.TagSpecification{
 			tags.BuildParamsToTagSpecification(
 				ec2.ResourceTypeSubnet,
-				s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags, sn.IsEdge()),
+				s.getSubnetTagParams(false, services.TemporaryResourceID, sn.IsPublic, sn.AvailabilityZone, sn.Tags, sn.IsEdge(), sn.LoadBalancerRole),
 			),
 		},
 	}
@@ -508,6 +657,9 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		input.Ipv6CidrBlock = aws.String(sn.IPv6CidrBlock)
 		sn.IsIPv6 = true
 	}
+	if sn.IsOutpost() {
+		input.OutpostArn = sn.OutpostARN
+	}
 	out, err := s.EC2Client.CreateSubnetWithContext(context.TODO(), input)
 	if err != nil {
 		record.Warnf(s.scope.InfraCluster(), "FailedCreateSubnet", "Failed creating new managed Subnet %v", err)
@@ -590,6 +742,7 @@ func (s *Service) createSubnet(sn *infrav1.SubnetSpec) (*infrav1.SubnetSpec, err
 		AvailabilityZone: *out.Subnet.AvailabilityZone,
 		CidrBlock:        *out.Subnet.CidrBlock, // TODO: this will panic in case of IPv6 only subnets...
 		IsPublic:         sn.IsPublic,
+		OutpostARN:       out.Subnet.OutpostArn,
 		Tags:             sn.Tags,
 	}
 	for _, set := range out.Subnet.Ipv6CidrBlockAssociationSet {
@@ -623,7 +776,7 @@ func (s *Service) deleteSubnet(id string) error {
 	return nil
 }
 
-func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool, zone string, manualTags infrav1.Tags, isEdge bool) infrav1.BuildParams {
+func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool, zone string, manualTags infrav1.Tags, isEdge bool, loadBalancerRole *infrav1.SubnetLoadBalancerRole) infrav1.BuildParams {
 	var role string
 	additionalTags := make(map[string]string)
 
@@ -632,15 +785,11 @@ func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool,
 
 		if public {
 			role = infrav1.PublicRoleTagValue
-			// Edge subnets should not have ELB tags to be selected by CCM to create load balancers.
-			if !isEdge {
-				additionalTags[externalLoadBalancerTag] = "1"
-			}
 		} else {
 			role = infrav1.PrivateRoleTagValue
-			if !isEdge {
-				additionalTags[internalLoadBalancerTag] = "1"
-			}
+		}
+		if tag := getLoadBalancerTag(loadBalancerRole, public, isEdge); tag != "" {
+			additionalTags[tag] = "1"
 		}
 		// Add tag needed for Service type=LoadBalancer
 		if unmanagedVPC {
@@ -648,6 +797,11 @@ func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool,
 		} else {
 			additionalTags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.KubernetesClusterName())] = string(infrav1.ResourceLifecycleOwned)
 		}
+	} else if loadBalancerRole != nil {
+		// The load balancer role requested for a subnet of an unmanaged VPC is tagged regardless.
+		if tag := getLoadBalancerTag(loadBalancerRole, public, isEdge); tag != "" {
+			additionalTags[tag] = "1"
+		}
 	}
 
 	if !unmanagedVPC {
@@ -682,3 +836,54 @@ func (s *Service) getSubnetTagParams(unmanagedVPC bool, id string, public bool,
 		Additional: additionalTags,
 	}
 }
+
+// getLoadBalancerTag returns the tag selecting the subnet for the load balancers of its role, or an empty string.
+func getLoadBalancerTag(loadBalancerRole *infrav1.SubnetLoadBalancerRole, public bool, isEdge bool) string {
+	role := infrav1.SubnetLoadBalancerRoleNone
+	switch {
+	case loadBalancerRole != nil:
+		role = *loadBalancerRole
+	case isEdge:
+		// Edge subnets should not have ELB tags to be selected by CCM to create load balancers.
+	case public:
+		role = infrav1.SubnetLoadBalancerRoleExternal
+	default:
+		role = infrav1.SubnetLoadBalancerRoleInternal
+	}
+
+	switch role {
+	case infrav1.SubnetLoadBalancerRoleExternal:
+		return externalLoadBalancerTag
+	case infrav1.SubnetLoadBalancerRoleInternal:
+		return internalLoadBalancerTag
+	default:
+		return ""
+	}
+}
+
+// deleteStaleLoadBalancerTags deletes the load balancer tags of the subnet which its load balancer role excludes.
+func (s *Service) deleteStaleLoadBalancerTags(subnet *infrav1.SubnetSpec, want infrav1.Tags) error {
+	stale := []*ec2.Tag{}
+	for _, tag := range []string{externalLoadBalancerTag, internalLoadBalancerTag} {
+		if _, ok := subnet.Tags[tag]; !ok {
+			continue
+		}
+		if _, ok := want[tag]; !ok {
+			stale = append(stale, &ec2.Tag{Key: aws.String(tag)})
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	if _, err := s.EC2Client.DeleteTagsWithContext(context.TODO(), &ec2.DeleteTagsInput{
+		Resources: aws.StringSlice([]string{subnet.GetResourceID()}),
+		Tags:      stale,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete load balancer tags of subnet %q", subnet.GetResourceID())
+	}
+	for _, tag := range stale {
+		delete(subnet.Tags, aws.StringValue(tag.Key))
+	}
+	return nil
+}
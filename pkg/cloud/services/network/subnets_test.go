@@ -183,6 +183,191 @@ func TestReconcileSubnets(t *testing.T) {
 			},
 			tagUnmanagedNetworkResources: false,
 		},
+		{
+			name: "Unmanaged VPC, disable TagUnmanagedNetworkResources, 2 existing subnets with load balancer roles in spec, should tag the roles",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						LoadBalancerRole: &infrav1.SubnetLoadBalancerRoleInternal,
+					},
+					{
+						ID:               "subnet-2",
+						LoadBalancerRole: &infrav1.SubnetLoadBalancerRoleNone,
+					},
+				},
+			}).WithTagUnmanagedNetworkResources(false),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.10.0/24"),
+								Tags: []*ec2.Tag{
+									{Key: aws.String("kubernetes.io/role/elb"), Value: aws.String("1")},
+								},
+							},
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-2"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.20.0/24"),
+								Tags: []*ec2.Tag{
+									{Key: aws.String("kubernetes.io/role/internal-elb"), Value: aws.String("1")},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTagsWithContext(context.TODO(), gomock.Eq(&ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-1"}),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("kubernetes.io/role/internal-elb"), Value: aws.String("1")},
+					},
+				})).Return(&ec2.CreateTagsOutput{}, nil)
+				m.DeleteTagsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-1"}),
+					Tags:      []*ec2.Tag{{Key: aws.String("kubernetes.io/role/elb")}},
+				})).Return(&ec2.DeleteTagsOutput{}, nil)
+				m.DeleteTagsWithContext(context.TODO(), gomock.Eq(&ec2.DeleteTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-2"}),
+					Tags:      []*ec2.Tag{{Key: aws.String("kubernetes.io/role/internal-elb")}},
+				})).Return(&ec2.DeleteTagsOutput{}, nil)
+
+				stubMockDescribeAvailabilityZonesWithContextCustomZones(m, []*ec2.AvailabilityZone{
+					{ZoneName: aws.String("us-east-1a")},
+				}).AnyTimes()
+			},
+		},
+		{
+			name: "With ManagedControlPlaneScope, Unmanaged VPC, 1 subnet in spec and 1 pod subnet, should tag the pod subnet as secondary",
+			input: NewManagedControlPlaneScope().
+				WithNetwork(&infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: subnetsVPCID,
+					},
+					Subnets: []infrav1.SubnetSpec{
+						{
+							ID: "subnet-1",
+						},
+					},
+				}).
+				WithPodSubnetIDs("subnet-pods-1"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.10.0/24"),
+							},
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-pods-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("100.64.0.0/16"),
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				m.CreateTagsWithContext(context.TODO(), gomock.Eq(&ec2.CreateTagsInput{
+					Resources: aws.StringSlice([]string{"subnet-pods-1"}),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/association"), Value: aws.String("secondary")},
+					},
+				})).Return(&ec2.CreateTagsOutput{}, nil)
+
+				stubMockDescribeAvailabilityZonesWithContextCustomZones(m, []*ec2.AvailabilityZone{
+					{ZoneName: aws.String("us-east-1a")},
+				}).AnyTimes()
+			},
+			optionalExpectSubnets: infrav1.Subnets{
+				{
+					ID:               "subnet-1",
+					ResourceID:       "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.10.0/24",
+					Tags:             infrav1.Tags{},
+				},
+				{
+					ID:               "subnet-pods-1",
+					ResourceID:       "subnet-pods-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "100.64.0.0/16",
+					Tags: infrav1.Tags{
+						infrav1.NameAWSSubnetAssociation: infrav1.SecondarySubnetTagValue,
+					},
+				},
+			},
+		},
+		{
+			name: "With ManagedControlPlaneScope, Unmanaged VPC, 2 pod subnets in the same availability zone, should fail",
+			input: NewManagedControlPlaneScope().
+				WithNetwork(&infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: subnetsVPCID,
+					},
+					Subnets: []infrav1.SubnetSpec{
+						{
+							ID: "subnet-1",
+						},
+					},
+				}).
+				WithPodSubnetIDs("subnet-pods-1", "subnet-pods-2"),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.10.0/24"),
+							},
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-pods-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("100.64.0.0/17"),
+							},
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-pods-2"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("100.64.128.0/17"),
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+			},
+			errorExpected:        true,
+			errorMessageExpected: `pod subnets "subnet-pods-1" and "subnet-pods-2" are in the same availability zone "us-east-1a"`,
+		},
 		{
 			name: "Unmanaged VPC, 2 existing subnets in vpc, 2 subnet in spec, subnets match, with routes, should succeed",
 			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
@@ -2236,26 +2421,352 @@ func TestReconcileSubnets(t *testing.T) {
 				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).
 					After(zone1PublicSubnet)
 
-				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
-					MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
-						Value: aws.Bool(true),
-					},
-					SubnetId: aws.String("subnet-1"),
-				}).
-					Return(&ec2.ModifySubnetAttributeOutput{}, nil).
-					After(zone1PublicSubnet)
+				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+					MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-1"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil).
+					After(zone1PublicSubnet)
+
+				zone1PrivateSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					CidrBlock:        aws.String("10.0.128.0/17"),
+					AvailabilityZone: aws.String("us-east-1b"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-private-us-east-1b"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/internal-elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("private"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:               aws.String(subnetsVPCID),
+							SubnetId:            aws.String("subnet-2"),
+							CidrBlock:           aws.String("10.0.128.0/17"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						},
+					}, nil).
+					After(zone1PublicSubnet)
+
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).
+					After(zone1PrivateSubnet)
+			},
+		},
+		{
+			name: "Managed VPC, no existing subnets exist, three az's, one availability zone in spec, expect one private and one public from default in that zone",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					CidrBlock:         defaultVPCCidr,
+					AvailabilityZones: []string{"us-east-1b"},
+				},
+				Subnets: []infrav1.SubnetSpec{},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeCall := m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("pending"), aws.String("available")},
+						},
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []*string{aws.String(subnetsVPCID)},
+						},
+					},
+				})).
+					Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(),
+					gomock.Eq(&ec2.DescribeNatGatewaysInput{
+						Filter: []*ec2.Filter{
+							{
+								Name:   aws.String("vpc-id"),
+								Values: []*string{aws.String(subnetsVPCID)},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("pending"), aws.String("available")},
+							},
+						},
+					}),
+					gomock.Any()).Return(nil)
+
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1a"),
+								ZoneType: aws.String("availability-zone"),
+							},
+							{
+								ZoneName: aws.String("us-east-1b"),
+								ZoneType: aws.String("availability-zone"),
+							},
+							{
+								ZoneName: aws.String("us-east-1c"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil).AnyTimes()
+
+				zone1PublicSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					CidrBlock:        aws.String("10.0.0.0/17"),
+					AvailabilityZone: aws.String("us-east-1b"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-public-us-east-1b"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("public"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:               aws.String(subnetsVPCID),
+							SubnetId:            aws.String("subnet-1"),
+							CidrBlock:           aws.String("10.0.0.0/17"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						},
+					}, nil).
+					After(describeCall)
+
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).
+					After(zone1PublicSubnet)
+
+				m.ModifySubnetAttributeWithContext(context.TODO(), &ec2.ModifySubnetAttributeInput{
+					MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{
+						Value: aws.Bool(true),
+					},
+					SubnetId: aws.String("subnet-1"),
+				}).
+					Return(&ec2.ModifySubnetAttributeOutput{}, nil).
+					After(zone1PublicSubnet)
+
+				zone1PrivateSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					CidrBlock:        aws.String("10.0.128.0/17"),
+					AvailabilityZone: aws.String("us-east-1b"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-private-us-east-1b"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/internal-elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("private"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:               aws.String(subnetsVPCID),
+							SubnetId:            aws.String("subnet-2"),
+							CidrBlock:           aws.String("10.0.128.0/17"),
+							AvailabilityZone:    aws.String("us-east-1b"),
+							MapPublicIpOnLaunch: aws.Bool(false),
+						},
+					}, nil).
+					After(zone1PublicSubnet)
+
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).
+					After(zone1PrivateSubnet)
+			},
+		},
+		{
+			name: "Managed VPC, no existing subnets exist, availability zone in spec is not available in the region, expect error",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+					CidrBlock:         defaultVPCCidr,
+					AvailabilityZones: []string{"us-east-1b", "us-east-1d"},
+				},
+				Subnets: []infrav1.SubnetSpec{},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1a"),
+								ZoneType: aws.String("availability-zone"),
+							},
+							{
+								ZoneName: aws.String("us-east-1b"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil)
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Managed VPC, existing public subnet, 2 subnets in spec, should create 1 subnet",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.0.0/17",
+						IsPublic:         true,
+					},
+					{
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.0.128.0/17",
+						IsPublic:         false,
+					},
+				},
+			}),
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeSubnetsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("state"),
+							Values: []*string{aws.String("pending"), aws.String("available")},
+						},
+						{
+							Name:   aws.String("vpc-id"),
+							Values: []*string{aws.String(subnetsVPCID)},
+						},
+					},
+				})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.0.0/17"),
+								Tags: []*ec2.Tag{
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+									{
+										Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+										Value: aws.String("public"),
+									},
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("test-cluster-subnet-public"),
+									},
+									{
+										Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+										Value: aws.String("owned"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(),
+					gomock.Eq(&ec2.DescribeNatGatewaysInput{
+						Filter: []*ec2.Filter{
+							{
+								Name:   aws.String("vpc-id"),
+								Values: []*string{aws.String(subnetsVPCID)},
+							},
+							{
+								Name:   aws.String("state"),
+								Values: []*string{aws.String("pending"), aws.String("available")},
+							},
+						},
+					}),
+					gomock.Any()).Return(nil)
 
-				zone1PrivateSubnet := m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+				m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
 					VpcId:            aws.String(subnetsVPCID),
 					CidrBlock:        aws.String("10.0.128.0/17"),
-					AvailabilityZone: aws.String("us-east-1b"),
+					AvailabilityZone: aws.String("us-east-1a"),
 					TagSpecifications: []*ec2.TagSpecification{
 						{
 							ResourceType: aws.String("subnet"),
 							Tags: []*ec2.Tag{
 								{
 									Key:   aws.String("Name"),
-									Value: aws.String("test-cluster-subnet-private-us-east-1b"),
+									Value: aws.String("test-cluster-subnet-private-us-east-1a"),
 								},
 								{
 									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
@@ -2279,21 +2790,32 @@ func TestReconcileSubnets(t *testing.T) {
 				})).
 					Return(&ec2.CreateSubnetOutput{
 						Subnet: &ec2.Subnet{
-							VpcId:               aws.String(subnetsVPCID),
-							SubnetId:            aws.String("subnet-2"),
-							CidrBlock:           aws.String("10.0.128.0/17"),
-							AvailabilityZone:    aws.String("us-east-1b"),
-							MapPublicIpOnLaunch: aws.Bool(false),
+							VpcId:            aws.String(subnetsVPCID),
+							SubnetId:         aws.String("subnet-2"),
+							CidrBlock:        aws.String("10.0.128.0/17"),
+							AvailabilityZone: aws.String("us-east-1a"),
 						},
-					}, nil).
-					After(zone1PublicSubnet)
+					}, nil)
 
-				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any()).
-					After(zone1PrivateSubnet)
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any())
+
+				// Public subnet
+				m.CreateTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
+					Return(nil, nil)
+
+				m.DescribeAvailabilityZonesWithContext(context.TODO(), gomock.Any()).
+					Return(&ec2.DescribeAvailabilityZonesOutput{
+						AvailabilityZones: []*ec2.AvailabilityZone{
+							{
+								ZoneName: aws.String("us-east-1a"),
+								ZoneType: aws.String("availability-zone"),
+							},
+						},
+					}, nil).AnyTimes()
 			},
 		},
 		{
-			name: "Managed VPC, existing public subnet, 2 subnets in spec, should create 1 subnet",
+			name: "Managed VPC, existing public subnet, 3 subnets in spec with one on an outpost, should create 2 subnets",
 			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
 				VPC: infrav1.VPCSpec{
 					ID: subnetsVPCID,
@@ -2313,6 +2835,12 @@ func TestReconcileSubnets(t *testing.T) {
 						CidrBlock:        "10.0.128.0/17",
 						IsPublic:         false,
 					},
+					{
+						AvailabilityZone: "us-east-1a",
+						CidrBlock:        "10.1.0.0/24",
+						IsPublic:         false,
+						OutpostARN:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+					},
 				},
 			}),
 			expect: func(m *mocks.MockEC2APIMockRecorder) {
@@ -2418,6 +2946,51 @@ func TestReconcileSubnets(t *testing.T) {
 
 				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any())
 
+				m.CreateSubnetWithContext(context.TODO(), gomock.Eq(&ec2.CreateSubnetInput{
+					VpcId:            aws.String(subnetsVPCID),
+					CidrBlock:        aws.String("10.1.0.0/24"),
+					AvailabilityZone: aws.String("us-east-1a"),
+					OutpostArn:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+					TagSpecifications: []*ec2.TagSpecification{
+						{
+							ResourceType: aws.String("subnet"),
+							Tags: []*ec2.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("test-cluster-subnet-private-us-east-1a"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("kubernetes.io/role/internal-elb"),
+									Value: aws.String("1"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"),
+									Value: aws.String("owned"),
+								},
+								{
+									Key:   aws.String("sigs.k8s.io/cluster-api-provider-aws/role"),
+									Value: aws.String("private"),
+								},
+							},
+						},
+					},
+				})).
+					Return(&ec2.CreateSubnetOutput{
+						Subnet: &ec2.Subnet{
+							VpcId:            aws.String(subnetsVPCID),
+							SubnetId:         aws.String("subnet-3"),
+							CidrBlock:        aws.String("10.1.0.0/24"),
+							AvailabilityZone: aws.String("us-east-1a"),
+							OutpostArn:       aws.String("arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"),
+						},
+					}, nil)
+
+				m.WaitUntilSubnetAvailableWithContext(context.TODO(), gomock.Any())
+
 				// Public subnet
 				m.CreateTagsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateTagsInput{})).
 					Return(nil, nil)
@@ -2752,6 +3325,102 @@ func TestReconcileSubnets(t *testing.T) {
 					}, nil).AnyTimes()
 			},
 		},
+		{
+			name: "Managed VPC, existing public and private subnets, private subnet using an existing route table, the existing route table is kept",
+			input: NewClusterScope().WithNetwork(&infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID: subnetsVPCID,
+					Tags: infrav1.Tags{
+						infrav1.ClusterTagKey("test-cluster"): "owned",
+					},
+				},
+				Subnets: []infrav1.SubnetSpec{
+					{
+						ID:               "subnet-1",
+						AvailabilityZone: "us-east-1a",
+						IsPublic:         true,
+					},
+					{
+						ID:                   "subnet-2",
+						AvailabilityZone:     "us-east-1a",
+						ExistingRouteTableID: "rtb-shared",
+					},
+				},
+			}),
+			optionalExpectSubnets: infrav1.Subnets{
+				{
+					ID:               "subnet-1",
+					ResourceID:       "subnet-1",
+					AvailabilityZone: "us-east-1a",
+					CidrBlock:        "10.0.0.0/17",
+					IsPublic:         true,
+					Tags: infrav1.Tags{
+						"Name":                                      "test-cluster-subnet-public-us-east-1a",
+						"kubernetes.io/cluster/test-cluster":        "owned",
+						"kubernetes.io/role/elb":                    "1",
+						infrav1.ClusterTagKey("test-cluster"):       "owned",
+						"sigs.k8s.io/cluster-api-provider-aws/role": "public",
+					},
+				},
+				{
+					ID:                   "subnet-2",
+					ResourceID:           "subnet-2",
+					AvailabilityZone:     "us-east-1a",
+					CidrBlock:            "10.0.128.0/17",
+					ExistingRouteTableID: "rtb-shared",
+					Tags: infrav1.Tags{
+						"Name":                                      "test-cluster-subnet-private-us-east-1a",
+						"kubernetes.io/cluster/test-cluster":        "owned",
+						"kubernetes.io/role/internal-elb":           "1",
+						infrav1.ClusterTagKey("test-cluster"):       "owned",
+						"sigs.k8s.io/cluster-api-provider-aws/role": "private",
+					},
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSubnetsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSubnetsInput{})).
+					Return(&ec2.DescribeSubnetsOutput{
+						Subnets: []*ec2.Subnet{
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-1"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.0.0/17"),
+								Tags: []*ec2.Tag{
+									{Key: aws.String("Name"), Value: aws.String("test-cluster-subnet-public-us-east-1a")},
+									{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String("owned")},
+									{Key: aws.String("kubernetes.io/role/elb"), Value: aws.String("1")},
+									{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+									{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("public")},
+								},
+							},
+							{
+								VpcId:            aws.String(subnetsVPCID),
+								SubnetId:         aws.String("subnet-2"),
+								AvailabilityZone: aws.String("us-east-1a"),
+								CidrBlock:        aws.String("10.0.128.0/17"),
+								Tags: []*ec2.Tag{
+									{Key: aws.String("Name"), Value: aws.String("test-cluster-subnet-private-us-east-1a")},
+									{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String("owned")},
+									{Key: aws.String("kubernetes.io/role/internal-elb"), Value: aws.String("1")},
+									{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Value: aws.String("owned")},
+									{Key: aws.String("sigs.k8s.io/cluster-api-provider-aws/role"), Value: aws.String("private")},
+								},
+							},
+						},
+					}, nil)
+
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{}, nil)
+
+				m.DescribeNatGatewaysPagesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeNatGatewaysInput{}), gomock.Any()).
+					Return(nil)
+
+				stubMockDescribeAvailabilityZonesWithContextCustomZones(m, []*ec2.AvailabilityZone{
+					{ZoneName: aws.String("us-east-1a")},
+				}).AnyTimes()
+			},
+		},
 		{
 			name: "With ManagedControlPlaneScope, Managed VPC, no existing subnets exist, two az's, expect two private and two public from default, created with tag including eksClusterName not a name of Cluster resource",
 			input: NewManagedControlPlaneScope().
@@ -4425,6 +5094,14 @@ func (b *ManagedControlPlaneScopeBuilder) WithEKSClusterName(name string) *Manag
 	return b
 }
 
+func (b *ManagedControlPlaneScopeBuilder) WithPodSubnetIDs(ids ...string) *ManagedControlPlaneScopeBuilder {
+	b.customizers = append(b.customizers, func(p *scope.ManagedControlPlaneScopeParams) {
+		p.ControlPlane.Spec.VpcCni.PodSubnetIDs = ids
+	})
+
+	return b
+}
+
 func (b *ManagedControlPlaneScopeBuilder) Build() (scope.NetworkScope, error) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -4681,6 +5358,106 @@ func TestService_retrieveZoneInfo(t *testing.T) {
 	}
 }
 
+func TestService_resolveZoneIDs(t *testing.T) {
+	describeZoneIDs := func(m *mocks.MockEC2APIMockRecorder, zoneIDs ...string) *gomock.Call {
+		return m.DescribeAvailabilityZonesWithContext(context.TODO(), &ec2.DescribeAvailabilityZonesInput{
+			Filters: []*ec2.Filter{{Name: aws.String("zone-id"), Values: aws.StringSlice(zoneIDs)}},
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		subnets        infrav1.Subnets
+		expect         func(m *mocks.MockEC2APIMockRecorder)
+		wantZones      []string
+		wantErrMessage string
+	}{
+		{
+			name:      "subnets without zone IDs",
+			subnets:   infrav1.Subnets{{ID: "subnet-1", AvailabilityZone: "us-east-1a"}},
+			wantZones: []string{"us-east-1a"},
+		},
+		{
+			name: "resolves zone IDs to the zone names of the account",
+			subnets: infrav1.Subnets{
+				{ID: "subnet-private", AvailabilityZoneID: "use1-az1"},
+				{ID: "subnet-public", AvailabilityZoneID: "use1-az1", IsPublic: true},
+				{ID: "subnet-other", AvailabilityZoneID: "use1-az4", AvailabilityZone: "us-east-1c"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeZoneIDs(m, "use1-az1", "use1-az4").Return(&ec2.DescribeAvailabilityZonesOutput{
+					AvailabilityZones: []*ec2.AvailabilityZone{
+						{ZoneId: aws.String("use1-az1"), ZoneName: aws.String("us-east-1d")},
+						{ZoneId: aws.String("use1-az4"), ZoneName: aws.String("us-east-1c")},
+					},
+				}, nil)
+			},
+			wantZones: []string{"us-east-1d", "us-east-1d", "us-east-1c"},
+		},
+		{
+			name:    "zone ID does not exist in the region",
+			subnets: infrav1.Subnets{{ID: "subnet-1", AvailabilityZoneID: "usw2-az1"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeZoneIDs(m, "usw2-az1").Return(&ec2.DescribeAvailabilityZonesOutput{}, nil)
+			},
+			wantErrMessage: `availability zone ID "usw2-az1" of subnet "subnet-1" does not exist in region "us-east-1"`,
+		},
+		{
+			name:    "zone name does not match the zone ID",
+			subnets: infrav1.Subnets{{ID: "subnet-1", AvailabilityZoneID: "use1-az1", AvailabilityZone: "us-east-1a"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeZoneIDs(m, "use1-az1").Return(&ec2.DescribeAvailabilityZonesOutput{
+					AvailabilityZones: []*ec2.AvailabilityZone{{ZoneId: aws.String("use1-az1"), ZoneName: aws.String("us-east-1d")}},
+				}, nil)
+			},
+			wantErrMessage: `availability zone "us-east-1a" of subnet "subnet-1" does not match availability zone ID "use1-az1", which is "us-east-1d" in this account`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec:       infrav1.AWSClusterSpec{Region: "us-east-1"},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			err = s.resolveZoneIDs(tc.subnets)
+			if tc.wantErrMessage != "" {
+				g.Expect(err).To(MatchError(tc.wantErrMessage))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			zones := []string{}
+			for _, sn := range tc.subnets {
+				zones = append(zones, sn.AvailabilityZone)
+			}
+			g.Expect(zones).To(Equal(tc.wantZones))
+		})
+	}
+}
+
 // Stub functions to generate AWS mock calls.
 
 func stubGetTags(prefix, role, zone string, isEdge bool) []*ec2.Tag {
@@ -4885,3 +5662,52 @@ func stubMockDescribeAvailabilityZonesWithContextCustomZones(m *mocks.MockEC2API
 			AvailabilityZones: zones,
 		}, nil).AnyTimes()
 }
+
+func TestGetLoadBalancerTag(t *testing.T) {
+	testCases := []struct {
+		name             string
+		loadBalancerRole *infrav1.SubnetLoadBalancerRole
+		public           bool
+		isEdge           bool
+		expected         string
+	}{
+		{
+			name:     "public subnet",
+			public:   true,
+			expected: "kubernetes.io/role/elb",
+		},
+		{
+			name:     "private subnet",
+			expected: "kubernetes.io/role/internal-elb",
+		},
+		{
+			name:   "edge subnet",
+			public: true,
+			isEdge: true,
+		},
+		{
+			name:             "public subnet with the internal role",
+			loadBalancerRole: &infrav1.SubnetLoadBalancerRoleInternal,
+			public:           true,
+			expected:         "kubernetes.io/role/internal-elb",
+		},
+		{
+			name:             "edge subnet with the external role",
+			loadBalancerRole: &infrav1.SubnetLoadBalancerRoleExternal,
+			public:           true,
+			isEdge:           true,
+			expected:         "kubernetes.io/role/elb",
+		},
+		{
+			name:             "private subnet with no role",
+			loadBalancerRole: &infrav1.SubnetLoadBalancerRoleNone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(getLoadBalancerTag(tc.loadBalancerRole, tc.public, tc.isEdge)).To(Equal(tc.expected))
+		})
+	}
+}
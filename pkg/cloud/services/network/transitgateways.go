@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// transitGatewayAttachmentStates are the states of an attachment which isn't deleted or being deleted.
+var transitGatewayAttachmentStates = []string{
+	ec2.TransitGatewayAttachmentStateInitiating,
+	ec2.TransitGatewayAttachmentStateInitiatingRequest,
+	ec2.TransitGatewayAttachmentStatePendingAcceptance,
+	ec2.TransitGatewayAttachmentStatePending,
+	ec2.TransitGatewayAttachmentStateAvailable,
+	ec2.TransitGatewayAttachmentStateModifying,
+}
+
+func (s *Service) reconcileTransitGatewayAttachment() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping transit gateway attachment reconcile in unmanaged mode")
+		return nil
+	}
+
+	spec := s.scope.TransitGatewayAttachment()
+	if spec == nil {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling transit gateway attachment", "transit-gateway-id", spec.TransitGatewayID)
+
+	subnetIDs := s.getTransitGatewayAttachmentSubnetIDs()
+	if len(subnetIDs) == 0 {
+		return errors.Errorf("failed to find subnets for the attachment to transit gateway %q", spec.TransitGatewayID)
+	}
+
+	attachments, err := s.describeTransitGatewayAttachments()
+	if err != nil {
+		return err
+	}
+
+	var attachment *ec2.TransitGatewayVpcAttachment
+	for _, a := range attachments {
+		if aws.StringValue(a.TransitGatewayId) == spec.TransitGatewayID {
+			attachment = a
+			break
+		}
+	}
+
+	if attachment == nil {
+		attachment, err = s.createTransitGatewayAttachment(spec.TransitGatewayID, subnetIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The subnets of the attachment can only be modified, and the routes only be created, once the
+	// transit gateway accepted the attachment.
+	if state := aws.StringValue(attachment.State); state != ec2.TransitGatewayAttachmentStateAvailable {
+		s.scope.Debug("Transit gateway attachment is not available yet", "transit-gateway-attachment-id", aws.StringValue(attachment.TransitGatewayAttachmentId), "state", state)
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition, infrav1.TransitGatewayAttachmentNotAvailableReason, clusterv1.ConditionSeverityInfo,
+			"Transit gateway attachment %q is in state %q", aws.StringValue(attachment.TransitGatewayAttachmentId), state)
+		return nil
+	}
+
+	if err := s.updateTransitGatewayAttachmentSubnets(attachment, subnetIDs); err != nil {
+		return err
+	}
+
+	if err := s.reconcileTransitGatewayRoutes(spec.TransitGatewayID, spec.RouteCidrBlocks); err != nil {
+		return err
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition)
+	return nil
+}
+
+// getTransitGatewayAttachmentSubnetIDs returns the subnets of the spec, or a private subnet of each
+// availability zone. A transit gateway attachment supports a single subnet per availability zone.
+func (s *Service) getTransitGatewayAttachmentSubnetIDs() []string {
+	if ids := s.scope.TransitGatewayAttachment().SubnetIDs; len(ids) > 0 {
+		return ids
+	}
+
+	zones := sets.New[string]()
+	subnetIDs := []string{}
+	for _, subnet := range s.scope.Subnets().FilterPrivate().FilterNonCni() {
+		if subnet.IsEdge() || zones.Has(subnet.AvailabilityZone) || subnet.GetResourceID() == "" {
+			continue
+		}
+		zones.Insert(subnet.AvailabilityZone)
+		subnetIDs = append(subnetIDs, subnet.GetResourceID())
+	}
+	return subnetIDs
+}
+
+func (s *Service) createTransitGatewayAttachment(transitGatewayID string, subnetIDs []string) (*ec2.TransitGatewayVpcAttachment, error) {
+	out, err := s.EC2Client.CreateTransitGatewayVpcAttachmentWithContext(context.TODO(), &ec2.CreateTransitGatewayVpcAttachmentInput{
+		TransitGatewayId: aws.String(transitGatewayID),
+		VpcId:            aws.String(s.scope.VPC().ID),
+		SubnetIds:        aws.StringSlice(subnetIDs),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeTransitGatewayAttachment, s.getTransitGatewayAttachmentTagParams(services.TemporaryResourceID)),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateTransitGatewayAttachment", "Failed to attach VPC %q to transit gateway %q: %v", s.scope.VPC().ID, transitGatewayID, err)
+		return nil, errors.Wrapf(err, "failed to attach vpc %q to transit gateway %q", s.scope.VPC().ID, transitGatewayID)
+	}
+
+	id := aws.StringValue(out.TransitGatewayVpcAttachment.TransitGatewayAttachmentId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateTransitGatewayAttachment", "Created Transit Gateway Attachment %q of VPC %q to transit gateway %q", id, s.scope.VPC().ID, transitGatewayID)
+	s.scope.Info("Created transit gateway attachment", "transit-gateway-attachment-id", id, "transit-gateway-id", transitGatewayID)
+
+	return out.TransitGatewayVpcAttachment, nil
+}
+
+func (s *Service) updateTransitGatewayAttachmentSubnets(attachment *ec2.TransitGatewayVpcAttachment, subnetIDs []string) error {
+	current := sets.New(aws.StringValueSlice(attachment.SubnetIds)...)
+	desired := sets.New(subnetIDs...)
+	if current.Equal(desired) {
+		return nil
+	}
+
+	id := aws.StringValue(attachment.TransitGatewayAttachmentId)
+	if _, err := s.EC2Client.ModifyTransitGatewayVpcAttachmentWithContext(context.TODO(), &ec2.ModifyTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+		AddSubnetIds:               aws.StringSlice(sets.List(desired.Difference(current))),
+		RemoveSubnetIds:            aws.StringSlice(sets.List(current.Difference(desired))),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedModifyTransitGatewayAttachment", "Failed to update the subnets of Transit Gateway Attachment %q: %v", id, err)
+		return errors.Wrapf(err, "failed to update the subnets of transit gateway attachment %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulModifyTransitGatewayAttachment", "Updated the subnets of Transit Gateway Attachment %q", id)
+	return nil
+}
+
+// reconcileTransitGatewayRoutes routes the CIDR blocks through the transit gateway from the route tables of the
+// private subnets, and deletes the routes through it to other CIDR blocks. The routes through other transit gateways
+// are left alone.
+func (s *Service) reconcileTransitGatewayRoutes(transitGatewayID string, cidrBlocks []string) error {
+	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return err
+	}
+
+	routeTables := sets.New[string]()
+	for _, subnet := range s.scope.Subnets().FilterPrivate() {
+		rt, ok := subnetRouteMap[subnet.GetResourceID()]
+		// The routes of existing route tables are managed by their owner.
+		if subnet.IsEdge() || subnet.ExistingRouteTableID != "" || !ok || routeTables.Has(aws.StringValue(rt.RouteTableId)) {
+			continue
+		}
+		routeTables.Insert(aws.StringValue(rt.RouteTableId))
+
+		missing := sets.New(cidrBlocks...)
+		for _, route := range rt.Routes {
+			if aws.StringValue(route.TransitGatewayId) != transitGatewayID || route.DestinationCidrBlock == nil {
+				continue
+			}
+			if missing.Has(*route.DestinationCidrBlock) {
+				missing.Delete(*route.DestinationCidrBlock)
+				continue
+			}
+			if err := s.deleteTransitGatewayRoute(rt, route); err != nil {
+				return err
+			}
+		}
+
+		for _, cidrBlock := range sets.List(missing) {
+			if err := s.createTransitGatewayRoute(rt, transitGatewayID, cidrBlock); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) createTransitGatewayRoute(rt *ec2.RouteTable, transitGatewayID, cidrBlock string) error {
+	if _, err := s.EC2Client.CreateRouteWithContext(context.TODO(), &ec2.CreateRouteInput{
+		RouteTableId:         rt.RouteTableId,
+		DestinationCidrBlock: aws.String(cidrBlock),
+		TransitGatewayId:     aws.String(transitGatewayID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateRoute", "Failed to create route to %q through transit gateway %q for RouteTable %q: %v", cidrBlock, transitGatewayID, *rt.RouteTableId, err)
+		return errors.Wrapf(err, "failed to create route to %q through transit gateway %q in route table %q", cidrBlock, transitGatewayID, *rt.RouteTableId)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateRoute", "Created route to %q through transit gateway %q for RouteTable %q", cidrBlock, transitGatewayID, *rt.RouteTableId)
+	return nil
+}
+
+func (s *Service) deleteTransitGatewayRoute(rt *ec2.RouteTable, route *ec2.Route) error {
+	if _, err := s.EC2Client.DeleteRouteWithContext(context.TODO(), &ec2.DeleteRouteInput{
+		RouteTableId:         rt.RouteTableId,
+		DestinationCidrBlock: route.DestinationCidrBlock,
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteRoute", "Failed to delete route to %q through transit gateway %q for RouteTable %q: %v", *route.DestinationCidrBlock, *route.TransitGatewayId, *rt.RouteTableId, err)
+		return errors.Wrapf(err, "failed to delete route to %q in route table %q", *route.DestinationCidrBlock, *rt.RouteTableId)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteRoute", "Deleted route to %q through transit gateway %q for RouteTable %q", *route.DestinationCidrBlock, *route.TransitGatewayId, *rt.RouteTableId)
+	return nil
+}
+
+// deleteTransitGatewayAttachments detaches the VPC from the transit gateway, and waits for the attachment to be
+// deleted as the network interfaces of the attachment prevent the deletion of the subnets.
+func (s *Service) deleteTransitGatewayAttachments() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
+		s.scope.Trace("Skipping transit gateway attachment deletion in unmanaged mode")
+		return nil
+	}
+
+	attachments, err := s.describeTransitGatewayAttachments()
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		id := aws.StringValue(attachment.TransitGatewayAttachmentId)
+		if _, err := s.EC2Client.DeleteTransitGatewayVpcAttachmentWithContext(context.TODO(), &ec2.DeleteTransitGatewayVpcAttachmentInput{
+			TransitGatewayAttachmentId: attachment.TransitGatewayAttachmentId,
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteTransitGatewayAttachment", "Failed to delete Transit Gateway Attachment %q: %v", id, err)
+			return errors.Wrapf(err, "failed to delete transit gateway attachment %q", id)
+		}
+
+		if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+			out, err := s.EC2Client.DescribeTransitGatewayVpcAttachmentsWithContext(context.TODO(), &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+				TransitGatewayAttachmentIds: []*string{attachment.TransitGatewayAttachmentId},
+			})
+			if err != nil {
+				return false, err
+			}
+			for _, a := range out.TransitGatewayVpcAttachments {
+				if aws.StringValue(a.State) != ec2.TransitGatewayAttachmentStateDeleted {
+					return false, nil
+				}
+			}
+			return true, nil
+		}); err != nil {
+			record.Warnf(s.scope.InfraCluster(), "FailedDeleteTransitGatewayAttachment", "Failed to wait for the deletion of Transit Gateway Attachment %q: %v", id, err)
+			return errors.Wrapf(err, "failed to wait for the deletion of transit gateway attachment %q", id)
+		}
+
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteTransitGatewayAttachment", "Deleted Transit Gateway Attachment %q of VPC %q", id, s.scope.VPC().ID)
+		s.scope.Info("Deleted transit gateway attachment", "transit-gateway-attachment-id", id)
+	}
+
+	return nil
+}
+
+// describeTransitGatewayAttachments returns the transit gateway attachments of the VPC owned by the cluster.
+func (s *Service) describeTransitGatewayAttachments() ([]*ec2.TransitGatewayVpcAttachment, error) {
+	out, err := s.EC2Client.DescribeTransitGatewayVpcAttachmentsWithContext(context.TODO(), &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.VPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.TransitGatewayAttachmentStates(transitGatewayAttachmentStates...),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeTransitGatewayAttachments", "Failed to describe transit gateway attachments in vpc %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe transit gateway attachments in vpc %q", s.scope.VPC().ID)
+	}
+
+	return out.TransitGatewayVpcAttachments, nil
+}
+
+func (s *Service) getTransitGatewayAttachmentTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-tgw-attachment", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
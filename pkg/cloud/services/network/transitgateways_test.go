@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileTransitGatewayAttachment(t *testing.T) {
+	describeAttachments := func(m *mocks.MockEC2APIMockRecorder, attachments ...*ec2.TransitGatewayVpcAttachment) {
+		m.DescribeTransitGatewayVpcAttachmentsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{"vpc-tgw"})},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+				{Name: aws.String("state"), Values: aws.StringSlice(transitGatewayAttachmentStates)},
+			},
+		})).Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{TransitGatewayVpcAttachments: attachments}, nil)
+	}
+	subnets := infrav1.Subnets{
+		{ResourceID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+		{ResourceID: "subnet-private-1a-2", AvailabilityZone: "us-east-1a"},
+		{ResourceID: "subnet-public-1a", AvailabilityZone: "us-east-1a", IsPublic: true},
+		{ResourceID: "subnet-private-1b", AvailabilityZone: "us-east-1b"},
+	}
+
+	testCases := []struct {
+		name              string
+		attachment        *infrav1.TransitGatewayAttachmentSpec
+		subnets           infrav1.Subnets
+		expect            func(m *mocks.MockEC2APIMockRecorder)
+		expectedCondition *clusterv1.Condition
+	}{
+		{
+			name:   "no attachment",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:       "creates an attachment in a private subnet of each availability zone",
+			attachment: &infrav1.TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1", RouteCidrBlocks: []string{"10.0.0.0/8"}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeAttachments(m)
+				m.CreateTransitGatewayVpcAttachmentWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateTransitGatewayVpcAttachmentInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateTransitGatewayVpcAttachmentInput, _ ...interface{}) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.TransitGatewayId).To(Equal(aws.String("tgw-1")))
+						g.Expect(input.VpcId).To(Equal(aws.String("vpc-tgw")))
+						g.Expect(input.SubnetIds).To(Equal(aws.StringSlice([]string{"subnet-private-1a", "subnet-private-1b"})))
+						g.Expect(input.TagSpecifications[0].ResourceType).To(Equal(aws.String(ec2.ResourceTypeTransitGatewayAttachment)))
+						return &ec2.CreateTransitGatewayVpcAttachmentOutput{
+							TransitGatewayVpcAttachment: &ec2.TransitGatewayVpcAttachment{
+								TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+								TransitGatewayId:           aws.String("tgw-1"),
+								State:                      aws.String(ec2.TransitGatewayAttachmentStatePending),
+							},
+						}, nil
+					})
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:     infrav1.TransitGatewayAttachmentReadyCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   infrav1.TransitGatewayAttachmentNotAvailableReason,
+				Message:  `Transit gateway attachment "tgw-attach-1" is in state "pending"`,
+			},
+		},
+		{
+			name: "updates the subnets and routes of an available attachment",
+			attachment: &infrav1.TransitGatewayAttachmentSpec{
+				TransitGatewayID: "tgw-1",
+				SubnetIDs:        []string{"subnet-private-1a-2", "subnet-private-1b"},
+				RouteCidrBlocks:  []string{"10.0.0.0/8", "192.168.0.0/16"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeAttachments(m, &ec2.TransitGatewayVpcAttachment{
+					TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+					TransitGatewayId:           aws.String("tgw-1"),
+					SubnetIds:                  aws.StringSlice([]string{"subnet-private-1a", "subnet-private-1b"}),
+					State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+				})
+				m.ModifyTransitGatewayVpcAttachmentWithContext(context.TODO(), gomock.Eq(&ec2.ModifyTransitGatewayVpcAttachmentInput{
+					TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+					AddSubnetIds:               aws.StringSlice([]string{"subnet-private-1a-2"}),
+					RemoveSubnetIds:            aws.StringSlice([]string{"subnet-private-1a"}),
+				})).Return(&ec2.ModifyTransitGatewayVpcAttachmentOutput{}, nil)
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rtb-private-1a"),
+								Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-private-1a")}},
+								Routes: []*ec2.Route{
+									{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1a")},
+									{DestinationCidrBlock: aws.String("10.0.0.0/8"), TransitGatewayId: aws.String("tgw-1")},
+									{DestinationCidrBlock: aws.String("172.16.0.0/12"), TransitGatewayId: aws.String("tgw-1")},
+									{DestinationCidrBlock: aws.String("100.64.0.0/10"), TransitGatewayId: aws.String("tgw-other")},
+								},
+							},
+							{
+								RouteTableId: aws.String("rtb-public-1a"),
+								Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public-1a")}},
+								Routes: []*ec2.Route{
+									{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")},
+								},
+							},
+						},
+					}, nil)
+				m.DeleteRouteWithContext(context.TODO(), gomock.Eq(&ec2.DeleteRouteInput{
+					RouteTableId:         aws.String("rtb-private-1a"),
+					DestinationCidrBlock: aws.String("172.16.0.0/12"),
+				})).Return(&ec2.DeleteRouteOutput{}, nil)
+				m.CreateRouteWithContext(context.TODO(), gomock.Eq(&ec2.CreateRouteInput{
+					RouteTableId:         aws.String("rtb-private-1a"),
+					DestinationCidrBlock: aws.String("192.168.0.0/16"),
+					TransitGatewayId:     aws.String("tgw-1"),
+				})).Return(&ec2.CreateRouteOutput{}, nil)
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:   infrav1.TransitGatewayAttachmentReadyCondition,
+				Status: "True",
+			},
+		},
+		{
+			name: "does not modify the routes of existing route tables",
+			attachment: &infrav1.TransitGatewayAttachmentSpec{
+				TransitGatewayID: "tgw-1",
+				RouteCidrBlocks:  []string{"10.0.0.0/8"},
+			},
+			subnets: infrav1.Subnets{
+				{ResourceID: "subnet-private-1a", AvailabilityZone: "us-east-1a", ExistingRouteTableID: "rtb-existing"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeAttachments(m, &ec2.TransitGatewayVpcAttachment{
+					TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+					TransitGatewayId:           aws.String("tgw-1"),
+					SubnetIds:                  aws.StringSlice([]string{"subnet-private-1a"}),
+					State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+				})
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).
+					Return(&ec2.DescribeRouteTablesOutput{
+						RouteTables: []*ec2.RouteTable{
+							{
+								RouteTableId: aws.String("rtb-existing"),
+								Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-private-1a")}},
+								Routes: []*ec2.Route{
+									{DestinationCidrBlock: aws.String("172.16.0.0/12"), TransitGatewayId: aws.String("tgw-1")},
+								},
+							},
+						},
+					}, nil)
+				m.CreateRouteWithContext(gomock.Any(), gomock.Any()).Times(0)
+				m.DeleteRouteWithContext(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:   infrav1.TransitGatewayAttachmentReadyCondition,
+				Status: "True",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scheme := runtime.NewScheme()
+			_ = infrav1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			if tc.subnets == nil {
+				tc.subnets = subnets
+			}
+			scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test"},
+					Spec: infrav1.AWSClusterSpec{
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{
+								ID: "vpc-tgw",
+								Tags: infrav1.Tags{
+									infrav1.ClusterTagKey("test-cluster"): "owned",
+								},
+							},
+							Subnets:                  tc.subnets,
+							TransitGatewayAttachment: tc.attachment,
+						},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileTransitGatewayAttachment()).To(Succeed())
+
+			condition := conditions.Get(scope.InfraCluster(), infrav1.TransitGatewayAttachmentReadyCondition)
+			if tc.expectedCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedCondition.Status))
+			g.Expect(condition.Severity).To(Equal(tc.expectedCondition.Severity))
+			g.Expect(condition.Reason).To(Equal(tc.expectedCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tc.expectedCondition.Message))
+		})
+	}
+}
+
+func TestDeleteTransitGatewayAttachments(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	scope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-tgw",
+						Tags: infrav1.Tags{
+							infrav1.ClusterTagKey("test-cluster"): "owned",
+						},
+					},
+					TransitGatewayAttachment: &infrav1.TransitGatewayAttachmentSpec{TransitGatewayID: "tgw-1"},
+				},
+			},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	gomock.InOrder(
+		ec2Mock.EXPECT().DescribeTransitGatewayVpcAttachmentsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeTransitGatewayVpcAttachmentsInput{})).
+			Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+				TransitGatewayVpcAttachments: []*ec2.TransitGatewayVpcAttachment{
+					{TransitGatewayAttachmentId: aws.String("tgw-attach-1"), State: aws.String(ec2.TransitGatewayAttachmentStateAvailable)},
+				},
+			}, nil),
+		ec2Mock.EXPECT().DeleteTransitGatewayVpcAttachmentWithContext(context.TODO(), gomock.Eq(&ec2.DeleteTransitGatewayVpcAttachmentInput{
+			TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+		})).Return(&ec2.DeleteTransitGatewayVpcAttachmentOutput{}, nil),
+		ec2Mock.EXPECT().DescribeTransitGatewayVpcAttachmentsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeTransitGatewayVpcAttachmentsInput{
+			TransitGatewayAttachmentIds: aws.StringSlice([]string{"tgw-attach-1"}),
+		})).Return(&ec2.DescribeTransitGatewayVpcAttachmentsOutput{
+			TransitGatewayVpcAttachments: []*ec2.TransitGatewayVpcAttachment{
+				{TransitGatewayAttachmentId: aws.String("tgw-attach-1"), State: aws.String(ec2.TransitGatewayAttachmentStateDeleted)},
+			},
+		}, nil),
+	)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.deleteTransitGatewayAttachments()).To(Succeed())
+}
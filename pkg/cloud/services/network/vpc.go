@@ -19,12 +19,14 @@ package network
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
@@ -38,6 +40,9 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// interfaceVPCEndpointServices are the services nodes in private subnets need to reach to join an EKS cluster.
+var interfaceVPCEndpointServices = []string{"ec2", "ecr.api", "ecr.dkr", "elasticloadbalancing", "sts"}
+
 const (
 	defaultVPCCidr             = "10.0.0.0/16"
 	defaultIpamV4NetmaskLength = 16
@@ -63,7 +68,7 @@ func (s *Service) reconcileVPC() error {
 		}
 
 		// If VPC is unmanaged, return early.
-		if vpc.IsUnmanaged(s.scope.Name()) {
+		if vpc.IsUnmanaged(s.scope.Name()) || s.scope.ExternallyManagedNetwork() {
 			s.scope.Debug("Working on unmanaged VPC", "vpc-id", vpc.ID)
 			if err := s.scope.PatchObject(); err != nil {
 				return errors.Wrap(err, "failed to patch unmanaged VPC fields")
@@ -177,26 +182,102 @@ func (s *Service) describeVPCEndpoints(filters ...*ec2.Filter) ([]*ec2.VpcEndpoi
 }
 
 // reconcileVPCEndpoints registers the AWS endpoints for the services that need to be enabled
-// in the VPC routing tables. If the VPC is unmanaged, this is a no-op.
-// For more information, see: https://docs.aws.amazon.com/vpc/latest/privatelink/gateway-endpoints.html
+// in the VPC. If the VPC is unmanaged, this is a no-op.
 func (s *Service) reconcileVPCEndpoints() error {
 	// If the VPC is unmanaged or not yet populated, return early.
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
 		return nil
 	}
 
-	// Gather all services that need to be enabled.
+	services := s.gatewayVPCEndpointServices().Union(sets.KeySet(s.interfaceVPCEndpoints()))
+	if services.Len() == 0 {
+		// The condition is only set if endpoints were configured, which are deleted once removed.
+		if !conditions.Has(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition) {
+			return nil
+		}
+		if err := s.deleteStaleVPCEndpoints(services); err != nil {
+			return err
+		}
+		conditions.Delete(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition)
+		return nil
+	}
+
+	if err := s.reconcileGatewayVPCEndpoints(); err != nil {
+		return err
+	}
+	if err := s.reconcileInterfaceVPCEndpoints(); err != nil {
+		return err
+	}
+	if err := s.deleteStaleVPCEndpoints(services); err != nil {
+		return err
+	}
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcEndpointsReadyCondition)
+	return nil
+}
+
+// deleteStaleVPCEndpoints deletes the endpoints owned by the cluster whose service is not in the given set.
+func (s *Service) deleteStaleVPCEndpoints(services sets.Set[string]) error {
+	endpoints, err := s.describeVPCEndpoints(filter.EC2.ClusterOwned(s.scope.Name()))
+	if err != nil {
+		return errors.Wrap(err, "failed to describe vpc endpoints")
+	}
+
+	ids := []*string{}
+	stale := []string{}
+	for _, ep := range endpoints {
+		// The case of the states isn't consistent across the API.
+		state := aws.StringValue(ep.State)
+		switch {
+		case aws.StringValue(ep.VpcEndpointId) == "",
+			services.Has(aws.StringValue(ep.ServiceName)),
+			strings.EqualFold(state, ec2.StateDeleting),
+			strings.EqualFold(state, ec2.StateDeleted):
+			continue
+		}
+		ids = append(ids, ep.VpcEndpointId)
+		stale = append(stale, aws.StringValue(ep.ServiceName))
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := s.EC2Client.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: ids,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to delete vpc endpoints %+v", aws.StringValueSlice(ids))
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteVPCEndpoint", "Deleted VPC endpoints for services %v", stale)
+	return nil
+}
+
+// gatewayVPCEndpointServices returns the services of the gateway endpoints to create.
+func (s *Service) gatewayVPCEndpointServices() sets.Set[string] {
 	services := sets.New[string]()
-	if s.scope.Bucket() != nil {
-		services.Insert(fmt.Sprintf("com.amazonaws.%s.s3", s.scope.Region()))
+	if s.scope.Bucket() != nil || s.scope.CreateVPCEndpoints() {
+		services.Insert(s.vpcEndpointServiceName("s3"))
+	}
+	for _, endpoint := range s.scope.VPCEndpoints().FilterGateway() {
+		services.Insert(s.vpcEndpointServiceName(endpoint.Service))
 	}
+	return services
+}
+
+// reconcileGatewayVPCEndpoints registers the AWS gateway endpoints for the services that need
+// to be enabled in the VPC routing tables.
+// For more information, see: https://docs.aws.amazon.com/vpc/latest/privatelink/gateway-endpoints.html
+func (s *Service) reconcileGatewayVPCEndpoints() error {
+	// Gather all services that need to be enabled.
+	services := s.gatewayVPCEndpointServices()
 	if services.Len() == 0 {
 		return nil
 	}
 
-	// Gather the current routes.
+	// Gather the current routes, the existing route tables of subnets are not modified.
 	routeTables := sets.New[string]()
 	for _, rt := range s.scope.Subnets() {
+		if rt.ExistingRouteTableID != "" {
+			continue
+		}
 		if rt.RouteTableID != nil && *rt.RouteTableID != "" {
 			routeTables.Insert(*rt.RouteTableID)
 		}
@@ -270,7 +351,92 @@ func (s *Service) reconcileVPCEndpoints() error {
 	return nil
 }
 
-func (s *Service) deleteVPCEndpoints() error {
+// reconcileInterfaceVPCEndpoints creates the AWS interface endpoints listed in the network spec, and
+// those of the services nodes in private subnets need to join a privately accessible EKS cluster
+// without a NAT gateway. The endpoints are placed in a private subnet of each availability zone.
+// For more information, see: https://docs.aws.amazon.com/eks/latest/userguide/private-clusters.html
+func (s *Service) reconcileInterfaceVPCEndpoints() error {
+	specs := s.interfaceVPCEndpoints()
+	if len(specs) == 0 {
+		return nil
+	}
+
+	// The security group of the endpoints is reconciled before the endpoints.
+	securityGroup, ok := s.scope.SecurityGroups()[infrav1.SecurityGroupVPCEndpoint]
+	if !ok || securityGroup.ID == "" {
+		return errors.New("security group of the interface VPC endpoints not found")
+	}
+
+	// An interface endpoint supports a single subnet per availability zone.
+	zones := sets.New[string]()
+	subnets := []string{}
+	for _, subnet := range s.scope.Subnets().FilterPrivate().FilterNonCni() {
+		if zones.Has(subnet.AvailabilityZone) || subnet.GetResourceID() == "" {
+			continue
+		}
+		zones.Insert(subnet.AvailabilityZone)
+		subnets = append(subnets, subnet.GetResourceID())
+	}
+	if len(subnets) == 0 {
+		return nil
+	}
+
+	services := sets.KeySet(specs)
+
+	endpoints, err := s.describeVPCEndpoints(&ec2.Filter{
+		Name:   aws.String("service-name"),
+		Values: aws.StringSlice(services.UnsortedList()),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to describe vpc endpoints")
+	}
+	for _, ep := range endpoints {
+		services.Delete(aws.StringValue(ep.ServiceName))
+	}
+
+	// Create the missing endpoints.
+	for _, service := range sets.List(services) {
+		spec := specs[service]
+		if _, err := s.EC2Client.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+			VpcId:             aws.String(s.scope.VPC().ID),
+			ServiceName:       aws.String(service),
+			VpcEndpointType:   aws.String(ec2.VpcEndpointTypeInterface),
+			SubnetIds:         aws.StringSlice(subnets),
+			SecurityGroupIds:  aws.StringSlice(append([]string{securityGroup.ID}, spec.AdditionalSecurityGroups...)),
+			PrivateDnsEnabled: aws.Bool(ptr.Deref(spec.PrivateDNSEnabled, true)),
+			TagSpecifications: []*ec2.TagSpecification{
+				tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpcEndpoint, s.getVPCEndpointTagParams()),
+			},
+		}); err != nil {
+			return errors.Wrapf(err, "failed to create vpc endpoint for service %q", service)
+		}
+		record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateVPCEndpoint", "Created new interface VPC endpoint for service %q", service)
+	}
+
+	return nil
+}
+
+// interfaceVPCEndpoints returns the interface endpoints to create by the name of their service.
+func (s *Service) interfaceVPCEndpoints() map[string]infrav1.VPCEndpointSpec {
+	specs := map[string]infrav1.VPCEndpointSpec{}
+	if s.scope.CreateVPCEndpoints() {
+		for _, service := range interfaceVPCEndpointServices {
+			specs[s.vpcEndpointServiceName(service)] = infrav1.VPCEndpointSpec{Service: service}
+		}
+	}
+	for _, endpoint := range s.scope.VPCEndpoints().FilterInterface() {
+		specs[s.vpcEndpointServiceName(endpoint.Service)] = endpoint
+	}
+	return specs
+}
+
+func (s *Service) vpcEndpointServiceName(service string) string {
+	return fmt.Sprintf("com.amazonaws.%s.%s", s.scope.Region(), service)
+}
+
+// DeleteVPCEndpoints deletes the VPC endpoints owned by the cluster. The interface endpoints have to be
+// deleted before the security groups of the cluster, as they use one of them.
+func (s *Service) DeleteVPCEndpoints() error {
 	// If the VPC is unmanaged or not yet populated, return early.
 	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
 		return nil
@@ -369,15 +535,15 @@ func (s *Service) ensureManagedVPCAttributes(vpc *infrav1.VPCSpec) error {
 	return nil
 }
 
-func (s *Service) getIPAMPoolID() (*string, error) {
+func (s *Service) getIPAMPoolID(pool *infrav1.IPAMPool) (*string, error) {
 	input := &ec2.DescribeIpamPoolsInput{}
 
-	if s.scope.VPC().IPAMPool.ID != "" {
-		input.Filters = append(input.Filters, filter.EC2.IPAM(s.scope.VPC().IPAMPool.ID))
+	if pool.ID != "" {
+		input.Filters = append(input.Filters, filter.EC2.IPAM(pool.ID))
 	}
 
-	if s.scope.VPC().IPAMPool.Name != "" {
-		input.Filters = append(input.Filters, filter.EC2.Name(s.scope.VPC().IPAMPool.Name))
+	if pool.Name != "" {
+		input.Filters = append(input.Filters, filter.EC2.Name(pool.Name))
 	}
 
 	output, err := s.EC2Client.DescribeIpamPools(input)
@@ -413,7 +579,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 			input.Ipv6Pool = aws.String(s.scope.VPC().IPv6.PoolID)
 			input.AmazonProvidedIpv6CidrBlock = aws.Bool(false)
 		case s.scope.VPC().IPv6.IPAMPool != nil:
-			ipamPoolID, err := s.getIPAMPoolID()
+			ipamPoolID, err := s.getIPAMPoolID(s.scope.VPC().IPv6.IPAMPool)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get IPAM Pool ID")
 			}
@@ -431,7 +597,7 @@ func (s *Service) createVPC() (*infrav1.VPCSpec, error) {
 
 	// IPv4-specific configuration
 	if s.scope.VPC().IPAMPool != nil {
-		ipamPoolID, err := s.getIPAMPoolID()
+		ipamPoolID, err := s.getIPAMPoolID(s.scope.VPC().IPAMPool)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get IPAM Pool ID")
 		}
@@ -32,10 +32,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func describeVpcAttributeTrue(_ context.Context, input *ec2.DescribeVpcAttributeInput, _ ...request.Option) (*ec2.DescribeVpcAttributeOutput, error) {
@@ -342,6 +344,152 @@ func TestReconcileVPC(t *testing.T) {
 				m.ModifyVpcAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifyVpcAttributeInput{})).Return(&ec2.ModifyVpcAttributeOutput{}, nil).Times(2)
 			},
 		},
+		{
+			name: "Should create a new VPC with a CIDR block allocated from an IPAM pool",
+			input: &infrav1.VPCSpec{
+				AvailabilityZoneUsageLimit: &usageLimit,
+				AvailabilityZoneSelection:  &selection,
+				IPAMPool:                   &infrav1.IPAMPool{ID: "ipam-pool-v4"},
+			},
+			wantErrContaining: nil,
+			want: &infrav1.VPCSpec{
+				ID:        "vpc-new",
+				CidrBlock: "10.1.0.0/16",
+				IPAMPool:  &infrav1.IPAMPool{ID: "ipam-pool-v4", NetmaskLength: 16},
+				Tags: map[string]string{
+					"sigs.k8s.io/cluster-api-provider-aws/role": "common",
+					"Name": "test-cluster-vpc",
+					"sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster": "owned",
+				},
+				AvailabilityZoneUsageLimit: &usageLimit,
+				AvailabilityZoneSelection:  &selection,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVPCByNameCall := m.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("tag:Name"),
+							Values: aws.StringSlice([]string{"test-cluster-vpc"}),
+						},
+					},
+				})).Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{}}, nil)
+				m.DescribeIpamPools(gomock.Eq(&ec2.DescribeIpamPoolsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("ipam-pool-id"),
+							Values: aws.StringSlice([]string{"ipam-pool-v4"}),
+						},
+					},
+				})).Return(&ec2.DescribeIpamPoolsOutput{
+					IpamPools: []*ec2.IpamPool{{IpamPoolId: aws.String("ipam-pool-v4")}},
+				}, nil)
+				m.CreateVpcWithContext(context.TODO(), gomock.Any()).After(describeVPCByNameCall).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateVpcInput, _ ...request.Option) (*ec2.CreateVpcOutput, error) {
+						if aws.StringValue(input.Ipv4IpamPoolId) != "ipam-pool-v4" || aws.Int64Value(input.Ipv4NetmaskLength) != 16 || input.CidrBlock != nil {
+							return nil, errors.Errorf("unexpected input %v", input)
+						}
+						return &ec2.CreateVpcOutput{
+							Vpc: &ec2.Vpc{
+								State:     aws.String("available"),
+								VpcId:     aws.String("vpc-new"),
+								CidrBlock: aws.String("10.1.0.0/16"),
+								Tags:      managedVPCTags,
+							},
+						}, nil
+					})
+
+				m.DescribeVpcAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcAttributeInput{})).
+					DoAndReturn(describeVpcAttributeFalse).MinTimes(1)
+
+				m.ModifyVpcAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifyVpcAttributeInput{})).Return(&ec2.ModifyVpcAttributeOutput{}, nil).Times(2)
+			},
+		},
+		{
+			name: "Should create a new IPv6 VPC with an IPv6 CIDR block allocated from an IPAM pool",
+			input: &infrav1.VPCSpec{
+				AvailabilityZoneUsageLimit: &usageLimit,
+				AvailabilityZoneSelection:  &selection,
+				IPv6: &infrav1.IPv6{
+					IPAMPool: &infrav1.IPAMPool{Name: "ipam-pool-v6"},
+				},
+			},
+			wantErrContaining: nil,
+			want: &infrav1.VPCSpec{
+				ID:        "vpc-new",
+				CidrBlock: "10.0.0.0/16",
+				IPv6: &infrav1.IPv6{
+					CidrBlock: "2001:db8:1234:1a00::/56",
+					PoolID:    "ipam-pool-v6-id",
+				},
+				Tags: map[string]string{
+					"sigs.k8s.io/cluster-api-provider-aws/role": "common",
+					"Name": "test-cluster-vpc",
+					"sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster": "owned",
+				},
+				AvailabilityZoneUsageLimit: &usageLimit,
+				AvailabilityZoneSelection:  &selection,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeVPCByNameCall := m.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("tag:Name"),
+							Values: aws.StringSlice([]string{"test-cluster-vpc"}),
+						},
+					},
+				})).Return(&ec2.DescribeVpcsOutput{Vpcs: []*ec2.Vpc{}}, nil)
+				m.DescribeIpamPools(gomock.Eq(&ec2.DescribeIpamPoolsInput{
+					Filters: []*ec2.Filter{
+						{
+							Name:   aws.String("tag:Name"),
+							Values: aws.StringSlice([]string{"ipam-pool-v6"}),
+						},
+					},
+				})).Return(&ec2.DescribeIpamPoolsOutput{
+					IpamPools: []*ec2.IpamPool{{IpamPoolId: aws.String("ipam-pool-v6-id")}},
+				}, nil)
+				m.CreateVpcWithContext(context.TODO(), gomock.Any()).After(describeVPCByNameCall).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateVpcInput, _ ...request.Option) (*ec2.CreateVpcOutput, error) {
+						if aws.StringValue(input.Ipv6IpamPoolId) != "ipam-pool-v6-id" || aws.Int64Value(input.Ipv6NetmaskLength) != 56 {
+							return nil, errors.Errorf("unexpected input %v", input)
+						}
+						return &ec2.CreateVpcOutput{
+							Vpc: &ec2.Vpc{
+								State:     aws.String("available"),
+								VpcId:     aws.String("vpc-new"),
+								CidrBlock: aws.String("10.0.0.0/16"),
+								Tags:      managedVPCTags,
+							},
+						}, nil
+					})
+				m.DescribeVpcsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcsInput{
+					VpcIds: aws.StringSlice([]string{"vpc-new"}),
+				})).Return(&ec2.DescribeVpcsOutput{
+					Vpcs: []*ec2.Vpc{
+						{
+							State:     aws.String("available"),
+							VpcId:     aws.String("vpc-new"),
+							CidrBlock: aws.String("10.0.0.0/16"),
+							Tags:      managedVPCTags,
+							Ipv6CidrBlockAssociationSet: []*ec2.VpcIpv6CidrBlockAssociation{
+								{
+									Ipv6CidrBlock: aws.String("2001:db8:1234:1a00::/56"),
+									Ipv6CidrBlockState: &ec2.VpcCidrBlockState{
+										State: aws.String(ec2.SubnetCidrBlockStateCodeAssociated),
+									},
+									Ipv6Pool: aws.String("ipam-pool-v6-id"),
+								},
+							},
+						},
+					},
+				}, nil)
+
+				m.DescribeVpcAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcAttributeInput{})).
+					DoAndReturn(describeVpcAttributeFalse).MinTimes(1)
+
+				m.ModifyVpcAttributeWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.ModifyVpcAttributeInput{})).Return(&ec2.ModifyVpcAttributeOutput{}, nil).Times(2)
+			},
+		},
 		{
 			name: "Describing the VPC fails with IPv6 VPC should return an error",
 			input: &infrav1.VPCSpec{
@@ -684,3 +832,206 @@ func getClusterScope(vpcSpec *infrav1.VPCSpec, additionalTags map[string]string)
 		Client:     client,
 	})
 }
+
+func TestReconcileVPCEndpoints(t *testing.T) {
+	const clusterName = "test-cluster"
+	subnets := infrav1.Subnets{
+		{ResourceID: "subnet-private-a1", AvailabilityZone: "us-east-1a", RouteTableID: aws.String("rtb-a")},
+		{ResourceID: "subnet-private-a2", AvailabilityZone: "us-east-1a", RouteTableID: aws.String("rtb-a")},
+		{ResourceID: "subnet-private-b", AvailabilityZone: "us-east-1b", RouteTableID: aws.String("rtb-b")},
+		{ResourceID: "subnet-public-a", AvailabilityZone: "us-east-1a", IsPublic: true, RouteTableID: aws.String("rtb-public")},
+	}
+
+	type interfaceEndpoint struct {
+		service        string
+		securityGroups []string
+		privateDNS     bool
+	}
+	defaultInterfaceEndpoint := func(service string) interfaceEndpoint {
+		return interfaceEndpoint{service: service, securityGroups: []string{"sg-endpoints"}, privateDNS: true}
+	}
+
+	testCases := []struct {
+		name                       string
+		createVPCEndpoints         bool
+		vpcEndpoints               infrav1.VPCEndpoints
+		securityGroups             map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
+		configured                 bool
+		existingServices           []string
+		removedServices            []string
+		expectedGatewayServices    []string
+		expectedInterfaceEndpoints []interfaceEndpoint
+		expectErr                  bool
+	}{
+		{
+			name: "no endpoints without a bucket or private cluster",
+		},
+		{
+			name:            "endpoints removed from the network spec are deleted",
+			configured:      true,
+			removedServices: []string{"com.amazonaws.us-east-1.dynamodb", "com.amazonaws.us-east-1.ssm"},
+		},
+		{
+			name: "endpoints removed from the network spec are deleted while reconciling the others",
+			vpcEndpoints: infrav1.VPCEndpoints{
+				{Service: "logs"},
+			},
+			securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+				infrav1.SecurityGroupVPCEndpoint: {ID: "sg-endpoints"},
+			},
+			existingServices: []string{"com.amazonaws.us-east-1.logs"},
+			removedServices:  []string{"com.amazonaws.us-east-1.dynamodb", "com.amazonaws.us-east-1.ssm"},
+		},
+		{
+			name:                    "fails to create the interface endpoints without a security group",
+			createVPCEndpoints:      true,
+			expectedGatewayServices: []string{"com.amazonaws.us-east-1.s3"},
+			expectErr:               true,
+		},
+		{
+			name:               "missing interface endpoints in a private subnet of each availability zone",
+			createVPCEndpoints: true,
+			securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+				infrav1.SecurityGroupVPCEndpoint: {ID: "sg-endpoints"},
+			},
+			existingServices:        []string{"com.amazonaws.us-east-1.ecr.api"},
+			expectedGatewayServices: []string{"com.amazonaws.us-east-1.s3"},
+			expectedInterfaceEndpoints: []interfaceEndpoint{
+				defaultInterfaceEndpoint("com.amazonaws.us-east-1.ec2"),
+				defaultInterfaceEndpoint("com.amazonaws.us-east-1.ecr.dkr"),
+				defaultInterfaceEndpoint("com.amazonaws.us-east-1.elasticloadbalancing"),
+				defaultInterfaceEndpoint("com.amazonaws.us-east-1.sts"),
+			},
+		},
+		{
+			name: "endpoints of the network spec",
+			vpcEndpoints: infrav1.VPCEndpoints{
+				{Service: "dynamodb", Type: infrav1.VPCEndpointTypeGateway},
+				{Service: "ssm", Type: infrav1.VPCEndpointTypeInterface},
+				{Service: "logs", PrivateDNSEnabled: aws.Bool(false), AdditionalSecurityGroups: []string{"sg-logs"}},
+			},
+			securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+				infrav1.SecurityGroupVPCEndpoint: {ID: "sg-endpoints"},
+			},
+			expectedGatewayServices: []string{"com.amazonaws.us-east-1.dynamodb"},
+			expectedInterfaceEndpoints: []interfaceEndpoint{
+				{service: "com.amazonaws.us-east-1.logs", securityGroups: []string{"sg-endpoints", "sg-logs"}, privateDNS: false},
+				defaultInterfaceEndpoint("com.amazonaws.us-east-1.ssm"),
+			},
+		},
+		{
+			name:               "endpoints of the network spec override the endpoints of private clusters",
+			createVPCEndpoints: true,
+			vpcEndpoints: infrav1.VPCEndpoints{
+				{Service: "sts", PrivateDNSEnabled: aws.Bool(false)},
+			},
+			securityGroups: map[infrav1.SecurityGroupRole]infrav1.SecurityGroup{
+				infrav1.SecurityGroupVPCEndpoint: {ID: "sg-endpoints"},
+			},
+			existingServices:        []string{"com.amazonaws.us-east-1.ec2", "com.amazonaws.us-east-1.ecr.api", "com.amazonaws.us-east-1.ecr.dkr", "com.amazonaws.us-east-1.elasticloadbalancing"},
+			expectedGatewayServices: []string{"com.amazonaws.us-east-1.s3"},
+			expectedInterfaceEndpoints: []interfaceEndpoint{
+				{service: "com.amazonaws.us-east-1.sts", securityGroups: []string{"sg-endpoints"}, privateDNS: false},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			scheme := runtime.NewScheme()
+			_ = ekscontrolplanev1.AddToScheme(scheme)
+			client := fake.NewClientBuilder().WithScheme(scheme).Build()
+			managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+				Client: client,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+				},
+				ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+					Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+						Region: "us-east-1",
+						EndpointAccess: ekscontrolplanev1.EndpointAccess{
+							CreateVPCEndpoints: tc.createVPCEndpoints,
+						},
+						NetworkSpec: infrav1.NetworkSpec{
+							VPC: infrav1.VPCSpec{
+								ID:   "vpc-managed",
+								Tags: map[string]string{infrav1.ClusterTagKey(clusterName): string(infrav1.ResourceLifecycleOwned)},
+							},
+							Subnets:      subnets,
+							VPCEndpoints: tc.vpcEndpoints,
+						},
+					},
+					Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{
+						Network: infrav1.NetworkStatus{SecurityGroups: tc.securityGroups},
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			created := []*ec2.CreateVpcEndpointInput{}
+			ec2Mock.EXPECT().DescribeVpcEndpointsPages(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ *ec2.DescribeVpcEndpointsInput, fn func(*ec2.DescribeVpcEndpointsOutput, bool) bool) error {
+					endpoints := []*ec2.VpcEndpoint{}
+					for _, service := range append(tc.existingServices, tc.removedServices...) {
+						endpoints = append(endpoints, &ec2.VpcEndpoint{VpcEndpointId: aws.String("vpce-" + service), ServiceName: aws.String(service)})
+					}
+					fn(&ec2.DescribeVpcEndpointsOutput{VpcEndpoints: endpoints}, true)
+					return nil
+				}).AnyTimes()
+			ec2Mock.EXPECT().CreateVpcEndpoint(gomock.Any()).
+				DoAndReturn(func(input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+					created = append(created, input)
+					return &ec2.CreateVpcEndpointOutput{}, nil
+				}).AnyTimes()
+
+			if len(tc.removedServices) > 0 {
+				ids := []*string{}
+				for _, service := range tc.removedServices {
+					ids = append(ids, aws.String("vpce-"+service))
+				}
+				ec2Mock.EXPECT().DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{VpcEndpointIds: ids}).
+					Return(&ec2.DeleteVpcEndpointsOutput{}, nil)
+			}
+			if tc.configured {
+				conditions.MarkTrue(managedScope.InfraCluster(), infrav1.VpcEndpointsReadyCondition)
+			}
+
+			s := NewService(managedScope)
+			s.EC2Client = ec2Mock
+
+			err = s.reconcileVPCEndpoints()
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			var gatewayServices []string
+			var interfaceEndpoints []interfaceEndpoint
+			for _, input := range created {
+				g.Expect(input.VpcId).To(Equal(aws.String("vpc-managed")))
+				if aws.StringValue(input.VpcEndpointType) != ec2.VpcEndpointTypeInterface {
+					gatewayServices = append(gatewayServices, aws.StringValue(input.ServiceName))
+					g.Expect(aws.StringValueSlice(input.RouteTableIds)).To(ConsistOf("rtb-a", "rtb-b", "rtb-public"))
+					continue
+				}
+				g.Expect(aws.StringValueSlice(input.SubnetIds)).To(Equal([]string{"subnet-private-a1", "subnet-private-b"}))
+				interfaceEndpoints = append(interfaceEndpoints, interfaceEndpoint{
+					service:        aws.StringValue(input.ServiceName),
+					securityGroups: aws.StringValueSlice(input.SecurityGroupIds),
+					privateDNS:     aws.BoolValue(input.PrivateDnsEnabled),
+				})
+			}
+			g.Expect(gatewayServices).To(ConsistOf(tc.expectedGatewayServices))
+			g.Expect(interfaceEndpoints).To(Equal(tc.expectedInterfaceEndpoints))
+			if !tc.expectErr {
+				endpointsConfigured := len(tc.vpcEndpoints) > 0 || tc.createVPCEndpoints
+				g.Expect(conditions.Has(managedScope.InfraCluster(), infrav1.VpcEndpointsReadyCondition)).To(Equal(endpointsConfigured))
+			}
+		})
+	}
+}
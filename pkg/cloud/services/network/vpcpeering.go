@@ -0,0 +1,352 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/filter"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/tags"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// vpcPeeringConnectionStatusCodes are the status codes of a peering connection which isn't deleted, or failed or
+// expired and has to be requested again.
+var vpcPeeringConnectionStatusCodes = []string{
+	ec2.VpcPeeringConnectionStateReasonCodeInitiatingRequest,
+	ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance,
+	ec2.VpcPeeringConnectionStateReasonCodeProvisioning,
+	ec2.VpcPeeringConnectionStateReasonCodeActive,
+	ec2.VpcPeeringConnectionStateReasonCodeRejected,
+}
+
+func (s *Service) reconcileVPCPeeringConnections() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) {
+		s.scope.Trace("Skipping VPC peering connections reconcile in unmanaged mode")
+		return nil
+	}
+
+	specs := s.scope.VPCPeeringConnections()
+	// The condition is only set if peering connections were configured, which are deleted together with
+	// their routes once all of them are removed from the spec.
+	if len(specs) == 0 && !conditions.Has(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition) {
+		return nil
+	}
+
+	s.scope.Debug("Reconciling VPC peering connections")
+
+	connections, err := s.describeVPCPeeringConnections()
+	if err != nil {
+		return err
+	}
+
+	owned := sets.New[string]()
+	byPeerVPC := map[string]*ec2.VpcPeeringConnection{}
+	for _, connection := range connections {
+		owned.Insert(aws.StringValue(connection.VpcPeeringConnectionId))
+		if connection.AccepterVpcInfo != nil {
+			byPeerVPC[aws.StringValue(connection.AccepterVpcInfo.VpcId)] = connection
+		}
+	}
+
+	routes := map[string]string{}
+	notActive := []string{}
+	for _, spec := range specs {
+		connection, ok := byPeerVPC[spec.PeerVPCID]
+		if !ok {
+			connection, err = s.createVPCPeeringConnection(spec)
+			if err != nil {
+				return err
+			}
+			owned.Insert(aws.StringValue(connection.VpcPeeringConnectionId))
+		}
+
+		if s.canAcceptVPCPeeringConnection(spec) && vpcPeeringConnectionStatusCode(connection) == ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance {
+			connection, err = s.acceptVPCPeeringConnection(connection)
+			if err != nil {
+				return err
+			}
+		}
+
+		id := aws.StringValue(connection.VpcPeeringConnectionId)
+		if code := vpcPeeringConnectionStatusCode(connection); code != ec2.VpcPeeringConnectionStateReasonCodeActive {
+			s.scope.Debug("VPC peering connection is not active yet", "vpc-peering-connection-id", id, "status", code)
+			notActive = append(notActive, fmt.Sprintf("%s (%s)", id, code))
+			continue
+		}
+		for _, cidrBlock := range spec.RouteCidrBlocks {
+			routes[cidrBlock] = id
+		}
+	}
+
+	if err := s.reconcileVPCPeeringRoutes(routes, owned); err != nil {
+		return err
+	}
+
+	// The routes through the peering connections removed from the spec were deleted above.
+	for peerVPCID, connection := range byPeerVPC {
+		if slices.ContainsFunc(specs, func(spec infrav1.VPCPeeringConnectionSpec) bool { return spec.PeerVPCID == peerVPCID }) {
+			continue
+		}
+		if err := s.deleteVPCPeeringConnection(connection); err != nil {
+			return err
+		}
+	}
+
+	if len(specs) == 0 {
+		conditions.Delete(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition)
+		return nil
+	}
+
+	if len(notActive) > 0 {
+		conditions.MarkFalse(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition, infrav1.VpcPeeringConnectionsNotActiveReason, clusterv1.ConditionSeverityInfo,
+			"VPC peering connections not active yet: %s", strings.Join(notActive, ", "))
+		return nil
+	}
+
+	conditions.MarkTrue(s.scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition)
+	return nil
+}
+
+// canAcceptVPCPeeringConnection returns whether the peering connection can be accepted with the credentials and
+// in the region of the cluster.
+func (s *Service) canAcceptVPCPeeringConnection(spec infrav1.VPCPeeringConnectionSpec) bool {
+	return spec.PeerOwnerID == "" && (spec.PeerRegion == "" || spec.PeerRegion == s.scope.Region())
+}
+
+func (s *Service) createVPCPeeringConnection(spec infrav1.VPCPeeringConnectionSpec) (*ec2.VpcPeeringConnection, error) {
+	input := &ec2.CreateVpcPeeringConnectionInput{
+		VpcId:     aws.String(s.scope.VPC().ID),
+		PeerVpcId: aws.String(spec.PeerVPCID),
+		TagSpecifications: []*ec2.TagSpecification{
+			tags.BuildParamsToTagSpecification(ec2.ResourceTypeVpcPeeringConnection, s.getVPCPeeringConnectionTagParams(services.TemporaryResourceID)),
+		},
+	}
+	if spec.PeerOwnerID != "" {
+		input.PeerOwnerId = aws.String(spec.PeerOwnerID)
+	}
+	if spec.PeerRegion != "" {
+		input.PeerRegion = aws.String(spec.PeerRegion)
+	}
+
+	out, err := s.EC2Client.CreateVpcPeeringConnectionWithContext(context.TODO(), input)
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateVPCPeeringConnection", "Failed to create VPC Peering Connection from VPC %q to VPC %q: %v", s.scope.VPC().ID, spec.PeerVPCID, err)
+		return nil, errors.Wrapf(err, "failed to create vpc peering connection from vpc %q to vpc %q", s.scope.VPC().ID, spec.PeerVPCID)
+	}
+
+	connection := out.VpcPeeringConnection
+	id := aws.StringValue(connection.VpcPeeringConnectionId)
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateVPCPeeringConnection", "Created VPC Peering Connection %q from VPC %q to VPC %q", id, s.scope.VPC().ID, spec.PeerVPCID)
+	s.scope.Info("Created VPC peering connection", "vpc-peering-connection-id", id, "peer-vpc-id", spec.PeerVPCID)
+
+	// A new peering connection can't be accepted before the request has been initiated.
+	if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
+		out, err := s.EC2Client.DescribeVpcPeeringConnectionsWithContext(context.TODO(), &ec2.DescribeVpcPeeringConnectionsInput{
+			VpcPeeringConnectionIds: []*string{connection.VpcPeeringConnectionId},
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(out.VpcPeeringConnections) == 0 || vpcPeeringConnectionStatusCode(out.VpcPeeringConnections[0]) == ec2.VpcPeeringConnectionStateReasonCodeInitiatingRequest {
+			return false, nil
+		}
+		connection = out.VpcPeeringConnections[0]
+		return true, nil
+	}, awserrors.VPCPeeringConnectionNotFound); err != nil {
+		return nil, errors.Wrapf(err, "failed to wait for vpc peering connection %q to be requested", id)
+	}
+
+	return connection, nil
+}
+
+func (s *Service) acceptVPCPeeringConnection(connection *ec2.VpcPeeringConnection) (*ec2.VpcPeeringConnection, error) {
+	id := aws.StringValue(connection.VpcPeeringConnectionId)
+	out, err := s.EC2Client.AcceptVpcPeeringConnectionWithContext(context.TODO(), &ec2.AcceptVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: connection.VpcPeeringConnectionId,
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedAcceptVPCPeeringConnection", "Failed to accept VPC Peering Connection %q: %v", id, err)
+		return nil, errors.Wrapf(err, "failed to accept vpc peering connection %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulAcceptVPCPeeringConnection", "Accepted VPC Peering Connection %q", id)
+	return out.VpcPeeringConnection, nil
+}
+
+// reconcileVPCPeeringRoutes routes the CIDR blocks through the peering connections from the route tables of the
+// subnets, and deletes the routes through an owned peering connection to other CIDR blocks. The routes through
+// peering connections the cluster doesn't own are left alone.
+func (s *Service) reconcileVPCPeeringRoutes(routes map[string]string, owned sets.Set[string]) error {
+	subnetRouteMap, err := s.describeVpcRouteTablesBySubnet()
+	if err != nil {
+		return err
+	}
+
+	routeTables := sets.New[string]()
+	for _, subnet := range s.scope.Subnets() {
+		rt, ok := subnetRouteMap[subnet.GetResourceID()]
+		// The routes of existing route tables are managed by their owner.
+		if subnet.IsEdge() || subnet.ExistingRouteTableID != "" || !ok || routeTables.Has(aws.StringValue(rt.RouteTableId)) {
+			continue
+		}
+		routeTables.Insert(aws.StringValue(rt.RouteTableId))
+
+		missing := sets.KeySet(routes)
+		for _, route := range rt.Routes {
+			if route.VpcPeeringConnectionId == nil || route.DestinationCidrBlock == nil {
+				continue
+			}
+			if routes[*route.DestinationCidrBlock] == *route.VpcPeeringConnectionId {
+				missing.Delete(*route.DestinationCidrBlock)
+				continue
+			}
+			if !owned.Has(*route.VpcPeeringConnectionId) {
+				continue
+			}
+			if err := s.deleteVPCPeeringRoute(rt, route); err != nil {
+				return err
+			}
+		}
+
+		for _, cidrBlock := range sets.List(missing) {
+			if err := s.createVPCPeeringRoute(rt, routes[cidrBlock], cidrBlock); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) createVPCPeeringRoute(rt *ec2.RouteTable, connectionID, cidrBlock string) error {
+	if _, err := s.EC2Client.CreateRouteWithContext(context.TODO(), &ec2.CreateRouteInput{
+		RouteTableId:           rt.RouteTableId,
+		DestinationCidrBlock:   aws.String(cidrBlock),
+		VpcPeeringConnectionId: aws.String(connectionID),
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedCreateRoute", "Failed to create route to %q through VPC peering connection %q for RouteTable %q: %v", cidrBlock, connectionID, *rt.RouteTableId, err)
+		return errors.Wrapf(err, "failed to create route to %q through vpc peering connection %q in route table %q", cidrBlock, connectionID, *rt.RouteTableId)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulCreateRoute", "Created route to %q through VPC peering connection %q for RouteTable %q", cidrBlock, connectionID, *rt.RouteTableId)
+	return nil
+}
+
+func (s *Service) deleteVPCPeeringRoute(rt *ec2.RouteTable, route *ec2.Route) error {
+	if _, err := s.EC2Client.DeleteRouteWithContext(context.TODO(), &ec2.DeleteRouteInput{
+		RouteTableId:         rt.RouteTableId,
+		DestinationCidrBlock: route.DestinationCidrBlock,
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteRoute", "Failed to delete route to %q through VPC peering connection %q for RouteTable %q: %v", *route.DestinationCidrBlock, *route.VpcPeeringConnectionId, *rt.RouteTableId, err)
+		return errors.Wrapf(err, "failed to delete route to %q in route table %q", *route.DestinationCidrBlock, *rt.RouteTableId)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteRoute", "Deleted route to %q through VPC peering connection %q for RouteTable %q", *route.DestinationCidrBlock, *route.VpcPeeringConnectionId, *rt.RouteTableId)
+	return nil
+}
+
+// deleteVPCPeeringConnections deletes the peering connections of the VPC owned by the cluster.
+func (s *Service) deleteVPCPeeringConnections() error {
+	if s.scope.VPC().IsUnmanaged(s.scope.Name()) || s.scope.VPC().ID == "" {
+		s.scope.Trace("Skipping VPC peering connections deletion in unmanaged mode")
+		return nil
+	}
+
+	connections, err := s.describeVPCPeeringConnections()
+	if err != nil {
+		return err
+	}
+
+	for _, connection := range connections {
+		if err := s.deleteVPCPeeringConnection(connection); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteVPCPeeringConnection(connection *ec2.VpcPeeringConnection) error {
+	// A rejected peering connection can't be deleted, it expires after a while.
+	if vpcPeeringConnectionStatusCode(connection) == ec2.VpcPeeringConnectionStateReasonCodeRejected {
+		return nil
+	}
+
+	id := aws.StringValue(connection.VpcPeeringConnectionId)
+	if _, err := s.EC2Client.DeleteVpcPeeringConnectionWithContext(context.TODO(), &ec2.DeleteVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: connection.VpcPeeringConnectionId,
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteVPCPeeringConnection", "Failed to delete VPC Peering Connection %q: %v", id, err)
+		return errors.Wrapf(err, "failed to delete vpc peering connection %q", id)
+	}
+
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteVPCPeeringConnection", "Deleted VPC Peering Connection %q of VPC %q", id, s.scope.VPC().ID)
+	s.scope.Info("Deleted VPC peering connection", "vpc-peering-connection-id", id)
+	return nil
+}
+
+// describeVPCPeeringConnections returns the peering connections requested by the VPC and owned by the cluster.
+func (s *Service) describeVPCPeeringConnections() ([]*ec2.VpcPeeringConnection, error) {
+	out, err := s.EC2Client.DescribeVpcPeeringConnectionsWithContext(context.TODO(), &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: []*ec2.Filter{
+			filter.EC2.RequesterVPC(s.scope.VPC().ID),
+			filter.EC2.ClusterOwned(s.scope.Name()),
+			filter.EC2.VPCPeeringConnectionStatusCodes(vpcPeeringConnectionStatusCodes...),
+		},
+	})
+	if err != nil {
+		record.Eventf(s.scope.InfraCluster(), "FailedDescribeVPCPeeringConnections", "Failed to describe VPC peering connections of vpc %q: %v", s.scope.VPC().ID, err)
+		return nil, errors.Wrapf(err, "failed to describe vpc peering connections of vpc %q", s.scope.VPC().ID)
+	}
+
+	return out.VpcPeeringConnections, nil
+}
+
+func vpcPeeringConnectionStatusCode(connection *ec2.VpcPeeringConnection) string {
+	if connection.Status == nil {
+		return ""
+	}
+	return aws.StringValue(connection.Status.Code)
+}
+
+func (s *Service) getVPCPeeringConnectionTagParams(id string) infrav1.BuildParams {
+	name := fmt.Sprintf("%s-pcx", s.scope.Name())
+
+	return infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		ResourceID:  id,
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Role:        aws.String(infrav1.CommonRoleTagValue),
+		Additional:  s.scope.AdditionalTags(),
+	}
+}
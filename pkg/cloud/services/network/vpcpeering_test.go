@@ -0,0 +1,306 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/mocks"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileVPCPeeringConnections(t *testing.T) {
+	describeConnections := func(m *mocks.MockEC2APIMockRecorder, connections ...*ec2.VpcPeeringConnection) {
+		m.DescribeVpcPeeringConnectionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcPeeringConnectionsInput{
+			Filters: []*ec2.Filter{
+				{Name: aws.String("requester-vpc-info.vpc-id"), Values: aws.StringSlice([]string{"vpc-pcx"})},
+				{Name: aws.String("tag:sigs.k8s.io/cluster-api-provider-aws/cluster/test-cluster"), Values: aws.StringSlice([]string{"owned"})},
+				{Name: aws.String("status-code"), Values: aws.StringSlice(vpcPeeringConnectionStatusCodes)},
+			},
+		})).Return(&ec2.DescribeVpcPeeringConnectionsOutput{VpcPeeringConnections: connections}, nil)
+	}
+	connection := func(id, peerVPCID, code string) *ec2.VpcPeeringConnection {
+		return &ec2.VpcPeeringConnection{
+			VpcPeeringConnectionId: aws.String(id),
+			AccepterVpcInfo:        &ec2.VpcPeeringConnectionVpcInfo{VpcId: aws.String(peerVPCID)},
+			Status:                 &ec2.VpcPeeringConnectionStateReason{Code: aws.String(code)},
+		}
+	}
+	routeTables := &ec2.DescribeRouteTablesOutput{
+		RouteTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-private-1a"),
+				Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-private-1a")}},
+				Routes: []*ec2.Route{
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1a")},
+					{DestinationCidrBlock: aws.String("172.20.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-old")},
+				},
+			},
+			{
+				RouteTableId: aws.String("rtb-public-1a"),
+				Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("subnet-public-1a")}},
+				Routes: []*ec2.Route{
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")},
+					{DestinationCidrBlock: aws.String("10.100.0.0/16"), VpcPeeringConnectionId: aws.String("pcx-1")},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		connections       infrav1.VPCPeeringConnections
+		subnets           infrav1.Subnets
+		ready             bool
+		expect            func(m *mocks.MockEC2APIMockRecorder)
+		expectedCondition *clusterv1.Condition
+	}{
+		{
+			name:   "no peering connections",
+			expect: func(m *mocks.MockEC2APIMockRecorder) {},
+		},
+		{
+			name:  "deletes the owned peering connections and their routes once all of them are removed",
+			ready: true,
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeConnections(m, connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodeActive))
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).Return(routeTables, nil)
+				m.DeleteRouteWithContext(context.TODO(), gomock.Eq(&ec2.DeleteRouteInput{
+					RouteTableId:         aws.String("rtb-public-1a"),
+					DestinationCidrBlock: aws.String("10.100.0.0/16"),
+				})).Return(&ec2.DeleteRouteOutput{}, nil)
+				m.DeleteVpcPeeringConnectionWithContext(context.TODO(), gomock.Eq(&ec2.DeleteVpcPeeringConnectionInput{
+					VpcPeeringConnectionId: aws.String("pcx-1"),
+				})).Return(&ec2.DeleteVpcPeeringConnectionOutput{}, nil)
+			},
+		},
+		{
+			name:        "creates and accepts a peering connection in the account and region of the cluster",
+			connections: infrav1.VPCPeeringConnections{{PeerVPCID: "vpc-peer", RouteCidrBlocks: []string{"10.100.0.0/16"}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeConnections(m)
+				m.CreateVpcPeeringConnectionWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.CreateVpcPeeringConnectionInput{})).
+					DoAndReturn(func(_ context.Context, input *ec2.CreateVpcPeeringConnectionInput, _ ...interface{}) (*ec2.CreateVpcPeeringConnectionOutput, error) {
+						g := NewWithT(t)
+						g.Expect(input.VpcId).To(Equal(aws.String("vpc-pcx")))
+						g.Expect(input.PeerVpcId).To(Equal(aws.String("vpc-peer")))
+						g.Expect(input.PeerOwnerId).To(BeNil())
+						g.Expect(input.PeerRegion).To(BeNil())
+						g.Expect(input.TagSpecifications[0].ResourceType).To(Equal(aws.String(ec2.ResourceTypeVpcPeeringConnection)))
+						return &ec2.CreateVpcPeeringConnectionOutput{
+							VpcPeeringConnection: connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodeInitiatingRequest),
+						}, nil
+					})
+				m.DescribeVpcPeeringConnectionsWithContext(context.TODO(), gomock.Eq(&ec2.DescribeVpcPeeringConnectionsInput{
+					VpcPeeringConnectionIds: aws.StringSlice([]string{"pcx-1"}),
+				})).Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+					VpcPeeringConnections: []*ec2.VpcPeeringConnection{connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance)},
+				}, nil)
+				m.AcceptVpcPeeringConnectionWithContext(context.TODO(), gomock.Eq(&ec2.AcceptVpcPeeringConnectionInput{
+					VpcPeeringConnectionId: aws.String("pcx-1"),
+				})).Return(&ec2.AcceptVpcPeeringConnectionOutput{
+					VpcPeeringConnection: connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodeActive),
+				}, nil)
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).Return(routeTables, nil)
+				m.CreateRouteWithContext(context.TODO(), gomock.Eq(&ec2.CreateRouteInput{
+					RouteTableId:           aws.String("rtb-private-1a"),
+					DestinationCidrBlock:   aws.String("10.100.0.0/16"),
+					VpcPeeringConnectionId: aws.String("pcx-1"),
+				})).Return(&ec2.CreateRouteOutput{}, nil)
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:   infrav1.VpcPeeringConnectionsReadyCondition,
+				Status: "True",
+			},
+		},
+		{
+			name:        "deletes a peering connection removed from the spec and its routes",
+			connections: infrav1.VPCPeeringConnections{{PeerVPCID: "vpc-peer", RouteCidrBlocks: []string{"10.100.0.0/16"}}},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeConnections(m,
+					connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodeActive),
+					connection("pcx-old", "vpc-old", ec2.VpcPeeringConnectionStateReasonCodeActive),
+				)
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).Return(routeTables, nil)
+				m.DeleteRouteWithContext(context.TODO(), gomock.Eq(&ec2.DeleteRouteInput{
+					RouteTableId:         aws.String("rtb-private-1a"),
+					DestinationCidrBlock: aws.String("172.20.0.0/16"),
+				})).Return(&ec2.DeleteRouteOutput{}, nil)
+				m.CreateRouteWithContext(context.TODO(), gomock.Eq(&ec2.CreateRouteInput{
+					RouteTableId:           aws.String("rtb-private-1a"),
+					DestinationCidrBlock:   aws.String("10.100.0.0/16"),
+					VpcPeeringConnectionId: aws.String("pcx-1"),
+				})).Return(&ec2.CreateRouteOutput{}, nil)
+				m.DeleteVpcPeeringConnectionWithContext(context.TODO(), gomock.Eq(&ec2.DeleteVpcPeeringConnectionInput{
+					VpcPeeringConnectionId: aws.String("pcx-old"),
+				})).Return(&ec2.DeleteVpcPeeringConnectionOutput{}, nil)
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:   infrav1.VpcPeeringConnectionsReadyCondition,
+				Status: "True",
+			},
+		},
+		{
+			name: "leaves a peering connection to another account pending",
+			connections: infrav1.VPCPeeringConnections{
+				{PeerVPCID: "vpc-peer", PeerOwnerID: "123456789012", RouteCidrBlocks: []string{"10.100.0.0/16"}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeConnections(m, connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodePendingAcceptance))
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).Return(routeTables, nil)
+				m.DeleteRouteWithContext(context.TODO(), gomock.Eq(&ec2.DeleteRouteInput{
+					RouteTableId:         aws.String("rtb-public-1a"),
+					DestinationCidrBlock: aws.String("10.100.0.0/16"),
+				})).Return(&ec2.DeleteRouteOutput{}, nil)
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:     infrav1.VpcPeeringConnectionsReadyCondition,
+				Status:   "False",
+				Severity: clusterv1.ConditionSeverityInfo,
+				Reason:   infrav1.VpcPeeringConnectionsNotActiveReason,
+				Message:  "VPC peering connections not active yet: pcx-1 (pending-acceptance)",
+			},
+		},
+		{
+			name:        "does not modify the routes of existing route tables",
+			connections: infrav1.VPCPeeringConnections{{PeerVPCID: "vpc-peer", RouteCidrBlocks: []string{"10.100.0.0/16"}}},
+			subnets: infrav1.Subnets{
+				{ResourceID: "subnet-private-1a", AvailabilityZone: "us-east-1a", ExistingRouteTableID: "rtb-private-1a"},
+				{ResourceID: "subnet-public-1a", AvailabilityZone: "us-east-1a", IsPublic: true, ExistingRouteTableID: "rtb-public-1a"},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				describeConnections(m, connection("pcx-1", "vpc-peer", ec2.VpcPeeringConnectionStateReasonCodeActive))
+				m.DescribeRouteTablesWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeRouteTablesInput{})).Return(routeTables, nil)
+				m.CreateRouteWithContext(gomock.Any(), gomock.Any()).Times(0)
+				m.DeleteRouteWithContext(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCondition: &clusterv1.Condition{
+				Type:   infrav1.VpcPeeringConnectionsReadyCondition,
+				Status: "True",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			scope, err := newVPCPeeringTestScope(tc.connections)
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.subnets != nil {
+				scope.AWSCluster.Spec.NetworkSpec.Subnets = tc.subnets
+			}
+			if tc.ready {
+				conditions.MarkTrue(scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition)
+			}
+
+			tc.expect(ec2Mock.EXPECT())
+
+			s := NewService(scope)
+			s.EC2Client = ec2Mock
+
+			g.Expect(s.reconcileVPCPeeringConnections()).To(Succeed())
+
+			condition := conditions.Get(scope.InfraCluster(), infrav1.VpcPeeringConnectionsReadyCondition)
+			if tc.expectedCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedCondition.Status))
+			g.Expect(condition.Severity).To(Equal(tc.expectedCondition.Severity))
+			g.Expect(condition.Reason).To(Equal(tc.expectedCondition.Reason))
+			g.Expect(condition.Message).To(Equal(tc.expectedCondition.Message))
+		})
+	}
+}
+
+func TestDeleteVPCPeeringConnections(t *testing.T) {
+	g := NewWithT(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+	scope, err := newVPCPeeringTestScope(infrav1.VPCPeeringConnections{{PeerVPCID: "vpc-peer"}, {PeerVPCID: "vpc-rejected"}})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ec2Mock.EXPECT().DescribeVpcPeeringConnectionsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeVpcPeeringConnectionsInput{})).
+		Return(&ec2.DescribeVpcPeeringConnectionsOutput{
+			VpcPeeringConnections: []*ec2.VpcPeeringConnection{
+				{VpcPeeringConnectionId: aws.String("pcx-1"), Status: &ec2.VpcPeeringConnectionStateReason{Code: aws.String(ec2.VpcPeeringConnectionStateReasonCodeActive)}},
+				{VpcPeeringConnectionId: aws.String("pcx-2"), Status: &ec2.VpcPeeringConnectionStateReason{Code: aws.String(ec2.VpcPeeringConnectionStateReasonCodeRejected)}},
+			},
+		}, nil)
+	ec2Mock.EXPECT().DeleteVpcPeeringConnectionWithContext(context.TODO(), gomock.Eq(&ec2.DeleteVpcPeeringConnectionInput{
+		VpcPeeringConnectionId: aws.String("pcx-1"),
+	})).Return(&ec2.DeleteVpcPeeringConnectionOutput{}, nil)
+
+	s := NewService(scope)
+	s.EC2Client = ec2Mock
+
+	g.Expect(s.deleteVPCPeeringConnections()).To(Succeed())
+}
+
+func newVPCPeeringTestScope(connections infrav1.VPCPeeringConnections) (*scope.ClusterScope, error) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: infrav1.AWSClusterSpec{
+				Region: "us-east-1",
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						ID: "vpc-pcx",
+						Tags: infrav1.Tags{
+							infrav1.ClusterTagKey("test-cluster"): "owned",
+						},
+					},
+					Subnets: infrav1.Subnets{
+						{ResourceID: "subnet-private-1a", AvailabilityZone: "us-east-1a"},
+						{ResourceID: "subnet-public-1a", AvailabilityZone: "us-east-1a", IsPublic: true},
+					},
+					VPCPeeringConnections: connections,
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// ReconcileControlPlaneEndpointDNSRecord ensures the alias record of the custom control plane endpoint name points
+// at the given load balancer. The ID of a change of the record is kept in changeID until the change has propagated,
+// and requeue is true while it hasn't.
+func (s *Service) ReconcileControlPlaneEndpointDNSRecord(endpointDNS *infrav1.ControlPlaneEndpointDNS, lb *infrav1.LoadBalancer, changeID *string) (requeue bool, err error) {
+	if lb.DNSName == "" || lb.CanonicalHostedZoneID == "" {
+		return false, errors.New("the DNS name and hosted zone of the control plane load balancer are not available yet")
+	}
+
+	// The control plane endpoint is only switched to the custom name once it resolves on all Route53 name servers.
+	if *changeID != "" {
+		out, err := s.Route53Client.GetChangeWithContext(context.TODO(), &route53.GetChangeInput{Id: aws.String(*changeID)})
+		if err != nil {
+			// Changes are only kept for a limited time, a change that is gone has propagated long ago.
+			if code, ok := awserrors.Code(err); !ok || code != route53.ErrCodeNoSuchChange {
+				return false, errors.Wrapf(err, "failed to get change %q of alias record %q", *changeID, endpointDNS.Name)
+			}
+		} else if aws.StringValue(out.ChangeInfo.Status) != route53.ChangeStatusInsync {
+			s.scope.Debug("Waiting for the control plane endpoint alias record to propagate", "name", endpointDNS.Name, "change-id", *changeID)
+			return true, nil
+		}
+		*changeID = ""
+	}
+
+	current, err := s.describeRecord(endpointDNS.HostedZoneID, endpointDNS.Name, route53.RRTypeA)
+	if err != nil {
+		return false, err
+	}
+	if current != nil && aliasPointsAt(current, lb) {
+		return false, nil
+	}
+
+	changeInfo, err := s.changeRecord(endpointDNS.HostedZoneID, route53.ChangeActionUpsert, &route53.ResourceRecordSet{
+		Name: aws.String(endpointDNS.Name),
+		Type: aws.String(route53.RRTypeA),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(lb.DNSName),
+			HostedZoneId:         aws.String(lb.CanonicalHostedZoneID),
+			EvaluateTargetHealth: aws.Bool(false),
+		},
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedUpsertDNSRecord", "Failed to set alias record %q in hosted zone %q: %v", endpointDNS.Name, endpointDNS.HostedZoneID, err)
+		return false, errors.Wrapf(err, "failed to set alias record %q in hosted zone %q", endpointDNS.Name, endpointDNS.HostedZoneID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulUpsertDNSRecord", "Set alias record %q in hosted zone %q to %q", endpointDNS.Name, endpointDNS.HostedZoneID, lb.DNSName)
+
+	*changeID = aws.StringValue(changeInfo.Id)
+	return true, nil
+}
+
+// DeleteControlPlaneEndpointDNSRecord deletes the alias record of the custom control plane endpoint name.
+// Records that don't point at the given load balancer are left untouched, as they are not managed by us.
+func (s *Service) DeleteControlPlaneEndpointDNSRecord(endpointDNS *infrav1.ControlPlaneEndpointDNS, lb *infrav1.LoadBalancer) error {
+	current, err := s.describeRecord(endpointDNS.HostedZoneID, endpointDNS.Name, route53.RRTypeA)
+	if err != nil {
+		if code, ok := awserrors.Code(errors.Cause(err)); ok && code == route53.ErrCodeNoSuchHostedZone {
+			return nil
+		}
+		return err
+	}
+	if current == nil || !aliasPointsAt(current, lb) {
+		s.scope.Debug("Skipping deletion of control plane endpoint record not pointing at the load balancer", "name", endpointDNS.Name, "hosted-zone-id", endpointDNS.HostedZoneID)
+		return nil
+	}
+
+	if _, err := s.changeRecord(endpointDNS.HostedZoneID, route53.ChangeActionDelete, current); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteDNSRecord", "Failed to delete alias record %q from hosted zone %q: %v", endpointDNS.Name, endpointDNS.HostedZoneID, err)
+		return errors.Wrapf(err, "failed to delete alias record %q from hosted zone %q", endpointDNS.Name, endpointDNS.HostedZoneID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteDNSRecord", "Deleted alias record %q from hosted zone %q", endpointDNS.Name, endpointDNS.HostedZoneID)
+
+	return nil
+}
+
+// aliasPointsAt returns true if the record set is an alias of the given load balancer.
+// Route53 may return the DNS name of classic load balancers with a "dualstack." prefix.
+func aliasPointsAt(rrs *route53.ResourceRecordSet, lb *infrav1.LoadBalancer) bool {
+	if rrs.AliasTarget == nil || lb.DNSName == "" {
+		return false
+	}
+	target := strings.TrimPrefix(normalizeRecordName(aws.StringValue(rrs.AliasTarget.DNSName)), "dualstack.")
+	return target == strings.TrimPrefix(normalizeRecordName(lb.DNSName), "dualstack.") &&
+		aws.StringValue(rrs.AliasTarget.HostedZoneId) == lb.CanonicalHostedZoneID
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53/mock_route53iface"
+)
+
+const (
+	testEndpointName           = "api.test-cluster.example.com"
+	testCanonicalHostedZoneID  = "Z35SXDOTRQ7X7K"
+	testEndpointRecordChangeID = "/change/C0123456789"
+)
+
+func TestReconcileControlPlaneEndpointDNSRecord(t *testing.T) {
+	endpointDNS := &infrav1.ControlPlaneEndpointDNS{HostedZoneID: testHostedZoneID, Name: testEndpointName}
+	lb := &infrav1.LoadBalancer{DNSName: testELBDNSName, CanonicalHostedZoneID: testCanonicalHostedZoneID}
+
+	testCases := []struct {
+		name         string
+		lb           *infrav1.LoadBalancer
+		changeID     string
+		expect       func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		wantErr      bool
+		wantRequeue  bool
+		wantChangeID string
+	}{
+		{
+			name:    "hosted zone of the load balancer is unknown, returns an error",
+			lb:      &infrav1.LoadBalancer{DNSName: testELBDNSName},
+			wantErr: true,
+		},
+		{
+			name: "record doesn't exist, creates it and requeues until it has propagated",
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSetsWithContext(context.TODO(), &route53.ListResourceRecordSetsInput{
+					HostedZoneId:    aws.String(testHostedZoneID),
+					StartRecordName: aws.String(testEndpointName),
+					StartRecordType: aws.String(route53.RRTypeA),
+					MaxItems:        aws.String("1"),
+				}).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), &route53.ChangeResourceRecordSetsInput{
+					HostedZoneId: aws.String(testHostedZoneID),
+					ChangeBatch: &route53.ChangeBatch{
+						Changes: []*route53.Change{
+							{
+								Action: aws.String(route53.ChangeActionUpsert),
+								ResourceRecordSet: &route53.ResourceRecordSet{
+									Name: aws.String(testEndpointName),
+									Type: aws.String(route53.RRTypeA),
+									AliasTarget: &route53.AliasTarget{
+										DNSName:              aws.String(testELBDNSName),
+										HostedZoneId:         aws.String(testCanonicalHostedZoneID),
+										EvaluateTargetHealth: aws.Bool(false),
+									},
+								},
+							},
+						},
+					},
+				}).Return(&route53.ChangeResourceRecordSetsOutput{
+					ChangeInfo: &route53.ChangeInfo{Id: aws.String(testEndpointRecordChangeID), Status: aws.String(route53.ChangeStatusPending)},
+				}, nil)
+			},
+			wantRequeue:  true,
+			wantChangeID: testEndpointRecordChangeID,
+		},
+		{
+			name: "record already points at the load balancer, nothing to do",
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectAliasRecord(m, "dualstack."+testELBDNSName+".")
+			},
+		},
+		{
+			name: "record points at another load balancer, updates it",
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectAliasRecord(m, "other-apiserver.elb.us-east-1.amazonaws.com.")
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Return(&route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{Id: aws.String(testEndpointRecordChangeID)}}, nil)
+			},
+			wantRequeue:  true,
+			wantChangeID: testEndpointRecordChangeID,
+		},
+		{
+			name:     "change hasn't propagated yet, requeues",
+			lb:       lb,
+			changeID: testEndpointRecordChangeID,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.GetChangeWithContext(context.TODO(), &route53.GetChangeInput{Id: aws.String(testEndpointRecordChangeID)}).
+					Return(&route53.GetChangeOutput{ChangeInfo: &route53.ChangeInfo{Status: aws.String(route53.ChangeStatusPending)}}, nil)
+			},
+			wantRequeue:  true,
+			wantChangeID: testEndpointRecordChangeID,
+		},
+		{
+			name:     "change has propagated, forgets the change",
+			lb:       lb,
+			changeID: testEndpointRecordChangeID,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.GetChangeWithContext(context.TODO(), &route53.GetChangeInput{Id: aws.String(testEndpointRecordChangeID)}).
+					Return(&route53.GetChangeOutput{ChangeInfo: &route53.ChangeInfo{Status: aws.String(route53.ChangeStatusInsync)}}, nil)
+				expectAliasRecord(m, "dualstack."+testELBDNSName+".")
+			},
+		},
+		{
+			name:     "change doesn't exist anymore, forgets the change",
+			lb:       lb,
+			changeID: testEndpointRecordChangeID,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.GetChangeWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(route53.ErrCodeNoSuchChange, "not found", nil))
+				expectAliasRecord(m, "dualstack."+testELBDNSName+".")
+			},
+		},
+		{
+			name:     "getting the change fails, returns an error",
+			lb:       lb,
+			changeID: testEndpointRecordChangeID,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.GetChangeWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New("Throttling", "rate exceeded", nil))
+			},
+			wantErr:      true,
+			wantChangeID: testEndpointRecordChangeID,
+		},
+		{
+			name: "setting the record fails, returns an error",
+			lb:   lb,
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSetsWithContext(context.TODO(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(route53.ErrCodeInvalidChangeBatch, "invalid", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(route53Mock.EXPECT())
+			}
+
+			s := NewService(newClusterScope(t, nil))
+			s.Route53Client = route53Mock
+
+			changeID := tc.changeID
+			requeue, err := s.ReconcileControlPlaneEndpointDNSRecord(endpointDNS, tc.lb, &changeID)
+			g.Expect(changeID).To(Equal(tc.wantChangeID))
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(requeue).To(Equal(tc.wantRequeue))
+		})
+	}
+}
+
+func TestDeleteControlPlaneEndpointDNSRecord(t *testing.T) {
+	endpointDNS := &infrav1.ControlPlaneEndpointDNS{HostedZoneID: testHostedZoneID, Name: testEndpointName}
+	lb := &infrav1.LoadBalancer{DNSName: testELBDNSName, CanonicalHostedZoneID: testCanonicalHostedZoneID}
+
+	testCases := []struct {
+		name    string
+		expect  func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		wantErr bool
+	}{
+		{
+			name: "record points at the load balancer, deletes it",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				current := expectAliasRecord(m, testELBDNSName+".")
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), &route53.ChangeResourceRecordSetsInput{
+					HostedZoneId: aws.String(testHostedZoneID),
+					ChangeBatch: &route53.ChangeBatch{
+						Changes: []*route53.Change{
+							{
+								Action:            aws.String(route53.ChangeActionDelete),
+								ResourceRecordSet: current,
+							},
+						},
+					},
+				}).Return(&route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{}}, nil)
+			},
+		},
+		{
+			name: "record points at another load balancer, leaves it untouched",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectAliasRecord(m, "other-apiserver.elb.us-east-1.amazonaws.com.")
+			},
+		},
+		{
+			name: "record doesn't exist, nothing to do",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSetsWithContext(context.TODO(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name: "hosted zone doesn't exist anymore, nothing to do",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "not found", nil))
+			},
+		},
+		{
+			name: "deleting the record fails, returns an error",
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectAliasRecord(m, testELBDNSName)
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(route53.ErrCodeInvalidChangeBatch, "invalid", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(route53Mock.EXPECT())
+			}
+
+			s := NewService(newClusterScope(t, nil))
+			s.Route53Client = route53Mock
+
+			err := s.DeleteControlPlaneEndpointDNSRecord(endpointDNS, lb)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func expectAliasRecord(m *mock_route53iface.MockRoute53APIMockRecorder, dnsName string) *route53.ResourceRecordSet {
+	rrs := &route53.ResourceRecordSet{
+		Name: aws.String(testEndpointName + "."),
+		Type: aws.String(route53.RRTypeA),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(dnsName),
+			HostedZoneId:         aws.String(testCanonicalHostedZoneID),
+			EvaluateTargetHealth: aws.Bool(false),
+		},
+	}
+	m.ListResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+		Return(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{rrs}}, nil)
+	return rrs
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+)
+
+// apiServerRecordTTL is the TTL in seconds of the API server record.
+const apiServerRecordTTL = 300
+
+// ReconcileAPIServerPrivateDNSRecord ensures the record of the API server in the private hosted zone points
+// at the API server endpoint.
+func (s *Service) ReconcileAPIServerPrivateDNSRecord() error {
+	dnsRecord := s.scope.APIServerPrivateDNSRecord()
+	if dnsRecord == nil {
+		return nil
+	}
+
+	target := s.scope.APIServerDNSName()
+	if target == "" {
+		return errors.New("the API server endpoint is not available yet")
+	}
+
+	out, err := s.Route53Client.GetHostedZoneWithContext(context.TODO(), &route53.GetHostedZoneInput{
+		Id: aws.String(dnsRecord.HostedZoneID),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get hosted zone %q", dnsRecord.HostedZoneID)
+	}
+	if out.HostedZone == nil || out.HostedZone.Config == nil || !aws.BoolValue(out.HostedZone.Config.PrivateZone) {
+		record.Warnf(s.scope.InfraCluster(), "InvalidHostedZone", "Hosted zone %q is not a private hosted zone", dnsRecord.HostedZoneID)
+		return errors.Errorf("hosted zone %q is not a private hosted zone", dnsRecord.HostedZoneID)
+	}
+
+	current, err := s.describeRecord(dnsRecord.HostedZoneID, dnsRecord.Name, route53.RRTypeCname)
+	if err != nil {
+		return err
+	}
+	if current != nil && recordPointsAt(current, target) {
+		return nil
+	}
+
+	if _, err := s.changeRecord(dnsRecord.HostedZoneID, route53.ChangeActionUpsert, &route53.ResourceRecordSet{
+		Name: aws.String(dnsRecord.Name),
+		Type: aws.String(route53.RRTypeCname),
+		TTL:  aws.Int64(apiServerRecordTTL),
+		ResourceRecords: []*route53.ResourceRecord{
+			{Value: aws.String(target)},
+		},
+	}); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedUpsertDNSRecord", "Failed to set record %q in hosted zone %q: %v", dnsRecord.Name, dnsRecord.HostedZoneID, err)
+		return errors.Wrapf(err, "failed to set record %q in hosted zone %q", dnsRecord.Name, dnsRecord.HostedZoneID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulUpsertDNSRecord", "Set record %q in hosted zone %q to %q", dnsRecord.Name, dnsRecord.HostedZoneID, target)
+
+	return nil
+}
+
+// DeleteAPIServerPrivateDNSRecord deletes the record of the API server from the private hosted zone.
+// Records that don't point at the API server endpoint are left untouched, as they are not managed by us.
+func (s *Service) DeleteAPIServerPrivateDNSRecord() error {
+	dnsRecord := s.scope.APIServerPrivateDNSRecord()
+	if dnsRecord == nil {
+		return nil
+	}
+
+	current, err := s.describeRecord(dnsRecord.HostedZoneID, dnsRecord.Name, route53.RRTypeCname)
+	if err != nil {
+		if code, ok := awserrors.Code(errors.Cause(err)); ok && code == route53.ErrCodeNoSuchHostedZone {
+			return nil
+		}
+		return err
+	}
+	if current == nil || !recordPointsAt(current, s.scope.APIServerDNSName()) {
+		s.scope.Debug("Skipping deletion of API server record not pointing at the API server", "name", dnsRecord.Name, "hosted-zone-id", dnsRecord.HostedZoneID)
+		return nil
+	}
+
+	if _, err := s.changeRecord(dnsRecord.HostedZoneID, route53.ChangeActionDelete, current); err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDeleteDNSRecord", "Failed to delete record %q from hosted zone %q: %v", dnsRecord.Name, dnsRecord.HostedZoneID, err)
+		return errors.Wrapf(err, "failed to delete record %q from hosted zone %q", dnsRecord.Name, dnsRecord.HostedZoneID)
+	}
+	record.Eventf(s.scope.InfraCluster(), "SuccessfulDeleteDNSRecord", "Deleted record %q from hosted zone %q", dnsRecord.Name, dnsRecord.HostedZoneID)
+
+	return nil
+}
+
+// describeRecord returns the record set with the given name and type, or nil if there is none.
+func (s *Service) describeRecord(hostedZoneID, name, rrType string) (*route53.ResourceRecordSet, error) {
+	out, err := s.Route53Client.ListResourceRecordSetsWithContext(context.TODO(), &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: aws.String(rrType),
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list records of hosted zone %q", hostedZoneID)
+	}
+
+	// The records are listed starting at the given name and type, the first one is not necessarily a match.
+	for _, rrs := range out.ResourceRecordSets {
+		if normalizeRecordName(aws.StringValue(rrs.Name)) == normalizeRecordName(name) && aws.StringValue(rrs.Type) == rrType {
+			return rrs, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Service) changeRecord(hostedZoneID, action string, rrs *route53.ResourceRecordSet) (*route53.ChangeInfo, error) {
+	out, err := s.Route53Client.ChangeResourceRecordSetsWithContext(context.TODO(), &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action:            aws.String(action),
+					ResourceRecordSet: rrs,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.ChangeInfo, nil
+}
+
+// recordPointsAt returns true if the record set has the given target as its only value.
+func recordPointsAt(rrs *route53.ResourceRecordSet, target string) bool {
+	return len(rrs.ResourceRecords) == 1 && normalizeRecordName(aws.StringValue(rrs.ResourceRecords[0].Value)) == normalizeRecordName(target)
+}
+
+// normalizeRecordName strips the trailing dot of fully qualified names returned by Route53.
+func normalizeRecordName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
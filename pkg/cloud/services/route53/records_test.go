@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route53
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/route53/mock_route53iface"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	testHostedZoneID = "Z0123456789ABCDEFGHIJ"
+	testRecordName   = "api.test-cluster.internal"
+	testELBDNSName   = "test-cluster-apiserver-123.elb.us-east-1.amazonaws.com"
+)
+
+func TestReconcileAPIServerPrivateDNSRecord(t *testing.T) {
+	testCases := []struct {
+		name      string
+		dnsRecord *infrav1.PrivateDNSRecord
+		expect    func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		wantErr   bool
+	}{
+		{
+			name: "no record, nothing to do",
+		},
+		{
+			name:      "record doesn't exist, creates it",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectPrivateHostedZone(m, true)
+				m.ListResourceRecordSetsWithContext(context.TODO(), &route53.ListResourceRecordSetsInput{
+					HostedZoneId:    aws.String(testHostedZoneID),
+					StartRecordName: aws.String(testRecordName),
+					StartRecordType: aws.String(route53.RRTypeCname),
+					MaxItems:        aws.String("1"),
+				}).Return(&route53.ListResourceRecordSetsOutput{
+					ResourceRecordSets: []*route53.ResourceRecordSet{
+						{Name: aws.String("zz.test-cluster.internal."), Type: aws.String(route53.RRTypeCname)},
+					},
+				}, nil)
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), &route53.ChangeResourceRecordSetsInput{
+					HostedZoneId: aws.String(testHostedZoneID),
+					ChangeBatch: &route53.ChangeBatch{
+						Changes: []*route53.Change{
+							{
+								Action: aws.String(route53.ChangeActionUpsert),
+								ResourceRecordSet: &route53.ResourceRecordSet{
+									Name:            aws.String(testRecordName),
+									Type:            aws.String(route53.RRTypeCname),
+									TTL:             aws.Int64(apiServerRecordTTL),
+									ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(testELBDNSName)}},
+								},
+							},
+						},
+					},
+				}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name:      "record points at another endpoint, updates it",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectPrivateHostedZone(m, true)
+				expectRecord(m, "old-apiserver.elb.us-east-1.amazonaws.com")
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Do(func(_ context.Context, input *route53.ChangeResourceRecordSetsInput, _ ...interface{}) {
+						g := NewWithT(t)
+						g.Expect(input.ChangeBatch.Changes).To(HaveLen(1))
+						g.Expect(aws.StringValue(input.ChangeBatch.Changes[0].Action)).To(Equal(route53.ChangeActionUpsert))
+						g.Expect(aws.StringValue(input.ChangeBatch.Changes[0].ResourceRecordSet.ResourceRecords[0].Value)).To(Equal(testELBDNSName))
+					}).
+					Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name:      "record is up to date, nothing to do",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectPrivateHostedZone(m, true)
+				expectRecord(m, testELBDNSName)
+			},
+		},
+		{
+			name:      "hosted zone is public, returns an error",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectPrivateHostedZone(m, false)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(route53Mock.EXPECT())
+			}
+
+			s := NewService(newClusterScope(t, tc.dnsRecord))
+			s.Route53Client = route53Mock
+
+			err := s.ReconcileAPIServerPrivateDNSRecord()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestDeleteAPIServerPrivateDNSRecord(t *testing.T) {
+	testCases := []struct {
+		name      string
+		dnsRecord *infrav1.PrivateDNSRecord
+		expect    func(m *mock_route53iface.MockRoute53APIMockRecorder)
+		wantErr   bool
+	}{
+		{
+			name: "no record, nothing to do",
+		},
+		{
+			name:      "record points at the endpoint, deletes it",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				current := expectRecord(m, testELBDNSName+".")
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), &route53.ChangeResourceRecordSetsInput{
+					HostedZoneId: aws.String(testHostedZoneID),
+					ChangeBatch: &route53.ChangeBatch{
+						Changes: []*route53.Change{
+							{
+								Action:            aws.String(route53.ChangeActionDelete),
+								ResourceRecordSet: current,
+							},
+						},
+					},
+				}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+			},
+		},
+		{
+			name:      "record points at another endpoint, leaves it untouched",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectRecord(m, "other-apiserver.elb.us-east-1.amazonaws.com")
+			},
+		},
+		{
+			name:      "hosted zone doesn't exist anymore, nothing to do",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				m.ListResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "not found", nil))
+			},
+		},
+		{
+			name:      "deleting the record fails, returns an error",
+			dnsRecord: &infrav1.PrivateDNSRecord{HostedZoneID: testHostedZoneID, Name: testRecordName},
+			expect: func(m *mock_route53iface.MockRoute53APIMockRecorder) {
+				expectRecord(m, testELBDNSName)
+				m.ChangeResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+					Return(nil, awserr.New(route53.ErrCodeInvalidChangeBatch, "invalid", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockCtrl := gomock.NewController(t)
+			route53Mock := mock_route53iface.NewMockRoute53API(mockCtrl)
+			if tc.expect != nil {
+				tc.expect(route53Mock.EXPECT())
+			}
+
+			s := NewService(newClusterScope(t, tc.dnsRecord))
+			s.Route53Client = route53Mock
+
+			err := s.DeleteAPIServerPrivateDNSRecord()
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func expectPrivateHostedZone(m *mock_route53iface.MockRoute53APIMockRecorder, private bool) {
+	m.GetHostedZoneWithContext(context.TODO(), &route53.GetHostedZoneInput{Id: aws.String(testHostedZoneID)}).
+		Return(&route53.GetHostedZoneOutput{
+			HostedZone: &route53.HostedZone{
+				Id:     aws.String("/hostedzone/" + testHostedZoneID),
+				Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(private)},
+			},
+		}, nil)
+}
+
+func expectRecord(m *mock_route53iface.MockRoute53APIMockRecorder, value string) *route53.ResourceRecordSet {
+	rrs := &route53.ResourceRecordSet{
+		Name:            aws.String(testRecordName + "."),
+		Type:            aws.String(route53.RRTypeCname),
+		TTL:             aws.Int64(apiServerRecordTTL),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(value)}},
+	}
+	m.ListResourceRecordSetsWithContext(context.TODO(), gomock.Any()).
+		Return(&route53.ListResourceRecordSetsOutput{ResourceRecordSets: []*route53.ResourceRecordSet{rrs}}, nil)
+	return rrs
+}
+
+func newClusterScope(t *testing.T, dnsRecord *infrav1.PrivateDNSRecord) *scope.ClusterScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				APIServerPrivateDNSRecord: dnsRecord,
+			},
+			Status: infrav1.AWSClusterStatus{
+				Network: infrav1.NetworkStatus{
+					APIServerELB: infrav1.LoadBalancer{DNSName: testELBDNSName},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	return clusterScope
+}
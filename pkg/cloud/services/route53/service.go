@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package route53 provides a way to interact with AWS Route53.
+package route53
+
+import (
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// Service holds a collection of interfaces.
+// The interfaces are broken down like this to group functions together.
+// One alternative is to have a large list of functions from the ec2 client.
+type Service struct {
+	scope         scope.Route53Scope
+	Route53Client route53iface.Route53API
+}
+
+// NewService returns a new service given the api clients.
+func NewService(route53Scope scope.Route53Scope) *Service {
+	return &Service{
+		scope:         route53Scope,
+		Route53Client: scope.NewRoute53Client(route53Scope, route53Scope, route53Scope, route53Scope.InfraCluster()),
+	}
+}
@@ -104,6 +104,19 @@ func (s *Service) ReconcileSecurityGroups() error {
 		if ok {
 			s.scope.Debug("Using security group override", "role", role, "security group", sgOverride.GroupName)
 			sg = sgOverride
+
+			if role == infrav1.SecurityGroupAPIServerLB {
+				if err := s.validateAPIServerLBSecurityGroupOverride(sgOverride); err != nil {
+					return err
+				}
+			}
+		} else if s.scope.ExternallyManagedNetwork() {
+			// No security group is created in an externally managed network.
+			record.Warnf(s.scope.InfraCluster(), "FailedSecurityGroupOverride", "No security group override found for role %q of the externally managed network", role)
+			return errors.Errorf("no security group override found for role %q of the externally managed network", role)
+		} else if s.scope.SkipSecurityGroupCreation() {
+			record.Warnf(s.scope.InfraCluster(), "FailedSecurityGroupOverride", "No security group override found for role %q while security group creation is skipped", role)
+			return errors.Errorf("no security group override found for role %q while security group creation is skipped", role)
 		}
 
 		existing, ok := sgs[*sg.GroupName]
@@ -183,6 +196,10 @@ func (s *Service) ReconcileSecurityGroups() error {
 
 		toAuthorize := want.Difference(current)
 		if len(toAuthorize) > 0 {
+			if err := s.validateManagedPrefixLists(toAuthorize); err != nil {
+				return err
+			}
+
 			if err := wait.WaitForWithRetryable(wait.NewBackoff(), func() (bool, error) {
 				if err := s.authorizeSecurityGroupIngressRules(sg.ID, toAuthorize); err != nil {
 					return false, err
@@ -214,7 +231,7 @@ func expandIngressRules(rules infrav1.IngressRules) infrav1.IngressRules {
 		}
 
 		// Nothing to expand
-		if len(rule.CidrBlocks) == 0 && len(rule.IPv6CidrBlocks) == 0 && len(rule.SourceSecurityGroupIDs) == 0 {
+		if len(rule.CidrBlocks) == 0 && len(rule.IPv6CidrBlocks) == 0 && len(rule.PrefixListIDs) == 0 && len(rule.SourceSecurityGroupIDs) == 0 {
 			res = append(res, base)
 			continue
 		}
@@ -231,6 +248,12 @@ func expandIngressRules(rules infrav1.IngressRules) infrav1.IngressRules {
 			res = append(res, rcopy)
 		}
 
+		for _, src := range rule.PrefixListIDs {
+			rcopy := base
+			rcopy.PrefixListIDs = []string{src}
+			res = append(res, rcopy)
+		}
+
 		for _, src := range rule.SourceSecurityGroupIDs {
 			rcopy := base
 			rcopy.SourceSecurityGroupIDs = []string{src}
@@ -240,6 +263,87 @@ func expandIngressRules(rules infrav1.IngressRules) infrav1.IngressRules {
 	return res
 }
 
+// validateManagedPrefixLists ensures the managed prefix lists referenced by the given ingress rules exist,
+// so that a typo in the spec surfaces as a clear error instead of a failed authorization.
+func (s *Service) validateManagedPrefixLists(rules infrav1.IngressRules) error {
+	ids := sets.New[string]()
+	for _, rule := range rules {
+		ids.Insert(rule.PrefixListIDs...)
+	}
+	if ids.Len() == 0 {
+		return nil
+	}
+
+	out, err := s.EC2Client.DescribeManagedPrefixListsWithContext(context.TODO(), &ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: aws.StringSlice(sets.List(ids)),
+	})
+	if err != nil {
+		record.Warnf(s.scope.InfraCluster(), "FailedDescribeManagedPrefixLists", "Failed to describe managed prefix lists %v: %v", sets.List(ids), err)
+		return errors.Wrapf(err, "failed to describe managed prefix lists %v", sets.List(ids))
+	}
+
+	for _, pl := range out.PrefixLists {
+		ids.Delete(aws.StringValue(pl.PrefixListId))
+	}
+	if ids.Len() > 0 {
+		record.Warnf(s.scope.InfraCluster(), "FailedDescribeManagedPrefixLists", "Managed prefix lists %v referenced by security group ingress rules were not found", sets.List(ids))
+		return errors.Errorf("managed prefix lists %v referenced by security group ingress rules were not found", sets.List(ids))
+	}
+
+	return nil
+}
+
+// validateAPIServerLBSecurityGroupOverride ensures the security group overriding the one of the control plane load
+// balancers allows the TCP traffic to all the ports the load balancers listen on. The rules of an override are not
+// reconciled, so a missing rule would otherwise leave the API server unreachable without any error.
+func (s *Service) validateAPIServerLBSecurityGroupOverride(sg *ec2.SecurityGroup) error {
+	ports := sets.New[int64]()
+	for _, lb := range s.scope.ControlPlaneLoadBalancers() {
+		// The security group is not attached to existing load balancers.
+		if lb == nil || lb.LoadBalancerType == infrav1.LoadBalancerTypeDisabled || lb.IsExisting() {
+			continue
+		}
+		if lb.LoadBalancerType == infrav1.LoadBalancerTypeClassic {
+			ports.Insert(int64(s.scope.APIServerPort()))
+		} else {
+			ports.Insert(infrav1.DefaultAPIServerPort)
+		}
+		for _, ln := range lb.AdditionalListeners {
+			ports.Insert(ln.Port)
+		}
+	}
+
+	var missing []int64
+	for _, port := range sets.List(ports) {
+		if !ipPermissionsAllowTCPPort(sg.IpPermissions, port) {
+			missing = append(missing, port)
+		}
+	}
+	if len(missing) > 0 {
+		record.Warnf(s.scope.InfraCluster(), "FailedSecurityGroupOverride", "Security group override %q for role %q does not allow TCP traffic to the control plane load balancer ports %v",
+			aws.StringValue(sg.GroupId), infrav1.SecurityGroupAPIServerLB, missing)
+		return errors.Errorf("security group override %q for role %q does not allow TCP traffic to the control plane load balancer ports %v",
+			aws.StringValue(sg.GroupId), infrav1.SecurityGroupAPIServerLB, missing)
+	}
+
+	return nil
+}
+
+// ipPermissionsAllowTCPPort returns true if one of the given ingress permissions allows TCP traffic to the port.
+func ipPermissionsAllowTCPPort(permissions []*ec2.IpPermission, port int64) bool {
+	for _, perm := range permissions {
+		switch aws.StringValue(perm.IpProtocol) {
+		case string(infrav1.SecurityGroupProtocolAll):
+			return true
+		case IPProtocolTCP:
+			if aws.Int64Value(perm.FromPort) <= port && port <= aws.Int64Value(perm.ToPort) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (s *Service) securityGroupIsAnOverride(securityGroupID string) bool {
 	for _, overrideID := range s.scope.SecurityGroupOverrides() {
 		if overrideID == securityGroupID {
@@ -574,6 +678,18 @@ func (s *Service) defaultSSHIngressRule(sourceSecurityGroupID string) infrav1.In
 	}
 }
 
+// efaIngressRule returns the rule allowing all the traffic within the given security group,
+// which the Elastic Fabric Adapter requires between the instances using it.
+func (s *Service) efaIngressRule(securityGroupID string) infrav1.IngressRule {
+	return infrav1.IngressRule{
+		Description:            "Elastic Fabric Adapter",
+		Protocol:               infrav1.SecurityGroupProtocolAll,
+		FromPort:               -1,
+		ToPort:                 -1,
+		SourceSecurityGroupIDs: []string{securityGroupID},
+	}
+}
+
 func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (infrav1.IngressRules, error) {
 	// Set source of CNI ingress rules to be control plane and node security groups
 	s.scope.Debug("getting security group ingress rules", "role", role)
@@ -581,10 +697,11 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 	cniRules := make(infrav1.IngressRules, len(s.scope.CNIIngressRules()))
 	for i, r := range s.scope.CNIIngressRules() {
 		cniRules[i] = infrav1.IngressRule{
-			Description: r.Description,
-			Protocol:    r.Protocol,
-			FromPort:    r.FromPort,
-			ToPort:      r.ToPort,
+			Description:   r.Description,
+			Protocol:      r.Protocol,
+			FromPort:      r.FromPort,
+			ToPort:        r.ToPort,
+			PrefixListIDs: r.PrefixListIDs,
 			SourceSecurityGroupIDs: []string{
 				s.scope.SecurityGroups()[infrav1.SecurityGroupControlPlane].ID,
 				s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID,
@@ -671,6 +788,9 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 		if s.scope.Bastion().Enabled {
 			rules = append(rules, s.defaultSSHIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupBastion].ID))
 		}
+		if s.scope.AllowNodeEFATraffic() {
+			rules = append(rules, s.efaIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupNode].ID))
+		}
 		if s.scope.VPC().IsIPv6Enabled() {
 			rules = append(rules, infrav1.IngressRule{
 				Description:    "Node Port Services IPv6",
@@ -681,11 +801,34 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 			})
 		}
 		return append(cniRules, rules...), nil
+	case infrav1.SecurityGroupVPCEndpoint:
+		rules := infrav1.IngressRules{
+			{
+				Description: "HTTPS from the VPC",
+				Protocol:    infrav1.SecurityGroupProtocolTCP,
+				FromPort:    443,
+				ToPort:      443,
+				CidrBlocks:  []string{s.scope.VPC().CidrBlock},
+			},
+		}
+		if s.scope.VPC().IsIPv6Enabled() {
+			rules = append(rules, infrav1.IngressRule{
+				Description:    "HTTPS from the VPC IPv6",
+				Protocol:       infrav1.SecurityGroupProtocolTCP,
+				FromPort:       443,
+				ToPort:         443,
+				IPv6CidrBlocks: []string{s.scope.VPC().IPv6.CidrBlock},
+			})
+		}
+		return rules, nil
 	case infrav1.SecurityGroupEKSNodeAdditional:
 		ingressRules := s.scope.AdditionalControlPlaneIngressRules()
 		if s.scope.Bastion().Enabled {
 			ingressRules = append(ingressRules, s.defaultSSHIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupBastion].ID))
 		}
+		if s.scope.AllowNodeEFATraffic() {
+			ingressRules = append(ingressRules, s.efaIngressRule(s.scope.SecurityGroups()[infrav1.SecurityGroupEKSNodeAdditional].ID))
+		}
 		return ingressRules, nil
 	case infrav1.SecurityGroupAPIServerLB:
 		kubeletRules := s.getIngressRulesToAllowKubeletToAccessTheControlPlaneLB()
@@ -735,10 +878,10 @@ func (s *Service) getSecurityGroupIngressRules(role infrav1.SecurityGroupRole) (
 
 			for _, ln := range lb.AdditionalListeners {
 				rules = append(rules, infrav1.IngressRule{
-					Description:    fmt.Sprintf("Allow NLB traffic to the control plane instances on port %d.", ln.Port),
+					Description:    fmt.Sprintf("Allow NLB traffic to the control plane instances on port %d.", ln.GetTargetPort()),
 					Protocol:       infrav1.SecurityGroupProtocolTCP,
-					FromPort:       ln.Port,
-					ToPort:         ln.Port,
+					FromPort:       ln.GetTargetPort(),
+					ToPort:         ln.GetTargetPort(),
 					CidrBlocks:     ipv4CidrBlocks,
 					IPv6CidrBlocks: ipv6CidrBlocks,
 				})
@@ -848,6 +991,18 @@ func ingressRuleToSDKType(scope scope.SGScope, i *infrav1.IngressRule) (res *ec2
 		res.Ipv6Ranges = append(res.Ipv6Ranges, ipV6Range)
 	}
 
+	for _, prefixListID := range i.PrefixListIDs {
+		prefixListIDItem := &ec2.PrefixListId{
+			PrefixListId: aws.String(prefixListID),
+		}
+
+		if i.Description != "" {
+			prefixListIDItem.Description = aws.String(i.Description)
+		}
+
+		res.PrefixListIds = append(res.PrefixListIds, prefixListIDItem)
+	}
+
 	for _, groupID := range i.SourceSecurityGroupIDs {
 		userIDGroupPair := &ec2.UserIdGroupPair{
 			GroupId: aws.String(groupID),
@@ -884,6 +1039,20 @@ func ingressRulesFromSDKType(v *ec2.IpPermission) (res infrav1.IngressRules) {
 		res = append(res, rule)
 	}
 
+	for _, prefixList := range v.PrefixListIds {
+		rule := ingressRuleFromSDKProtocol(v)
+		if prefixList.PrefixListId == nil {
+			continue
+		}
+
+		if prefixList.Description != nil && *prefixList.Description != "" {
+			rule.Description = *prefixList.Description
+		}
+
+		rule.PrefixListIDs = []string{*prefixList.PrefixListId}
+		res = append(res, rule)
+	}
+
 	for _, pair := range v.UserIdGroupPairs {
 		rule := ingressRuleFromSDKProtocol(v)
 		if pair.GroupId == nil {
@@ -970,51 +1139,55 @@ func (s *Service) getControlPlaneLBIngressRules() infrav1.IngressRules {
 }
 
 func (s *Service) getIngressRuleToAllowAnyIPInTheAPIServer() infrav1.IngressRules {
-	if s.scope.VPC().IsIPv6Enabled() {
-		return infrav1.IngressRules{
-			{
-				Description:    "Kubernetes API IPv6",
-				Protocol:       infrav1.SecurityGroupProtocolTCP,
-				FromPort:       int64(s.scope.APIServerPort()),
-				ToPort:         int64(s.scope.APIServerPort()),
-				IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
-			},
-		}
-	}
+	rules := infrav1.IngressRules{}
 
-	return infrav1.IngressRules{
-		{
+	// IPv6 only clusters don't allow any IPv4 traffic, dual-stack clusters allow both address families.
+	if !s.scope.VPC().IsIPv6Enabled() || s.scope.DualStack() {
+		rules = append(rules, infrav1.IngressRule{
 			Description: "Kubernetes API",
 			Protocol:    infrav1.SecurityGroupProtocolTCP,
 			FromPort:    int64(s.scope.APIServerPort()),
 			ToPort:      int64(s.scope.APIServerPort()),
 			CidrBlocks:  []string{services.AnyIPv4CidrBlock},
-		},
+		})
 	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		rules = append(rules, infrav1.IngressRule{
+			Description:    "Kubernetes API IPv6",
+			Protocol:       infrav1.SecurityGroupProtocolTCP,
+			FromPort:       int64(s.scope.APIServerPort()),
+			ToPort:         int64(s.scope.APIServerPort()),
+			IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
+		})
+	}
+
+	return rules
 }
 
 func (s *Service) getIngressRuleToAllowVPCCidrInTheAPIServer() infrav1.IngressRules {
-	if s.scope.VPC().IsIPv6Enabled() {
-		return infrav1.IngressRules{
-			{
-				Description:    "Kubernetes API IPv6",
-				Protocol:       infrav1.SecurityGroupProtocolTCP,
-				FromPort:       int64(s.scope.APIServerPort()),
-				ToPort:         int64(s.scope.APIServerPort()),
-				IPv6CidrBlocks: []string{s.scope.VPC().IPv6.CidrBlock},
-			},
-		}
-	}
+	rules := infrav1.IngressRules{}
 
-	return infrav1.IngressRules{
-		{
+	// IPv6 only clusters don't allow any IPv4 traffic, dual-stack clusters allow both address families.
+	if !s.scope.VPC().IsIPv6Enabled() || s.scope.DualStack() {
+		rules = append(rules, infrav1.IngressRule{
 			Description: "Kubernetes API",
 			Protocol:    infrav1.SecurityGroupProtocolTCP,
 			FromPort:    int64(s.scope.APIServerPort()),
 			ToPort:      int64(s.scope.APIServerPort()),
 			CidrBlocks:  []string{s.scope.VPC().CidrBlock},
-		},
+		})
 	}
+	if s.scope.VPC().IsIPv6Enabled() {
+		rules = append(rules, infrav1.IngressRule{
+			Description:    "Kubernetes API IPv6",
+			Protocol:       infrav1.SecurityGroupProtocolTCP,
+			FromPort:       int64(s.scope.APIServerPort()),
+			ToPort:         int64(s.scope.APIServerPort()),
+			IPv6CidrBlocks: []string{s.scope.VPC().IPv6.CidrBlock},
+		})
+	}
+
+	return rules
 }
 
 func (s *Service) processIngressRulesSGs(ingressRules []infrav1.IngressRule) (infrav1.IngressRules, error) {
@@ -1036,7 +1209,7 @@ func (s *Service) processIngressRulesSGs(ingressRules []infrav1.IngressRule) (in
 			return nil, errors.New("NAT Gateway IPs are not available yet")
 		}
 
-		if len(rule.CidrBlocks) != 0 || len(rule.IPv6CidrBlocks) != 0 { // don't set source security group if cidr blocks are set
+		if len(rule.CidrBlocks) != 0 || len(rule.IPv6CidrBlocks) != 0 || len(rule.PrefixListIDs) != 0 { // don't set source security group if cidr blocks or prefix lists are set
 			output = append(output, rule)
 			continue
 		}
@@ -522,6 +522,59 @@ func TestReconcileSecurityGroups(t *testing.T) {
 					}, nil).AnyTimes()
 			},
 		},
+		{
+			name: "apiserver load balancer override does not allow the api server port",
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				acl.Spec.ControlPlaneLoadBalancer = &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeClassic,
+				}
+				return acl
+			},
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-securitygroups",
+					InternetGatewayID: aws.String("igw-01"),
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-securitygroups-private",
+						IsPublic:         false,
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupBastion:      "sg-bastion",
+					infrav1.SecurityGroupAPIServerLB:  "sg-apiserver-lb",
+					infrav1.SecurityGroupLB:           "sg-lb",
+					infrav1.SecurityGroupControlPlane: "sg-control",
+					infrav1.SecurityGroupNode:         "sg-node",
+				},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{
+							{GroupId: aws.String("sg-bastion"), GroupName: aws.String("Bastion Security Group")},
+							{
+								GroupId:   aws.String("sg-apiserver-lb"),
+								GroupName: aws.String("API load balancer Security Group"),
+								IpPermissions: []*ec2.IpPermission{
+									{
+										IpProtocol: aws.String("tcp"),
+										FromPort:   aws.Int64(443),
+										ToPort:     aws.Int64(443),
+										IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+									},
+								},
+							},
+							{GroupId: aws.String("sg-lb"), GroupName: aws.String("Load balancer Security Group")},
+							{GroupId: aws.String("sg-control"), GroupName: aws.String("Control plane Security Group")},
+							{GroupId: aws.String("sg-node"), GroupName: aws.String("Node Security Group")},
+						},
+					}, nil).AnyTimes()
+			},
+			err: errors.New("does not allow TCP traffic to the control plane load balancer ports [6443]"),
+		},
 		{
 			name: "additional tags includes cloud provider tag, only tag lb",
 			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
@@ -762,6 +815,44 @@ func TestReconcileSecurityGroups(t *testing.T) {
 			},
 			err: errors.New(`security group overrides provided for managed vpc "test-cluster"`),
 		},
+		{
+			name: "security group creation skipped with a missing override, returns error",
+			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
+				return acl
+			},
+			input: &infrav1.NetworkSpec{
+				VPC: infrav1.VPCSpec{
+					ID:                "vpc-securitygroups",
+					InternetGatewayID: aws.String("igw-01"),
+				},
+				Subnets: infrav1.Subnets{
+					infrav1.SubnetSpec{
+						ID:               "subnet-securitygroups-private",
+						IsPublic:         false,
+						AvailabilityZone: "us-east-1a",
+					},
+				},
+				SecurityGroupOverrides: map[infrav1.SecurityGroupRole]string{
+					infrav1.SecurityGroupBastion:      "sg-bastion",
+					infrav1.SecurityGroupAPIServerLB:  "sg-apiserver-lb",
+					infrav1.SecurityGroupLB:           "sg-lb",
+					infrav1.SecurityGroupControlPlane: "sg-control",
+				},
+				SkipSecurityGroupCreation: true,
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeSecurityGroupsWithContext(context.TODO(), gomock.AssignableToTypeOf(&ec2.DescribeSecurityGroupsInput{})).
+					Return(&ec2.DescribeSecurityGroupsOutput{
+						SecurityGroups: []*ec2.SecurityGroup{
+							{GroupId: aws.String("sg-bastion"), GroupName: aws.String("Bastion Security Group")},
+							{GroupId: aws.String("sg-apiserver-lb"), GroupName: aws.String("API load balancer Security Group")},
+							{GroupId: aws.String("sg-lb"), GroupName: aws.String("Load balancer Security Group")},
+							{GroupId: aws.String("sg-control"), GroupName: aws.String("Control plane Security Group")},
+						},
+					}, nil).AnyTimes()
+			},
+			err: errors.New(`no security group override found for role "node" while security group creation is skipped`),
+		},
 		{
 			name: "when VPC default security group has no rules then no errors are returned",
 			awsCluster: func(acl infrav1.AWSCluster) infrav1.AWSCluster {
@@ -1189,6 +1280,60 @@ func TestControlPlaneSecurityGroupNotOpenToAnyCIDR(t *testing.T) {
 	}
 }
 
+func TestLoadBalancerSecurityGroupAdditionalListenerTargetPort(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		Client: client,
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		AWSCluster: &infrav1.AWSCluster{
+			Spec: infrav1.AWSClusterSpec{
+				ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{
+					LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+					AdditionalListeners: []infrav1.AdditionalListenerSpec{
+						{
+							Port:       8132,
+							Protocol:   infrav1.ELBProtocolTCP,
+							TargetPort: aws.Int64(8133),
+						},
+					},
+				},
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						CidrBlock: "10.0.0.0/16",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	s := NewService(cs, testSecurityGroupRoles)
+	rules, err := s.getSecurityGroupIngressRules(infrav1.SecurityGroupLB)
+	if err != nil {
+		t.Fatalf("Failed to lookup load balancer security group ingress rules: %v", err)
+	}
+
+	expected := infrav1.IngressRule{
+		Description: "Allow NLB traffic to the control plane instances on port 8133.",
+		Protocol:    infrav1.SecurityGroupProtocolTCP,
+		FromPort:    8133,
+		ToPort:      8133,
+		CidrBlocks:  []string{"10.0.0.0/16"},
+	}
+	for _, r := range rules {
+		if r.Equals(&expected) {
+			return
+		}
+	}
+	t.Fatalf("Expected ingress rule %v, got %v", expected, rules)
+}
+
 func TestAdditionalControlPlaneSecurityGroup(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -1568,6 +1713,52 @@ func TestAdditionalManagedControlPlaneSecurityGroup(t *testing.T) {
 	}
 }
 
+func TestAPIServerIngressRulesIPv6OnlyManagedControlPlane(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	_ = ekscontrolplanev1.AddToScheme(scheme)
+	cs, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		},
+		ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+			Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+				NetworkSpec: infrav1.NetworkSpec{
+					VPC: infrav1.VPCSpec{
+						CidrBlock: "10.0.0.0/16",
+						IPv6: &infrav1.IPv6{
+							CidrBlock: "2001:db8:1234:1a00::/56",
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test context: %v", err)
+	}
+
+	s := NewService(cs, testSecurityGroupRoles)
+	expected := infrav1.IngressRules{
+		{
+			Description:    "Kubernetes API IPv6",
+			Protocol:       infrav1.SecurityGroupProtocolTCP,
+			FromPort:       443,
+			ToPort:         443,
+			IPv6CidrBlocks: []string{services.AnyIPv6CidrBlock},
+		},
+	}
+	if rules := s.getIngressRuleToAllowAnyIPInTheAPIServer(); !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("Expected ingress rules %#v, got %#v", expected, rules)
+	}
+
+	expected[0].IPv6CidrBlocks = []string{"2001:db8:1234:1a00::/56"}
+	if rules := s.getIngressRuleToAllowVPCCidrInTheAPIServer(); !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("Expected ingress rules %#v, got %#v", expected, rules)
+	}
+}
+
 func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -1601,7 +1792,7 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 			},
 		},
 		{
-			name: "when no ingress rules are passed and nat gateway IPs are not available, the default for IPv6 is set",
+			name: "when no ingress rules are passed and nat gateway IPs are not available, the defaults for dual-stack are set",
 			awsCluster: &infrav1.AWSCluster{
 				Spec: infrav1.AWSClusterSpec{
 					ControlPlaneLoadBalancer: &infrav1.AWSLoadBalancerSpec{},
@@ -1615,6 +1806,13 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 				Status: infrav1.AWSClusterStatus{},
 			},
 			expectedIngresRules: infrav1.IngressRules{
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{services.AnyIPv4CidrBlock},
+				},
 				infrav1.IngressRule{
 					Description:    "Kubernetes API IPv6",
 					Protocol:       infrav1.SecurityGroupProtocolTCP,
@@ -1742,20 +1940,35 @@ func TestControlPlaneLoadBalancerIngressRules(t *testing.T) {
 					},
 					NetworkSpec: infrav1.NetworkSpec{
 						VPC: infrav1.VPCSpec{
+							CidrBlock: "10.0.0.0/16",
 							IPv6: &infrav1.IPv6{
-								CidrBlock: "10.0.0.0/16",
+								CidrBlock: "2001:db8:1234:1a00::/56",
 							},
 						},
 					},
 				},
 			},
 			expectedIngresRules: infrav1.IngressRules{
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{"10.0.0.0/16"},
+				},
 				infrav1.IngressRule{
 					Description:    "Kubernetes API IPv6",
 					Protocol:       infrav1.SecurityGroupProtocolTCP,
 					FromPort:       6443,
 					ToPort:         6443,
-					IPv6CidrBlocks: []string{"10.0.0.0/16"},
+					IPv6CidrBlocks: []string{"2001:db8:1234:1a00::/56"},
+				},
+				infrav1.IngressRule{
+					Description: "Kubernetes API",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{services.AnyIPv4CidrBlock},
 				},
 				infrav1.IngressRule{
 					Description:    "Kubernetes API IPv6",
@@ -2187,6 +2400,42 @@ func TestIngressRulesFromSDKType(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Mix of prefix lists and cidr blocks",
+			input: &ec2.IpPermission{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int64(6443),
+				ToPort:     aws.Int64(6443),
+				IpRanges: []*ec2.IpRange{
+					{
+						CidrIp:      aws.String("192.168.1.1/32"),
+						Description: aws.String("My VPN"),
+					},
+				},
+				PrefixListIds: []*ec2.PrefixListId{
+					{
+						PrefixListId: aws.String("pl-source-1"),
+						Description:  aws.String("Corporate network"),
+					},
+				},
+			},
+			expected: infrav1.IngressRules{
+				{
+					Description: "My VPN",
+					Protocol:    "tcp",
+					FromPort:    6443,
+					ToPort:      6443,
+					CidrBlocks:  []string{"192.168.1.1/32"},
+				},
+				{
+					Description:   "Corporate network",
+					Protocol:      "tcp",
+					FromPort:      6443,
+					ToPort:        6443,
+					PrefixListIDs: []string{"pl-source-1"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2333,6 +2582,34 @@ func TestExpandIngressRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "prefix lists expand",
+			input: infrav1.IngressRules{
+				{
+					Description:   "SSH",
+					Protocol:      infrav1.SecurityGroupProtocolTCP,
+					FromPort:      22,
+					ToPort:        22,
+					PrefixListIDs: []string{"pl-1", "pl-2"},
+				},
+			},
+			expected: infrav1.IngressRules{
+				{
+					Description:   "SSH",
+					Protocol:      infrav1.SecurityGroupProtocolTCP,
+					FromPort:      22,
+					ToPort:        22,
+					PrefixListIDs: []string{"pl-1"},
+				},
+				{
+					Description:   "SSH",
+					Protocol:      infrav1.SecurityGroupProtocolTCP,
+					FromPort:      22,
+					ToPort:        22,
+					PrefixListIDs: []string{"pl-2"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2345,6 +2622,204 @@ func TestExpandIngressRules(t *testing.T) {
 	}
 }
 
+func TestValidateManagedPrefixLists(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	testCases := []struct {
+		name    string
+		rules   infrav1.IngressRules
+		expect  func(m *mocks.MockEC2APIMockRecorder)
+		wantErr bool
+	}{
+		{
+			name: "no prefix lists, nothing to validate",
+			rules: infrav1.IngressRules{
+				{Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, CidrBlocks: []string{"10.0.0.0/8"}},
+			},
+		},
+		{
+			name: "all prefix lists exist",
+			rules: infrav1.IngressRules{
+				{Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, PrefixListIDs: []string{"pl-2"}},
+				{Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 443, ToPort: 443, PrefixListIDs: []string{"pl-1", "pl-2"}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), &ec2.DescribeManagedPrefixListsInput{
+					PrefixListIds: aws.StringSlice([]string{"pl-1", "pl-2"}),
+				}).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{
+						{PrefixListId: aws.String("pl-1")},
+						{PrefixListId: aws.String("pl-2")},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "prefix list is missing",
+			rules: infrav1.IngressRules{
+				{Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, PrefixListIDs: []string{"pl-1", "pl-2"}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.Any()).Return(&ec2.DescribeManagedPrefixListsOutput{
+					PrefixLists: []*ec2.ManagedPrefixList{
+						{PrefixListId: aws.String("pl-1")},
+					},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "describing the prefix lists fails",
+			rules: infrav1.IngressRules{
+				{Protocol: infrav1.SecurityGroupProtocolTCP, FromPort: 22, ToPort: 22, PrefixListIDs: []string{"pl-1"}},
+			},
+			expect: func(m *mocks.MockEC2APIMockRecorder) {
+				m.DescribeManagedPrefixListsWithContext(context.TODO(), gomock.Any()).Return(nil, awserr.New("InvalidPrefixListID.NotFound", "not found", nil))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+
+			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if tc.expect != nil {
+				tc.expect(ec2Mock.EXPECT())
+			}
+
+			s := NewService(cs, testSecurityGroupRoles)
+			s.EC2Client = ec2Mock
+
+			err = s.validateManagedPrefixLists(tc.rules)
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestValidateAPIServerLBSecurityGroupOverride(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+
+	apiServerRule := &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(6443),
+		ToPort:     aws.Int64(6443),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}},
+	}
+
+	testCases := []struct {
+		name         string
+		lb           *infrav1.AWSLoadBalancerSpec
+		ipPermission []*ec2.IpPermission
+		wantErr      bool
+	}{
+		{
+			name:         "api server port is allowed",
+			lb:           &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeClassic},
+			ipPermission: []*ec2.IpPermission{apiServerRule},
+		},
+		{
+			name: "all traffic is allowed",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:    infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{{Port: 8132, Protocol: infrav1.ELBProtocolTCP}},
+			},
+			ipPermission: []*ec2.IpPermission{
+				{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+			},
+		},
+		{
+			name: "port range covers the additional listener",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:    infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{{Port: 8132, Protocol: infrav1.ELBProtocolTCP}},
+			},
+			ipPermission: []*ec2.IpPermission{
+				apiServerRule,
+				{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(8000), ToPort: aws.Int64(9000), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+			},
+		},
+		{
+			name: "additional listener port is not allowed",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				LoadBalancerType:    infrav1.LoadBalancerTypeNLB,
+				AdditionalListeners: []infrav1.AdditionalListenerSpec{{Port: 8132, Protocol: infrav1.ELBProtocolTCP}},
+			},
+			ipPermission: []*ec2.IpPermission{apiServerRule},
+			wantErr:      true,
+		},
+		{
+			name: "api server port is only allowed for udp",
+			lb:   &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeNLB},
+			ipPermission: []*ec2.IpPermission{
+				{IpProtocol: aws.String("udp"), FromPort: aws.Int64(6443), ToPort: aws.Int64(6443), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "load balancer is disabled",
+			lb:   &infrav1.AWSLoadBalancerSpec{LoadBalancerType: infrav1.LoadBalancerTypeDisabled},
+		},
+		{
+			name: "existing load balancer",
+			lb: &infrav1.AWSLoadBalancerSpec{
+				ARN:              aws.String("arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/my-nlb/0123456789abcdef"),
+				LoadBalancerType: infrav1.LoadBalancerTypeNLB,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cs, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+				},
+				AWSCluster: &infrav1.AWSCluster{
+					Spec: infrav1.AWSClusterSpec{
+						ControlPlaneLoadBalancer: tc.lb,
+					},
+				},
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			s := NewService(cs, testSecurityGroupRoles)
+
+			err = s.validateAPIServerLBSecurityGroupOverride(&ec2.SecurityGroup{
+				GroupId:       aws.String("sg-apiserver-lb"),
+				IpPermissions: tc.ipPermission,
+			})
+			if tc.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
 func TestNodePortServicesIngressRules(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = infrav1.AddToScheme(scheme)
@@ -2352,6 +2827,7 @@ func TestNodePortServicesIngressRules(t *testing.T) {
 	testCases := []struct {
 		name                string
 		cidrBlocks          []string
+		allowEFATraffic     bool
 		expectedIngresRules infrav1.IngressRules
 	}{
 		{
@@ -2394,6 +2870,33 @@ func TestNodePortServicesIngressRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "elastic fabric adapter traffic allowed within the node security group",
+			allowEFATraffic: true,
+			expectedIngresRules: infrav1.IngressRules{
+				{
+					Description: "Node Port Services",
+					Protocol:    infrav1.SecurityGroupProtocolTCP,
+					FromPort:    30000,
+					ToPort:      32767,
+					CidrBlocks:  []string{services.AnyIPv4CidrBlock},
+				},
+				{
+					Description:            "Kubelet API",
+					Protocol:               infrav1.SecurityGroupProtocolTCP,
+					FromPort:               10250,
+					ToPort:                 10250,
+					SourceSecurityGroupIDs: []string{"Id1", "Id2"},
+				},
+				{
+					Description:            "Elastic Fabric Adapter",
+					Protocol:               infrav1.SecurityGroupProtocolAll,
+					FromPort:               -1,
+					ToPort:                 -1,
+					SourceSecurityGroupIDs: []string{"Id2"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2411,6 +2914,7 @@ func TestNodePortServicesIngressRules(t *testing.T) {
 								CidrBlock: "10.0.0.0/16",
 							},
 							NodePortIngressRuleCidrBlocks: tc.cidrBlocks,
+							AllowNodeEFATraffic:           tc.allowEFATraffic,
 						},
 					},
 					Status: infrav1.AWSClusterStatus{
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+)
+
+// eksBottlerocketSettings are the settings.kubernetes keys EKS generates for the nodes of a
+// managed nodegroup with a Bottlerocket AMI type to join them to the cluster.
+var eksBottlerocketSettings = []string{"api-server", "cluster-certificate", "cluster-name"}
+
+// BottlerocketUserData prepares the bootstrap data of an EKS managed nodegroup with a Bottlerocket AMI type
+// for its launch template. EKS merges the user data of the launch template with the settings it generates
+// for the nodes, so the bootstrap data must consist of Bottlerocket settings in TOML. Settings generated by
+// EKS are removed from it, so that the values of EKS are used. The bootstrap data is returned unchanged if
+// it doesn't contain any of them.
+func BottlerocketUserData(bootstrapData []byte) ([]byte, error) {
+	settings := map[string]interface{}{}
+	if err := toml.Unmarshal(bootstrapData, &settings); err != nil {
+		return nil, errors.Wrap(err, "bootstrap data of a Bottlerocket nodegroup must be Bottlerocket settings in TOML")
+	}
+
+	root, _ := settings["settings"].(map[string]interface{})
+	kubernetes, _ := root["kubernetes"].(map[string]interface{})
+	var removed bool
+	for _, key := range eksBottlerocketSettings {
+		if _, ok := kubernetes[key]; ok {
+			delete(kubernetes, key)
+			removed = true
+		}
+	}
+	if !removed {
+		return bootstrapData, nil
+	}
+
+	merged, err := toml.Marshal(settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode Bottlerocket settings")
+	}
+	return merged, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBottlerocketUserData(t *testing.T) {
+	tests := []struct {
+		name          string
+		bootstrapData string
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name: "settings without settings of EKS are unchanged",
+			bootstrapData: `[settings.kubernetes]
+max-pods = 110
+
+[settings.kubernetes.node-labels]
+team = "ml"
+`,
+			expected: `[settings.kubernetes]
+max-pods = 110
+
+[settings.kubernetes.node-labels]
+team = "ml"
+`,
+		},
+		{
+			name: "settings of EKS are removed",
+			bootstrapData: `[settings.kubernetes]
+api-server = "https://example.com"
+cluster-name = "other"
+max-pods = 110
+`,
+			expected: `[settings]
+[settings.kubernetes]
+max-pods = 110
+`,
+		},
+		{
+			name: "cloud-init bootstrap data is rejected",
+			bootstrapData: `#cloud-config
+runcmd:
+  - /etc/eks/bootstrap.sh cluster
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			userData, err := BottlerocketUserData([]byte(tc.bootstrapData))
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(userData)).To(Equal(tc.expected))
+		})
+	}
+}
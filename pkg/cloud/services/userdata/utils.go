@@ -26,6 +26,8 @@ import (
 	"text/template"
 
 	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/mime"
 )
 
 var defaultTemplateFuncMap = template.FuncMap{
@@ -58,6 +60,16 @@ func GzipBytes(dat []byte) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// WithHostname adds a part setting the instance hostname to a multipart MIME cloud-init user data document.
+func WithHostname(dat []byte, hostname string) ([]byte, error) {
+	out, err := mime.AddHostname(dat, hostname)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to add hostname to user data")
+	}
+
+	return out, nil
+}
+
 // ComputeHash returns the SHA256 hash of the user data byte array.
 func ComputeHash(dat []byte) string {
 	return fmt.Sprintf("%x", sha256.Sum256(dat))
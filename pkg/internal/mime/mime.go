@@ -21,9 +21,14 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"io"
+	"mime"
 	"mime/multipart"
+	"net/mail"
 	"net/textproto"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -39,6 +44,10 @@ var (
 		"content-type": {"text/cloud-boothook"},
 	}
 
+	cloudConfigType = textproto.MIMEHeader{
+		"content-type": {"text/cloud-config"},
+	}
+
 	multipartHeader = strings.Join([]string{
 		"MIME-Version: 1.0",
 		"Content-Type: multipart/mixed; boundary=\"%s\"",
@@ -99,3 +108,56 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 
 	return buf.Bytes(), nil
 }
+
+// AddHostname prepends a cloud-config part to a multipart MIME UserData document
+// which sets the hostname of the instance before the bootstrap data is processed.
+func AddHostname(document []byte, hostname string) ([]byte, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(document))
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to parse MIME document")
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "failed to parse MIME document content type")
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return []byte{}, errors.Errorf("expected a multipart MIME document but got %q", mediaType)
+	}
+
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+
+	hostnameWriter, err := mpWriter.CreatePart(cloudConfigType)
+	if err != nil {
+		return []byte{}, err
+	}
+	if _, err := fmt.Fprintf(hostnameWriter, "#cloud-config\nhostname: %s\npreserve_hostname: false\n", hostname); err != nil {
+		return []byte{}, err
+	}
+
+	mpReader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mpReader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return []byte{}, errors.Wrap(err, "failed to read MIME document part")
+		}
+
+		partWriter, err := mpWriter.CreatePart(part.Header)
+		if err != nil {
+			return []byte{}, err
+		}
+		if _, err := io.Copy(partWriter, part); err != nil {
+			return []byte{}, err
+		}
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -18,7 +18,12 @@ package mime
 
 import (
 	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/mail"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +35,54 @@ func TestGenerateInitDocument(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestAddHostname(t *testing.T) {
+	doc, err := GenerateInitDocument("secretARN", 1, "eu-west-1", "localhost", "abc123")
+	if err != nil {
+		t.Fatalf("Cannot generate MIME doc: %+v", err)
+	}
+
+	doc, err = AddHostname(doc, "machine-0-aws")
+	if err != nil {
+		t.Fatalf("Cannot add hostname to MIME doc: %+v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewBuffer(doc))
+	if err != nil {
+		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("Cannot parse MIME doc content type: %+v", err)
+	}
+
+	var contentTypes []string
+	mpReader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mpReader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Cannot read MIME doc part: %+v", err)
+		}
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		if len(contentTypes) == 1 {
+			content, _ := io.ReadAll(part)
+			if !strings.Contains(string(content), "hostname: machine-0-aws\n") {
+				t.Fatalf("Expected hostname part to set hostname, got:\n%s", string(content))
+			}
+		}
+	}
+
+	expected := []string{"text/cloud-config", "text/cloud-boothook", "text/x-include-url"}
+	if strings.Join(contentTypes, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected parts %v, got %v", expected, contentTypes)
+	}
+}
+
+func TestAddHostnameRejectsNonMultipart(t *testing.T) {
+	if _, err := AddHostname([]byte("#cloud-config\n"), "machine-0"); err == nil {
+		t.Fatal("Expected an error for a non-multipart document")
+	}
+}